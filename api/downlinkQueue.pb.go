@@ -21,7 +21,8 @@ var _ = math.Inf
 type EnqueueDownlinkQueueItemRequest struct {
 	// Hex encoded DevEUI of the node.
 	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
-	// Random reference (used on ack notification).
+	// Random reference (used on ack notification). Payloads with the same
+	// reference for the same node are ignored, so that enqueues can be retried.
 	Reference string `protobuf:"bytes,2,opt,name=reference" json:"reference,omitempty"`
 	// Is an ACK required from the node.
 	Confirmed bool `protobuf:"varint,3,opt,name=confirmed" json:"confirmed,omitempty"`
@@ -72,6 +73,8 @@ func (m *EnqueueDownlinkQueueItemRequest) GetData() []byte {
 }
 
 type EnqueueDownlinkQueueItemResponse struct {
+	// Advisory metadata about the transmission of the enqueued item.
+	Advisory *DownlinkAdvisory `protobuf:"bytes,1,opt,name=advisory" json:"advisory,omitempty"`
 }
 
 func (m *EnqueueDownlinkQueueItemResponse) Reset()         { *m = EnqueueDownlinkQueueItemResponse{} }
@@ -81,6 +84,70 @@ func (*EnqueueDownlinkQueueItemResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor2, []int{1}
 }
 
+func (m *EnqueueDownlinkQueueItemResponse) GetAdvisory() *DownlinkAdvisory {
+	if m != nil {
+		return m.Advisory
+	}
+	return nil
+}
+
+type DownlinkAdvisory struct {
+	// Estimated airtime (ms) of the transmission, based on the data-rate of
+	// the last uplink of the node.
+	EstimatedAirtime uint32 `protobuf:"varint,1,opt,name=estimatedAirtime" json:"estimatedAirtime,omitempty"`
+	// Position of the item in the queue (1 = next to be transmitted), 0 when
+	// it was pushed directly to the network-server (class-C).
+	QueuePosition uint32 `protobuf:"varint,2,opt,name=queuePosition" json:"queuePosition,omitempty"`
+	// The item is transmitted in the receive window following an uplink
+	// (class-A). One item is transmitted per uplink.
+	WaitForUplink bool `protobuf:"varint,3,opt,name=waitForUplink" json:"waitForUplink,omitempty"`
+	// Earliest time of transmission (RFC3339), taking the airtime and the
+	// duty-cycle off-period of the items ahead in the queue into account.
+	TransmitAfter string `protobuf:"bytes,4,opt,name=transmitAfter" json:"transmitAfter,omitempty"`
+	// Max. duty-cycle (%) of the band, 0 when unrestricted.
+	DutyCycle float64 `protobuf:"fixed64,5,opt,name=dutyCycle" json:"dutyCycle,omitempty"`
+}
+
+func (m *DownlinkAdvisory) Reset()                    { *m = DownlinkAdvisory{} }
+func (m *DownlinkAdvisory) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkAdvisory) ProtoMessage()               {}
+func (*DownlinkAdvisory) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+
+func (m *DownlinkAdvisory) GetEstimatedAirtime() uint32 {
+	if m != nil {
+		return m.EstimatedAirtime
+	}
+	return 0
+}
+
+func (m *DownlinkAdvisory) GetQueuePosition() uint32 {
+	if m != nil {
+		return m.QueuePosition
+	}
+	return 0
+}
+
+func (m *DownlinkAdvisory) GetWaitForUplink() bool {
+	if m != nil {
+		return m.WaitForUplink
+	}
+	return false
+}
+
+func (m *DownlinkAdvisory) GetTransmitAfter() string {
+	if m != nil {
+		return m.TransmitAfter
+	}
+	return ""
+}
+
+func (m *DownlinkAdvisory) GetDutyCycle() float64 {
+	if m != nil {
+		return m.DutyCycle
+	}
+	return 0
+}
+
 type DeleteDownlinkQeueueItemRequest struct {
 	// Hex encoded DevEUI of the node.
 	DevEUI string `protobuf:"bytes,2,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -91,7 +158,7 @@ type DeleteDownlinkQeueueItemRequest struct {
 func (m *DeleteDownlinkQeueueItemRequest) Reset()                    { *m = DeleteDownlinkQeueueItemRequest{} }
 func (m *DeleteDownlinkQeueueItemRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteDownlinkQeueueItemRequest) ProtoMessage()               {}
-func (*DeleteDownlinkQeueueItemRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+func (*DeleteDownlinkQeueueItemRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
 
 func (m *DeleteDownlinkQeueueItemRequest) GetDevEUI() string {
 	if m != nil {
@@ -113,7 +180,7 @@ type DeleteDownlinkQueueItemResponse struct {
 func (m *DeleteDownlinkQueueItemResponse) Reset()                    { *m = DeleteDownlinkQueueItemResponse{} }
 func (m *DeleteDownlinkQueueItemResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteDownlinkQueueItemResponse) ProtoMessage()               {}
-func (*DeleteDownlinkQueueItemResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
+func (*DeleteDownlinkQueueItemResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
 
 type DownlinkQueueItem struct {
 	// ID of the queue item.
@@ -135,7 +202,7 @@ type DownlinkQueueItem struct {
 func (m *DownlinkQueueItem) Reset()                    { *m = DownlinkQueueItem{} }
 func (m *DownlinkQueueItem) String() string            { return proto.CompactTextString(m) }
 func (*DownlinkQueueItem) ProtoMessage()               {}
-func (*DownlinkQueueItem) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
+func (*DownlinkQueueItem) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
 
 func (m *DownlinkQueueItem) GetId() int64 {
 	if m != nil {
@@ -194,7 +261,7 @@ type ListDownlinkQueueItemsRequest struct {
 func (m *ListDownlinkQueueItemsRequest) Reset()                    { *m = ListDownlinkQueueItemsRequest{} }
 func (m *ListDownlinkQueueItemsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListDownlinkQueueItemsRequest) ProtoMessage()               {}
-func (*ListDownlinkQueueItemsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+func (*ListDownlinkQueueItemsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
 
 func (m *ListDownlinkQueueItemsRequest) GetDevEUI() string {
 	if m != nil {
@@ -210,7 +277,7 @@ type ListDownlinkQueueItemsResponse struct {
 func (m *ListDownlinkQueueItemsResponse) Reset()                    { *m = ListDownlinkQueueItemsResponse{} }
 func (m *ListDownlinkQueueItemsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListDownlinkQueueItemsResponse) ProtoMessage()               {}
-func (*ListDownlinkQueueItemsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+func (*ListDownlinkQueueItemsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{7} }
 
 func (m *ListDownlinkQueueItemsResponse) GetItems() []*DownlinkQueueItem {
 	if m != nil {
@@ -219,14 +286,118 @@ func (m *ListDownlinkQueueItemsResponse) GetItems() []*DownlinkQueueItem {
 	return nil
 }
 
+type EnqueueDownlinkTemplateRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Name of the downlink template.
+	Template string `protobuf:"bytes,2,opt,name=template" json:"template,omitempty"`
+	// Parameters of the template as a JSON object string, e.g.
+	// {"interval": 300}.
+	ParametersJSON string `protobuf:"bytes,3,opt,name=parametersJSON" json:"parametersJSON,omitempty"`
+	// Random reference (used on ack notification). Payloads with the same
+	// reference for the same node are ignored, so that enqueues can be retried.
+	Reference string `protobuf:"bytes,4,opt,name=reference" json:"reference,omitempty"`
+	// Only validate the parameters and return the encoded payload, without
+	// enqueueing it.
+	DryRun bool `protobuf:"varint,5,opt,name=dryRun" json:"dryRun,omitempty"`
+}
+
+func (m *EnqueueDownlinkTemplateRequest) Reset()                    { *m = EnqueueDownlinkTemplateRequest{} }
+func (m *EnqueueDownlinkTemplateRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnqueueDownlinkTemplateRequest) ProtoMessage()               {}
+func (*EnqueueDownlinkTemplateRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{8} }
+
+func (m *EnqueueDownlinkTemplateRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *EnqueueDownlinkTemplateRequest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *EnqueueDownlinkTemplateRequest) GetParametersJSON() string {
+	if m != nil {
+		return m.ParametersJSON
+	}
+	return ""
+}
+
+func (m *EnqueueDownlinkTemplateRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *EnqueueDownlinkTemplateRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type EnqueueDownlinkTemplateResponse struct {
+	// FPort of the payload.
+	FPort uint32 `protobuf:"varint,1,opt,name=fPort" json:"fPort,omitempty"`
+	// Is an ACK required from the node.
+	Confirmed bool `protobuf:"varint,2,opt,name=confirmed" json:"confirmed,omitempty"`
+	// Base64 encoded payload.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Advisory metadata about the transmission of the enqueued item (not set
+	// for a dry run).
+	Advisory *DownlinkAdvisory `protobuf:"bytes,4,opt,name=advisory" json:"advisory,omitempty"`
+}
+
+func (m *EnqueueDownlinkTemplateResponse) Reset()                    { *m = EnqueueDownlinkTemplateResponse{} }
+func (m *EnqueueDownlinkTemplateResponse) String() string            { return proto.CompactTextString(m) }
+func (*EnqueueDownlinkTemplateResponse) ProtoMessage()               {}
+func (*EnqueueDownlinkTemplateResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{9} }
+
+func (m *EnqueueDownlinkTemplateResponse) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *EnqueueDownlinkTemplateResponse) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *EnqueueDownlinkTemplateResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EnqueueDownlinkTemplateResponse) GetAdvisory() *DownlinkAdvisory {
+	if m != nil {
+		return m.Advisory
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*EnqueueDownlinkQueueItemRequest)(nil), "api.EnqueueDownlinkQueueItemRequest")
 	proto.RegisterType((*EnqueueDownlinkQueueItemResponse)(nil), "api.EnqueueDownlinkQueueItemResponse")
+	proto.RegisterType((*DownlinkAdvisory)(nil), "api.DownlinkAdvisory")
 	proto.RegisterType((*DeleteDownlinkQeueueItemRequest)(nil), "api.DeleteDownlinkQeueueItemRequest")
 	proto.RegisterType((*DeleteDownlinkQueueItemResponse)(nil), "api.DeleteDownlinkQueueItemResponse")
 	proto.RegisterType((*DownlinkQueueItem)(nil), "api.DownlinkQueueItem")
 	proto.RegisterType((*ListDownlinkQueueItemsRequest)(nil), "api.ListDownlinkQueueItemsRequest")
 	proto.RegisterType((*ListDownlinkQueueItemsResponse)(nil), "api.ListDownlinkQueueItemsResponse")
+	proto.RegisterType((*EnqueueDownlinkTemplateRequest)(nil), "api.EnqueueDownlinkTemplateRequest")
+	proto.RegisterType((*EnqueueDownlinkTemplateResponse)(nil), "api.EnqueueDownlinkTemplateResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -247,6 +418,10 @@ type DownlinkQueueClient interface {
 	Delete(ctx context.Context, in *DeleteDownlinkQeueueItemRequest, opts ...grpc.CallOption) (*DeleteDownlinkQueueItemResponse, error)
 	// List lists the items in the queue for the given node.
 	List(ctx context.Context, in *ListDownlinkQueueItemsRequest, opts ...grpc.CallOption) (*ListDownlinkQueueItemsResponse, error)
+	// EnqueueTemplate validates the given parameters and adds the payload
+	// encoded by the given downlink template of the application of the node
+	// to the queue.
+	EnqueueTemplate(ctx context.Context, in *EnqueueDownlinkTemplateRequest, opts ...grpc.CallOption) (*EnqueueDownlinkTemplateResponse, error)
 }
 
 type downlinkQueueClient struct {
@@ -284,6 +459,15 @@ func (c *downlinkQueueClient) List(ctx context.Context, in *ListDownlinkQueueIte
 	return out, nil
 }
 
+func (c *downlinkQueueClient) EnqueueTemplate(ctx context.Context, in *EnqueueDownlinkTemplateRequest, opts ...grpc.CallOption) (*EnqueueDownlinkTemplateResponse, error) {
+	out := new(EnqueueDownlinkTemplateResponse)
+	err := grpc.Invoke(ctx, "/api.DownlinkQueue/EnqueueTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for DownlinkQueue service
 
 type DownlinkQueueServer interface {
@@ -294,6 +478,10 @@ type DownlinkQueueServer interface {
 	Delete(context.Context, *DeleteDownlinkQeueueItemRequest) (*DeleteDownlinkQueueItemResponse, error)
 	// List lists the items in the queue for the given node.
 	List(context.Context, *ListDownlinkQueueItemsRequest) (*ListDownlinkQueueItemsResponse, error)
+	// EnqueueTemplate validates the given parameters and adds the payload
+	// encoded by the given downlink template of the application of the node
+	// to the queue.
+	EnqueueTemplate(context.Context, *EnqueueDownlinkTemplateRequest) (*EnqueueDownlinkTemplateResponse, error)
 }
 
 func RegisterDownlinkQueueServer(s *grpc.Server, srv DownlinkQueueServer) {
@@ -354,6 +542,24 @@ func _DownlinkQueue_List_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DownlinkQueue_EnqueueTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDownlinkTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownlinkQueueServer).EnqueueTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.DownlinkQueue/EnqueueTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownlinkQueueServer).EnqueueTemplate(ctx, req.(*EnqueueDownlinkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DownlinkQueue_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.DownlinkQueue",
 	HandlerType: (*DownlinkQueueServer)(nil),
@@ -370,6 +576,10 @@ var _DownlinkQueue_serviceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _DownlinkQueue_List_Handler,
 		},
+		{
+			MethodName: "EnqueueTemplate",
+			Handler:    _DownlinkQueue_EnqueueTemplate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "downlinkQueue.proto",
@@ -378,33 +588,48 @@ var _DownlinkQueue_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("downlinkQueue.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 437 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x41, 0x6e, 0xd3, 0x40,
-	0x14, 0xd5, 0xd8, 0x8e, 0xd3, 0x7e, 0x28, 0x12, 0x03, 0xaa, 0x8c, 0x29, 0xa9, 0x3b, 0x14, 0x64,
-	0x55, 0x28, 0x96, 0xca, 0x02, 0x89, 0x75, 0xbb, 0x88, 0x84, 0x10, 0x58, 0xe2, 0x00, 0xa6, 0xf3,
-	0x13, 0x8d, 0x48, 0x66, 0x1c, 0x7b, 0x02, 0x8b, 0x24, 0x1b, 0xae, 0xc0, 0x01, 0x38, 0x08, 0xc7,
-	0xe0, 0x0a, 0xdc, 0x03, 0xe4, 0xb1, 0x91, 0x93, 0x38, 0x8e, 0x77, 0x9e, 0xff, 0xdf, 0xff, 0xcf,
-	0xef, 0xbd, 0xb1, 0xe1, 0x11, 0x57, 0xdf, 0xe4, 0x54, 0xc8, 0x2f, 0x1f, 0x17, 0xb8, 0xc0, 0x61,
-	0x9a, 0x29, 0xad, 0xa8, 0x9d, 0xa4, 0xc2, 0x3f, 0x9b, 0x28, 0x35, 0x99, 0x62, 0x94, 0xa4, 0x22,
-	0x4a, 0xa4, 0x54, 0x3a, 0xd1, 0x42, 0xc9, 0xbc, 0x84, 0xb0, 0x9f, 0x04, 0xce, 0x6f, 0xe5, 0xbc,
-	0x18, 0xba, 0xd9, 0xdc, 0x30, 0xd2, 0x38, 0x8b, 0x71, 0xbe, 0xc0, 0x5c, 0xd3, 0x53, 0x70, 0x39,
-	0x7e, 0xbd, 0xfd, 0x34, 0xf2, 0x48, 0x40, 0xc2, 0xe3, 0xb8, 0x3a, 0xd1, 0x33, 0x38, 0xce, 0x70,
-	0x8c, 0x19, 0xca, 0x3b, 0xf4, 0x2c, 0xd3, 0xaa, 0x0b, 0x45, 0xf7, 0x4e, 0xc9, 0xb1, 0xc8, 0x66,
-	0xc8, 0x3d, 0x3b, 0x20, 0xe1, 0x51, 0x5c, 0x17, 0xe8, 0x63, 0xe8, 0x8d, 0x3f, 0xa8, 0x4c, 0x7b,
-	0x4e, 0x40, 0xc2, 0x93, 0xb8, 0x3c, 0x50, 0x0a, 0x0e, 0x4f, 0x74, 0xe2, 0xf5, 0x02, 0x12, 0xde,
-	0x8f, 0xcd, 0x33, 0x63, 0x10, 0xb4, 0xbf, 0x60, 0x9e, 0x2a, 0x99, 0x23, 0x1b, 0xc1, 0xf9, 0x0d,
-	0x4e, 0x51, 0xd7, 0x10, 0x6c, 0x17, 0x61, 0x6d, 0x89, 0x78, 0x00, 0x96, 0xe0, 0x46, 0x98, 0x1d,
-	0x5b, 0x82, 0xb3, 0x8b, 0xc6, 0xaa, 0x06, 0xdb, 0x2f, 0x02, 0x0f, 0x1b, 0xdd, 0xdd, 0x45, 0xad,
-	0x84, 0x5b, 0xae, 0xd9, 0x07, 0x5d, 0x73, 0x76, 0x5d, 0xf3, 0xa0, 0x9f, 0xa2, 0xe4, 0x42, 0x4e,
-	0x8c, 0x45, 0x47, 0xf1, 0xff, 0x63, 0xed, 0xa7, 0xbb, 0xcf, 0xcf, 0xfe, 0x86, 0x9f, 0x6f, 0xe0,
-	0xd9, 0x3b, 0x91, 0xeb, 0x86, 0x80, 0xbc, 0x23, 0x6e, 0xf6, 0x1e, 0x06, 0x6d, 0x83, 0xa5, 0x31,
-	0xf4, 0x15, 0xf4, 0x44, 0x51, 0xf0, 0x48, 0x60, 0x87, 0xf7, 0xae, 0x4f, 0x87, 0x49, 0x2a, 0x86,
-	0x4d, 0x1f, 0x4b, 0xd0, 0xf5, 0x5f, 0x0b, 0x4e, 0xb6, 0x9a, 0x74, 0x05, 0xfd, 0x2a, 0x6a, 0x7a,
-	0x69, 0x66, 0x3b, 0x6e, 0xa6, 0xff, 0xa2, 0x03, 0x55, 0x05, 0x76, 0xf9, 0xfd, 0xf7, 0x9f, 0x1f,
-	0xd6, 0x80, 0x3d, 0x31, 0x1f, 0x81, 0x54, 0x1c, 0xf3, 0x68, 0x59, 0xaa, 0x5a, 0x47, 0x66, 0xf6,
-	0x2d, 0xb9, 0xa2, 0x2b, 0x70, 0xcb, 0xe4, 0x2b, 0xf2, 0x8e, 0x1b, 0xe5, 0xef, 0x45, 0x35, 0xb8,
-	0x5f, 0x1a, 0xee, 0xe0, 0x6a, 0xd0, 0xca, 0x1d, 0x2d, 0x05, 0x5f, 0xd3, 0x0c, 0x9c, 0xc2, 0x5d,
-	0xca, 0xcc, 0xd6, 0x83, 0x09, 0xf9, 0xcf, 0x0f, 0x62, 0x2a, 0xe2, 0x0b, 0x43, 0xfc, 0x94, 0xb6,
-	0x8b, 0xfe, 0xec, 0x9a, 0x7f, 0xc0, 0xeb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x16, 0xa7, 0x90,
-	0xc0, 0x3d, 0x04, 0x00, 0x00,
+	// 681 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4f, 0x4f, 0x13, 0x4f,
+	0x18, 0xce, 0xb4, 0x4b, 0x29, 0xc3, 0xaf, 0xfc, 0x70, 0x54, 0xb2, 0x56, 0x2c, 0xcb, 0x52, 0xb1,
+	0x69, 0x94, 0x46, 0x3c, 0x98, 0x70, 0x23, 0x82, 0x09, 0xc6, 0x20, 0x8e, 0xf2, 0x01, 0x46, 0xf6,
+	0x2d, 0x99, 0xd8, 0x9d, 0x59, 0x66, 0xa7, 0x90, 0x06, 0xb8, 0x78, 0x35, 0x9e, 0xbc, 0x9a, 0xf8,
+	0x1d, 0xbc, 0xfa, 0x1d, 0xbc, 0xf8, 0x15, 0xfc, 0x20, 0x66, 0xa7, 0x53, 0x96, 0xdd, 0xb2, 0xad,
+	0xb7, 0xce, 0x3b, 0xcf, 0xfb, 0x67, 0x9e, 0xe7, 0x79, 0xb7, 0xf8, 0x76, 0x20, 0xcf, 0x44, 0x8f,
+	0x8b, 0x8f, 0x6f, 0xfb, 0xd0, 0x87, 0x8d, 0x48, 0x49, 0x2d, 0x49, 0x99, 0x45, 0xbc, 0xbe, 0x7c,
+	0x2c, 0xe5, 0x71, 0x0f, 0x3a, 0x2c, 0xe2, 0x1d, 0x26, 0x84, 0xd4, 0x4c, 0x73, 0x29, 0xe2, 0x21,
+	0xc4, 0xff, 0x8e, 0xf0, 0xca, 0xae, 0x38, 0x49, 0x92, 0x76, 0xae, 0x57, 0xd8, 0xd3, 0x10, 0x52,
+	0x38, 0xe9, 0x43, 0xac, 0xc9, 0x12, 0xae, 0x04, 0x70, 0xba, 0x7b, 0xb8, 0xe7, 0x22, 0x0f, 0xb5,
+	0xe6, 0xa8, 0x3d, 0x91, 0x65, 0x3c, 0xa7, 0xa0, 0x0b, 0x0a, 0xc4, 0x11, 0xb8, 0x25, 0x73, 0x95,
+	0x06, 0x92, 0xdb, 0x23, 0x29, 0xba, 0x5c, 0x85, 0x10, 0xb8, 0x65, 0x0f, 0xb5, 0xaa, 0x34, 0x0d,
+	0x90, 0x3b, 0x78, 0xa6, 0x7b, 0x20, 0x95, 0x76, 0x1d, 0x0f, 0xb5, 0x6a, 0x74, 0x78, 0x20, 0x04,
+	0x3b, 0x01, 0xd3, 0xcc, 0x9d, 0xf1, 0x50, 0xeb, 0x3f, 0x6a, 0x7e, 0xfb, 0x87, 0xd8, 0x2b, 0x1e,
+	0x30, 0x8e, 0xa4, 0x88, 0x81, 0x3c, 0xc5, 0x55, 0x16, 0x9c, 0xf2, 0x58, 0xaa, 0x81, 0x99, 0x71,
+	0x7e, 0xf3, 0xee, 0x06, 0x8b, 0xf8, 0xc6, 0x28, 0x63, 0xdb, 0x5e, 0xd2, 0x2b, 0x98, 0xff, 0x0b,
+	0xe1, 0xc5, 0xfc, 0x35, 0x69, 0xe3, 0x45, 0x88, 0x35, 0x0f, 0x99, 0x86, 0x60, 0x9b, 0x2b, 0xcd,
+	0x43, 0x30, 0xf5, 0x6a, 0x74, 0x2c, 0x4e, 0x9a, 0xb8, 0x66, 0xa6, 0x3a, 0x90, 0x31, 0x4f, 0x18,
+	0x35, 0x0c, 0xd4, 0x68, 0x36, 0x98, 0xa0, 0xce, 0x18, 0xd7, 0x2f, 0xa5, 0x3a, 0x8c, 0x92, 0x56,
+	0x96, 0x89, 0x6c, 0x30, 0x41, 0x69, 0xc5, 0x44, 0x1c, 0x72, 0xbd, 0xdd, 0xd5, 0xa0, 0x0c, 0x2b,
+	0x73, 0x34, 0x1b, 0x4c, 0x18, 0x0d, 0xfa, 0x7a, 0xf0, 0x62, 0x70, 0xd4, 0x03, 0x43, 0x11, 0xa2,
+	0x69, 0xc0, 0xdf, 0xc3, 0x2b, 0x3b, 0xd0, 0x03, 0x9d, 0xd2, 0x04, 0xc5, 0x42, 0x96, 0x32, 0x42,
+	0x2e, 0xe0, 0x12, 0x0f, 0xcc, 0x43, 0xcb, 0xb4, 0xc4, 0x03, 0x7f, 0x75, 0xac, 0x54, 0x9e, 0x71,
+	0xff, 0x27, 0xc2, 0xb7, 0xc6, 0x6e, 0xf3, 0x85, 0x0a, 0x1b, 0x66, 0x9c, 0x53, 0x9e, 0xe8, 0x1c,
+	0x27, 0xef, 0x1c, 0x17, 0xcf, 0x46, 0x20, 0x02, 0x2e, 0x8e, 0x0d, 0x07, 0x55, 0x3a, 0x3a, 0xa6,
+	0x9e, 0xaa, 0xdc, 0xe4, 0xa9, 0xd9, 0x6b, 0x9e, 0x7a, 0x8e, 0x1f, 0xbc, 0xe6, 0xb1, 0x1e, 0x7b,
+	0x40, 0x3c, 0xc5, 0xf2, 0xfe, 0x3e, 0x6e, 0x14, 0x25, 0x5a, 0x2b, 0x3e, 0xc6, 0x33, 0x3c, 0x09,
+	0xb8, 0xc8, 0x2b, 0xb7, 0xe6, 0x37, 0x97, 0x32, 0x3e, 0x4c, 0x79, 0x1c, 0x82, 0xfc, 0x1f, 0x08,
+	0x37, 0x72, 0xee, 0x7e, 0x0f, 0x61, 0xd4, 0x63, 0x1a, 0xa6, 0x6d, 0x5f, 0x1d, 0x57, 0xb5, 0x85,
+	0x5a, 0x76, 0xaf, 0xce, 0x64, 0x1d, 0x2f, 0x44, 0x4c, 0xb1, 0x10, 0x34, 0xa8, 0xf8, 0xd5, 0xbb,
+	0x37, 0xfb, 0x96, 0xe4, 0x5c, 0x34, 0xab, 0x83, 0x93, 0xd7, 0x21, 0xe9, 0xac, 0x06, 0xb4, 0x2f,
+	0x2c, 0xd1, 0xf6, 0xe4, 0x7f, 0x1b, 0xff, 0x66, 0xa4, 0x43, 0x5b, 0x1a, 0xae, 0xb4, 0x40, 0xd7,
+	0xb5, 0xc8, 0x28, 0x5b, 0xca, 0x2b, 0x3b, 0x52, 0xaa, 0x9c, 0x2a, 0x95, 0xd9, 0x6c, 0xe7, 0x9f,
+	0x36, 0x7b, 0xf3, 0x8b, 0x83, 0x6b, 0x19, 0xc2, 0xc9, 0x05, 0x9e, 0xb5, 0xf3, 0x92, 0xa6, 0xc9,
+	0x9e, 0xf2, 0xc5, 0xab, 0x3f, 0x9c, 0x82, 0xb2, 0x4b, 0xd0, 0xfc, 0xf4, 0xfb, 0xcf, 0xd7, 0x52,
+	0xc3, 0xbf, 0x67, 0x3e, 0xae, 0x42, 0x06, 0x10, 0x77, 0xce, 0x87, 0xf2, 0x5c, 0x76, 0x4c, 0xee,
+	0x16, 0x6a, 0x93, 0x0b, 0x5c, 0x19, 0x6e, 0x93, 0x6d, 0x3e, 0x65, 0x4b, 0xeb, 0x37, 0xa2, 0xc6,
+	0x7a, 0xaf, 0x9b, 0xde, 0x5e, 0xbb, 0x51, 0xd8, 0xbb, 0x73, 0xce, 0x83, 0x4b, 0xa2, 0xb0, 0x93,
+	0x38, 0x96, 0xf8, 0xa6, 0xea, 0x44, 0xd7, 0xd7, 0xd7, 0x26, 0x62, 0x6c, 0xe3, 0x55, 0xd3, 0xf8,
+	0x3e, 0x29, 0x7e, 0x34, 0xf9, 0x8c, 0xf0, 0xff, 0x96, 0xbc, 0x91, 0x31, 0xc8, 0xda, 0x4d, 0x94,
+	0xe6, 0xbc, 0x5e, 0x6f, 0x4e, 0x06, 0xd9, 0x09, 0x9e, 0x98, 0x09, 0x1e, 0xf9, 0x7e, 0xf1, 0xd3,
+	0x47, 0x9b, 0xb0, 0x85, 0xda, 0x1f, 0x2a, 0xe6, 0x9f, 0xee, 0xd9, 0xdf, 0x00, 0x00, 0x00, 0xff,
+	0xff, 0x8e, 0x01, 0x76, 0x80, 0x23, 0x07, 0x00, 0x00,
 }
@@ -16,10 +16,10 @@ done
 IFS=$OIFS
 
 # generate the gRPC code
-protoc -I/usr/local/include -I. ${GOPATHLIST} --go_out=plugins=grpc:. node.proto application.proto downlinkQueue.proto common.proto user.proto gateway.proto organization.proto
+protoc -I/usr/local/include -I. ${GOPATHLIST} --go_out=plugins=grpc:. node.proto application.proto downlinkQueue.proto common.proto user.proto gateway.proto organization.proto integration.proto
 # generate the JSON interface code
-protoc -I/usr/local/include -I. ${GOPATHLIST} --grpc-gateway_out=logtostderr=true:. node.proto application.proto downlinkQueue.proto common.proto user.proto gateway.proto organization.proto
+protoc -I/usr/local/include -I. ${GOPATHLIST} --grpc-gateway_out=logtostderr=true:. node.proto application.proto downlinkQueue.proto common.proto user.proto gateway.proto organization.proto integration.proto
 # generate the swagger definitions
-protoc -I/usr/local/include -I. ${GOPATHLIST} --swagger_out=logtostderr=true:./swagger node.proto application.proto downlinkQueue.proto common.proto user.proto gateway.proto organization.proto
+protoc -I/usr/local/include -I. ${GOPATHLIST} --swagger_out=logtostderr=true:./swagger node.proto application.proto downlinkQueue.proto common.proto user.proto gateway.proto organization.proto integration.proto
 # merge the swagger code into one file
 go run swagger/main.go swagger > ../static/swagger/api.swagger.json
@@ -12,18 +12,27 @@ It is generated from these files:
 	user.proto
 	gateway.proto
 	organization.proto
+	integration.proto
 
 It has these top-level messages:
 	CreateNodeRequest
 	CreateNodeResponse
+	NodeVariable
 	GetNodeRequest
 	GetNodeResponse
 	DeleteNodeRequest
 	DeleteNodeResponse
+	DecommissionNodeRequest
+	DecommissionNodeResponse
 	ListNodeByApplicationIDRequest
 	ListNodeResponse
 	UpdateNodeRequest
 	UpdateNodeResponse
+	CreateNodeBatchRequest
+	CreateNodeBatchResponse
+	UpdateNodeBatchRequest
+	UpdateNodeBatchResponse
+	NodeBatchResult
 	ActivateNodeRequest
 	ActivateNodeResponse
 	GetNodeActivationRequest
@@ -32,10 +41,58 @@ It has these top-level messages:
 	GetRandomDevAddrResponse
 	GetFrameLogsRequest
 	GetFrameLogsResponse
+	GetNextNodeEventRequest
+	GetNextNodeEventResponse
 	FrameLog
 	DataRate
 	RXInfo
 	TXInfo
+	GetNodeDownlinkAirtimeRequest
+	GetNodeDownlinkAirtimeResponse
+	LinkQuality
+	LinkQualityBucket
+	GetNodeLinkQualityRequest
+	GetNodeLinkQualityResponse
+	ListNodeLinkQualityRequest
+	NodeLinkQuality
+	ListNodeLinkQualityResponse
+	Availability
+	GetNodeAvailabilityRequest
+	GetNodeAvailabilityResponse
+	ListNodeAvailabilityRequest
+	NodeAvailability
+	ListNodeAvailabilityResponse
+	NodeLastValue
+	GetNodeLastValuesRequest
+	GetNodeLastValuesResponse
+	ListNodeLastValuesRequest
+	NodeLastValues
+	ListNodeLastValuesResponse
+	BulkNodeTagsRequest
+	BulkNodeTagsResponse
+	GetNodeEffectiveConfigRequest
+	NodeNetworkSettings
+	NodeIntegrationRoute
+	GetNodeEffectiveConfigResponse
+	GetNodeMaintenanceRequest
+	NodeMaintenance
+	UpdateNodeMaintenanceResponse
+	GetNodeAliasRequest
+	NodeAlias
+	UpdateNodeAliasResponse
+	GetNodeByAliasRequest
+	LookupNodeRequest
+	LookupNodeResponse
+	NodeIntegrationRequest
+	NodeHTTPIntegration
+	CreateNodeHTTPIntegrationResponse
+	UpdateNodeHTTPIntegrationResponse
+	DeleteNodeHTTPIntegrationResponse
+	ListNodeIntegrationsResponse
+	NodeIntegrationChaos
+	UpdateNodeIntegrationChaosResponse
+	NodeIntegrationFilter
+	UpdateNodeIntegrationFilterResponse
 	CreateApplicationRequest
 	CreateApplicationResponse
 	GetApplicationRequest
@@ -54,24 +111,91 @@ It has these top-level messages:
 	UpdateApplicationUserRequest
 	EmptyApplicationUserResponse
 	EmptyResponse
+	GatewayFilter
+	GetGatewayFilterRequest
+	DeleteGatewayFilterRequest
+	SendProprietaryPayloadRequest
 	HTTPIntegrationHeader
 	HTTPIntegration
+	SyslogIntegration
+	GetSyslogIntegrationRequest
+	AMQPIntegration
+	GetAMQPIntegrationRequest
+	PostgreSQLIntegration
+	GetPostgreSQLIntegrationRequest
+	AWSSNSIntegration
+	GetAWSSNSIntegrationRequest
+	AzureIntegration
+	GetAzureIntegrationRequest
+	GCPPubSubIntegration
+	GetGCPPubSubIntegrationRequest
+	ThingsBoardIntegration
+	GetThingsBoardIntegrationRequest
+	MyDevicesIntegration
+	GetMyDevicesIntegrationRequest
+	ElasticsearchIntegration
+	GetElasticsearchIntegrationRequest
 	GetHTTPIntegrationRequest
 	DeleteIntegrationRequest
+	StreamApplicationEventsRequest
+	ApplicationEvent
 	ListIntegrationRequest
 	ListIntegrationResponse
+	IntegrationListItem
+	ListIntegrationHealthResponse
+	IntegrationHealth
+	GetIntegrationChaosRequest
+	IntegrationChaos
+	GetIntegrationFilterRequest
+	IntegrationFilter
+	GetApplicationMaintenanceRequest
+	ApplicationMaintenance
+	GetApplicationMQTTCredentialsRequest
+	ApplicationMQTTCredentials
+	GenerateApplicationMQTTCredentialsRequest
+	GenerateApplicationMQTTCredentialsResponse
+	DeleteApplicationMQTTCredentialsRequest
+	GetApplicationStatusPageRequest
+	ApplicationStatusPage
+	EnableApplicationStatusPageRequest
+	DisableApplicationStatusPageRequest
+	SendCustomEventRequest
+	DownlinkTemplateField
+	DownlinkTemplate
+	DownlinkTemplateRequest
+	CreateDownlinkTemplateResponse
+	ListDownlinkTemplatesRequest
+	ListDownlinkTemplatesResponse
 	EnqueueDownlinkQueueItemRequest
 	EnqueueDownlinkQueueItemResponse
+	DownlinkAdvisory
 	DeleteDownlinkQeueueItemRequest
 	DeleteDownlinkQueueItemResponse
 	DownlinkQueueItem
 	ListDownlinkQueueItemsRequest
 	ListDownlinkQueueItemsResponse
+	EnqueueDownlinkTemplateRequest
+	EnqueueDownlinkTemplateResponse
 	ApplicationLink
 	OrganizationLink
 	UserProfile
 	ProfileRequest
 	ProfileResponse
+	EgressRequest
+	EgressResponse
+	ListRequestLogsRequest
+	RequestLog
+	ListRequestLogsResponse
+	GetAdminStatsRequest
+	TopApplication
+	GetTopApplicationsResponse
+	OrganizationErrorRate
+	GetOrganizationErrorRatesResponse
+	IntegrationFailure
+	GetIntegrationFailuresResponse
+	GetLeaderElectionsRequest
+	LeaderElection
+	GetLeaderElectionsResponse
 	ProfileSettings
 	LoginRequest
 	LoginResponse
@@ -140,6 +264,38 @@ It has these top-level messages:
 	GetOrganizationUserRequest
 	GetOrganizationUserResponse
 	ListOrganizationUsersResponse
+	SavedNodeFilterRequest
+	CreateSavedNodeFilterRequest
+	CreateSavedNodeFilterResponse
+	GetSavedNodeFilterResponse
+	UpdateSavedNodeFilterRequest
+	ListSavedNodeFiltersRequest
+	ListSavedNodeFiltersResponse
+	OrganizationDigestRequest
+	OrganizationDigest
+	PreviewOrganizationDigestResponse
+	OrganizationElevationRequest
+	CreateOrganizationElevationRequest
+	CreateOrganizationElevationResponse
+	GetOrganizationElevationResponse
+	ListOrganizationElevationsRequest
+	ListOrganizationElevationsResponse
+	DeadLetterRequest
+	DeadLetter
+	ListDeadLettersRequest
+	ListDeadLettersResponse
+	PurgeDeadLettersRequest
+	PurgeDeadLettersResponse
+	IntegrationRXInfo
+	IntegrationTXInfo
+	UplinkEvent
+	JoinEvent
+	AckEvent
+	ErrorEvent
+	SecurityEvent
+	ProprietaryUplinkEvent
+	CustomEvent
+	GatewayEvent
 */
 package api
 
@@ -197,6 +353,10 @@ type CreateNodeRequest struct {
 	IsClassC bool `protobuf:"varint,16,opt,name=isClassC" json:"isClassC,omitempty"`
 	// When set to true, the application settings will be used to populate the node network settings.
 	UseApplicationSettings bool `protobuf:"varint,17,opt,name=useApplicationSettings" json:"useApplicationSettings,omitempty"`
+	// Tags of the node.
+	Tags []string `protobuf:"bytes,18,rep,name=tags" json:"tags,omitempty"`
+	// Variables of the node (e.g. the credentials used by an integration).
+	Variables []*NodeVariable `protobuf:"bytes,19,rep,name=variables" json:"variables,omitempty"`
 }
 
 func (m *CreateNodeRequest) Reset()                    { *m = CreateNodeRequest{} }
@@ -316,6 +476,20 @@ func (m *CreateNodeRequest) GetUseApplicationSettings() bool {
 	return false
 }
 
+func (m *CreateNodeRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *CreateNodeRequest) GetVariables() []*NodeVariable {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
 type CreateNodeResponse struct {
 }
 
@@ -324,6 +498,32 @@ func (m *CreateNodeResponse) String() string            { return proto.CompactTe
 func (*CreateNodeResponse) ProtoMessage()               {}
 func (*CreateNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+type NodeVariable struct {
+	// Name of the variable.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Value of the variable.
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *NodeVariable) Reset()                    { *m = NodeVariable{} }
+func (m *NodeVariable) String() string            { return proto.CompactTextString(m) }
+func (*NodeVariable) ProtoMessage()               {}
+func (*NodeVariable) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *NodeVariable) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeVariable) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 type GetNodeRequest struct {
 	// Hex encoded DevEUI of the node.
 	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -332,7 +532,7 @@ type GetNodeRequest struct {
 func (m *GetNodeRequest) Reset()                    { *m = GetNodeRequest{} }
 func (m *GetNodeRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetNodeRequest) ProtoMessage()               {}
-func (*GetNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*GetNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *GetNodeRequest) GetDevEUI() string {
 	if m != nil {
@@ -374,12 +574,19 @@ type GetNodeResponse struct {
 	IsClassC bool `protobuf:"varint,16,opt,name=isClassC" json:"isClassC,omitempty"`
 	// When set to true, the application settings will be used to populate the node network settings.
 	UseApplicationSettings bool `protobuf:"varint,17,opt,name=useApplicationSettings" json:"useApplicationSettings,omitempty"`
+	// Tags of the node.
+	Tags []string `protobuf:"bytes,18,rep,name=tags" json:"tags,omitempty"`
+	// Variables of the node (e.g. the credentials used by an integration).
+	Variables []*NodeVariable `protobuf:"bytes,19,rep,name=variables" json:"variables,omitempty"`
+	// Timestamp (RFC3339) at which the node was decommissioned (empty when
+	// the node is not retired).
+	RetiredAt string `protobuf:"bytes,20,opt,name=retiredAt" json:"retiredAt,omitempty"`
 }
 
 func (m *GetNodeResponse) Reset()                    { *m = GetNodeResponse{} }
 func (m *GetNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetNodeResponse) ProtoMessage()               {}
-func (*GetNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*GetNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *GetNodeResponse) GetDevEUI() string {
 	if m != nil {
@@ -493,6 +700,27 @@ func (m *GetNodeResponse) GetUseApplicationSettings() bool {
 	return false
 }
 
+func (m *GetNodeResponse) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *GetNodeResponse) GetVariables() []*NodeVariable {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+func (m *GetNodeResponse) GetRetiredAt() string {
+	if m != nil {
+		return m.RetiredAt
+	}
+	return ""
+}
+
 type DeleteNodeRequest struct {
 	// Hex encoded DevEUI of the node.
 	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
@@ -501,7 +729,7 @@ type DeleteNodeRequest struct {
 func (m *DeleteNodeRequest) Reset()                    { *m = DeleteNodeRequest{} }
 func (m *DeleteNodeRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeRequest) ProtoMessage()               {}
-func (*DeleteNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*DeleteNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *DeleteNodeRequest) GetDevEUI() string {
 	if m != nil {
@@ -516,7 +744,43 @@ type DeleteNodeResponse struct {
 func (m *DeleteNodeResponse) Reset()                    { *m = DeleteNodeResponse{} }
 func (m *DeleteNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeResponse) ProtoMessage()               {}
-func (*DeleteNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*DeleteNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type DecommissionNodeRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Delete the history (link-quality and last values) of the node right
+	// away. When not set, the history is kept until it expires according to
+	// the retention.
+	DeleteHistory bool `protobuf:"varint,2,opt,name=deleteHistory" json:"deleteHistory,omitempty"`
+}
+
+func (m *DecommissionNodeRequest) Reset()                    { *m = DecommissionNodeRequest{} }
+func (m *DecommissionNodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*DecommissionNodeRequest) ProtoMessage()               {}
+func (*DecommissionNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DecommissionNodeRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *DecommissionNodeRequest) GetDeleteHistory() bool {
+	if m != nil {
+		return m.DeleteHistory
+	}
+	return false
+}
+
+type DecommissionNodeResponse struct {
+}
+
+func (m *DecommissionNodeResponse) Reset()                    { *m = DecommissionNodeResponse{} }
+func (m *DecommissionNodeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DecommissionNodeResponse) ProtoMessage()               {}
+func (*DecommissionNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 type ListNodeByApplicationIDRequest struct {
 	// ID of the application for which to list the nodes.
@@ -525,12 +789,14 @@ type ListNodeByApplicationIDRequest struct {
 	Limit int64 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
 	// Offset of the result-set (for pagination).
 	Offset int64 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	// ID of the saved node filter to apply (optional).
+	FilterID int64 `protobuf:"varint,4,opt,name=filterID" json:"filterID,omitempty"`
 }
 
 func (m *ListNodeByApplicationIDRequest) Reset()                    { *m = ListNodeByApplicationIDRequest{} }
 func (m *ListNodeByApplicationIDRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListNodeByApplicationIDRequest) ProtoMessage()               {}
-func (*ListNodeByApplicationIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*ListNodeByApplicationIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *ListNodeByApplicationIDRequest) GetApplicationID() int64 {
 	if m != nil {
@@ -553,6 +819,13 @@ func (m *ListNodeByApplicationIDRequest) GetOffset() int64 {
 	return 0
 }
 
+func (m *ListNodeByApplicationIDRequest) GetFilterID() int64 {
+	if m != nil {
+		return m.FilterID
+	}
+	return 0
+}
+
 type ListNodeResponse struct {
 	// Total number of nodes available within the result-set.
 	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
@@ -563,7 +836,7 @@ type ListNodeResponse struct {
 func (m *ListNodeResponse) Reset()                    { *m = ListNodeResponse{} }
 func (m *ListNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListNodeResponse) ProtoMessage()               {}
-func (*ListNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*ListNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *ListNodeResponse) GetTotalCount() int64 {
 	if m != nil {
@@ -612,12 +885,22 @@ type UpdateNodeRequest struct {
 	IsClassC bool `protobuf:"varint,16,opt,name=isClassC" json:"isClassC,omitempty"`
 	// When set to true, the application settings will be used to populate the node network settings.
 	UseApplicationSettings bool `protobuf:"varint,17,opt,name=useApplicationSettings" json:"useApplicationSettings,omitempty"`
+	// Tags of the node.
+	Tags []string `protobuf:"bytes,19,rep,name=tags" json:"tags,omitempty"`
+	// Variables of the node (e.g. the credentials used by an integration).
+	Variables []*NodeVariable `protobuf:"bytes,20,rep,name=variables" json:"variables,omitempty"`
+	// Fields to update (e.g. name, description). When empty, all fields are updated.
+	UpdateMask []string `protobuf:"bytes,18,rep,name=updateMask" json:"updateMask,omitempty"`
+	// Revision (ETag) of the node on which the update is based, required by
+	// UpdateBatch (use "*" to update regardless of the current revision).
+	// Update and Patch use the If-Match header instead.
+	Revision string `protobuf:"bytes,21,opt,name=revision" json:"revision,omitempty"`
 }
 
 func (m *UpdateNodeRequest) Reset()                    { *m = UpdateNodeRequest{} }
 func (m *UpdateNodeRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateNodeRequest) ProtoMessage()               {}
-func (*UpdateNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*UpdateNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *UpdateNodeRequest) GetDevEUI() string {
 	if m != nil {
@@ -731,13 +1014,145 @@ func (m *UpdateNodeRequest) GetUseApplicationSettings() bool {
 	return false
 }
 
+func (m *UpdateNodeRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *UpdateNodeRequest) GetVariables() []*NodeVariable {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+func (m *UpdateNodeRequest) GetUpdateMask() []string {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateNodeRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
 type UpdateNodeResponse struct {
 }
 
 func (m *UpdateNodeResponse) Reset()                    { *m = UpdateNodeResponse{} }
 func (m *UpdateNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*UpdateNodeResponse) ProtoMessage()               {}
-func (*UpdateNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*UpdateNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+type CreateNodeBatchRequest struct {
+	// Nodes to create.
+	Nodes []*CreateNodeRequest `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *CreateNodeBatchRequest) Reset()                    { *m = CreateNodeBatchRequest{} }
+func (m *CreateNodeBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateNodeBatchRequest) ProtoMessage()               {}
+func (*CreateNodeBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *CreateNodeBatchRequest) GetNodes() []*CreateNodeRequest {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type CreateNodeBatchResponse struct {
+	// Result for each node (in the same order as the request).
+	Result []*NodeBatchResult `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *CreateNodeBatchResponse) Reset()                    { *m = CreateNodeBatchResponse{} }
+func (m *CreateNodeBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateNodeBatchResponse) ProtoMessage()               {}
+func (*CreateNodeBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *CreateNodeBatchResponse) GetResult() []*NodeBatchResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type UpdateNodeBatchRequest struct {
+	// Nodes to update.
+	Nodes []*UpdateNodeRequest `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *UpdateNodeBatchRequest) Reset()                    { *m = UpdateNodeBatchRequest{} }
+func (m *UpdateNodeBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeBatchRequest) ProtoMessage()               {}
+func (*UpdateNodeBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *UpdateNodeBatchRequest) GetNodes() []*UpdateNodeRequest {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type UpdateNodeBatchResponse struct {
+	// Result for each node (in the same order as the request).
+	Result []*NodeBatchResult `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *UpdateNodeBatchResponse) Reset()                    { *m = UpdateNodeBatchResponse{} }
+func (m *UpdateNodeBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeBatchResponse) ProtoMessage()               {}
+func (*UpdateNodeBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *UpdateNodeBatchResponse) GetResult() []*NodeBatchResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type NodeBatchResult struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Error (empty when the node was processed successfully).
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	// gRPC status code of the error (e.g. FailedPrecondition when the node
+	// has been modified in the meantime), empty on success.
+	Code string `protobuf:"bytes,3,opt,name=code" json:"code,omitempty"`
+}
+
+func (m *NodeBatchResult) Reset()                    { *m = NodeBatchResult{} }
+func (m *NodeBatchResult) String() string            { return proto.CompactTextString(m) }
+func (*NodeBatchResult) ProtoMessage()               {}
+func (*NodeBatchResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *NodeBatchResult) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeBatchResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *NodeBatchResult) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
 
 type ActivateNodeRequest struct {
 	// Hex encoded DevEUI of the node to activate.
@@ -757,7 +1172,7 @@ type ActivateNodeRequest struct {
 func (m *ActivateNodeRequest) Reset()                    { *m = ActivateNodeRequest{} }
 func (m *ActivateNodeRequest) String() string            { return proto.CompactTextString(m) }
 func (*ActivateNodeRequest) ProtoMessage()               {}
-func (*ActivateNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*ActivateNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *ActivateNodeRequest) GetDevEUI() string {
 	if m != nil {
@@ -807,7 +1222,7 @@ type ActivateNodeResponse struct {
 func (m *ActivateNodeResponse) Reset()                    { *m = ActivateNodeResponse{} }
 func (m *ActivateNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*ActivateNodeResponse) ProtoMessage()               {}
-func (*ActivateNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ActivateNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 type GetNodeActivationRequest struct {
 	// Hex encoded DevEUI of the node.
@@ -817,7 +1232,7 @@ type GetNodeActivationRequest struct {
 func (m *GetNodeActivationRequest) Reset()                    { *m = GetNodeActivationRequest{} }
 func (m *GetNodeActivationRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetNodeActivationRequest) ProtoMessage()               {}
-func (*GetNodeActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*GetNodeActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *GetNodeActivationRequest) GetDevEUI() string {
 	if m != nil {
@@ -842,7 +1257,7 @@ type GetNodeActivationResponse struct {
 func (m *GetNodeActivationResponse) Reset()                    { *m = GetNodeActivationResponse{} }
 func (m *GetNodeActivationResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetNodeActivationResponse) ProtoMessage()               {}
-func (*GetNodeActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*GetNodeActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *GetNodeActivationResponse) GetDevAddr() string {
 	if m != nil {
@@ -885,7 +1300,7 @@ type GetRandomDevAddrRequest struct {
 func (m *GetRandomDevAddrRequest) Reset()                    { *m = GetRandomDevAddrRequest{} }
 func (m *GetRandomDevAddrRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetRandomDevAddrRequest) ProtoMessage()               {}
-func (*GetRandomDevAddrRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*GetRandomDevAddrRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 type GetRandomDevAddrResponse struct {
 	// Hex encoded DevAddr.
@@ -895,7 +1310,7 @@ type GetRandomDevAddrResponse struct {
 func (m *GetRandomDevAddrResponse) Reset()                    { *m = GetRandomDevAddrResponse{} }
 func (m *GetRandomDevAddrResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetRandomDevAddrResponse) ProtoMessage()               {}
-func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *GetRandomDevAddrResponse) GetDevAddr() string {
 	if m != nil {
@@ -916,7 +1331,7 @@ type GetFrameLogsRequest struct {
 func (m *GetFrameLogsRequest) Reset()                    { *m = GetFrameLogsRequest{} }
 func (m *GetFrameLogsRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetFrameLogsRequest) ProtoMessage()               {}
-func (*GetFrameLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*GetFrameLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *GetFrameLogsRequest) GetDevEUI() string {
 	if m != nil {
@@ -949,7 +1364,7 @@ type GetFrameLogsResponse struct {
 func (m *GetFrameLogsResponse) Reset()                    { *m = GetFrameLogsResponse{} }
 func (m *GetFrameLogsResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetFrameLogsResponse) ProtoMessage()               {}
-func (*GetFrameLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*GetFrameLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *GetFrameLogsResponse) GetTotalCount() int32 {
 	if m != nil {
@@ -965,6 +1380,58 @@ func (m *GetFrameLogsResponse) GetResult() []*FrameLog {
 	return nil
 }
 
+type GetNextNodeEventRequest struct {
+	// Hex encoded DevEUI.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Max number of seconds to wait for an event (default 30, max 300).
+	Timeout uint32 `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *GetNextNodeEventRequest) Reset()                    { *m = GetNextNodeEventRequest{} }
+func (m *GetNextNodeEventRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNextNodeEventRequest) ProtoMessage()               {}
+func (*GetNextNodeEventRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *GetNextNodeEventRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *GetNextNodeEventRequest) GetTimeout() uint32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type GetNextNodeEventResponse struct {
+	// Type of the event (uplink, join, ack or error, empty on timeout).
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// Payload of the event as a JSON string (as published by the integrations).
+	PayloadJSON string `protobuf:"bytes,2,opt,name=payloadJSON" json:"payloadJSON,omitempty"`
+}
+
+func (m *GetNextNodeEventResponse) Reset()                    { *m = GetNextNodeEventResponse{} }
+func (m *GetNextNodeEventResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNextNodeEventResponse) ProtoMessage()               {}
+func (*GetNextNodeEventResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *GetNextNodeEventResponse) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *GetNextNodeEventResponse) GetPayloadJSON() string {
+	if m != nil {
+		return m.PayloadJSON
+	}
+	return ""
+}
+
 type FrameLog struct {
 	// Timestamp of when the frame was logged.
 	CreatedAt string `protobuf:"bytes,1,opt,name=createdAt" json:"createdAt,omitempty"`
@@ -979,7 +1446,7 @@ type FrameLog struct {
 func (m *FrameLog) Reset()                    { *m = FrameLog{} }
 func (m *FrameLog) String() string            { return proto.CompactTextString(m) }
 func (*FrameLog) ProtoMessage()               {}
-func (*FrameLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*FrameLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *FrameLog) GetCreatedAt() string {
 	if m != nil {
@@ -1019,7 +1486,7 @@ type DataRate struct {
 func (m *DataRate) Reset()                    { *m = DataRate{} }
 func (m *DataRate) String() string            { return proto.CompactTextString(m) }
 func (*DataRate) ProtoMessage()               {}
-func (*DataRate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*DataRate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *DataRate) GetModulation() string {
 	if m != nil {
@@ -1073,7 +1540,7 @@ type RXInfo struct {
 func (m *RXInfo) Reset()                    { *m = RXInfo{} }
 func (m *RXInfo) String() string            { return proto.CompactTextString(m) }
 func (*RXInfo) ProtoMessage()               {}
-func (*RXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*RXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *RXInfo) GetChannel() int32 {
 	if m != nil {
@@ -1158,7 +1625,7 @@ type TXInfo struct {
 func (m *TXInfo) Reset()                    { *m = TXInfo{} }
 func (m *TXInfo) String() string            { return proto.CompactTextString(m) }
 func (*TXInfo) ProtoMessage()               {}
-func (*TXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*TXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *TXInfo) GetCodeRate() string {
 	if m != nil {
@@ -1209,95 +1676,1891 @@ func (m *TXInfo) GetDataRate() *DataRate {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*CreateNodeRequest)(nil), "api.CreateNodeRequest")
-	proto.RegisterType((*CreateNodeResponse)(nil), "api.CreateNodeResponse")
-	proto.RegisterType((*GetNodeRequest)(nil), "api.GetNodeRequest")
-	proto.RegisterType((*GetNodeResponse)(nil), "api.GetNodeResponse")
-	proto.RegisterType((*DeleteNodeRequest)(nil), "api.DeleteNodeRequest")
-	proto.RegisterType((*DeleteNodeResponse)(nil), "api.DeleteNodeResponse")
-	proto.RegisterType((*ListNodeByApplicationIDRequest)(nil), "api.ListNodeByApplicationIDRequest")
-	proto.RegisterType((*ListNodeResponse)(nil), "api.ListNodeResponse")
-	proto.RegisterType((*UpdateNodeRequest)(nil), "api.UpdateNodeRequest")
-	proto.RegisterType((*UpdateNodeResponse)(nil), "api.UpdateNodeResponse")
-	proto.RegisterType((*ActivateNodeRequest)(nil), "api.ActivateNodeRequest")
-	proto.RegisterType((*ActivateNodeResponse)(nil), "api.ActivateNodeResponse")
-	proto.RegisterType((*GetNodeActivationRequest)(nil), "api.GetNodeActivationRequest")
-	proto.RegisterType((*GetNodeActivationResponse)(nil), "api.GetNodeActivationResponse")
-	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "api.GetRandomDevAddrRequest")
-	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "api.GetRandomDevAddrResponse")
-	proto.RegisterType((*GetFrameLogsRequest)(nil), "api.GetFrameLogsRequest")
-	proto.RegisterType((*GetFrameLogsResponse)(nil), "api.GetFrameLogsResponse")
-	proto.RegisterType((*FrameLog)(nil), "api.FrameLog")
-	proto.RegisterType((*DataRate)(nil), "api.DataRate")
-	proto.RegisterType((*RXInfo)(nil), "api.RXInfo")
-	proto.RegisterType((*TXInfo)(nil), "api.TXInfo")
+type GetNodeDownlinkAirtimeRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Node service
+func (m *GetNodeDownlinkAirtimeRequest) Reset()                    { *m = GetNodeDownlinkAirtimeRequest{} }
+func (m *GetNodeDownlinkAirtimeRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeDownlinkAirtimeRequest) ProtoMessage()               {}
+func (*GetNodeDownlinkAirtimeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
-type NodeClient interface {
-	// Create creates the given node.
-	Create(ctx context.Context, in *CreateNodeRequest, opts ...grpc.CallOption) (*CreateNodeResponse, error)
-	// Get returns the node for the requested DevEUI.
-	Get(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
-	// Delete deletes the node matching the given DevEUI.
-	Delete(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error)
-	// ListByApplicationID lists the nodes by the given application ID, sorted by the name of the node.
-	ListByApplicationID(ctx context.Context, in *ListNodeByApplicationIDRequest, opts ...grpc.CallOption) (*ListNodeResponse, error)
-	// Update updates the node matching the given DevEUI.
-	Update(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error)
-	// Activate (re)activates the node (only when ABP is set to true).
-	Activate(ctx context.Context, in *ActivateNodeRequest, opts ...grpc.CallOption) (*ActivateNodeResponse, error)
-	// GetActivation returns the current activation details of the node (OTAA and ABP).
-	GetActivation(ctx context.Context, in *GetNodeActivationRequest, opts ...grpc.CallOption) (*GetNodeActivationResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
-	// GetFrameLogs returns the uplink / downlink frame log for the given DevEUI.
-	GetFrameLogs(ctx context.Context, in *GetFrameLogsRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error)
+func (m *GetNodeDownlinkAirtimeRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
 }
 
-type nodeClient struct {
-	cc *grpc.ClientConn
+type GetNodeDownlinkAirtimeResponse struct {
+	// Start of the current window (RFC3339 formatted).
+	WindowStart string `protobuf:"bytes,1,opt,name=windowStart" json:"windowStart,omitempty"`
+	// Downlink airtime (in ms) used within the current window.
+	Used uint32 `protobuf:"varint,2,opt,name=used" json:"used,omitempty"`
+	// Downlink airtime budget (in ms) per window (0 = no budget).
+	Budget uint32 `protobuf:"varint,3,opt,name=budget" json:"budget,omitempty"`
+	// The budget is enforced (downlink payloads exceeding it are rejected).
+	Enforce bool `protobuf:"varint,4,opt,name=enforce" json:"enforce,omitempty"`
 }
 
-func NewNodeClient(cc *grpc.ClientConn) NodeClient {
-	return &nodeClient{cc}
+func (m *GetNodeDownlinkAirtimeResponse) Reset()                    { *m = GetNodeDownlinkAirtimeResponse{} }
+func (m *GetNodeDownlinkAirtimeResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeDownlinkAirtimeResponse) ProtoMessage()               {}
+func (*GetNodeDownlinkAirtimeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *GetNodeDownlinkAirtimeResponse) GetWindowStart() string {
+	if m != nil {
+		return m.WindowStart
+	}
+	return ""
 }
 
-func (c *nodeClient) Create(ctx context.Context, in *CreateNodeRequest, opts ...grpc.CallOption) (*CreateNodeResponse, error) {
-	out := new(CreateNodeResponse)
-	err := grpc.Invoke(ctx, "/api.Node/Create", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetNodeDownlinkAirtimeResponse) GetUsed() uint32 {
+	if m != nil {
+		return m.Used
 	}
-	return out, nil
+	return 0
 }
 
-func (c *nodeClient) Get(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
-	out := new(GetNodeResponse)
-	err := grpc.Invoke(ctx, "/api.Node/Get", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetNodeDownlinkAirtimeResponse) GetBudget() uint32 {
+	if m != nil {
+		return m.Budget
 	}
-	return out, nil
+	return 0
 }
 
-func (c *nodeClient) Delete(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error) {
-	out := new(DeleteNodeResponse)
-	err := grpc.Invoke(ctx, "/api.Node/Delete", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetNodeDownlinkAirtimeResponse) GetEnforce() bool {
+	if m != nil {
+		return m.Enforce
 	}
-	return out, nil
+	return false
+}
+
+type LinkQuality struct {
+	// Link-quality score (0 - 100, higher is better).
+	Score float64 `protobuf:"fixed64,1,opt,name=score" json:"score,omitempty"`
+	// Average SNR margin (dB) above the min. SNR needed for the used
+	// spread-factor.
+	SnrMargin float64 `protobuf:"fixed64,2,opt,name=snrMargin" json:"snrMargin,omitempty"`
+	// Number of received uplinks.
+	Uplinks uint32 `protobuf:"varint,3,opt,name=uplinks" json:"uplinks,omitempty"`
+	// Number of missed uplinks (based on frame-counter gaps).
+	Missed uint32 `protobuf:"varint,4,opt,name=missed" json:"missed,omitempty"`
+	// Number of retransmitted uplinks.
+	Retransmissions uint32 `protobuf:"varint,5,opt,name=retransmissions" json:"retransmissions,omitempty"`
+}
+
+func (m *LinkQuality) Reset()                    { *m = LinkQuality{} }
+func (m *LinkQuality) String() string            { return proto.CompactTextString(m) }
+func (*LinkQuality) ProtoMessage()               {}
+func (*LinkQuality) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *LinkQuality) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *LinkQuality) GetSnrMargin() float64 {
+	if m != nil {
+		return m.SnrMargin
+	}
+	return 0
+}
+
+func (m *LinkQuality) GetUplinks() uint32 {
+	if m != nil {
+		return m.Uplinks
+	}
+	return 0
+}
+
+func (m *LinkQuality) GetMissed() uint32 {
+	if m != nil {
+		return m.Missed
+	}
+	return 0
+}
+
+func (m *LinkQuality) GetRetransmissions() uint32 {
+	if m != nil {
+		return m.Retransmissions
+	}
+	return 0
+}
+
+type LinkQualityBucket struct {
+	// Start of the (hourly) bucket (RFC3339 formatted).
+	Bucket string `protobuf:"bytes,1,opt,name=bucket" json:"bucket,omitempty"`
+	// Link-quality within this bucket.
+	LinkQuality *LinkQuality `protobuf:"bytes,2,opt,name=linkQuality" json:"linkQuality,omitempty"`
+}
+
+func (m *LinkQualityBucket) Reset()                    { *m = LinkQualityBucket{} }
+func (m *LinkQualityBucket) String() string            { return proto.CompactTextString(m) }
+func (*LinkQualityBucket) ProtoMessage()               {}
+func (*LinkQualityBucket) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *LinkQualityBucket) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *LinkQualityBucket) GetLinkQuality() *LinkQuality {
+	if m != nil {
+		return m.LinkQuality
+	}
+	return nil
+}
+
+type GetNodeLinkQualityRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Number of hours to take into account (default 24, max 720).
+	Hours uint32 `protobuf:"varint,2,opt,name=hours" json:"hours,omitempty"`
+}
+
+func (m *GetNodeLinkQualityRequest) Reset()                    { *m = GetNodeLinkQualityRequest{} }
+func (m *GetNodeLinkQualityRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeLinkQualityRequest) ProtoMessage()               {}
+func (*GetNodeLinkQualityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *GetNodeLinkQualityRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *GetNodeLinkQualityRequest) GetHours() uint32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+type GetNodeLinkQualityResponse struct {
+	// Link-quality over the requested period.
+	LinkQuality *LinkQuality `protobuf:"bytes,1,opt,name=linkQuality" json:"linkQuality,omitempty"`
+	// Hourly link-quality history.
+	History []*LinkQualityBucket `protobuf:"bytes,2,rep,name=history" json:"history,omitempty"`
+}
+
+func (m *GetNodeLinkQualityResponse) Reset()                    { *m = GetNodeLinkQualityResponse{} }
+func (m *GetNodeLinkQualityResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeLinkQualityResponse) ProtoMessage()               {}
+func (*GetNodeLinkQualityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *GetNodeLinkQualityResponse) GetLinkQuality() *LinkQuality {
+	if m != nil {
+		return m.LinkQuality
+	}
+	return nil
+}
+
+func (m *GetNodeLinkQualityResponse) GetHistory() []*LinkQualityBucket {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type ListNodeLinkQualityRequest struct {
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Number of hours to take into account (default 24, max 720).
+	Hours uint32 `protobuf:"varint,2,opt,name=hours" json:"hours,omitempty"`
+	// Max number of items to return.
+	Limit int64 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int64 `protobuf:"varint,4,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListNodeLinkQualityRequest) Reset()                    { *m = ListNodeLinkQualityRequest{} }
+func (m *ListNodeLinkQualityRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeLinkQualityRequest) ProtoMessage()               {}
+func (*ListNodeLinkQualityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ListNodeLinkQualityRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *ListNodeLinkQualityRequest) GetHours() uint32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+func (m *ListNodeLinkQualityRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListNodeLinkQualityRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type NodeLinkQuality struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Link-quality over the requested period.
+	LinkQuality *LinkQuality `protobuf:"bytes,3,opt,name=linkQuality" json:"linkQuality,omitempty"`
+}
+
+func (m *NodeLinkQuality) Reset()                    { *m = NodeLinkQuality{} }
+func (m *NodeLinkQuality) String() string            { return proto.CompactTextString(m) }
+func (*NodeLinkQuality) ProtoMessage()               {}
+func (*NodeLinkQuality) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *NodeLinkQuality) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeLinkQuality) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeLinkQuality) GetLinkQuality() *LinkQuality {
+	if m != nil {
+		return m.LinkQuality
+	}
+	return nil
+}
+
+type ListNodeLinkQualityResponse struct {
+	// Total number of nodes with link-quality data.
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Nodes ranked by link-quality score (worst first).
+	Result []*NodeLinkQuality `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListNodeLinkQualityResponse) Reset()                    { *m = ListNodeLinkQualityResponse{} }
+func (m *ListNodeLinkQualityResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeLinkQualityResponse) ProtoMessage()               {}
+func (*ListNodeLinkQualityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ListNodeLinkQualityResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListNodeLinkQualityResponse) GetResult() []*NodeLinkQuality {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type Availability struct {
+	// Number of expected uplinks.
+	ExpectedUplinks uint32 `protobuf:"varint,1,opt,name=expectedUplinks" json:"expectedUplinks,omitempty"`
+	// Number of received uplinks (excluding retransmissions).
+	ReceivedUplinks uint32 `protobuf:"varint,2,opt,name=receivedUplinks" json:"receivedUplinks,omitempty"`
+	// Availability (0 - 100), the percentage of the expected uplinks that
+	// were received.
+	Percentage float64 `protobuf:"fixed64,3,opt,name=percentage" json:"percentage,omitempty"`
+}
+
+func (m *Availability) Reset()                    { *m = Availability{} }
+func (m *Availability) String() string            { return proto.CompactTextString(m) }
+func (*Availability) ProtoMessage()               {}
+func (*Availability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *Availability) GetExpectedUplinks() uint32 {
+	if m != nil {
+		return m.ExpectedUplinks
+	}
+	return 0
+}
+
+func (m *Availability) GetReceivedUplinks() uint32 {
+	if m != nil {
+		return m.ReceivedUplinks
+	}
+	return 0
+}
+
+func (m *Availability) GetPercentage() float64 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+type GetNodeAvailabilityRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Number of hours to take into account (default 24, max 720).
+	Hours uint32 `protobuf:"varint,2,opt,name=hours" json:"hours,omitempty"`
+	// Interval (in seconds) in which the node is expected to send uplinks
+	// (optional). When not set, the expected uplinks are based on the
+	// frame-counter gaps.
+	UplinkInterval uint32 `protobuf:"varint,3,opt,name=uplinkInterval" json:"uplinkInterval,omitempty"`
+}
+
+func (m *GetNodeAvailabilityRequest) Reset()                    { *m = GetNodeAvailabilityRequest{} }
+func (m *GetNodeAvailabilityRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeAvailabilityRequest) ProtoMessage()               {}
+func (*GetNodeAvailabilityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *GetNodeAvailabilityRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *GetNodeAvailabilityRequest) GetHours() uint32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+func (m *GetNodeAvailabilityRequest) GetUplinkInterval() uint32 {
+	if m != nil {
+		return m.UplinkInterval
+	}
+	return 0
+}
+
+type GetNodeAvailabilityResponse struct {
+	// Availability over the requested period.
+	Availability *Availability `protobuf:"bytes,1,opt,name=availability" json:"availability,omitempty"`
+}
+
+func (m *GetNodeAvailabilityResponse) Reset()                    { *m = GetNodeAvailabilityResponse{} }
+func (m *GetNodeAvailabilityResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeAvailabilityResponse) ProtoMessage()               {}
+func (*GetNodeAvailabilityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *GetNodeAvailabilityResponse) GetAvailability() *Availability {
+	if m != nil {
+		return m.Availability
+	}
+	return nil
+}
+
+type ListNodeAvailabilityRequest struct {
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Number of hours to take into account (default 24, max 720).
+	Hours uint32 `protobuf:"varint,2,opt,name=hours" json:"hours,omitempty"`
+	// Interval (in seconds) in which the nodes are expected to send uplinks
+	// (optional). When not set, the expected uplinks are based on the
+	// frame-counter gaps.
+	UplinkInterval uint32 `protobuf:"varint,3,opt,name=uplinkInterval" json:"uplinkInterval,omitempty"`
+	// Max number of items to return.
+	Limit int64 `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int64 `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListNodeAvailabilityRequest) Reset()                    { *m = ListNodeAvailabilityRequest{} }
+func (m *ListNodeAvailabilityRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeAvailabilityRequest) ProtoMessage()               {}
+func (*ListNodeAvailabilityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *ListNodeAvailabilityRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *ListNodeAvailabilityRequest) GetHours() uint32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+func (m *ListNodeAvailabilityRequest) GetUplinkInterval() uint32 {
+	if m != nil {
+		return m.UplinkInterval
+	}
+	return 0
+}
+
+func (m *ListNodeAvailabilityRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListNodeAvailabilityRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type NodeAvailability struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Availability over the requested period.
+	Availability *Availability `protobuf:"bytes,3,opt,name=availability" json:"availability,omitempty"`
+}
+
+func (m *NodeAvailability) Reset()                    { *m = NodeAvailability{} }
+func (m *NodeAvailability) String() string            { return proto.CompactTextString(m) }
+func (*NodeAvailability) ProtoMessage()               {}
+func (*NodeAvailability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *NodeAvailability) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeAvailability) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeAvailability) GetAvailability() *Availability {
+	if m != nil {
+		return m.Availability
+	}
+	return nil
+}
+
+type ListNodeAvailabilityResponse struct {
+	// Total number of (enabled) nodes.
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Nodes ranked by availability (worst first).
+	Result []*NodeAvailability `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+	// Availability of the application (all uplinks received vs expected
+	// of all nodes).
+	ApplicationAvailability *Availability `protobuf:"bytes,3,opt,name=applicationAvailability" json:"applicationAvailability,omitempty"`
+}
+
+func (m *ListNodeAvailabilityResponse) Reset()                    { *m = ListNodeAvailabilityResponse{} }
+func (m *ListNodeAvailabilityResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeAvailabilityResponse) ProtoMessage()               {}
+func (*ListNodeAvailabilityResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *ListNodeAvailabilityResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListNodeAvailabilityResponse) GetResult() []*NodeAvailability {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *ListNodeAvailabilityResponse) GetApplicationAvailability() *Availability {
+	if m != nil {
+		return m.ApplicationAvailability
+	}
+	return nil
+}
+
+type NodeLastValue struct {
+	// FPort of the payload.
+	FPort uint32 `protobuf:"varint,1,opt,name=fPort" json:"fPort,omitempty"`
+	// Frame-counter of the payload.
+	FCnt uint32 `protobuf:"varint,2,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Base64 encoded (decrypted) payload.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Timestamp of reception (RFC3339).
+	ReceivedAt string `protobuf:"bytes,4,opt,name=receivedAt" json:"receivedAt,omitempty"`
+}
+
+func (m *NodeLastValue) Reset()                    { *m = NodeLastValue{} }
+func (m *NodeLastValue) String() string            { return proto.CompactTextString(m) }
+func (*NodeLastValue) ProtoMessage()               {}
+func (*NodeLastValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *NodeLastValue) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *NodeLastValue) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *NodeLastValue) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *NodeLastValue) GetReceivedAt() string {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return ""
+}
+
+type GetNodeLastValuesRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+}
+
+func (m *GetNodeLastValuesRequest) Reset()                    { *m = GetNodeLastValuesRequest{} }
+func (m *GetNodeLastValuesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeLastValuesRequest) ProtoMessage()               {}
+func (*GetNodeLastValuesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *GetNodeLastValuesRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type GetNodeLastValuesResponse struct {
+	// Last values, sorted by fPort.
+	Result []*NodeLastValue `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetNodeLastValuesResponse) Reset()                    { *m = GetNodeLastValuesResponse{} }
+func (m *GetNodeLastValuesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeLastValuesResponse) ProtoMessage()               {}
+func (*GetNodeLastValuesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *GetNodeLastValuesResponse) GetResult() []*NodeLastValue {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ListNodeLastValuesRequest struct {
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Max number of nodes to return.
+	Limit int64 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int64 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListNodeLastValuesRequest) Reset()                    { *m = ListNodeLastValuesRequest{} }
+func (m *ListNodeLastValuesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeLastValuesRequest) ProtoMessage()               {}
+func (*ListNodeLastValuesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *ListNodeLastValuesRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *ListNodeLastValuesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListNodeLastValuesRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type NodeLastValues struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Last values, sorted by fPort.
+	Values []*NodeLastValue `protobuf:"bytes,3,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *NodeLastValues) Reset()                    { *m = NodeLastValues{} }
+func (m *NodeLastValues) String() string            { return proto.CompactTextString(m) }
+func (*NodeLastValues) ProtoMessage()               {}
+func (*NodeLastValues) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *NodeLastValues) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeLastValues) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeLastValues) GetValues() []*NodeLastValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type ListNodeLastValuesResponse struct {
+	// Total number of nodes within the application.
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Nodes with their last values.
+	Result []*NodeLastValues `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListNodeLastValuesResponse) Reset()                    { *m = ListNodeLastValuesResponse{} }
+func (m *ListNodeLastValuesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeLastValuesResponse) ProtoMessage()               {}
+func (*ListNodeLastValuesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *ListNodeLastValuesResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListNodeLastValuesResponse) GetResult() []*NodeLastValues {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type BulkNodeTagsRequest struct {
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Only nodes having all of these tags are affected (optional).
+	FilterTags []string `protobuf:"bytes,2,rep,name=filterTags" json:"filterTags,omitempty"`
+	// Only nodes with a name matching this pattern are affected (optional).
+	// The pattern may contain the wildcards * (any sequence of characters)
+	// and ? (any single character).
+	NamePattern string `protobuf:"bytes,3,opt,name=namePattern" json:"namePattern,omitempty"`
+	// Tags to add or remove.
+	Tags []string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+	// When set to true, the affected nodes are returned without making
+	// any changes.
+	DryRun bool `protobuf:"varint,5,opt,name=dryRun" json:"dryRun,omitempty"`
+	// ID of the saved node filter to apply (optional). It can not be
+	// combined with filterTags and namePattern.
+	FilterID int64 `protobuf:"varint,6,opt,name=filterID" json:"filterID,omitempty"`
+}
+
+func (m *BulkNodeTagsRequest) Reset()                    { *m = BulkNodeTagsRequest{} }
+func (m *BulkNodeTagsRequest) String() string            { return proto.CompactTextString(m) }
+func (*BulkNodeTagsRequest) ProtoMessage()               {}
+func (*BulkNodeTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *BulkNodeTagsRequest) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *BulkNodeTagsRequest) GetFilterTags() []string {
+	if m != nil {
+		return m.FilterTags
+	}
+	return nil
+}
+
+func (m *BulkNodeTagsRequest) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
+	}
+	return ""
+}
+
+func (m *BulkNodeTagsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *BulkNodeTagsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *BulkNodeTagsRequest) GetFilterID() int64 {
+	if m != nil {
+		return m.FilterID
+	}
+	return 0
+}
+
+type BulkNodeTagsResponse struct {
+	// Hex encoded DevEUIs of the (to be) changed nodes.
+	DevEUIs []string `protobuf:"bytes,1,rep,name=devEUIs" json:"devEUIs,omitempty"`
+}
+
+func (m *BulkNodeTagsResponse) Reset()                    { *m = BulkNodeTagsResponse{} }
+func (m *BulkNodeTagsResponse) String() string            { return proto.CompactTextString(m) }
+func (*BulkNodeTagsResponse) ProtoMessage()               {}
+func (*BulkNodeTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *BulkNodeTagsResponse) GetDevEUIs() []string {
+	if m != nil {
+		return m.DevEUIs
+	}
+	return nil
+}
+
+type GetNodeEffectiveConfigRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+}
+
+func (m *GetNodeEffectiveConfigRequest) Reset()                    { *m = GetNodeEffectiveConfigRequest{} }
+func (m *GetNodeEffectiveConfigRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeEffectiveConfigRequest) ProtoMessage()               {}
+func (*GetNodeEffectiveConfigRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *GetNodeEffectiveConfigRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type NodeNetworkSettings struct {
+	// The node is an ABP node.
+	IsABP bool `protobuf:"varint,1,opt,name=isABP" json:"isABP,omitempty"`
+	// The node operates in Class-C mode.
+	IsClassC bool `protobuf:"varint,2,opt,name=isClassC" json:"isClassC,omitempty"`
+	// RX window to use for downlink transmissions.
+	RxWindow RXWindow `protobuf:"varint,3,opt,name=rxWindow,enum=api.RXWindow" json:"rxWindow,omitempty"`
+	// RX delay (in seconds).
+	RxDelay uint32 `protobuf:"varint,4,opt,name=rxDelay" json:"rxDelay,omitempty"`
+	// RX1 data-rate offset.
+	Rx1DROffset uint32 `protobuf:"varint,5,opt,name=rx1DROffset" json:"rx1DROffset,omitempty"`
+	// RX2 data-rate.
+	Rx2DR uint32 `protobuf:"varint,6,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// Relax frame-counter mode is enabled.
+	RelaxFCnt bool `protobuf:"varint,7,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
+	// ADR interval.
+	AdrInterval uint32 `protobuf:"varint,8,opt,name=adrInterval" json:"adrInterval,omitempty"`
+	// Installation margin (in dB) used by the ADR engine.
+	InstallationMargin float64 `protobuf:"fixed64,9,opt,name=installationMargin" json:"installationMargin,omitempty"`
+}
+
+func (m *NodeNetworkSettings) Reset()                    { *m = NodeNetworkSettings{} }
+func (m *NodeNetworkSettings) String() string            { return proto.CompactTextString(m) }
+func (*NodeNetworkSettings) ProtoMessage()               {}
+func (*NodeNetworkSettings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *NodeNetworkSettings) GetIsABP() bool {
+	if m != nil {
+		return m.IsABP
+	}
+	return false
+}
+
+func (m *NodeNetworkSettings) GetIsClassC() bool {
+	if m != nil {
+		return m.IsClassC
+	}
+	return false
+}
+
+func (m *NodeNetworkSettings) GetRxWindow() RXWindow {
+	if m != nil {
+		return m.RxWindow
+	}
+	return RXWindow_RX1
+}
+
+func (m *NodeNetworkSettings) GetRxDelay() uint32 {
+	if m != nil {
+		return m.RxDelay
+	}
+	return 0
+}
+
+func (m *NodeNetworkSettings) GetRx1DROffset() uint32 {
+	if m != nil {
+		return m.Rx1DROffset
+	}
+	return 0
+}
+
+func (m *NodeNetworkSettings) GetRx2DR() uint32 {
+	if m != nil {
+		return m.Rx2DR
+	}
+	return 0
+}
+
+func (m *NodeNetworkSettings) GetRelaxFCnt() bool {
+	if m != nil {
+		return m.RelaxFCnt
+	}
+	return false
+}
+
+func (m *NodeNetworkSettings) GetAdrInterval() uint32 {
+	if m != nil {
+		return m.AdrInterval
+	}
+	return 0
+}
+
+func (m *NodeNetworkSettings) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+type NodeIntegrationRoute struct {
+	// Kind of the integration (MQTT, HTTP or SYSLOG).
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// The events of the node are sent to this integration.
+	Enabled bool `protobuf:"varint,2,opt,name=enabled" json:"enabled,omitempty"`
+	// Reason why the events of the node are not sent to this integration.
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	// The integration is configured on the node itself (device-level)
+	// instead of on its application.
+	Device bool `protobuf:"varint,4,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *NodeIntegrationRoute) Reset()                    { *m = NodeIntegrationRoute{} }
+func (m *NodeIntegrationRoute) String() string            { return proto.CompactTextString(m) }
+func (*NodeIntegrationRoute) ProtoMessage()               {}
+func (*NodeIntegrationRoute) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *NodeIntegrationRoute) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *NodeIntegrationRoute) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *NodeIntegrationRoute) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *NodeIntegrationRoute) GetDevice() bool {
+	if m != nil {
+		return m.Device
+	}
+	return false
+}
+
+type GetNodeEffectiveConfigResponse struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,3,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Name of the application.
+	ApplicationName string `protobuf:"bytes,4,opt,name=applicationName" json:"applicationName,omitempty"`
+	// Environment of the application.
+	Environment string `protobuf:"bytes,5,opt,name=environment" json:"environment,omitempty"`
+	// Tags of the node.
+	Tags []string `protobuf:"bytes,6,rep,name=tags" json:"tags,omitempty"`
+	// Source of the network settings: APPLICATION when the node uses the
+	// application settings, NODE otherwise.
+	NetworkSettingsSource string `protobuf:"bytes,7,opt,name=networkSettingsSource" json:"networkSettingsSource,omitempty"`
+	// Effective network settings.
+	NetworkSettings *NodeNetworkSettings `protobuf:"bytes,8,opt,name=networkSettings" json:"networkSettings,omitempty"`
+	// Integrations of the application and whether they receive the events
+	// of the node.
+	Integrations []*NodeIntegrationRoute `protobuf:"bytes,9,rep,name=integrations" json:"integrations,omitempty"`
+	// Gateway filter mode of the application (ALLOW or DENY, empty when
+	// no gateway filter is configured).
+	GatewayFilterMode string `protobuf:"bytes,10,opt,name=gatewayFilterMode" json:"gatewayFilterMode,omitempty"`
+	// Hex encoded MAC addresses of the gateways listed by the gateway
+	// filter.
+	GatewayFilterMACs []string `protobuf:"bytes,11,rep,name=gatewayFilterMACs" json:"gatewayFilterMACs,omitempty"`
+	// Downlink airtime budget (in ms) per hour (0 = no budget).
+	DownlinkAirtimeBudget uint32 `protobuf:"varint,12,opt,name=downlinkAirtimeBudget" json:"downlinkAirtimeBudget,omitempty"`
+	// The downlink airtime budget is enforced.
+	DownlinkAirtimeBudgetEnforce bool `protobuf:"varint,13,opt,name=downlinkAirtimeBudgetEnforce" json:"downlinkAirtimeBudgetEnforce,omitempty"`
+	// Hex encoded proprietary payload prefix of the application.
+	ProprietaryPayloadPrefix string `protobuf:"bytes,14,opt,name=proprietaryPayloadPrefix" json:"proprietaryPayloadPrefix,omitempty"`
+}
+
+func (m *GetNodeEffectiveConfigResponse) Reset()                    { *m = GetNodeEffectiveConfigResponse{} }
+func (m *GetNodeEffectiveConfigResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeEffectiveConfigResponse) ProtoMessage()               {}
+func (*GetNodeEffectiveConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *GetNodeEffectiveConfigResponse) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetApplicationName() string {
+	if m != nil {
+		return m.ApplicationName
+	}
+	return ""
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetNetworkSettingsSource() string {
+	if m != nil {
+		return m.NetworkSettingsSource
+	}
+	return ""
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetNetworkSettings() *NodeNetworkSettings {
+	if m != nil {
+		return m.NetworkSettings
+	}
+	return nil
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetIntegrations() []*NodeIntegrationRoute {
+	if m != nil {
+		return m.Integrations
+	}
+	return nil
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetGatewayFilterMode() string {
+	if m != nil {
+		return m.GatewayFilterMode
+	}
+	return ""
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetGatewayFilterMACs() []string {
+	if m != nil {
+		return m.GatewayFilterMACs
+	}
+	return nil
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetDownlinkAirtimeBudget() uint32 {
+	if m != nil {
+		return m.DownlinkAirtimeBudget
+	}
+	return 0
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetDownlinkAirtimeBudgetEnforce() bool {
+	if m != nil {
+		return m.DownlinkAirtimeBudgetEnforce
+	}
+	return false
+}
+
+func (m *GetNodeEffectiveConfigResponse) GetProprietaryPayloadPrefix() string {
+	if m != nil {
+		return m.ProprietaryPayloadPrefix
+	}
+	return ""
+}
+
+type GetNodeMaintenanceRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+}
+
+func (m *GetNodeMaintenanceRequest) Reset()                    { *m = GetNodeMaintenanceRequest{} }
+func (m *GetNodeMaintenanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeMaintenanceRequest) ProtoMessage()               {}
+func (*GetNodeMaintenanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *GetNodeMaintenanceRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type NodeMaintenance struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Maintenance mode is enabled.
+	Enabled bool `protobuf:"varint,2,opt,name=enabled" json:"enabled,omitempty"`
+	// End of the maintenance (RFC3339, optional). When empty, the
+	// maintenance mode is active until disabled.
+	Until string `protobuf:"bytes,3,opt,name=until" json:"until,omitempty"`
+	// Maintenance mode of the node or its application is active.
+	Active bool `protobuf:"varint,4,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *NodeMaintenance) Reset()                    { *m = NodeMaintenance{} }
+func (m *NodeMaintenance) String() string            { return proto.CompactTextString(m) }
+func (*NodeMaintenance) ProtoMessage()               {}
+func (*NodeMaintenance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *NodeMaintenance) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeMaintenance) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *NodeMaintenance) GetUntil() string {
+	if m != nil {
+		return m.Until
+	}
+	return ""
+}
+
+func (m *NodeMaintenance) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type UpdateNodeMaintenanceResponse struct {
+}
+
+func (m *UpdateNodeMaintenanceResponse) Reset()                    { *m = UpdateNodeMaintenanceResponse{} }
+func (m *UpdateNodeMaintenanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeMaintenanceResponse) ProtoMessage()               {}
+func (*UpdateNodeMaintenanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+type GetNodeAliasRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+}
+
+func (m *GetNodeAliasRequest) Reset()                    { *m = GetNodeAliasRequest{} }
+func (m *GetNodeAliasRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeAliasRequest) ProtoMessage()               {}
+func (*GetNodeAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *GetNodeAliasRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type NodeAlias struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Alias of the node (unique within the organization, may only contain
+	// lower case characters, digits, -, _ and .).
+	Alias string `protobuf:"bytes,2,opt,name=alias" json:"alias,omitempty"`
+}
+
+func (m *NodeAlias) Reset()                    { *m = NodeAlias{} }
+func (m *NodeAlias) String() string            { return proto.CompactTextString(m) }
+func (*NodeAlias) ProtoMessage()               {}
+func (*NodeAlias) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *NodeAlias) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeAlias) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+type UpdateNodeAliasResponse struct {
+}
+
+func (m *UpdateNodeAliasResponse) Reset()                    { *m = UpdateNodeAliasResponse{} }
+func (m *UpdateNodeAliasResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeAliasResponse) ProtoMessage()               {}
+func (*UpdateNodeAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+type GetNodeByAliasRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Alias of the node.
+	Alias string `protobuf:"bytes,2,opt,name=alias" json:"alias,omitempty"`
+}
+
+func (m *GetNodeByAliasRequest) Reset()                    { *m = GetNodeByAliasRequest{} }
+func (m *GetNodeByAliasRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeByAliasRequest) ProtoMessage()               {}
+func (*GetNodeByAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *GetNodeByAliasRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *GetNodeByAliasRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+type LookupNodeRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+}
+
+func (m *LookupNodeRequest) Reset()                    { *m = LookupNodeRequest{} }
+func (m *LookupNodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*LookupNodeRequest) ProtoMessage()               {}
+func (*LookupNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *LookupNodeRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type LookupNodeResponse struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Alias of the node (empty when not set).
+	Alias string `protobuf:"bytes,3,opt,name=alias" json:"alias,omitempty"`
+	// ID of the application.
+	ApplicationID int64 `protobuf:"varint,4,opt,name=applicationID" json:"applicationID,omitempty"`
+	// Name of the application.
+	ApplicationName string `protobuf:"bytes,5,opt,name=applicationName" json:"applicationName,omitempty"`
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,6,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Name of the organization.
+	OrganizationName string `protobuf:"bytes,7,opt,name=organizationName" json:"organizationName,omitempty"`
+}
+
+func (m *LookupNodeResponse) Reset()                    { *m = LookupNodeResponse{} }
+func (m *LookupNodeResponse) String() string            { return proto.CompactTextString(m) }
+func (*LookupNodeResponse) ProtoMessage()               {}
+func (*LookupNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *LookupNodeResponse) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *LookupNodeResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LookupNodeResponse) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *LookupNodeResponse) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *LookupNodeResponse) GetApplicationName() string {
+	if m != nil {
+		return m.ApplicationName
+	}
+	return ""
+}
+
+func (m *LookupNodeResponse) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *LookupNodeResponse) GetOrganizationName() string {
+	if m != nil {
+		return m.OrganizationName
+	}
+	return ""
+}
+
+type NodeIntegrationRequest struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+}
+
+func (m *NodeIntegrationRequest) Reset()                    { *m = NodeIntegrationRequest{} }
+func (m *NodeIntegrationRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeIntegrationRequest) ProtoMessage()               {}
+func (*NodeIntegrationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *NodeIntegrationRequest) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+type NodeHTTPIntegration struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// The headers to use when making HTTP callbacks.
+	Headers []*HTTPIntegrationHeader `protobuf:"bytes,2,rep,name=headers" json:"headers,omitempty"`
+	// The URL to call for uplink data.
+	DataUpURL string `protobuf:"bytes,3,opt,name=dataUpURL" json:"dataUpURL,omitempty"`
+	// The URL to call for join notifications.
+	JoinNotificationURL string `protobuf:"bytes,4,opt,name=joinNotificationURL" json:"joinNotificationURL,omitempty"`
+	// The URL to call for ACK notifications (for confirmed downlink data).
+	AckNotificationURL string `protobuf:"bytes,5,opt,name=ackNotificationURL" json:"ackNotificationURL,omitempty"`
+	// The URL to call for error notifications.
+	ErrorNotificationURL string `protobuf:"bytes,6,opt,name=errorNotificationURL" json:"errorNotificationURL,omitempty"`
+	// The URL to call for security notifications.
+	SecurityNotificationURL string `protobuf:"bytes,7,opt,name=securityNotificationURL" json:"securityNotificationURL,omitempty"`
+	// Username for HTTP basic authentication (optional).
+	BasicAuthUsername string `protobuf:"bytes,8,opt,name=basicAuthUsername" json:"basicAuthUsername,omitempty"`
+	// Password for HTTP basic authentication (optional, stored encrypted,
+	// returned masked).
+	BasicAuthPassword string `protobuf:"bytes,9,opt,name=basicAuthPassword" json:"basicAuthPassword,omitempty"`
+	// Bearer token, sent as Authorization header (optional, stored
+	// encrypted, returned masked). Can not be combined with basic
+	// authentication.
+	BearerToken string `protobuf:"bytes,10,opt,name=bearerToken" json:"bearerToken,omitempty"`
+	// Max. number of delivery attempts of an event (0 - 10, 0 means the
+	// default of 3).
+	MaxAttempts uint32 `protobuf:"varint,11,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	// Shared secret for signing the request bodies (optional, stored
+	// encrypted, returned masked).
+	SigningSecret string `protobuf:"bytes,12,opt,name=signingSecret" json:"signingSecret,omitempty"`
+	// Max. size (in bytes) of the request bodies (0 means no limit, else at
+	// least 512).
+	MaxPayloadSize uint32 `protobuf:"varint,13,opt,name=maxPayloadSize" json:"maxPayloadSize,omitempty"`
+	// Encoding of the request bodies.
+	Marshaler IntegrationMarshaler `protobuf:"varint,14,opt,name=marshaler,enum=api.IntegrationMarshaler" json:"marshaler,omitempty"`
+}
+
+func (m *NodeHTTPIntegration) Reset()                    { *m = NodeHTTPIntegration{} }
+func (m *NodeHTTPIntegration) String() string            { return proto.CompactTextString(m) }
+func (*NodeHTTPIntegration) ProtoMessage()               {}
+func (*NodeHTTPIntegration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *NodeHTTPIntegration) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetHeaders() []*HTTPIntegrationHeader {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *NodeHTTPIntegration) GetDataUpURL() string {
+	if m != nil {
+		return m.DataUpURL
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetJoinNotificationURL() string {
+	if m != nil {
+		return m.JoinNotificationURL
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetAckNotificationURL() string {
+	if m != nil {
+		return m.AckNotificationURL
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetErrorNotificationURL() string {
+	if m != nil {
+		return m.ErrorNotificationURL
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetSecurityNotificationURL() string {
+	if m != nil {
+		return m.SecurityNotificationURL
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetBasicAuthUsername() string {
+	if m != nil {
+		return m.BasicAuthUsername
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetBasicAuthPassword() string {
+	if m != nil {
+		return m.BasicAuthPassword
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetBearerToken() string {
+	if m != nil {
+		return m.BearerToken
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *NodeHTTPIntegration) GetSigningSecret() string {
+	if m != nil {
+		return m.SigningSecret
+	}
+	return ""
+}
+
+func (m *NodeHTTPIntegration) GetMaxPayloadSize() uint32 {
+	if m != nil {
+		return m.MaxPayloadSize
+	}
+	return 0
+}
+
+func (m *NodeHTTPIntegration) GetMarshaler() IntegrationMarshaler {
+	if m != nil {
+		return m.Marshaler
+	}
+	return IntegrationMarshaler_JSON
+}
+
+type CreateNodeHTTPIntegrationResponse struct {
+}
+
+func (m *CreateNodeHTTPIntegrationResponse) Reset()         { *m = CreateNodeHTTPIntegrationResponse{} }
+func (m *CreateNodeHTTPIntegrationResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateNodeHTTPIntegrationResponse) ProtoMessage()    {}
+func (*CreateNodeHTTPIntegrationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{70}
+}
+
+type UpdateNodeHTTPIntegrationResponse struct {
+}
+
+func (m *UpdateNodeHTTPIntegrationResponse) Reset()         { *m = UpdateNodeHTTPIntegrationResponse{} }
+func (m *UpdateNodeHTTPIntegrationResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeHTTPIntegrationResponse) ProtoMessage()    {}
+func (*UpdateNodeHTTPIntegrationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{71}
+}
+
+type DeleteNodeHTTPIntegrationResponse struct {
+}
+
+func (m *DeleteNodeHTTPIntegrationResponse) Reset()         { *m = DeleteNodeHTTPIntegrationResponse{} }
+func (m *DeleteNodeHTTPIntegrationResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteNodeHTTPIntegrationResponse) ProtoMessage()    {}
+func (*DeleteNodeHTTPIntegrationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{72}
+}
+
+type ListNodeIntegrationsResponse struct {
+	// The integration kinds configured for the node.
+	Kinds []IntegrationKind `protobuf:"varint,1,rep,packed,name=kinds,enum=api.IntegrationKind" json:"kinds,omitempty"`
+}
+
+func (m *ListNodeIntegrationsResponse) Reset()                    { *m = ListNodeIntegrationsResponse{} }
+func (m *ListNodeIntegrationsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListNodeIntegrationsResponse) ProtoMessage()               {}
+func (*ListNodeIntegrationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *ListNodeIntegrationsResponse) GetKinds() []IntegrationKind {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+// The failure simulation of a node-integration (see IntegrationChaos).
+type NodeIntegrationChaos struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Percentage (0 - 100) of the deliveries that fail.
+	FailureRate uint32 `protobuf:"varint,2,opt,name=failureRate" json:"failureRate,omitempty"`
+	// Latency added to each delivery in milliseconds (max. 60000).
+	Latency uint32 `protobuf:"varint,3,opt,name=latency" json:"latency,omitempty"`
+	// End of the failure simulation (RFC3339, max. 24 hours ahead). Required
+	// when a failure rate or latency is set.
+	Until string `protobuf:"bytes,4,opt,name=until" json:"until,omitempty"`
+	// The failure simulation is active (enabled and the end has not passed).
+	Active bool `protobuf:"varint,5,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *NodeIntegrationChaos) Reset()                    { *m = NodeIntegrationChaos{} }
+func (m *NodeIntegrationChaos) String() string            { return proto.CompactTextString(m) }
+func (*NodeIntegrationChaos) ProtoMessage()               {}
+func (*NodeIntegrationChaos) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+func (m *NodeIntegrationChaos) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeIntegrationChaos) GetFailureRate() uint32 {
+	if m != nil {
+		return m.FailureRate
+	}
+	return 0
+}
+
+func (m *NodeIntegrationChaos) GetLatency() uint32 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *NodeIntegrationChaos) GetUntil() string {
+	if m != nil {
+		return m.Until
+	}
+	return ""
+}
+
+func (m *NodeIntegrationChaos) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type UpdateNodeIntegrationChaosResponse struct {
+}
+
+func (m *UpdateNodeIntegrationChaosResponse) Reset()         { *m = UpdateNodeIntegrationChaosResponse{} }
+func (m *UpdateNodeIntegrationChaosResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeIntegrationChaosResponse) ProtoMessage()    {}
+func (*UpdateNodeIntegrationChaosResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{75}
+}
+
+// The event filter of a node-integration (see IntegrationFilter).
+type NodeIntegrationFilter struct {
+	// Hex encoded DevEUI of the node.
+	DevEUI string `protobuf:"bytes,1,opt,name=devEUI" json:"devEUI,omitempty"`
+	// Event types to forward (up, join, ack, error, security, proprietary
+	// or custom).
+	EventTypes []string `protobuf:"bytes,2,rep,name=eventTypes" json:"eventTypes,omitempty"`
+	// fPorts of the uplinks to forward (1 - 255). Does not apply to the
+	// other event types.
+	FPorts []uint32 `protobuf:"varint,3,rep,packed,name=fPorts" json:"fPorts,omitempty"`
+	// Tags which the node must all have.
+	Tags []string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *NodeIntegrationFilter) Reset()                    { *m = NodeIntegrationFilter{} }
+func (m *NodeIntegrationFilter) String() string            { return proto.CompactTextString(m) }
+func (*NodeIntegrationFilter) ProtoMessage()               {}
+func (*NodeIntegrationFilter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+func (m *NodeIntegrationFilter) GetDevEUI() string {
+	if m != nil {
+		return m.DevEUI
+	}
+	return ""
+}
+
+func (m *NodeIntegrationFilter) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *NodeIntegrationFilter) GetFPorts() []uint32 {
+	if m != nil {
+		return m.FPorts
+	}
+	return nil
+}
+
+func (m *NodeIntegrationFilter) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type UpdateNodeIntegrationFilterResponse struct {
+}
+
+func (m *UpdateNodeIntegrationFilterResponse) Reset()         { *m = UpdateNodeIntegrationFilterResponse{} }
+func (m *UpdateNodeIntegrationFilterResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeIntegrationFilterResponse) ProtoMessage()    {}
+func (*UpdateNodeIntegrationFilterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{77}
+}
+
+func init() {
+	proto.RegisterType((*CreateNodeRequest)(nil), "api.CreateNodeRequest")
+	proto.RegisterType((*CreateNodeResponse)(nil), "api.CreateNodeResponse")
+	proto.RegisterType((*NodeVariable)(nil), "api.NodeVariable")
+	proto.RegisterType((*GetNodeRequest)(nil), "api.GetNodeRequest")
+	proto.RegisterType((*GetNodeResponse)(nil), "api.GetNodeResponse")
+	proto.RegisterType((*DeleteNodeRequest)(nil), "api.DeleteNodeRequest")
+	proto.RegisterType((*DeleteNodeResponse)(nil), "api.DeleteNodeResponse")
+	proto.RegisterType((*DecommissionNodeRequest)(nil), "api.DecommissionNodeRequest")
+	proto.RegisterType((*DecommissionNodeResponse)(nil), "api.DecommissionNodeResponse")
+	proto.RegisterType((*ListNodeByApplicationIDRequest)(nil), "api.ListNodeByApplicationIDRequest")
+	proto.RegisterType((*ListNodeResponse)(nil), "api.ListNodeResponse")
+	proto.RegisterType((*UpdateNodeRequest)(nil), "api.UpdateNodeRequest")
+	proto.RegisterType((*UpdateNodeResponse)(nil), "api.UpdateNodeResponse")
+	proto.RegisterType((*CreateNodeBatchRequest)(nil), "api.CreateNodeBatchRequest")
+	proto.RegisterType((*CreateNodeBatchResponse)(nil), "api.CreateNodeBatchResponse")
+	proto.RegisterType((*UpdateNodeBatchRequest)(nil), "api.UpdateNodeBatchRequest")
+	proto.RegisterType((*UpdateNodeBatchResponse)(nil), "api.UpdateNodeBatchResponse")
+	proto.RegisterType((*NodeBatchResult)(nil), "api.NodeBatchResult")
+	proto.RegisterType((*ActivateNodeRequest)(nil), "api.ActivateNodeRequest")
+	proto.RegisterType((*ActivateNodeResponse)(nil), "api.ActivateNodeResponse")
+	proto.RegisterType((*GetNodeActivationRequest)(nil), "api.GetNodeActivationRequest")
+	proto.RegisterType((*GetNodeActivationResponse)(nil), "api.GetNodeActivationResponse")
+	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "api.GetRandomDevAddrRequest")
+	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "api.GetRandomDevAddrResponse")
+	proto.RegisterType((*GetFrameLogsRequest)(nil), "api.GetFrameLogsRequest")
+	proto.RegisterType((*GetFrameLogsResponse)(nil), "api.GetFrameLogsResponse")
+	proto.RegisterType((*GetNextNodeEventRequest)(nil), "api.GetNextNodeEventRequest")
+	proto.RegisterType((*GetNextNodeEventResponse)(nil), "api.GetNextNodeEventResponse")
+	proto.RegisterType((*FrameLog)(nil), "api.FrameLog")
+	proto.RegisterType((*DataRate)(nil), "api.DataRate")
+	proto.RegisterType((*RXInfo)(nil), "api.RXInfo")
+	proto.RegisterType((*TXInfo)(nil), "api.TXInfo")
+	proto.RegisterType((*GetNodeDownlinkAirtimeRequest)(nil), "api.GetNodeDownlinkAirtimeRequest")
+	proto.RegisterType((*GetNodeDownlinkAirtimeResponse)(nil), "api.GetNodeDownlinkAirtimeResponse")
+	proto.RegisterType((*LinkQuality)(nil), "api.LinkQuality")
+	proto.RegisterType((*LinkQualityBucket)(nil), "api.LinkQualityBucket")
+	proto.RegisterType((*GetNodeLinkQualityRequest)(nil), "api.GetNodeLinkQualityRequest")
+	proto.RegisterType((*GetNodeLinkQualityResponse)(nil), "api.GetNodeLinkQualityResponse")
+	proto.RegisterType((*ListNodeLinkQualityRequest)(nil), "api.ListNodeLinkQualityRequest")
+	proto.RegisterType((*NodeLinkQuality)(nil), "api.NodeLinkQuality")
+	proto.RegisterType((*ListNodeLinkQualityResponse)(nil), "api.ListNodeLinkQualityResponse")
+	proto.RegisterType((*Availability)(nil), "api.Availability")
+	proto.RegisterType((*GetNodeAvailabilityRequest)(nil), "api.GetNodeAvailabilityRequest")
+	proto.RegisterType((*GetNodeAvailabilityResponse)(nil), "api.GetNodeAvailabilityResponse")
+	proto.RegisterType((*ListNodeAvailabilityRequest)(nil), "api.ListNodeAvailabilityRequest")
+	proto.RegisterType((*NodeAvailability)(nil), "api.NodeAvailability")
+	proto.RegisterType((*ListNodeAvailabilityResponse)(nil), "api.ListNodeAvailabilityResponse")
+	proto.RegisterType((*NodeLastValue)(nil), "api.NodeLastValue")
+	proto.RegisterType((*GetNodeLastValuesRequest)(nil), "api.GetNodeLastValuesRequest")
+	proto.RegisterType((*GetNodeLastValuesResponse)(nil), "api.GetNodeLastValuesResponse")
+	proto.RegisterType((*ListNodeLastValuesRequest)(nil), "api.ListNodeLastValuesRequest")
+	proto.RegisterType((*NodeLastValues)(nil), "api.NodeLastValues")
+	proto.RegisterType((*ListNodeLastValuesResponse)(nil), "api.ListNodeLastValuesResponse")
+	proto.RegisterType((*BulkNodeTagsRequest)(nil), "api.BulkNodeTagsRequest")
+	proto.RegisterType((*BulkNodeTagsResponse)(nil), "api.BulkNodeTagsResponse")
+	proto.RegisterType((*GetNodeEffectiveConfigRequest)(nil), "api.GetNodeEffectiveConfigRequest")
+	proto.RegisterType((*NodeNetworkSettings)(nil), "api.NodeNetworkSettings")
+	proto.RegisterType((*NodeIntegrationRoute)(nil), "api.NodeIntegrationRoute")
+	proto.RegisterType((*GetNodeEffectiveConfigResponse)(nil), "api.GetNodeEffectiveConfigResponse")
+	proto.RegisterType((*GetNodeMaintenanceRequest)(nil), "api.GetNodeMaintenanceRequest")
+	proto.RegisterType((*NodeMaintenance)(nil), "api.NodeMaintenance")
+	proto.RegisterType((*UpdateNodeMaintenanceResponse)(nil), "api.UpdateNodeMaintenanceResponse")
+	proto.RegisterType((*GetNodeAliasRequest)(nil), "api.GetNodeAliasRequest")
+	proto.RegisterType((*NodeAlias)(nil), "api.NodeAlias")
+	proto.RegisterType((*UpdateNodeAliasResponse)(nil), "api.UpdateNodeAliasResponse")
+	proto.RegisterType((*GetNodeByAliasRequest)(nil), "api.GetNodeByAliasRequest")
+	proto.RegisterType((*LookupNodeRequest)(nil), "api.LookupNodeRequest")
+	proto.RegisterType((*LookupNodeResponse)(nil), "api.LookupNodeResponse")
+	proto.RegisterType((*NodeIntegrationRequest)(nil), "api.NodeIntegrationRequest")
+	proto.RegisterType((*NodeHTTPIntegration)(nil), "api.NodeHTTPIntegration")
+	proto.RegisterType((*CreateNodeHTTPIntegrationResponse)(nil), "api.CreateNodeHTTPIntegrationResponse")
+	proto.RegisterType((*UpdateNodeHTTPIntegrationResponse)(nil), "api.UpdateNodeHTTPIntegrationResponse")
+	proto.RegisterType((*DeleteNodeHTTPIntegrationResponse)(nil), "api.DeleteNodeHTTPIntegrationResponse")
+	proto.RegisterType((*ListNodeIntegrationsResponse)(nil), "api.ListNodeIntegrationsResponse")
+	proto.RegisterType((*NodeIntegrationChaos)(nil), "api.NodeIntegrationChaos")
+	proto.RegisterType((*UpdateNodeIntegrationChaosResponse)(nil), "api.UpdateNodeIntegrationChaosResponse")
+	proto.RegisterType((*NodeIntegrationFilter)(nil), "api.NodeIntegrationFilter")
+	proto.RegisterType((*UpdateNodeIntegrationFilterResponse)(nil), "api.UpdateNodeIntegrationFilterResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Node service
+
+type NodeClient interface {
+	// Create creates the given node.
+	Create(ctx context.Context, in *CreateNodeRequest, opts ...grpc.CallOption) (*CreateNodeResponse, error)
+	// CreateBatch creates the given nodes within a single transaction.
+	// The result of each node is reported in the response. A node failing
+	// to be created does not affect the other nodes of the batch.
+	CreateBatch(ctx context.Context, in *CreateNodeBatchRequest, opts ...grpc.CallOption) (*CreateNodeBatchResponse, error)
+	// UpdateBatch updates the given nodes within a single transaction.
+	// The result of each node is reported in the response. A node failing
+	// to be updated does not affect the other nodes of the batch.
+	UpdateBatch(ctx context.Context, in *UpdateNodeBatchRequest, opts ...grpc.CallOption) (*UpdateNodeBatchResponse, error)
+	// Get returns the node for the requested DevEUI.
+	Get(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
+	// Delete deletes the node matching the given DevEUI.
+	Delete(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error)
+	// Decommission retires the node as a single operation: it disables the
+	// node, flushes its downlink queue, deletes its node-session from the
+	// network-server and (optionally) deletes its history.
+	Decommission(ctx context.Context, in *DecommissionNodeRequest, opts ...grpc.CallOption) (*DecommissionNodeResponse, error)
+	// ListByApplicationID lists the nodes by the given application ID, sorted by the name of the node.
+	ListByApplicationID(ctx context.Context, in *ListNodeByApplicationIDRequest, opts ...grpc.CallOption) (*ListNodeResponse, error)
+	// Update updates the node matching the given DevEUI.
+	Update(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error)
+	// Patch updates the fields listed in updateMask of the node matching
+	// the given DevEUI. Other fields are left untouched.
+	Patch(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error)
+	// Activate (re)activates the node (only when ABP is set to true).
+	Activate(ctx context.Context, in *ActivateNodeRequest, opts ...grpc.CallOption) (*ActivateNodeResponse, error)
+	// GetActivation returns the current activation details of the node (OTAA and ABP).
+	GetActivation(ctx context.Context, in *GetNodeActivationRequest, opts ...grpc.CallOption) (*GetNodeActivationResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
+	// GetFrameLogs returns the uplink / downlink frame log for the given DevEUI.
+	GetFrameLogs(ctx context.Context, in *GetFrameLogsRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error)
+	// GetNextEvent waits for the next event (uplink, join, ack or error) of
+	// the given DevEUI and returns it (long-poll). An empty response is
+	// returned when no event was received within the given timeout.
+	GetNextEvent(ctx context.Context, in *GetNextNodeEventRequest, opts ...grpc.CallOption) (*GetNextNodeEventResponse, error)
+	// GetDownlinkAirtime returns the downlink airtime used by the node
+	// within the current window (hour) and the configured budget.
+	GetDownlinkAirtime(ctx context.Context, in *GetNodeDownlinkAirtimeRequest, opts ...grpc.CallOption) (*GetNodeDownlinkAirtimeResponse, error)
+	// GetLinkQuality returns the link-quality score of the node and its
+	// hourly history.
+	GetLinkQuality(ctx context.Context, in *GetNodeLinkQualityRequest, opts ...grpc.CallOption) (*GetNodeLinkQualityResponse, error)
+	// ListLinkQuality returns the nodes of the given application, ranked
+	// by link-quality score (worst first).
+	ListLinkQuality(ctx context.Context, in *ListNodeLinkQualityRequest, opts ...grpc.CallOption) (*ListNodeLinkQualityResponse, error)
+	// GetAvailability returns the availability of the node (received vs
+	// expected uplinks).
+	GetAvailability(ctx context.Context, in *GetNodeAvailabilityRequest, opts ...grpc.CallOption) (*GetNodeAvailabilityResponse, error)
+	// ListAvailability returns the availability of the nodes of the given
+	// application (worst first) and the availability of the application.
+	ListAvailability(ctx context.Context, in *ListNodeAvailabilityRequest, opts ...grpc.CallOption) (*ListNodeAvailabilityResponse, error)
+	// GetLastValues returns the last received payload of the node per
+	// fPort.
+	GetLastValues(ctx context.Context, in *GetNodeLastValuesRequest, opts ...grpc.CallOption) (*GetNodeLastValuesResponse, error)
+	// ListLastValues returns the last received payloads of the nodes of
+	// the given application.
+	ListLastValues(ctx context.Context, in *ListNodeLastValuesRequest, opts ...grpc.CallOption) (*ListNodeLastValuesResponse, error)
+	// AddTags adds the given tags to the nodes of the given application
+	// matching the filter.
+	AddTags(ctx context.Context, in *BulkNodeTagsRequest, opts ...grpc.CallOption) (*BulkNodeTagsResponse, error)
+	// RemoveTags removes the given tags from the nodes of the given
+	// application matching the filter.
+	RemoveTags(ctx context.Context, in *BulkNodeTagsRequest, opts ...grpc.CallOption) (*BulkNodeTagsResponse, error)
+	// GetEffectiveConfig returns the effective configuration of the node,
+	// merging the node and application settings and the integrations
+	// receiving the events of the node.
+	GetEffectiveConfig(ctx context.Context, in *GetNodeEffectiveConfigRequest, opts ...grpc.CallOption) (*GetNodeEffectiveConfigResponse, error)
+	// GetMaintenance returns the maintenance mode of the node.
+	GetMaintenance(ctx context.Context, in *GetNodeMaintenanceRequest, opts ...grpc.CallOption) (*NodeMaintenance, error)
+	// UpdateMaintenance updates the maintenance mode of the node.
+	UpdateMaintenance(ctx context.Context, in *NodeMaintenance, opts ...grpc.CallOption) (*UpdateNodeMaintenanceResponse, error)
+	// GetAlias returns the alias of the node.
+	GetAlias(ctx context.Context, in *GetNodeAliasRequest, opts ...grpc.CallOption) (*NodeAlias, error)
+	// UpdateAlias sets (or removes, when empty) the alias of the node. The
+	// alias must be unique within the organization.
+	UpdateAlias(ctx context.Context, in *NodeAlias, opts ...grpc.CallOption) (*UpdateNodeAliasResponse, error)
+	// GetByAlias returns the node of the organization matching the given alias.
+	GetByAlias(ctx context.Context, in *GetNodeByAliasRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
+	// Lookup returns the organization and application of the node with the
+	// given DevEUI, across all organizations (global admin only).
+	Lookup(ctx context.Context, in *LookupNodeRequest, opts ...grpc.CallOption) (*LookupNodeResponse, error)
+	// CreateHTTPIntegration creates an HTTP node-integration, forwarding the
+	// events of the node to an extra endpoint (next to the integrations of
+	// the application).
+	CreateHTTPIntegration(ctx context.Context, in *NodeHTTPIntegration, opts ...grpc.CallOption) (*CreateNodeHTTPIntegrationResponse, error)
+	// GetHTTPIntegration returns the HTTP node-integration.
+	GetHTTPIntegration(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*NodeHTTPIntegration, error)
+	// UpdateHTTPIntegration updates the HTTP node-integration.
+	UpdateHTTPIntegration(ctx context.Context, in *NodeHTTPIntegration, opts ...grpc.CallOption) (*UpdateNodeHTTPIntegrationResponse, error)
+	// DeleteHTTPIntegration deletes the HTTP node-integration.
+	DeleteHTTPIntegration(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*DeleteNodeHTTPIntegrationResponse, error)
+	// ListIntegrations lists the integrations configured for the node.
+	ListIntegrations(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*ListNodeIntegrationsResponse, error)
+	// GetHTTPIntegrationChaos returns the failure simulation of the HTTP
+	// node-integration.
+	GetHTTPIntegrationChaos(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*NodeIntegrationChaos, error)
+	// UpdateHTTPIntegrationChaos updates the failure simulation of the HTTP
+	// node-integration (global admin users only).
+	UpdateHTTPIntegrationChaos(ctx context.Context, in *NodeIntegrationChaos, opts ...grpc.CallOption) (*UpdateNodeIntegrationChaosResponse, error)
+	// GetHTTPIntegrationFilter returns the event filter of the HTTP
+	// node-integration.
+	GetHTTPIntegrationFilter(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*NodeIntegrationFilter, error)
+	// UpdateHTTPIntegrationFilter updates the event filter of the HTTP
+	// node-integration.
+	UpdateHTTPIntegrationFilter(ctx context.Context, in *NodeIntegrationFilter, opts ...grpc.CallOption) (*UpdateNodeIntegrationFilterResponse, error)
+	// GetHTTPIntegrationHealth returns the delivery health of the HTTP
+	// node-integration.
+	GetHTTPIntegrationHealth(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*IntegrationHealth, error)
+}
+
+type nodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeClient(cc *grpc.ClientConn) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) Create(ctx context.Context, in *CreateNodeRequest, opts ...grpc.CallOption) (*CreateNodeResponse, error) {
+	out := new(CreateNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) CreateBatch(ctx context.Context, in *CreateNodeBatchRequest, opts ...grpc.CallOption) (*CreateNodeBatchResponse, error) {
+	out := new(CreateNodeBatchResponse)
+	err := grpc.Invoke(ctx, "/api.Node/CreateBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) UpdateBatch(ctx context.Context, in *UpdateNodeBatchRequest, opts ...grpc.CallOption) (*UpdateNodeBatchResponse, error) {
+	out := new(UpdateNodeBatchResponse)
+	err := grpc.Invoke(ctx, "/api.Node/UpdateBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Get(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
+	out := new(GetNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Delete(ctx context.Context, in *DeleteNodeRequest, opts ...grpc.CallOption) (*DeleteNodeResponse, error) {
+	out := new(DeleteNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Decommission(ctx context.Context, in *DecommissionNodeRequest, opts ...grpc.CallOption) (*DecommissionNodeResponse, error) {
+	out := new(DecommissionNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Decommission", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *nodeClient) ListByApplicationID(ctx context.Context, in *ListNodeByApplicationIDRequest, opts ...grpc.CallOption) (*ListNodeResponse, error) {
@@ -1306,239 +3569,1140 @@ func (c *nodeClient) ListByApplicationID(ctx context.Context, in *ListNodeByAppl
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *nodeClient) Update(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error) {
+	out := new(UpdateNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Patch(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error) {
+	out := new(UpdateNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Patch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Activate(ctx context.Context, in *ActivateNodeRequest, opts ...grpc.CallOption) (*ActivateNodeResponse, error) {
+	out := new(ActivateNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Activate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetActivation(ctx context.Context, in *GetNodeActivationRequest, opts ...grpc.CallOption) (*GetNodeActivationResponse, error) {
+	out := new(GetNodeActivationResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetActivation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
+	out := new(GetRandomDevAddrResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetRandomDevAddr", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetFrameLogs(ctx context.Context, in *GetFrameLogsRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error) {
+	out := new(GetFrameLogsResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetFrameLogs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetNextEvent(ctx context.Context, in *GetNextNodeEventRequest, opts ...grpc.CallOption) (*GetNextNodeEventResponse, error) {
+	out := new(GetNextNodeEventResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetNextEvent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetDownlinkAirtime(ctx context.Context, in *GetNodeDownlinkAirtimeRequest, opts ...grpc.CallOption) (*GetNodeDownlinkAirtimeResponse, error) {
+	out := new(GetNodeDownlinkAirtimeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetDownlinkAirtime", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetLinkQuality(ctx context.Context, in *GetNodeLinkQualityRequest, opts ...grpc.CallOption) (*GetNodeLinkQualityResponse, error) {
+	out := new(GetNodeLinkQualityResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetLinkQuality", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListLinkQuality(ctx context.Context, in *ListNodeLinkQualityRequest, opts ...grpc.CallOption) (*ListNodeLinkQualityResponse, error) {
+	out := new(ListNodeLinkQualityResponse)
+	err := grpc.Invoke(ctx, "/api.Node/ListLinkQuality", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetAvailability(ctx context.Context, in *GetNodeAvailabilityRequest, opts ...grpc.CallOption) (*GetNodeAvailabilityResponse, error) {
+	out := new(GetNodeAvailabilityResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetAvailability", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListAvailability(ctx context.Context, in *ListNodeAvailabilityRequest, opts ...grpc.CallOption) (*ListNodeAvailabilityResponse, error) {
+	out := new(ListNodeAvailabilityResponse)
+	err := grpc.Invoke(ctx, "/api.Node/ListAvailability", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetLastValues(ctx context.Context, in *GetNodeLastValuesRequest, opts ...grpc.CallOption) (*GetNodeLastValuesResponse, error) {
+	out := new(GetNodeLastValuesResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetLastValues", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListLastValues(ctx context.Context, in *ListNodeLastValuesRequest, opts ...grpc.CallOption) (*ListNodeLastValuesResponse, error) {
+	out := new(ListNodeLastValuesResponse)
+	err := grpc.Invoke(ctx, "/api.Node/ListLastValues", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) AddTags(ctx context.Context, in *BulkNodeTagsRequest, opts ...grpc.CallOption) (*BulkNodeTagsResponse, error) {
+	out := new(BulkNodeTagsResponse)
+	err := grpc.Invoke(ctx, "/api.Node/AddTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) RemoveTags(ctx context.Context, in *BulkNodeTagsRequest, opts ...grpc.CallOption) (*BulkNodeTagsResponse, error) {
+	out := new(BulkNodeTagsResponse)
+	err := grpc.Invoke(ctx, "/api.Node/RemoveTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetEffectiveConfig(ctx context.Context, in *GetNodeEffectiveConfigRequest, opts ...grpc.CallOption) (*GetNodeEffectiveConfigResponse, error) {
+	out := new(GetNodeEffectiveConfigResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetEffectiveConfig", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetMaintenance(ctx context.Context, in *GetNodeMaintenanceRequest, opts ...grpc.CallOption) (*NodeMaintenance, error) {
+	out := new(NodeMaintenance)
+	err := grpc.Invoke(ctx, "/api.Node/GetMaintenance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) UpdateMaintenance(ctx context.Context, in *NodeMaintenance, opts ...grpc.CallOption) (*UpdateNodeMaintenanceResponse, error) {
+	out := new(UpdateNodeMaintenanceResponse)
+	err := grpc.Invoke(ctx, "/api.Node/UpdateMaintenance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetAlias(ctx context.Context, in *GetNodeAliasRequest, opts ...grpc.CallOption) (*NodeAlias, error) {
+	out := new(NodeAlias)
+	err := grpc.Invoke(ctx, "/api.Node/GetAlias", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) UpdateAlias(ctx context.Context, in *NodeAlias, opts ...grpc.CallOption) (*UpdateNodeAliasResponse, error) {
+	out := new(UpdateNodeAliasResponse)
+	err := grpc.Invoke(ctx, "/api.Node/UpdateAlias", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetByAlias(ctx context.Context, in *GetNodeByAliasRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
+	out := new(GetNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/GetByAlias", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) Lookup(ctx context.Context, in *LookupNodeRequest, opts ...grpc.CallOption) (*LookupNodeResponse, error) {
+	out := new(LookupNodeResponse)
+	err := grpc.Invoke(ctx, "/api.Node/Lookup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) CreateHTTPIntegration(ctx context.Context, in *NodeHTTPIntegration, opts ...grpc.CallOption) (*CreateNodeHTTPIntegrationResponse, error) {
+	out := new(CreateNodeHTTPIntegrationResponse)
+	err := grpc.Invoke(ctx, "/api.Node/CreateHTTPIntegration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetHTTPIntegration(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*NodeHTTPIntegration, error) {
+	out := new(NodeHTTPIntegration)
+	err := grpc.Invoke(ctx, "/api.Node/GetHTTPIntegration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) UpdateHTTPIntegration(ctx context.Context, in *NodeHTTPIntegration, opts ...grpc.CallOption) (*UpdateNodeHTTPIntegrationResponse, error) {
+	out := new(UpdateNodeHTTPIntegrationResponse)
+	err := grpc.Invoke(ctx, "/api.Node/UpdateHTTPIntegration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) DeleteHTTPIntegration(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*DeleteNodeHTTPIntegrationResponse, error) {
+	out := new(DeleteNodeHTTPIntegrationResponse)
+	err := grpc.Invoke(ctx, "/api.Node/DeleteHTTPIntegration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListIntegrations(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*ListNodeIntegrationsResponse, error) {
+	out := new(ListNodeIntegrationsResponse)
+	err := grpc.Invoke(ctx, "/api.Node/ListIntegrations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetHTTPIntegrationChaos(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*NodeIntegrationChaos, error) {
+	out := new(NodeIntegrationChaos)
+	err := grpc.Invoke(ctx, "/api.Node/GetHTTPIntegrationChaos", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) UpdateHTTPIntegrationChaos(ctx context.Context, in *NodeIntegrationChaos, opts ...grpc.CallOption) (*UpdateNodeIntegrationChaosResponse, error) {
+	out := new(UpdateNodeIntegrationChaosResponse)
+	err := grpc.Invoke(ctx, "/api.Node/UpdateHTTPIntegrationChaos", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetHTTPIntegrationFilter(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*NodeIntegrationFilter, error) {
+	out := new(NodeIntegrationFilter)
+	err := grpc.Invoke(ctx, "/api.Node/GetHTTPIntegrationFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) UpdateHTTPIntegrationFilter(ctx context.Context, in *NodeIntegrationFilter, opts ...grpc.CallOption) (*UpdateNodeIntegrationFilterResponse, error) {
+	out := new(UpdateNodeIntegrationFilterResponse)
+	err := grpc.Invoke(ctx, "/api.Node/UpdateHTTPIntegrationFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetHTTPIntegrationHealth(ctx context.Context, in *NodeIntegrationRequest, opts ...grpc.CallOption) (*IntegrationHealth, error) {
+	out := new(IntegrationHealth)
+	err := grpc.Invoke(ctx, "/api.Node/GetHTTPIntegrationHealth", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Node service
+
+type NodeServer interface {
+	// Create creates the given node.
+	Create(context.Context, *CreateNodeRequest) (*CreateNodeResponse, error)
+	// CreateBatch creates the given nodes within a single transaction.
+	// The result of each node is reported in the response. A node failing
+	// to be created does not affect the other nodes of the batch.
+	CreateBatch(context.Context, *CreateNodeBatchRequest) (*CreateNodeBatchResponse, error)
+	// UpdateBatch updates the given nodes within a single transaction.
+	// The result of each node is reported in the response. A node failing
+	// to be updated does not affect the other nodes of the batch.
+	UpdateBatch(context.Context, *UpdateNodeBatchRequest) (*UpdateNodeBatchResponse, error)
+	// Get returns the node for the requested DevEUI.
+	Get(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
+	// Delete deletes the node matching the given DevEUI.
+	Delete(context.Context, *DeleteNodeRequest) (*DeleteNodeResponse, error)
+	// Decommission retires the node as a single operation: it disables the
+	// node, flushes its downlink queue, deletes its node-session from the
+	// network-server and (optionally) deletes its history.
+	Decommission(context.Context, *DecommissionNodeRequest) (*DecommissionNodeResponse, error)
+	// ListByApplicationID lists the nodes by the given application ID, sorted by the name of the node.
+	ListByApplicationID(context.Context, *ListNodeByApplicationIDRequest) (*ListNodeResponse, error)
+	// Update updates the node matching the given DevEUI.
+	Update(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error)
+	// Patch updates the fields listed in updateMask of the node matching
+	// the given DevEUI. Other fields are left untouched.
+	Patch(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error)
+	// Activate (re)activates the node (only when ABP is set to true).
+	Activate(context.Context, *ActivateNodeRequest) (*ActivateNodeResponse, error)
+	// GetActivation returns the current activation details of the node (OTAA and ABP).
+	GetActivation(context.Context, *GetNodeActivationRequest) (*GetNodeActivationResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
+	// GetFrameLogs returns the uplink / downlink frame log for the given DevEUI.
+	GetFrameLogs(context.Context, *GetFrameLogsRequest) (*GetFrameLogsResponse, error)
+	// GetNextEvent waits for the next event (uplink, join, ack or error) of
+	// the given DevEUI and returns it (long-poll). An empty response is
+	// returned when no event was received within the given timeout.
+	GetNextEvent(context.Context, *GetNextNodeEventRequest) (*GetNextNodeEventResponse, error)
+	// GetDownlinkAirtime returns the downlink airtime used by the node
+	// within the current window (hour) and the configured budget.
+	GetDownlinkAirtime(context.Context, *GetNodeDownlinkAirtimeRequest) (*GetNodeDownlinkAirtimeResponse, error)
+	// GetLinkQuality returns the link-quality score of the node and its
+	// hourly history.
+	GetLinkQuality(context.Context, *GetNodeLinkQualityRequest) (*GetNodeLinkQualityResponse, error)
+	// ListLinkQuality returns the nodes of the given application, ranked
+	// by link-quality score (worst first).
+	ListLinkQuality(context.Context, *ListNodeLinkQualityRequest) (*ListNodeLinkQualityResponse, error)
+	// GetAvailability returns the availability of the node (received vs
+	// expected uplinks).
+	GetAvailability(context.Context, *GetNodeAvailabilityRequest) (*GetNodeAvailabilityResponse, error)
+	// ListAvailability returns the availability of the nodes of the given
+	// application (worst first) and the availability of the application.
+	ListAvailability(context.Context, *ListNodeAvailabilityRequest) (*ListNodeAvailabilityResponse, error)
+	// GetLastValues returns the last received payload of the node per
+	// fPort.
+	GetLastValues(context.Context, *GetNodeLastValuesRequest) (*GetNodeLastValuesResponse, error)
+	// ListLastValues returns the last received payloads of the nodes of
+	// the given application.
+	ListLastValues(context.Context, *ListNodeLastValuesRequest) (*ListNodeLastValuesResponse, error)
+	// AddTags adds the given tags to the nodes of the given application
+	// matching the filter.
+	AddTags(context.Context, *BulkNodeTagsRequest) (*BulkNodeTagsResponse, error)
+	// RemoveTags removes the given tags from the nodes of the given
+	// application matching the filter.
+	RemoveTags(context.Context, *BulkNodeTagsRequest) (*BulkNodeTagsResponse, error)
+	// GetEffectiveConfig returns the effective configuration of the node,
+	// merging the node and application settings and the integrations
+	// receiving the events of the node.
+	GetEffectiveConfig(context.Context, *GetNodeEffectiveConfigRequest) (*GetNodeEffectiveConfigResponse, error)
+	// GetMaintenance returns the maintenance mode of the node.
+	GetMaintenance(context.Context, *GetNodeMaintenanceRequest) (*NodeMaintenance, error)
+	// UpdateMaintenance updates the maintenance mode of the node.
+	UpdateMaintenance(context.Context, *NodeMaintenance) (*UpdateNodeMaintenanceResponse, error)
+	// GetAlias returns the alias of the node.
+	GetAlias(context.Context, *GetNodeAliasRequest) (*NodeAlias, error)
+	// UpdateAlias sets (or removes, when empty) the alias of the node. The
+	// alias must be unique within the organization.
+	UpdateAlias(context.Context, *NodeAlias) (*UpdateNodeAliasResponse, error)
+	// GetByAlias returns the node of the organization matching the given alias.
+	GetByAlias(context.Context, *GetNodeByAliasRequest) (*GetNodeResponse, error)
+	// Lookup returns the organization and application of the node with the
+	// given DevEUI, across all organizations (global admin only).
+	Lookup(context.Context, *LookupNodeRequest) (*LookupNodeResponse, error)
+	// CreateHTTPIntegration creates an HTTP node-integration, forwarding the
+	// events of the node to an extra endpoint (next to the integrations of
+	// the application).
+	CreateHTTPIntegration(context.Context, *NodeHTTPIntegration) (*CreateNodeHTTPIntegrationResponse, error)
+	// GetHTTPIntegration returns the HTTP node-integration.
+	GetHTTPIntegration(context.Context, *NodeIntegrationRequest) (*NodeHTTPIntegration, error)
+	// UpdateHTTPIntegration updates the HTTP node-integration.
+	UpdateHTTPIntegration(context.Context, *NodeHTTPIntegration) (*UpdateNodeHTTPIntegrationResponse, error)
+	// DeleteHTTPIntegration deletes the HTTP node-integration.
+	DeleteHTTPIntegration(context.Context, *NodeIntegrationRequest) (*DeleteNodeHTTPIntegrationResponse, error)
+	// ListIntegrations lists the integrations configured for the node.
+	ListIntegrations(context.Context, *NodeIntegrationRequest) (*ListNodeIntegrationsResponse, error)
+	// GetHTTPIntegrationChaos returns the failure simulation of the HTTP
+	// node-integration.
+	GetHTTPIntegrationChaos(context.Context, *NodeIntegrationRequest) (*NodeIntegrationChaos, error)
+	// UpdateHTTPIntegrationChaos updates the failure simulation of the HTTP
+	// node-integration (global admin users only).
+	UpdateHTTPIntegrationChaos(context.Context, *NodeIntegrationChaos) (*UpdateNodeIntegrationChaosResponse, error)
+	// GetHTTPIntegrationFilter returns the event filter of the HTTP
+	// node-integration.
+	GetHTTPIntegrationFilter(context.Context, *NodeIntegrationRequest) (*NodeIntegrationFilter, error)
+	// UpdateHTTPIntegrationFilter updates the event filter of the HTTP
+	// node-integration.
+	UpdateHTTPIntegrationFilter(context.Context, *NodeIntegrationFilter) (*UpdateNodeIntegrationFilterResponse, error)
+	// GetHTTPIntegrationHealth returns the delivery health of the HTTP
+	// node-integration.
+	GetHTTPIntegrationHealth(context.Context, *NodeIntegrationRequest) (*IntegrationHealth, error)
+}
+
+func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
+	s.RegisterService(&_Node_serviceDesc, srv)
+}
+
+func _Node_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Create(ctx, req.(*CreateNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_CreateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNodeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).CreateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/CreateBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).CreateBatch(ctx, req.(*CreateNodeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_UpdateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).UpdateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/UpdateBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).UpdateBatch(ctx, req.(*UpdateNodeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Get(ctx, req.(*GetNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Delete(ctx, req.(*DeleteNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Decommission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecommissionNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Decommission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Decommission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Decommission(ctx, req.(*DecommissionNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListByApplicationID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeByApplicationIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListByApplicationID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/ListByApplicationID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListByApplicationID(ctx, req.(*ListNodeByApplicationIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Update(ctx, req.(*UpdateNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Patch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Patch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Patch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Patch(ctx, req.(*UpdateNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Activate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Activate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Activate(ctx, req.(*ActivateNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeActivationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetActivation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetActivation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetActivation(ctx, req.(*GetNodeActivationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRandomDevAddrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetRandomDevAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetRandomDevAddr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetRandomDevAddr(ctx, req.(*GetRandomDevAddrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetFrameLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrameLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetFrameLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetFrameLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetFrameLogs(ctx, req.(*GetFrameLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetNextEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextNodeEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetNextEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetNextEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetNextEvent(ctx, req.(*GetNextNodeEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetDownlinkAirtime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeDownlinkAirtimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetDownlinkAirtime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetDownlinkAirtime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetDownlinkAirtime(ctx, req.(*GetNodeDownlinkAirtimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetLinkQuality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeLinkQualityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetLinkQuality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetLinkQuality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetLinkQuality(ctx, req.(*GetNodeLinkQualityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListLinkQuality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeLinkQualityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListLinkQuality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/ListLinkQuality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListLinkQuality(ctx, req.(*ListNodeLinkQualityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetAvailability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetAvailability(ctx, req.(*GetNodeAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/ListAvailability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListAvailability(ctx, req.(*ListNodeAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetLastValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeLastValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetLastValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetLastValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetLastValues(ctx, req.(*GetNodeLastValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListLastValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeLastValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListLastValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/ListLastValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListLastValues(ctx, req.(*ListNodeLastValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkNodeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/AddTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).AddTags(ctx, req.(*BulkNodeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nodeClient) Update(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error) {
-	out := new(UpdateNodeResponse)
-	err := grpc.Invoke(ctx, "/api.Node/Update", in, out, c.cc, opts...)
-	if err != nil {
+func _Node_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkNodeTagsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NodeServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/RemoveTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).RemoveTags(ctx, req.(*BulkNodeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nodeClient) Activate(ctx context.Context, in *ActivateNodeRequest, opts ...grpc.CallOption) (*ActivateNodeResponse, error) {
-	out := new(ActivateNodeResponse)
-	err := grpc.Invoke(ctx, "/api.Node/Activate", in, out, c.cc, opts...)
-	if err != nil {
+func _Node_GetEffectiveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeEffectiveConfigRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NodeServer).GetEffectiveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetEffectiveConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetEffectiveConfig(ctx, req.(*GetNodeEffectiveConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nodeClient) GetActivation(ctx context.Context, in *GetNodeActivationRequest, opts ...grpc.CallOption) (*GetNodeActivationResponse, error) {
-	out := new(GetNodeActivationResponse)
-	err := grpc.Invoke(ctx, "/api.Node/GetActivation", in, out, c.cc, opts...)
-	if err != nil {
+func _Node_GetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeMaintenanceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NodeServer).GetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetMaintenance(ctx, req.(*GetNodeMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nodeClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
-	out := new(GetRandomDevAddrResponse)
-	err := grpc.Invoke(ctx, "/api.Node/GetRandomDevAddr", in, out, c.cc, opts...)
-	if err != nil {
+func _Node_UpdateMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeMaintenance)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NodeServer).UpdateMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/UpdateMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).UpdateMaintenance(ctx, req.(*NodeMaintenance))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nodeClient) GetFrameLogs(ctx context.Context, in *GetFrameLogsRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error) {
-	out := new(GetFrameLogsResponse)
-	err := grpc.Invoke(ctx, "/api.Node/GetFrameLogs", in, out, c.cc, opts...)
-	if err != nil {
+func _Node_GetAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeAliasRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NodeServer).GetAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetAlias(ctx, req.(*GetNodeAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Server API for Node service
+func _Node_UpdateAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeAlias)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).UpdateAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/UpdateAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).UpdateAlias(ctx, req.(*NodeAlias))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-type NodeServer interface {
-	// Create creates the given node.
-	Create(context.Context, *CreateNodeRequest) (*CreateNodeResponse, error)
-	// Get returns the node for the requested DevEUI.
-	Get(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
-	// Delete deletes the node matching the given DevEUI.
-	Delete(context.Context, *DeleteNodeRequest) (*DeleteNodeResponse, error)
-	// ListByApplicationID lists the nodes by the given application ID, sorted by the name of the node.
-	ListByApplicationID(context.Context, *ListNodeByApplicationIDRequest) (*ListNodeResponse, error)
-	// Update updates the node matching the given DevEUI.
-	Update(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error)
-	// Activate (re)activates the node (only when ABP is set to true).
-	Activate(context.Context, *ActivateNodeRequest) (*ActivateNodeResponse, error)
-	// GetActivation returns the current activation details of the node (OTAA and ABP).
-	GetActivation(context.Context, *GetNodeActivationRequest) (*GetNodeActivationResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
-	// GetFrameLogs returns the uplink / downlink frame log for the given DevEUI.
-	GetFrameLogs(context.Context, *GetFrameLogsRequest) (*GetFrameLogsResponse, error)
+func _Node_GetByAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeByAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetByAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetByAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetByAlias(ctx, req.(*GetNodeByAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
-	s.RegisterService(&_Node_serviceDesc, srv)
+func _Node_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/Lookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Lookup(ctx, req.(*LookupNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateNodeRequest)
+func _Node_CreateHTTPIntegration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeHTTPIntegration)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).Create(ctx, in)
+		return srv.(NodeServer).CreateHTTPIntegration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/Create",
+		FullMethod: "/api.Node/CreateHTTPIntegration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Create(ctx, req.(*CreateNodeRequest))
+		return srv.(NodeServer).CreateHTTPIntegration(ctx, req.(*NodeHTTPIntegration))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodeRequest)
+func _Node_GetHTTPIntegration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).Get(ctx, in)
+		return srv.(NodeServer).GetHTTPIntegration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/Get",
+		FullMethod: "/api.Node/GetHTTPIntegration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Get(ctx, req.(*GetNodeRequest))
+		return srv.(NodeServer).GetHTTPIntegration(ctx, req.(*NodeIntegrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteNodeRequest)
+func _Node_UpdateHTTPIntegration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeHTTPIntegration)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).Delete(ctx, in)
+		return srv.(NodeServer).UpdateHTTPIntegration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/Delete",
+		FullMethod: "/api.Node/UpdateHTTPIntegration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Delete(ctx, req.(*DeleteNodeRequest))
+		return srv.(NodeServer).UpdateHTTPIntegration(ctx, req.(*NodeHTTPIntegration))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_ListByApplicationID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListNodeByApplicationIDRequest)
+func _Node_DeleteHTTPIntegration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).ListByApplicationID(ctx, in)
+		return srv.(NodeServer).DeleteHTTPIntegration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/ListByApplicationID",
+		FullMethod: "/api.Node/DeleteHTTPIntegration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).ListByApplicationID(ctx, req.(*ListNodeByApplicationIDRequest))
+		return srv.(NodeServer).DeleteHTTPIntegration(ctx, req.(*NodeIntegrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateNodeRequest)
+func _Node_ListIntegrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).Update(ctx, in)
+		return srv.(NodeServer).ListIntegrations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/Update",
+		FullMethod: "/api.Node/ListIntegrations",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Update(ctx, req.(*UpdateNodeRequest))
+		return srv.(NodeServer).ListIntegrations(ctx, req.(*NodeIntegrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ActivateNodeRequest)
+func _Node_GetHTTPIntegrationChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).Activate(ctx, in)
+		return srv.(NodeServer).GetHTTPIntegrationChaos(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/Activate",
+		FullMethod: "/api.Node/GetHTTPIntegrationChaos",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Activate(ctx, req.(*ActivateNodeRequest))
+		return srv.(NodeServer).GetHTTPIntegrationChaos(ctx, req.(*NodeIntegrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_GetActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodeActivationRequest)
+func _Node_UpdateHTTPIntegrationChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationChaos)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).GetActivation(ctx, in)
+		return srv.(NodeServer).UpdateHTTPIntegrationChaos(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/GetActivation",
+		FullMethod: "/api.Node/UpdateHTTPIntegrationChaos",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).GetActivation(ctx, req.(*GetNodeActivationRequest))
+		return srv.(NodeServer).UpdateHTTPIntegrationChaos(ctx, req.(*NodeIntegrationChaos))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRandomDevAddrRequest)
+func _Node_GetHTTPIntegrationFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).GetRandomDevAddr(ctx, in)
+		return srv.(NodeServer).GetHTTPIntegrationFilter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/GetRandomDevAddr",
+		FullMethod: "/api.Node/GetHTTPIntegrationFilter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).GetRandomDevAddr(ctx, req.(*GetRandomDevAddrRequest))
+		return srv.(NodeServer).GetHTTPIntegrationFilter(ctx, req.(*NodeIntegrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Node_GetFrameLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFrameLogsRequest)
+func _Node_UpdateHTTPIntegrationFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationFilter)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeServer).GetFrameLogs(ctx, in)
+		return srv.(NodeServer).UpdateHTTPIntegrationFilter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.Node/GetFrameLogs",
+		FullMethod: "/api.Node/UpdateHTTPIntegrationFilter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).GetFrameLogs(ctx, req.(*GetFrameLogsRequest))
+		return srv.(NodeServer).UpdateHTTPIntegrationFilter(ctx, req.(*NodeIntegrationFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetHTTPIntegrationHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeIntegrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetHTTPIntegrationHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Node/GetHTTPIntegrationHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetHTTPIntegrationHealth(ctx, req.(*NodeIntegrationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1551,6 +4715,14 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Create",
 			Handler:    _Node_Create_Handler,
 		},
+		{
+			MethodName: "CreateBatch",
+			Handler:    _Node_CreateBatch_Handler,
+		},
+		{
+			MethodName: "UpdateBatch",
+			Handler:    _Node_UpdateBatch_Handler,
+		},
 		{
 			MethodName: "Get",
 			Handler:    _Node_Get_Handler,
@@ -1559,6 +4731,10 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Node_Delete_Handler,
 		},
+		{
+			MethodName: "Decommission",
+			Handler:    _Node_Decommission_Handler,
+		},
 		{
 			MethodName: "ListByApplicationID",
 			Handler:    _Node_ListByApplicationID_Handler,
@@ -1567,6 +4743,10 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _Node_Update_Handler,
 		},
+		{
+			MethodName: "Patch",
+			Handler:    _Node_Patch_Handler,
+		},
 		{
 			MethodName: "Activate",
 			Handler:    _Node_Activate_Handler,
@@ -1583,6 +4763,114 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetFrameLogs",
 			Handler:    _Node_GetFrameLogs_Handler,
 		},
+		{
+			MethodName: "GetNextEvent",
+			Handler:    _Node_GetNextEvent_Handler,
+		},
+		{
+			MethodName: "GetDownlinkAirtime",
+			Handler:    _Node_GetDownlinkAirtime_Handler,
+		},
+		{
+			MethodName: "GetLinkQuality",
+			Handler:    _Node_GetLinkQuality_Handler,
+		},
+		{
+			MethodName: "ListLinkQuality",
+			Handler:    _Node_ListLinkQuality_Handler,
+		},
+		{
+			MethodName: "GetAvailability",
+			Handler:    _Node_GetAvailability_Handler,
+		},
+		{
+			MethodName: "ListAvailability",
+			Handler:    _Node_ListAvailability_Handler,
+		},
+		{
+			MethodName: "GetLastValues",
+			Handler:    _Node_GetLastValues_Handler,
+		},
+		{
+			MethodName: "ListLastValues",
+			Handler:    _Node_ListLastValues_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _Node_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _Node_RemoveTags_Handler,
+		},
+		{
+			MethodName: "GetEffectiveConfig",
+			Handler:    _Node_GetEffectiveConfig_Handler,
+		},
+		{
+			MethodName: "GetMaintenance",
+			Handler:    _Node_GetMaintenance_Handler,
+		},
+		{
+			MethodName: "UpdateMaintenance",
+			Handler:    _Node_UpdateMaintenance_Handler,
+		},
+		{
+			MethodName: "GetAlias",
+			Handler:    _Node_GetAlias_Handler,
+		},
+		{
+			MethodName: "UpdateAlias",
+			Handler:    _Node_UpdateAlias_Handler,
+		},
+		{
+			MethodName: "GetByAlias",
+			Handler:    _Node_GetByAlias_Handler,
+		},
+		{
+			MethodName: "Lookup",
+			Handler:    _Node_Lookup_Handler,
+		},
+		{
+			MethodName: "CreateHTTPIntegration",
+			Handler:    _Node_CreateHTTPIntegration_Handler,
+		},
+		{
+			MethodName: "GetHTTPIntegration",
+			Handler:    _Node_GetHTTPIntegration_Handler,
+		},
+		{
+			MethodName: "UpdateHTTPIntegration",
+			Handler:    _Node_UpdateHTTPIntegration_Handler,
+		},
+		{
+			MethodName: "DeleteHTTPIntegration",
+			Handler:    _Node_DeleteHTTPIntegration_Handler,
+		},
+		{
+			MethodName: "ListIntegrations",
+			Handler:    _Node_ListIntegrations_Handler,
+		},
+		{
+			MethodName: "GetHTTPIntegrationChaos",
+			Handler:    _Node_GetHTTPIntegrationChaos_Handler,
+		},
+		{
+			MethodName: "UpdateHTTPIntegrationChaos",
+			Handler:    _Node_UpdateHTTPIntegrationChaos_Handler,
+		},
+		{
+			MethodName: "GetHTTPIntegrationFilter",
+			Handler:    _Node_GetHTTPIntegrationFilter_Handler,
+		},
+		{
+			MethodName: "UpdateHTTPIntegrationFilter",
+			Handler:    _Node_UpdateHTTPIntegrationFilter_Handler,
+		},
+		{
+			MethodName: "GetHTTPIntegrationHealth",
+			Handler:    _Node_GetHTTPIntegrationHealth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "node.proto",
@@ -1591,87 +4879,250 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("node.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1306 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4d, 0x6f, 0x23, 0x45,
-	0x13, 0xd6, 0xf8, 0x2b, 0x4e, 0x25, 0x4e, 0xb2, 0x1d, 0x6f, 0x76, 0xd6, 0x9b, 0x8d, 0xac, 0xc9,
-	0xfb, 0x2e, 0xce, 0x82, 0x62, 0x11, 0x10, 0x07, 0x6e, 0x5e, 0x9b, 0x8d, 0xc2, 0x7e, 0xaa, 0xc3,
-	0xb2, 0x20, 0x84, 0x44, 0xaf, 0xa7, 0xed, 0x0c, 0xcc, 0x74, 0x0f, 0x33, 0x9d, 0xc4, 0xd6, 0x6a,
-	0x2f, 0x7b, 0xe0, 0x84, 0xb8, 0x70, 0x46, 0x42, 0xfc, 0x01, 0x7e, 0x0c, 0xe2, 0x1f, 0xf0, 0x27,
-	0xb8, 0xa1, 0xfe, 0x98, 0xf1, 0xd8, 0x1e, 0x27, 0x39, 0xc0, 0x2d, 0xa7, 0x4c, 0x3d, 0xd5, 0xae,
-	0xaa, 0xa7, 0xe7, 0xe9, 0xaa, 0x9e, 0x00, 0x30, 0xee, 0xd2, 0xfd, 0x30, 0xe2, 0x82, 0xa3, 0x22,
-	0x09, 0xbd, 0xc6, 0xf6, 0x90, 0xf3, 0xa1, 0x4f, 0xdb, 0x24, 0xf4, 0xda, 0x84, 0x31, 0x2e, 0x88,
-	0xf0, 0x38, 0x8b, 0xf5, 0x92, 0xc6, 0x6a, 0x9f, 0x07, 0x01, 0x67, 0xda, 0x72, 0x7e, 0x2a, 0xc1,
-	0x8d, 0x6e, 0x44, 0x89, 0xa0, 0x4f, 0xb9, 0x4b, 0x31, 0xfd, 0xfe, 0x94, 0xc6, 0x02, 0x6d, 0x41,
-	0xc5, 0xa5, 0x67, 0x9f, 0xbc, 0x38, 0xb2, 0xad, 0xa6, 0xd5, 0x5a, 0xc6, 0xc6, 0x92, 0x38, 0x09,
-	0x43, 0x89, 0x17, 0x34, 0xae, 0x2d, 0x83, 0x3f, 0xa2, 0x63, 0xbb, 0x98, 0xe2, 0x8f, 0xe8, 0x18,
-	0xd9, 0xb0, 0x14, 0x8d, 0x7a, 0xd4, 0x27, 0x63, 0xbb, 0xd4, 0xb4, 0x5a, 0x35, 0x9c, 0x98, 0xa8,
-	0x09, 0x2b, 0xd1, 0xe8, 0xfd, 0x1e, 0x7e, 0x36, 0x18, 0xc4, 0x54, 0xd8, 0x65, 0xe5, 0xcd, 0x42,
-	0x68, 0x0f, 0xaa, 0xd1, 0xe8, 0xa5, 0xc7, 0x5c, 0x7e, 0x6e, 0x2f, 0x35, 0xad, 0xd6, 0xda, 0x41,
-	0x6d, 0x9f, 0x84, 0xde, 0x3e, 0xfe, 0x42, 0x83, 0x38, 0x75, 0xa3, 0x3a, 0x94, 0xa3, 0xd1, 0x41,
-	0x0f, 0xdb, 0x55, 0x15, 0x46, 0x1b, 0x08, 0x41, 0x89, 0x91, 0x80, 0xda, 0xcb, 0xaa, 0x24, 0xf5,
-	0x8c, 0xb6, 0x61, 0x39, 0xa2, 0x3e, 0x19, 0x3d, 0xec, 0x32, 0x61, 0x43, 0xd3, 0x6a, 0x55, 0xf1,
-	0x04, 0x90, 0x45, 0x11, 0x37, 0x3a, 0x62, 0x82, 0x46, 0x67, 0xc4, 0xb7, 0x57, 0x74, 0x51, 0x19,
-	0x08, 0xed, 0x03, 0xf2, 0x58, 0x2c, 0x88, 0xef, 0xab, 0x3d, 0x7d, 0x42, 0xa2, 0xa1, 0xc7, 0xec,
-	0xd5, 0xa6, 0xd5, 0xb2, 0x70, 0x8e, 0x07, 0xfd, 0x0f, 0x6a, 0x24, 0x0c, 0x7d, 0xaf, 0xaf, 0xc0,
-	0xa3, 0x9e, 0x5d, 0x6b, 0x5a, 0xad, 0x22, 0x9e, 0x06, 0x65, 0x5e, 0x97, 0xc6, 0xfd, 0xc8, 0x0b,
-	0x25, 0x60, 0xaf, 0xa9, 0x82, 0xb3, 0x90, 0x64, 0xe8, 0xc5, 0x9d, 0x07, 0xcf, 0xed, 0x75, 0x55,
-	0xb3, 0x36, 0x50, 0x03, 0xaa, 0x5e, 0xdc, 0xf5, 0x49, 0x1c, 0x77, 0xed, 0x0d, 0xe5, 0x48, 0x6d,
-	0xf4, 0x11, 0x6c, 0x9d, 0xc6, 0xb4, 0x33, 0xc9, 0x73, 0x4c, 0x85, 0xf0, 0xd8, 0x30, 0xb6, 0x6f,
-	0xa8, 0x95, 0x0b, 0xbc, 0x4e, 0x1d, 0x50, 0x56, 0x0f, 0x71, 0xc8, 0x59, 0x4c, 0x9d, 0x16, 0xac,
-	0x1d, 0x52, 0x71, 0x05, 0x89, 0x38, 0x3f, 0x96, 0x60, 0x3d, 0x5d, 0xaa, 0x7f, 0x7d, 0x2d, 0xa7,
-	0x7f, 0x4b, 0x4e, 0x33, 0x42, 0xa9, 0x5d, 0x20, 0x94, 0xb5, 0xac, 0x50, 0xe6, 0x64, 0xb8, 0x9e,
-	0x27, 0xc3, 0xff, 0x42, 0x4e, 0xef, 0xc2, 0x8d, 0x1e, 0xf5, 0xe9, 0x95, 0xda, 0x8b, 0xd4, 0x5e,
-	0x76, 0xb1, 0xd1, 0x9e, 0x80, 0x9d, 0xc7, 0x5e, 0xac, 0x14, 0xf5, 0x60, 0xdc, 0xc9, 0x56, 0x9c,
-	0xc4, 0x9b, 0xa3, 0x57, 0xcc, 0xa3, 0x57, 0x87, 0xb2, 0xef, 0x05, 0x9e, 0x50, 0x49, 0x8b, 0x58,
-	0x1b, 0xb2, 0x16, 0xae, 0x45, 0x53, 0x50, 0xb0, 0xb1, 0x9c, 0x6f, 0x60, 0x23, 0xc9, 0x9a, 0xea,
-	0x78, 0x07, 0x40, 0x70, 0x41, 0xfc, 0x2e, 0x3f, 0x65, 0x49, 0x98, 0x0c, 0x82, 0xde, 0x83, 0x4a,
-	0x44, 0xe3, 0x53, 0x5f, 0xc6, 0x2a, 0xb6, 0x56, 0x0e, 0xea, 0x4a, 0x61, 0x33, 0xa7, 0x01, 0x9b,
-	0x35, 0xaa, 0xf5, 0xbe, 0x08, 0xdd, 0xeb, 0xd6, 0x7b, 0xdd, 0x7a, 0xd3, 0xd6, 0x9b, 0xd5, 0x83,
-	0x91, 0xff, 0xef, 0x16, 0x6c, 0x76, 0xfa, 0xc2, 0x3b, 0xbb, 0xa2, 0x50, 0x6c, 0x58, 0x72, 0xe9,
-	0x59, 0xc7, 0x75, 0x23, 0xa3, 0x94, 0xc4, 0x94, 0x1e, 0x12, 0x86, 0xc7, 0x13, 0xad, 0x24, 0xa6,
-	0xf4, 0xb0, 0xf3, 0xef, 0x94, 0xa7, 0xa4, 0x3d, 0xc6, 0x94, 0x59, 0x06, 0x5d, 0x26, 0x5e, 0x84,
-	0x46, 0x27, 0xc6, 0x92, 0xfc, 0xe5, 0x53, 0x8f, 0x9f, 0x33, 0xbb, 0xa2, 0x3c, 0xa9, 0xed, 0x6c,
-	0x41, 0x7d, 0xba, 0x60, 0xc3, 0xe4, 0x00, 0x6c, 0x73, 0x16, 0x8c, 0xdb, 0xe3, 0xec, 0xb2, 0x96,
-	0xf0, 0x8b, 0x05, 0xb7, 0x73, 0x7e, 0x64, 0x0e, 0x64, 0x86, 0xab, 0xb5, 0x90, 0x6b, 0x61, 0x21,
-	0xd7, 0xe2, 0x22, 0xae, 0xa5, 0x85, 0x5c, 0xcb, 0x33, 0x5c, 0x6f, 0xc3, 0xad, 0x43, 0x2a, 0x30,
-	0x61, 0x2e, 0x0f, 0x7a, 0x3a, 0xb7, 0xa1, 0xe4, 0x7c, 0xa8, 0xe8, 0xce, 0xb8, 0x2e, 0x2b, 0xdc,
-	0xf9, 0x0a, 0x36, 0x0f, 0xa9, 0x78, 0x18, 0x91, 0x80, 0x3e, 0xe6, 0xc3, 0xf8, 0xb2, 0xb7, 0x9d,
-	0x36, 0xb5, 0x42, 0x7e, 0x53, 0x2b, 0x4e, 0x35, 0xb5, 0xaf, 0xa1, 0x3e, 0x1d, 0x7c, 0x61, 0x63,
-	0x2b, 0x4f, 0x35, 0xb6, 0xff, 0xcf, 0x34, 0x36, 0xdd, 0x0e, 0x92, 0x38, 0x69, 0x47, 0xfb, 0xd5,
-	0x82, 0x6a, 0x02, 0xca, 0xf3, 0xde, 0x57, 0x17, 0x09, 0xb7, 0x23, 0x4c, 0xd1, 0x13, 0x00, 0xed,
-	0xc1, 0x72, 0x34, 0x3a, 0x62, 0x03, 0x7e, 0x4c, 0x93, 0xa0, 0x2b, 0xa6, 0xc7, 0x48, 0x14, 0x4f,
-	0xbc, 0x68, 0x17, 0x2a, 0x42, 0x19, 0x8a, 0x4c, 0xb2, 0xee, 0x33, 0xbd, 0xce, 0xb8, 0xd0, 0x3d,
-	0x58, 0x0b, 0x4f, 0xc6, 0xcf, 0xc9, 0xd8, 0xe7, 0xc4, 0xfd, 0xf4, 0xf8, 0xd9, 0x53, 0x23, 0xe4,
-	0x19, 0xd4, 0xf9, 0xc1, 0x82, 0x6a, 0x8f, 0x08, 0x82, 0x89, 0x50, 0xb4, 0x03, 0xee, 0x9e, 0xea,
-	0xb6, 0x61, 0x6a, 0xcc, 0x20, 0x92, 0xc2, 0x2b, 0xc2, 0xdc, 0x97, 0x9e, 0x2b, 0x4e, 0xd4, 0x06,
-	0xd7, 0xf0, 0x04, 0x40, 0x0e, 0xac, 0xc6, 0x61, 0x44, 0x89, 0xfb, 0x90, 0xf4, 0x05, 0x8f, 0x54,
-	0x75, 0x35, 0x3c, 0x85, 0xc9, 0xf7, 0xfc, 0xca, 0x13, 0x11, 0x11, 0x34, 0xe9, 0xc2, 0xc6, 0x74,
-	0xfe, 0xb6, 0xa0, 0xa2, 0xb9, 0xca, 0x45, 0xfd, 0x13, 0xc2, 0x18, 0xf5, 0xcd, 0xd6, 0x27, 0xa6,
-	0x54, 0x5e, 0x5f, 0x9e, 0x20, 0xf9, 0x7b, 0x2d, 0xe3, 0xd4, 0x96, 0xc5, 0x0d, 0x22, 0xa9, 0x0e,
-	0xd6, 0x1f, 0x9b, 0xd7, 0x3c, 0x01, 0x64, 0x4c, 0x9f, 0x63, 0x72, 0xfc, 0x14, 0xab, 0xc4, 0x16,
-	0x4e, 0x4c, 0xd9, 0x9b, 0xa3, 0x38, 0xf6, 0x94, 0x92, 0xcb, 0x58, 0x3d, 0x4b, 0x4c, 0x78, 0x01,
-	0x55, 0x27, 0x79, 0x19, 0xab, 0x67, 0x19, 0x5f, 0xfe, 0x8d, 0x05, 0x09, 0x42, 0x35, 0x05, 0x6a,
-	0x78, 0x02, 0xc8, 0x11, 0xe1, 0x9a, 0x6d, 0x54, 0xad, 0x3f, 0xd1, 0x44, 0xb2, 0xb7, 0x38, 0x75,
-	0xa3, 0x0d, 0x28, 0x06, 0xa4, 0x6f, 0x66, 0x81, 0x7c, 0x74, 0xfe, 0xb4, 0xa0, 0xa2, 0xdf, 0xdf,
-	0x14, 0x43, 0xeb, 0x22, 0x86, 0x85, 0x59, 0x86, 0x4d, 0x58, 0xf1, 0x82, 0x80, 0xba, 0x1e, 0x11,
-	0xd4, 0xd7, 0x3b, 0x50, 0xc5, 0x59, 0x28, 0x49, 0x5c, 0x4a, 0x13, 0xcb, 0xd3, 0x12, 0xf2, 0x73,
-	0x1a, 0x19, 0xf2, 0xda, 0x98, 0x66, 0x5a, 0xb9, 0x88, 0xe9, 0xd2, 0x85, 0x4c, 0x0f, 0x7e, 0x5b,
-	0x82, 0x92, 0xec, 0x54, 0xe8, 0x39, 0x54, 0xf4, 0x25, 0x1a, 0x6d, 0xa9, 0xb5, 0x73, 0x5f, 0x58,
-	0x8d, 0x5b, 0x73, 0xb8, 0x69, 0x92, 0x37, 0xdf, 0xfe, 0xf1, 0xd7, 0xcf, 0x85, 0x75, 0x07, 0xd4,
-	0xe7, 0x9b, 0xfc, 0xb4, 0x8b, 0x3f, 0xb6, 0xee, 0xa3, 0x27, 0x50, 0x3c, 0xa4, 0x02, 0x6d, 0x4e,
-	0xdf, 0x28, 0x74, 0xac, 0xdc, 0x6b, 0x86, 0x73, 0x47, 0x05, 0xba, 0x89, 0x36, 0x27, 0x81, 0xda,
-	0xaf, 0x75, 0xd7, 0x78, 0x83, 0x3e, 0x87, 0x8a, 0xbe, 0x69, 0x99, 0x02, 0xe7, 0xee, 0x68, 0xa6,
-	0xc0, 0x9c, 0xeb, 0x98, 0x89, 0x7b, 0x3f, 0x37, 0xee, 0x5b, 0x0b, 0x36, 0xe5, 0xb5, 0x69, 0xe6,
-	0xa2, 0x86, 0x76, 0x55, 0xb4, 0x8b, 0xaf, 0x71, 0x8d, 0x9b, 0x53, 0x8b, 0xd2, 0x84, 0x6d, 0x95,
-	0x70, 0x0f, 0xbd, 0xa3, 0x3f, 0x68, 0x27, 0xbf, 0x8c, 0xdb, 0xaf, 0xa7, 0x86, 0xf9, 0x1b, 0x5d,
-	0x0d, 0xfa, 0x12, 0x2a, 0x7a, 0x8e, 0x1a, 0x72, 0x73, 0x97, 0x2c, 0x43, 0x2e, 0x67, 0xd8, 0xee,
-	0xa8, 0x5c, 0x76, 0x23, 0x8f, 0x9c, 0x7c, 0x0d, 0xdf, 0x42, 0x35, 0x19, 0x6d, 0xc8, 0x56, 0x41,
-	0x72, 0x46, 0x73, 0xe3, 0x76, 0x8e, 0xc7, 0x24, 0xd8, 0x53, 0x09, 0x76, 0x9d, 0x9d, 0x9c, 0x04,
-	0x6d, 0x92, 0x4e, 0x38, 0x99, 0xeb, 0x0c, 0x6a, 0x87, 0x54, 0x4c, 0xa6, 0x1e, 0xba, 0x9b, 0x7d,
-	0xcf, 0x73, 0x23, 0xb4, 0xb1, 0xb3, 0xc8, 0x6d, 0x52, 0xdf, 0x53, 0xa9, 0x9b, 0xe8, 0x92, 0xd4,
-	0x48, 0xc0, 0xc6, 0xec, 0xdc, 0x42, 0xdb, 0x49, 0xec, 0xbc, 0x49, 0xd7, 0xb8, 0xbb, 0xc0, 0x6b,
-	0x12, 0xef, 0xaa, 0xc4, 0x77, 0x9d, 0x3b, 0x99, 0xc4, 0xc3, 0xd9, 0x0c, 0x43, 0x58, 0xcd, 0x8e,
-	0x26, 0xb3, 0xbb, 0x39, 0xa3, 0xd0, 0xec, 0x6e, 0xde, 0x1c, 0x73, 0x1c, 0x95, 0x69, 0x1b, 0x35,
-	0xf2, 0x28, 0x0e, 0xe4, 0xf2, 0xf8, 0x55, 0x45, 0xfd, 0xe3, 0xe3, 0x83, 0x7f, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x74, 0xab, 0x8c, 0x51, 0x37, 0x11, 0x00, 0x00,
+	// 3916 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3b, 0x4b, 0x53, 0xe4, 0x48,
+	0x7a, 0x21, 0x8a, 0x2a, 0xe0, 0x83, 0x82, 0x26, 0x81, 0x46, 0x08, 0x9a, 0xae, 0x56, 0x3f, 0x86,
+	0xe9, 0x17, 0xbd, 0x4c, 0xef, 0xcc, 0x78, 0xfd, 0x88, 0xe0, 0xd1, 0xcd, 0xf4, 0xf6, 0x63, 0xb1,
+	0x68, 0x66, 0xd6, 0xe1, 0x70, 0xac, 0x93, 0x52, 0x52, 0x68, 0xa9, 0x92, 0x6a, 0xa4, 0x2c, 0xa0,
+	0xa6, 0x3d, 0x8e, 0x70, 0x47, 0x78, 0x6d, 0xc7, 0x1e, 0xec, 0xb0, 0xc3, 0x11, 0x7b, 0xf0, 0x46,
+	0xf8, 0x1f, 0xf8, 0xe2, 0x93, 0xef, 0xfe, 0x05, 0x1b, 0xbe, 0xfa, 0xe4, 0xbb, 0xaf, 0xb6, 0x4f,
+	0x8e, 0x7c, 0x48, 0x4a, 0x49, 0xa9, 0xaa, 0xa2, 0xc7, 0x07, 0x1f, 0xe6, 0x44, 0x7d, 0x8f, 0xcc,
+	0xef, 0x91, 0x5f, 0x7e, 0x5f, 0xe6, 0x97, 0x02, 0xc0, 0x0f, 0x5c, 0xf2, 0xb8, 0x1b, 0x06, 0x34,
+	0x40, 0x15, 0xdc, 0xf5, 0xac, 0xb5, 0x56, 0x10, 0xb4, 0xda, 0x64, 0x13, 0x77, 0xbd, 0x4d, 0xec,
+	0xfb, 0x01, 0xc5, 0xd4, 0x0b, 0xfc, 0x48, 0xb0, 0x58, 0x33, 0xcd, 0xa0, 0xd3, 0x09, 0x7c, 0x09,
+	0xcd, 0xe3, 0x6e, 0xb7, 0xed, 0x35, 0x39, 0x87, 0x40, 0xd9, 0xbf, 0x19, 0x87, 0xf9, 0xdd, 0x90,
+	0x60, 0x4a, 0xde, 0x04, 0x2e, 0x71, 0xc8, 0xd7, 0x3d, 0x12, 0x51, 0x74, 0x1d, 0x6a, 0x2e, 0x39,
+	0x7f, 0x76, 0xf4, 0xc2, 0x34, 0x1a, 0xc6, 0xc6, 0x94, 0x23, 0x21, 0x86, 0xc7, 0xdd, 0x2e, 0xc3,
+	0x8f, 0x09, 0xbc, 0x80, 0x24, 0xfe, 0x25, 0xe9, 0x9b, 0x95, 0x04, 0xff, 0x92, 0xf4, 0x91, 0x09,
+	0x13, 0xe1, 0xe5, 0x1e, 0x69, 0xe3, 0xbe, 0x39, 0xde, 0x30, 0x36, 0xea, 0x4e, 0x0c, 0xa2, 0x06,
+	0x4c, 0x87, 0x97, 0x3f, 0xd8, 0x73, 0x7e, 0x72, 0x72, 0x12, 0x11, 0x6a, 0x56, 0x39, 0x55, 0x45,
+	0xa1, 0x8f, 0x61, 0x32, 0xbc, 0xfc, 0xca, 0xf3, 0xdd, 0xe0, 0xc2, 0x9c, 0x68, 0x18, 0x1b, 0xb3,
+	0x5b, 0xf5, 0xc7, 0xb8, 0xeb, 0x3d, 0x76, 0x7e, 0x2a, 0x90, 0x4e, 0x42, 0x46, 0x8b, 0x50, 0x0d,
+	0x2f, 0xb7, 0xf6, 0x1c, 0x73, 0x92, 0x4f, 0x23, 0x00, 0x84, 0x60, 0xdc, 0xc7, 0x1d, 0x62, 0x4e,
+	0x71, 0x95, 0xf8, 0x6f, 0xb4, 0x06, 0x53, 0x21, 0x69, 0xe3, 0xcb, 0xe7, 0xbb, 0x3e, 0x35, 0xa1,
+	0x61, 0x6c, 0x4c, 0x3a, 0x29, 0x82, 0x29, 0x85, 0xdd, 0xf0, 0x85, 0x4f, 0x49, 0x78, 0x8e, 0xdb,
+	0xe6, 0xb4, 0x50, 0x4a, 0x41, 0xa1, 0xc7, 0x80, 0x3c, 0x3f, 0xa2, 0xb8, 0xdd, 0xe6, 0x4e, 0x7c,
+	0x8d, 0xc3, 0x96, 0xe7, 0x9b, 0x33, 0x0d, 0x63, 0xc3, 0x70, 0x34, 0x14, 0x74, 0x07, 0xea, 0x8a,
+	0xcf, 0x5f, 0xec, 0x99, 0xf5, 0x86, 0xb1, 0x51, 0x71, 0xb2, 0x48, 0x26, 0xd7, 0x25, 0x51, 0x33,
+	0xf4, 0xba, 0x0c, 0x61, 0xce, 0x72, 0x85, 0x55, 0x14, 0xb3, 0xd0, 0x8b, 0xb6, 0x77, 0x0e, 0xcc,
+	0x39, 0xae, 0xb3, 0x00, 0x90, 0x05, 0x93, 0x5e, 0xb4, 0xdb, 0xc6, 0x51, 0xb4, 0x6b, 0x5e, 0xe3,
+	0x84, 0x04, 0x46, 0x9f, 0xc2, 0xf5, 0x5e, 0x44, 0xb6, 0x53, 0x39, 0x87, 0x84, 0x52, 0xcf, 0x6f,
+	0x45, 0xe6, 0x3c, 0xe7, 0x2c, 0xa1, 0x32, 0xaf, 0x51, 0xdc, 0x8a, 0x4c, 0xd4, 0xa8, 0x30, 0xaf,
+	0xb1, 0xdf, 0x68, 0x13, 0xa6, 0xce, 0x71, 0xe8, 0xe1, 0xe3, 0x36, 0x89, 0xcc, 0x85, 0x46, 0x65,
+	0x63, 0x7a, 0x6b, 0x9e, 0xaf, 0x05, 0x8b, 0x99, 0x2f, 0x25, 0xc5, 0x49, 0x79, 0xec, 0x45, 0x40,
+	0x6a, 0x50, 0x45, 0xdd, 0xc0, 0x8f, 0x88, 0xfd, 0x39, 0xcc, 0xa8, 0x03, 0x92, 0x05, 0x32, 0x94,
+	0x05, 0x5a, 0x84, 0xea, 0x39, 0x6e, 0xf7, 0x88, 0x0c, 0x30, 0x01, 0xd8, 0x1b, 0x30, 0xbb, 0x4f,
+	0xe8, 0x08, 0x11, 0x6a, 0xff, 0xe7, 0x38, 0xcc, 0x25, 0xac, 0x42, 0xee, 0xf7, 0xd1, 0xfc, 0x7f,
+	0x15, 0xcd, 0xb9, 0x38, 0xad, 0x0f, 0x88, 0xd3, 0x59, 0x35, 0x4e, 0x0b, 0xbb, 0x60, 0x4e, 0xb7,
+	0x0b, 0xfe, 0xbf, 0x46, 0xb3, 0x70, 0x33, 0xf5, 0x42, 0xe2, 0x6e, 0x53, 0x73, 0x91, 0x1b, 0x9d,
+	0x22, 0xec, 0x07, 0x30, 0xbf, 0x47, 0xda, 0x64, 0xa4, 0x04, 0xca, 0x36, 0x86, 0xca, 0x2c, 0x37,
+	0xc6, 0x57, 0xb0, 0xbc, 0x47, 0x58, 0xa6, 0xf6, 0xa2, 0xc8, 0x0b, 0xfc, 0x51, 0x32, 0xf1, 0x1d,
+	0xa8, 0xbb, 0x7c, 0xa2, 0x2f, 0xbc, 0x88, 0x06, 0x61, 0x9f, 0x87, 0xf0, 0xa4, 0x93, 0x45, 0xda,
+	0x16, 0x98, 0xc5, 0x89, 0xa5, 0xd0, 0xbf, 0x31, 0x60, 0xfd, 0x95, 0x17, 0xf1, 0xad, 0xb2, 0xd3,
+	0xdf, 0x56, 0x97, 0x22, 0x16, 0x5e, 0x58, 0xb7, 0x8a, 0x6e, 0xdd, 0x16, 0xa1, 0xda, 0xf6, 0x3a,
+	0x1e, 0xe5, 0x1a, 0x56, 0x1c, 0x01, 0x30, 0xc5, 0x03, 0xb1, 0x1b, 0xc6, 0x38, 0x5a, 0x42, 0x6c,
+	0x95, 0x4f, 0xbc, 0x36, 0x25, 0xe1, 0x8b, 0x3d, 0xbe, 0x8b, 0x2a, 0x4e, 0x02, 0xdb, 0x7f, 0x0c,
+	0xd7, 0x62, 0x8d, 0x92, 0xcd, 0xbb, 0x0e, 0x40, 0x03, 0x8a, 0xdb, 0xbb, 0x41, 0xcf, 0x8f, 0x45,
+	0x28, 0x18, 0xf4, 0x10, 0x6a, 0x21, 0x89, 0x7a, 0x6d, 0x26, 0x87, 0x2d, 0xe5, 0x22, 0x5f, 0xca,
+	0x5c, 0x0a, 0x70, 0x24, 0x8f, 0xfd, 0xd7, 0x55, 0x98, 0x3f, 0xea, 0xba, 0xdf, 0x97, 0xbb, 0xef,
+	0xcb, 0x5d, 0x36, 0x41, 0x2c, 0x94, 0x25, 0x88, 0xc5, 0x11, 0x12, 0xc4, 0x3a, 0x40, 0x8f, 0x07,
+	0xd5, 0x6b, 0x1c, 0x9d, 0xc9, 0x5c, 0xa3, 0x60, 0x98, 0xe2, 0x21, 0x39, 0xf7, 0xd8, 0x16, 0x34,
+	0x97, 0xb8, 0xb5, 0x09, 0xcc, 0x32, 0x82, 0x1a, 0x90, 0x72, 0x73, 0x3e, 0x87, 0xeb, 0x69, 0x01,
+	0xdd, 0xc1, 0xb4, 0x79, 0x1a, 0xc7, 0xea, 0x43, 0xa8, 0xb2, 0x23, 0x60, 0x64, 0x1a, 0x5c, 0xb1,
+	0xeb, 0x5c, 0xb1, 0xc2, 0x09, 0xce, 0x11, 0x4c, 0xf6, 0x3e, 0x2c, 0x17, 0xe6, 0x91, 0x1b, 0x2b,
+	0xdd, 0x38, 0x86, 0xb2, 0x71, 0x54, 0xbe, 0x5e, 0x9b, 0x26, 0x1b, 0xe7, 0x39, 0x5c, 0x4f, 0xd5,
+	0x1c, 0xae, 0x50, 0x61, 0x8f, 0x29, 0x0a, 0x15, 0xe6, 0xf9, 0x20, 0x85, 0x0e, 0x61, 0x2e, 0x47,
+	0x2a, 0xdd, 0xc6, 0x8b, 0x50, 0x25, 0x61, 0x18, 0x84, 0xf1, 0x99, 0x82, 0x03, 0x6c, 0xe5, 0x9b,
+	0x81, 0x4b, 0xe4, 0x16, 0xe6, 0xbf, 0xed, 0x7f, 0x32, 0x60, 0x61, 0xbb, 0x49, 0xbd, 0xf3, 0x11,
+	0x13, 0x84, 0x09, 0x13, 0x2e, 0x39, 0xdf, 0x76, 0xdd, 0x78, 0xee, 0x18, 0x64, 0x14, 0xdc, 0xed,
+	0x1e, 0xa6, 0x39, 0x22, 0x06, 0x19, 0xc5, 0xbf, 0x38, 0xe3, 0x94, 0x71, 0x41, 0x91, 0x20, 0x93,
+	0x72, 0xb2, 0xeb, 0xd3, 0xa3, 0xae, 0xcc, 0x0f, 0x12, 0xe2, 0x29, 0x73, 0xd7, 0xa7, 0x7b, 0xc1,
+	0x85, 0x6f, 0xd6, 0x38, 0x25, 0x81, 0xed, 0xeb, 0xb0, 0x98, 0x55, 0x58, 0x06, 0xd0, 0x16, 0x98,
+	0x32, 0x07, 0x4a, 0xb2, 0x17, 0xf8, 0xc3, 0x8a, 0xd3, 0xaf, 0x0d, 0x58, 0xd1, 0x0c, 0x92, 0xcb,
+	0xa3, 0xd8, 0x6a, 0x94, 0xda, 0x3a, 0x56, 0x6a, 0x6b, 0xa5, 0xcc, 0xd6, 0xf1, 0x52, 0x5b, 0xab,
+	0x39, 0x5b, 0x57, 0x60, 0x79, 0x9f, 0x50, 0x07, 0xfb, 0x6e, 0xd0, 0xd9, 0x13, 0xb2, 0xa5, 0x49,
+	0xf6, 0x53, 0x30, 0x8b, 0xa4, 0x61, 0x8a, 0xdb, 0x7f, 0x08, 0x0b, 0xfb, 0x84, 0x3e, 0x0f, 0x71,
+	0x87, 0xbc, 0x0a, 0x5a, 0xd1, 0xb0, 0xd5, 0x4e, 0x0a, 0xdd, 0x98, 0xbe, 0xd0, 0x55, 0xd4, 0x42,
+	0x67, 0xff, 0x11, 0x2c, 0x66, 0x27, 0x2f, 0x2d, 0x68, 0xd5, 0x4c, 0x41, 0xbb, 0x9b, 0x2b, 0x68,
+	0xa2, 0x0c, 0xc4, 0xf3, 0x24, 0xf1, 0xff, 0x92, 0x3b, 0xe3, 0x0d, 0xb9, 0xe4, 0xeb, 0xf5, 0xec,
+	0x9c, 0xf8, 0x74, 0x84, 0x68, 0xa5, 0x5e, 0x87, 0x04, 0x3d, 0x61, 0x41, 0xdd, 0x89, 0x41, 0xfb,
+	0x00, 0xcc, 0xe2, 0x64, 0x52, 0x5f, 0x96, 0x21, 0xfb, 0xdd, 0xe4, 0x94, 0xce, 0x7e, 0xb3, 0x0c,
+	0xde, 0xc5, 0xfd, 0x76, 0x80, 0xdd, 0x1f, 0x1f, 0xfe, 0xe4, 0x8d, 0x5c, 0x75, 0x15, 0x65, 0xff,
+	0xa3, 0x01, 0x93, 0xb1, 0xce, 0xac, 0x0c, 0x35, 0x79, 0x16, 0x62, 0x07, 0x28, 0x31, 0x4f, 0x8a,
+	0x40, 0x1f, 0xc3, 0x54, 0x78, 0xf9, 0xc2, 0x3f, 0x09, 0x0e, 0x49, 0x6c, 0xf3, 0xb4, 0x2c, 0x7d,
+	0x0c, 0xeb, 0xa4, 0x54, 0x74, 0x1b, 0x6a, 0x94, 0x03, 0xdc, 0xd7, 0x31, 0xdf, 0x5b, 0xc1, 0x27,
+	0x49, 0xe8, 0x1e, 0xcc, 0x76, 0x4f, 0xfb, 0x07, 0x8a, 0x7e, 0x62, 0x9f, 0xe5, 0xb0, 0xf6, 0x2f,
+	0x0c, 0x98, 0xdc, 0xc3, 0x14, 0x3b, 0x98, 0xf2, 0x55, 0xe9, 0x04, 0x6e, 0x4f, 0x54, 0x33, 0xa9,
+	0xa3, 0x82, 0x61, 0x26, 0x1c, 0x63, 0xdf, 0xfd, 0xca, 0x73, 0xe9, 0xa9, 0xf4, 0x5e, 0x8a, 0x40,
+	0x36, 0xcc, 0x44, 0xdd, 0x90, 0x60, 0xf7, 0x39, 0x6e, 0xd2, 0x20, 0xe4, 0xda, 0xd5, 0x9d, 0x0c,
+	0x8e, 0x79, 0xff, 0xd8, 0xa3, 0x21, 0xa6, 0x24, 0x3e, 0x1c, 0x48, 0xd0, 0xfe, 0x6f, 0x03, 0x6a,
+	0xc2, 0x56, 0xc6, 0xd4, 0x3c, 0xc5, 0xbe, 0x4f, 0xda, 0x32, 0x32, 0x62, 0x90, 0x6d, 0x0c, 0x96,
+	0xa2, 0x98, 0xb2, 0xd2, 0xdf, 0x09, 0xcc, 0x94, 0x3b, 0x09, 0xd9, 0xe2, 0xfb, 0xcd, 0xbe, 0x8c,
+	0xc2, 0x14, 0xc1, 0xe6, 0x6c, 0x07, 0x0e, 0x3e, 0x7c, 0xe3, 0x70, 0xc1, 0x86, 0x13, 0x83, 0x6c,
+	0x69, 0xc3, 0x28, 0xf2, 0xf8, 0x46, 0xab, 0x3a, 0xfc, 0x37, 0xc3, 0xb1, 0xa8, 0x30, 0x6b, 0x72,
+	0xb9, 0x3d, 0x71, 0x8c, 0x60, 0x7f, 0x23, 0x8a, 0x3b, 0x5d, 0x7e, 0x38, 0xa9, 0x3b, 0x29, 0x82,
+	0x9d, 0x5c, 0x5c, 0xe9, 0x46, 0x7e, 0x22, 0x89, 0x43, 0x36, 0xf6, 0xad, 0x93, 0x90, 0xd1, 0x35,
+	0xa8, 0x74, 0x70, 0x53, 0x1e, 0x51, 0xd8, 0x4f, 0xfb, 0xdf, 0x0c, 0xa8, 0x89, 0xf5, 0xcb, 0x58,
+	0x68, 0x0c, 0xb2, 0x70, 0x2c, 0x6f, 0x61, 0x03, 0xa6, 0xbd, 0x4e, 0x87, 0xb8, 0x1e, 0xa6, 0xa4,
+	0x2d, 0x3c, 0x30, 0xe9, 0xa8, 0xa8, 0x58, 0xf0, 0x78, 0x22, 0x98, 0x6d, 0xe6, 0x6e, 0x70, 0x41,
+	0x42, 0x69, 0xbc, 0x00, 0xb2, 0x96, 0xd6, 0x06, 0x59, 0x3a, 0x31, 0xd0, 0x52, 0xfb, 0x33, 0xb8,
+	0x21, 0x53, 0x29, 0x4b, 0x5d, 0x6d, 0xcf, 0x3f, 0xdb, 0xf6, 0x42, 0x36, 0xd3, 0xb0, 0x24, 0xfc,
+	0x97, 0x06, 0xac, 0x97, 0x8d, 0x94, 0x3b, 0xb2, 0x01, 0xd3, 0x17, 0xfc, 0x24, 0x78, 0x48, 0x71,
+	0x18, 0x6f, 0x28, 0x15, 0xc5, 0x16, 0xb1, 0x17, 0x11, 0x57, 0x06, 0x2a, 0xff, 0xcd, 0x04, 0x1e,
+	0xf7, 0xdc, 0x96, 0xcc, 0x53, 0x75, 0x47, 0x42, 0x2c, 0x3c, 0x88, 0x7f, 0x12, 0x84, 0x4d, 0x11,
+	0x97, 0x93, 0x4e, 0x0c, 0xb2, 0x7a, 0x30, 0xfd, 0xca, 0xf3, 0xcf, 0x7e, 0xbf, 0x87, 0xdb, 0x1e,
+	0xed, 0x33, 0x97, 0x45, 0xcd, 0x20, 0x14, 0xab, 0x63, 0x38, 0x02, 0x60, 0x2e, 0x8b, 0xfc, 0x50,
+	0x1e, 0x0d, 0xc7, 0x38, 0x25, 0x45, 0xb0, 0xd9, 0x7b, 0x5d, 0x66, 0x44, 0x24, 0xc5, 0xc6, 0x20,
+	0xd3, 0x87, 0x5d, 0x4b, 0x88, 0x1b, 0x57, 0x00, 0x01, 0xa1, 0x0d, 0x98, 0x0b, 0x09, 0x0d, 0xb1,
+	0x1f, 0xc9, 0x5b, 0x4b, 0x24, 0x0b, 0x41, 0x1e, 0x6d, 0xff, 0x0c, 0xe6, 0x15, 0xf5, 0x76, 0x7a,
+	0xcd, 0x33, 0x42, 0x85, 0x99, 0xec, 0x57, 0xec, 0x57, 0x01, 0xa1, 0x2d, 0x98, 0x6e, 0xa7, 0xcc,
+	0x5c, 0xd1, 0xe9, 0xad, 0x6b, 0x7c, 0xf9, 0x94, 0x49, 0x1c, 0x95, 0xc9, 0x7e, 0x91, 0xd4, 0x43,
+	0x95, 0x65, 0x78, 0x95, 0x38, 0x0d, 0x7a, 0x61, 0x24, 0x9d, 0x2f, 0x00, 0xfb, 0xbd, 0x01, 0x96,
+	0x6e, 0x2e, 0xb9, 0xa4, 0x39, 0xed, 0x8c, 0x11, 0xb4, 0x43, 0x4f, 0x60, 0xe2, 0x34, 0xb9, 0xfc,
+	0xa5, 0x47, 0xaf, 0x82, 0x4b, 0x9c, 0x98, 0x8d, 0x65, 0x3c, 0x2b, 0xbe, 0x60, 0x69, 0x2c, 0x2a,
+	0x9c, 0xde, 0x8d, 0x92, 0xeb, 0x5e, 0xd1, 0xbe, 0xb4, 0x36, 0x56, 0xf4, 0xb5, 0x71, 0x3c, 0x53,
+	0x1b, 0xbf, 0x86, 0xb9, 0x9c, 0x0e, 0xa5, 0xee, 0x8c, 0xaf, 0x35, 0x63, 0xca, 0xb5, 0x26, 0xe7,
+	0xad, 0xca, 0x28, 0x6b, 0x79, 0x06, 0xab, 0x5a, 0xd3, 0xbf, 0xd3, 0x35, 0x33, 0x3f, 0x5b, 0x5c,
+	0x9c, 0xdf, 0x1b, 0x30, 0xb3, 0x7d, 0x8e, 0xbd, 0x36, 0x3e, 0xf6, 0xb8, 0x75, 0x1b, 0x30, 0x47,
+	0x2e, 0xbb, 0xa4, 0x49, 0x89, 0x7b, 0x24, 0xb7, 0x83, 0x21, 0x82, 0x3a, 0x87, 0x16, 0xe1, 0xdf,
+	0x24, 0xde, 0x79, 0xca, 0x39, 0x16, 0x87, 0x7f, 0x06, 0xcd, 0x54, 0xee, 0x92, 0xb0, 0x49, 0x7c,
+	0x8a, 0x5b, 0xe2, 0x18, 0x6b, 0x38, 0x0a, 0xc6, 0x0e, 0x93, 0x88, 0x53, 0x55, 0xf9, 0xa0, 0xf0,
+	0x65, 0x35, 0x55, 0xec, 0xdb, 0xe4, 0xb6, 0x28, 0x76, 0x73, 0x0e, 0x6b, 0xbf, 0x85, 0x55, 0xad,
+	0x4c, 0xe9, 0xe5, 0x1f, 0xc2, 0x0c, 0x56, 0xf0, 0x32, 0xce, 0xc5, 0xe5, 0x2a, 0x33, 0x20, 0xc3,
+	0xc6, 0x8e, 0xe5, 0xc9, 0xe2, 0xe9, 0x6c, 0xf9, 0x2e, 0x81, 0x3b, 0xa2, 0x65, 0x69, 0x80, 0x8f,
+	0xeb, 0x03, 0xbc, 0x9a, 0x09, 0xf0, 0x1e, 0x5c, 0xcb, 0x2b, 0x7b, 0xa5, 0x08, 0xcf, 0x3b, 0xaa,
+	0x32, 0x9a, 0xa3, 0xfe, 0xc5, 0x80, 0x35, 0xbd, 0xa3, 0x46, 0x0c, 0xf3, 0x47, 0xb9, 0x30, 0x5f,
+	0x4a, 0xc2, 0x3c, 0x33, 0x9d, 0x64, 0x42, 0x2f, 0x61, 0x59, 0xf1, 0xf1, 0xf6, 0x48, 0x1a, 0x97,
+	0x8d, 0xb0, 0x3b, 0x50, 0xe7, 0xfb, 0x09, 0x47, 0xf4, 0x4b, 0xd6, 0xf5, 0x65, 0x2e, 0x3f, 0x39,
+	0x08, 0x64, 0x85, 0xab, 0x3b, 0x02, 0x60, 0xee, 0x62, 0x37, 0x82, 0xb8, 0xb6, 0xb1, 0xdf, 0x0c,
+	0xc7, 0x2a, 0x2f, 0x17, 0x3a, 0xe3, 0xf0, 0xdf, 0xcc, 0xd4, 0x78, 0xc7, 0x6c, 0x53, 0x59, 0xf9,
+	0x15, 0x8c, 0x72, 0x43, 0x4a, 0x24, 0x0e, 0xbb, 0x01, 0xd8, 0xfb, 0xb0, 0xa2, 0x19, 0x23, 0x7d,
+	0x7b, 0x3f, 0x77, 0x7f, 0x45, 0x69, 0x8a, 0x88, 0x99, 0x93, 0x04, 0x11, 0xc0, 0x4a, 0x92, 0x8d,
+	0x0a, 0xd2, 0x47, 0x0e, 0xe7, 0x2b, 0xdc, 0x46, 0x4e, 0x61, 0x36, 0x2b, 0xec, 0x4a, 0xe1, 0x78,
+	0x1f, 0x6a, 0xbc, 0x11, 0xcf, 0x8a, 0x78, 0xa9, 0x69, 0x82, 0xc3, 0xf6, 0x94, 0x1a, 0x53, 0x74,
+	0xd2, 0xb0, 0x00, 0x7c, 0x90, 0x0b, 0xc0, 0x85, 0xa2, 0xa4, 0x28, 0xf1, 0xe2, 0xbf, 0x1a, 0xb0,
+	0xb0, 0xd3, 0x6b, 0x9f, 0x31, 0xf2, 0x5b, 0xdc, 0xba, 0xa2, 0x03, 0xd7, 0x01, 0x44, 0xe7, 0x91,
+	0x0d, 0xe5, 0xe2, 0xa6, 0x1c, 0x05, 0xc3, 0x8e, 0x59, 0xcc, 0xf8, 0x03, 0x4c, 0x29, 0x09, 0x7d,
+	0x79, 0x81, 0x55, 0x51, 0x49, 0xf3, 0x68, 0x5c, 0x69, 0x1e, 0x31, 0xb7, 0x86, 0x7d, 0xa7, 0x27,
+	0xae, 0xaf, 0x93, 0x8e, 0x84, 0x32, 0x7d, 0xcf, 0x5a, 0xae, 0xef, 0xf9, 0x04, 0x16, 0xb3, 0x66,
+	0x64, 0x6e, 0xae, 0xcf, 0x8e, 0x5e, 0x88, 0xe6, 0xca, 0x94, 0x13, 0x83, 0xca, 0xf1, 0xf2, 0xd9,
+	0xc9, 0x09, 0x61, 0x97, 0x75, 0xb2, 0x1b, 0xf8, 0x27, 0x5e, 0x6b, 0x58, 0x04, 0xff, 0xf3, 0x18,
+	0x2c, 0xb0, 0x61, 0x6f, 0x08, 0xbd, 0x08, 0xc2, 0xb3, 0xa4, 0x0f, 0x96, 0x74, 0xdc, 0x8c, 0xb2,
+	0x8e, 0xdb, 0x58, 0xae, 0xe3, 0xa6, 0x36, 0x2c, 0x2b, 0x83, 0x1b, 0x96, 0xdf, 0xa5, 0x2f, 0x9a,
+	0x34, 0x3b, 0x6b, 0x6a, 0xb3, 0x33, 0xd3, 0xd8, 0x9c, 0x18, 0xd2, 0xd8, 0x9c, 0x1c, 0xb5, 0xb1,
+	0x39, 0x55, 0xd6, 0xd8, 0xb4, 0x29, 0x2c, 0x32, 0xaf, 0xb1, 0xf1, 0xad, 0x90, 0x13, 0x9c, 0xa0,
+	0x47, 0xf9, 0xe5, 0xf8, 0xcc, 0xf3, 0xdd, 0xf8, 0x72, 0xcc, 0x7e, 0x8b, 0x03, 0x35, 0x6b, 0x0c,
+	0xba, 0xd2, 0x67, 0x31, 0xc8, 0x16, 0x25, 0x24, 0x38, 0x0a, 0xe2, 0x60, 0x92, 0x90, 0x5c, 0x2c,
+	0x2f, 0x39, 0x81, 0x4b, 0xc8, 0xfe, 0x75, 0x15, 0xd6, 0xcb, 0x96, 0x79, 0xc8, 0xdb, 0x96, 0x6e,
+	0x17, 0x8f, 0xd6, 0xce, 0xdf, 0x80, 0x39, 0x05, 0xf1, 0x06, 0x77, 0x84, 0x56, 0x53, 0x4e, 0x1e,
+	0xcd, 0xdc, 0x4c, 0xfc, 0x73, 0x2f, 0x0c, 0xfc, 0x0e, 0xf1, 0xc5, 0xe2, 0x4d, 0x39, 0x2a, 0x2a,
+	0xd9, 0x20, 0x35, 0x65, 0x83, 0x3c, 0x85, 0x25, 0x3f, 0x1b, 0x7c, 0x87, 0x41, 0x8f, 0xdd, 0x3e,
+	0x26, 0xf8, 0x78, 0x3d, 0x11, 0xed, 0xc0, 0x5c, 0x8e, 0x20, 0xef, 0x9a, 0x66, 0x92, 0x20, 0x72,
+	0x21, 0xed, 0xe4, 0x07, 0xa0, 0xdf, 0x85, 0x19, 0x2f, 0x5d, 0xc0, 0xc8, 0x9c, 0xe2, 0x19, 0x66,
+	0x25, 0x99, 0x20, 0xbf, 0xba, 0x4e, 0x86, 0x1d, 0x3d, 0x84, 0xf9, 0x16, 0xa6, 0xe4, 0x02, 0xf7,
+	0x9f, 0xf3, 0x8d, 0xfb, 0x9a, 0x75, 0x0f, 0x81, 0x2b, 0x5d, 0x24, 0x14, 0xb9, 0xb7, 0x77, 0x23,
+	0x73, 0x9a, 0xfb, 0xa1, 0x48, 0x60, 0x4e, 0x71, 0xb3, 0xb7, 0xbd, 0x1d, 0x71, 0x57, 0x9b, 0xe1,
+	0xb1, 0xab, 0x27, 0xa2, 0x1d, 0x58, 0xd3, 0x12, 0x9e, 0xc9, 0xfb, 0x5c, 0x9d, 0x47, 0xd3, 0x40,
+	0x1e, 0xf4, 0x23, 0x30, 0xbb, 0x61, 0xd0, 0x0d, 0x3d, 0x42, 0x71, 0x18, 0xf7, 0x47, 0x0e, 0x42,
+	0x72, 0xe2, 0x5d, 0xca, 0xce, 0x7c, 0x29, 0xdd, 0xfe, 0x24, 0x29, 0x87, 0xaf, 0x31, 0x73, 0x95,
+	0x8f, 0xfd, 0xe6, 0xd0, 0x0b, 0xae, 0x3c, 0xfb, 0x2b, 0x23, 0x06, 0x35, 0xac, 0x4a, 0x76, 0xd2,
+	0x22, 0x54, 0x7b, 0x3e, 0xf5, 0xda, 0x72, 0x23, 0x09, 0x80, 0xcd, 0x83, 0xf9, 0x26, 0x89, 0xf7,
+	0x91, 0x80, 0xec, 0x9b, 0x70, 0x23, 0x6d, 0x3a, 0x67, 0x54, 0x95, 0xdd, 0xd2, 0x47, 0xbc, 0x11,
+	0xc8, 0xa8, 0xdb, 0x6d, 0x0f, 0x0f, 0x3d, 0x06, 0xfc, 0x16, 0x4c, 0x25, 0xbc, 0x83, 0x0e, 0xd2,
+	0x98, 0x31, 0xc4, 0x5d, 0x67, 0x0e, 0xb0, 0x1e, 0x66, 0xaa, 0x8a, 0x14, 0x26, 0x95, 0x38, 0x82,
+	0x25, 0xa9, 0xc4, 0x4e, 0x3f, 0xa3, 0xc6, 0x3d, 0x98, 0x0d, 0xc2, 0x16, 0xf6, 0xbd, 0x6f, 0xb2,
+	0xf5, 0x2c, 0x87, 0x2d, 0x91, 0xf8, 0x00, 0xe6, 0x5f, 0x05, 0xc1, 0x59, 0xaf, 0x3b, 0xca, 0xfb,
+	0xe4, 0xff, 0x18, 0x80, 0x54, 0xee, 0x0f, 0xc8, 0x32, 0x89, 0x16, 0x15, 0x45, 0x8b, 0x62, 0xee,
+	0x19, 0x1f, 0x31, 0xf7, 0x54, 0xf5, 0xb9, 0xa7, 0xe8, 0x93, 0x9a, 0xd6, 0x27, 0xf7, 0xe1, 0x9a,
+	0x8a, 0xe1, 0x53, 0x8a, 0x44, 0x53, 0xc0, 0xdb, 0x4f, 0xe0, 0x7a, 0x3e, 0x0d, 0x0c, 0x71, 0xd7,
+	0x7f, 0x8d, 0x8b, 0x6a, 0xfa, 0xc5, 0xdb, 0xb7, 0x07, 0xca, 0xb0, 0x52, 0x7f, 0x3d, 0x85, 0x89,
+	0x53, 0x82, 0x5d, 0x12, 0x46, 0xf2, 0x78, 0x63, 0xf1, 0xe4, 0x93, 0x1b, 0xfe, 0x05, 0x67, 0x71,
+	0x62, 0x56, 0x56, 0xec, 0x5c, 0x4c, 0xf1, 0x51, 0xf7, 0xc8, 0x79, 0x25, 0xbd, 0x9a, 0x22, 0xd0,
+	0x13, 0x58, 0xf8, 0x79, 0xe0, 0xf9, 0x6f, 0x02, 0xea, 0x9d, 0x48, 0x0f, 0x31, 0x3e, 0x91, 0xb3,
+	0x75, 0x24, 0x56, 0xfc, 0x70, 0xf3, 0x2c, 0x3f, 0x40, 0x38, 0x5a, 0x43, 0x41, 0x5b, 0xb0, 0xc8,
+	0x9f, 0x4c, 0xf2, 0x23, 0x44, 0x8b, 0x50, 0x4b, 0x43, 0x9f, 0xc3, 0x72, 0x44, 0x9a, 0xbd, 0xd0,
+	0xa3, 0xfd, 0xfc, 0x30, 0xe1, 0xfe, 0x32, 0x32, 0x4b, 0x9c, 0xc7, 0x38, 0xf2, 0x9a, 0xdb, 0x3d,
+	0x7a, 0x7a, 0x14, 0x91, 0x90, 0x07, 0xd8, 0xa4, 0x48, 0xb3, 0x05, 0x42, 0x86, 0xfb, 0x00, 0x47,
+	0xd1, 0x45, 0x10, 0xba, 0xb2, 0xbf, 0x58, 0x24, 0xb0, 0x8a, 0x75, 0x4c, 0x70, 0x48, 0xc2, 0xb7,
+	0xc1, 0x19, 0xf1, 0x65, 0xf2, 0x56, 0x51, 0x8c, 0xa3, 0x83, 0x2f, 0xb7, 0x29, 0x25, 0x9d, 0x2e,
+	0x8d, 0xe2, 0x37, 0x51, 0x05, 0xc5, 0x22, 0x39, 0xf2, 0x5a, 0xbe, 0xe7, 0xb7, 0x0e, 0x49, 0x33,
+	0x94, 0x29, 0x7a, 0xca, 0xc9, 0x22, 0x59, 0x7c, 0x76, 0xf0, 0xa5, 0x4c, 0x97, 0x87, 0xde, 0x37,
+	0x22, 0x19, 0xd7, 0x9d, 0x1c, 0x16, 0x7d, 0x06, 0x53, 0x1d, 0x1c, 0x46, 0xa7, 0xb8, 0x4d, 0x42,
+	0x9e, 0x6f, 0x67, 0x65, 0x41, 0x52, 0xe2, 0xe1, 0x75, 0xcc, 0xe0, 0xa4, 0xbc, 0xf6, 0x6d, 0xb8,
+	0x95, 0xbe, 0xec, 0xe5, 0x02, 0x28, 0x49, 0x29, 0xb7, 0xe1, 0x56, 0x9a, 0x6d, 0x06, 0x30, 0xa5,
+	0xdf, 0x24, 0x94, 0x31, 0xfd, 0x38, 0xbd, 0x58, 0x2a, 0x64, 0xf5, 0xf2, 0x53, 0x65, 0x87, 0x1f,
+	0x71, 0x50, 0x9d, 0x95, 0xed, 0x11, 0x85, 0xf3, 0xa5, 0xe7, 0xbb, 0x8e, 0x60, 0xb1, 0x7f, 0x65,
+	0x14, 0x4e, 0x53, 0xbb, 0xa7, 0x38, 0x28, 0x4f, 0xa5, 0x0d, 0x98, 0x3e, 0xc1, 0x5e, 0xbb, 0x17,
+	0xa6, 0xed, 0xef, 0xba, 0xa3, 0xa2, 0x78, 0x8f, 0x1b, 0xd3, 0xa4, 0xff, 0x5d, 0x77, 0x62, 0x30,
+	0xad, 0x14, 0xe3, 0xfa, 0x4a, 0x51, 0xcd, 0x54, 0x8a, 0x3b, 0x60, 0xa7, 0x0e, 0xcb, 0xeb, 0x97,
+	0x38, 0xe3, 0x1d, 0x2c, 0xe5, 0xe8, 0xa2, 0x94, 0x97, 0x1a, 0xb0, 0x0e, 0x40, 0xd8, 0xa3, 0xca,
+	0xdb, 0x7e, 0x97, 0x24, 0x57, 0x8d, 0x14, 0xc3, 0xc6, 0xf1, 0xcb, 0xad, 0xb8, 0x5f, 0xd5, 0x1d,
+	0x09, 0xe9, 0x2e, 0x18, 0xf6, 0x5d, 0xb8, 0xad, 0x55, 0x51, 0xa8, 0x10, 0xeb, 0xb8, 0xf5, 0xef,
+	0x77, 0x61, 0x9c, 0x71, 0xa0, 0x03, 0xa8, 0x89, 0x40, 0x41, 0x25, 0x6f, 0xc5, 0xd6, 0x72, 0x01,
+	0x2f, 0xed, 0x5c, 0x7a, 0xff, 0x9b, 0xff, 0xf8, 0xbb, 0xb1, 0x39, 0x1b, 0xf8, 0xd7, 0x85, 0xfc,
+	0x01, 0xf7, 0x47, 0xc6, 0x7d, 0x44, 0x60, 0x5a, 0x30, 0xf3, 0xc7, 0x57, 0xb4, 0x9a, 0x1b, 0xae,
+	0xbe, 0x0e, 0x5b, 0x6b, 0x7a, 0xa2, 0x14, 0xb0, 0xca, 0x05, 0x2c, 0xd9, 0xd7, 0x52, 0x01, 0x9b,
+	0xc7, 0x8c, 0x43, 0x8a, 0x11, 0x86, 0xaa, 0x62, 0xf4, 0x8f, 0xd0, 0xd6, 0x9a, 0x9e, 0x98, 0x15,
+	0x63, 0x69, 0xc5, 0xbc, 0x86, 0xca, 0x3e, 0xa1, 0x68, 0x21, 0xfb, 0xdd, 0x88, 0x98, 0x56, 0xfb,
+	0x31, 0x49, 0x3c, 0x1d, 0x5a, 0x50, 0xa6, 0x7b, 0x27, 0x56, 0xfa, 0x5b, 0xf4, 0x25, 0xd4, 0xc4,
+	0x6e, 0x92, 0xee, 0x2e, 0x7c, 0x1b, 0x64, 0x2d, 0x17, 0xf0, 0xd9, 0x79, 0xef, 0x6b, 0xe7, 0xbd,
+	0x84, 0x19, 0xf5, 0x53, 0x1e, 0xb4, 0x26, 0x67, 0xd1, 0x7e, 0x36, 0x64, 0xdd, 0x28, 0xa1, 0x4a,
+	0x49, 0x0f, 0xb8, 0xa4, 0xbb, 0x76, 0x43, 0x23, 0x69, 0xd3, 0x55, 0x46, 0x31, 0x07, 0xbd, 0x37,
+	0x60, 0x81, 0xed, 0xfd, 0xdc, 0x47, 0x42, 0xe8, 0xb6, 0x6c, 0xb8, 0x0e, 0xfa, 0x84, 0xc8, 0x5a,
+	0xca, 0x30, 0x25, 0x0a, 0x6c, 0x72, 0x05, 0x3e, 0x46, 0x1f, 0x71, 0x05, 0x94, 0xe2, 0x1e, 0x6d,
+	0xbe, 0xcb, 0x1c, 0x09, 0xbe, 0x15, 0xda, 0xa1, 0x3f, 0x80, 0x9a, 0x58, 0x5d, 0x54, 0xf2, 0x81,
+	0x81, 0xb5, 0x5c, 0xc0, 0x4b, 0x59, 0xeb, 0x5c, 0x96, 0x69, 0xe9, 0xdc, 0xca, 0xec, 0xfb, 0x29,
+	0x54, 0x0f, 0x78, 0x84, 0x7d, 0xe8, 0xcc, 0x5b, 0x65, 0x33, 0xff, 0x1c, 0x26, 0xe3, 0xc7, 0x79,
+	0x24, 0xee, 0x29, 0x9a, 0x8f, 0x0b, 0xac, 0x15, 0x0d, 0x45, 0x0a, 0xf8, 0x98, 0x0b, 0xb8, 0x6d,
+	0xaf, 0xeb, 0xd6, 0x09, 0x27, 0x6f, 0xf4, 0x4c, 0xd6, 0x39, 0xd4, 0xf7, 0x09, 0x4d, 0xdf, 0xed,
+	0xd1, 0x0d, 0x35, 0x76, 0x0b, 0x1f, 0x01, 0x58, 0xeb, 0x65, 0x64, 0x29, 0xfa, 0x1e, 0x17, 0xdd,
+	0x40, 0x43, 0x44, 0x23, 0x0a, 0xd7, 0xf2, 0x2f, 0xef, 0x32, 0x36, 0x4b, 0xde, 0xea, 0xad, 0x1b,
+	0x25, 0xd4, 0xb8, 0x1c, 0x71, 0xc1, 0x37, 0xec, 0x55, 0x45, 0x70, 0x2b, 0x2f, 0xa1, 0x05, 0x33,
+	0xea, 0xe3, 0xba, 0xf4, 0xae, 0xe6, 0x31, 0xdf, 0x5a, 0xd1, 0x50, 0xa4, 0x24, 0x9b, 0x4b, 0x5a,
+	0x43, 0x96, 0xce, 0xc4, 0x13, 0xc6, 0x1e, 0x21, 0x0a, 0x33, 0xf2, 0x65, 0x9c, 0xbf, 0x8a, 0xa7,
+	0xa6, 0xe9, 0x5e, 0xde, 0xad, 0x1b, 0x25, 0x54, 0x29, 0xf0, 0x23, 0x2e, 0xf0, 0x16, 0xba, 0xa9,
+	0x13, 0xc8, 0xcb, 0x41, 0xb4, 0xe9, 0x93, 0x4b, 0xca, 0xb6, 0x1c, 0xda, 0x27, 0x34, 0xf7, 0x00,
+	0x88, 0x6c, 0x75, 0xcd, 0xf4, 0xef, 0x8a, 0xd6, 0xed, 0x81, 0x3c, 0x59, 0x1f, 0xa3, 0x55, 0xed,
+	0xe2, 0x4a, 0x69, 0xef, 0xf8, 0x47, 0xb7, 0xea, 0x1b, 0x4d, 0x26, 0x66, 0x8a, 0x0f, 0x48, 0xd6,
+	0xcd, 0x52, 0xba, 0x94, 0xbb, 0xc1, 0xe5, 0xda, 0x48, 0x9b, 0x77, 0x98, 0xa2, 0x8f, 0xbe, 0x96,
+	0xa2, 0xfe, 0xd6, 0x80, 0x39, 0x96, 0x35, 0x54, 0xf1, 0x37, 0x33, 0xb9, 0x44, 0x23, 0xbf, 0x51,
+	0xce, 0x20, 0x15, 0xf8, 0x1d, 0xae, 0xc0, 0xa7, 0xe8, 0xe9, 0x88, 0x79, 0x27, 0xab, 0xd4, 0x9f,
+	0xf2, 0x6f, 0x8b, 0x33, 0x4d, 0xfd, 0x8c, 0xc9, 0x9a, 0xb7, 0x09, 0xab, 0x51, 0xce, 0x30, 0x8a,
+	0x53, 0xd4, 0xf6, 0x3e, 0xfa, 0x7b, 0x43, 0x7c, 0x20, 0x99, 0xd1, 0x20, 0x6b, 0xb4, 0x4e, 0x85,
+	0x5b, 0x03, 0x38, 0x3e, 0xd4, 0x2f, 0x19, 0xbd, 0xba, 0x3c, 0xf7, 0x28, 0xbd, 0xe5, 0x4c, 0xee,
+	0x29, 0x34, 0xb8, 0xad, 0xf5, 0x32, 0xb2, 0xd4, 0xa6, 0xc1, 0xb5, 0xb1, 0x90, 0xa9, 0x0d, 0x13,
+	0x1c, 0x51, 0xf4, 0x17, 0x06, 0xcc, 0xf2, 0xf0, 0x48, 0x65, 0xae, 0x67, 0x17, 0xbf, 0x20, 0xf4,
+	0x66, 0x29, 0x5d, 0x4a, 0x7d, 0xca, 0xa5, 0x3e, 0x46, 0x0f, 0x47, 0x8e, 0x0d, 0xa6, 0xc9, 0x3b,
+	0x98, 0xd8, 0x76, 0xdd, 0xb7, 0x38, 0x49, 0x42, 0x9a, 0x86, 0xb4, 0xb5, 0xa2, 0xa1, 0x48, 0xa9,
+	0xbf, 0xcd, 0xa5, 0xfe, 0xd0, 0x7e, 0x32, 0xaa, 0x54, 0x76, 0x08, 0xdc, 0xc4, 0xae, 0xcb, 0x92,
+	0xfe, 0x9f, 0x19, 0x00, 0x0e, 0xe9, 0x04, 0xe7, 0xe4, 0xc3, 0x15, 0xf8, 0x3d, 0xae, 0xc0, 0xe7,
+	0xf6, 0x27, 0x57, 0x52, 0x20, 0xe4, 0x52, 0x99, 0x0e, 0xbf, 0x14, 0xb9, 0x2a, 0xd7, 0xa0, 0xcc,
+	0xe6, 0x2a, 0x7d, 0x93, 0xda, 0xba, 0x3d, 0x90, 0x47, 0xea, 0xf7, 0x90, 0xeb, 0x77, 0x0f, 0xdd,
+	0xd1, 0x26, 0xcd, 0x78, 0xd0, 0xa3, 0xa6, 0x10, 0x1b, 0xf0, 0xa4, 0xa5, 0x36, 0x97, 0x32, 0xc1,
+	0x56, 0xec, 0x53, 0x59, 0xe9, 0x4b, 0xae, 0x42, 0x1c, 0x9c, 0xaa, 0x3b, 0xca, 0xf4, 0xfd, 0xf8,
+	0x83, 0x62, 0x55, 0xa6, 0x76, 0x4e, 0xcb, 0xce, 0x9d, 0x23, 0x74, 0x9d, 0xa8, 0xfb, 0x5c, 0xee,
+	0x1d, 0x6b, 0x98, 0x5c, 0xe6, 0xf9, 0xaf, 0x60, 0x92, 0xa5, 0x23, 0xde, 0x5f, 0x31, 0x33, 0x69,
+	0x46, 0xe9, 0x1e, 0x59, 0xb3, 0xe9, 0x13, 0x1e, 0x43, 0xdb, 0xb7, 0xb8, 0x84, 0x55, 0xb4, 0xa2,
+	0x93, 0x20, 0x9a, 0x35, 0x38, 0x3e, 0x79, 0x8b, 0xb9, 0x73, 0x33, 0x14, 0x0e, 0xdb, 0xd9, 0x36,
+	0xd6, 0x1d, 0x3e, 0xff, 0xba, 0x55, 0x3e, 0x3f, 0xd3, 0xdd, 0x05, 0xd8, 0x27, 0x54, 0x36, 0xba,
+	0x90, 0xa5, 0x6a, 0x9f, 0xed, 0x7e, 0x95, 0x9c, 0xc1, 0xa5, 0x14, 0xb4, 0xc6, 0xa5, 0x88, 0x7e,
+	0x79, 0xb4, 0x79, 0xdc, 0x7f, 0xc4, 0xba, 0x02, 0x9b, 0xef, 0xb8, 0x9c, 0x6f, 0xd1, 0xcf, 0xa0,
+	0x26, 0xba, 0x59, 0xf2, 0x6c, 0x57, 0x68, 0x84, 0x59, 0xcb, 0x05, 0xfc, 0x40, 0x01, 0x6d, 0xce,
+	0x98, 0x9e, 0xca, 0x7f, 0x69, 0xc0, 0x92, 0xb8, 0xdc, 0xe4, 0x5b, 0x40, 0x69, 0x5f, 0x3a, 0x47,
+	0xb1, 0xee, 0xe5, 0xae, 0x44, 0x65, 0x57, 0xee, 0x27, 0x5c, 0x83, 0xfb, 0xf6, 0x5d, 0x9d, 0x23,
+	0xd5, 0xce, 0xf4, 0xe6, 0x29, 0xa5, 0x5d, 0xe6, 0xd4, 0x6f, 0xf8, 0x4e, 0xcc, 0x6b, 0xb2, 0xaa,
+	0x6d, 0x70, 0x4b, 0xfb, 0x4b, 0xd5, 0xb4, 0x1f, 0x71, 0xf1, 0x1f, 0xa1, 0xd1, 0xc4, 0x73, 0x4f,
+	0x88, 0x90, 0xb8, 0xaa, 0x27, 0x86, 0x77, 0x28, 0xa4, 0x27, 0xac, 0xd1, 0x3d, 0xc1, 0xb4, 0x11,
+	0x37, 0xac, 0x2b, 0x79, 0xe3, 0x5e, 0xee, 0x6a, 0x56, 0xa6, 0x90, 0xf4, 0xcd, 0xfd, 0x11, 0x7d,
+	0xf3, 0x27, 0xa2, 0x6c, 0x2b, 0x33, 0x45, 0x83, 0xf5, 0xc8, 0x56, 0x6c, 0x5d, 0xc3, 0x65, 0xf0,
+	0xa9, 0x41, 0x55, 0x01, 0xfd, 0xc2, 0xe0, 0x9f, 0x8a, 0xe6, 0x6c, 0x11, 0x1d, 0x97, 0x81, 0x5a,
+	0x68, 0x5f, 0x46, 0xf8, 0x38, 0xfb, 0x13, 0x2e, 0xfd, 0x11, 0x7a, 0x30, 0x92, 0x03, 0x36, 0x9b,
+	0x5c, 0xd8, 0xaf, 0x0c, 0xb0, 0xb4, 0x21, 0x22, 0x74, 0x29, 0x17, 0x67, 0x7d, 0x94, 0x0b, 0x94,
+	0xd2, 0xce, 0xcc, 0xa7, 0x5c, 0xaf, 0x27, 0xd6, 0x55, 0xf4, 0x62, 0xf1, 0xf2, 0x57, 0x06, 0xff,
+	0x1a, 0x20, 0xa7, 0x97, 0xec, 0xea, 0x0c, 0x74, 0x92, 0xa5, 0x23, 0x8a, 0x81, 0xb9, 0x13, 0xc5,
+	0x50, 0x6d, 0xc4, 0x7b, 0x30, 0xfa, 0x07, 0x03, 0x56, 0xb5, 0x6e, 0x92, 0xea, 0x0c, 0x90, 0x68,
+	0x6d, 0x94, 0x3b, 0x2a, 0xdb, 0x1f, 0xb2, 0x3f, 0xe3, 0xba, 0xfd, 0xc0, 0xba, 0x92, 0x6e, 0xcc,
+	0x55, 0x7f, 0xae, 0x75, 0xd5, 0x17, 0x04, 0xb7, 0xe9, 0xe9, 0x60, 0x57, 0x5d, 0xcf, 0x37, 0x05,
+	0xc5, 0xa0, 0xab, 0xba, 0xe9, 0x94, 0x8f, 0x3a, 0xae, 0xf1, 0x7f, 0x60, 0xfd, 0xe4, 0x7f, 0x03,
+	0x00, 0x00, 0xff, 0xff, 0x76, 0x58, 0x59, 0x84, 0x12, 0x3b, 0x00, 0x00,
 }
@@ -18,6 +18,29 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+type DigestFrequency int32
+
+const (
+	// Daily digest.
+	DigestFrequency_DAILY DigestFrequency = 0
+	// Weekly digest.
+	DigestFrequency_WEEKLY DigestFrequency = 1
+)
+
+var DigestFrequency_name = map[int32]string{
+	0: "DAILY",
+	1: "WEEKLY",
+}
+var DigestFrequency_value = map[string]int32{
+	"DAILY":  0,
+	"WEEKLY": 1,
+}
+
+func (x DigestFrequency) String() string {
+	return proto.EnumName(DigestFrequency_name, int32(x))
+}
+func (DigestFrequency) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+
 // Request the organizations defined in the system.
 type ListOrganizationRequest struct {
 	// Max number of organizations to return in the result-set.
@@ -85,6 +108,8 @@ type GetOrganizationResponse struct {
 	CreatedAt string `protobuf:"bytes,5,opt,name=createdAt" json:"createdAt,omitempty"`
 	// When the user was last updated (excludes changes in application access).
 	UpdatedAt string `protobuf:"bytes,6,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// UUID of the organization (can be used instead of the ID in the REST API paths).
+	Uuid string `protobuf:"bytes,7,opt,name=uuid" json:"uuid,omitempty"`
 }
 
 func (m *GetOrganizationResponse) Reset()                    { *m = GetOrganizationResponse{} }
@@ -134,6 +159,13 @@ func (m *GetOrganizationResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (m *GetOrganizationResponse) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
 // Add a new organization.
 type CreateOrganizationRequest struct {
 	// Organization name.
@@ -468,235 +500,1330 @@ func (m *ListOrganizationUsersResponse) GetResult() []*GetOrganizationUserRespon
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ListOrganizationRequest)(nil), "api.ListOrganizationRequest")
-	proto.RegisterType((*OrganizationRequest)(nil), "api.OrganizationRequest")
-	proto.RegisterType((*GetOrganizationResponse)(nil), "api.GetOrganizationResponse")
-	proto.RegisterType((*CreateOrganizationRequest)(nil), "api.CreateOrganizationRequest")
-	proto.RegisterType((*CreateOrganizationResponse)(nil), "api.CreateOrganizationResponse")
-	proto.RegisterType((*UpdateOrganizationRequest)(nil), "api.UpdateOrganizationRequest")
-	proto.RegisterType((*ListOrganizationResponse)(nil), "api.ListOrganizationResponse")
-	proto.RegisterType((*OrganizationEmptyResponse)(nil), "api.OrganizationEmptyResponse")
-	proto.RegisterType((*OrganizationUserRequest)(nil), "api.OrganizationUserRequest")
-	proto.RegisterType((*DeleteOrganizationUserRequest)(nil), "api.DeleteOrganizationUserRequest")
-	proto.RegisterType((*ListOrganizationUsersRequest)(nil), "api.ListOrganizationUsersRequest")
-	proto.RegisterType((*GetOrganizationUserRequest)(nil), "api.GetOrganizationUserRequest")
-	proto.RegisterType((*GetOrganizationUserResponse)(nil), "api.GetOrganizationUserResponse")
-	proto.RegisterType((*ListOrganizationUsersResponse)(nil), "api.ListOrganizationUsersResponse")
+type SavedNodeFilterRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// ID of the saved node filter.
+	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Organization service
+func (m *SavedNodeFilterRequest) Reset()                    { *m = SavedNodeFilterRequest{} }
+func (m *SavedNodeFilterRequest) String() string            { return proto.CompactTextString(m) }
+func (*SavedNodeFilterRequest) ProtoMessage()               {}
+func (*SavedNodeFilterRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{14} }
 
-type OrganizationClient interface {
-	// Get organization list.
-	List(ctx context.Context, in *ListOrganizationRequest, opts ...grpc.CallOption) (*ListOrganizationResponse, error)
-	// Get data for a particular organization.
-	Get(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error)
-	// Create a new organization.
-	Create(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
-	// Update an existing organization.
-	Update(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
-	// Delete an organization.
-	Delete(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
-	// Get organization's user list.
-	ListUsers(ctx context.Context, in *ListOrganizationUsersRequest, opts ...grpc.CallOption) (*ListOrganizationUsersResponse, error)
-	// Get data for a particular organization user.
-	GetUser(ctx context.Context, in *GetOrganizationUserRequest, opts ...grpc.CallOption) (*GetOrganizationUserResponse, error)
-	// Add a new user to an organization.
-	AddUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
-	// Update a user in an organization.
-	UpdateUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
-	// Delete a user from an organization.
-	DeleteUser(ctx context.Context, in *DeleteOrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+func (m *SavedNodeFilterRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
 }
 
-type organizationClient struct {
-	cc *grpc.ClientConn
+func (m *SavedNodeFilterRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
 }
 
-func NewOrganizationClient(cc *grpc.ClientConn) OrganizationClient {
-	return &organizationClient{cc}
+type CreateSavedNodeFilterRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Name of the filter.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Tags which the nodes must all have.
+	Tags []string `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+	// Pattern which the node name must match (* and ? wildcards).
+	NamePattern string `protobuf:"bytes,4,opt,name=namePattern" json:"namePattern,omitempty"`
+	// Only match the nodes from which no uplink has been received within
+	// the given number of hours (0 = disabled).
+	NotSeenHours uint32 `protobuf:"varint,5,opt,name=notSeenHours" json:"notSeenHours,omitempty"`
 }
 
-func (c *organizationClient) List(ctx context.Context, in *ListOrganizationRequest, opts ...grpc.CallOption) (*ListOrganizationResponse, error) {
-	out := new(ListOrganizationResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/List", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateSavedNodeFilterRequest) Reset()                    { *m = CreateSavedNodeFilterRequest{} }
+func (m *CreateSavedNodeFilterRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateSavedNodeFilterRequest) ProtoMessage()               {}
+func (*CreateSavedNodeFilterRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{15} }
+
+func (m *CreateSavedNodeFilterRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
 	}
-	return out, nil
+	return 0
 }
 
-func (c *organizationClient) Get(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error) {
-	out := new(GetOrganizationResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/Get", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateSavedNodeFilterRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *organizationClient) Create(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
-	out := new(CreateOrganizationResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/Create", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateSavedNodeFilterRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
-	return out, nil
+	return nil
 }
 
-func (c *organizationClient) Update(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
-	out := new(OrganizationEmptyResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/Update", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateSavedNodeFilterRequest) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
 	}
-	return out, nil
+	return ""
 }
 
-func (c *organizationClient) Delete(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
-	out := new(OrganizationEmptyResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/Delete", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateSavedNodeFilterRequest) GetNotSeenHours() uint32 {
+	if m != nil {
+		return m.NotSeenHours
 	}
-	return out, nil
+	return 0
 }
 
-func (c *organizationClient) ListUsers(ctx context.Context, in *ListOrganizationUsersRequest, opts ...grpc.CallOption) (*ListOrganizationUsersResponse, error) {
-	out := new(ListOrganizationUsersResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/ListUsers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CreateSavedNodeFilterResponse struct {
+	// ID of the created filter.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 }
 
-func (c *organizationClient) GetUser(ctx context.Context, in *GetOrganizationUserRequest, opts ...grpc.CallOption) (*GetOrganizationUserResponse, error) {
-	out := new(GetOrganizationUserResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/GetUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateSavedNodeFilterResponse) Reset()                    { *m = CreateSavedNodeFilterResponse{} }
+func (m *CreateSavedNodeFilterResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateSavedNodeFilterResponse) ProtoMessage()               {}
+func (*CreateSavedNodeFilterResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{16} }
+
+func (m *CreateSavedNodeFilterResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return 0
 }
 
-func (c *organizationClient) AddUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
-	out := new(OrganizationEmptyResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/AddUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type GetSavedNodeFilterResponse struct {
+	// ID of the filter.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Name of the filter.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Tags which the nodes must all have.
+	Tags []string `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+	// Pattern which the node name must match (* and ? wildcards).
+	NamePattern string `protobuf:"bytes,4,opt,name=namePattern" json:"namePattern,omitempty"`
+	// Only match the nodes from which no uplink has been received within
+	// the given number of hours (0 = disabled).
+	NotSeenHours uint32 `protobuf:"varint,5,opt,name=notSeenHours" json:"notSeenHours,omitempty"`
+	// When the filter was created.
+	CreatedAt string `protobuf:"bytes,6,opt,name=createdAt" json:"createdAt,omitempty"`
+	// When the filter was last updated.
+	UpdatedAt string `protobuf:"bytes,7,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *GetSavedNodeFilterResponse) Reset()                    { *m = GetSavedNodeFilterResponse{} }
+func (m *GetSavedNodeFilterResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetSavedNodeFilterResponse) ProtoMessage()               {}
+func (*GetSavedNodeFilterResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{17} }
+
+func (m *GetSavedNodeFilterResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return 0
 }
 
-func (c *organizationClient) UpdateUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
-	out := new(OrganizationEmptyResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/UpdateUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetSavedNodeFilterResponse) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *organizationClient) DeleteUser(ctx context.Context, in *DeleteOrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
-	out := new(OrganizationEmptyResponse)
-	err := grpc.Invoke(ctx, "/api.Organization/DeleteUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetSavedNodeFilterResponse) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
-	return out, nil
+	return nil
 }
 
-// Server API for Organization service
-
-type OrganizationServer interface {
-	// Get organization list.
-	List(context.Context, *ListOrganizationRequest) (*ListOrganizationResponse, error)
-	// Get data for a particular organization.
-	Get(context.Context, *OrganizationRequest) (*GetOrganizationResponse, error)
-	// Create a new organization.
-	Create(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
-	// Update an existing organization.
-	Update(context.Context, *UpdateOrganizationRequest) (*OrganizationEmptyResponse, error)
-	// Delete an organization.
-	Delete(context.Context, *OrganizationRequest) (*OrganizationEmptyResponse, error)
-	// Get organization's user list.
-	ListUsers(context.Context, *ListOrganizationUsersRequest) (*ListOrganizationUsersResponse, error)
-	// Get data for a particular organization user.
-	GetUser(context.Context, *GetOrganizationUserRequest) (*GetOrganizationUserResponse, error)
-	// Add a new user to an organization.
-	AddUser(context.Context, *OrganizationUserRequest) (*OrganizationEmptyResponse, error)
-	// Update a user in an organization.
-	UpdateUser(context.Context, *OrganizationUserRequest) (*OrganizationEmptyResponse, error)
-	// Delete a user from an organization.
-	DeleteUser(context.Context, *DeleteOrganizationUserRequest) (*OrganizationEmptyResponse, error)
+func (m *GetSavedNodeFilterResponse) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
+	}
+	return ""
 }
 
-func RegisterOrganizationServer(s *grpc.Server, srv OrganizationServer) {
-	s.RegisterService(&_Organization_serviceDesc, srv)
+func (m *GetSavedNodeFilterResponse) GetNotSeenHours() uint32 {
+	if m != nil {
+		return m.NotSeenHours
+	}
+	return 0
 }
 
-func _Organization_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListOrganizationRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(OrganizationServer).List(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Organization/List",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrganizationServer).List(ctx, req.(*ListOrganizationRequest))
+func (m *GetSavedNodeFilterResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Organization_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrganizationRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(OrganizationServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Organization/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrganizationServer).Get(ctx, req.(*OrganizationRequest))
+func (m *GetSavedNodeFilterResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Organization_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateOrganizationRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type UpdateSavedNodeFilterRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// ID of the filter.
+	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+	// Name of the filter.
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// Tags which the nodes must all have.
+	Tags []string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+	// Pattern which the node name must match (* and ? wildcards).
+	NamePattern string `protobuf:"bytes,5,opt,name=namePattern" json:"namePattern,omitempty"`
+	// Only match the nodes from which no uplink has been received within
+	// the given number of hours (0 = disabled).
+	NotSeenHours uint32 `protobuf:"varint,6,opt,name=notSeenHours" json:"notSeenHours,omitempty"`
+}
+
+func (m *UpdateSavedNodeFilterRequest) Reset()                    { *m = UpdateSavedNodeFilterRequest{} }
+func (m *UpdateSavedNodeFilterRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateSavedNodeFilterRequest) ProtoMessage()               {}
+func (*UpdateSavedNodeFilterRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{18} }
+
+func (m *UpdateSavedNodeFilterRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
 	}
-	if interceptor == nil {
-		return srv.(OrganizationServer).Create(ctx, in)
+	return 0
+}
+
+func (m *UpdateSavedNodeFilterRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/api.Organization/Create",
+	return 0
+}
+
+func (m *UpdateSavedNodeFilterRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrganizationServer).Create(ctx, req.(*CreateOrganizationRequest))
+	return ""
+}
+
+func (m *UpdateSavedNodeFilterRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
+}
+
+func (m *UpdateSavedNodeFilterRequest) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
+	}
+	return ""
+}
+
+func (m *UpdateSavedNodeFilterRequest) GetNotSeenHours() uint32 {
+	if m != nil {
+		return m.NotSeenHours
+	}
+	return 0
+}
+
+type ListSavedNodeFiltersRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Max number of filters to return in the result-set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListSavedNodeFiltersRequest) Reset()                    { *m = ListSavedNodeFiltersRequest{} }
+func (m *ListSavedNodeFiltersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSavedNodeFiltersRequest) ProtoMessage()               {}
+func (*ListSavedNodeFiltersRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{19} }
+
+func (m *ListSavedNodeFiltersRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *ListSavedNodeFiltersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListSavedNodeFiltersRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListSavedNodeFiltersResponse struct {
+	// The total number of saved node filters in the organization.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// The filters in the requested limit, offset range.
+	Result []*GetSavedNodeFilterResponse `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListSavedNodeFiltersResponse) Reset()                    { *m = ListSavedNodeFiltersResponse{} }
+func (m *ListSavedNodeFiltersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSavedNodeFiltersResponse) ProtoMessage()               {}
+func (*ListSavedNodeFiltersResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{20} }
+
+func (m *ListSavedNodeFiltersResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListSavedNodeFiltersResponse) GetResult() []*GetSavedNodeFilterResponse {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type OrganizationDigestRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+}
+
+func (m *OrganizationDigestRequest) Reset()                    { *m = OrganizationDigestRequest{} }
+func (m *OrganizationDigestRequest) String() string            { return proto.CompactTextString(m) }
+func (*OrganizationDigestRequest) ProtoMessage()               {}
+func (*OrganizationDigestRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{21} }
+
+func (m *OrganizationDigestRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+type OrganizationDigest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Frequency of the digest.
+	Frequency DigestFrequency `protobuf:"varint,2,opt,name=frequency,enum=api.DigestFrequency" json:"frequency,omitempty"`
+	// URL of the webhook to which the digest is posted.
+	WebhookURL string `protobuf:"bytes,3,opt,name=webhookURL" json:"webhookURL,omitempty"`
+	// Go template used to render the digest (optional). When empty, the
+	// digest is posted as JSON.
+	Template string `protobuf:"bytes,4,opt,name=template" json:"template,omitempty"`
+	// Last time the digest was sent (RFC3339, read-only).
+	LastSentAt string `protobuf:"bytes,5,opt,name=lastSentAt" json:"lastSentAt,omitempty"`
+}
+
+func (m *OrganizationDigest) Reset()                    { *m = OrganizationDigest{} }
+func (m *OrganizationDigest) String() string            { return proto.CompactTextString(m) }
+func (*OrganizationDigest) ProtoMessage()               {}
+func (*OrganizationDigest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{22} }
+
+func (m *OrganizationDigest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *OrganizationDigest) GetFrequency() DigestFrequency {
+	if m != nil {
+		return m.Frequency
+	}
+	return DigestFrequency_DAILY
+}
+
+func (m *OrganizationDigest) GetWebhookURL() string {
+	if m != nil {
+		return m.WebhookURL
+	}
+	return ""
+}
+
+func (m *OrganizationDigest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *OrganizationDigest) GetLastSentAt() string {
+	if m != nil {
+		return m.LastSentAt
+	}
+	return ""
+}
+
+type PreviewOrganizationDigestResponse struct {
+	// Content-type of the rendered digest.
+	ContentType string `protobuf:"bytes,1,opt,name=contentType" json:"contentType,omitempty"`
+	// Rendered digest.
+	Body string `protobuf:"bytes,2,opt,name=body" json:"body,omitempty"`
+}
+
+func (m *PreviewOrganizationDigestResponse) Reset()         { *m = PreviewOrganizationDigestResponse{} }
+func (m *PreviewOrganizationDigestResponse) String() string { return proto.CompactTextString(m) }
+func (*PreviewOrganizationDigestResponse) ProtoMessage()    {}
+func (*PreviewOrganizationDigestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor6, []int{23}
+}
+
+func (m *PreviewOrganizationDigestResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *PreviewOrganizationDigestResponse) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+type OrganizationElevationRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// ID of the elevation.
+	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *OrganizationElevationRequest) Reset()                    { *m = OrganizationElevationRequest{} }
+func (m *OrganizationElevationRequest) String() string            { return proto.CompactTextString(m) }
+func (*OrganizationElevationRequest) ProtoMessage()               {}
+func (*OrganizationElevationRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{24} }
+
+func (m *OrganizationElevationRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *OrganizationElevationRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type CreateOrganizationElevationRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// ID of the user to elevate to organization admin.
+	UserID int64 `protobuf:"varint,2,opt,name=userID" json:"userID,omitempty"`
+	// Duration of the elevation in hours (max. 24).
+	Hours uint32 `protobuf:"varint,3,opt,name=hours" json:"hours,omitempty"`
+	// Justification of the elevation (required, e.g. the ticket reference).
+	Justification string `protobuf:"bytes,4,opt,name=justification" json:"justification,omitempty"`
+}
+
+func (m *CreateOrganizationElevationRequest) Reset()         { *m = CreateOrganizationElevationRequest{} }
+func (m *CreateOrganizationElevationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateOrganizationElevationRequest) ProtoMessage()    {}
+func (*CreateOrganizationElevationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor6, []int{25}
+}
+
+func (m *CreateOrganizationElevationRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *CreateOrganizationElevationRequest) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *CreateOrganizationElevationRequest) GetHours() uint32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+func (m *CreateOrganizationElevationRequest) GetJustification() string {
+	if m != nil {
+		return m.Justification
+	}
+	return ""
+}
+
+type CreateOrganizationElevationResponse struct {
+	// ID of the created elevation.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// When the elevation expires.
+	ExpiresAt string `protobuf:"bytes,2,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *CreateOrganizationElevationResponse) Reset()         { *m = CreateOrganizationElevationResponse{} }
+func (m *CreateOrganizationElevationResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateOrganizationElevationResponse) ProtoMessage()    {}
+func (*CreateOrganizationElevationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor6, []int{26}
+}
+
+func (m *CreateOrganizationElevationResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CreateOrganizationElevationResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type GetOrganizationElevationResponse struct {
+	// ID of the elevation.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// ID of the elevated user.
+	UserID int64 `protobuf:"varint,2,opt,name=userID" json:"userID,omitempty"`
+	// Username of the elevated user.
+	Username string `protobuf:"bytes,3,opt,name=username" json:"username,omitempty"`
+	// Username of the user who granted the elevation.
+	GrantedBy string `protobuf:"bytes,4,opt,name=grantedBy" json:"grantedBy,omitempty"`
+	// Justification of the elevation.
+	Justification string `protobuf:"bytes,5,opt,name=justification" json:"justification,omitempty"`
+	// When the elevation was granted.
+	CreatedAt string `protobuf:"bytes,6,opt,name=createdAt" json:"createdAt,omitempty"`
+	// When the elevation expires.
+	ExpiresAt string `protobuf:"bytes,7,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// When the elevation was revoked (empty when not revoked).
+	RevokedAt string `protobuf:"bytes,8,opt,name=revokedAt" json:"revokedAt,omitempty"`
+	// Username of the user who revoked the elevation.
+	RevokedBy string `protobuf:"bytes,9,opt,name=revokedBy" json:"revokedBy,omitempty"`
+	// The elevation is active (not expired or revoked).
+	Active bool `protobuf:"varint,10,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *GetOrganizationElevationResponse) Reset()         { *m = GetOrganizationElevationResponse{} }
+func (m *GetOrganizationElevationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOrganizationElevationResponse) ProtoMessage()    {}
+func (*GetOrganizationElevationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor6, []int{27}
+}
+
+func (m *GetOrganizationElevationResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetOrganizationElevationResponse) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *GetOrganizationElevationResponse) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetGrantedBy() string {
+	if m != nil {
+		return m.GrantedBy
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetJustification() string {
+	if m != nil {
+		return m.Justification
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetRevokedAt() string {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetRevokedBy() string {
+	if m != nil {
+		return m.RevokedBy
+	}
+	return ""
+}
+
+func (m *GetOrganizationElevationResponse) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type ListOrganizationElevationsRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Max number of elevations to return in the result-set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListOrganizationElevationsRequest) Reset()         { *m = ListOrganizationElevationsRequest{} }
+func (m *ListOrganizationElevationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOrganizationElevationsRequest) ProtoMessage()    {}
+func (*ListOrganizationElevationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor6, []int{28}
+}
+
+func (m *ListOrganizationElevationsRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *ListOrganizationElevationsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListOrganizationElevationsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListOrganizationElevationsResponse struct {
+	// The total number of elevations of the organization.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// The elevations in the requested limit, offset range (most recent
+	// first).
+	Result []*GetOrganizationElevationResponse `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListOrganizationElevationsResponse) Reset()         { *m = ListOrganizationElevationsResponse{} }
+func (m *ListOrganizationElevationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOrganizationElevationsResponse) ProtoMessage()    {}
+func (*ListOrganizationElevationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor6, []int{29}
+}
+
+func (m *ListOrganizationElevationsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListOrganizationElevationsResponse) GetResult() []*GetOrganizationElevationResponse {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DeadLetterRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// ID of the dead-lettered event.
+	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeadLetterRequest) Reset()                    { *m = DeadLetterRequest{} }
+func (m *DeadLetterRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeadLetterRequest) ProtoMessage()               {}
+func (*DeadLetterRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{30} }
+
+func (m *DeadLetterRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *DeadLetterRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeadLetter struct {
+	// ID of the dead-lettered event.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Type of the event (e.g. data_up, join, ack, error).
+	Type string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// Payload of the event as a JSON string.
+	PayloadJSON string `protobuf:"bytes,3,opt,name=payloadJSON" json:"payloadJSON,omitempty"`
+	// Number of delivery attempts.
+	Attempts uint32 `protobuf:"varint,4,opt,name=attempts" json:"attempts,omitempty"`
+	// Error of the last delivery attempt.
+	Error string `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+	// When the event was created.
+	CreatedAt string `protobuf:"bytes,6,opt,name=createdAt" json:"createdAt,omitempty"`
+	// When the event was moved to the dead-letter store.
+	FailedAt string `protobuf:"bytes,7,opt,name=failedAt" json:"failedAt,omitempty"`
+	// The delivery target (e.g. integration:[UUID]) which dead-lettered the
+	// event, the event is only delivered to this target when retried. Empty
+	// when the event is retried for all the targets which did not receive
+	// it yet.
+	Target string `protobuf:"bytes,8,opt,name=target" json:"target,omitempty"`
+}
+
+func (m *DeadLetter) Reset()                    { *m = DeadLetter{} }
+func (m *DeadLetter) String() string            { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()               {}
+func (*DeadLetter) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{31} }
+
+func (m *DeadLetter) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetPayloadJSON() string {
+	if m != nil {
+		return m.PayloadJSON
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetFailedAt() string {
+	if m != nil {
+		return m.FailedAt
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type ListDeadLettersRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	// Max number of events to return in the result-set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListDeadLettersRequest) Reset()                    { *m = ListDeadLettersRequest{} }
+func (m *ListDeadLettersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListDeadLettersRequest) ProtoMessage()               {}
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{32} }
+
+func (m *ListDeadLettersRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *ListDeadLettersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListDeadLettersRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	// The total number of dead-lettered events of the organization.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// The events in the requested limit, offset range (most recently
+	// failed first).
+	Result []*DeadLetter `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListDeadLettersResponse) Reset()                    { *m = ListDeadLettersResponse{} }
+func (m *ListDeadLettersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListDeadLettersResponse) ProtoMessage()               {}
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{33} }
+
+func (m *ListDeadLettersResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListDeadLettersResponse) GetResult() []*DeadLetter {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type PurgeDeadLettersRequest struct {
+	// ID of the organization.
+	OrganizationID int64 `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+}
+
+func (m *PurgeDeadLettersRequest) Reset()                    { *m = PurgeDeadLettersRequest{} }
+func (m *PurgeDeadLettersRequest) String() string            { return proto.CompactTextString(m) }
+func (*PurgeDeadLettersRequest) ProtoMessage()               {}
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{34} }
+
+func (m *PurgeDeadLettersRequest) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+type PurgeDeadLettersResponse struct {
+	// Number of deleted events.
+	Count uint32 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *PurgeDeadLettersResponse) Reset()                    { *m = PurgeDeadLettersResponse{} }
+func (m *PurgeDeadLettersResponse) String() string            { return proto.CompactTextString(m) }
+func (*PurgeDeadLettersResponse) ProtoMessage()               {}
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{35} }
+
+func (m *PurgeDeadLettersResponse) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ListOrganizationRequest)(nil), "api.ListOrganizationRequest")
+	proto.RegisterType((*OrganizationRequest)(nil), "api.OrganizationRequest")
+	proto.RegisterType((*GetOrganizationResponse)(nil), "api.GetOrganizationResponse")
+	proto.RegisterType((*CreateOrganizationRequest)(nil), "api.CreateOrganizationRequest")
+	proto.RegisterType((*CreateOrganizationResponse)(nil), "api.CreateOrganizationResponse")
+	proto.RegisterType((*UpdateOrganizationRequest)(nil), "api.UpdateOrganizationRequest")
+	proto.RegisterType((*ListOrganizationResponse)(nil), "api.ListOrganizationResponse")
+	proto.RegisterType((*OrganizationEmptyResponse)(nil), "api.OrganizationEmptyResponse")
+	proto.RegisterType((*OrganizationUserRequest)(nil), "api.OrganizationUserRequest")
+	proto.RegisterType((*DeleteOrganizationUserRequest)(nil), "api.DeleteOrganizationUserRequest")
+	proto.RegisterType((*ListOrganizationUsersRequest)(nil), "api.ListOrganizationUsersRequest")
+	proto.RegisterType((*GetOrganizationUserRequest)(nil), "api.GetOrganizationUserRequest")
+	proto.RegisterType((*GetOrganizationUserResponse)(nil), "api.GetOrganizationUserResponse")
+	proto.RegisterType((*ListOrganizationUsersResponse)(nil), "api.ListOrganizationUsersResponse")
+	proto.RegisterType((*SavedNodeFilterRequest)(nil), "api.SavedNodeFilterRequest")
+	proto.RegisterType((*CreateSavedNodeFilterRequest)(nil), "api.CreateSavedNodeFilterRequest")
+	proto.RegisterType((*CreateSavedNodeFilterResponse)(nil), "api.CreateSavedNodeFilterResponse")
+	proto.RegisterType((*GetSavedNodeFilterResponse)(nil), "api.GetSavedNodeFilterResponse")
+	proto.RegisterType((*UpdateSavedNodeFilterRequest)(nil), "api.UpdateSavedNodeFilterRequest")
+	proto.RegisterType((*ListSavedNodeFiltersRequest)(nil), "api.ListSavedNodeFiltersRequest")
+	proto.RegisterType((*ListSavedNodeFiltersResponse)(nil), "api.ListSavedNodeFiltersResponse")
+	proto.RegisterType((*OrganizationDigestRequest)(nil), "api.OrganizationDigestRequest")
+	proto.RegisterType((*OrganizationDigest)(nil), "api.OrganizationDigest")
+	proto.RegisterType((*PreviewOrganizationDigestResponse)(nil), "api.PreviewOrganizationDigestResponse")
+	proto.RegisterType((*OrganizationElevationRequest)(nil), "api.OrganizationElevationRequest")
+	proto.RegisterType((*CreateOrganizationElevationRequest)(nil), "api.CreateOrganizationElevationRequest")
+	proto.RegisterType((*CreateOrganizationElevationResponse)(nil), "api.CreateOrganizationElevationResponse")
+	proto.RegisterType((*GetOrganizationElevationResponse)(nil), "api.GetOrganizationElevationResponse")
+	proto.RegisterType((*ListOrganizationElevationsRequest)(nil), "api.ListOrganizationElevationsRequest")
+	proto.RegisterType((*ListOrganizationElevationsResponse)(nil), "api.ListOrganizationElevationsResponse")
+	proto.RegisterType((*DeadLetterRequest)(nil), "api.DeadLetterRequest")
+	proto.RegisterType((*DeadLetter)(nil), "api.DeadLetter")
+	proto.RegisterType((*ListDeadLettersRequest)(nil), "api.ListDeadLettersRequest")
+	proto.RegisterType((*ListDeadLettersResponse)(nil), "api.ListDeadLettersResponse")
+	proto.RegisterType((*PurgeDeadLettersRequest)(nil), "api.PurgeDeadLettersRequest")
+	proto.RegisterType((*PurgeDeadLettersResponse)(nil), "api.PurgeDeadLettersResponse")
+	proto.RegisterEnum("api.DigestFrequency", DigestFrequency_name, DigestFrequency_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Organization service
+
+type OrganizationClient interface {
+	// Get organization list.
+	List(ctx context.Context, in *ListOrganizationRequest, opts ...grpc.CallOption) (*ListOrganizationResponse, error)
+	// Get data for a particular organization.
+	Get(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error)
+	// Create a new organization.
+	Create(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	// Update an existing organization.
+	Update(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// Delete an organization.
+	Delete(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// Get organization's user list.
+	ListUsers(ctx context.Context, in *ListOrganizationUsersRequest, opts ...grpc.CallOption) (*ListOrganizationUsersResponse, error)
+	// Get data for a particular organization user.
+	GetUser(ctx context.Context, in *GetOrganizationUserRequest, opts ...grpc.CallOption) (*GetOrganizationUserResponse, error)
+	// Add a new user to an organization.
+	AddUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// Update a user in an organization.
+	UpdateUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// Delete a user from an organization.
+	DeleteUser(ctx context.Context, in *DeleteOrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// Get the organization's saved node filter list.
+	ListSavedNodeFilters(ctx context.Context, in *ListSavedNodeFiltersRequest, opts ...grpc.CallOption) (*ListSavedNodeFiltersResponse, error)
+	// Get data for a particular saved node filter.
+	GetSavedNodeFilter(ctx context.Context, in *SavedNodeFilterRequest, opts ...grpc.CallOption) (*GetSavedNodeFilterResponse, error)
+	// Create a new saved node filter.
+	CreateSavedNodeFilter(ctx context.Context, in *CreateSavedNodeFilterRequest, opts ...grpc.CallOption) (*CreateSavedNodeFilterResponse, error)
+	// Update a saved node filter.
+	UpdateSavedNodeFilter(ctx context.Context, in *UpdateSavedNodeFilterRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// Delete a saved node filter.
+	DeleteSavedNodeFilter(ctx context.Context, in *SavedNodeFilterRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// GetDigest returns the digest report configuration of the organization.
+	GetDigest(ctx context.Context, in *OrganizationDigestRequest, opts ...grpc.CallOption) (*OrganizationDigest, error)
+	// UpdateDigest creates or updates the digest report configuration of
+	// the organization.
+	UpdateDigest(ctx context.Context, in *OrganizationDigest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// DeleteDigest deletes the digest report configuration of the
+	// organization.
+	DeleteDigest(ctx context.Context, in *OrganizationDigestRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// PreviewDigest renders the digest report of the organization for the
+	// period ending now, without sending it.
+	PreviewDigest(ctx context.Context, in *OrganizationDigestRequest, opts ...grpc.CallOption) (*PreviewOrganizationDigestResponse, error)
+	// ListElevations lists the (active, expired and revoked) elevations of
+	// users to organization admin.
+	ListElevations(ctx context.Context, in *ListOrganizationElevationsRequest, opts ...grpc.CallOption) (*ListOrganizationElevationsResponse, error)
+	// CreateElevation elevates the given user to organization admin for
+	// the given number of hours.
+	CreateElevation(ctx context.Context, in *CreateOrganizationElevationRequest, opts ...grpc.CallOption) (*CreateOrganizationElevationResponse, error)
+	// RevokeElevation revokes the given (active) elevation.
+	RevokeElevation(ctx context.Context, in *OrganizationElevationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// ListDeadLetters lists the events of the organization which could not
+	// be delivered to the integrations (dead-letter store).
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	// RetryDeadLetter moves the given dead-lettered event back to the event
+	// outbox, so that its delivery is retried.
+	RetryDeadLetter(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error)
+	// PurgeDeadLetters deletes all dead-lettered events of the organization.
+	PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error)
+}
+
+type organizationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrganizationClient(cc *grpc.ClientConn) OrganizationClient {
+	return &organizationClient{cc}
+}
+
+func (c *organizationClient) List(ctx context.Context, in *ListOrganizationRequest, opts ...grpc.CallOption) (*ListOrganizationResponse, error) {
+	out := new(ListOrganizationResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) Get(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error) {
+	out := new(GetOrganizationResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) Create(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
+	out := new(CreateOrganizationResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) Update(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) Delete(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) ListUsers(ctx context.Context, in *ListOrganizationUsersRequest, opts ...grpc.CallOption) (*ListOrganizationUsersResponse, error) {
+	out := new(ListOrganizationUsersResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/ListUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) GetUser(ctx context.Context, in *GetOrganizationUserRequest, opts ...grpc.CallOption) (*GetOrganizationUserResponse, error) {
+	out := new(GetOrganizationUserResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/GetUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) AddUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/AddUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) UpdateUser(ctx context.Context, in *OrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/UpdateUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) DeleteUser(ctx context.Context, in *DeleteOrganizationUserRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/DeleteUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) ListSavedNodeFilters(ctx context.Context, in *ListSavedNodeFiltersRequest, opts ...grpc.CallOption) (*ListSavedNodeFiltersResponse, error) {
+	out := new(ListSavedNodeFiltersResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/ListSavedNodeFilters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) GetSavedNodeFilter(ctx context.Context, in *SavedNodeFilterRequest, opts ...grpc.CallOption) (*GetSavedNodeFilterResponse, error) {
+	out := new(GetSavedNodeFilterResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/GetSavedNodeFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) CreateSavedNodeFilter(ctx context.Context, in *CreateSavedNodeFilterRequest, opts ...grpc.CallOption) (*CreateSavedNodeFilterResponse, error) {
+	out := new(CreateSavedNodeFilterResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/CreateSavedNodeFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) UpdateSavedNodeFilter(ctx context.Context, in *UpdateSavedNodeFilterRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/UpdateSavedNodeFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) DeleteSavedNodeFilter(ctx context.Context, in *SavedNodeFilterRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/DeleteSavedNodeFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) GetDigest(ctx context.Context, in *OrganizationDigestRequest, opts ...grpc.CallOption) (*OrganizationDigest, error) {
+	out := new(OrganizationDigest)
+	err := grpc.Invoke(ctx, "/api.Organization/GetDigest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) UpdateDigest(ctx context.Context, in *OrganizationDigest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/UpdateDigest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) DeleteDigest(ctx context.Context, in *OrganizationDigestRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/DeleteDigest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) PreviewDigest(ctx context.Context, in *OrganizationDigestRequest, opts ...grpc.CallOption) (*PreviewOrganizationDigestResponse, error) {
+	out := new(PreviewOrganizationDigestResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/PreviewDigest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) ListElevations(ctx context.Context, in *ListOrganizationElevationsRequest, opts ...grpc.CallOption) (*ListOrganizationElevationsResponse, error) {
+	out := new(ListOrganizationElevationsResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/ListElevations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) CreateElevation(ctx context.Context, in *CreateOrganizationElevationRequest, opts ...grpc.CallOption) (*CreateOrganizationElevationResponse, error) {
+	out := new(CreateOrganizationElevationResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/CreateElevation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) RevokeElevation(ctx context.Context, in *OrganizationElevationRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/RevokeElevation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/ListDeadLetters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) RetryDeadLetter(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*OrganizationEmptyResponse, error) {
+	out := new(OrganizationEmptyResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/RetryDeadLetter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationClient) PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error) {
+	out := new(PurgeDeadLettersResponse)
+	err := grpc.Invoke(ctx, "/api.Organization/PurgeDeadLetters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Organization service
+
+type OrganizationServer interface {
+	// Get organization list.
+	List(context.Context, *ListOrganizationRequest) (*ListOrganizationResponse, error)
+	// Get data for a particular organization.
+	Get(context.Context, *OrganizationRequest) (*GetOrganizationResponse, error)
+	// Create a new organization.
+	Create(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	// Update an existing organization.
+	Update(context.Context, *UpdateOrganizationRequest) (*OrganizationEmptyResponse, error)
+	// Delete an organization.
+	Delete(context.Context, *OrganizationRequest) (*OrganizationEmptyResponse, error)
+	// Get organization's user list.
+	ListUsers(context.Context, *ListOrganizationUsersRequest) (*ListOrganizationUsersResponse, error)
+	// Get data for a particular organization user.
+	GetUser(context.Context, *GetOrganizationUserRequest) (*GetOrganizationUserResponse, error)
+	// Add a new user to an organization.
+	AddUser(context.Context, *OrganizationUserRequest) (*OrganizationEmptyResponse, error)
+	// Update a user in an organization.
+	UpdateUser(context.Context, *OrganizationUserRequest) (*OrganizationEmptyResponse, error)
+	// Delete a user from an organization.
+	DeleteUser(context.Context, *DeleteOrganizationUserRequest) (*OrganizationEmptyResponse, error)
+	// Get the organization's saved node filter list.
+	ListSavedNodeFilters(context.Context, *ListSavedNodeFiltersRequest) (*ListSavedNodeFiltersResponse, error)
+	// Get data for a particular saved node filter.
+	GetSavedNodeFilter(context.Context, *SavedNodeFilterRequest) (*GetSavedNodeFilterResponse, error)
+	// Create a new saved node filter.
+	CreateSavedNodeFilter(context.Context, *CreateSavedNodeFilterRequest) (*CreateSavedNodeFilterResponse, error)
+	// Update a saved node filter.
+	UpdateSavedNodeFilter(context.Context, *UpdateSavedNodeFilterRequest) (*OrganizationEmptyResponse, error)
+	// Delete a saved node filter.
+	DeleteSavedNodeFilter(context.Context, *SavedNodeFilterRequest) (*OrganizationEmptyResponse, error)
+	// GetDigest returns the digest report configuration of the organization.
+	GetDigest(context.Context, *OrganizationDigestRequest) (*OrganizationDigest, error)
+	// UpdateDigest creates or updates the digest report configuration of
+	// the organization.
+	UpdateDigest(context.Context, *OrganizationDigest) (*OrganizationEmptyResponse, error)
+	// DeleteDigest deletes the digest report configuration of the
+	// organization.
+	DeleteDigest(context.Context, *OrganizationDigestRequest) (*OrganizationEmptyResponse, error)
+	// PreviewDigest renders the digest report of the organization for the
+	// period ending now, without sending it.
+	PreviewDigest(context.Context, *OrganizationDigestRequest) (*PreviewOrganizationDigestResponse, error)
+	// ListElevations lists the (active, expired and revoked) elevations of
+	// users to organization admin.
+	ListElevations(context.Context, *ListOrganizationElevationsRequest) (*ListOrganizationElevationsResponse, error)
+	// CreateElevation elevates the given user to organization admin for
+	// the given number of hours.
+	CreateElevation(context.Context, *CreateOrganizationElevationRequest) (*CreateOrganizationElevationResponse, error)
+	// RevokeElevation revokes the given (active) elevation.
+	RevokeElevation(context.Context, *OrganizationElevationRequest) (*OrganizationEmptyResponse, error)
+	// ListDeadLetters lists the events of the organization which could not
+	// be delivered to the integrations (dead-letter store).
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	// RetryDeadLetter moves the given dead-lettered event back to the event
+	// outbox, so that its delivery is retried.
+	RetryDeadLetter(context.Context, *DeadLetterRequest) (*OrganizationEmptyResponse, error)
+	// PurgeDeadLetters deletes all dead-lettered events of the organization.
+	PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error)
+}
+
+func RegisterOrganizationServer(s *grpc.Server, srv OrganizationServer) {
+	s.RegisterService(&_Organization_serviceDesc, srv)
+}
+
+func _Organization_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).List(ctx, req.(*ListOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).Get(ctx, req.(*OrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).Create(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 func _Organization_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -825,6 +1952,276 @@ func _Organization_DeleteUser_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Organization_ListSavedNodeFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedNodeFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).ListSavedNodeFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/ListSavedNodeFilters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).ListSavedNodeFilters(ctx, req.(*ListSavedNodeFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_GetSavedNodeFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavedNodeFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).GetSavedNodeFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/GetSavedNodeFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).GetSavedNodeFilter(ctx, req.(*SavedNodeFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_CreateSavedNodeFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedNodeFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).CreateSavedNodeFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/CreateSavedNodeFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).CreateSavedNodeFilter(ctx, req.(*CreateSavedNodeFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_UpdateSavedNodeFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSavedNodeFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).UpdateSavedNodeFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/UpdateSavedNodeFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).UpdateSavedNodeFilter(ctx, req.(*UpdateSavedNodeFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_DeleteSavedNodeFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavedNodeFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).DeleteSavedNodeFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/DeleteSavedNodeFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).DeleteSavedNodeFilter(ctx, req.(*SavedNodeFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_GetDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).GetDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/GetDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).GetDigest(ctx, req.(*OrganizationDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_UpdateDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationDigest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).UpdateDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/UpdateDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).UpdateDigest(ctx, req.(*OrganizationDigest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_DeleteDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).DeleteDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/DeleteDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).DeleteDigest(ctx, req.(*OrganizationDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_PreviewDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).PreviewDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/PreviewDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).PreviewDigest(ctx, req.(*OrganizationDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_ListElevations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationElevationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).ListElevations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/ListElevations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).ListElevations(ctx, req.(*ListOrganizationElevationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_CreateElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).CreateElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/CreateElevation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).CreateElevation(ctx, req.(*CreateOrganizationElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_RevokeElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).RevokeElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/RevokeElevation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).RevokeElevation(ctx, req.(*OrganizationElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_RetryDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).RetryDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/RetryDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).RetryDeadLetter(ctx, req.(*DeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organization_PurgeDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServer).PurgeDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Organization/PurgeDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServer).PurgeDeadLetters(ctx, req.(*PurgeDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Organization_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Organization",
 	HandlerType: (*OrganizationServer)(nil),
@@ -869,6 +2266,66 @@ var _Organization_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _Organization_DeleteUser_Handler,
 		},
+		{
+			MethodName: "ListSavedNodeFilters",
+			Handler:    _Organization_ListSavedNodeFilters_Handler,
+		},
+		{
+			MethodName: "GetSavedNodeFilter",
+			Handler:    _Organization_GetSavedNodeFilter_Handler,
+		},
+		{
+			MethodName: "CreateSavedNodeFilter",
+			Handler:    _Organization_CreateSavedNodeFilter_Handler,
+		},
+		{
+			MethodName: "UpdateSavedNodeFilter",
+			Handler:    _Organization_UpdateSavedNodeFilter_Handler,
+		},
+		{
+			MethodName: "DeleteSavedNodeFilter",
+			Handler:    _Organization_DeleteSavedNodeFilter_Handler,
+		},
+		{
+			MethodName: "GetDigest",
+			Handler:    _Organization_GetDigest_Handler,
+		},
+		{
+			MethodName: "UpdateDigest",
+			Handler:    _Organization_UpdateDigest_Handler,
+		},
+		{
+			MethodName: "DeleteDigest",
+			Handler:    _Organization_DeleteDigest_Handler,
+		},
+		{
+			MethodName: "PreviewDigest",
+			Handler:    _Organization_PreviewDigest_Handler,
+		},
+		{
+			MethodName: "ListElevations",
+			Handler:    _Organization_ListElevations_Handler,
+		},
+		{
+			MethodName: "CreateElevation",
+			Handler:    _Organization_CreateElevation_Handler,
+		},
+		{
+			MethodName: "RevokeElevation",
+			Handler:    _Organization_RevokeElevation_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _Organization_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RetryDeadLetter",
+			Handler:    _Organization_RetryDeadLetter_Handler,
+		},
+		{
+			MethodName: "PurgeDeadLetters",
+			Handler:    _Organization_PurgeDeadLetters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "organization.proto",
@@ -877,52 +2334,119 @@ var _Organization_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("organization.proto", fileDescriptor6) }
 
 var fileDescriptor6 = []byte{
-	// 745 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x95, 0xe3, 0xc4, 0x6d, 0xa6, 0x08, 0xa4, 0xa5, 0x6a, 0x1c, 0x37, 0x69, 0xc2, 0x4a, 0x45,
-	0x51, 0x41, 0x89, 0x28, 0x1c, 0x10, 0xb7, 0xaa, 0x45, 0x01, 0x09, 0x81, 0x64, 0xa9, 0x27, 0x10,
-	0xd5, 0x12, 0x6f, 0xdb, 0x95, 0x1c, 0xdb, 0xf5, 0x6e, 0x40, 0xa1, 0x54, 0x42, 0x5c, 0x39, 0xf2,
-	0x01, 0x7c, 0x0d, 0x5f, 0xc0, 0x2f, 0xf0, 0x15, 0x9c, 0x90, 0xd7, 0x9b, 0xe2, 0x3a, 0x5e, 0x27,
-	0xaa, 0xe0, 0xd6, 0xdd, 0x99, 0xcc, 0x7b, 0xf3, 0xf6, 0xcd, 0xb8, 0x80, 0xc2, 0xf8, 0x84, 0x04,
-	0xec, 0x23, 0x11, 0x2c, 0x0c, 0xfa, 0x51, 0x1c, 0x8a, 0x10, 0x99, 0x24, 0x62, 0x4e, 0xeb, 0x24,
-	0x0c, 0x4f, 0x7c, 0x3a, 0x20, 0x11, 0x1b, 0x90, 0x20, 0x08, 0x85, 0xcc, 0xe0, 0x69, 0x0a, 0x3e,
-	0x82, 0xc6, 0x0b, 0xc6, 0xc5, 0xab, 0xcc, 0x8f, 0x5d, 0x7a, 0x36, 0xa1, 0x5c, 0xa0, 0x75, 0xa8,
-	0xf9, 0x6c, 0xcc, 0x84, 0x6d, 0x74, 0x8d, 0x5e, 0xcd, 0x4d, 0x0f, 0x68, 0x03, 0xac, 0xf0, 0xf8,
-	0x98, 0x53, 0x61, 0x57, 0xe4, 0xb5, 0x3a, 0x25, 0xf7, 0x9c, 0x92, 0x78, 0x74, 0x6a, 0x9b, 0x5d,
-	0xa3, 0x57, 0x77, 0xd5, 0x09, 0x6f, 0xc3, 0xed, 0xa2, 0xe2, 0x37, 0xa1, 0xc2, 0x3c, 0x59, 0xd9,
-	0x74, 0x2b, 0xcc, 0xc3, 0x3f, 0x0c, 0x68, 0x0c, 0x69, 0x8e, 0x07, 0x8f, 0xc2, 0x80, 0xd3, 0x7c,
-	0x2e, 0x42, 0x50, 0x0d, 0xc8, 0x98, 0x4a, 0x02, 0x75, 0x57, 0xfe, 0x8d, 0xba, 0xb0, 0xe6, 0x31,
-	0x1e, 0xf9, 0x64, 0xfa, 0x32, 0x09, 0xa5, 0x1c, 0xb2, 0x57, 0xa8, 0x07, 0xb7, 0x46, 0x24, 0x78,
-	0x46, 0xde, 0xd3, 0x21, 0x11, 0xf4, 0x03, 0x99, 0x72, 0xbb, 0xda, 0x35, 0x7a, 0xab, 0x6e, 0xfe,
-	0x1a, 0xb5, 0xa0, 0x3e, 0x8a, 0x29, 0x11, 0xd4, 0xdb, 0x13, 0x76, 0x4d, 0x56, 0xfa, 0x7b, 0x91,
-	0x44, 0x27, 0x91, 0xa7, 0xa2, 0x56, 0x1a, 0xbd, 0xbc, 0xc0, 0xe7, 0xd0, 0xdc, 0x97, 0xa9, 0x45,
-	0x4d, 0xcf, 0x88, 0x1b, 0x7a, 0xe2, 0x95, 0xa5, 0x88, 0x9b, 0x85, 0xc4, 0xf1, 0x7d, 0x70, 0x8a,
-	0xc0, 0x8b, 0x65, 0xc4, 0x5f, 0x0d, 0x68, 0x1e, 0x4a, 0xe2, 0x4b, 0x3c, 0xd0, 0xff, 0x16, 0x1d,
-	0x47, 0x60, 0xcf, 0x1b, 0x51, 0x31, 0xdf, 0x02, 0x10, 0xa1, 0x20, 0xfe, 0x7e, 0x38, 0x09, 0x66,
-	0x76, 0xcc, 0xdc, 0xa0, 0x47, 0x60, 0xc5, 0x94, 0x4f, 0xfc, 0xc4, 0x93, 0x66, 0x6f, 0x6d, 0xb7,
-	0xd5, 0x27, 0x11, 0xeb, 0x6b, 0xec, 0xe4, 0xaa, 0x5c, 0xbc, 0x09, 0xcd, 0x6c, 0xfc, 0xe9, 0x38,
-	0x12, 0xd3, 0x59, 0x12, 0x7e, 0x0d, 0x8d, 0x6c, 0xf0, 0x90, 0xd3, 0x58, 0xa7, 0xcc, 0x06, 0x58,
-	0x13, 0x4e, 0xe3, 0xe7, 0x07, 0x52, 0x1b, 0xd3, 0x55, 0x27, 0x64, 0xc3, 0x0a, 0xe3, 0x7b, 0xde,
-	0x98, 0x05, 0xea, 0xbd, 0x66, 0x47, 0x3c, 0x84, 0xf6, 0x01, 0xf5, 0xe9, 0x55, 0xe1, 0xaf, 0x01,
-	0x81, 0xdf, 0x40, 0x2b, 0x2f, 0x5a, 0x52, 0x86, 0xeb, 0xea, 0x5c, 0x8e, 0x74, 0xa5, 0x78, 0xa4,
-	0xcd, 0xec, 0x48, 0xe3, 0x03, 0x70, 0x72, 0x1a, 0x5e, 0x87, 0xe3, 0x77, 0x03, 0x36, 0x0b, 0xcb,
-	0x68, 0xa6, 0xdb, 0x81, 0xd5, 0xe4, 0x97, 0x19, 0xb3, 0x5d, 0x9e, 0xf5, 0x92, 0x5e, 0x9d, 0xd9,
-	0x6a, 0xe9, 0xcc, 0xd6, 0xf2, 0x33, 0x3b, 0x85, 0xb6, 0x46, 0xc5, 0x25, 0xfd, 0xf7, 0x38, 0xe7,
-	0xbf, 0x6e, 0x91, 0xff, 0xb2, 0x4d, 0xcf, 0x3c, 0xb8, 0xfb, 0x7b, 0x15, 0x6e, 0x64, 0x93, 0xd0,
-	0x11, 0x54, 0x13, 0x2e, 0x28, 0xb5, 0xb0, 0x66, 0x35, 0x3b, 0x6d, 0x4d, 0x54, 0x99, 0xd7, 0xf9,
-	0xf2, 0xf3, 0xd7, 0xb7, 0xca, 0x3a, 0x42, 0x72, 0xe9, 0x67, 0x3f, 0x0c, 0x1c, 0xbd, 0x05, 0x73,
-	0x48, 0x05, 0xb2, 0x65, 0x85, 0xa2, 0xda, 0xa5, 0xc3, 0x83, 0x3b, 0xb2, 0x74, 0x13, 0x35, 0xe6,
-	0x4b, 0x0f, 0xce, 0x99, 0x77, 0x81, 0x4e, 0xc1, 0x4a, 0x77, 0x10, 0xda, 0x92, 0x85, 0xb4, 0xdb,
-	0xd0, 0xe9, 0x68, 0xe3, 0x0a, 0xab, 0x2d, 0xb1, 0x1a, 0xb8, 0xa0, 0x8d, 0x27, 0xc6, 0x0e, 0xf2,
-	0xc1, 0x4a, 0xd7, 0x97, 0x42, 0xd2, 0xee, 0x32, 0x67, 0x6b, 0xae, 0xd9, 0xab, 0xc3, 0x8e, 0x25,
-	0x50, 0xcb, 0xd1, 0x35, 0x95, 0xa0, 0x8d, 0xc0, 0x4a, 0x67, 0xb6, 0x44, 0xba, 0x45, 0x38, 0x4a,
-	0xbc, 0x1d, 0xad, 0x78, 0x53, 0xa8, 0x27, 0x8f, 0x2a, 0xdd, 0x87, 0xee, 0x14, 0x3e, 0x72, 0x76,
-	0xbe, 0x1d, 0x5c, 0x96, 0xa2, 0x40, 0xb7, 0x25, 0x68, 0x07, 0xb5, 0x35, 0xa0, 0x83, 0x89, 0x44,
-	0xfb, 0x04, 0x2b, 0x43, 0x2a, 0x91, 0x51, 0x47, 0x6f, 0xdf, 0x14, 0x76, 0xa1, 0xbf, 0x71, 0x5f,
-	0x82, 0xf6, 0xd0, 0xdd, 0x52, 0xd0, 0xc1, 0x79, 0xba, 0x23, 0x2e, 0xd0, 0x19, 0xac, 0xec, 0x79,
-	0x9e, 0x44, 0x6f, 0xcd, 0x89, 0x98, 0x85, 0x5e, 0x24, 0x71, 0x4f, 0x02, 0x63, 0x5c, 0xde, 0x6d,
-	0xf2, 0xa0, 0x17, 0x00, 0xa9, 0x63, 0xfe, 0x01, 0xea, 0x03, 0x89, 0x7a, 0xcf, 0x59, 0xb2, 0xdd,
-	0x04, 0xfe, 0xb3, 0x01, 0x90, 0x1a, 0x4a, 0xe2, 0xa7, 0x2f, 0x59, 0xfa, 0x55, 0x58, 0xc8, 0x42,
-	0x89, 0xbe, 0xb3, 0x24, 0x8b, 0x77, 0x96, 0xfc, 0x17, 0xf0, 0xe1, 0x9f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x81, 0xc9, 0x5a, 0xec, 0x3b, 0x0a, 0x00, 0x00,
+	// 1809 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcd, 0x6f, 0x13, 0x47,
+	0x14, 0xef, 0xda, 0xb1, 0x13, 0x3f, 0xf2, 0x41, 0xa7, 0x21, 0x76, 0x36, 0x0e, 0x71, 0xa6, 0x25,
+	0x58, 0x2e, 0xc4, 0x34, 0x40, 0xa1, 0x20, 0x24, 0x02, 0x81, 0x40, 0x89, 0x20, 0x72, 0x4a, 0x2b,
+	0xd4, 0xaa, 0x68, 0xe3, 0x9d, 0x38, 0x5b, 0x9c, 0x5d, 0xb3, 0x3b, 0x4e, 0x70, 0x69, 0xaa, 0x8a,
+	0x4a, 0x95, 0x50, 0x4f, 0x08, 0xf5, 0x50, 0xb5, 0x12, 0xfd, 0x23, 0x7a, 0xea, 0xb9, 0xc7, 0xde,
+	0x2a, 0x21, 0xf5, 0xde, 0x3f, 0xa4, 0x9a, 0x0f, 0xaf, 0xd7, 0xfb, 0xe1, 0xb5, 0x03, 0xf4, 0x96,
+	0x79, 0x33, 0x7e, 0xef, 0xf7, 0x7e, 0xef, 0x63, 0xe6, 0x6d, 0x00, 0x59, 0x76, 0x4d, 0x33, 0x8d,
+	0xaf, 0x35, 0x6a, 0x58, 0xe6, 0x62, 0xc3, 0xb6, 0xa8, 0x85, 0x92, 0x5a, 0xc3, 0x50, 0xf3, 0x35,
+	0xcb, 0xaa, 0xd5, 0x49, 0x59, 0x6b, 0x18, 0x65, 0xcd, 0x34, 0x2d, 0xca, 0x4f, 0x38, 0xe2, 0x08,
+	0xbe, 0x0f, 0xd9, 0x35, 0xc3, 0xa1, 0x77, 0x3c, 0x3f, 0xae, 0x90, 0x87, 0x4d, 0xe2, 0x50, 0x34,
+	0x09, 0xa9, 0xba, 0xb1, 0x63, 0xd0, 0x9c, 0x52, 0x50, 0x8a, 0xa9, 0x8a, 0x58, 0xa0, 0x29, 0x48,
+	0x5b, 0x5b, 0x5b, 0x0e, 0xa1, 0xb9, 0x04, 0x17, 0xcb, 0x15, 0x93, 0x3b, 0x44, 0xb3, 0xab, 0xdb,
+	0xb9, 0x64, 0x41, 0x29, 0x66, 0x2a, 0x72, 0x85, 0x8f, 0xc1, 0x3b, 0x61, 0xca, 0xc7, 0x21, 0x61,
+	0xe8, 0x5c, 0x73, 0xb2, 0x92, 0x30, 0x74, 0xfc, 0x8f, 0x02, 0xd9, 0x55, 0xe2, 0xc3, 0xe1, 0x34,
+	0x2c, 0xd3, 0x21, 0xfe, 0xb3, 0x08, 0xc1, 0x90, 0xa9, 0xed, 0x10, 0x0e, 0x20, 0x53, 0xe1, 0x7f,
+	0xa3, 0x02, 0x1c, 0xd2, 0x0d, 0xa7, 0x51, 0xd7, 0x5a, 0xb7, 0xd9, 0x96, 0xc0, 0xe0, 0x15, 0xa1,
+	0x22, 0x4c, 0x54, 0x35, 0xf3, 0x86, 0xb6, 0x4b, 0x56, 0x35, 0x4a, 0xf6, 0xb4, 0x96, 0x93, 0x1b,
+	0x2a, 0x28, 0xc5, 0x91, 0x8a, 0x5f, 0x8c, 0xf2, 0x90, 0xa9, 0xda, 0x44, 0xa3, 0x44, 0x5f, 0xa6,
+	0xb9, 0x14, 0xd7, 0xd4, 0x11, 0xb0, 0xdd, 0x66, 0x43, 0x97, 0xbb, 0x69, 0xb1, 0xeb, 0x0a, 0x18,
+	0xb6, 0x66, 0xd3, 0xd0, 0x73, 0xc3, 0x02, 0x1b, 0xfb, 0x1b, 0x3f, 0x86, 0xe9, 0xab, 0xfc, 0xe7,
+	0x61, 0x44, 0xb4, 0x9d, 0x51, 0xa2, 0x9d, 0x49, 0xf4, 0xe5, 0x4c, 0x32, 0xd4, 0x19, 0x7c, 0x02,
+	0xd4, 0x30, 0xe3, 0xe1, 0xd4, 0xe2, 0x1f, 0x15, 0x98, 0xbe, 0xdb, 0xd0, 0x03, 0xc7, 0x43, 0x83,
+	0xf6, 0xa6, 0x03, 0x81, 0x1b, 0x90, 0x0b, 0x26, 0xa7, 0x44, 0x7e, 0x14, 0x80, 0x5a, 0x54, 0xab,
+	0x5f, 0xb5, 0x9a, 0x66, 0x3b, 0x45, 0x3d, 0x12, 0x74, 0x06, 0xd2, 0x36, 0x71, 0x9a, 0x75, 0x96,
+	0xa7, 0xc9, 0xe2, 0xa1, 0xa5, 0xfc, 0xa2, 0xd6, 0x30, 0x16, 0x23, 0x52, 0xac, 0x22, 0xcf, 0xe2,
+	0x19, 0x98, 0xf6, 0xee, 0x5f, 0xdb, 0x69, 0xd0, 0x56, 0xfb, 0x10, 0xfe, 0x1c, 0xb2, 0xde, 0xcd,
+	0xbb, 0x0e, 0xb1, 0xa3, 0x98, 0x99, 0x82, 0x74, 0xd3, 0x21, 0xf6, 0xcd, 0x15, 0xce, 0x4d, 0xb2,
+	0x22, 0x57, 0x28, 0x07, 0xc3, 0x86, 0xb3, 0xac, 0xef, 0x18, 0xa6, 0x8c, 0x57, 0x7b, 0x89, 0x57,
+	0x61, 0x76, 0x85, 0xd4, 0x09, 0x25, 0xaf, 0x68, 0x02, 0x7f, 0x01, 0x79, 0x3f, 0x69, 0x4c, 0x8d,
+	0x13, 0xa5, 0xc7, 0x2d, 0xf3, 0x44, 0x78, 0x99, 0x27, 0xbd, 0x65, 0x8e, 0x57, 0x40, 0x5d, 0x25,
+	0x01, 0xe5, 0x83, 0x62, 0x7c, 0xa1, 0xc0, 0x4c, 0xa8, 0x9a, 0x88, 0x8a, 0x57, 0x61, 0x84, 0xfd,
+	0xd2, 0x93, 0x6c, 0xee, 0x3a, 0x9a, 0xd2, 0xee, 0x3a, 0x1e, 0xea, 0x59, 0xc7, 0x29, 0x5f, 0x1d,
+	0xe3, 0x16, 0xcc, 0x46, 0xb0, 0xd8, 0x67, 0xfe, 0x9d, 0xf7, 0xe5, 0x5f, 0x21, 0x2c, 0xff, 0xbc,
+	0x4e, 0xbb, 0x39, 0xb8, 0x0e, 0x53, 0x1b, 0xda, 0x2e, 0xd1, 0x6f, 0x5b, 0x3a, 0xb9, 0x6e, 0xd4,
+	0x69, 0x87, 0xde, 0x05, 0x18, 0xf7, 0x76, 0xf9, 0x9b, 0x2b, 0x92, 0x22, 0x9f, 0x54, 0xd2, 0x97,
+	0x70, 0xab, 0xfa, 0x77, 0x05, 0xf2, 0xa2, 0x09, 0xbc, 0xa2, 0xe2, 0xb0, 0x82, 0x47, 0x30, 0x44,
+	0xb5, 0x1a, 0xeb, 0x3f, 0x49, 0x26, 0x63, 0x7f, 0xb3, 0x26, 0xc0, 0xf6, 0xd6, 0x35, 0x4a, 0x89,
+	0x6d, 0x4a, 0xee, 0xbd, 0x22, 0x84, 0x61, 0xd4, 0xb4, 0xe8, 0x06, 0x21, 0xe6, 0x0d, 0xab, 0x69,
+	0x3b, 0x3c, 0x00, 0x63, 0x95, 0x2e, 0x19, 0x2e, 0xc3, 0x6c, 0x04, 0xea, 0x88, 0xee, 0xf5, 0x52,
+	0xe1, 0xd9, 0xd9, 0xe7, 0xf1, 0xff, 0xd7, 0x9b, 0xee, 0x6c, 0x4c, 0xf7, 0xcc, 0xc6, 0x61, 0x7f,
+	0x36, 0xfe, 0xa9, 0x40, 0x5e, 0xb4, 0xe5, 0xd7, 0x9b, 0x19, 0x2e, 0x05, 0xc9, 0x10, 0x0a, 0x86,
+	0xa2, 0x29, 0x48, 0xc5, 0x53, 0x90, 0x0e, 0x09, 0xa8, 0x03, 0x33, 0xac, 0xa8, 0x7c, 0x3e, 0x38,
+	0x83, 0x3a, 0x31, 0x58, 0xc7, 0xda, 0x83, 0x7c, 0xb8, 0xd1, 0x3e, 0x0b, 0xf9, 0x9c, 0xaf, 0x90,
+	0xe7, 0xda, 0x85, 0x1c, 0x91, 0x66, 0x6e, 0x1d, 0x5f, 0xed, 0xbe, 0x4b, 0x56, 0x8c, 0x1a, 0x71,
+	0xe8, 0x80, 0xbe, 0xe2, 0xbf, 0x14, 0x40, 0x41, 0x2d, 0x7d, 0x53, 0xb5, 0x04, 0x99, 0x2d, 0x9b,
+	0x99, 0x34, 0xab, 0x2d, 0x4e, 0xd7, 0xf8, 0xd2, 0x24, 0xc7, 0x2f, 0xf4, 0x5c, 0x6f, 0xef, 0x55,
+	0x3a, 0xc7, 0x18, 0x21, 0x7b, 0x64, 0x73, 0xdb, 0xb2, 0x1e, 0xdc, 0xad, 0xac, 0xc9, 0xcc, 0xf0,
+	0x48, 0x58, 0x33, 0xa6, 0x64, 0xa7, 0x51, 0xd7, 0x28, 0x91, 0xb5, 0xe0, 0xae, 0xd9, 0x6f, 0xeb,
+	0x9a, 0x43, 0x37, 0x88, 0x49, 0xdd, 0xae, 0xea, 0x91, 0xe0, 0x7b, 0x30, 0xbf, 0x6e, 0x93, 0x5d,
+	0x83, 0xec, 0x85, 0x51, 0x23, 0x23, 0x52, 0x80, 0x43, 0x55, 0xcb, 0xa4, 0xc4, 0xa4, 0x9f, 0xb4,
+	0x1a, 0xed, 0x97, 0x91, 0x57, 0xc4, 0x52, 0x74, 0xd3, 0xd2, 0x5b, 0xed, 0xca, 0x65, 0x7f, 0xe3,
+	0x4f, 0x21, 0xdf, 0x75, 0x75, 0xd7, 0xc9, 0x6e, 0xd7, 0xe3, 0xe5, 0xa0, 0xcd, 0xf3, 0x37, 0x05,
+	0x70, 0xf0, 0x05, 0x75, 0x60, 0xf5, 0x51, 0x2f, 0x83, 0x49, 0x48, 0x6d, 0xf3, 0xc2, 0x49, 0xf2,
+	0xc2, 0x11, 0x0b, 0xf4, 0x1e, 0x8c, 0x7d, 0xd5, 0x74, 0xa8, 0xb1, 0x65, 0x54, 0xb9, 0x02, 0x49,
+	0x78, 0xb7, 0x10, 0x6f, 0xc0, 0xbb, 0x3d, 0x11, 0x46, 0xf4, 0xbf, 0x3c, 0x64, 0xc8, 0xa3, 0x86,
+	0x61, 0x13, 0x67, 0x99, 0x4a, 0x2a, 0x3b, 0x02, 0xfc, 0x47, 0x02, 0x0a, 0xbe, 0xeb, 0x2a, 0x5e,
+	0x65, 0x94, 0x77, 0xde, 0x0b, 0x3c, 0xe9, 0xbb, 0xc0, 0xf3, 0x90, 0xa9, 0xd9, 0x9a, 0x49, 0x89,
+	0x7e, 0xa5, 0xd5, 0xbe, 0xa6, 0x5d, 0x41, 0x90, 0x81, 0x54, 0x08, 0x03, 0xf1, 0xcd, 0xb5, 0xe3,
+	0xe8, 0xb0, 0xcf, 0x51, 0xb6, 0x6b, 0x93, 0x5d, 0xeb, 0x01, 0xff, 0xed, 0x88, 0xd8, 0x75, 0x05,
+	0x9e, 0xdd, 0x2b, 0xad, 0x5c, 0xa6, 0x6b, 0xf7, 0x4a, 0x8b, 0xf9, 0xab, 0x55, 0xa9, 0xb1, 0x4b,
+	0x72, 0xc0, 0xdf, 0x1e, 0x72, 0x85, 0x5b, 0x30, 0xef, 0x7f, 0x3e, 0xb8, 0xe4, 0xbd, 0xe1, 0x7e,
+	0xf7, 0xbd, 0x02, 0xb8, 0x97, 0xed, 0x3e, 0xdb, 0xde, 0x25, 0x5f, 0xdb, 0x3b, 0x16, 0xf6, 0x7e,
+	0x09, 0x24, 0x84, 0xdb, 0xfc, 0x6e, 0xc1, 0xdb, 0x2b, 0x44, 0xd3, 0xd7, 0x08, 0x7d, 0x0d, 0xef,
+	0x97, 0x97, 0x0a, 0x40, 0x47, 0x5b, 0xd8, 0x3d, 0x4e, 0x59, 0xa3, 0x90, 0xdd, 0x80, 0xfd, 0xcd,
+	0x7a, 0x48, 0x43, 0x6b, 0xd5, 0x2d, 0x4d, 0xff, 0x78, 0xe3, 0xce, 0xed, 0xf6, 0x18, 0xe2, 0x11,
+	0xb1, 0x94, 0xd4, 0x28, 0x6b, 0x5c, 0x54, 0xcc, 0x1f, 0x63, 0x15, 0x77, 0xcd, 0x18, 0x27, 0xb6,
+	0x6d, 0xd9, 0x32, 0xd9, 0xc4, 0x22, 0x26, 0xc9, 0x54, 0x18, 0xd9, 0xd2, 0x8c, 0xba, 0xe7, 0x02,
+	0x77, 0xd7, 0x2c, 0x56, 0x54, 0xb3, 0x6b, 0xa4, 0x9d, 0x5f, 0x72, 0x85, 0x4d, 0x98, 0x62, 0xa1,
+	0xea, 0xf8, 0xf6, 0x86, 0x73, 0x63, 0x13, 0xb2, 0x01, 0x7b, 0x7d, 0xe6, 0xc3, 0x71, 0x5f, 0x3e,
+	0x4c, 0x88, 0x6b, 0xa4, 0x13, 0xe3, 0x76, 0xe4, 0x97, 0x21, 0xbb, 0xde, 0xb4, 0x6b, 0xe4, 0xe0,
+	0x4e, 0xe1, 0x53, 0x90, 0x0b, 0xaa, 0x90, 0x38, 0x27, 0x21, 0x55, 0x75, 0x21, 0x8e, 0x55, 0xc4,
+	0xa2, 0x54, 0x84, 0x09, 0xdf, 0x8d, 0x86, 0x32, 0x90, 0x5a, 0x59, 0xbe, 0xb9, 0x76, 0xef, 0xf0,
+	0x5b, 0x08, 0x20, 0xfd, 0xd9, 0xb5, 0x6b, 0xb7, 0xd6, 0xee, 0x1d, 0x56, 0x96, 0x9e, 0xaa, 0x30,
+	0xea, 0x4d, 0x61, 0x74, 0x1f, 0x86, 0x18, 0x27, 0x48, 0x0c, 0x88, 0x11, 0x1f, 0x43, 0xd4, 0xd9,
+	0x88, 0x5d, 0x39, 0x1a, 0xaa, 0x4f, 0xfe, 0xfe, 0xf7, 0x79, 0x62, 0x12, 0x21, 0xfe, 0x99, 0xc5,
+	0xeb, 0x8e, 0x83, 0xbe, 0x84, 0xe4, 0x2a, 0xa1, 0x28, 0xc7, 0x35, 0x84, 0xe9, 0xee, 0x39, 0x9a,
+	0xe2, 0x39, 0xae, 0x7a, 0x1a, 0x65, 0x83, 0xaa, 0xcb, 0x8f, 0x0d, 0x7d, 0x1f, 0x6d, 0x43, 0x5a,
+	0x74, 0x7f, 0x74, 0x94, 0x2b, 0x8a, 0xfc, 0xd6, 0xa0, 0xce, 0x45, 0xee, 0x4b, 0x5b, 0xb3, 0xdc,
+	0x56, 0x16, 0x87, 0xb8, 0x71, 0x41, 0x29, 0xa1, 0x3a, 0xa4, 0xc5, 0x2b, 0x54, 0x5a, 0x8a, 0xfc,
+	0x52, 0xa0, 0x1e, 0x0d, 0x38, 0xdb, 0x3d, 0x4a, 0x63, 0x6e, 0x28, 0xaf, 0x46, 0x39, 0xc5, 0xac,
+	0x55, 0x21, 0x2d, 0x26, 0xe2, 0x1e, 0xd4, 0xc5, 0xd9, 0x91, 0xe4, 0x95, 0x22, 0xc9, 0x6b, 0x41,
+	0x86, 0x05, 0x95, 0xcf, 0x76, 0x68, 0x3e, 0x34, 0xc8, 0xde, 0xe9, 0x59, 0xc5, 0xbd, 0x8e, 0x48,
+	0xa3, 0xc7, 0xb8, 0xd1, 0x39, 0x34, 0x1b, 0x61, 0xb4, 0xdc, 0xe4, 0xd6, 0xbe, 0x81, 0xe1, 0x55,
+	0xc2, 0x2d, 0xa3, 0xb9, 0xe8, 0xe1, 0x50, 0x98, 0x8d, 0x9d, 0x1e, 0xf1, 0x22, 0x37, 0x5a, 0x44,
+	0x0b, 0x3d, 0x8d, 0x96, 0x1f, 0x8b, 0x0b, 0x79, 0x1f, 0x3d, 0x84, 0xe1, 0x65, 0x5d, 0xe7, 0xd6,
+	0xf3, 0x01, 0x12, 0xbd, 0xa6, 0xe3, 0x28, 0x2e, 0x72, 0xc3, 0x18, 0xf7, 0xf6, 0x96, 0x05, 0x74,
+	0x1f, 0x40, 0x64, 0xcc, 0x6b, 0xb0, 0xfa, 0x01, 0xb7, 0xfa, 0xbe, 0xda, 0xa7, 0xbb, 0xcc, 0xfc,
+	0x77, 0xfc, 0x16, 0x61, 0x09, 0xc5, 0xed, 0x63, 0xd9, 0xc0, 0x7a, 0x7c, 0x73, 0x89, 0x45, 0x21,
+	0x49, 0x2f, 0xf5, 0x4b, 0xfa, 0x4f, 0x0a, 0x4c, 0x86, 0x0d, 0x23, 0xa8, 0xe0, 0xa6, 0x55, 0xc4,
+	0x70, 0xa4, 0xce, 0xf7, 0x38, 0x21, 0xd1, 0x9c, 0xe7, 0x68, 0x96, 0xd0, 0xa9, 0x30, 0x34, 0xdd,
+	0x2d, 0x76, 0xbf, 0x6c, 0x5a, 0x3a, 0x39, 0xb9, 0x25, 0xcd, 0x3f, 0x53, 0x00, 0x05, 0x27, 0x1a,
+	0x34, 0xc3, 0x6d, 0x86, 0x8f, 0x9c, 0x6a, 0xdc, 0x1c, 0x84, 0x2f, 0x71, 0x38, 0xe7, 0xd0, 0xd9,
+	0x41, 0xe1, 0x88, 0xca, 0xfc, 0x45, 0x81, 0x23, 0xa1, 0xe3, 0xbf, 0x2c, 0xd3, 0x5e, 0x1f, 0x34,
+	0x54, 0xdc, 0xeb, 0x88, 0xc4, 0x77, 0x91, 0xe3, 0x3b, 0x8b, 0x07, 0xa6, 0x8b, 0x25, 0xd3, 0xaf,
+	0x0a, 0x1c, 0x09, 0x9d, 0xc8, 0x25, 0xba, 0x5e, 0xd3, 0x7a, 0x6c, 0x5a, 0x5d, 0xe6, 0xc8, 0x2e,
+	0xa8, 0x07, 0x63, 0x8e, 0xc1, 0x7b, 0xae, 0xc0, 0x11, 0x91, 0xda, 0x03, 0xc5, 0x34, 0x0e, 0x98,
+	0x0c, 0x69, 0xe9, 0x80, 0x21, 0x7d, 0x04, 0x99, 0x55, 0x42, 0xe5, 0x08, 0x1b, 0xb4, 0xd5, 0x35,
+	0x21, 0xab, 0xd9, 0x88, 0x7d, 0xbc, 0xc4, 0x41, 0x9c, 0x40, 0xa5, 0x7e, 0x40, 0xe8, 0xc2, 0xd8,
+	0xb7, 0x30, 0x2a, 0x22, 0x22, 0x8d, 0x47, 0x29, 0x8f, 0x65, 0xe0, 0x2c, 0x37, 0x5e, 0x56, 0x07,
+	0x30, 0xce, 0xe2, 0xf1, 0x44, 0x81, 0x51, 0x11, 0x8f, 0x3e, 0xbd, 0x8f, 0xc3, 0x21, 0x49, 0x28,
+	0x0d, 0x42, 0xc2, 0x73, 0x05, 0xc6, 0xe4, 0xf4, 0xdd, 0x27, 0x8a, 0x05, 0xbe, 0x1f, 0x3b, 0xb1,
+	0xe3, 0x0b, 0x1c, 0xcd, 0x19, 0xb4, 0xd4, 0x3f, 0x9a, 0x72, 0x43, 0x68, 0x45, 0x3f, 0x2b, 0x30,
+	0xce, 0xda, 0x5a, 0x67, 0x46, 0x41, 0x0b, 0xa1, 0x97, 0x6c, 0x60, 0x80, 0x52, 0x8f, 0xc7, 0x9e,
+	0x93, 0xf8, 0x3e, 0xe4, 0xf8, 0x4e, 0xa1, 0xc5, 0x7e, 0xf0, 0x91, 0x0e, 0x90, 0x17, 0x0a, 0x4c,
+	0x88, 0x26, 0xe2, 0x2a, 0x45, 0xc7, 0x23, 0x1e, 0x51, 0xfe, 0x2f, 0x02, 0x6a, 0x31, 0xfe, 0xa0,
+	0x84, 0xf7, 0x11, 0x87, 0x77, 0x1a, 0x0f, 0x08, 0x8f, 0x25, 0xd6, 0x33, 0x05, 0x26, 0x2a, 0x7c,
+	0x1c, 0xed, 0x20, 0x9c, 0x0f, 0xe6, 0x8e, 0x1f, 0x5b, 0x5c, 0x7a, 0xc9, 0xde, 0x58, 0x3a, 0x3d,
+	0x18, 0x22, 0x51, 0xe6, 0x3f, 0x28, 0x30, 0xe1, 0x1b, 0x33, 0x64, 0xdb, 0x09, 0x1f, 0x76, 0xd4,
+	0x7c, 0xf8, 0xe6, 0x41, 0xae, 0x35, 0x9d, 0x68, 0xfa, 0xc9, 0xba, 0x34, 0x2a, 0xc8, 0xa1, 0x76,
+	0xab, 0xa3, 0x16, 0x4d, 0xf9, 0xe7, 0x96, 0x3e, 0x19, 0xb9, 0xce, 0x51, 0x5c, 0xc6, 0x17, 0x07,
+	0x45, 0x21, 0x1e, 0x02, 0x36, 0x83, 0xc1, 0x02, 0xf6, 0x54, 0x81, 0xc3, 0xfe, 0xe1, 0x46, 0xbe,
+	0x85, 0x22, 0xc6, 0x26, 0x75, 0x36, 0x62, 0xb7, 0x9b, 0x9f, 0xd2, 0xc0, 0xfc, 0x6c, 0xa6, 0xf9,
+	0xff, 0x80, 0x4f, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0xa8, 0x1d, 0xcf, 0xe9, 0x3c, 0x1e, 0x00,
+	0x00,
 }
@@ -187,6 +187,537 @@ func (m *ProfileResponse) GetSettings() *ProfileSettings {
 	return nil
 }
 
+type EgressRequest struct {
+}
+
+func (m *EgressRequest) Reset()                    { *m = EgressRequest{} }
+func (m *EgressRequest) String() string            { return proto.CompactTextString(m) }
+func (*EgressRequest) ProtoMessage()               {}
+func (*EgressRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{5} }
+
+// The egress configuration of the outbound connections (e.g. the HTTP
+// integration webhooks). This can be used to allow-list the source
+// addresses of these connections.
+type EgressResponse struct {
+	// Public (source) IP addresses of the outbound connections.
+	IpAddresses []string `protobuf:"bytes,1,rep,name=ipAddresses" json:"ipAddresses,omitempty"`
+	// Proxy (host:port) used for the outbound connections (if configured).
+	Proxy string `protobuf:"bytes,2,opt,name=proxy" json:"proxy,omitempty"`
+	// Local address to which the outbound connections are bound (if configured).
+	BindAddress string `protobuf:"bytes,3,opt,name=bindAddress" json:"bindAddress,omitempty"`
+}
+
+func (m *EgressResponse) Reset()                    { *m = EgressResponse{} }
+func (m *EgressResponse) String() string            { return proto.CompactTextString(m) }
+func (*EgressResponse) ProtoMessage()               {}
+func (*EgressResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{6} }
+
+func (m *EgressResponse) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *EgressResponse) GetProxy() string {
+	if m != nil {
+		return m.Proxy
+	}
+	return ""
+}
+
+func (m *EgressResponse) GetBindAddress() string {
+	if m != nil {
+		return m.BindAddress
+	}
+	return ""
+}
+
+type ListRequestLogsRequest struct {
+	// Max number of requests to return (optional).
+	Limit int64 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Only return the requests of which the method contains the given
+	// string (e.g. api.Node/Create, optional).
+	Method string `protobuf:"bytes,2,opt,name=method" json:"method,omitempty"`
+	// Only return the failed requests.
+	ErrorsOnly bool `protobuf:"varint,3,opt,name=errorsOnly" json:"errorsOnly,omitempty"`
+}
+
+func (m *ListRequestLogsRequest) Reset()                    { *m = ListRequestLogsRequest{} }
+func (m *ListRequestLogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequestLogsRequest) ProtoMessage()               {}
+func (*ListRequestLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{7} }
+
+func (m *ListRequestLogsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListRequestLogsRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ListRequestLogsRequest) GetErrorsOnly() bool {
+	if m != nil {
+		return m.ErrorsOnly
+	}
+	return false
+}
+
+// A logged API request. Secrets (e.g. passwords, keys and tokens) are
+// redacted.
+type RequestLog struct {
+	CreatedAt string `protobuf:"bytes,1,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Full gRPC method name (e.g. /api.Node/Create).
+	Method string `protobuf:"bytes,2,opt,name=method" json:"method,omitempty"`
+	// gRPC status code of the response (e.g. InvalidArgument).
+	Code string `protobuf:"bytes,3,opt,name=code" json:"code,omitempty"`
+	// Error message (when the request failed).
+	Error string `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	// Duration of the request in milliseconds.
+	DurationMS int64 `protobuf:"varint,5,opt,name=durationMS" json:"durationMS,omitempty"`
+	// Remote address of the client.
+	RemoteAddress string `protobuf:"bytes,6,opt,name=remoteAddress" json:"remoteAddress,omitempty"`
+	// User-agent of the client.
+	UserAgent string `protobuf:"bytes,7,opt,name=userAgent" json:"userAgent,omitempty"`
+	// Request as a JSON string.
+	RequestJSON string `protobuf:"bytes,8,opt,name=requestJSON" json:"requestJSON,omitempty"`
+	// Response as a JSON string (when the request succeeded).
+	ResponseJSON string `protobuf:"bytes,9,opt,name=responseJSON" json:"responseJSON,omitempty"`
+}
+
+func (m *RequestLog) Reset()                    { *m = RequestLog{} }
+func (m *RequestLog) String() string            { return proto.CompactTextString(m) }
+func (*RequestLog) ProtoMessage()               {}
+func (*RequestLog) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{8} }
+
+func (m *RequestLog) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *RequestLog) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *RequestLog) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *RequestLog) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *RequestLog) GetDurationMS() int64 {
+	if m != nil {
+		return m.DurationMS
+	}
+	return 0
+}
+
+func (m *RequestLog) GetRemoteAddress() string {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return ""
+}
+
+func (m *RequestLog) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+func (m *RequestLog) GetRequestJSON() string {
+	if m != nil {
+		return m.RequestJSON
+	}
+	return ""
+}
+
+func (m *RequestLog) GetResponseJSON() string {
+	if m != nil {
+		return m.ResponseJSON
+	}
+	return ""
+}
+
+type ListRequestLogsResponse struct {
+	// Logged requests (newest first).
+	Result []*RequestLog `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListRequestLogsResponse) Reset()                    { *m = ListRequestLogsResponse{} }
+func (m *ListRequestLogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListRequestLogsResponse) ProtoMessage()               {}
+func (*ListRequestLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{9} }
+
+func (m *ListRequestLogsResponse) GetResult() []*RequestLog {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetAdminStatsRequest struct {
+	// Period (in hours) over which the statistics are aggregated (default 24, max 168).
+	Hours uint32 `protobuf:"varint,1,opt,name=hours" json:"hours,omitempty"`
+	// Max number of items to return (default 10, max 100).
+	Limit uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *GetAdminStatsRequest) Reset()                    { *m = GetAdminStatsRequest{} }
+func (m *GetAdminStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAdminStatsRequest) ProtoMessage()               {}
+func (*GetAdminStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{10} }
+
+func (m *GetAdminStatsRequest) GetHours() uint32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+func (m *GetAdminStatsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type TopApplication struct {
+	ApplicationID    int64  `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	ApplicationName  string `protobuf:"bytes,2,opt,name=applicationName" json:"applicationName,omitempty"`
+	OrganizationID   int64  `protobuf:"varint,3,opt,name=organizationID" json:"organizationID,omitempty"`
+	OrganizationName string `protobuf:"bytes,4,opt,name=organizationName" json:"organizationName,omitempty"`
+	// Number of uplinks within the period.
+	Uplinks int64 `protobuf:"varint,5,opt,name=uplinks" json:"uplinks,omitempty"`
+	// Number of nodes from which uplinks were received within the period.
+	Nodes int64 `protobuf:"varint,6,opt,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *TopApplication) Reset()                    { *m = TopApplication{} }
+func (m *TopApplication) String() string            { return proto.CompactTextString(m) }
+func (*TopApplication) ProtoMessage()               {}
+func (*TopApplication) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{11} }
+
+func (m *TopApplication) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *TopApplication) GetApplicationName() string {
+	if m != nil {
+		return m.ApplicationName
+	}
+	return ""
+}
+
+func (m *TopApplication) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *TopApplication) GetOrganizationName() string {
+	if m != nil {
+		return m.OrganizationName
+	}
+	return ""
+}
+
+func (m *TopApplication) GetUplinks() int64 {
+	if m != nil {
+		return m.Uplinks
+	}
+	return 0
+}
+
+func (m *TopApplication) GetNodes() int64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+type GetTopApplicationsResponse struct {
+	// Applications ordered by number of uplinks.
+	Result []*TopApplication `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetTopApplicationsResponse) Reset()                    { *m = GetTopApplicationsResponse{} }
+func (m *GetTopApplicationsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetTopApplicationsResponse) ProtoMessage()               {}
+func (*GetTopApplicationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{12} }
+
+func (m *GetTopApplicationsResponse) GetResult() []*TopApplication {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type OrganizationErrorRate struct {
+	OrganizationID   int64  `protobuf:"varint,1,opt,name=organizationID" json:"organizationID,omitempty"`
+	OrganizationName string `protobuf:"bytes,2,opt,name=organizationName" json:"organizationName,omitempty"`
+	// Number of uplinks within the period.
+	Uplinks int64 `protobuf:"varint,3,opt,name=uplinks" json:"uplinks,omitempty"`
+	// Number of error notifications within the period.
+	Errors int64 `protobuf:"varint,4,opt,name=errors" json:"errors,omitempty"`
+	// Error notifications per uplink.
+	ErrorRate float64 `protobuf:"fixed64,5,opt,name=errorRate" json:"errorRate,omitempty"`
+}
+
+func (m *OrganizationErrorRate) Reset()                    { *m = OrganizationErrorRate{} }
+func (m *OrganizationErrorRate) String() string            { return proto.CompactTextString(m) }
+func (*OrganizationErrorRate) ProtoMessage()               {}
+func (*OrganizationErrorRate) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{13} }
+
+func (m *OrganizationErrorRate) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *OrganizationErrorRate) GetOrganizationName() string {
+	if m != nil {
+		return m.OrganizationName
+	}
+	return ""
+}
+
+func (m *OrganizationErrorRate) GetUplinks() int64 {
+	if m != nil {
+		return m.Uplinks
+	}
+	return 0
+}
+
+func (m *OrganizationErrorRate) GetErrors() int64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *OrganizationErrorRate) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
+	}
+	return 0
+}
+
+type GetOrganizationErrorRatesResponse struct {
+	// Organizations (with error notifications) ordered by error rate.
+	Result []*OrganizationErrorRate `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetOrganizationErrorRatesResponse) Reset()         { *m = GetOrganizationErrorRatesResponse{} }
+func (m *GetOrganizationErrorRatesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOrganizationErrorRatesResponse) ProtoMessage()    {}
+func (*GetOrganizationErrorRatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor4, []int{14}
+}
+
+func (m *GetOrganizationErrorRatesResponse) GetResult() []*OrganizationErrorRate {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type IntegrationFailure struct {
+	ApplicationID    int64  `protobuf:"varint,1,opt,name=applicationID" json:"applicationID,omitempty"`
+	ApplicationName  string `protobuf:"bytes,2,opt,name=applicationName" json:"applicationName,omitempty"`
+	OrganizationID   int64  `protobuf:"varint,3,opt,name=organizationID" json:"organizationID,omitempty"`
+	OrganizationName string `protobuf:"bytes,4,opt,name=organizationName" json:"organizationName,omitempty"`
+	// Integration kind (e.g. HTTP).
+	Kind string `protobuf:"bytes,5,opt,name=kind" json:"kind,omitempty"`
+	// Number of deliveries within the period.
+	Deliveries int64 `protobuf:"varint,6,opt,name=deliveries" json:"deliveries,omitempty"`
+	// Number of failed deliveries within the period.
+	Failures int64 `protobuf:"varint,7,opt,name=failures" json:"failures,omitempty"`
+	// Fraction (0 - 1) of the deliveries that failed.
+	FailureRate float64 `protobuf:"fixed64,8,opt,name=failureRate" json:"failureRate,omitempty"`
+}
+
+func (m *IntegrationFailure) Reset()                    { *m = IntegrationFailure{} }
+func (m *IntegrationFailure) String() string            { return proto.CompactTextString(m) }
+func (*IntegrationFailure) ProtoMessage()               {}
+func (*IntegrationFailure) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{15} }
+
+func (m *IntegrationFailure) GetApplicationID() int64 {
+	if m != nil {
+		return m.ApplicationID
+	}
+	return 0
+}
+
+func (m *IntegrationFailure) GetApplicationName() string {
+	if m != nil {
+		return m.ApplicationName
+	}
+	return ""
+}
+
+func (m *IntegrationFailure) GetOrganizationID() int64 {
+	if m != nil {
+		return m.OrganizationID
+	}
+	return 0
+}
+
+func (m *IntegrationFailure) GetOrganizationName() string {
+	if m != nil {
+		return m.OrganizationName
+	}
+	return ""
+}
+
+func (m *IntegrationFailure) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *IntegrationFailure) GetDeliveries() int64 {
+	if m != nil {
+		return m.Deliveries
+	}
+	return 0
+}
+
+func (m *IntegrationFailure) GetFailures() int64 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *IntegrationFailure) GetFailureRate() float64 {
+	if m != nil {
+		return m.FailureRate
+	}
+	return 0
+}
+
+type GetIntegrationFailuresResponse struct {
+	// Integrations (with failed deliveries) ordered by number of failures.
+	Result []*IntegrationFailure `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetIntegrationFailuresResponse) Reset()                    { *m = GetIntegrationFailuresResponse{} }
+func (m *GetIntegrationFailuresResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetIntegrationFailuresResponse) ProtoMessage()               {}
+func (*GetIntegrationFailuresResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{16} }
+
+func (m *GetIntegrationFailuresResponse) GetResult() []*IntegrationFailure {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetLeaderElectionsRequest struct {
+}
+
+func (m *GetLeaderElectionsRequest) Reset()                    { *m = GetLeaderElectionsRequest{} }
+func (m *GetLeaderElectionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetLeaderElectionsRequest) ProtoMessage()               {}
+func (*GetLeaderElectionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{17} }
+
+type LeaderElection struct {
+	// Name of the background job.
+	Job string `protobuf:"bytes,1,opt,name=job" json:"job,omitempty"`
+	// ID of the instance running the job (empty when there is no leader).
+	LeaderID string `protobuf:"bytes,2,opt,name=leaderID" json:"leaderID,omitempty"`
+	// The instance handling this request is the leader.
+	IsLeader bool `protobuf:"varint,3,opt,name=isLeader" json:"isLeader,omitempty"`
+	// Time at which the leadership expires when not renewed (RFC3339).
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *LeaderElection) Reset()                    { *m = LeaderElection{} }
+func (m *LeaderElection) String() string            { return proto.CompactTextString(m) }
+func (*LeaderElection) ProtoMessage()               {}
+func (*LeaderElection) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{18} }
+
+func (m *LeaderElection) GetJob() string {
+	if m != nil {
+		return m.Job
+	}
+	return ""
+}
+
+func (m *LeaderElection) GetLeaderID() string {
+	if m != nil {
+		return m.LeaderID
+	}
+	return ""
+}
+
+func (m *LeaderElection) GetIsLeader() bool {
+	if m != nil {
+		return m.IsLeader
+	}
+	return false
+}
+
+func (m *LeaderElection) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type GetLeaderElectionsResponse struct {
+	// ID of the instance handling this request.
+	InstanceID string `protobuf:"bytes,1,opt,name=instanceID" json:"instanceID,omitempty"`
+	// Leader election status per background job.
+	Result []*LeaderElection `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetLeaderElectionsResponse) Reset()                    { *m = GetLeaderElectionsResponse{} }
+func (m *GetLeaderElectionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetLeaderElectionsResponse) ProtoMessage()               {}
+func (*GetLeaderElectionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{19} }
+
+func (m *GetLeaderElectionsResponse) GetInstanceID() string {
+	if m != nil {
+		return m.InstanceID
+	}
+	return ""
+}
+
+func (m *GetLeaderElectionsResponse) GetResult() []*LeaderElection {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
 type ProfileSettings struct {
 	// Existing users in the system can not be assigned to organizations and
 	// application and can not be listed by non global admin users.
@@ -196,7 +727,7 @@ type ProfileSettings struct {
 func (m *ProfileSettings) Reset()                    { *m = ProfileSettings{} }
 func (m *ProfileSettings) String() string            { return proto.CompactTextString(m) }
 func (*ProfileSettings) ProtoMessage()               {}
-func (*ProfileSettings) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{5} }
+func (*ProfileSettings) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{20} }
 
 func (m *ProfileSettings) GetDisableAssignExistingUsers() bool {
 	if m != nil {
@@ -217,7 +748,7 @@ type LoginRequest struct {
 func (m *LoginRequest) Reset()                    { *m = LoginRequest{} }
 func (m *LoginRequest) String() string            { return proto.CompactTextString(m) }
 func (*LoginRequest) ProtoMessage()               {}
-func (*LoginRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{6} }
+func (*LoginRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{21} }
 
 func (m *LoginRequest) GetUsername() string {
 	if m != nil {
@@ -244,7 +775,7 @@ type LoginResponse struct {
 func (m *LoginResponse) Reset()                    { *m = LoginResponse{} }
 func (m *LoginResponse) String() string            { return proto.CompactTextString(m) }
 func (*LoginResponse) ProtoMessage()               {}
-func (*LoginResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{7} }
+func (*LoginResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{22} }
 
 func (m *LoginResponse) GetJwt() string {
 	if m != nil {
@@ -266,7 +797,7 @@ type ListUserRequest struct {
 func (m *ListUserRequest) Reset()                    { *m = ListUserRequest{} }
 func (m *ListUserRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListUserRequest) ProtoMessage()               {}
-func (*ListUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{8} }
+func (*ListUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{23} }
 
 func (m *ListUserRequest) GetLimit() int32 {
 	if m != nil {
@@ -297,7 +828,7 @@ type UserRequest struct {
 func (m *UserRequest) Reset()                    { *m = UserRequest{} }
 func (m *UserRequest) String() string            { return proto.CompactTextString(m) }
 func (*UserRequest) ProtoMessage()               {}
-func (*UserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{9} }
+func (*UserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{24} }
 
 func (m *UserRequest) GetId() int64 {
 	if m != nil {
@@ -313,7 +844,7 @@ type AddUserResponse struct {
 func (m *AddUserResponse) Reset()                    { *m = AddUserResponse{} }
 func (m *AddUserResponse) String() string            { return proto.CompactTextString(m) }
 func (*AddUserResponse) ProtoMessage()               {}
-func (*AddUserResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{10} }
+func (*AddUserResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{25} }
 
 func (m *AddUserResponse) GetId() int64 {
 	if m != nil {
@@ -343,7 +874,7 @@ type UserSettings struct {
 func (m *UserSettings) Reset()                    { *m = UserSettings{} }
 func (m *UserSettings) String() string            { return proto.CompactTextString(m) }
 func (*UserSettings) ProtoMessage()               {}
-func (*UserSettings) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{11} }
+func (*UserSettings) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{26} }
 
 func (m *UserSettings) GetId() int64 {
 	if m != nil {
@@ -403,7 +934,7 @@ type UserInfo struct {
 func (m *UserInfo) Reset()                    { *m = UserInfo{} }
 func (m *UserInfo) String() string            { return proto.CompactTextString(m) }
 func (*UserInfo) ProtoMessage()               {}
-func (*UserInfo) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{12} }
+func (*UserInfo) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{27} }
 
 func (m *UserInfo) GetUserSettings() *UserSettings {
 	if m != nil {
@@ -439,7 +970,7 @@ type GetUserResponse struct {
 func (m *GetUserResponse) Reset()                    { *m = GetUserResponse{} }
 func (m *GetUserResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetUserResponse) ProtoMessage()               {}
-func (*GetUserResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{13} }
+func (*GetUserResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{28} }
 
 func (m *GetUserResponse) GetId() int64 {
 	if m != nil {
@@ -512,7 +1043,7 @@ type AddUserRequest struct {
 func (m *AddUserRequest) Reset()                    { *m = AddUserRequest{} }
 func (m *AddUserRequest) String() string            { return proto.CompactTextString(m) }
 func (*AddUserRequest) ProtoMessage()               {}
-func (*AddUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{14} }
+func (*AddUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{29} }
 
 func (m *AddUserRequest) GetUsername() string {
 	if m != nil {
@@ -573,7 +1104,7 @@ type AddUserOrganization struct {
 func (m *AddUserOrganization) Reset()                    { *m = AddUserOrganization{} }
 func (m *AddUserOrganization) String() string            { return proto.CompactTextString(m) }
 func (*AddUserOrganization) ProtoMessage()               {}
-func (*AddUserOrganization) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{15} }
+func (*AddUserOrganization) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{30} }
 
 func (m *AddUserOrganization) GetOrganizationID() int64 {
 	if m != nil {
@@ -599,7 +1130,7 @@ type AddUserApplication struct {
 func (m *AddUserApplication) Reset()                    { *m = AddUserApplication{} }
 func (m *AddUserApplication) String() string            { return proto.CompactTextString(m) }
 func (*AddUserApplication) ProtoMessage()               {}
-func (*AddUserApplication) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{16} }
+func (*AddUserApplication) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{31} }
 
 func (m *AddUserApplication) GetApplicationID() int64 {
 	if m != nil {
@@ -632,7 +1163,7 @@ type UpdateUserRequest struct {
 func (m *UpdateUserRequest) Reset()                    { *m = UpdateUserRequest{} }
 func (m *UpdateUserRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateUserRequest) ProtoMessage()               {}
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{17} }
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{32} }
 
 func (m *UpdateUserRequest) GetId() int64 {
 	if m != nil {
@@ -677,7 +1208,7 @@ type ListUserResponse struct {
 func (m *ListUserResponse) Reset()                    { *m = ListUserResponse{} }
 func (m *ListUserResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListUserResponse) ProtoMessage()               {}
-func (*ListUserResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{18} }
+func (*ListUserResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{33} }
 
 func (m *ListUserResponse) GetTotalCount() int32 {
 	if m != nil {
@@ -699,7 +1230,7 @@ type UserEmptyResponse struct {
 func (m *UserEmptyResponse) Reset()                    { *m = UserEmptyResponse{} }
 func (m *UserEmptyResponse) String() string            { return proto.CompactTextString(m) }
 func (*UserEmptyResponse) ProtoMessage()               {}
-func (*UserEmptyResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{19} }
+func (*UserEmptyResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{34} }
 
 type UpdateUserPasswordRequest struct {
 	// The ID of the user for which to update the password.
@@ -711,7 +1242,7 @@ type UpdateUserPasswordRequest struct {
 func (m *UpdateUserPasswordRequest) Reset()                    { *m = UpdateUserPasswordRequest{} }
 func (m *UpdateUserPasswordRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateUserPasswordRequest) ProtoMessage()               {}
-func (*UpdateUserPasswordRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{20} }
+func (*UpdateUserPasswordRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{35} }
 
 func (m *UpdateUserPasswordRequest) GetId() int64 {
 	if m != nil {
@@ -733,6 +1264,21 @@ func init() {
 	proto.RegisterType((*UserProfile)(nil), "api.UserProfile")
 	proto.RegisterType((*ProfileRequest)(nil), "api.ProfileRequest")
 	proto.RegisterType((*ProfileResponse)(nil), "api.ProfileResponse")
+	proto.RegisterType((*EgressRequest)(nil), "api.EgressRequest")
+	proto.RegisterType((*EgressResponse)(nil), "api.EgressResponse")
+	proto.RegisterType((*ListRequestLogsRequest)(nil), "api.ListRequestLogsRequest")
+	proto.RegisterType((*RequestLog)(nil), "api.RequestLog")
+	proto.RegisterType((*ListRequestLogsResponse)(nil), "api.ListRequestLogsResponse")
+	proto.RegisterType((*GetAdminStatsRequest)(nil), "api.GetAdminStatsRequest")
+	proto.RegisterType((*TopApplication)(nil), "api.TopApplication")
+	proto.RegisterType((*GetTopApplicationsResponse)(nil), "api.GetTopApplicationsResponse")
+	proto.RegisterType((*OrganizationErrorRate)(nil), "api.OrganizationErrorRate")
+	proto.RegisterType((*GetOrganizationErrorRatesResponse)(nil), "api.GetOrganizationErrorRatesResponse")
+	proto.RegisterType((*IntegrationFailure)(nil), "api.IntegrationFailure")
+	proto.RegisterType((*GetIntegrationFailuresResponse)(nil), "api.GetIntegrationFailuresResponse")
+	proto.RegisterType((*GetLeaderElectionsRequest)(nil), "api.GetLeaderElectionsRequest")
+	proto.RegisterType((*LeaderElection)(nil), "api.LeaderElection")
+	proto.RegisterType((*GetLeaderElectionsResponse)(nil), "api.GetLeaderElectionsResponse")
 	proto.RegisterType((*ProfileSettings)(nil), "api.ProfileSettings")
 	proto.RegisterType((*LoginRequest)(nil), "api.LoginRequest")
 	proto.RegisterType((*LoginResponse)(nil), "api.LoginResponse")
@@ -1007,6 +1553,18 @@ type InternalClient interface {
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// Get the current user's profile
 	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	// Get the egress configuration of the outbound (e.g. webhook) connections
+	Egress(ctx context.Context, in *EgressRequest, opts ...grpc.CallOption) (*EgressResponse, error)
+	// List the (sampled) API requests, for debugging malformed requests (global admin users only)
+	ListRequestLogs(ctx context.Context, in *ListRequestLogsRequest, opts ...grpc.CallOption) (*ListRequestLogsResponse, error)
+	// Get the applications with the most uplinks (global admin users only)
+	GetTopApplications(ctx context.Context, in *GetAdminStatsRequest, opts ...grpc.CallOption) (*GetTopApplicationsResponse, error)
+	// Get the organizations with the highest error notification rates (global admin users only)
+	GetOrganizationErrorRates(ctx context.Context, in *GetAdminStatsRequest, opts ...grpc.CallOption) (*GetOrganizationErrorRatesResponse, error)
+	// Get the application integrations with the most failed deliveries (global admin users only)
+	GetIntegrationFailures(ctx context.Context, in *GetAdminStatsRequest, opts ...grpc.CallOption) (*GetIntegrationFailuresResponse, error)
+	// Get the leader election status of the background jobs (global admin users only)
+	GetLeaderElections(ctx context.Context, in *GetLeaderElectionsRequest, opts ...grpc.CallOption) (*GetLeaderElectionsResponse, error)
 }
 
 type internalClient struct {
@@ -1035,6 +1593,60 @@ func (c *internalClient) Profile(ctx context.Context, in *ProfileRequest, opts .
 	return out, nil
 }
 
+func (c *internalClient) Egress(ctx context.Context, in *EgressRequest, opts ...grpc.CallOption) (*EgressResponse, error) {
+	out := new(EgressResponse)
+	err := grpc.Invoke(ctx, "/api.Internal/Egress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) ListRequestLogs(ctx context.Context, in *ListRequestLogsRequest, opts ...grpc.CallOption) (*ListRequestLogsResponse, error) {
+	out := new(ListRequestLogsResponse)
+	err := grpc.Invoke(ctx, "/api.Internal/ListRequestLogs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetTopApplications(ctx context.Context, in *GetAdminStatsRequest, opts ...grpc.CallOption) (*GetTopApplicationsResponse, error) {
+	out := new(GetTopApplicationsResponse)
+	err := grpc.Invoke(ctx, "/api.Internal/GetTopApplications", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetOrganizationErrorRates(ctx context.Context, in *GetAdminStatsRequest, opts ...grpc.CallOption) (*GetOrganizationErrorRatesResponse, error) {
+	out := new(GetOrganizationErrorRatesResponse)
+	err := grpc.Invoke(ctx, "/api.Internal/GetOrganizationErrorRates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetIntegrationFailures(ctx context.Context, in *GetAdminStatsRequest, opts ...grpc.CallOption) (*GetIntegrationFailuresResponse, error) {
+	out := new(GetIntegrationFailuresResponse)
+	err := grpc.Invoke(ctx, "/api.Internal/GetIntegrationFailures", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetLeaderElections(ctx context.Context, in *GetLeaderElectionsRequest, opts ...grpc.CallOption) (*GetLeaderElectionsResponse, error) {
+	out := new(GetLeaderElectionsResponse)
+	err := grpc.Invoke(ctx, "/api.Internal/GetLeaderElections", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Internal service
 
 type InternalServer interface {
@@ -1042,6 +1654,18 @@ type InternalServer interface {
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	// Get the current user's profile
 	Profile(context.Context, *ProfileRequest) (*ProfileResponse, error)
+	// Get the egress configuration of the outbound (e.g. webhook) connections
+	Egress(context.Context, *EgressRequest) (*EgressResponse, error)
+	// List the (sampled) API requests, for debugging malformed requests (global admin users only)
+	ListRequestLogs(context.Context, *ListRequestLogsRequest) (*ListRequestLogsResponse, error)
+	// Get the applications with the most uplinks (global admin users only)
+	GetTopApplications(context.Context, *GetAdminStatsRequest) (*GetTopApplicationsResponse, error)
+	// Get the organizations with the highest error notification rates (global admin users only)
+	GetOrganizationErrorRates(context.Context, *GetAdminStatsRequest) (*GetOrganizationErrorRatesResponse, error)
+	// Get the application integrations with the most failed deliveries (global admin users only)
+	GetIntegrationFailures(context.Context, *GetAdminStatsRequest) (*GetIntegrationFailuresResponse, error)
+	// Get the leader election status of the background jobs (global admin users only)
+	GetLeaderElections(context.Context, *GetLeaderElectionsRequest) (*GetLeaderElectionsResponse, error)
 }
 
 func RegisterInternalServer(s *grpc.Server, srv InternalServer) {
@@ -1084,6 +1708,114 @@ func _Internal_Profile_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Internal_Egress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).Egress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/Egress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).Egress(ctx, req.(*EgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_ListRequestLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequestLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).ListRequestLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/ListRequestLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).ListRequestLogs(ctx, req.(*ListRequestLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetTopApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdminStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetTopApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetTopApplications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetTopApplications(ctx, req.(*GetAdminStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetOrganizationErrorRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdminStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetOrganizationErrorRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetOrganizationErrorRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetOrganizationErrorRates(ctx, req.(*GetAdminStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetIntegrationFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdminStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetIntegrationFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetIntegrationFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetIntegrationFailures(ctx, req.(*GetAdminStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetLeaderElections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderElectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetLeaderElections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetLeaderElections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetLeaderElections(ctx, req.(*GetLeaderElectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Internal_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Internal",
 	HandlerType: (*InternalServer)(nil),
@@ -1096,6 +1828,30 @@ var _Internal_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Profile",
 			Handler:    _Internal_Profile_Handler,
 		},
+		{
+			MethodName: "Egress",
+			Handler:    _Internal_Egress_Handler,
+		},
+		{
+			MethodName: "ListRequestLogs",
+			Handler:    _Internal_ListRequestLogs_Handler,
+		},
+		{
+			MethodName: "GetTopApplications",
+			Handler:    _Internal_GetTopApplications_Handler,
+		},
+		{
+			MethodName: "GetOrganizationErrorRates",
+			Handler:    _Internal_GetOrganizationErrorRates_Handler,
+		},
+		{
+			MethodName: "GetIntegrationFailures",
+			Handler:    _Internal_GetIntegrationFailures_Handler,
+		},
+		{
+			MethodName: "GetLeaderElections",
+			Handler:    _Internal_GetLeaderElections_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
@@ -1104,67 +1860,112 @@ var _Internal_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("user.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 983 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0xcd, 0x6e, 0x23, 0x45,
-	0x10, 0xd6, 0xf8, 0x2f, 0x4e, 0xc5, 0xb1, 0x9d, 0x72, 0x7e, 0x86, 0xd1, 0x26, 0x0a, 0x0d, 0x42,
-	0x56, 0x84, 0x62, 0x64, 0x84, 0x84, 0x76, 0xa5, 0x95, 0xac, 0xfd, 0xb1, 0x02, 0xd1, 0x12, 0x66,
-	0xb3, 0xda, 0x2b, 0xb3, 0x99, 0x8e, 0x69, 0x18, 0xcf, 0x0c, 0xd3, 0x6d, 0x96, 0x1f, 0x71, 0xe1,
-	0x15, 0x10, 0x27, 0x1e, 0x82, 0x23, 0x17, 0x1e, 0x01, 0x89, 0x03, 0xaf, 0xb0, 0xaf, 0xc0, 0x1d,
-	0x75, 0x4f, 0xcf, 0xb8, 0xa7, 0x9d, 0x44, 0x91, 0xd0, 0x22, 0x71, 0x73, 0x7f, 0x5d, 0xfd, 0xd5,
-	0x57, 0xd5, 0x55, 0xd5, 0x63, 0x80, 0x05, 0xa7, 0xd9, 0x71, 0x9a, 0x25, 0x22, 0xc1, 0x7a, 0x90,
-	0x32, 0xef, 0xce, 0x2c, 0x49, 0x66, 0x11, 0x1d, 0x05, 0x29, 0x1b, 0x05, 0x71, 0x9c, 0x88, 0x40,
-	0xb0, 0x24, 0xe6, 0xb9, 0x09, 0xf9, 0xcd, 0x81, 0xde, 0x24, 0x4d, 0x23, 0x76, 0xa1, 0xe0, 0x53,
-	0x16, 0x7f, 0x89, 0x6f, 0xc3, 0x66, 0xb0, 0x84, 0x4e, 0x1e, 0xba, 0xce, 0xa1, 0x33, 0xac, 0xfb,
-	0x55, 0x10, 0x87, 0xd0, 0x33, 0x80, 0x27, 0xc1, 0x9c, 0xba, 0xb5, 0x43, 0x67, 0xb8, 0xee, 0xdb,
-	0x30, 0xba, 0xb0, 0xc6, 0xf8, 0x24, 0x9c, 0xb3, 0xd8, 0xad, 0x1f, 0x3a, 0xc3, 0xb6, 0x5f, 0x2c,
-	0xf1, 0x0e, 0xac, 0x5f, 0x64, 0x34, 0x10, 0x34, 0x9c, 0x08, 0xb7, 0xa1, 0x4e, 0x2f, 0x01, 0xb9,
-	0xbb, 0x48, 0x43, 0xbd, 0xdb, 0xcc, 0x77, 0x4b, 0x80, 0xfc, 0xee, 0x40, 0xff, 0x93, 0x6c, 0x16,
-	0xc4, 0xec, 0xbb, 0xa5, 0xf4, 0x77, 0xa0, 0x9b, 0x18, 0x58, 0xa9, 0xdd, 0x42, 0xf1, 0x08, 0xfa,
-	0x26, 0x62, 0xa8, 0x5f, 0xc1, 0x5f, 0x93, 0xfc, 0x29, 0x6c, 0x3c, 0xe3, 0x34, 0x3b, 0xcb, 0x92,
-	0x4b, 0x16, 0x51, 0xfc, 0x10, 0x3a, 0x46, 0xda, 0xb8, 0xeb, 0x1c, 0xd6, 0x87, 0x1b, 0xe3, 0xed,
-	0xe3, 0x20, 0x65, 0xc7, 0xd6, 0xfd, 0xf8, 0x15, 0x4b, 0xd2, 0x87, 0xae, 0x26, 0xf1, 0xe9, 0x57,
-	0x0b, 0xca, 0x05, 0x79, 0xe5, 0x40, 0xaf, 0x84, 0x78, 0x9a, 0xc4, 0x9c, 0xe2, 0x10, 0x1a, 0xb2,
-	0x30, 0x54, 0x3a, 0x0a, 0xde, 0x29, 0x15, 0x52, 0x42, 0x61, 0xe3, 0x2b, 0x8b, 0x15, 0x25, 0xb5,
-	0xdb, 0x2a, 0xc1, 0x7b, 0xb0, 0x69, 0x26, 0x8f, 0xbb, 0x75, 0x75, 0x74, 0x47, 0x1d, 0xb5, 0xaf,
-	0xca, 0xaf, 0xda, 0xe2, 0x7b, 0xd0, 0xe6, 0x54, 0x08, 0x16, 0xcf, 0xb8, 0x4a, 0x65, 0xe1, 0x52,
-	0x07, 0xf2, 0x54, 0xef, 0xf9, 0xa5, 0x15, 0xf9, 0xb4, 0x8c, 0xb2, 0xd8, 0xc4, 0xfb, 0xe0, 0x85,
-	0x8c, 0x07, 0x2f, 0x22, 0x3a, 0xe1, 0x9c, 0xcd, 0xe2, 0x47, 0xdf, 0x30, 0x2e, 0x77, 0x64, 0x98,
-	0x5c, 0xc5, 0xde, 0xf6, 0x6f, 0xb0, 0x20, 0x8f, 0xa1, 0x73, 0x9a, 0xcc, 0x58, 0xac, 0x33, 0x89,
-	0x1e, 0xb4, 0x65, 0x4e, 0x62, 0x59, 0x1e, 0x8e, 0xba, 0xc1, 0x72, 0x2d, 0xf7, 0xd2, 0x80, 0xf3,
-	0x97, 0x49, 0x16, 0xea, 0xd2, 0x29, 0xd7, 0xe4, 0x4d, 0xd8, 0xd4, 0x3c, 0x3a, 0xfd, 0x7d, 0xa8,
-	0x7f, 0xf1, 0x52, 0x68, 0x0e, 0xf9, 0x93, 0x3c, 0x87, 0xde, 0x29, 0xe3, 0xfa, 0x02, 0x72, 0x6f,
-	0xdb, 0xd0, 0x8c, 0xd8, 0x9c, 0xe5, 0x66, 0x4d, 0x3f, 0x5f, 0xe0, 0x2e, 0xb4, 0x92, 0xcb, 0x4b,
-	0x4e, 0x85, 0xf2, 0xd2, 0xf4, 0xf5, 0x4a, 0xe2, 0x9c, 0x06, 0xd9, 0xc5, 0xe7, 0xaa, 0x2a, 0xd7,
-	0x7d, 0xbd, 0x22, 0xfb, 0x79, 0x61, 0x15, 0xa4, 0x5d, 0xa8, 0xb1, 0x50, 0x77, 0x41, 0x8d, 0x49,
-	0x69, 0xbd, 0x49, 0x18, 0x9a, 0xf7, 0xbe, 0x62, 0xf2, 0x87, 0x03, 0x1d, 0x69, 0x50, 0xa6, 0xd5,
-	0x32, 0xa8, 0xa4, 0xa5, 0x66, 0xa5, 0xe5, 0x00, 0x80, 0x53, 0xce, 0x59, 0x12, 0x9f, 0x9f, 0x9f,
-	0x2a, 0x69, 0x4d, 0xdf, 0x40, 0xcc, 0x6e, 0x6a, 0x54, 0xbb, 0xc9, 0x83, 0x36, 0xe3, 0x93, 0x0b,
-	0xc1, 0xbe, 0xa6, 0xaa, 0x5d, 0xda, 0x7e, 0xb9, 0xae, 0x76, 0x5a, 0xeb, 0xc6, 0x4e, 0x5b, 0xb3,
-	0x3b, 0x6d, 0x01, 0x6d, 0x19, 0xcd, 0x49, 0x7c, 0x99, 0xe0, 0x07, 0xd0, 0x59, 0x18, 0x91, 0xe9,
-	0x76, 0xd8, 0x52, 0x95, 0x66, 0x86, 0xec, 0x57, 0xcc, 0x70, 0x0c, 0x1b, 0x8b, 0x65, 0xb3, 0xaa,
-	0x98, 0x37, 0xc6, 0xfd, 0xf2, 0x54, 0xd1, 0x6c, 0xa6, 0x11, 0xf9, 0xd3, 0x81, 0x9e, 0xd5, 0x61,
-	0xff, 0xf3, 0x44, 0xfe, 0x52, 0x83, 0x6e, 0x59, 0x3b, 0xff, 0xaa, 0x41, 0x5e, 0x53, 0x70, 0xf7,
-	0xed, 0x01, 0xd4, 0x52, 0x03, 0xc8, 0xcd, 0x67, 0x57, 0xae, 0xdc, 0x9c, 0x43, 0xf6, 0x0c, 0xba,
-	0x67, 0x8d, 0xbe, 0x35, 0x75, 0x7c, 0xcf, 0x3c, 0x6e, 0x4c, 0x40, 0x6b, 0x0e, 0x3f, 0x87, 0xc1,
-	0x15, 0x2e, 0x6e, 0xfd, 0x22, 0x19, 0x11, 0xd7, 0x2a, 0x11, 0x93, 0x73, 0xc0, 0x55, 0xe7, 0xb7,
-	0x7c, 0xa4, 0xaf, 0x67, 0xfd, 0xd9, 0x81, 0xad, 0x67, 0xea, 0x6a, 0x6f, 0x98, 0x16, 0xff, 0x7d,
-	0x81, 0x92, 0xcf, 0xa0, 0xbf, 0x9c, 0x8b, 0xba, 0x6d, 0x0e, 0x00, 0x44, 0x22, 0x82, 0xe8, 0x41,
-	0xb2, 0x88, 0x8b, 0xe9, 0x68, 0x20, 0xf8, 0x2e, 0xb4, 0x32, 0xca, 0x17, 0x91, 0xa8, 0x3c, 0x56,
-	0xf6, 0xf3, 0xa6, 0x6d, 0xc8, 0x00, 0xb6, 0x24, 0xfe, 0x68, 0x9e, 0x8a, 0x6f, 0x8b, 0x4d, 0x32,
-	0x85, 0x37, 0x96, 0xd9, 0x38, 0xd3, 0x65, 0x7a, 0x43, 0x56, 0xae, 0xab, 0xec, 0xf1, 0xdf, 0x75,
-	0x68, 0x48, 0x0e, 0x9c, 0x42, 0x43, 0x06, 0x82, 0xb9, 0x18, 0x6b, 0xd6, 0x7b, 0x3b, 0x16, 0xaa,
-	0x65, 0xe0, 0x8f, 0x7f, 0xbd, 0xfa, 0xa9, 0xd6, 0x41, 0x50, 0x9f, 0x6b, 0x32, 0xd5, 0x1c, 0x1f,
-	0x43, 0x7d, 0x4a, 0x05, 0x2e, 0xc7, 0x4d, 0xc1, 0x71, 0x65, 0x98, 0x64, 0x4f, 0x51, 0x6c, 0x61,
-	0x6f, 0x49, 0x31, 0xfa, 0x9e, 0x85, 0x3f, 0xe0, 0x47, 0xd0, 0x7a, 0xa0, 0x3a, 0x1d, 0x07, 0x66,
-	0x45, 0x57, 0xd9, 0xac, 0xb7, 0x81, 0xec, 0x28, 0xb6, 0x1e, 0x31, 0x04, 0xdd, 0x75, 0x8e, 0xf0,
-	0x1c, 0x5a, 0x79, 0xba, 0x70, 0x37, 0x97, 0x65, 0x57, 0x92, 0xb7, 0x5b, 0xca, 0xad, 0x26, 0xda,
-	0x53, 0x84, 0xdb, 0x9e, 0x2d, 0x4f, 0xb2, 0x7e, 0x0c, 0xad, 0x87, 0x34, 0xa2, 0x82, 0x5e, 0x11,
-	0xec, 0x75, 0x7c, 0x3a, 0xdc, 0xa3, 0x95, 0x70, 0xe7, 0xd0, 0xcd, 0x55, 0x9d, 0x95, 0x43, 0xc7,
-	0x92, 0x6a, 0x5d, 0xf3, 0xb5, 0x2e, 0xde, 0x52, 0x2e, 0xf6, 0x3d, 0xd7, 0x72, 0x31, 0x2a, 0x2e,
-	0xfd, 0xae, 0x73, 0x34, 0xfe, 0xd5, 0x81, 0xf6, 0x49, 0x2c, 0x64, 0x6f, 0x44, 0xf8, 0x04, 0x9a,
-	0xea, 0xfd, 0xc7, 0xfc, 0x65, 0x31, 0xbf, 0x29, 0x3c, 0x34, 0x21, 0xed, 0xe1, 0x40, 0x79, 0x70,
-	0xc9, 0x40, 0x79, 0x60, 0x9a, 0x66, 0x14, 0x49, 0x23, 0x99, 0x98, 0xa7, 0xb0, 0x56, 0x7c, 0x28,
-	0x0e, 0xcc, 0xaf, 0xa2, 0xea, 0xdd, 0x59, 0xdf, 0x7c, 0x64, 0x5f, 0xb1, 0xee, 0xe1, 0x4e, 0x95,
-	0x35, 0xcd, 0xcd, 0x5e, 0xb4, 0xd4, 0x3f, 0x80, 0xf7, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x87,
-	0x6b, 0x5e, 0x10, 0x32, 0x0c, 0x00, 0x00,
+	// 1698 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0xdd, 0x6e, 0xd4, 0xc6,
+	0x17, 0x97, 0x77, 0x37, 0x9b, 0xcd, 0x49, 0xb2, 0x9b, 0x4c, 0xbe, 0x16, 0x13, 0x42, 0x18, 0x50,
+	0xfe, 0x51, 0xfe, 0x24, 0x41, 0x69, 0x2b, 0x55, 0x20, 0x21, 0x2d, 0x10, 0x56, 0xa1, 0x29, 0x01,
+	0x27, 0x88, 0xdb, 0x3a, 0xf1, 0x64, 0x19, 0xf0, 0xda, 0xae, 0x67, 0x16, 0x42, 0x2b, 0x7a, 0xd1,
+	0xcb, 0xde, 0xb6, 0xf4, 0xa6, 0x6f, 0xd0, 0x17, 0xe8, 0x45, 0xfb, 0x08, 0x95, 0x7a, 0xd1, 0x57,
+	0xe0, 0x15, 0x7a, 0x5f, 0xcd, 0x87, 0xed, 0xb1, 0xf7, 0x43, 0x91, 0x5a, 0x2a, 0x71, 0xe7, 0xf9,
+	0xcd, 0x99, 0xf3, 0x3d, 0xe7, 0x9c, 0x31, 0x40, 0x8f, 0x91, 0x78, 0x2b, 0x8a, 0x43, 0x1e, 0xa2,
+	0xb2, 0x1b, 0x51, 0x7b, 0xb9, 0x13, 0x86, 0x1d, 0x9f, 0x6c, 0xbb, 0x11, 0xdd, 0x76, 0x83, 0x20,
+	0xe4, 0x2e, 0xa7, 0x61, 0xc0, 0x14, 0x09, 0xfe, 0xc5, 0x82, 0x46, 0x2b, 0x8a, 0x7c, 0x7a, 0x22,
+	0xe1, 0x7d, 0x1a, 0xbc, 0x40, 0xd7, 0x60, 0xda, 0xcd, 0xa0, 0xbd, 0x7b, 0x4d, 0x6b, 0xd5, 0x5a,
+	0x2f, 0x3b, 0x79, 0x10, 0xad, 0x43, 0xc3, 0x00, 0x1e, 0xba, 0x5d, 0xd2, 0x2c, 0xad, 0x5a, 0xeb,
+	0x13, 0x4e, 0x11, 0x46, 0x4d, 0x18, 0xa7, 0xac, 0xe5, 0x75, 0x69, 0xd0, 0x2c, 0xaf, 0x5a, 0xeb,
+	0x35, 0x27, 0x59, 0xa2, 0x65, 0x98, 0x38, 0x89, 0x89, 0xcb, 0x89, 0xd7, 0xe2, 0xcd, 0x8a, 0x3c,
+	0x9d, 0x01, 0x62, 0xb7, 0x17, 0x79, 0x7a, 0x77, 0x4c, 0xed, 0xa6, 0x00, 0xfe, 0xcd, 0x82, 0x99,
+	0x83, 0xb8, 0xe3, 0x06, 0xf4, 0xab, 0x4c, 0xf5, 0x35, 0xa8, 0x87, 0x06, 0x96, 0xea, 0x5e, 0x40,
+	0xd1, 0x06, 0xcc, 0x98, 0x88, 0xa1, 0x7d, 0x1f, 0xfe, 0x9e, 0xd4, 0x6f, 0xc3, 0xe4, 0x13, 0x46,
+	0xe2, 0x47, 0x71, 0x78, 0x4a, 0x7d, 0x82, 0x3e, 0x85, 0x29, 0xc3, 0x6d, 0xac, 0x69, 0xad, 0x96,
+	0xd7, 0x27, 0x77, 0xe6, 0xb7, 0xdc, 0x88, 0x6e, 0x15, 0xe2, 0xe3, 0xe4, 0x28, 0xf1, 0x0c, 0xd4,
+	0x35, 0x13, 0x87, 0x7c, 0xd9, 0x23, 0x8c, 0xe3, 0x77, 0x16, 0x34, 0x52, 0x88, 0x45, 0x61, 0xc0,
+	0x08, 0x5a, 0x87, 0x8a, 0x48, 0x0c, 0xe9, 0x8e, 0x84, 0x6f, 0x9b, 0x70, 0xa1, 0x42, 0x42, 0xe3,
+	0x48, 0x8a, 0x3e, 0x4d, 0x4a, 0xe7, 0xd5, 0x04, 0xdd, 0x82, 0x69, 0xd3, 0x79, 0xac, 0x59, 0x96,
+	0x47, 0x17, 0xe4, 0xd1, 0x62, 0xa8, 0x9c, 0x3c, 0x2d, 0xba, 0x01, 0x35, 0x46, 0x38, 0xa7, 0x41,
+	0x87, 0x49, 0x57, 0x26, 0x22, 0xb5, 0x21, 0x87, 0x7a, 0xcf, 0x49, 0xa9, 0x70, 0x03, 0xa6, 0x77,
+	0x3b, 0x31, 0x61, 0x2c, 0xb1, 0xfb, 0x39, 0xd4, 0x13, 0x40, 0x5b, 0xbd, 0x0a, 0x93, 0x34, 0x6a,
+	0x79, 0x9e, 0x00, 0x89, 0x72, 0xea, 0x84, 0x63, 0x42, 0x68, 0x1e, 0xc6, 0xa2, 0x38, 0x3c, 0x7b,
+	0xad, 0xa3, 0xaf, 0x16, 0xe2, 0xdc, 0x31, 0x0d, 0x3c, 0x4d, 0x26, 0xc3, 0x3e, 0xe1, 0x98, 0x10,
+	0x3e, 0x85, 0xc5, 0x7d, 0xca, 0xb8, 0x16, 0xbd, 0x1f, 0x76, 0x12, 0x2d, 0x04, 0x47, 0x9f, 0x76,
+	0x29, 0xd7, 0x99, 0xa7, 0x16, 0x68, 0x11, 0xaa, 0x5d, 0xc2, 0x9f, 0x85, 0x9e, 0x16, 0xa4, 0x57,
+	0x68, 0x05, 0x80, 0xc4, 0x71, 0x18, 0xb3, 0x83, 0xc0, 0x7f, 0xad, 0xf3, 0xcb, 0x40, 0xf0, 0x8f,
+	0x25, 0x80, 0x4c, 0x48, 0x3e, 0xe3, 0xac, 0x62, 0xc6, 0x0d, 0x13, 0x82, 0xa0, 0x72, 0x12, 0x7a,
+	0x44, 0xdb, 0x21, 0xbf, 0x85, 0x9a, 0x52, 0x8c, 0xce, 0x5b, 0xb5, 0x10, 0xea, 0x78, 0xbd, 0x58,
+	0x86, 0xe4, 0xf3, 0x43, 0x99, 0xb4, 0x65, 0xc7, 0x40, 0x44, 0x69, 0x88, 0x49, 0x37, 0xe4, 0x24,
+	0x71, 0x4d, 0x55, 0x9e, 0xce, 0x83, 0x32, 0xf3, 0x19, 0x89, 0x5b, 0x1d, 0x12, 0xf0, 0xe6, 0xb8,
+	0xce, 0xfc, 0x04, 0x10, 0xce, 0x8d, 0x95, 0x45, 0x0f, 0x0e, 0x0f, 0x1e, 0x36, 0x6b, 0xca, 0xb9,
+	0x06, 0x84, 0x30, 0x4c, 0xc5, 0x3a, 0x84, 0x92, 0x64, 0x42, 0x92, 0xe4, 0x30, 0x7c, 0x07, 0x96,
+	0xfa, 0x02, 0xa0, 0xa3, 0xfe, 0x3f, 0xa8, 0xc6, 0x84, 0xf5, 0x7c, 0xae, 0x6f, 0x51, 0x43, 0x26,
+	0x52, 0x46, 0xe9, 0xe8, 0x6d, 0x7c, 0x07, 0xe6, 0xdb, 0x84, 0xcb, 0xbb, 0x7c, 0xc8, 0x5d, 0x6e,
+	0x86, 0xf0, 0x59, 0xd8, 0x8b, 0x99, 0xf4, 0xf0, 0xb4, 0xa3, 0x16, 0x59, 0x60, 0x4b, 0x0a, 0x95,
+	0x0b, 0x71, 0xd9, 0xea, 0x47, 0x61, 0x64, 0xdc, 0x8c, 0x7f, 0xbd, 0x7e, 0xf6, 0x17, 0xb5, 0xf2,
+	0xb9, 0x8b, 0x5a, 0x65, 0x78, 0x51, 0xeb, 0x45, 0x3e, 0x0d, 0x5e, 0x30, 0x1d, 0xe5, 0x64, 0x29,
+	0xcc, 0x0c, 0x42, 0x8f, 0xa8, 0xd0, 0x96, 0x1d, 0xb5, 0xc0, 0x7b, 0x60, 0xb7, 0x09, 0xcf, 0x1b,
+	0x9a, 0x79, 0xfc, 0xff, 0x05, 0x8f, 0xcf, 0x49, 0x8f, 0xe7, 0xa9, 0x53, 0xaf, 0xff, 0x6a, 0xc1,
+	0x82, 0x59, 0x0d, 0x76, 0x45, 0xe6, 0x39, 0x2e, 0x27, 0xef, 0xab, 0x7a, 0x27, 0x86, 0x96, 0xf3,
+	0x86, 0x2e, 0x42, 0x55, 0x5d, 0x34, 0xe9, 0xa4, 0xb2, 0xa3, 0x57, 0x22, 0x7b, 0x49, 0xa2, 0x92,
+	0x74, 0x8e, 0xe5, 0x64, 0x00, 0x7e, 0x0a, 0x57, 0xda, 0x84, 0x0f, 0xd4, 0x3f, 0xf3, 0xc7, 0x4e,
+	0xc1, 0x1f, 0x76, 0x5f, 0x09, 0x4c, 0x0f, 0xa5, 0x6e, 0xf9, 0xb9, 0x04, 0x68, 0x2f, 0xe0, 0xa4,
+	0xa3, 0x2e, 0xdb, 0x7d, 0x97, 0xfa, 0xbd, 0x98, 0x7c, 0x10, 0xc9, 0x84, 0xa0, 0xf2, 0x82, 0x06,
+	0x9e, 0x6e, 0x72, 0xf2, 0x5b, 0x56, 0x12, 0xe2, 0xd3, 0x97, 0x24, 0xa6, 0x69, 0x2e, 0x19, 0x08,
+	0xb2, 0xa1, 0x76, 0xaa, 0x4c, 0x64, 0xb2, 0x44, 0x94, 0x9d, 0x74, 0x2d, 0x2a, 0x84, 0xfe, 0x96,
+	0x31, 0xa8, 0xc9, 0x18, 0x98, 0x10, 0x7e, 0x0c, 0x2b, 0x6d, 0xc2, 0xfb, 0xdd, 0x95, 0x85, 0x60,
+	0xbb, 0x10, 0x82, 0x25, 0x19, 0x82, 0xfe, 0x13, 0xa9, 0xff, 0x2f, 0xc2, 0x85, 0x36, 0xe1, 0xfb,
+	0xc4, 0xf5, 0x48, 0xbc, 0xeb, 0x93, 0x13, 0x9d, 0xe1, 0xaa, 0xb5, 0x9c, 0x41, 0x3d, 0xbf, 0x83,
+	0x66, 0xa0, 0xfc, 0x3c, 0x3c, 0xd6, 0x35, 0x58, 0x7c, 0x0a, 0x8b, 0x7c, 0x49, 0xb3, 0x77, 0x4f,
+	0x3b, 0x3f, 0x5d, 0x8b, 0x3d, 0xca, 0x14, 0x07, 0x5d, 0xe4, 0xd3, 0xb5, 0xcc, 0xb7, 0xb3, 0x88,
+	0xc6, 0x84, 0x65, 0x53, 0x44, 0x0a, 0x60, 0x2a, 0x2f, 0x5e, 0x9f, 0x5a, 0xda, 0xca, 0x15, 0x00,
+	0x1a, 0x30, 0xee, 0x06, 0x27, 0x44, 0xa7, 0xc6, 0x84, 0x63, 0x20, 0xc6, 0xc5, 0x2c, 0x19, 0x17,
+	0x33, 0xcf, 0x2d, 0xf5, 0xc0, 0x63, 0x68, 0x14, 0xba, 0x2d, 0xba, 0x0d, 0xb6, 0x47, 0x99, 0x7b,
+	0xec, 0x93, 0x16, 0x63, 0xb4, 0x13, 0xec, 0x9e, 0x51, 0x26, 0x76, 0xc4, 0xdc, 0xa0, 0xca, 0x63,
+	0xcd, 0x19, 0x41, 0x81, 0xef, 0xc3, 0xd4, 0x7e, 0xd8, 0xa1, 0x41, 0x52, 0x59, 0x6d, 0xa8, 0x89,
+	0x46, 0x10, 0x88, 0x6c, 0x52, 0xda, 0xa6, 0x6b, 0xb1, 0x17, 0xb9, 0x8c, 0xbd, 0x0a, 0xe3, 0xa4,
+	0x7f, 0xa5, 0x6b, 0x7c, 0x05, 0xa6, 0x35, 0x1f, 0x6d, 0xb8, 0x70, 0xff, 0x2b, 0x9e, 0xba, 0xff,
+	0x15, 0xc7, 0x4f, 0xa1, 0x21, 0x1a, 0x82, 0x9a, 0x68, 0x06, 0xb4, 0xe2, 0x31, 0xa3, 0x15, 0x87,
+	0xa7, 0xa7, 0x8c, 0xa8, 0x42, 0x3e, 0xe6, 0xe8, 0x95, 0xc0, 0x19, 0x71, 0xe3, 0x93, 0x67, 0xba,
+	0x4f, 0xea, 0x15, 0xbe, 0x04, 0x93, 0x26, 0xd3, 0x3a, 0x94, 0xa8, 0xa7, 0x6f, 0x61, 0x89, 0x0a,
+	0xd5, 0x1a, 0x2d, 0xcf, 0x33, 0x07, 0xa9, 0x3e, 0x92, 0xdf, 0x2d, 0x98, 0x12, 0x04, 0xa9, 0x5b,
+	0x0b, 0x04, 0x39, 0xb7, 0x94, 0x0a, 0x6e, 0x59, 0x01, 0x60, 0x84, 0x31, 0x1a, 0x06, 0x47, 0x47,
+	0xfb, 0x52, 0xb5, 0x31, 0xc7, 0x40, 0xcc, 0xf1, 0xb4, 0x92, 0x1f, 0x4f, 0x65, 0xd2, 0xb5, 0x4e,
+	0x38, 0x7d, 0xa9, 0xea, 0x58, 0xcd, 0x49, 0xd7, 0xf9, 0x41, 0xa2, 0x3a, 0x72, 0x74, 0x1d, 0x2f,
+	0x8e, 0xae, 0x3d, 0xa8, 0x09, 0x6b, 0xf6, 0x82, 0xd3, 0x10, 0x7d, 0x02, 0x53, 0x3d, 0xc3, 0x32,
+	0x3d, 0x5f, 0xce, 0xca, 0x34, 0x33, 0x4d, 0x76, 0x72, 0x64, 0x68, 0x07, 0x26, 0x7b, 0xd9, 0xf4,
+	0x2b, 0x6d, 0x9e, 0xdc, 0x99, 0x49, 0x4f, 0x69, 0xdc, 0x31, 0x89, 0xf0, 0x1f, 0x16, 0x34, 0x0a,
+	0x23, 0xeb, 0x07, 0xee, 0xc8, 0x9f, 0x4a, 0x50, 0x4f, 0x73, 0xe7, 0x1f, 0x5d, 0x90, 0xf7, 0x64,
+	0xdc, 0xed, 0xe2, 0x44, 0x5f, 0x95, 0x55, 0xa4, 0xa9, 0x1e, 0x03, 0x4a, 0x73, 0xb3, 0xab, 0x15,
+	0x87, 0xfa, 0x5b, 0x85, 0xb7, 0xc4, 0xb8, 0x51, 0x8a, 0xf5, 0x71, 0x73, 0x42, 0xc8, 0x3f, 0x6c,
+	0x9e, 0xc2, 0xdc, 0x00, 0x11, 0xe7, 0x1e, 0x12, 0x0c, 0x8b, 0x4b, 0x39, 0x8b, 0xf1, 0x11, 0xa0,
+	0x7e, 0xe1, 0xe7, 0x6c, 0xb4, 0xc3, 0xb9, 0xbe, 0xb5, 0x60, 0xf6, 0x89, 0x0c, 0xed, 0x88, 0x6a,
+	0xf1, 0xdf, 0x27, 0x28, 0xfe, 0x02, 0x66, 0xb2, 0xba, 0x98, 0xb5, 0x0d, 0x1e, 0x72, 0xd7, 0xbf,
+	0x1b, 0xf6, 0x82, 0xa4, 0x3a, 0x1a, 0x08, 0xba, 0x5e, 0x68, 0x1b, 0x83, 0xdf, 0x8b, 0x49, 0xdf,
+	0x98, 0x83, 0x59, 0x81, 0xef, 0x76, 0x23, 0xfe, 0x3a, 0xd9, 0xc4, 0x6d, 0xb8, 0x90, 0x79, 0xe3,
+	0x91, 0x4e, 0xd3, 0x11, 0x5e, 0x19, 0x96, 0xd9, 0x3b, 0x7f, 0x95, 0xa1, 0x22, 0x78, 0xa0, 0x36,
+	0x54, 0x84, 0x21, 0x48, 0x29, 0x53, 0xa8, 0xf5, 0xf6, 0x42, 0x01, 0xd5, 0x6a, 0xa0, 0x6f, 0xff,
+	0x7c, 0xf7, 0x7d, 0x69, 0x0a, 0x81, 0xfc, 0xff, 0x21, 0x5c, 0xcd, 0xd0, 0x7d, 0x28, 0xb7, 0x09,
+	0x47, 0x59, 0xb9, 0x49, 0x78, 0x0c, 0x34, 0x13, 0x2f, 0x49, 0x16, 0xb3, 0xa8, 0x91, 0xb1, 0xd8,
+	0xfe, 0x9a, 0x7a, 0x6f, 0xd0, 0x03, 0xa8, 0xde, 0x95, 0x37, 0x1d, 0xcd, 0x99, 0x19, 0x9d, 0xe7,
+	0x56, 0xe8, 0x0d, 0x78, 0x41, 0x72, 0x6b, 0x60, 0x43, 0xa1, 0x9b, 0xd6, 0x06, 0x3a, 0x82, 0xaa,
+	0x72, 0x17, 0x5a, 0x54, 0x6a, 0x15, 0x33, 0xc9, 0x5e, 0x4c, 0xd5, 0xcd, 0x3b, 0xda, 0x96, 0x0c,
+	0xe7, 0xed, 0xa2, 0x7a, 0x82, 0xeb, 0x67, 0x50, 0xbd, 0x47, 0x7c, 0xc2, 0xc9, 0x00, 0x63, 0x87,
+	0xf1, 0xd3, 0xe6, 0x6e, 0xf4, 0x99, 0xdb, 0x85, 0xba, 0xd2, 0xea, 0x51, 0x5a, 0x74, 0x0a, 0xaa,
+	0x16, 0xc2, 0x3c, 0x54, 0xc4, 0x55, 0x29, 0xe2, 0x92, 0xdd, 0x2c, 0x88, 0xd8, 0x4e, 0x82, 0x7e,
+	0xd3, 0xda, 0xd8, 0x79, 0x3b, 0x0e, 0x35, 0x31, 0xae, 0xc5, 0x81, 0xeb, 0xa3, 0x87, 0x30, 0x26,
+	0xfb, 0x3f, 0x52, 0x9d, 0xc5, 0x9c, 0x29, 0x6c, 0x64, 0x42, 0x5a, 0xc2, 0x8a, 0x94, 0xd0, 0xc4,
+	0x73, 0x52, 0x02, 0xd5, 0x6c, 0xb6, 0x7d, 0x41, 0x24, 0x1c, 0x73, 0x08, 0xe3, 0xc9, 0x9f, 0x97,
+	0x39, 0xf3, 0x37, 0x43, 0x3e, 0x76, 0x85, 0x9f, 0x28, 0xf8, 0x92, 0xe4, 0xba, 0x84, 0x16, 0xf2,
+	0x5c, 0x23, 0xcd, 0xe9, 0x00, 0xaa, 0xea, 0xff, 0x03, 0x52, 0x2a, 0xe5, 0xfe, 0x4e, 0xd8, 0x73,
+	0x39, 0x4c, 0x73, 0x5c, 0x96, 0x1c, 0x17, 0xd1, 0x7c, 0x9e, 0x23, 0x51, 0x6c, 0x22, 0x35, 0xd2,
+	0x18, 0x6f, 0x5c, 0x74, 0x31, 0x4d, 0xf3, 0xfe, 0x5f, 0x0f, 0xf6, 0xf2, 0xe0, 0x4d, 0x2d, 0x0b,
+	0x4b, 0x59, 0xcb, 0xc8, 0xce, 0xcb, 0xd2, 0x0f, 0xef, 0x4d, 0x5f, 0xb0, 0xff, 0x06, 0x50, 0xff,
+	0x33, 0x0f, 0x5d, 0x48, 0xee, 0x45, 0xdf, 0x53, 0xd9, 0xbe, 0x9c, 0x6c, 0x0d, 0x79, 0x1a, 0xe2,
+	0xeb, 0x52, 0xea, 0x1a, 0xba, 0x96, 0x97, 0xca, 0x04, 0x93, 0x6d, 0x1e, 0x46, 0x9b, 0xb9, 0x5f,
+	0x48, 0x3f, 0x58, 0x72, 0x0a, 0x1f, 0xfc, 0xbc, 0x1a, 0xa5, 0xc7, 0x5a, 0xb2, 0x35, 0xfa, 0x65,
+	0x86, 0x3f, 0x96, 0xea, 0x6c, 0xa1, 0xeb, 0x83, 0xd4, 0x31, 0x3b, 0xc8, 0xa6, 0x7c, 0xf2, 0x6d,
+	0xc6, 0x52, 0xf0, 0x77, 0x16, 0x2c, 0x0e, 0x7e, 0x6f, 0x8c, 0xd2, 0xe9, 0x6a, 0xb2, 0x35, 0xe2,
+	0x9d, 0x82, 0x6f, 0x48, 0x85, 0x36, 0xd0, 0xfa, 0x20, 0x85, 0x68, 0x76, 0x70, 0x33, 0x7d, 0x1d,
+	0xbd, 0x91, 0x31, 0x2a, 0xbc, 0x08, 0xf4, 0x5d, 0x1c, 0xfa, 0x82, 0xb1, 0x2f, 0x0f, 0xdd, 0xd7,
+	0x8a, 0xac, 0x49, 0x45, 0x56, 0xd1, 0x4a, 0xe1, 0xca, 0x48, 0xf2, 0x4d, 0x92, 0xd0, 0x1f, 0x57,
+	0xe5, 0x8f, 0xe3, 0x8f, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xdd, 0xf7, 0xdb, 0x0d, 0x69, 0x16,
+	0x00, 0x00,
 }
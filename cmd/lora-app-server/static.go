@@ -0,0 +1,14 @@
+// +build !nostatic
+
+package main
+
+import (
+	"github.com/brocaar/lora-app-server/internal/assets"
+	"github.com/brocaar/lora-app-server/internal/static"
+)
+
+// When building with the nostatic build tag, the static assets are not
+// embedded and must be provided using --static-dir or --static-url.
+func init() {
+	embeddedAssets = assets.NewEmbedded(static.Asset, static.AssetDir, static.AssetInfo)
+}
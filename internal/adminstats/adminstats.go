@@ -0,0 +1,161 @@
+// Package adminstats keeps hourly counters of the error notifications and
+// the integration deliveries per application, so that the operators of
+// (large) multi-tenant instances can spot the applications and integrations
+// causing problems.
+package adminstats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+)
+
+const (
+	errorsKeyTempl     = "admin-stats:errors:%d"
+	deliveriesKeyTempl = "admin-stats:integration-deliveries:%d"
+	failuresKeyTempl   = "admin-stats:integration-failures:%d"
+)
+
+// BucketDuration defines the duration over which the counters are
+// aggregated.
+const BucketDuration = time.Hour
+
+// Retention defines how long the counters are kept.
+var Retention = 7 * 24 * time.Hour
+
+// IntegrationCount contains the number of deliveries and failed deliveries
+// of an application integration.
+type IntegrationCount struct {
+	ApplicationID int64
+	Kind          string
+	Deliveries    int
+	Failures      int
+}
+
+// RecordError accounts an error notification of the given application.
+func RecordError(applicationID int64) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(errorsKeyTempl, bucket(time.Now()))
+	c.Send("MULTI")
+	c.Send("HINCRBY", key, applicationID, 1)
+	c.Send("PEXPIRE", key, int64(Retention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "increment error count error")
+	}
+	return nil
+}
+
+// RecordDelivery accounts a delivery to the given integration (kind) of the
+// given application.
+func RecordDelivery(applicationID int64, kind string, failed bool) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	b := bucket(time.Now())
+	field := fmt.Sprintf("%d:%s", applicationID, kind)
+	ttl := int64(Retention / time.Millisecond)
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", common.RedisKey(deliveriesKeyTempl, b), field, 1)
+	c.Send("PEXPIRE", common.RedisKey(deliveriesKeyTempl, b), ttl)
+	if failed {
+		c.Send("HINCRBY", common.RedisKey(failuresKeyTempl, b), field, 1)
+		c.Send("PEXPIRE", common.RedisKey(failuresKeyTempl, b), ttl)
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "increment delivery count error")
+	}
+	return nil
+}
+
+// GetErrorCounts returns the number of error notifications per application
+// since the given time (rounded down to the start of the bucket).
+func GetErrorCounts(since time.Time) (map[int64]int, error) {
+	counts, err := getCounts(errorsKeyTempl, since)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[int64]int)
+	for field, count := range counts {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse application id error")
+		}
+		out[id] = count
+	}
+	return out, nil
+}
+
+// GetIntegrationCounts returns the number of deliveries and failed
+// deliveries per application integration since the given time (rounded
+// down to the start of the bucket).
+func GetIntegrationCounts(since time.Time) ([]IntegrationCount, error) {
+	deliveries, err := getCounts(deliveriesKeyTempl, since)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := getCounts(failuresKeyTempl, since)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]IntegrationCount, 0, len(deliveries))
+	for field, count := range deliveries {
+		parts := strings.SplitN(field, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid integration field: %s", field)
+		}
+		id, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse application id error")
+		}
+		out = append(out, IntegrationCount{
+			ApplicationID: id,
+			Kind:          parts[1],
+			Deliveries:    count,
+			Failures:      failures[field],
+		})
+	}
+	return out, nil
+}
+
+// getCounts returns the summed counters of the buckets since the given
+// time.
+func getCounts(keyTempl string, since time.Time) (map[string]int, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	var buckets int
+	for t := since.Truncate(BucketDuration); !t.After(time.Now()); t = t.Add(BucketDuration) {
+		c.Send("HGETALL", common.RedisKey(keyTempl, bucket(t)))
+		buckets++
+	}
+	if err := c.Flush(); err != nil {
+		return nil, errors.Wrap(err, "get counts error")
+	}
+
+	out := make(map[string]int)
+	for i := 0; i < buckets; i++ {
+		counts, err := redis.IntMap(c.Receive())
+		if err != nil {
+			return nil, errors.Wrap(err, "get counts error")
+		}
+		for field, count := range counts {
+			out[field] += count
+		}
+	}
+	return out, nil
+}
+
+func bucket(t time.Time) int64 {
+	return t.Truncate(BucketDuration).Unix()
+}
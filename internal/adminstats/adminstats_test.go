@@ -0,0 +1,47 @@
+package adminstats
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestAdminStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		Convey("When recording errors", func() {
+			So(RecordError(1), ShouldBeNil)
+			So(RecordError(1), ShouldBeNil)
+			So(RecordError(2), ShouldBeNil)
+
+			Convey("Then GetErrorCounts returns the errors per application", func() {
+				counts, err := GetErrorCounts(time.Now().Add(-time.Hour))
+				So(err, ShouldBeNil)
+				So(counts, ShouldResemble, map[int64]int{1: 2, 2: 1})
+			})
+		})
+
+		Convey("When recording integration deliveries", func() {
+			So(RecordDelivery(1, "HTTP", false), ShouldBeNil)
+			So(RecordDelivery(1, "HTTP", true), ShouldBeNil)
+			So(RecordDelivery(1, "HTTP", true), ShouldBeNil)
+
+			Convey("Then GetIntegrationCounts returns the deliveries and failures", func() {
+				counts, err := GetIntegrationCounts(time.Now().Add(-time.Hour))
+				So(err, ShouldBeNil)
+				So(counts, ShouldResemble, []IntegrationCount{
+					{ApplicationID: 1, Kind: "HTTP", Deliveries: 3, Failures: 2},
+				})
+			})
+		})
+	})
+}
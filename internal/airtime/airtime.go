@@ -0,0 +1,239 @@
+// Package airtime provides functions to estimate the airtime of downlink
+// transmissions and to keep track of the downlink airtime used per device,
+// so that it can be checked against the configured airtime budget.
+package airtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	dataRateKeyTempl = "device:%s:datarate"
+	usageKeyTempl    = "device:%s:airtime:downlink:%d"
+)
+
+const (
+	// Window defines the window in which the downlink airtime is accounted.
+	Window = time.Hour
+
+	// phyPayloadOverhead defines the number of bytes added to the
+	// FRMPayload (MHDR, FHDR without FOpts, FPort and MIC).
+	phyPayloadOverhead = 13
+
+	dataRateTTL = 24 * time.Hour
+)
+
+// ErrUnknownBand is returned when the configured band is unknown.
+var ErrUnknownBand = errors.New("unknown band")
+
+// bandDutyCycles contains per band the max. duty-cycle of the downlink
+// transmissions (see the LoRaWAN Regional Parameters). As the RX1 downlinks
+// use the uplink channels, the duty-cycle of these is used. Bands without
+// duty-cycle restrictions are set to 0.
+var bandDutyCycles = map[string]float64{
+	"AS_923":     0,
+	"AU_915_928": 0,
+	"CN_470_510": 0,
+	"CN_779_787": 0.01,
+	"EU_433":     0.01,
+	"EU_863_870": 0.01,
+	"IN_865_867": 0,
+	"KR_920_923": 0,
+	"US_902_928": 0,
+}
+
+// dutyCycle holds the duty-cycle of the configured band.
+var dutyCycle float64
+
+// defaultDataRate is used when the data-rate of the device is unknown
+// (worst case).
+var defaultDataRate = handler.DataRate{
+	Modulation:   "LORA",
+	Bandwidth:    125,
+	SpreadFactor: 12,
+}
+
+// CalculateLoRaAirtime calculates the airtime of a LoRa modulated frame
+// (see the Semtech LoRa modem designer's guide). The bandwidth must be given
+// in kHz, the codingRate as 1 - 4 (meaning 4/5 - 4/8).
+func CalculateLoRaAirtime(payloadSize, sf, bandwidth, preambleNumber, codingRate int, headerEnabled, crc bool) (time.Duration, error) {
+	if sf < 6 || sf > 12 {
+		return 0, fmt.Errorf("invalid spread-factor: %d", sf)
+	}
+	if bandwidth <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth: %d", bandwidth)
+	}
+	if codingRate < 1 || codingRate > 4 {
+		return 0, fmt.Errorf("invalid coding-rate: %d", codingRate)
+	}
+
+	var h, de, crcBits float64
+	if !headerEnabled {
+		h = 1
+	}
+	// low data-rate optimization is mandated for SF11 and SF12 at 125kHz
+	if sf >= 11 && bandwidth == 125 {
+		de = 1
+	}
+	if crc {
+		crcBits = 16
+	}
+
+	tSym := math.Pow(2, float64(sf)) / float64(bandwidth*1000)
+	tPreamble := (float64(preambleNumber) + 4.25) * tSym
+	payloadSymbNb := 8 + math.Max(math.Ceil((8*float64(payloadSize)-4*float64(sf)+28+crcBits-20*h)/(4*(float64(sf)-2*de)))*float64(codingRate+4), 0)
+	tPayload := payloadSymbNb * tSym
+
+	// round to the nearest nanosecond to avoid floating-point artifacts
+	return time.Duration((tPreamble+tPayload)*float64(time.Second) + 0.5), nil
+}
+
+// CalculateFSKAirtime calculates the airtime of a FSK modulated frame.
+// The bitrate must be given in bits per second.
+func CalculateFSKAirtime(payloadSize, bitrate int) (time.Duration, error) {
+	if bitrate <= 0 {
+		return 0, fmt.Errorf("invalid bitrate: %d", bitrate)
+	}
+	// preamble (5), sync-word (3), length (1) and crc (2)
+	bits := (payloadSize + 11) * 8
+	return time.Duration(float64(bits)/float64(bitrate)*float64(time.Second) + 0.5), nil
+}
+
+// SetBand sets the band of the network, used to take the duty-cycle
+// restrictions into account. An empty band disables these.
+func SetBand(band string) error {
+	if band == "" {
+		dutyCycle = 0
+		return nil
+	}
+	dc, ok := bandDutyCycles[band]
+	if !ok {
+		return ErrUnknownBand
+	}
+	dutyCycle = dc
+	return nil
+}
+
+// DutyCycle returns the max. duty-cycle (0 - 1) of the downlink
+// transmissions for the configured band, 0 meaning unrestricted.
+func DutyCycle() float64 {
+	return dutyCycle
+}
+
+// OffPeriod returns the period after a transmission with the given airtime
+// during which no other transmission is allowed, given the duty-cycle of
+// the configured band.
+func OffPeriod(d time.Duration) time.Duration {
+	if dutyCycle == 0 {
+		return 0
+	}
+	return time.Duration(float64(d)/dutyCycle) - d
+}
+
+// SetDataRate stores the data-rate of the last uplink of the given device.
+// It is used to estimate the airtime of downlink transmissions.
+func SetDataRate(devEUI lorawan.EUI64, dr handler.DataRate) error {
+	b, err := json.Marshal(dr)
+	if err != nil {
+		return errors.Wrap(err, "marshal data-rate error")
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	_, err = c.Do("PSETEX", common.RedisKey(dataRateKeyTempl, devEUI), int64(dataRateTTL/time.Millisecond), b)
+	if err != nil {
+		return errors.Wrap(err, "set data-rate error")
+	}
+	return nil
+}
+
+// GetDataRate returns the data-rate of the last uplink of the given device.
+// When unknown, the data-rate with the longest airtime (SF12) is returned.
+func GetDataRate(devEUI lorawan.EUI64) (handler.DataRate, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", common.RedisKey(dataRateKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return defaultDataRate, nil
+		}
+		return handler.DataRate{}, errors.Wrap(err, "get data-rate error")
+	}
+
+	var dr handler.DataRate
+	if err := json.Unmarshal(b, &dr); err != nil {
+		return handler.DataRate{}, errors.Wrap(err, "unmarshal data-rate error")
+	}
+	return dr, nil
+}
+
+// EstimateDownlinkAirtime estimates the airtime of a downlink transmission
+// with the given FRMPayload size to the given device. As the data-rate of
+// the downlink is decided by the network-server, the data-rate of the last
+// uplink is used.
+func EstimateDownlinkAirtime(devEUI lorawan.EUI64, frmPayloadSize int) (time.Duration, error) {
+	dr, err := GetDataRate(devEUI)
+	if err != nil {
+		return 0, err
+	}
+
+	size := frmPayloadSize + phyPayloadOverhead
+	if dr.Modulation == "FSK" {
+		return CalculateFSKAirtime(size, dr.Bitrate)
+	}
+	// downlink transmissions have no payload CRC
+	return CalculateLoRaAirtime(size, dr.SpreadFactor, dr.Bandwidth, 8, 1, true, false)
+}
+
+// GetDownlinkUsage returns the downlink airtime used by the given device
+// within the current window.
+func GetDownlinkUsage(devEUI lorawan.EUI64) (time.Duration, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	ms, err := redis.Int64(c.Do("GET", usageKey(devEUI, time.Now())))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "get downlink airtime usage error")
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// AddDownlinkUsage adds the given airtime to the downlink airtime used by
+// the given device within the current window.
+func AddDownlinkUsage(devEUI lorawan.EUI64, d time.Duration) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := usageKey(devEUI, time.Now())
+	c.Send("MULTI")
+	c.Send("INCRBY", key, int64(math.Ceil(float64(d)/float64(time.Millisecond))))
+	c.Send("PEXPIRE", key, int64(2*Window/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "add downlink airtime usage error")
+	}
+	return nil
+}
+
+// GetWindowStart returns the start of the current window.
+func GetWindowStart() time.Time {
+	return time.Now().Truncate(Window)
+}
+
+func usageKey(devEUI lorawan.EUI64, t time.Time) string {
+	return common.RedisKey(usageKeyTempl, devEUI, t.Truncate(Window).Unix())
+}
@@ -0,0 +1,128 @@
+package airtime
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestCalculateLoRaAirtime(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			PayloadSize int
+			SF          int
+			Bandwidth   int
+			CRC         bool
+			Expected    time.Duration
+		}{
+			{13, 7, 125, true, 46336 * time.Microsecond},
+			{13, 7, 250, true, 23168 * time.Microsecond},
+			{13, 12, 125, true, 1155072 * time.Microsecond},
+			{51, 9, 125, false, 328704 * time.Microsecond},
+		}
+
+		for _, test := range tests {
+			d, err := CalculateLoRaAirtime(test.PayloadSize, test.SF, test.Bandwidth, 8, 1, true, test.CRC)
+			So(err, ShouldBeNil)
+			So(d, ShouldEqual, test.Expected)
+		}
+	})
+
+	Convey("Given an invalid spread-factor", t, func() {
+		_, err := CalculateLoRaAirtime(13, 13, 125, 8, 1, true, true)
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCalculateFSKAirtime(t *testing.T) {
+	Convey("Given a payload of 14 bytes at 50kbps", t, func() {
+		d, err := CalculateFSKAirtime(14, 50000)
+		So(err, ShouldBeNil)
+
+		Convey("Then the airtime is 4ms", func() {
+			So(d, ShouldEqual, 4*time.Millisecond)
+		})
+	})
+}
+
+func TestBand(t *testing.T) {
+	Convey("Given the EU_863_870 band", t, func() {
+		So(SetBand("EU_863_870"), ShouldBeNil)
+		defer SetBand("")
+
+		Convey("Then the duty-cycle is 1%", func() {
+			So(DutyCycle(), ShouldEqual, 0.01)
+		})
+
+		Convey("Then the off-period is 99 times the airtime", func() {
+			So(OffPeriod(time.Second), ShouldEqual, 99*time.Second)
+		})
+	})
+
+	Convey("Given the US_902_928 band", t, func() {
+		So(SetBand("US_902_928"), ShouldBeNil)
+		defer SetBand("")
+
+		Convey("Then there is no off-period", func() {
+			So(OffPeriod(time.Second), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Then setting an unknown band returns an error", t, func() {
+		So(SetBand("FOO"), ShouldEqual, ErrUnknownBand)
+	})
+}
+
+func TestDownlinkUsage(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("When the data-rate of the device is unknown", func() {
+			Convey("Then the downlink airtime is estimated using SF12", func() {
+				d, err := EstimateDownlinkAirtime(devEUI, 0)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 1155072*time.Microsecond)
+			})
+		})
+
+		Convey("When the data-rate of the device is set to SF7", func() {
+			So(SetDataRate(devEUI, handler.DataRate{
+				Modulation:   "LORA",
+				Bandwidth:    125,
+				SpreadFactor: 7,
+			}), ShouldBeNil)
+
+			Convey("Then the downlink airtime is estimated using SF7", func() {
+				d, err := EstimateDownlinkAirtime(devEUI, 0)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 41216*time.Microsecond)
+			})
+		})
+
+		Convey("When adding downlink usage", func() {
+			So(AddDownlinkUsage(devEUI, 100*time.Millisecond), ShouldBeNil)
+			So(AddDownlinkUsage(devEUI, 50*time.Millisecond), ShouldBeNil)
+
+			Convey("Then the usage within the current window is returned", func() {
+				d, err := GetDownlinkUsage(devEUI)
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, 150*time.Millisecond)
+			})
+		})
+	})
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/adminstats"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// defaultAdminStatsHours, maxAdminStatsHours, defaultAdminStatsLimit and
+// maxAdminStatsLimit define the defaults and limits of the period and the
+// number of returned items of the admin statistics.
+const (
+	defaultAdminStatsHours = 24
+	maxAdminStatsHours     = 7 * 24
+	defaultAdminStatsLimit = 10
+	maxAdminStatsLimit     = 100
+)
+
+// GetTopApplications returns the applications with the most uplinks.
+func (a *InternalUserAPI) GetTopApplications(ctx context.Context, req *pb.GetAdminStatsRequest) (*pb.GetTopApplicationsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, limit, err := adminStatsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := storage.GetTopApplicationsByUplinks(common.DB, since, limit)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetTopApplicationsResponse{
+		Result: make([]*pb.TopApplication, 0, len(apps)),
+	}
+	for _, app := range apps {
+		resp.Result = append(resp.Result, &pb.TopApplication{
+			ApplicationID:    app.ApplicationID,
+			ApplicationName:  app.ApplicationName,
+			OrganizationID:   app.OrganizationID,
+			OrganizationName: app.OrganizationName,
+			Uplinks:          int64(app.Uplinks),
+			Nodes:            int64(app.Nodes),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetOrganizationErrorRates returns the organizations with the highest
+// error notification rates.
+func (a *InternalUserAPI) GetOrganizationErrorRates(ctx context.Context, req *pb.GetAdminStatsRequest) (*pb.GetOrganizationErrorRatesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, limit, err := adminStatsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	errorCounts, err := adminstats.GetErrorCounts(since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	var ids []int64
+	for id := range errorCounts {
+		ids = append(ids, id)
+	}
+	apps, err := storage.GetApplicationSummaries(common.DB, ids)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	orgErrors := make(map[int64]int)
+	for _, app := range apps {
+		orgErrors[app.OrganizationID] += errorCounts[app.ApplicationID]
+	}
+
+	orgs, err := storage.GetOrganizationUplinks(common.DB, since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var result []*pb.OrganizationErrorRate
+	for _, org := range orgs {
+		errors := orgErrors[org.OrganizationID]
+		if errors == 0 {
+			continue
+		}
+
+		rate := float64(errors)
+		if org.Uplinks > 0 {
+			rate = float64(errors) / float64(org.Uplinks)
+		}
+		result = append(result, &pb.OrganizationErrorRate{
+			OrganizationID:   org.OrganizationID,
+			OrganizationName: org.OrganizationName,
+			Uplinks:          int64(org.Uplinks),
+			Errors:           int64(errors),
+			ErrorRate:        rate,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].ErrorRate != result[j].ErrorRate {
+			return result[i].ErrorRate > result[j].ErrorRate
+		}
+		return result[i].Errors > result[j].Errors
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return &pb.GetOrganizationErrorRatesResponse{
+		Result: result,
+	}, nil
+}
+
+// GetIntegrationFailures returns the application integrations with the
+// most failed deliveries.
+func (a *InternalUserAPI) GetIntegrationFailures(ctx context.Context, req *pb.GetAdminStatsRequest) (*pb.GetIntegrationFailuresResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, limit, err := adminStatsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := adminstats.GetIntegrationCounts(since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var failed []adminstats.IntegrationCount
+	for _, c := range counts {
+		if c.Failures > 0 {
+			failed = append(failed, c)
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool {
+		if failed[i].Failures != failed[j].Failures {
+			return failed[i].Failures > failed[j].Failures
+		}
+		if failed[i].ApplicationID != failed[j].ApplicationID {
+			return failed[i].ApplicationID < failed[j].ApplicationID
+		}
+		return failed[i].Kind < failed[j].Kind
+	})
+	if len(failed) > limit {
+		failed = failed[:limit]
+	}
+
+	var ids []int64
+	for _, c := range failed {
+		ids = append(ids, c.ApplicationID)
+	}
+	apps, err := storage.GetApplicationSummaries(common.DB, ids)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	summaries := make(map[int64]storage.ApplicationSummary)
+	for _, app := range apps {
+		summaries[app.ApplicationID] = app
+	}
+
+	resp := pb.GetIntegrationFailuresResponse{
+		Result: make([]*pb.IntegrationFailure, 0, len(failed)),
+	}
+	for _, c := range failed {
+		// the application could have been deleted in the meantime
+		app, ok := summaries[c.ApplicationID]
+		if !ok {
+			continue
+		}
+
+		resp.Result = append(resp.Result, &pb.IntegrationFailure{
+			ApplicationID:    app.ApplicationID,
+			ApplicationName:  app.ApplicationName,
+			OrganizationID:   app.OrganizationID,
+			OrganizationName: app.OrganizationName,
+			Kind:             c.Kind,
+			Deliveries:       int64(c.Deliveries),
+			Failures:         int64(c.Failures),
+			FailureRate:      float64(c.Failures) / float64(c.Deliveries),
+		})
+	}
+
+	return &resp, nil
+}
+
+// adminStatsPeriod returns the start of the period and the max. number of
+// items of the given request.
+func adminStatsPeriod(req *pb.GetAdminStatsRequest) (time.Time, int, error) {
+	hours := req.Hours
+	if hours == 0 {
+		hours = defaultAdminStatsHours
+	}
+	if hours > maxAdminStatsHours {
+		return time.Time{}, 0, grpc.Errorf(codes.InvalidArgument, "hours must be between 1 and %d", maxAdminStatsHours)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultAdminStatsLimit
+	}
+	if limit > maxAdminStatsLimit {
+		return time.Time{}, 0, grpc.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxAdminStatsLimit)
+	}
+
+	return time.Now().Add(-time.Duration(hours) * time.Hour), limit, nil
+}
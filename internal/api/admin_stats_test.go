@@ -0,0 +1,132 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/adminstats"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestAdminStatsAPI(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database, Redis database and api instance", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		ctx := context.Background()
+		validator := &TestValidator{}
+		api := NewInternalUserAPI(validator)
+
+		Convey("Given two organizations with an application and node", func() {
+			var apps []storage.Application
+			for i, name := range []string{"org-a", "org-b"} {
+				org := storage.Organization{Name: name}
+				So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+				app := storage.Application{Name: "app-" + name, OrganizationID: org.ID}
+				So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+				apps = append(apps, app)
+
+				So(storage.CreateNode(common.DB, storage.Node{
+					ApplicationID: app.ID,
+					Name:          "node-" + name,
+					DevEUI:        lorawan.EUI64{byte(i + 1)},
+				}), ShouldBeNil)
+			}
+
+			now := time.Now().Truncate(time.Hour)
+			So(storage.AddLinkQuality(common.DB, storage.LinkQuality{DevEUI: lorawan.EUI64{1}, Bucket: now, Uplinks: 10}), ShouldBeNil)
+			So(storage.AddLinkQuality(common.DB, storage.LinkQuality{DevEUI: lorawan.EUI64{2}, Bucket: now, Uplinks: 20}), ShouldBeNil)
+
+			Convey("When calling GetTopApplications", func() {
+				resp, err := api.GetTopApplications(ctx, &pb.GetAdminStatsRequest{Hours: 2})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				Convey("Then the applications are returned ordered by uplinks", func() {
+					So(resp.Result, ShouldHaveLength, 2)
+					So(resp.Result[0], ShouldResemble, &pb.TopApplication{
+						ApplicationID:    apps[1].ID,
+						ApplicationName:  "app-org-b",
+						OrganizationID:   apps[1].OrganizationID,
+						OrganizationName: "org-b",
+						Uplinks:          20,
+						Nodes:            1,
+					})
+					So(resp.Result[1].ApplicationID, ShouldEqual, apps[0].ID)
+				})
+			})
+
+			Convey("Given error notifications for both applications", func() {
+				for i := 0; i < 5; i++ {
+					So(adminstats.RecordError(apps[0].ID), ShouldBeNil)
+				}
+				So(adminstats.RecordError(apps[1].ID), ShouldBeNil)
+
+				Convey("Then GetOrganizationErrorRates returns the organizations ordered by error rate", func() {
+					resp, err := api.GetOrganizationErrorRates(ctx, &pb.GetAdminStatsRequest{Hours: 2})
+					So(err, ShouldBeNil)
+					So(resp.Result, ShouldResemble, []*pb.OrganizationErrorRate{
+						{OrganizationID: apps[0].OrganizationID, OrganizationName: "org-a", Uplinks: 10, Errors: 5, ErrorRate: 0.5},
+						{OrganizationID: apps[1].OrganizationID, OrganizationName: "org-b", Uplinks: 20, Errors: 1, ErrorRate: 0.05},
+					})
+				})
+			})
+
+			Convey("Given integration deliveries for both applications", func() {
+				So(adminstats.RecordDelivery(apps[0].ID, "HTTP", false), ShouldBeNil)
+				So(adminstats.RecordDelivery(apps[0].ID, "HTTP", true), ShouldBeNil)
+				So(adminstats.RecordDelivery(apps[1].ID, "HTTP", false), ShouldBeNil)
+
+				Convey("Then GetIntegrationFailures only returns the failing integrations", func() {
+					resp, err := api.GetIntegrationFailures(ctx, &pb.GetAdminStatsRequest{})
+					So(err, ShouldBeNil)
+					So(resp.Result, ShouldResemble, []*pb.IntegrationFailure{
+						{
+							ApplicationID:    apps[0].ID,
+							ApplicationName:  "app-org-a",
+							OrganizationID:   apps[0].OrganizationID,
+							OrganizationName: "org-a",
+							Kind:             "HTTP",
+							Deliveries:       2,
+							Failures:         1,
+							FailureRate:      0.5,
+						},
+					})
+				})
+			})
+		})
+
+		Convey("When requesting more than the max. number of hours", func() {
+			_, err := api.GetTopApplications(ctx, &pb.GetAdminStatsRequest{Hours: maxAdminStatsHours + 1})
+
+			Convey("Then an invalid argument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
+		Convey("When the validator returns an error", func() {
+			validator.returnError = grpc.Errorf(codes.Unauthenticated, "not an admin")
+			_, err := api.GetIntegrationFailures(ctx, &pb.GetAdminStatsRequest{})
+
+			Convey("Then an unauthenticated error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.Unauthenticated)
+			})
+		})
+	})
+}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -10,9 +13,25 @@ import (
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/amqphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/azurehandler"
+	"github.com/brocaar/lora-app-server/internal/handler/elasticsearchhandler"
 	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/mqtthandler"
+	"github.com/brocaar/lora-app-server/internal/handler/mydeviceshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/postgresqlhandler"
+	"github.com/brocaar/lora-app-server/internal/handler/pubsubhandler"
+	"github.com/brocaar/lora-app-server/internal/handler/snshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/sysloghandler"
+	"github.com/brocaar/lora-app-server/internal/handler/thingsboardhandler"
+	"github.com/brocaar/lora-app-server/internal/integrationhealth"
+	"github.com/brocaar/lora-app-server/internal/residency"
+	"github.com/brocaar/lora-app-server/internal/secret"
 	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/lorawan"
 )
 
 // ApplicationAPI exports the Application related functions.
@@ -34,6 +53,15 @@ func (a *ApplicationAPI) Create(ctx context.Context, req *pb.CreateApplicationRe
 		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
 	}
 
+	prefix, err := parseProprietaryPayloadPrefix(req.ProprietaryPayloadPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateResidencyRegion(req.ResidencyRegion); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	app := storage.Application{
 		Name:               req.Name,
 		Description:        req.Description,
@@ -47,6 +75,13 @@ func (a *ApplicationAPI) Create(ctx context.Context, req *pb.CreateApplicationRe
 		ADRInterval:        req.AdrInterval,
 		InstallationMargin: req.InstallationMargin,
 		OrganizationID:     req.OrganizationID,
+		Environment:        req.Environment,
+		ResidencyRegion:    req.ResidencyRegion,
+		MQTTBufferTTL:      int32(req.MqttBufferTTL),
+
+		DownlinkAirtimeBudget:        int32(req.DownlinkAirtimeBudget),
+		DownlinkAirtimeBudgetEnforce: req.DownlinkAirtimeBudgetEnforce,
+		ProprietaryPayloadPrefix:     prefix,
 	}
 
 	if err := storage.CreateApplication(common.DB, &app); err != nil {
@@ -83,12 +118,34 @@ func (a *ApplicationAPI) Get(ctx context.Context, req *pb.GetApplicationRequest)
 		AdrInterval:        app.ADRInterval,
 		InstallationMargin: app.InstallationMargin,
 		OrganizationID:     app.OrganizationID,
+		Environment:        app.Environment,
+		ResidencyRegion:    app.ResidencyRegion,
+		MqttBufferTTL:      uint32(app.MQTTBufferTTL),
+
+		DownlinkAirtimeBudget:        uint32(app.DownlinkAirtimeBudget),
+		DownlinkAirtimeBudgetEnforce: app.DownlinkAirtimeBudgetEnforce,
+		ProprietaryPayloadPrefix:     hex.EncodeToString(app.ProprietaryPayloadPrefix),
+		Uuid:                         app.UUID.String(),
 	}
+	setETag(ctx, app.Revision)
 
 	return &resp, nil
 }
 
 func (a *ApplicationAPI) Update(ctx context.Context, req *pb.UpdateApplicationRequest) (*pb.UpdateApplicationResponse, error) {
+	return a.update(ctx, req, true)
+}
+
+// Patch updates the fields listed in the update mask of the given
+// application. In contrast to Update, the If-Match header is optional.
+func (a *ApplicationAPI) Patch(ctx context.Context, req *pb.UpdateApplicationRequest) (*pb.UpdateApplicationResponse, error) {
+	if len(req.UpdateMask) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "updateMask must not be empty")
+	}
+	return a.update(ctx, req, false)
+}
+
+func (a *ApplicationAPI) update(ctx context.Context, req *pb.UpdateApplicationRequest, requireIfMatch bool) (*pb.UpdateApplicationResponse, error) {
 	if err := a.validator.Validate(ctx,
 		auth.ValidateApplicationAccess(req.Id, auth.Update),
 	); err != nil {
@@ -100,24 +157,68 @@ func (a *ApplicationAPI) Update(ctx context.Context, req *pb.UpdateApplicationRe
 		return nil, errToRPCError(err)
 	}
 
+	if requireIfMatch {
+		app.Revision, err = getIfMatchRevision(ctx, app.Revision)
+	} else {
+		app.Revision, err = getOptionalIfMatchRevision(ctx, app.Revision)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	// update the fields
-	app.Name = req.Name
-	app.Description = req.Description
-	app.IsABP = req.IsABP
-	app.IsClassC = req.IsClassC
-	app.RXDelay = uint8(req.RxDelay)
-	app.RX1DROffset = uint8(req.Rx1DROffset)
-	app.RXWindow = storage.RXWindow(req.RxWindow)
-	app.RX2DR = uint8(req.Rx2DR)
-	app.RelaxFCnt = req.RelaxFCnt
-	app.ADRInterval = req.AdrInterval
-	app.InstallationMargin = req.InstallationMargin
-	app.OrganizationID = req.OrganizationID
+	err = applyUpdateMask(req.UpdateMask, map[string]func() error{
+		"name":               func() error { app.Name = req.Name; return nil },
+		"description":        func() error { app.Description = req.Description; return nil },
+		"isABP":              func() error { app.IsABP = req.IsABP; return nil },
+		"isClassC":           func() error { app.IsClassC = req.IsClassC; return nil },
+		"rxDelay":            func() error { app.RXDelay = uint8(req.RxDelay); return nil },
+		"rx1DROffset":        func() error { app.RX1DROffset = uint8(req.Rx1DROffset); return nil },
+		"rxWindow":           func() error { app.RXWindow = storage.RXWindow(req.RxWindow); return nil },
+		"rx2DR":              func() error { app.RX2DR = uint8(req.Rx2DR); return nil },
+		"relaxFCnt":          func() error { app.RelaxFCnt = req.RelaxFCnt; return nil },
+		"adrInterval":        func() error { app.ADRInterval = req.AdrInterval; return nil },
+		"installationMargin": func() error { app.InstallationMargin = req.InstallationMargin; return nil },
+		"organizationID":     func() error { app.OrganizationID = req.OrganizationID; return nil },
+		"environment":        func() error { app.Environment = req.Environment; return nil },
+		"downlinkAirtimeBudget": func() error {
+			app.DownlinkAirtimeBudget = int32(req.DownlinkAirtimeBudget)
+			return nil
+		},
+		"downlinkAirtimeBudgetEnforce": func() error {
+			app.DownlinkAirtimeBudgetEnforce = req.DownlinkAirtimeBudgetEnforce
+			return nil
+		},
+		"proprietaryPayloadPrefix": func() error {
+			app.ProprietaryPayloadPrefix, err = parseProprietaryPayloadPrefix(req.ProprietaryPayloadPrefix)
+			return err
+		},
+		"residencyRegion": func() error {
+			// only validate a changed region, so that the application can
+			// still be updated when its region is no longer configured
+			if req.ResidencyRegion == app.ResidencyRegion {
+				return nil
+			}
+			app.ResidencyRegion = req.ResidencyRegion
+			return validateResidencyRegion(app.ResidencyRegion)
+		},
+		"mqttBufferTTL": func() error {
+			if req.MqttBufferTTL > storage.MaxApplicationMQTTBufferTTL {
+				return storage.ErrApplicationInvalidMQTTBufferTTL
+			}
+			app.MQTTBufferTTL = int32(req.MqttBufferTTL)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
 
 	err = storage.UpdateApplication(common.DB, app)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
+	setETag(ctx, app.Revision+1)
 
 	return &pb.UpdateApplicationResponse{}, nil
 }
@@ -158,40 +259,40 @@ func (a *ApplicationAPI) List(ctx context.Context, req *pb.ListApplicationReques
 
 	if req.OrganizationID == 0 {
 		if isAdmin {
-			apps, err = storage.GetApplications(common.DB, int(req.Limit), int(req.Offset))
+			apps, err = storage.GetApplications(common.DB, req.Environment, int(req.Limit), int(req.Offset))
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
-			count, err = storage.GetApplicationCount(common.DB)
+			count, err = storage.GetApplicationCount(common.DB, req.Environment)
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
 		} else {
-			apps, err = storage.GetApplicationsForUser(common.DB, username, 0, int(req.Limit), int(req.Offset))
+			apps, err = storage.GetApplicationsForUser(common.DB, username, 0, req.Environment, int(req.Limit), int(req.Offset))
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
-			count, err = storage.GetApplicationCountForUser(common.DB, username, 0)
+			count, err = storage.GetApplicationCountForUser(common.DB, username, 0, req.Environment)
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
 		}
 	} else {
 		if isAdmin {
-			apps, err = storage.GetApplicationsForOrganizationID(common.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+			apps, err = storage.GetApplicationsForOrganizationID(common.DB, req.OrganizationID, req.Environment, int(req.Limit), int(req.Offset))
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
-			count, err = storage.GetApplicationCountForOrganizationID(common.DB, req.OrganizationID)
+			count, err = storage.GetApplicationCountForOrganizationID(common.DB, req.OrganizationID, req.Environment)
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
 		} else {
-			apps, err = storage.GetApplicationsForUser(common.DB, username, req.OrganizationID, int(req.Limit), int(req.Offset))
+			apps, err = storage.GetApplicationsForUser(common.DB, username, req.OrganizationID, req.Environment, int(req.Limit), int(req.Offset))
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
-			count, err = storage.GetApplicationCountForUser(common.DB, username, req.OrganizationID)
+			count, err = storage.GetApplicationCountForUser(common.DB, username, req.OrganizationID, req.Environment)
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
@@ -216,6 +317,14 @@ func (a *ApplicationAPI) List(ctx context.Context, req *pb.ListApplicationReques
 			AdrInterval:        app.ADRInterval,
 			InstallationMargin: app.InstallationMargin,
 			OrganizationID:     app.OrganizationID,
+			Environment:        app.Environment,
+			ResidencyRegion:    app.ResidencyRegion,
+			MqttBufferTTL:      uint32(app.MQTTBufferTTL),
+
+			DownlinkAirtimeBudget:        uint32(app.DownlinkAirtimeBudget),
+			DownlinkAirtimeBudgetEnforce: app.DownlinkAirtimeBudgetEnforce,
+			ProprietaryPayloadPrefix:     hex.EncodeToString(app.ProprietaryPayloadPrefix),
+			Uuid:                         app.UUID.String(),
 		}
 
 		resp.Result = append(resp.Result, &item)
@@ -335,12 +444,38 @@ func (a *ApplicationAPI) CreateHTTPIntegration(ctx context.Context, in *pb.HTTPI
 		headers[h.Key] = h.Value
 	}
 
+	devEUIs, err := parseDevEUIs(in.DevEUIs)
+	if err != nil {
+		return nil, err
+	}
+
 	conf := httphandler.HandlerConfig{
-		Headers:              headers,
-		DataUpURL:            in.DataUpURL,
-		JoinNotificationURL:  in.JoinNotificationURL,
-		ACKNotificationURL:   in.AckNotificationURL,
-		ErrorNotificationURL: in.ErrorNotificationURL,
+		Headers:                  headers,
+		DataUpURL:                in.DataUpURL,
+		JoinNotificationURL:      in.JoinNotificationURL,
+		ACKNotificationURL:       in.AckNotificationURL,
+		ErrorNotificationURL:     in.ErrorNotificationURL,
+		SecurityNotificationURL:  in.SecurityNotificationURL,
+		ProprietaryUpURL:         in.ProprietaryUpURL,
+		CustomEventURL:           in.CustomEventURL,
+		DevicePercentage:         int(in.DevicePercentage),
+		DevEUIs:                  devEUIs,
+		BasicAuthUsername:        in.BasicAuthUsername,
+		BasicAuthPassword:        secret.String(in.BasicAuthPassword),
+		BearerToken:              secret.String(in.BearerToken),
+		MaxAttempts:              int(in.MaxAttempts),
+		SigningSecret:            secret.String(in.SigningSecret),
+		TLSCert:                  in.TlsCert,
+		TLSKey:                   secret.String(in.TlsKey),
+		CACert:                   in.CaCert,
+		MaxPayloadSize:           int(in.MaxPayloadSize),
+		Marshaler:                in.Marshaler.String(),
+		BatchSize:                int(in.BatchSize),
+		BatchInterval:            int(in.BatchInterval),
+		CircuitBreakerThreshold:  int(in.CircuitBreakerThreshold),
+		CircuitBreakerCooldown:   int(in.CircuitBreakerCooldown),
+		CircuitBreakerDeadLetter: in.CircuitBreakerDeadLetter,
+		CircuitBreakerDrop:       in.CircuitBreakerDrop,
 	}
 	if err := conf.Validate(); err != nil {
 		return nil, errToRPCError(err)
@@ -380,6 +515,12 @@ func (a *ApplicationAPI) GetHTTPIntegration(ctx context.Context, in *pb.GetHTTPI
 	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
 		return nil, errToRPCError(err)
 	}
+	setETag(ctx, integration.Revision)
+
+	var devEUIs []string
+	for _, devEUI := range conf.DevEUIs {
+		devEUIs = append(devEUIs, devEUI.String())
+	}
 
 	var headers []*pb.HTTPIntegrationHeader
 	for k, v := range conf.Headers {
@@ -391,12 +532,33 @@ func (a *ApplicationAPI) GetHTTPIntegration(ctx context.Context, in *pb.GetHTTPI
 	}
 
 	return &pb.HTTPIntegration{
-		Id:                   integration.ApplicationID,
-		Headers:              headers,
-		DataUpURL:            conf.DataUpURL,
-		JoinNotificationURL:  conf.JoinNotificationURL,
-		AckNotificationURL:   conf.ACKNotificationURL,
-		ErrorNotificationURL: conf.ErrorNotificationURL,
+		Id:                       integration.ApplicationID,
+		Headers:                  headers,
+		DataUpURL:                conf.DataUpURL,
+		JoinNotificationURL:      conf.JoinNotificationURL,
+		AckNotificationURL:       conf.ACKNotificationURL,
+		ErrorNotificationURL:     conf.ErrorNotificationURL,
+		SecurityNotificationURL:  conf.SecurityNotificationURL,
+		ProprietaryUpURL:         conf.ProprietaryUpURL,
+		CustomEventURL:           conf.CustomEventURL,
+		DevicePercentage:         uint32(conf.DevicePercentage),
+		DevEUIs:                  devEUIs,
+		BasicAuthUsername:        conf.BasicAuthUsername,
+		BasicAuthPassword:        maskSecret(conf.BasicAuthPassword),
+		BearerToken:              maskSecret(conf.BearerToken),
+		MaxAttempts:              uint32(conf.MaxAttempts),
+		SigningSecret:            maskSecret(conf.SigningSecret),
+		TlsCert:                  conf.TLSCert,
+		TlsKey:                   maskSecret(conf.TLSKey),
+		CaCert:                   conf.CACert,
+		MaxPayloadSize:           uint32(conf.MaxPayloadSize),
+		Marshaler:                pb.IntegrationMarshaler(pb.IntegrationMarshaler_value[conf.Marshaler]),
+		BatchSize:                uint32(conf.BatchSize),
+		BatchInterval:            uint32(conf.BatchInterval),
+		CircuitBreakerThreshold:  uint32(conf.CircuitBreakerThreshold),
+		CircuitBreakerCooldown:   uint32(conf.CircuitBreakerCooldown),
+		CircuitBreakerDeadLetter: conf.CircuitBreakerDeadLetter,
+		CircuitBreakerDrop:       conf.CircuitBreakerDrop,
 	}, nil
 }
 
@@ -413,17 +575,53 @@ func (a *ApplicationAPI) UpdateHTTPIntegration(ctx context.Context, in *pb.HTTPI
 		return nil, errToRPCError(err)
 	}
 
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(map[string]string)
 	for _, h := range in.Headers {
 		headers[h.Key] = h.Value
 	}
 
+	devEUIs, err := parseDevEUIs(in.DevEUIs)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored httphandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	conf := httphandler.HandlerConfig{
-		Headers:              headers,
-		DataUpURL:            in.DataUpURL,
-		JoinNotificationURL:  in.JoinNotificationURL,
-		ACKNotificationURL:   in.AckNotificationURL,
-		ErrorNotificationURL: in.ErrorNotificationURL,
+		Headers:                  headers,
+		DataUpURL:                in.DataUpURL,
+		JoinNotificationURL:      in.JoinNotificationURL,
+		ACKNotificationURL:       in.AckNotificationURL,
+		ErrorNotificationURL:     in.ErrorNotificationURL,
+		SecurityNotificationURL:  in.SecurityNotificationURL,
+		ProprietaryUpURL:         in.ProprietaryUpURL,
+		CustomEventURL:           in.CustomEventURL,
+		DevicePercentage:         int(in.DevicePercentage),
+		DevEUIs:                  devEUIs,
+		BasicAuthUsername:        in.BasicAuthUsername,
+		BasicAuthPassword:        unmaskSecret(in.BasicAuthPassword, stored.BasicAuthPassword),
+		BearerToken:              unmaskSecret(in.BearerToken, stored.BearerToken),
+		MaxAttempts:              int(in.MaxAttempts),
+		SigningSecret:            unmaskSecret(in.SigningSecret, stored.SigningSecret),
+		TLSCert:                  in.TlsCert,
+		TLSKey:                   unmaskSecret(in.TlsKey, stored.TLSKey),
+		CACert:                   in.CaCert,
+		MaxPayloadSize:           int(in.MaxPayloadSize),
+		Marshaler:                in.Marshaler.String(),
+		BatchSize:                int(in.BatchSize),
+		BatchInterval:            int(in.BatchInterval),
+		CircuitBreakerThreshold:  int(in.CircuitBreakerThreshold),
+		CircuitBreakerCooldown:   int(in.CircuitBreakerCooldown),
+		CircuitBreakerDeadLetter: in.CircuitBreakerDeadLetter,
+		CircuitBreakerDrop:       in.CircuitBreakerDrop,
 	}
 	if err := conf.Validate(); err != nil {
 		return nil, errToRPCError(err)
@@ -438,6 +636,7 @@ func (a *ApplicationAPI) UpdateHTTPIntegration(ctx context.Context, in *pb.HTTPI
 	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
 		return nil, errToRPCError(err)
 	}
+	setETag(ctx, integration.Revision)
 
 	return &pb.EmptyResponse{}, nil
 }
@@ -462,28 +661,2088 @@ func (a *ApplicationAPI) DeleteHTTPIntegration(ctx context.Context, in *pb.Delet
 	return &pb.EmptyResponse{}, nil
 }
 
-// ListIntegrations lists all configured integrations.
-func (a *ApplicationAPI) ListIntegrations(ctx context.Context, in *pb.ListIntegrationRequest) (*pb.ListIntegrationResponse, error) {
+// CreateSyslogIntegration creates a syslog application-integration.
+func (a *ApplicationAPI) CreateSyslogIntegration(ctx context.Context, in *pb.SyslogIntegration) (*pb.EmptyResponse, error) {
 	if err := a.validator.Validate(ctx,
 		auth.ValidateApplicationAccess(in.Id, auth.Update),
 	); err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
 	}
 
-	integrations, err := storage.GetIntegrationsForApplicationID(common.DB, in.Id)
+	conf := sysloghandler.HandlerConfig{
+		Network:  in.Network,
+		Server:   in.Server,
+		CACert:   in.CaCert,
+		Facility: int(in.Facility),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	var out pb.ListIntegrationResponse
-	for _, integration := range integrations {
-		switch integration.Kind {
-		case handler.HTTPHandlerKind:
-			out.Kinds = append(out.Kinds, pb.IntegrationKind_HTTP)
-		default:
-			return nil, grpc.Errorf(codes.Internal, "unknown integration kind: %s", integration.Kind)
-		}
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.SyslogHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
 	}
 
-	return &out, nil
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetSyslogIntegration returns the syslog application-integration.
+func (a *ApplicationAPI) GetSyslogIntegration(ctx context.Context, in *pb.GetSyslogIntegrationRequest) (*pb.SyslogIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.SyslogHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf sysloghandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.SyslogIntegration{
+		Id:       integration.ApplicationID,
+		Network:  conf.Network,
+		Server:   conf.Server,
+		CaCert:   conf.CACert,
+		Facility: uint32(conf.Facility),
+	}, nil
+}
+
+// UpdateSyslogIntegration updates the syslog application-integration.
+func (a *ApplicationAPI) UpdateSyslogIntegration(ctx context.Context, in *pb.SyslogIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.SyslogHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := sysloghandler.HandlerConfig{
+		Network:  in.Network,
+		Server:   in.Server,
+		CACert:   in.CaCert,
+		Facility: int(in.Facility),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteSyslogIntegration deletes the syslog application-integration.
+func (a *ApplicationAPI) DeleteSyslogIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.SyslogHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateAMQPIntegration creates an AMQP application-integration.
+func (a *ApplicationAPI) CreateAMQPIntegration(ctx context.Context, in *pb.AMQPIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := amqphandler.HandlerConfig{
+		URL:                secret.String(in.Url),
+		Exchange:           in.Exchange,
+		RoutingKeyTemplate: in.RoutingKeyTemplate,
+		ConnectMode:        in.ConnectMode.String(),
+		KeepAlive:          int(in.KeepAlive),
+		MaxIdle:            int(in.MaxIdle),
+		Marshaler:          in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.AMQPHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// connect eager integrations before the first event
+	if err := amqphandler.Prewarm(conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetAMQPIntegration returns the AMQP application-integration.
+func (a *ApplicationAPI) GetAMQPIntegration(ctx context.Context, in *pb.GetAMQPIntegrationRequest) (*pb.AMQPIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AMQPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf amqphandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.AMQPIntegration{
+		Id:                 integration.ApplicationID,
+		Url:                maskSecret(conf.URL),
+		Exchange:           conf.Exchange,
+		RoutingKeyTemplate: conf.RoutingKeyTemplate,
+		ConnectMode:        pb.IntegrationConnectMode(pb.IntegrationConnectMode_value[conf.ConnectMode]),
+		KeepAlive:          uint32(conf.KeepAlive),
+		MaxIdle:            uint32(conf.MaxIdle),
+		Marshaler:          pb.IntegrationMarshaler(pb.IntegrationMarshaler_value[conf.Marshaler]),
+	}, nil
+}
+
+// UpdateAMQPIntegration updates the AMQP application-integration.
+func (a *ApplicationAPI) UpdateAMQPIntegration(ctx context.Context, in *pb.AMQPIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AMQPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored amqphandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	conf := amqphandler.HandlerConfig{
+		URL:                unmaskSecret(in.Url, stored.URL),
+		Exchange:           in.Exchange,
+		RoutingKeyTemplate: in.RoutingKeyTemplate,
+		ConnectMode:        in.ConnectMode.String(),
+		KeepAlive:          int(in.KeepAlive),
+		MaxIdle:            int(in.MaxIdle),
+		Marshaler:          in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	// connect eager integrations before the first event
+	if err := amqphandler.Prewarm(conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteAMQPIntegration deletes the AMQP application-integration.
+func (a *ApplicationAPI) DeleteAMQPIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AMQPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreatePostgreSQLIntegration creates a PostgreSQL application-integration.
+func (a *ApplicationAPI) CreatePostgreSQLIntegration(ctx context.Context, in *pb.PostgreSQLIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := postgresqlhandler.HandlerConfig{
+		DSN: secret.String(in.Dsn),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.PostgreSQLHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetPostgreSQLIntegration returns the PostgreSQL application-integration.
+func (a *ApplicationAPI) GetPostgreSQLIntegration(ctx context.Context, in *pb.GetPostgreSQLIntegrationRequest) (*pb.PostgreSQLIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.PostgreSQLHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf postgresqlhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.PostgreSQLIntegration{
+		Id:  integration.ApplicationID,
+		Dsn: maskSecret(conf.DSN),
+	}, nil
+}
+
+// UpdatePostgreSQLIntegration updates the PostgreSQL application-integration.
+func (a *ApplicationAPI) UpdatePostgreSQLIntegration(ctx context.Context, in *pb.PostgreSQLIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.PostgreSQLHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored postgresqlhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	conf := postgresqlhandler.HandlerConfig{
+		DSN: unmaskSecret(in.Dsn, stored.DSN),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeletePostgreSQLIntegration deletes the PostgreSQL application-integration.
+func (a *ApplicationAPI) DeletePostgreSQLIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.PostgreSQLHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateAWSSNSIntegration creates an AWS SNS application-integration.
+func (a *ApplicationAPI) CreateAWSSNSIntegration(ctx context.Context, in *pb.AWSSNSIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := snshandler.HandlerConfig{
+		Region:          in.Region,
+		AccessKeyID:     in.AccessKeyID,
+		SecretAccessKey: secret.String(in.SecretAccessKey),
+		TopicARN:        in.TopicARN,
+		Marshaler:       in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.AWSSNSHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetAWSSNSIntegration returns the AWS SNS application-integration.
+func (a *ApplicationAPI) GetAWSSNSIntegration(ctx context.Context, in *pb.GetAWSSNSIntegrationRequest) (*pb.AWSSNSIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AWSSNSHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf snshandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.AWSSNSIntegration{
+		Id:              integration.ApplicationID,
+		Region:          conf.Region,
+		AccessKeyID:     conf.AccessKeyID,
+		SecretAccessKey: maskSecret(conf.SecretAccessKey),
+		TopicARN:        conf.TopicARN,
+		Marshaler:       pb.IntegrationMarshaler(pb.IntegrationMarshaler_value[conf.Marshaler]),
+	}, nil
+}
+
+// UpdateAWSSNSIntegration updates the AWS SNS application-integration.
+func (a *ApplicationAPI) UpdateAWSSNSIntegration(ctx context.Context, in *pb.AWSSNSIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AWSSNSHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored snshandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	conf := snshandler.HandlerConfig{
+		Region:          in.Region,
+		AccessKeyID:     in.AccessKeyID,
+		SecretAccessKey: unmaskSecret(in.SecretAccessKey, stored.SecretAccessKey),
+		TopicARN:        in.TopicARN,
+		Marshaler:       in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteAWSSNSIntegration deletes the AWS SNS application-integration.
+func (a *ApplicationAPI) DeleteAWSSNSIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AWSSNSHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateAzureIntegration creates an Azure application-integration.
+func (a *ApplicationAPI) CreateAzureIntegration(ctx context.Context, in *pb.AzureIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := azurehandler.HandlerConfig{
+		ConnectionString: secret.String(in.ConnectionString),
+		QueueOrTopic:     in.QueueOrTopic,
+		Marshaler:        in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.AzureHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetAzureIntegration returns the Azure application-integration.
+func (a *ApplicationAPI) GetAzureIntegration(ctx context.Context, in *pb.GetAzureIntegrationRequest) (*pb.AzureIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AzureHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf azurehandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.AzureIntegration{
+		Id:               integration.ApplicationID,
+		ConnectionString: maskSecret(conf.ConnectionString),
+		QueueOrTopic:     conf.QueueOrTopic,
+		Marshaler:        pb.IntegrationMarshaler(pb.IntegrationMarshaler_value[conf.Marshaler]),
+	}, nil
+}
+
+// UpdateAzureIntegration updates the Azure application-integration.
+func (a *ApplicationAPI) UpdateAzureIntegration(ctx context.Context, in *pb.AzureIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AzureHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored azurehandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	conf := azurehandler.HandlerConfig{
+		ConnectionString: unmaskSecret(in.ConnectionString, stored.ConnectionString),
+		QueueOrTopic:     in.QueueOrTopic,
+		Marshaler:        in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteAzureIntegration deletes the Azure application-integration.
+func (a *ApplicationAPI) DeleteAzureIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.AzureHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateGCPPubSubIntegration creates an GCP Pub/Sub application-integration.
+func (a *ApplicationAPI) CreateGCPPubSubIntegration(ctx context.Context, in *pb.GCPPubSubIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := pubsubhandler.HandlerConfig{
+		CredentialsFile: secret.String(in.CredentialsFile),
+		ProjectID:       in.ProjectID,
+		TopicName:       in.TopicName,
+		Marshaler:       in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.GCPPubSubHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetGCPPubSubIntegration returns the GCP Pub/Sub application-integration.
+func (a *ApplicationAPI) GetGCPPubSubIntegration(ctx context.Context, in *pb.GetGCPPubSubIntegrationRequest) (*pb.GCPPubSubIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.GCPPubSubHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf pubsubhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.GCPPubSubIntegration{
+		Id:              integration.ApplicationID,
+		CredentialsFile: maskSecret(conf.CredentialsFile),
+		ProjectID:       conf.ProjectID,
+		TopicName:       conf.TopicName,
+		Marshaler:       pb.IntegrationMarshaler(pb.IntegrationMarshaler_value[conf.Marshaler]),
+	}, nil
+}
+
+// UpdateGCPPubSubIntegration updates the GCP Pub/Sub application-integration.
+func (a *ApplicationAPI) UpdateGCPPubSubIntegration(ctx context.Context, in *pb.GCPPubSubIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.GCPPubSubHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored pubsubhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	conf := pubsubhandler.HandlerConfig{
+		CredentialsFile: unmaskSecret(in.CredentialsFile, stored.CredentialsFile),
+		ProjectID:       in.ProjectID,
+		TopicName:       in.TopicName,
+		Marshaler:       in.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteGCPPubSubIntegration deletes the GCP Pub/Sub application-integration.
+func (a *ApplicationAPI) DeleteGCPPubSubIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.GCPPubSubHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateThingsBoardIntegration creates a ThingsBoard application-integration.
+func (a *ApplicationAPI) CreateThingsBoardIntegration(ctx context.Context, in *pb.ThingsBoardIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := thingsboardhandler.HandlerConfig{
+		Server: in.Server,
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.ThingsBoardHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetThingsBoardIntegration returns the ThingsBoard application-integration.
+func (a *ApplicationAPI) GetThingsBoardIntegration(ctx context.Context, in *pb.GetThingsBoardIntegrationRequest) (*pb.ThingsBoardIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.ThingsBoardHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf thingsboardhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.ThingsBoardIntegration{
+		Id:     integration.ApplicationID,
+		Server: conf.Server,
+	}, nil
+}
+
+// UpdateThingsBoardIntegration updates the ThingsBoard application-integration.
+func (a *ApplicationAPI) UpdateThingsBoardIntegration(ctx context.Context, in *pb.ThingsBoardIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.ThingsBoardHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := thingsboardhandler.HandlerConfig{
+		Server: in.Server,
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteThingsBoardIntegration deletes the ThingsBoard application-integration.
+func (a *ApplicationAPI) DeleteThingsBoardIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.ThingsBoardHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateMyDevicesIntegration creates a myDevices application-integration.
+func (a *ApplicationAPI) CreateMyDevicesIntegration(ctx context.Context, in *pb.MyDevicesIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := mydeviceshandler.HandlerConfig{
+		Endpoint: in.Endpoint,
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.MyDevicesHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetMyDevicesIntegration returns the MyDevices application-integration.
+func (a *ApplicationAPI) GetMyDevicesIntegration(ctx context.Context, in *pb.GetMyDevicesIntegrationRequest) (*pb.MyDevicesIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.MyDevicesHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf mydeviceshandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.MyDevicesIntegration{
+		Id:       integration.ApplicationID,
+		Endpoint: conf.Endpoint,
+	}, nil
+}
+
+// UpdateMyDevicesIntegration updates the MyDevices application-integration.
+func (a *ApplicationAPI) UpdateMyDevicesIntegration(ctx context.Context, in *pb.MyDevicesIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.MyDevicesHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := mydeviceshandler.HandlerConfig{
+		Endpoint: in.Endpoint,
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteMyDevicesIntegration deletes the MyDevices application-integration.
+func (a *ApplicationAPI) DeleteMyDevicesIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.MyDevicesHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// CreateElasticsearchIntegration creates an Elasticsearch application-integration.
+func (a *ApplicationAPI) CreateElasticsearchIntegration(ctx context.Context, in *pb.ElasticsearchIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := elasticsearchhandler.HandlerConfig{
+		Server:       in.Server,
+		IndexPattern: in.IndexPattern,
+		Username:     in.Username,
+		Password:     secret.String(in.Password),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration := storage.Integration{
+		ApplicationID: in.Id,
+		Kind:          handler.ElasticsearchHandlerKind,
+		Settings:      confJSON,
+	}
+	if err = storage.CreateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetElasticsearchIntegration returns the Elasticsearch application-integration.
+func (a *ApplicationAPI) GetElasticsearchIntegration(ctx context.Context, in *pb.GetElasticsearchIntegrationRequest) (*pb.ElasticsearchIntegration, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.ElasticsearchHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf elasticsearchhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.ElasticsearchIntegration{
+		Id:           integration.ApplicationID,
+		Server:       conf.Server,
+		IndexPattern: conf.IndexPattern,
+		Username:     conf.Username,
+		Password:     maskSecret(conf.Password),
+	}, nil
+}
+
+// UpdateElasticsearchIntegration updates the Elasticsearch application-integration.
+func (a *ApplicationAPI) UpdateElasticsearchIntegration(ctx context.Context, in *pb.ElasticsearchIntegration) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.ElasticsearchHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored elasticsearchhandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	conf := elasticsearchhandler.HandlerConfig{
+		Server:       in.Server,
+		IndexPattern: in.IndexPattern,
+		Username:     in.Username,
+		Password:     unmaskSecret(in.Password, stored.Password),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	integration.Settings = confJSON
+
+	if err = storage.UpdateIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteElasticsearchIntegration deletes the Elasticsearch application-integration.
+func (a *ApplicationAPI) DeleteElasticsearchIntegration(ctx context.Context, in *pb.DeleteIntegrationRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, handler.ElasticsearchHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// ListIntegrations lists all configured integrations.
+func (a *ApplicationAPI) ListIntegrations(ctx context.Context, in *pb.ListIntegrationRequest) (*pb.ListIntegrationResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integrations, err := storage.GetIntegrationsForApplicationID(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListIntegrationResponse
+	for _, integration := range integrations {
+		kind, err := integrationKindToPB(integration.Kind)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Kinds = append(out.Kinds, kind)
+		out.Result = append(out.Result, &pb.IntegrationListItem{
+			Kind: kind,
+			Uuid: integration.UUID.String(),
+		})
+	}
+
+	return &out, nil
+}
+
+// ListIntegrationHealth lists the delivery health of the integrations of
+// the given application.
+func (a *ApplicationAPI) ListIntegrationHealth(ctx context.Context, in *pb.ListIntegrationRequest) (*pb.ListIntegrationHealthResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integrations, err := storage.GetIntegrationsForApplicationID(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListIntegrationHealthResponse
+	for _, integration := range integrations {
+		kind, err := integrationKindToPB(integration.Kind)
+		if err != nil {
+			return nil, err
+		}
+
+		ih, err := getIntegrationHealth(kind, integration.UUID)
+		if err != nil {
+			return nil, err
+		}
+		out.Result = append(out.Result, ih)
+	}
+
+	return &out, nil
+}
+
+// getIntegrationHealth returns the delivery health of the integration with
+// the given kind and UUID.
+func getIntegrationHealth(kind pb.IntegrationKind, uuid storage.UUID) (*pb.IntegrationHealth, error) {
+	h, err := integrationhealth.GetHealth(uuid.String())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ih := pb.IntegrationHealth{
+		Kind:                kind,
+		Uuid:                uuid.String(),
+		ConsecutiveFailures: uint32(h.ConsecutiveFailures),
+		LastError:           h.LastError,
+		Healthy:             h.Healthy(),
+	}
+	if h.LastDeliveryAt != nil {
+		ih.LastDeliveryAt = h.LastDeliveryAt.Format(time.RFC3339Nano)
+	}
+	if h.LastFailureAt != nil {
+		ih.LastFailureAt = h.LastFailureAt.Format(time.RFC3339Nano)
+	}
+	return &ih, nil
+}
+
+// StreamEvents streams the uplink, join, ack and error events of the given
+// application until the client cancels the stream.
+func (a *ApplicationAPI) StreamEvents(in *pb.StreamApplicationEventsRequest, stream pb.Application_StreamEventsServer) error {
+	if err := a.validator.Validate(stream.Context(),
+		auth.ValidateNodesAccess(in.Id, auth.List)); err != nil {
+		return grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	eventsChan := make(chan eventlog.EventLog, eventStreamBufferSize)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- eventlog.GetEventLogForApplication(ctx, in.Id, eventsChan)
+	}()
+
+	for {
+		select {
+		case el := <-eventsChan:
+			if !eventStreamTypes[el.Type] {
+				continue
+			}
+
+			if err := stream.Send(&pb.ApplicationEvent{
+				Type:        el.Type,
+				PayloadJSON: string(el.Payload),
+			}); err != nil {
+				cancel()
+				<-errChan
+				return err
+			}
+		case err := <-errChan:
+			if err != nil {
+				return errToRPCError(err)
+			}
+			return nil
+		case <-ctx.Done():
+			<-errChan
+			return nil
+		}
+	}
+}
+
+// GetIntegrationChaos returns the failure simulation of the given
+// application-integration.
+func (a *ApplicationAPI) GetIntegrationChaos(ctx context.Context, in *pb.GetIntegrationChaosRequest) (*pb.IntegrationChaos, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	kind, err := integrationKindFromPB(in.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, kind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	c := integration.Chaos()
+	return &pb.IntegrationChaos{
+		Id:          in.Id,
+		Kind:        in.Kind,
+		FailureRate: uint32(c.FailureRate),
+		Latency:     uint32(c.Latency / time.Millisecond),
+		Until:       maintenanceUntilToPB(c.Until),
+		Active:      c.Active(time.Now()),
+	}, nil
+}
+
+// UpdateIntegrationChaos updates the failure simulation of the given
+// application-integration. Only global admin users are allowed to simulate
+// integration failures.
+func (a *ApplicationAPI) UpdateIntegrationChaos(ctx context.Context, in *pb.IntegrationChaos) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin(),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	kind, err := integrationKindFromPB(in.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	c := storage.IntegrationChaos{
+		FailureRate: int(in.FailureRate),
+		Latency:     time.Duration(in.Latency) * time.Millisecond,
+	}
+	if in.Until != "" {
+		t, err := time.Parse(time.RFC3339Nano, in.Until)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "until: %s", err)
+		}
+		c.Until = &t
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, kind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SetIntegrationChaos(common.DB, integration.ID, c); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetIntegrationFilter returns the event filter of the given
+// application-integration.
+func (a *ApplicationAPI) GetIntegrationFilter(ctx context.Context, in *pb.GetIntegrationFilterRequest) (*pb.IntegrationFilter, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	kind, err := integrationKindFromPB(in.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, kind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	f := integration.Filter()
+	resp := pb.IntegrationFilter{
+		Id:         in.Id,
+		Kind:       in.Kind,
+		EventTypes: f.EventTypes,
+		Tags:       f.Tags,
+	}
+	for _, fPort := range f.FPorts {
+		resp.FPorts = append(resp.FPorts, uint32(fPort))
+	}
+
+	return &resp, nil
+}
+
+// UpdateIntegrationFilter updates the event filter of the given
+// application-integration.
+func (a *ApplicationAPI) UpdateIntegrationFilter(ctx context.Context, in *pb.IntegrationFilter) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	kind, err := integrationKindFromPB(in.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	f := storage.IntegrationFilter{
+		EventTypes: in.EventTypes,
+		Tags:       in.Tags,
+	}
+	for _, fPort := range in.FPorts {
+		f.FPorts = append(f.FPorts, int(fPort))
+	}
+
+	integration, err := storage.GetIntegrationByApplicationID(common.DB, in.Id, kind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SetIntegrationFilter(common.DB, integration.ID, f); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetGatewayFilter returns the gateway filter of the given application.
+func (a *ApplicationAPI) GetGatewayFilter(ctx context.Context, in *pb.GetGatewayFilterRequest) (*pb.GatewayFilter, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	filter, err := storage.GetGatewayFilter(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := pb.GatewayFilter{
+		Id: in.Id,
+	}
+	switch filter.Mode {
+	case storage.GatewayFilterAllow:
+		out.Mode = pb.GatewayFilterMode_ALLOW
+	case storage.GatewayFilterDeny:
+		out.Mode = pb.GatewayFilterMode_DENY
+	default:
+		return nil, grpc.Errorf(codes.Internal, "unknown gateway filter mode: %s", filter.Mode)
+	}
+	for _, mac := range filter.GatewayMACs {
+		out.GatewayMACs = append(out.GatewayMACs, mac.String())
+	}
+
+	return &out, nil
+}
+
+// UpdateGatewayFilter creates or updates the gateway filter of the given
+// application.
+func (a *ApplicationAPI) UpdateGatewayFilter(ctx context.Context, in *pb.GatewayFilter) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	filter := storage.GatewayFilter{
+		ApplicationID: in.Id,
+	}
+	switch in.Mode {
+	case pb.GatewayFilterMode_ALLOW:
+		filter.Mode = storage.GatewayFilterAllow
+	case pb.GatewayFilterMode_DENY:
+		filter.Mode = storage.GatewayFilterDeny
+	default:
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown gateway filter mode: %s", in.Mode)
+	}
+	for _, s := range in.GatewayMACs {
+		var mac lorawan.EUI64
+		if err := mac.UnmarshalText([]byte(s)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "gatewayMACs: %s", err)
+		}
+		filter.GatewayMACs = append(filter.GatewayMACs, mac)
+	}
+
+	if err := storage.UpdateGatewayFilter(common.DB, &filter); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteGatewayFilter deletes the gateway filter of the given application.
+func (a *ApplicationAPI) DeleteGatewayFilter(ctx context.Context, in *pb.DeleteGatewayFilterRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteGatewayFilter(common.DB, in.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetMaintenance returns the maintenance mode of the given application.
+func (a *ApplicationAPI) GetMaintenance(ctx context.Context, in *pb.GetApplicationMaintenanceRequest) (*pb.ApplicationMaintenance, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	app, err := storage.GetApplication(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	m := storage.Maintenance{
+		Enabled: app.MaintenanceMode,
+		Until:   app.MaintenanceUntil,
+	}
+
+	return &pb.ApplicationMaintenance{
+		Id:      app.ID,
+		Enabled: m.Enabled,
+		Until:   maintenanceUntilToPB(m.Until),
+		Active:  m.Active(time.Now()),
+	}, nil
+}
+
+// UpdateMaintenance updates the maintenance mode of the given application.
+func (a *ApplicationAPI) UpdateMaintenance(ctx context.Context, in *pb.ApplicationMaintenance) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	m, err := maintenanceFromPB(in.Enabled, in.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.SetApplicationMaintenance(common.DB, in.Id, m); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetMQTTCredentials returns the MQTT credentials of the given application.
+func (a *ApplicationAPI) GetMQTTCredentials(ctx context.Context, in *pb.GetApplicationMQTTCredentialsRequest) (*pb.ApplicationMQTTCredentials, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	creds, err := storage.GetApplicationMQTTCredentials(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.ApplicationMQTTCredentials{
+		Id:          creds.ApplicationID,
+		Username:    creds.Username,
+		TopicPrefix: mqtthandler.ApplicationTopicPrefix(creds.ApplicationID),
+		CreatedAt:   creds.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   creds.UpdatedAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// GenerateMQTTCredentials generates (or rotates) the MQTT credentials of the
+// given application.
+func (a *ApplicationAPI) GenerateMQTTCredentials(ctx context.Context, in *pb.GenerateApplicationMQTTCredentialsRequest) (*pb.GenerateApplicationMQTTCredentialsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	creds, password, err := storage.GenerateApplicationMQTTCredentials(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.GenerateApplicationMQTTCredentialsResponse{
+		Username:    creds.Username,
+		Password:    password,
+		TopicPrefix: mqtthandler.ApplicationTopicPrefix(creds.ApplicationID),
+	}, nil
+}
+
+// DeleteMQTTCredentials deletes the MQTT credentials of the given
+// application.
+func (a *ApplicationAPI) DeleteMQTTCredentials(ctx context.Context, in *pb.DeleteApplicationMQTTCredentialsRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteApplicationMQTTCredentials(common.DB, in.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetStatusPage returns the public status page of the given application.
+func (a *ApplicationAPI) GetStatusPage(ctx context.Context, in *pb.GetApplicationStatusPageRequest) (*pb.ApplicationStatusPage, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	sp, err := storage.GetApplicationStatusPage(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return statusPageToPB(sp), nil
+}
+
+// EnableStatusPage enables the public status page of the given application
+// (or rotates its token).
+func (a *ApplicationAPI) EnableStatusPage(ctx context.Context, in *pb.EnableApplicationStatusPageRequest) (*pb.ApplicationStatusPage, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	sp, err := storage.EnableApplicationStatusPage(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return statusPageToPB(sp), nil
+}
+
+// DisableStatusPage disables the public status page of the given
+// application.
+func (a *ApplicationAPI) DisableStatusPage(ctx context.Context, in *pb.DisableApplicationStatusPageRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DisableApplicationStatusPage(common.DB, in.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// SendProprietaryPayload sends a proprietary LoRaWAN frame through the given
+// gateways. The gateways must belong to the organization of the application
+// and the MAC payload must start with the proprietary payload prefix of the
+// application.
+func (a *ApplicationAPI) SendProprietaryPayload(ctx context.Context, in *pb.SendProprietaryPayloadRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	app, err := storage.GetApplication(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if len(app.ProprietaryPayloadPrefix) == 0 {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "application has no proprietary payload prefix")
+	}
+	if !bytes.HasPrefix(in.MacPayload, app.ProprietaryPayloadPrefix) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "macPayload must start with the proprietary payload prefix of the application")
+	}
+	if len(in.Mic) != 4 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "mic must be exactly 4 bytes")
+	}
+	if len(in.GatewayMACs) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "gatewayMACs must not be empty")
+	}
+
+	var gatewayMACs [][]byte
+	for _, s := range in.GatewayMACs {
+		var mac lorawan.EUI64
+		if err := mac.UnmarshalText([]byte(s)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "gatewayMACs: %s", err)
+		}
+
+		gw, err := storage.GetGateway(common.DB, mac, false)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		if gw.OrganizationID != app.OrganizationID {
+			return nil, grpc.Errorf(codes.InvalidArgument, "gateway %s does not belong to the organization of the application", mac)
+		}
+		gatewayMACs = append(gatewayMACs, mac[:])
+	}
+
+	_, err = common.NetworkServer.SendProprietaryPayload(ctx, &ns.SendProprietaryPayloadRequest{
+		MacPayload:  in.MacPayload,
+		Mic:         in.Mic,
+		GatewayMACs: gatewayMACs,
+		IPol:        in.IPol,
+		Frequency:   in.Frequency,
+		Dr:          in.Dr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// SendCustomEvent sends a custom (named) event through the integrations of
+// the application.
+func (a *ApplicationAPI) SendCustomEvent(ctx context.Context, in *pb.SendCustomEventRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.Id, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := handler.ValidateCustomEventName(in.Name); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var payload json.RawMessage
+	if in.PayloadJSON != "" {
+		if !json.Valid([]byte(in.PayloadJSON)) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "payloadJSON must be valid JSON")
+		}
+		payload = json.RawMessage(in.PayloadJSON)
+	}
+
+	app, err := storage.GetApplication(common.DB, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	pl := handler.CustomEvent{
+		ApplicationID:   app.ID,
+		ApplicationName: app.Name,
+		Environment:     app.Environment,
+		Name:            in.Name,
+		Payload:         payload,
+		Time:            time.Now(),
+	}
+
+	if in.DevEUI != "" {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(in.DevEUI)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+		}
+
+		node, err := storage.GetNode(common.DB, devEUI)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		if node.ApplicationID != app.ID {
+			return nil, grpc.Errorf(codes.InvalidArgument, "device %s does not belong to the application", devEUI)
+		}
+		pl.DevEUI = &devEUI
+		pl.NodeName = node.Name
+	}
+
+	if err := common.Handler.SendCustomEvent(pl); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "send custom event error: %s", err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// ListDownlinkTemplates returns the downlink templates of the application.
+func (a *ApplicationAPI) ListDownlinkTemplates(ctx context.Context, in *pb.ListDownlinkTemplatesRequest) (*pb.ListDownlinkTemplatesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.ApplicationID, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	templates, err := storage.GetDownlinkTemplates(common.DB, in.ApplicationID, int(in.Limit), int(in.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	count, err := storage.GetDownlinkTemplateCount(common.DB, in.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	result := make([]*pb.DownlinkTemplate, len(templates))
+	for i, t := range templates {
+		result[i] = downlinkTemplateToPB(t)
+	}
+
+	return &pb.ListDownlinkTemplatesResponse{
+		TotalCount: int32(count),
+		Result:     result,
+	}, nil
+}
+
+// GetDownlinkTemplate returns the given downlink template.
+func (a *ApplicationAPI) GetDownlinkTemplate(ctx context.Context, in *pb.DownlinkTemplateRequest) (*pb.DownlinkTemplate, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.ApplicationID, auth.Read),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	t, err := getDownlinkTemplate(in.ApplicationID, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return downlinkTemplateToPB(t), nil
+}
+
+// CreateDownlinkTemplate creates the given downlink template.
+func (a *ApplicationAPI) CreateDownlinkTemplate(ctx context.Context, in *pb.DownlinkTemplate) (*pb.CreateDownlinkTemplateResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.ApplicationID, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	t := storage.DownlinkTemplate{
+		ApplicationID: in.ApplicationID,
+	}
+	downlinkTemplateFromPB(in, &t)
+
+	if err := storage.CreateDownlinkTemplate(common.DB, &t); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateDownlinkTemplateResponse{
+		Id: t.ID,
+	}, nil
+}
+
+// UpdateDownlinkTemplate updates the given downlink template.
+func (a *ApplicationAPI) UpdateDownlinkTemplate(ctx context.Context, in *pb.DownlinkTemplate) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.ApplicationID, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	t, err := getDownlinkTemplate(in.ApplicationID, in.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	downlinkTemplateFromPB(in, &t)
+
+	if err := storage.UpdateDownlinkTemplate(common.DB, &t); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// DeleteDownlinkTemplate deletes the given downlink template.
+func (a *ApplicationAPI) DeleteDownlinkTemplate(ctx context.Context, in *pb.DownlinkTemplateRequest) (*pb.EmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateApplicationAccess(in.ApplicationID, auth.Update),
+	); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if _, err := getDownlinkTemplate(in.ApplicationID, in.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.DeleteDownlinkTemplate(common.DB, in.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func getDownlinkTemplate(applicationID, id int64) (storage.DownlinkTemplate, error) {
+	t, err := storage.GetDownlinkTemplate(common.DB, id)
+	if err != nil {
+		return t, err
+	}
+	if t.ApplicationID != applicationID {
+		return t, storage.ErrDoesNotExist
+	}
+	return t, nil
+}
+
+func downlinkTemplateFromPB(in *pb.DownlinkTemplate, t *storage.DownlinkTemplate) {
+	t.Name = in.Name
+	t.Description = in.Description
+	t.FPort = int(in.FPort)
+	t.Confirmed = in.Confirmed
+	t.Fields = make(storage.DownlinkTemplateFields, len(in.Fields))
+	for i, f := range in.Fields {
+		t.Fields[i] = storage.DownlinkTemplateField{
+			Name:         f.Name,
+			Type:         f.Type.String(),
+			Description:  f.Description,
+			Min:          f.Min,
+			Max:          f.Max,
+			LittleEndian: f.LittleEndian,
+			Length:       int(f.Length),
+			Value:        f.Value,
+		}
+	}
+}
+
+func downlinkTemplateToPB(t storage.DownlinkTemplate) *pb.DownlinkTemplate {
+	out := pb.DownlinkTemplate{
+		Id:            t.ID,
+		ApplicationID: t.ApplicationID,
+		Name:          t.Name,
+		Description:   t.Description,
+		FPort:         uint32(t.FPort),
+		Confirmed:     t.Confirmed,
+		CreatedAt:     t.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:     t.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	for _, f := range t.Fields {
+		out.Fields = append(out.Fields, &pb.DownlinkTemplateField{
+			Name:         f.Name,
+			Type:         pb.DownlinkTemplateFieldType(pb.DownlinkTemplateFieldType_value[f.Type]),
+			Description:  f.Description,
+			Min:          f.Min,
+			Max:          f.Max,
+			LittleEndian: f.LittleEndian,
+			Length:       uint32(f.Length),
+			Value:        f.Value,
+		})
+	}
+	return &out
+}
+
+// parseProprietaryPayloadPrefix parses the given hex encoded proprietary
+// payload prefix. An empty string results in no prefix.
+func parseProprietaryPayloadPrefix(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "proprietaryPayloadPrefix: %s", err)
+	}
+	return b, nil
+}
+
+// validateResidencyRegion returns an error when the given (non-empty)
+// residency region has not been configured.
+func validateResidencyRegion(region string) error {
+	if region == "" {
+		return nil
+	}
+	_, err := residency.GetRegion(region)
+	return err
+}
+
+// parseDevEUIs parses the given hex encoded DevEUIs. Empty values are
+// ignored.
+func parseDevEUIs(devEUIs []string) ([]lorawan.EUI64, error) {
+	var out []lorawan.EUI64
+	for _, s := range devEUIs {
+		if s == "" {
+			continue
+		}
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(s)); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "devEUIs: %s", err)
+		}
+		out = append(out, devEUI)
+	}
+	return out, nil
+}
+
+// secretMask is returned instead of the stored secrets of the integrations.
+// When sent back on update, the stored secret is kept.
+const secretMask = "********"
+
+// maskSecret returns secretMask for a non-empty secret, so that the secret
+// itself is never returned by the API.
+func maskSecret(s secret.String) string {
+	if s == "" {
+		return ""
+	}
+	return secretMask
+}
+
+// unmaskSecret returns the stored secret when the given value equals
+// secretMask, else the given value.
+func unmaskSecret(s string, stored secret.String) secret.String {
+	if s == secretMask {
+		return stored
+	}
+	return secret.String(s)
+}
+
+// maintenanceFromPB returns the maintenance mode for the given enabled flag
+// and RFC3339 formatted end time. An empty end time results in a
+// maintenance mode without end.
+func maintenanceFromPB(enabled bool, until string) (storage.Maintenance, error) {
+	m := storage.Maintenance{
+		Enabled: enabled,
+	}
+	if until == "" {
+		return m, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, until)
+	if err != nil {
+		return m, grpc.Errorf(codes.InvalidArgument, "until: %s", err)
+	}
+	m.Until = &t
+	return m, nil
+}
+
+// integrationKindFromPB returns the handler kind of the given integration
+// kind.
+func integrationKindFromPB(kind pb.IntegrationKind) (string, error) {
+	switch kind {
+	case pb.IntegrationKind_HTTP:
+		return handler.HTTPHandlerKind, nil
+	case pb.IntegrationKind_SYSLOG:
+		return handler.SyslogHandlerKind, nil
+	case pb.IntegrationKind_AMQP:
+		return handler.AMQPHandlerKind, nil
+	case pb.IntegrationKind_POSTGRESQL:
+		return handler.PostgreSQLHandlerKind, nil
+	case pb.IntegrationKind_AWS_SNS:
+		return handler.AWSSNSHandlerKind, nil
+	case pb.IntegrationKind_AZURE:
+		return handler.AzureHandlerKind, nil
+	case pb.IntegrationKind_GCP_PUB_SUB:
+		return handler.GCPPubSubHandlerKind, nil
+	case pb.IntegrationKind_THINGSBOARD:
+		return handler.ThingsBoardHandlerKind, nil
+	case pb.IntegrationKind_MY_DEVICES:
+		return handler.MyDevicesHandlerKind, nil
+	case pb.IntegrationKind_ELASTICSEARCH:
+		return handler.ElasticsearchHandlerKind, nil
+	default:
+		return "", grpc.Errorf(codes.InvalidArgument, "unknown integration kind: %s", kind)
+	}
+}
+
+func integrationKindToPB(kind string) (pb.IntegrationKind, error) {
+	switch kind {
+	case handler.HTTPHandlerKind:
+		return pb.IntegrationKind_HTTP, nil
+	case handler.SyslogHandlerKind:
+		return pb.IntegrationKind_SYSLOG, nil
+	case handler.AMQPHandlerKind:
+		return pb.IntegrationKind_AMQP, nil
+	case handler.PostgreSQLHandlerKind:
+		return pb.IntegrationKind_POSTGRESQL, nil
+	case handler.AWSSNSHandlerKind:
+		return pb.IntegrationKind_AWS_SNS, nil
+	case handler.AzureHandlerKind:
+		return pb.IntegrationKind_AZURE, nil
+	case handler.GCPPubSubHandlerKind:
+		return pb.IntegrationKind_GCP_PUB_SUB, nil
+	case handler.ThingsBoardHandlerKind:
+		return pb.IntegrationKind_THINGSBOARD, nil
+	case handler.MyDevicesHandlerKind:
+		return pb.IntegrationKind_MY_DEVICES, nil
+	case handler.ElasticsearchHandlerKind:
+		return pb.IntegrationKind_ELASTICSEARCH, nil
+	default:
+		return 0, grpc.Errorf(codes.Internal, "unknown integration kind: %s", kind)
+	}
+}
+
+// maintenanceUntilToPB returns the given maintenance end time RFC3339
+// formatted (or an empty string when not set).
+func maintenanceUntilToPB(until *time.Time) string {
+	if until == nil {
+		return ""
+	}
+	return until.Format(time.RFC3339Nano)
+}
+
+func statusPageToPB(sp storage.ApplicationStatusPage) *pb.ApplicationStatusPage {
+	return &pb.ApplicationStatusPage{
+		Id:        sp.ApplicationID,
+		Token:     sp.Token,
+		Path:      statusPagePath(sp.Token),
+		CreatedAt: sp.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: sp.UpdatedAt.Format(time.RFC3339Nano),
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/aes"
 	"crypto/rand"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/brocaar/lora-app-server/internal/gwping"
@@ -13,8 +14,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/lora-app-server/internal/airtime"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/fcntanomaly"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/lastvalue"
+	"github.com/brocaar/lora-app-server/internal/linkquality"
+	"github.com/brocaar/lora-app-server/internal/nsevent"
+	"github.com/brocaar/lora-app-server/internal/security"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/lorawan"
@@ -58,6 +65,10 @@ func (a *ApplicationServerAPI) JoinRequest(ctx context.Context, req *as.JoinRequ
 		}).Errorf("join-request node does not exist")
 		return nil, grpc.Errorf(codes.Unknown, err.Error())
 	}
+	if node.Disabled {
+		log.WithField("dev_eui", node.DevEUI).Warning("join-request of disabled node rejected")
+		return nil, grpc.Errorf(codes.FailedPrecondition, "node is disabled")
+	}
 	app, err := storage.GetApplication(common.DB, node.ApplicationID)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -66,6 +77,17 @@ func (a *ApplicationServerAPI) JoinRequest(ctx context.Context, req *as.JoinRequ
 		return nil, grpc.Errorf(codes.Internal, err.Error())
 	}
 
+	flood, err := security.CheckJoinFlood(node.DevEUI)
+	if err != nil {
+		log.WithField("dev_eui", node.DevEUI).Errorf("check join flood error: %s", err)
+	}
+	if flood {
+		msg := fmt.Sprintf("more than %d join-requests within %s", security.JoinFloodThreshold, security.JoinFloodWindow)
+		if err := security.Notify(app, node, security.JoinFlood, msg); err != nil {
+			log.WithField("dev_eui", node.DevEUI).Errorf("notify security event error: %s", err)
+		}
+	}
+
 	if node.AppEUI != jrPL.AppEUI {
 		log.WithFields(log.Fields{
 			"dev_eui":          node.DevEUI,
@@ -90,6 +112,9 @@ func (a *ApplicationServerAPI) JoinRequest(ctx context.Context, req *as.JoinRequ
 			"app_eui": node.AppEUI,
 			"mic":     phy.MIC,
 		}).Error("join-request invalid mic")
+		if err := security.Notify(app, node, security.MICFailure, "join-request invalid MIC"); err != nil {
+			log.WithField("dev_eui", node.DevEUI).Errorf("notify security event error: %s", err)
+		}
 		return nil, grpc.Errorf(codes.InvalidArgument, "invalid MIC")
 	}
 
@@ -100,6 +125,10 @@ func (a *ApplicationServerAPI) JoinRequest(ctx context.Context, req *as.JoinRequ
 			"app_eui":   node.AppEUI,
 			"dev_nonce": jrPL.DevNonce,
 		}).Error("join-request DevNonce has already been used")
+		msg := fmt.Sprintf("join-request DevNonce %X has already been used", jrPL.DevNonce[:])
+		if err := security.Notify(app, node, security.DevNonceReuse, msg); err != nil {
+			log.WithField("dev_eui", node.DevEUI).Errorf("notify security event error: %s", err)
+		}
 		return nil, grpc.Errorf(codes.InvalidArgument, "DevNonce has already been used")
 	}
 
@@ -196,6 +225,7 @@ func (a *ApplicationServerAPI) JoinRequest(ctx context.Context, req *as.JoinRequ
 	err = common.Handler.SendJoinNotification(handler.JoinNotification{
 		ApplicationID:   app.ID,
 		ApplicationName: app.Name,
+		Environment:     app.Environment,
 		NodeName:        node.Name,
 		DevAddr:         node.DevAddr,
 		DevEUI:          node.DevEUI,
@@ -223,6 +253,13 @@ func (a *ApplicationServerAPI) HandleDataUp(ctx context.Context, req *as.HandleD
 		log.WithField("dev_eui", devEUI).Error(errStr)
 		return nil, grpc.Errorf(codes.Internal, errStr)
 	}
+	if node.Disabled {
+		log.WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"f_cnt":   req.FCnt,
+		}).Warning("uplink of disabled node ignored")
+		return &as.HandleDataUpResponse{}, nil
+	}
 	app, err := storage.GetApplication(common.DB, node.ApplicationID)
 	if err != nil {
 		errStr := fmt.Sprintf("get application error: %s", err)
@@ -242,6 +279,7 @@ func (a *ApplicationServerAPI) HandleDataUp(ctx context.Context, req *as.HandleD
 	pl := handler.DataUpPayload{
 		ApplicationID:   app.ID,
 		ApplicationName: app.Name,
+		Environment:     app.Environment,
 		NodeName:        node.Name,
 		DevEUI:          devEUI,
 		RXInfo:          []handler.RXInfo{},
@@ -256,9 +294,14 @@ func (a *ApplicationServerAPI) HandleDataUp(ctx context.Context, req *as.HandleD
 			ADR:      req.TxInfo.Adr,
 			CodeRate: req.TxInfo.CodeRate,
 		},
-		FCnt:  req.FCnt,
-		FPort: uint8(req.FPort),
-		Data:  b,
+		FCnt:        req.FCnt,
+		FPort:       uint8(req.FPort),
+		Data:        b,
+		Maintenance: storage.InMaintenance(app, node, time.Now()),
+	}
+
+	if err := airtime.SetDataRate(devEUI, pl.TXInfo.DataRate); err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("set data-rate error: %s", err)
 	}
 
 	for _, rxInfo := range req.RxInfo {
@@ -289,6 +332,59 @@ func (a *ApplicationServerAPI) HandleDataUp(ctx context.Context, req *as.HandleD
 		})
 	}
 
+	accepted, err := applyGatewayFilter(app, node, &pl)
+	if err != nil {
+		errStr := fmt.Sprintf("apply gateway filter error: %s", err)
+		log.WithField("dev_eui", devEUI).Error(errStr)
+		return nil, grpc.Errorf(codes.Internal, errStr)
+	}
+	if !accepted {
+		return &as.HandleDataUpResponse{}, nil
+	}
+
+	if err := linkquality.HandleUplink(devEUI, pl.FCnt, pl.TXInfo.DataRate, pl.RXInfo); err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("handle link-quality error: %s", err)
+	}
+
+	anomaly, err := fcntanomaly.Check(devEUI, pl.FCnt, pl.Data)
+	if err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("check frame-counter anomaly error: %s", err)
+	}
+	if anomaly != nil {
+		log.WithFields(log.Fields{
+			"application_name": app.Name,
+			"node_name":        node.Name,
+			"type":             anomaly.Type,
+			"dev_eui":          devEUI,
+		}).Warning(anomaly.Message)
+
+		// no alarms are raised during maintenance
+		if !pl.Maintenance {
+			err = common.Handler.SendErrorNotification(handler.ErrorNotification{
+				ApplicationID:   app.ID,
+				ApplicationName: app.Name,
+				Environment:     app.Environment,
+				NodeName:        node.Name,
+				DevEUI:          devEUI,
+				Type:            anomaly.Type,
+				Error:           anomaly.Message,
+			})
+			if err != nil {
+				log.WithField("dev_eui", devEUI).Errorf("send frame-counter anomaly notification to handler error: %s", err)
+			}
+		}
+	}
+
+	err = lastvalue.Set(devEUI, lastvalue.Value{
+		FPort:      pl.FPort,
+		FCnt:       pl.FCnt,
+		Data:       pl.Data,
+		ReceivedAt: time.Now(),
+	})
+	if err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("set last value error: %s", err)
+	}
+
 	err = common.Handler.SendDataUp(pl)
 	if err != nil {
 		errStr := fmt.Sprintf("send data up to handler error: %s", err)
@@ -415,6 +511,7 @@ func (a *ApplicationServerAPI) HandleDataDownACK(ctx context.Context, req *as.Ha
 	err = common.Handler.SendACKNotification(handler.ACKNotification{
 		ApplicationID:   app.ID,
 		ApplicationName: app.Name,
+		Environment:     app.Environment,
 		NodeName:        node.Name,
 		DevEUI:          devEUI,
 		Reference:       qi.Reference,
@@ -451,18 +548,28 @@ func (a *ApplicationServerAPI) HandleError(ctx context.Context, req *as.HandleEr
 		"dev_eui":          devEUI,
 	}).Error(req.Error)
 
-	err = common.Handler.SendErrorNotification(handler.ErrorNotification{
-		ApplicationID:   app.ID,
-		ApplicationName: app.Name,
-		NodeName:        node.Name,
-		DevEUI:          devEUI,
-		Type:            req.Type.String(),
-		Error:           req.Error,
-	})
-	if err != nil {
-		errStr := fmt.Sprintf("send error notification to handler error: %s", err)
-		log.Error(errStr)
-		return nil, grpc.Errorf(codes.Internal, errStr)
+	// no alarms are raised during maintenance
+	if !storage.InMaintenance(app, node, time.Now()) {
+		err = common.Handler.SendErrorNotification(handler.ErrorNotification{
+			ApplicationID:   app.ID,
+			ApplicationName: app.Name,
+			Environment:     app.Environment,
+			NodeName:        node.Name,
+			DevEUI:          devEUI,
+			Type:            req.Type.String(),
+			Error:           req.Error,
+		})
+		if err != nil {
+			errStr := fmt.Sprintf("send error notification to handler error: %s", err)
+			log.Error(errStr)
+			return nil, grpc.Errorf(codes.Internal, errStr)
+		}
+	}
+
+	if req.Type == as.ErrorType_DATA_UP_MIC {
+		if err := security.Notify(app, node, security.MICFailure, req.Error); err != nil {
+			log.WithField("dev_eui", devEUI).Errorf("notify security event error: %s", err)
+		}
 	}
 
 	return &as.HandleErrorResponse{}, nil
@@ -471,6 +578,16 @@ func (a *ApplicationServerAPI) HandleError(ctx context.Context, req *as.HandleEr
 // HandleProprietaryUp handles proprietary uplink payloads.
 func (a *ApplicationServerAPI) HandleProprietaryUp(ctx context.Context, req *as.HandleProprietaryUpRequest) (*as.HandleProprietaryUpResponse, error) {
 	err := gwping.HandleReceivedPing(req)
+	if err == gwping.ErrUnknownPing {
+		// not a gateway ping, dispatch it as proprietary frame
+		err = nsevent.HandleProprietaryUp(req)
+		if err != nil {
+			errStr := fmt.Sprintf("handle proprietary uplink error: %s", err)
+			log.Error(errStr)
+			return nil, grpc.Errorf(codes.Internal, errStr)
+		}
+		return &as.HandleProprietaryUpResponse{}, nil
+	}
 	if err != nil {
 		errStr := fmt.Sprintf("handle received ping error: %s", err)
 		log.Error(errStr)
@@ -527,3 +644,38 @@ func getSKey(typ byte, appkey lorawan.AES128Key, netID lorawan.NetID, appNonce [
 	block.Encrypt(key[:], b)
 	return key, nil
 }
+
+// applyGatewayFilter removes the RX info of the gateways not allowed by the
+// gateway filter of the application (if any) from the given payload. When
+// none of the receiving gateways is allowed, a security notification is
+// sent and false is returned (the uplink must not be dispatched).
+func applyGatewayFilter(app storage.Application, node storage.Node, pl *handler.DataUpPayload) (bool, error) {
+	filter, err := storage.GetGatewayFilter(common.DB, app.ID)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return true, nil
+		}
+		return false, err
+	}
+
+	var rxInfo []handler.RXInfo
+	var rejected []string
+	for _, info := range pl.RXInfo {
+		if filter.Allows(info.MAC) {
+			rxInfo = append(rxInfo, info)
+		} else {
+			rejected = append(rejected, info.MAC.String())
+		}
+	}
+
+	if len(rxInfo) == 0 {
+		msg := fmt.Sprintf("uplink (fCnt %d) only received by gateway(s) not allowed by the gateway filter: %s", pl.FCnt, strings.Join(rejected, ", "))
+		if err := security.Notify(app, node, security.GatewayFilterViolation, msg); err != nil {
+			log.WithField("dev_eui", node.DevEUI).Errorf("send security notification error: %s", err)
+		}
+		return false, nil
+	}
+
+	pl.RXInfo = rxInfo
+	return true, nil
+}
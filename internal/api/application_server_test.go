@@ -12,6 +12,8 @@ import (
 
 	"github.com/brocaar/lora-app-server/internal/common"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/lastvalue"
+	"github.com/brocaar/lora-app-server/internal/security"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/lora-app-server/internal/test/testhandler"
@@ -94,6 +96,46 @@ func TestApplicationServerAPI(t *testing.T) {
 			})
 		})
 
+		Convey("Given the node is disabled", func() {
+			So(storage.SetNodeDisabled(common.DB, node.DevEUI, true), ShouldBeNil)
+
+			Convey("When calling HandleDataUp", func() {
+				_, err := api.HandleDataUp(ctx, &as.HandleDataUpRequest{
+					DevEUI: node.DevEUI[:],
+					AppEUI: node.AppEUI[:],
+					FCnt:   10,
+					FPort:  3,
+					Data:   []byte{1, 2, 3, 4},
+					RxInfo: []*as.RXInfo{
+						{Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+					},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the uplink was ignored", func() {
+					So(h.SendDataUpChan, ShouldHaveLength, 0)
+				})
+			})
+		})
+
+		Convey("Given the application is in maintenance mode", func() {
+			So(storage.SetApplicationMaintenance(common.DB, app.ID, storage.Maintenance{Enabled: true}), ShouldBeNil)
+
+			Convey("When calling HandleError", func() {
+				_, err := api.HandleError(ctx, &as.HandleErrorRequest{
+					DevEUI: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					AppEUI: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+					Type:   as.ErrorType_DATA_UP_FCNT,
+					Error:  "BOOM!",
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then no error notification has been sent to the handler", func() {
+					So(h.SendErrorNotificationChan, ShouldHaveLength, 0)
+				})
+			})
+		})
+
 		Convey("Given a gateway with ping enabled and a pending ping", func() {
 			pingGW := storage.Gateway{
 				MAC:            lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
@@ -245,6 +287,54 @@ func TestApplicationServerAPI(t *testing.T) {
 						Data:  []byte{67, 216, 236, 205},
 					})
 				})
+
+				Convey("Then the last value of the fPort was stored", func() {
+					vals, err := lastvalue.Get(node.DevEUI)
+					So(err, ShouldBeNil)
+					So(vals, ShouldHaveLength, 1)
+					So(vals[0].FPort, ShouldEqual, 3)
+					So(vals[0].FCnt, ShouldEqual, 10)
+					So(vals[0].Data, ShouldResemble, []byte{67, 216, 236, 205})
+				})
+			})
+
+			Convey("Given a gateway filter denying the receiving gateway", func() {
+				So(storage.UpdateGatewayFilter(common.DB, &storage.GatewayFilter{
+					ApplicationID: app.ID,
+					Mode:          storage.GatewayFilterDeny,
+					GatewayMACs:   []lorawan.EUI64{gw.MAC},
+				}), ShouldBeNil)
+
+				Convey("When calling HandleDataUp", func() {
+					_, err := api.HandleDataUp(ctx, &as.HandleDataUpRequest{
+						DevEUI: node.DevEUI[:],
+						AppEUI: node.AppEUI[:],
+						FCnt:   10,
+						FPort:  3,
+						Data:   []byte{1, 2, 3, 4},
+						RxInfo: []*as.RXInfo{
+							{Mac: gw.MAC[:], Rssi: -60, LoRaSNR: 5},
+						},
+						TxInfo: &as.TXInfo{
+							Frequency: 868100000,
+							DataRate: &as.DataRate{
+								Modulation:   "LORA",
+								BandWidth:    125,
+								SpreadFactor: 7,
+							},
+							CodeRate: "4/5",
+						},
+					})
+					So(err, ShouldBeNil)
+
+					Convey("Then the payload was not sent to the handler and a security notification was sent", func() {
+						So(h.SendDataUpChan, ShouldHaveLength, 0)
+						So(h.SendSecurityNotificationChan, ShouldHaveLength, 1)
+						pl := <-h.SendSecurityNotificationChan
+						So(pl.Type, ShouldEqual, security.GatewayFilterViolation)
+						So(pl.DevEUI, ShouldEqual, node.DevEUI)
+					})
+				})
 			})
 
 			Convey("Given the node is an ABP device", func() {
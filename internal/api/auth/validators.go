@@ -30,7 +30,7 @@ const (
 const userQuery = `
 	select count(*)
 	from "user" u
-	left join organization_user ou
+	left join organization_member ou
 		on u.id = ou.user_id
 	left join organization o
 		on o.id = ou.organization_id
@@ -54,6 +54,18 @@ func ValidateActiveUser() ValidatorFunc {
 	}
 }
 
+// ValidateIsAdmin validates if the user in the JWT claim is an active
+// global admin user.
+func ValidateIsAdmin() ValidatorFunc {
+	where := [][]string{
+		{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
+	}
+
+	return func(db *sqlx.DB, claims *Claims) (bool, error) {
+		return executeQuery(db, userQuery, where, claims.Username)
+	}
+}
+
 // ValidateUsersAccess validates if the client has access to the global users
 // resource.
 func ValidateUsersAccess(flag Flag) ValidatorFunc {
@@ -280,9 +292,10 @@ func ValidateNodesAccess(applicationID int64, flag Flag) ValidatorFunc {
 	var where = [][]string{}
 
 	switch flag {
-	case Create:
+	case Create, Update:
 		// global admin users, organization admin users or application
-		// admin users.
+		// admin users (update applies to the nodes of the application
+		// in bulk).
 		where = [][]string{
 			{"u.username = $1", "u.is_active = true", "u.is_admin = true"},
 			{"u.username = $1", "u.is_active = true", "au.is_admin = true or ou.is_admin = true", "a.id = $2"},
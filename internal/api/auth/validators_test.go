@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
@@ -41,6 +42,8 @@ func TestValidators(t *testing.T) {
 		10: admin of organization 1
 		11: member of organization 1 (but is_active=false)
 		12: admin of organization 2
+		13: elevated to admin of organization 1
+		14: elevated to admin of organization 1 (but expired or revoked)
 
 		Organizations:
 		1: organization 1 (can have gateways)
@@ -107,6 +110,8 @@ func TestValidators(t *testing.T) {
 		{ID: 20, Username: "user10", IsActive: true},
 		{ID: 21, Username: "user11", IsActive: false},
 		{ID: 22, Username: "user12", IsActive: true},
+		{ID: 23, Username: "user13", IsActive: true},
+		{ID: 24, Username: "user14", IsActive: true},
 	}
 	for _, user := range users {
 		_, err = db.Exec(`insert into "user" (id, created_at, updated_at, username, password_hash, session_ttl, is_active, is_admin) values ($1, now(), now(), $2, '', 0, $3, $4)`, user.ID, user.Username, user.IsActive, user.IsAdmin)
@@ -149,6 +154,23 @@ func TestValidators(t *testing.T) {
 		}
 	}
 
+	orgElevations := []struct {
+		UserID         int64
+		OrganizationID int64
+		ExpiresIn      time.Duration
+		Revoked        bool
+	}{
+		{UserID: users[12].ID, OrganizationID: organizations[0].ID, ExpiresIn: time.Hour},
+		{UserID: users[13].ID, OrganizationID: organizations[0].ID, ExpiresIn: -time.Hour},
+		{UserID: users[13].ID, OrganizationID: organizations[0].ID, ExpiresIn: time.Hour, Revoked: true},
+	}
+	for _, e := range orgElevations {
+		_, err = db.Exec("insert into organization_elevation (created_at, organization_id, user_id, granted_by, justification, expires_at, revoked_at) values (now(), $1, $2, 'user1', 'test', $3, case when $4 then now() end)", e.OrganizationID, e.UserID, time.Now().Add(e.ExpiresIn), e.Revoked)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	gateways := []storage.Gateway{
 		{MAC: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Name: "gateway1", OrganizationID: organizations[0].ID},
 		{MAC: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Name: "gateway2", OrganizationID: organizations[1].ID},
@@ -161,6 +183,30 @@ func TestValidators(t *testing.T) {
 
 	Convey("Given a set of test users, applications and nodes", t, func() {
 
+		Convey("When testing ValidateIsAdmin", func() {
+			tests := []validatorTest{
+				{
+					Name:       "global admin user is admin",
+					Validators: []ValidatorFunc{ValidateIsAdmin()},
+					Claims:     Claims{Username: "user1"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "inactive global admin user is not admin",
+					Validators: []ValidatorFunc{ValidateIsAdmin()},
+					Claims:     Claims{Username: "user8"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "application admin user is not admin",
+					Validators: []ValidatorFunc{ValidateIsAdmin()},
+					Claims:     Claims{Username: "user2"},
+					ExpectedOK: false,
+				},
+			}
+			runTests(tests, db)
+		})
+
 		Convey("When testing ValidateUsersAccess (DisableAssignExistingUsers=false)", func() {
 			DisableAssignExistingUsers = false
 			tests := []validatorTest{
@@ -552,6 +598,12 @@ func TestValidators(t *testing.T) {
 					Claims:     Claims{Username: "user3"},
 					ExpectedOK: true,
 				},
+				{
+					Name:       "organization admin users can update",
+					Validators: []ValidatorFunc{ValidateNodesAccess(applications[0].ID, Update)},
+					Claims:     Claims{Username: "user10"},
+					ExpectedOK: true,
+				},
 				{
 					Name:       "organization users can not create",
 					Validators: []ValidatorFunc{ValidateNodesAccess(applications[0].ID, Create)},
@@ -559,8 +611,8 @@ func TestValidators(t *testing.T) {
 					ExpectedOK: false,
 				},
 				{
-					Name:       "application users can not create",
-					Validators: []ValidatorFunc{ValidateNodesAccess(applications[0].ID, Create)},
+					Name:       "application users can not create or update",
+					Validators: []ValidatorFunc{ValidateNodesAccess(applications[0].ID, Create), ValidateNodesAccess(applications[0].ID, Update)},
 					Claims:     Claims{Username: "user3"},
 					ExpectedOK: false,
 				},
@@ -778,6 +830,31 @@ func TestValidators(t *testing.T) {
 			runTests(tests, db)
 		})
 
+		Convey("When testing organization elevations", func() {
+			tests := []validatorTest{
+				{
+					Name:       "elevated users are organization admin",
+					Validators: []ValidatorFunc{ValidateIsOrganizationAdmin(organizations[0].ID), ValidateOrganizationAccess(Update, organizations[0].ID), ValidateNodeAccess(nodes[0].DevEUI, Update)},
+					Claims:     Claims{Username: "user13"},
+					ExpectedOK: true,
+				},
+				{
+					Name:       "elevated users are not admin of other organizations",
+					Validators: []ValidatorFunc{ValidateIsOrganizationAdmin(organizations[1].ID), ValidateOrganizationAccess(Read, organizations[1].ID)},
+					Claims:     Claims{Username: "user13"},
+					ExpectedOK: false,
+				},
+				{
+					Name:       "users with an expired or revoked elevation are not organization admin",
+					Validators: []ValidatorFunc{ValidateIsOrganizationAdmin(organizations[0].ID), ValidateOrganizationAccess(Read, organizations[0].ID), ValidateNodeAccess(nodes[0].DevEUI, Read)},
+					Claims:     Claims{Username: "user14"},
+					ExpectedOK: false,
+				},
+			}
+
+			runTests(tests, db)
+		})
+
 		Convey("When testing ValidateOrganizationsAccess", func() {
 			tests := []validatorTest{
 				{
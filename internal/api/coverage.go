@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/coverage"
+	"github.com/brocaar/lora-app-server/internal/geohash"
+	"github.com/brocaar/lorawan"
+)
+
+// defaultCoverageDays and maxCoverageDays define the default and max
+// number of days of gateway pings taken into account for the coverage map.
+const (
+	defaultCoverageDays = 7
+	maxCoverageDays     = 90
+)
+
+// GatewayCoverageHandler implements a http.Handler which returns a tile of
+// the coverage map of the gateways of an organization as GeoJSON. The
+// RSSI and SNR of the received gateway pings are aggregated per gateway
+// (sending the ping) and geohash cell.
+type GatewayCoverageHandler struct {
+	validator auth.Validator
+}
+
+// NewGatewayCoverageHandler creates a new GatewayCoverageHandler.
+func NewGatewayCoverageHandler(validator auth.Validator) *GatewayCoverageHandler {
+	return &GatewayCoverageHandler{
+		validator: validator,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GatewayCoverageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationID, err := strconv.ParseInt(vars["organizationID"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid organization id", http.StatusBadRequest)
+		return
+	}
+
+	var tile coverage.Tile
+	for name, v := range map[string]*int{"z": &tile.Z, "x": &tile.X, "y": &tile.Y} {
+		if *v, err = strconv.Atoi(vars[name]); err != nil {
+			http.Error(w, fmt.Sprintf("invalid tile coordinate %s", name), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := tile.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var mac *lorawan.EUI64
+	if s := query.Get("gatewayMAC"); s != "" {
+		mac = &lorawan.EUI64{}
+		if err := mac.UnmarshalText([]byte(s)); err != nil {
+			http.Error(w, fmt.Sprintf("gatewayMAC: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	days := defaultCoverageDays
+	if s := query.Get("days"); s != "" {
+		days, err = strconv.Atoi(s)
+		if err != nil || days < 1 || days > maxCoverageDays {
+			http.Error(w, fmt.Sprintf("days must be between 1 and %d", maxCoverageDays), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var precision int
+	if s := query.Get("precision"); s != "" {
+		precision, err = strconv.Atoi(s)
+		if err != nil || precision < 1 || precision > geohash.MaxPrecision {
+			http.Error(w, fmt.Sprintf("precision must be between 1 and %d", geohash.MaxPrecision), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := getContextFromHTTPRequest(r)
+	if err := h.validator.Validate(ctx,
+		auth.ValidateGatewaysAccess(auth.List, organizationID)); err != nil {
+		http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+		return
+	}
+
+	fc, err := coverage.GetTile(organizationID, mac, time.Now().AddDate(0, 0, -days), tile, precision)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"organization_id": organizationID,
+			"z":               tile.Z,
+			"x":               tile.X,
+			"y":               tile.Y,
+		}).Errorf("get coverage tile error: %s", err)
+		http.Error(w, "get coverage tile error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	if err := json.NewEncoder(w).Encode(fc); err != nil {
+		log.Errorf("encode coverage tile error: %s", err)
+	}
+}
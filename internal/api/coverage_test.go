@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/coverage"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGatewayCoverageHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a gateway ping and a coverage handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		validator := &TestValidator{}
+		r := mux.NewRouter()
+		r.Handle("/api/organizations/{organizationID}/gateways/coverage/{z}/{x}/{y}", NewGatewayCoverageHandler(validator))
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		gw := storage.Gateway{
+			MAC:            lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:           "test-gw",
+			OrganizationID: org.ID,
+		}
+		So(storage.CreateGateway(common.DB, &gw), ShouldBeNil)
+		ping := storage.GatewayPing{
+			GatewayMAC: gw.MAC,
+			Frequency:  868100000,
+			DR:         5,
+		}
+		So(storage.CreateGatewayPing(common.DB, &ping), ShouldBeNil)
+		now := time.Now()
+		So(storage.CreateGatewayPingRX(common.DB, &storage.GatewayPingRX{
+			PingID:     ping.ID,
+			GatewayMAC: gw.MAC,
+			ReceivedAt: &now,
+			RSSI:       -90,
+			LoRaSNR:    5,
+			Location: storage.GPSPoint{
+				Latitude:  52.3,
+				Longitude: 5.7,
+			},
+		}), ShouldBeNil)
+
+		Convey("When requesting the tile containing the ping", func() {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/organizations/%d/gateways/coverage/10/528/336", org.ID), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			Convey("Then the coverage cell is returned as GeoJSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/geo+json")
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				var fc coverage.FeatureCollection
+				So(json.NewDecoder(w.Body).Decode(&fc), ShouldBeNil)
+				So(fc.Features, ShouldHaveLength, 1)
+				So(fc.Features[0].Properties.GatewayMAC, ShouldEqual, gw.MAC.String())
+				So(fc.Features[0].Properties.Geohash, ShouldEqual, "u1k198")
+				So(fc.Features[0].Properties.Count, ShouldEqual, 1)
+				So(fc.Features[0].Properties.RSSIAvg, ShouldEqual, -90)
+			})
+		})
+
+		Convey("When requesting a different tile", func() {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/organizations/%d/gateways/coverage/10/0/0", org.ID), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			Convey("Then an empty feature collection is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var fc coverage.FeatureCollection
+				So(json.NewDecoder(w.Body).Decode(&fc), ShouldBeNil)
+				So(fc.Features, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When requesting an invalid tile", func() {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/organizations/%d/gateways/coverage/1/2/0", org.ID), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the validator returns an error", func() {
+			validator.returnError = fmt.Errorf("boom")
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/organizations/%d/gateways/coverage/10/528/336", org.ID), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
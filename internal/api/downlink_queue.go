@@ -1,6 +1,11 @@
 package api
 
 import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -40,6 +45,9 @@ func (d *DownlinkQueueAPI) Enqueue(ctx context.Context, req *pb.EnqueueDownlinkQ
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
+	if node.RetiredAt != nil {
+		return nil, errToRPCError(storage.ErrNodeRetired)
+	}
 
 	qi := storage.DownlinkQueueItem{
 		DevEUI:    node.DevEUI,
@@ -53,7 +61,104 @@ func (d *DownlinkQueueAPI) Enqueue(ctx context.Context, req *pb.EnqueueDownlinkQ
 		return nil, errToRPCError(err)
 	}
 
-	return &pb.EnqueueDownlinkQueueItemResponse{}, nil
+	// the item has been enqueued, a failing advisory only omits it from
+	// the response
+	adv, err := downlink.GetAdvisory(node, qi)
+	if err != nil {
+		log.WithField("dev_eui", node.DevEUI).Errorf("get downlink advisory error: %s", err)
+		return &pb.EnqueueDownlinkQueueItemResponse{}, nil
+	}
+
+	return &pb.EnqueueDownlinkQueueItemResponse{
+		Advisory: &pb.DownlinkAdvisory{
+			EstimatedAirtime: uint32(adv.Airtime / time.Millisecond),
+			QueuePosition:    uint32(adv.QueuePosition),
+			WaitForUplink:    adv.WaitForUplink,
+			TransmitAfter:    adv.TransmitAfter.Format(time.RFC3339),
+			DutyCycle:        adv.DutyCycle * 100,
+		},
+	}, nil
+}
+
+// EnqueueTemplate encodes the given parameters using the downlink template
+// of the node its application and adds the resulting payload to the
+// downlink queue. With dryRun set, only the encoded payload is returned.
+func (d *DownlinkQueueAPI) EnqueueTemplate(ctx context.Context, req *pb.EnqueueDownlinkTemplateRequest) (*pb.EnqueueDownlinkTemplateResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := d.validator.Validate(ctx,
+		auth.ValidateNodeQueueAccess(devEUI, auth.Create)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	if node.RetiredAt != nil {
+		return nil, errToRPCError(storage.ErrNodeRetired)
+	}
+
+	t, err := storage.GetDownlinkTemplateByName(common.DB, node.ApplicationID, req.Template)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	params := make(map[string]interface{})
+	if req.ParametersJSON != "" {
+		dec := json.NewDecoder(strings.NewReader(req.ParametersJSON))
+		dec.UseNumber()
+		if err := dec.Decode(&params); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parametersJSON: %s", err)
+		}
+	}
+
+	b, err := t.Encode(params)
+	if err != nil {
+		if _, ok := err.(storage.DownlinkTemplateParameterError); ok {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.EnqueueDownlinkTemplateResponse{
+		FPort:     uint32(t.FPort),
+		Confirmed: t.Confirmed,
+		Data:      b,
+	}
+	if req.DryRun {
+		return &resp, nil
+	}
+
+	qi := storage.DownlinkQueueItem{
+		DevEUI:    node.DevEUI,
+		Reference: req.Reference,
+		Confirmed: t.Confirmed,
+		FPort:     uint8(t.FPort),
+		Data:      b,
+	}
+
+	if err := downlink.HandleDownlinkQueueItem(node, &qi); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	adv, err := downlink.GetAdvisory(node, qi)
+	if err != nil {
+		log.WithField("dev_eui", node.DevEUI).Errorf("get downlink advisory error: %s", err)
+		return &resp, nil
+	}
+
+	resp.Advisory = &pb.DownlinkAdvisory{
+		EstimatedAirtime: uint32(adv.Airtime / time.Millisecond),
+		QueuePosition:    uint32(adv.QueuePosition),
+		WaitForUplink:    adv.WaitForUplink,
+		TransmitAfter:    adv.TransmitAfter.Format(time.RFC3339),
+		DutyCycle:        adv.DutyCycle * 100,
+	}
+	return &resp, nil
 }
 
 func (d *DownlinkQueueAPI) Delete(ctx context.Context, req *pb.DeleteDownlinkQeueueItemRequest) (*pb.DeleteDownlinkQueueItemResponse, error) {
@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"testing"
+	"time"
 
 	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/airtime"
 	"github.com/brocaar/lora-app-server/internal/common"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
@@ -21,6 +23,8 @@ func TestDownlinkQueueAPI(t *testing.T) {
 		So(err, ShouldBeNil)
 		common.DB = db
 		test.MustResetDB(common.DB)
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
 
 		nsClient := test.NewNetworkServerClient()
 		common.NetworkServer = nsClient
@@ -154,6 +158,30 @@ func TestDownlinkQueueAPI(t *testing.T) {
 				So(nsClient.PushDataDownChan, ShouldHaveLength, 0)
 			})
 
+			Convey("When enqueueing a second item with a duty-cycle restricted band", func() {
+				So(airtime.SetBand("EU_863_870"), ShouldBeNil)
+				defer airtime.SetBand("")
+
+				resp, err := api.Enqueue(ctx, &pb.EnqueueDownlinkQueueItemRequest{
+					DevEUI: node.DevEUI.String(),
+					FPort:  10,
+					Data:   []byte{1, 2, 3, 4},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the advisory contains the queue position and the duty-cycle off-period", func() {
+					So(resp.Advisory, ShouldNotBeNil)
+					So(resp.Advisory.QueuePosition, ShouldEqual, 2)
+					So(resp.Advisory.WaitForUplink, ShouldBeTrue)
+					So(resp.Advisory.DutyCycle, ShouldEqual, 1)
+					So(resp.Advisory.EstimatedAirtime, ShouldEqual, 1155)
+
+					transmitAfter, err := time.Parse(time.RFC3339, resp.Advisory.TransmitAfter)
+					So(err, ShouldBeNil)
+					So(transmitAfter.After(time.Now().Add(time.Minute)), ShouldBeTrue)
+				})
+			})
+
 			Convey("When removing the queue item", func() {
 				_, err := api.Delete(ctx, &pb.DeleteDownlinkQeueueItemRequest{
 					DevEUI: node.DevEUI.String(),
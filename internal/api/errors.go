@@ -1,7 +1,20 @@
 package api
 
 import (
+	"github.com/brocaar/lora-app-server/internal/downlink"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/amqphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/azurehandler"
+	"github.com/brocaar/lora-app-server/internal/handler/elasticsearchhandler"
 	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/handler/mydeviceshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/postgresqlhandler"
+	"github.com/brocaar/lora-app-server/internal/handler/pubsubhandler"
+	"github.com/brocaar/lora-app-server/internal/handler/snshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/sysloghandler"
+	"github.com/brocaar/lora-app-server/internal/handler/thingsboardhandler"
+	"github.com/brocaar/lora-app-server/internal/residency"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -9,16 +22,96 @@ import (
 )
 
 var errToCode = map[error]codes.Code{
-	storage.ErrAlreadyExists:             codes.AlreadyExists,
-	storage.ErrDoesNotExist:              codes.NotFound,
-	storage.ErrApplicationInvalidName:    codes.InvalidArgument,
-	storage.ErrNodeInvalidName:           codes.InvalidArgument,
-	storage.ErrNodeMaxRXDelay:            codes.InvalidArgument,
-	storage.ErrCFListTooManyChannels:     codes.InvalidArgument,
-	storage.ErrUserInvalidUsername:       codes.InvalidArgument,
-	storage.ErrUserPasswordLength:        codes.InvalidArgument,
-	storage.ErrInvalidUsernameOrPassword: codes.Unauthenticated,
-	httphandler.ErrInvalidHeaderName:     codes.InvalidArgument,
+	storage.ErrAlreadyExists:                          codes.AlreadyExists,
+	storage.ErrDoesNotExist:                           codes.NotFound,
+	storage.ErrRevisionMismatch:                       codes.FailedPrecondition,
+	storage.ErrApplicationInvalidName:                 codes.InvalidArgument,
+	storage.ErrApplicationInvalidEnvironment:          codes.InvalidArgument,
+	storage.ErrApplicationInvalidResidencyRegion:      codes.InvalidArgument,
+	storage.ErrApplicationInvalidMQTTBufferTTL:        codes.InvalidArgument,
+	residency.ErrUnknownRegion:                        codes.InvalidArgument,
+	storage.ErrNodeInvalidAlias:                       codes.InvalidArgument,
+	storage.ErrNodeRetired:                            codes.FailedPrecondition,
+	storage.ErrNodeInvalidName:                        codes.InvalidArgument,
+	storage.ErrNodeMaxRXDelay:                         codes.InvalidArgument,
+	storage.ErrNodeInvalidTag:                         codes.InvalidArgument,
+	storage.ErrNodeInvalidVariableName:                codes.InvalidArgument,
+	storage.ErrNodeTagsRequired:                       codes.InvalidArgument,
+	storage.ErrNodeFilterInvalidNotSeenHours:          codes.InvalidArgument,
+	storage.ErrNodeFilterInvalidName:                  codes.InvalidArgument,
+	storage.ErrCFListTooManyChannels:                  codes.InvalidArgument,
+	storage.ErrUserInvalidUsername:                    codes.InvalidArgument,
+	storage.ErrUserPasswordLength:                     codes.InvalidArgument,
+	storage.ErrInvalidUsernameOrPassword:              codes.Unauthenticated,
+	storage.ErrGatewayFilterInvalidMode:               codes.InvalidArgument,
+	storage.ErrDigestInvalidFrequency:                 codes.InvalidArgument,
+	storage.ErrDigestInvalidWebhookURL:                codes.InvalidArgument,
+	storage.ErrChaosInvalidFailureRate:                codes.InvalidArgument,
+	storage.ErrChaosInvalidLatency:                    codes.InvalidArgument,
+	storage.ErrChaosInvalidUntil:                      codes.InvalidArgument,
+	storage.ErrIntegrationFilterInvalidEventType:      codes.InvalidArgument,
+	storage.ErrIntegrationFilterInvalidFPort:          codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidName:            codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidFPort:           codes.InvalidArgument,
+	storage.ErrDownlinkTemplateNoFields:               codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidFieldName:       codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidFieldType:       codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidRange:           codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidLength:          codes.InvalidArgument,
+	storage.ErrDownlinkTemplateInvalidConst:           codes.InvalidArgument,
+	storage.ErrDownlinkTemplateTooLarge:               codes.InvalidArgument,
+	storage.ErrElevationJustificationRequired:         codes.InvalidArgument,
+	storage.ErrElevationInvalidExpiresAt:              codes.InvalidArgument,
+	storage.ErrElevationInactive:                      codes.FailedPrecondition,
+	downlink.ErrAirtimeBudgetExceeded:                 codes.ResourceExhausted,
+	httphandler.ErrInvalidHeaderName:                  codes.InvalidArgument,
+	httphandler.ErrInvalidDevicePercentage:            codes.InvalidArgument,
+	httphandler.ErrBasicAuthUsernameRequired:          codes.InvalidArgument,
+	httphandler.ErrMultipleAuthMethods:                codes.InvalidArgument,
+	httphandler.ErrSecretKeyNotConfigured:             codes.FailedPrecondition,
+	httphandler.ErrInvalidMaxAttempts:                 codes.InvalidArgument,
+	httphandler.ErrInvalidMaxPayloadSize:              codes.InvalidArgument,
+	httphandler.ErrInvalidBatchSize:                   codes.InvalidArgument,
+	httphandler.ErrInvalidBatchInterval:               codes.InvalidArgument,
+	httphandler.ErrBatchingRequiresJSON:               codes.InvalidArgument,
+	httphandler.ErrInvalidCircuitBreaker:              codes.InvalidArgument,
+	httphandler.ErrCircuitBreakerMode:                 codes.InvalidArgument,
+	httphandler.ErrTLSCertKeyRequired:                 codes.InvalidArgument,
+	httphandler.ErrInvalidTLSCert:                     codes.InvalidArgument,
+	httphandler.ErrInvalidCACert:                      codes.InvalidArgument,
+	sysloghandler.ErrInvalidNetwork:                   codes.InvalidArgument,
+	sysloghandler.ErrInvalidServer:                    codes.InvalidArgument,
+	sysloghandler.ErrInvalidFacility:                  codes.InvalidArgument,
+	sysloghandler.ErrInvalidCACert:                    codes.InvalidArgument,
+	amqphandler.ErrInvalidURL:                         codes.InvalidArgument,
+	amqphandler.ErrInvalidExchange:                    codes.InvalidArgument,
+	amqphandler.ErrInvalidRoutingKeyTemplate:          codes.InvalidArgument,
+	amqphandler.ErrInvalidConnectMode:                 codes.InvalidArgument,
+	amqphandler.ErrInvalidKeepAlive:                   codes.InvalidArgument,
+	amqphandler.ErrInvalidMaxIdle:                     codes.InvalidArgument,
+	amqphandler.ErrSecretKeyNotConfigured:             codes.FailedPrecondition,
+	handler.ErrInvalidCustomEventName:                 codes.InvalidArgument,
+	marshaler.ErrUnknownMarshaler:                     codes.InvalidArgument,
+	postgresqlhandler.ErrInvalidDSN:                   codes.InvalidArgument,
+	postgresqlhandler.ErrSecretKeyNotConfigured:       codes.FailedPrecondition,
+	snshandler.ErrInvalidRegion:                       codes.InvalidArgument,
+	snshandler.ErrInvalidTopicARN:                     codes.InvalidArgument,
+	snshandler.ErrInvalidCredentials:                  codes.InvalidArgument,
+	snshandler.ErrInvalidMarshaler:                    codes.InvalidArgument,
+	snshandler.ErrSecretKeyNotConfigured:              codes.FailedPrecondition,
+	azurehandler.ErrInvalidConnectionString:           codes.InvalidArgument,
+	azurehandler.ErrInvalidQueueOrTopic:               codes.InvalidArgument,
+	azurehandler.ErrSecretKeyNotConfigured:            codes.FailedPrecondition,
+	pubsubhandler.ErrInvalidCredentials:               codes.InvalidArgument,
+	pubsubhandler.ErrInvalidProjectID:                 codes.InvalidArgument,
+	pubsubhandler.ErrInvalidTopicName:                 codes.InvalidArgument,
+	pubsubhandler.ErrSecretKeyNotConfigured:           codes.FailedPrecondition,
+	thingsboardhandler.ErrInvalidServer:               codes.InvalidArgument,
+	mydeviceshandler.ErrInvalidEndpoint:               codes.InvalidArgument,
+	elasticsearchhandler.ErrInvalidServer:             codes.InvalidArgument,
+	elasticsearchhandler.ErrInvalidIndexPattern:       codes.InvalidArgument,
+	elasticsearchhandler.ErrBasicAuthUsernameRequired: codes.InvalidArgument,
+	elasticsearchhandler.ErrSecretKeyNotConfigured:    codes.FailedPrecondition,
 }
 
 func errToRPCError(err error) error {
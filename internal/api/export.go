@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// exportFlushInterval defines after how many records the response is
+// flushed to the client.
+const exportFlushInterval = 100
+
+// NodeExportHandler implements a http.Handler which streams all nodes of
+// an application as newline delimited JSON (NDJSON), one node per line.
+// Nodes are written while they are read from the database, so a slow
+// client slows down the reading (instead of the result-set being buffered).
+type NodeExportHandler struct {
+	validator auth.Validator
+}
+
+// NewNodeExportHandler creates a new NodeExportHandler.
+func NewNodeExportHandler(validator auth.Validator) *NodeExportHandler {
+	return &NodeExportHandler{
+		validator: validator,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *NodeExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := strconv.ParseInt(mux.Vars(r)["applicationID"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := getContextFromHTTPRequest(r)
+	if err := h.validator.Validate(ctx,
+		auth.ValidateNodesAccess(applicationID, auth.List)); err != nil {
+		http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	marshaler := jsonpb.Marshaler{EmitDefaults: true}
+
+	var count int
+	err = storage.StreamNodesForApplicationID(common.DB, applicationID, func(node storage.Node) error {
+		// stop reading from the database when the client went away
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		item := pb.GetNodeResponse{
+			Name:                   node.Name,
+			Description:            node.Description,
+			DevEUI:                 node.DevEUI.String(),
+			AppEUI:                 node.AppEUI.String(),
+			AppKey:                 node.AppKey.String(),
+			IsABP:                  node.IsABP,
+			IsClassC:               node.IsClassC,
+			RxDelay:                uint32(node.RXDelay),
+			Rx1DROffset:            uint32(node.RX1DROffset),
+			RxWindow:               pb.RXWindow(node.RXWindow),
+			Rx2DR:                  uint32(node.RX2DR),
+			RelaxFCnt:              node.RelaxFCnt,
+			AdrInterval:            node.ADRInterval,
+			InstallationMargin:     node.InstallationMargin,
+			ApplicationID:          node.ApplicationID,
+			UseApplicationSettings: node.UseApplicationSettings,
+		}
+
+		if err := marshaler.Marshal(w, &item); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte("\n")); err != nil {
+			return err
+		}
+
+		count++
+		if flusher != nil && count%exportFlushInterval == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// the status-code has already been sent at this point, the client
+		// will notice the truncated response
+		log.WithFields(log.Fields{
+			"application_id": applicationID,
+			"count":          count,
+		}).Errorf("export nodes error: %s", err)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"application_id": applicationID,
+		"count":          count,
+	}).Info("nodes exported")
+}
+
+// getContextFromHTTPRequest returns the context of the given request,
+// holding the authorization metadata in the same way as it would have been
+// forwarded by the grpc-gateway.
+func getContextFromHTTPRequest(r *http.Request) context.Context {
+	token := r.Header.Get("Grpc-Metadata-Authorization")
+	if token == "" {
+		token = r.Header.Get("Authorization")
+	}
+	return metadata.NewIncomingContext(r.Context(), metadata.Pairs("authorization", token))
+}
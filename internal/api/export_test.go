@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestNodeExportHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application, nodes and an export handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		validator := &TestValidator{}
+		r := mux.NewRouter()
+		r.Handle("/api/applications/{applicationID}/nodes/export", NewNodeExportHandler(validator))
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		for i := 1; i <= 3; i++ {
+			So(storage.CreateNode(common.DB, storage.Node{
+				ApplicationID: app.ID,
+				Name:          fmt.Sprintf("test-node-%d", i),
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, byte(i)},
+			}), ShouldBeNil)
+		}
+
+		Convey("When requesting the export", func() {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/applications/%d/nodes/export", app.ID), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			Convey("Then the nodes are returned as NDJSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/x-ndjson")
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				var nodes []pb.GetNodeResponse
+				scanner := bufio.NewScanner(w.Body)
+				for scanner.Scan() {
+					var node pb.GetNodeResponse
+					So(jsonpb.UnmarshalString(scanner.Text(), &node), ShouldBeNil)
+					nodes = append(nodes, node)
+				}
+				So(nodes, ShouldHaveLength, 3)
+				So(nodes[0].Name, ShouldEqual, "test-node-1")
+				So(nodes[2].DevEUI, ShouldEqual, "0102030405060703")
+			})
+		})
+
+		Convey("When the validator returns an error", func() {
+			validator.returnError = fmt.Errorf("boom")
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/applications/%d/nodes/export", app.ID), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
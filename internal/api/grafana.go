@@ -0,0 +1,298 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/linkquality"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+var errGrafanaInvalidQuery = errors.New("invalid annotation query")
+
+// grafanaMetrics contains the link-quality metrics exposed to Grafana.
+var grafanaMetrics = map[string]func(storage.LinkQuality) float64{
+	"uplinks":         func(lq storage.LinkQuality) float64 { return float64(lq.Uplinks) },
+	"missed":          func(lq storage.LinkQuality) float64 { return float64(lq.Missed) },
+	"retransmissions": func(lq storage.LinkQuality) float64 { return float64(lq.Retransmissions) },
+	"snrMargin":       linkquality.SNRMargin,
+	"score":           linkquality.Score,
+}
+
+// GrafanaHandler implements a http.Handler serving the link-quality metrics
+// of the nodes of an application as Grafana (simple JSON) datasource. The
+// metrics are exposed as targets in the format [node name].[metric], e.g.
+// garden-sensor.score.
+//
+// Annotations are generated for the buckets of which a metric crosses the
+// threshold given by the annotation query in the format
+// [node name.]metric [<|>] value, e.g. score < 50.
+type GrafanaHandler struct {
+	validator auth.Validator
+}
+
+// NewGrafanaHandler creates a new GrafanaHandler.
+func NewGrafanaHandler(validator auth.Validator) *GrafanaHandler {
+	return &GrafanaHandler{
+		validator: validator,
+	}
+}
+
+type grafanaRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type grafanaQueryRequest struct {
+	Range   grafanaRange `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+	} `json:"targets"`
+}
+
+type grafanaTimeSeries struct {
+	Target     string       `json:"target"`
+	DataPoints [][2]float64 `json:"datapoints"`
+}
+
+type grafanaAnnotationRequest struct {
+	Range      grafanaRange    `json:"range"`
+	Annotation json.RawMessage `json:"annotation"`
+}
+
+type grafanaAnnotation struct {
+	Annotation json.RawMessage `json:"annotation"`
+	Time       int64           `json:"time"`
+	Title      string          `json:"title"`
+	Text       string          `json:"text"`
+	Tags       []string        `json:"tags"`
+}
+
+// grafanaThreshold defines an annotation query.
+type grafanaThreshold struct {
+	NodeName string
+	Metric   string
+	Above    bool
+	Value    float64
+}
+
+// matches returns true when the given value crosses the threshold.
+func (t grafanaThreshold) matches(v float64) bool {
+	if t.Above {
+		return v > t.Value
+	}
+	return v < t.Value
+}
+
+// String returns the threshold without node name, e.g. score < 50.
+func (t grafanaThreshold) String() string {
+	op := "<"
+	if t.Above {
+		op = ">"
+	}
+	return fmt.Sprintf("%s %s %g", t.Metric, op, t.Value)
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GrafanaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := strconv.ParseInt(mux.Vars(r)["applicationID"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := getContextFromHTTPRequest(r)
+	if err := h.validator.Validate(ctx,
+		auth.ValidateNodesAccess(applicationID, auth.List)); err != nil {
+		http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+		return
+	}
+
+	var resp interface{}
+	switch path.Base(r.URL.Path) {
+	case "grafana":
+		// used by Grafana to test the datasource
+		w.WriteHeader(http.StatusOK)
+		return
+	case "search":
+		resp, err = h.search(applicationID)
+	case "query":
+		var req grafanaQueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("decode request error: %s", err), http.StatusBadRequest)
+			return
+		}
+		resp, err = h.query(applicationID, req)
+	case "annotations":
+		var req grafanaAnnotationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("decode request error: %s", err), http.StatusBadRequest)
+			return
+		}
+		resp, err = h.annotations(applicationID, req)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		if errors.Cause(err) == errGrafanaInvalidQuery {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.WithField("application_id", applicationID).Errorf("grafana datasource error: %s", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorf("encode grafana response error: %s", err)
+	}
+}
+
+// search returns all available targets.
+func (h *GrafanaHandler) search(applicationID int64) ([]string, error) {
+	var metrics []string
+	for m := range grafanaMetrics {
+		metrics = append(metrics, m)
+	}
+	sort.Strings(metrics)
+
+	targets := []string{}
+	err := storage.StreamNodesForApplicationID(common.DB, applicationID, func(node storage.Node) error {
+		for _, m := range metrics {
+			targets = append(targets, node.Name+"."+m)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "get nodes error")
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// query returns the time-series for the requested targets.
+func (h *GrafanaHandler) query(applicationID int64, req grafanaQueryRequest) ([]grafanaTimeSeries, error) {
+	lqs, err := storage.GetLinkQualityHistoryForApplicationID(common.DB, applicationID, req.Range.From, req.Range.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "get link-quality history error")
+	}
+
+	out := []grafanaTimeSeries{}
+	for _, t := range req.Targets {
+		i := strings.LastIndex(t.Target, ".")
+		if i == -1 {
+			continue
+		}
+		nodeName, metric := t.Target[:i], t.Target[i+1:]
+		fn, ok := grafanaMetrics[metric]
+		if !ok {
+			continue
+		}
+
+		ts := grafanaTimeSeries{
+			Target:     t.Target,
+			DataPoints: [][2]float64{},
+		}
+		for _, lq := range lqs {
+			if lq.Name != nodeName {
+				continue
+			}
+			ts.DataPoints = append(ts.DataPoints, [2]float64{fn(lq.LinkQuality), float64(grafanaTimestamp(lq.Bucket))})
+		}
+		out = append(out, ts)
+	}
+
+	return out, nil
+}
+
+// annotations returns an annotation for each bucket of which the metric
+// crosses the threshold of the annotation query.
+func (h *GrafanaHandler) annotations(applicationID int64, req grafanaAnnotationRequest) ([]grafanaAnnotation, error) {
+	var annotation struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(req.Annotation, &annotation); err != nil {
+		return nil, errors.Wrap(errGrafanaInvalidQuery, err.Error())
+	}
+	threshold, err := parseGrafanaThreshold(annotation.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	lqs, err := storage.GetLinkQualityHistoryForApplicationID(common.DB, applicationID, req.Range.From, req.Range.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "get link-quality history error")
+	}
+
+	out := []grafanaAnnotation{}
+	for _, lq := range lqs {
+		if threshold.NodeName != "" && lq.Name != threshold.NodeName {
+			continue
+		}
+
+		v := grafanaMetrics[threshold.Metric](lq.LinkQuality)
+		if !threshold.matches(v) {
+			continue
+		}
+
+		out = append(out, grafanaAnnotation{
+			Annotation: req.Annotation,
+			Time:       grafanaTimestamp(lq.Bucket),
+			Title:      fmt.Sprintf("%s: %s", lq.Name, threshold),
+			Text:       fmt.Sprintf("%s of %s is %.2f", threshold.Metric, lq.Name, v),
+			Tags:       []string{lq.Name, threshold.Metric},
+		})
+	}
+
+	return out, nil
+}
+
+// parseGrafanaThreshold parses the given annotation query in the format
+// [node name.]metric [<|>] value.
+func parseGrafanaThreshold(q string) (grafanaThreshold, error) {
+	var t grafanaThreshold
+
+	i := strings.IndexAny(q, "<>")
+	if i == -1 {
+		return t, errors.Wrap(errGrafanaInvalidQuery, "expected < or > operator")
+	}
+	t.Above = q[i] == '>'
+
+	target := strings.TrimSpace(q[:i])
+	if j := strings.LastIndex(target, "."); j != -1 {
+		t.NodeName, t.Metric = target[:j], target[j+1:]
+	} else {
+		t.Metric = target
+	}
+	if _, ok := grafanaMetrics[t.Metric]; !ok {
+		return t, errors.Wrapf(errGrafanaInvalidQuery, "unknown metric %s", t.Metric)
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(q[i+1:]), 64)
+	if err != nil {
+		return t, errors.Wrapf(errGrafanaInvalidQuery, "invalid value: %s", err)
+	}
+	t.Value = v
+
+	return t, nil
+}
+
+// grafanaTimestamp returns the given time as milliseconds since epoch.
+func grafanaTimestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestParseGrafanaThreshold(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Query         string
+			Expected      grafanaThreshold
+			ExpectedError error
+		}{
+			{"score < 50", grafanaThreshold{Metric: "score", Value: 50}, nil},
+			{"garden-sensor.missed>2", grafanaThreshold{NodeName: "garden-sensor", Metric: "missed", Above: true, Value: 2}, nil},
+			{"score = 50", grafanaThreshold{}, errGrafanaInvalidQuery},
+			{"foo < 50", grafanaThreshold{}, errGrafanaInvalidQuery},
+			{"score < bar", grafanaThreshold{}, errGrafanaInvalidQuery},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Query, func() {
+				threshold, err := parseGrafanaThreshold(test.Query)
+				So(errors.Cause(err), ShouldEqual, test.ExpectedError)
+				if err == nil {
+					So(threshold, ShouldResemble, test.Expected)
+				}
+			})
+		}
+	})
+}
+
+func TestGrafanaHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a node having link-quality metrics and a grafana handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		validator := &TestValidator{}
+		r := mux.NewRouter()
+		r.PathPrefix("/api/applications/{applicationID}/grafana").Handler(NewGrafanaHandler(validator))
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+		node := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateNode(common.DB, node), ShouldBeNil)
+
+		bucket := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+		So(storage.AddLinkQuality(common.DB, storage.LinkQuality{
+			DevEUI:  node.DevEUI,
+			Bucket:  bucket,
+			Uplinks: 8,
+			Missed:  2,
+		}), ShouldBeNil)
+
+		post := func(endpoint, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/applications/%d/grafana/%s", app.ID, endpoint), strings.NewReader(body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			return w
+		}
+		rangeJSON := `"range": {"from": "2017-10-01T00:00:00Z", "to": "2017-10-02T00:00:00Z"}`
+
+		Convey("When searching the targets", func() {
+			w := post("search", `{"target": ""}`)
+
+			Convey("Then the metrics of the node are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var targets []string
+				So(json.NewDecoder(w.Body).Decode(&targets), ShouldBeNil)
+				So(targets, ShouldContain, "test-node.uplinks")
+				So(targets, ShouldContain, "test-node.score")
+			})
+		})
+
+		Convey("When querying a target", func() {
+			w := post("query", `{`+rangeJSON+`, "targets": [{"target": "test-node.missed"}]}`)
+
+			Convey("Then the time-series is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var series []grafanaTimeSeries
+				So(json.NewDecoder(w.Body).Decode(&series), ShouldBeNil)
+				So(series, ShouldResemble, []grafanaTimeSeries{
+					{Target: "test-node.missed", DataPoints: [][2]float64{{2, float64(grafanaTimestamp(bucket))}}},
+				})
+			})
+		})
+
+		Convey("When requesting the annotations", func() {
+			w := post("annotations", `{`+rangeJSON+`, "annotation": {"name": "missed", "query": "missed > 1"}}`)
+
+			Convey("Then an annotation is returned for the bucket", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var annotations []grafanaAnnotation
+				So(json.NewDecoder(w.Body).Decode(&annotations), ShouldBeNil)
+				So(annotations, ShouldHaveLength, 1)
+				So(annotations[0].Time, ShouldEqual, grafanaTimestamp(bucket))
+				So(annotations[0].Title, ShouldEqual, "test-node: missed > 1")
+			})
+		})
+
+		Convey("When requesting the annotations with an invalid query", func() {
+			w := post("annotations", `{`+rangeJSON+`, "annotation": {"query": "foo"}}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/brocaar/lora-app-server/internal/i18n"
+)
+
+// acceptLanguageMetadataKeys contains the metadata keys containing the
+// Accept-Language value, when called directly or through the REST gateway.
+var acceptLanguageMetadataKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+// I18nUnaryServerInterceptor implements a grpc.UnaryServerInterceptor which
+// translates the message of the returned error into the language requested
+// by the Accept-Language header (or metadata).
+func I18nUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	lang := i18n.Match(acceptLanguageFromContext(ctx))
+	if lang == i18n.DefaultLanguage {
+		return resp, err
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return resp, err
+	}
+	return resp, status.Error(s.Code(), i18n.TranslateError(lang, s.Message()))
+}
+
+// ChainUnaryServerInterceptors returns a grpc.UnaryServerInterceptor
+// executing the given interceptors in order (the first one being the
+// outermost).
+func ChainUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		h := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], h
+			h = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return h(ctx, req)
+	}
+}
+
+func acceptLanguageFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range acceptLanguageMetadataKeys {
+		if values := md[key]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// UIStringsResponse contains the UI strings of a language.
+type UIStringsResponse struct {
+	Language  string            `json:"language"`
+	Languages []string          `json:"languages"`
+	Strings   map[string]string `json:"strings"`
+}
+
+// UIStringsHandler implements a http.Handler serving the localized UI
+// strings. The language is selected by the lang query parameter or else by
+// the Accept-Language header. As these strings are needed before login, the
+// handler does not require authentication.
+type UIStringsHandler struct{}
+
+// NewUIStringsHandler creates a new UIStringsHandler.
+func NewUIStringsHandler() *UIStringsHandler {
+	return &UIStringsHandler{}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *UIStringsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	acceptLanguage := r.URL.Query().Get("lang")
+	if acceptLanguage == "" {
+		acceptLanguage = r.Header.Get("Accept-Language")
+	}
+	lang := i18n.Match(acceptLanguage)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("Vary", "Accept-Language")
+	if err := json.NewEncoder(w).Encode(UIStringsResponse{
+		Language:  lang,
+		Languages: i18n.Languages(),
+		Strings:   i18n.UIStrings(lang),
+	}); err != nil {
+		log.Errorf("encode ui strings error: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/brocaar/lora-app-server/internal/i18n"
+)
+
+func TestI18n(t *testing.T) {
+	Convey("Given a German catalog", t, func() {
+		i18n.SetCatalogs(map[string]i18n.Catalog{
+			"de": {
+				Errors: map[string]string{
+					"authentication failed": "Authentifizierung fehlgeschlagen",
+				},
+				UI: map[string]string{
+					"login.username": "Benutzername",
+				},
+			},
+		})
+		defer i18n.SetCatalogs(nil)
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: not authenticated")
+		}
+
+		Convey("When calling a method returning an error with Accept-Language de", func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("grpcgateway-accept-language", "de-DE, en;q=0.5"))
+			_, err := I18nUnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+			Convey("Then the error message is translated", func() {
+				So(grpc.Code(err), ShouldEqual, codes.Unauthenticated)
+				So(grpc.ErrorDesc(err), ShouldEqual, "Authentifizierung fehlgeschlagen: not authenticated")
+			})
+		})
+
+		Convey("When calling a method returning an error without Accept-Language", func() {
+			_, err := I18nUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+			Convey("Then the error message is not translated", func() {
+				So(grpc.ErrorDesc(err), ShouldEqual, "authentication failed: not authenticated")
+			})
+		})
+
+		Convey("When requesting the ui strings with Accept-Language de", func() {
+			req := httptest.NewRequest("GET", "/api/i18n", nil)
+			req.Header.Set("Accept-Language", "de")
+			rec := httptest.NewRecorder()
+			NewUIStringsHandler().ServeHTTP(rec, req)
+
+			Convey("Then the German strings are returned", func() {
+				var resp UIStringsResponse
+				So(json.NewDecoder(rec.Body).Decode(&resp), ShouldBeNil)
+				So(rec.Header().Get("Content-Language"), ShouldEqual, "de")
+				So(resp, ShouldResemble, UIStringsResponse{
+					Language:  "de",
+					Languages: []string{"en", "de"},
+					Strings:   map[string]string{"login.username": "Benutzername"},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+)
+
+// IdempotencyKeyHeader defines the (HTTP) header / gRPC metadata key
+// holding the idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	idempotencyMetadataKey = "idempotency-key"
+	idempotencyKeyTempl    = "idempotency:%s:%s:%s"
+	idempotencyPendingTTL  = time.Minute
+	idempotencyMaxKeyBytes = 255
+)
+
+// IdempotencyKeyTTL defines how long the result of a request is stored
+// for the given idempotency key.
+var IdempotencyKeyTTL = 24 * time.Hour
+
+// idempotentMethods contains the (full) gRPC method names supporting the
+// idempotency key.
+var idempotentMethods = map[string]bool{
+	"/api.Application/Create":    true,
+	"/api.DownlinkQueue/Enqueue": true,
+	"/api.Gateway/Create":        true,
+	"/api.Node/Create":           true,
+	"/api.Node/CreateBatch":      true,
+	"/api.Organization/Create":   true,
+	"/api.User/Create":           true,
+}
+
+// idempotencyResult contains the stored result of a request. While the
+// request is in progress, only the request hash is set.
+type idempotencyResult struct {
+	RequestHash string `json:"requestHash"`
+	Type        string `json:"type,omitempty"`
+	Data        []byte `json:"data,omitempty"`
+}
+
+// IdempotencyUnaryServerInterceptor implements a grpc.UnaryServerInterceptor
+// which stores the response of successful create / enqueue requests under
+// the given idempotency key. When the same request is retried with the same
+// key, the stored response is returned instead of executing the request
+// again. Results are scoped by method and authorization token. Reusing a key
+// for a different request returns a FailedPrecondition error.
+func IdempotencyUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !idempotentMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+	keys := md[idempotencyMetadataKey]
+	if len(keys) == 0 || keys[0] == "" {
+		return handler(ctx, req)
+	}
+	if len(keys[0]) > idempotencyMaxKeyBytes {
+		return nil, grpc.Errorf(codes.InvalidArgument, "idempotency key exceeds max length of %d bytes", idempotencyMaxKeyBytes)
+	}
+
+	var token string
+	if tokens := md["authorization"]; len(tokens) > 0 {
+		token = tokens[0]
+	}
+	tokenHash := sha256.Sum256([]byte(token))
+	key := common.RedisKey(idempotencyKeyTempl, info.FullMethod, hex.EncodeToString(tokenHash[:]), keys[0])
+
+	requestHash, err := idempotencyRequestHash(req)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp, err := getIdempotencyResult(key, requestHash)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		log.WithFields(log.Fields{
+			"method":          info.FullMethod,
+			"idempotency_key": keys[0],
+		}).Info("returning stored response for idempotency key")
+		return resp, nil
+	}
+
+	resp, err = handler(ctx, req)
+	if err != nil {
+		// remove the pending state so that the request can be retried
+		if delErr := deleteIdempotencyResult(key); delErr != nil {
+			log.Errorf("delete idempotency key error: %s", delErr)
+		}
+		return resp, err
+	}
+
+	if err := storeIdempotencyResult(key, requestHash, resp); err != nil {
+		log.Errorf("store idempotency key error: %s", err)
+	}
+
+	return resp, nil
+}
+
+// idempotencyRequestHash returns the hash of the given (JSON encoded)
+// request, so that a key reused for a different request can be detected.
+func idempotencyRequestHash(req interface{}) (string, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal request error")
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// getIdempotencyResult returns the stored response for the given key.
+// In case no response is stored, the key is marked as pending and nil is
+// returned. A FailedPrecondition error is returned when the key was used for
+// a request with a different hash and an Aborted error when the key is
+// still pending.
+func getIdempotencyResult(key, requestHash string) (interface{}, error) {
+	pending, err := json.Marshal(idempotencyResult{RequestHash: requestHash})
+	if err != nil {
+		return nil, errToRPCError(errors.Wrap(err, "marshal idempotency result error"))
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	_, err = redis.String(c.Do("SET", key, pending, "PX", int64(idempotencyPendingTTL/time.Millisecond), "NX"))
+	if err == nil {
+		return nil, nil
+	}
+	if err != redis.ErrNil {
+		return nil, errToRPCError(errors.Wrap(err, "set idempotency key error"))
+	}
+
+	b, err := redis.Bytes(c.Do("GET", key))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the key expired in the meantime
+			return getIdempotencyResult(key, requestHash)
+		}
+		return nil, errToRPCError(errors.Wrap(err, "get idempotency key error"))
+	}
+	if len(b) == 0 {
+		// pending key stored without request hash
+		return nil, grpc.Errorf(codes.Aborted, "a request with the same idempotency key is in progress")
+	}
+
+	var result idempotencyResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, errToRPCError(errors.Wrap(err, "unmarshal idempotency result error"))
+	}
+	if result.RequestHash != "" && result.RequestHash != requestHash {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "the idempotency key was used for a different request")
+	}
+	if result.Type == "" {
+		return nil, grpc.Errorf(codes.Aborted, "a request with the same idempotency key is in progress")
+	}
+
+	t := proto.MessageType(result.Type)
+	if t == nil {
+		return nil, grpc.Errorf(codes.Internal, "unknown message type: %s", result.Type)
+	}
+	msg, ok := reflect.New(t.Elem()).Interface().(proto.Message)
+	if !ok {
+		return nil, grpc.Errorf(codes.Internal, "invalid message type: %s", result.Type)
+	}
+	if err := proto.Unmarshal(result.Data, msg); err != nil {
+		return nil, errToRPCError(errors.Wrap(err, "unmarshal idempotency response error"))
+	}
+
+	return msg, nil
+}
+
+// storeIdempotencyResult stores the given response (of the request with the
+// given hash) under the given key.
+func storeIdempotencyResult(key, requestHash string, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("expected proto.Message, got %T", resp)
+	}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal response error")
+	}
+	b, err := json.Marshal(idempotencyResult{
+		RequestHash: requestHash,
+		Type:        proto.MessageName(msg),
+		Data:        data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal idempotency result error")
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	_, err = c.Do("PSETEX", key, int64(IdempotencyKeyTTL/time.Millisecond), b)
+	if err != nil {
+		return errors.Wrap(err, "set idempotency key error")
+	}
+	return nil
+}
+
+// deleteIdempotencyResult deletes the given key.
+func deleteIdempotencyResult(key string) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", key)
+	if err != nil {
+		return errors.Wrap(err, "delete idempotency key error")
+	}
+	return nil
+}
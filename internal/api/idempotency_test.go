@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIdempotencyUnaryServerInterceptor(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a handler counting its calls", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		var calls int
+		var handlerErr error
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			if handlerErr != nil {
+				return nil, handlerErr
+			}
+			return &pb.CreateApplicationResponse{Id: int64(calls)}, nil
+		}
+		info := grpc.UnaryServerInfo{FullMethod: "/api.Application/Create"}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"authorization", "token",
+			"idempotency-key", "abc123",
+		))
+
+		Convey("When calling the same request twice with the same idempotency key", func() {
+			resp1, err := IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+			So(err, ShouldBeNil)
+			resp2, err := IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+			So(err, ShouldBeNil)
+
+			Convey("Then the handler was called once and the stored response is returned", func() {
+				So(calls, ShouldEqual, 1)
+				So(resp2, ShouldResemble, resp1)
+			})
+		})
+
+		Convey("When calling a different request with the same idempotency key", func() {
+			_, err := IdempotencyUnaryServerInterceptor(ctx, &pb.CreateApplicationRequest{Name: "app-1"}, &info, handler)
+			So(err, ShouldBeNil)
+			_, err = IdempotencyUnaryServerInterceptor(ctx, &pb.CreateApplicationRequest{Name: "app-2"}, &info, handler)
+
+			Convey("Then a FailedPrecondition error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When calling the same request with a different authorization token", func() {
+			_, err := IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+			So(err, ShouldBeNil)
+			ctx2 := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				"authorization", "other-token",
+				"idempotency-key", "abc123",
+			))
+			_, err = IdempotencyUnaryServerInterceptor(ctx2, nil, &info, handler)
+			So(err, ShouldBeNil)
+
+			Convey("Then the handler was called twice", func() {
+				So(calls, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the first request returns an error", func() {
+			handlerErr = errors.New("boom")
+			_, err := IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+			So(err, ShouldNotBeNil)
+
+			Convey("Then a retry executes the handler again", func() {
+				handlerErr = nil
+				resp, err := IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+				So(err, ShouldBeNil)
+				So(calls, ShouldEqual, 2)
+				So(resp, ShouldResemble, &pb.CreateApplicationResponse{Id: 2})
+			})
+		})
+
+		Convey("When calling a method not supporting idempotency keys", func() {
+			info.FullMethod = "/api.Application/Update"
+			_, err := IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+			So(err, ShouldBeNil)
+			_, err = IdempotencyUnaryServerInterceptor(ctx, nil, &info, handler)
+			So(err, ShouldBeNil)
+
+			Convey("Then the handler was called twice", func() {
+				So(calls, ShouldEqual, 2)
+			})
+		})
+	})
+}
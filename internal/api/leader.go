@@ -0,0 +1,45 @@
+package api
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/leader"
+)
+
+// GetLeaderElections returns the leader election status of the background
+// jobs.
+func (a *InternalUserAPI) GetLeaderElections(ctx context.Context, req *pb.GetLeaderElectionsRequest) (*pb.GetLeaderElectionsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	status, err := leader.GetStatus()
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetLeaderElectionsResponse{
+		InstanceID: leader.InstanceID,
+		Result:     make([]*pb.LeaderElection, 0, len(status)),
+	}
+	for _, s := range status {
+		e := pb.LeaderElection{
+			Job:      s.Job,
+			LeaderID: s.LeaderID,
+			IsLeader: s.IsLeader,
+		}
+		if s.ExpiresAt != nil {
+			e.ExpiresAt = s.ExpiresAt.Format(time.RFC3339)
+		}
+		resp.Result = append(resp.Result, &e)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/leader"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestLeaderElectionAPI(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and api instance", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		ctx := context.Background()
+		validator := &TestValidator{}
+		api := NewInternalUserAPI(validator)
+
+		Convey("Given this instance joined the election of a job", func() {
+			So(leader.IsLeader("test-api-job"), ShouldBeTrue)
+
+			Convey("Then GetLeaderElections returns this instance as leader", func() {
+				resp, err := api.GetLeaderElections(ctx, &pb.GetLeaderElectionsRequest{})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+				So(resp.InstanceID, ShouldEqual, leader.InstanceID)
+
+				var found bool
+				for _, e := range resp.Result {
+					if e.Job == "test-api-job" {
+						found = true
+						So(e.LeaderID, ShouldEqual, leader.InstanceID)
+						So(e.IsLeader, ShouldBeTrue)
+						So(e.ExpiresAt, ShouldNotEqual, "")
+					}
+				}
+				So(found, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler/mqtthandler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// mqttAuthRequest contains the parameters of a MQTT broker authentication
+// or ACL check request.
+type mqttAuthRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	ClientID string `json:"clientid"`
+	Topic    string `json:"topic"`
+	Acc      int    `json:"acc"`
+}
+
+// MQTTAuthHandler implements a http.Handler which is used by the MQTT
+// broker (e.g. using the mosquitto-go-auth HTTP backend) to authenticate
+// applications with their MQTT credentials and to isolate them to their
+// own topics. It serves the .../user, .../superuser and .../acl endpoints
+// and responds with 200 when access is granted and 403 otherwise. As this
+// handler does not require authentication, it must only be reachable by
+// the MQTT broker.
+type MQTTAuthHandler struct{}
+
+// NewMQTTAuthHandler creates a new MQTTAuthHandler.
+func NewMQTTAuthHandler() *MQTTAuthHandler {
+	return &MQTTAuthHandler{}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *MQTTAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, err := decodeMQTTAuthRequest(r)
+	if err != nil {
+		http.Error(w, "decode request error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var allowed bool
+	switch path.Base(r.URL.Path) {
+	case "user":
+		allowed, err = h.authenticate(req)
+	case "superuser":
+		// application credentials never grant superuser access
+	case "acl":
+		allowed, err = h.checkACL(req)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.WithField("username", req.Username).Errorf("mqtt auth error: %s", err)
+		http.Error(w, "mqtt auth error", http.StatusInternalServerError)
+		return
+	}
+
+	if !allowed {
+		http.Error(w, "access denied", http.StatusForbidden)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *MQTTAuthHandler) authenticate(req mqttAuthRequest) (bool, error) {
+	_, err := storage.AuthenticateApplicationMQTTCredentials(common.DB, req.Username, req.Password)
+	if err != nil {
+		if err == storage.ErrInvalidUsernameOrPassword {
+			log.WithFields(log.Fields{
+				"username":  req.Username,
+				"client_id": req.ClientID,
+			}).Warning("mqtt auth: invalid username or password")
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *MQTTAuthHandler) checkACL(req mqttAuthRequest) (bool, error) {
+	creds, err := storage.GetApplicationMQTTCredentialsByUsername(common.DB, req.Username)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return false, nil
+		}
+		return false, err
+	}
+	return mqtthandler.ApplicationTopicAllowed(creds.ApplicationID, req.Topic, req.Acc), nil
+}
+
+// decodeMQTTAuthRequest decodes the request parameters, which are either
+// posted as JSON or as form values.
+func decodeMQTTAuthRequest(r *http.Request) (mqttAuthRequest, error) {
+	var req mqttAuthRequest
+
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "application/json" {
+		err := json.NewDecoder(r.Body).Decode(&req)
+		return req, err
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return req, err
+	}
+	req.Username = r.PostForm.Get("username")
+	req.Password = r.PostForm.Get("password")
+	req.ClientID = r.PostForm.Get("clientid")
+	req.Topic = r.PostForm.Get("topic")
+	if acc := r.PostForm.Get("acc"); acc != "" {
+		var err error
+		if req.Acc, err = strconv.Atoi(acc); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
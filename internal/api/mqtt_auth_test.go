@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler/mqtthandler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestMQTTAuthHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application with mqtt credentials", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-organization",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		creds, password, err := storage.GenerateApplicationMQTTCredentials(db, app.ID)
+		So(err, ShouldBeNil)
+
+		authHandler := NewMQTTAuthHandler()
+		post := func(endpoint, body, contentType string) int {
+			req := httptest.NewRequest("POST", "/mqtt/"+endpoint, strings.NewReader(body))
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			authHandler.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		Convey("Then the application is authenticated with its credentials", func() {
+			So(post("user", `{"username": "`+creds.Username+`", "password": "`+password+`"}`, "application/json"), ShouldEqual, http.StatusOK)
+			So(post("user", `{"username": "`+creds.Username+`", "password": "invalid"}`, "application/json"), ShouldEqual, http.StatusForbidden)
+			So(post("user", url.Values{"username": {creds.Username}, "password": {password}}.Encode(), "application/x-www-form-urlencoded"), ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Then the application is not a superuser", func() {
+			So(post("superuser", `{"username": "`+creds.Username+`"}`, "application/json"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Then the application is isolated to its own topics", func() {
+			own := mqtthandler.ApplicationTopicPrefix(app.ID)
+			So(post("acl", `{"username": "`+creds.Username+`", "topic": "`+own+`#", "acc": 4}`, "application/json"), ShouldEqual, http.StatusOK)
+			So(post("acl", `{"username": "`+creds.Username+`", "topic": "application/+/node/+/rx", "acc": 4}`, "application/json"), ShouldEqual, http.StatusForbidden)
+			So(post("acl", `{"username": "`+creds.Username+`", "topic": "`+own+`node/0102030405060708/tx", "acc": 2}`, "application/json"), ShouldEqual, http.StatusOK)
+			So(post("acl", `{"username": "unknown", "topic": "`+own+`#", "acc": 4}`, "application/json"), ShouldEqual, http.StatusForbidden)
+		})
+	})
+}
@@ -3,20 +3,50 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/airtime"
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
+	"github.com/brocaar/lora-app-server/internal/lastvalue"
+	"github.com/brocaar/lora-app-server/internal/linkquality"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/lorawan"
 )
 
+// defaultNextEventTimeout and maxNextEventTimeout define the default and
+// max duration that GetNextEvent waits for an event.
+const (
+	defaultNextEventTimeout = 30 * time.Second
+	maxNextEventTimeout     = 300 * time.Second
+)
+
+// defaultLinkQualityHours and maxLinkQualityHours define the default and
+// max number of hours taken into account for the link-quality.
+const (
+	defaultLinkQualityHours = 24
+	maxLinkQualityHours     = 720
+)
+
+// maxNodeBatchSize defines the max number of nodes that can be created or
+// updated within a single batch request.
+const maxNodeBatchSize = 1000
+
 // NodeAPI exports the Node related functions.
 type NodeAPI struct {
 	validator auth.Validator
@@ -73,6 +103,8 @@ func (a *NodeAPI) Create(ctx context.Context, req *pb.CreateNodeRequest) (*pb.Cr
 
 		ADRInterval:        req.AdrInterval,
 		InstallationMargin: req.InstallationMargin,
+		Tags:               req.Tags,
+		Variables:          nodeVariablesFromPB(req.Variables),
 	}
 
 	if err := storage.CreateNode(common.DB, node); err != nil {
@@ -82,6 +114,86 @@ func (a *NodeAPI) Create(ctx context.Context, req *pb.CreateNodeRequest) (*pb.Cr
 	return &pb.CreateNodeResponse{}, nil
 }
 
+// CreateBatch creates the given nodes within a single transaction. The
+// result of each node is reported within the response.
+func (a *NodeAPI) CreateBatch(ctx context.Context, req *pb.CreateNodeBatchRequest) (*pb.CreateNodeBatchResponse, error) {
+	if len(req.Nodes) > maxNodeBatchSize {
+		return nil, grpc.Errorf(codes.InvalidArgument, "batch exceeds max size of %d nodes", maxNodeBatchSize)
+	}
+
+	var resp pb.CreateNodeBatchResponse
+	err := storage.Transaction(common.DB, func(tx *sqlx.Tx) error {
+		for i, nodeReq := range req.Nodes {
+			result := pb.NodeBatchResult{
+				DevEUI: nodeReq.DevEUI,
+			}
+
+			if err := a.createBatchNode(ctx, tx, i, nodeReq); err != nil {
+				setNodeBatchError(&result, err)
+			}
+			resp.Result = append(resp.Result, &result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+func (a *NodeAPI) createBatchNode(ctx context.Context, tx *sqlx.Tx, i int, req *pb.CreateNodeRequest) error {
+	var appEUI, devEUI lorawan.EUI64
+	var appKey lorawan.AES128Key
+
+	if err := appEUI.UnmarshalText([]byte(req.AppEUI)); err != nil {
+		return err
+	}
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return err
+	}
+	if err := appKey.UnmarshalText([]byte(req.AppKey)); err != nil {
+		return err
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.Create)); err != nil {
+		return fmt.Errorf("authentication failed: %s", err)
+	}
+
+	// if Name is "", set it to the DevEUI
+	if req.Name == "" {
+		req.Name = req.DevEUI
+	}
+
+	node := storage.Node{
+		ApplicationID:          req.ApplicationID,
+		UseApplicationSettings: req.UseApplicationSettings,
+		Name:                   req.Name,
+		Description:            req.Description,
+		DevEUI:                 devEUI,
+		AppEUI:                 appEUI,
+		AppKey:                 appKey,
+		IsABP:                  req.IsABP,
+		IsClassC:               req.IsClassC,
+		RelaxFCnt:              req.RelaxFCnt,
+
+		RXDelay:     uint8(req.RxDelay),
+		RX1DROffset: uint8(req.Rx1DROffset),
+		RXWindow:    storage.RXWindow(req.RxWindow),
+		RX2DR:       uint8(req.Rx2DR),
+
+		ADRInterval:        req.AdrInterval,
+		InstallationMargin: req.InstallationMargin,
+		Tags:               req.Tags,
+		Variables:          nodeVariablesFromPB(req.Variables),
+	}
+
+	return storage.Savepoint(tx, fmt.Sprintf("batch_node_%d", i), func() error {
+		return storage.CreateNode(tx, node)
+	})
+}
+
 // Get returns the Node for the given name.
 func (a *NodeAPI) Get(ctx context.Context, req *pb.GetNodeRequest) (*pb.GetNodeResponse, error) {
 	var eui lorawan.EUI64
@@ -116,7 +228,13 @@ func (a *NodeAPI) Get(ctx context.Context, req *pb.GetNodeRequest) (*pb.GetNodeR
 		InstallationMargin:     node.InstallationMargin,
 		ApplicationID:          node.ApplicationID,
 		UseApplicationSettings: node.UseApplicationSettings,
+		Tags:                   node.Tags,
+		Variables:              nodeVariablesToPB(node.Variables),
 	}
+	if node.RetiredAt != nil {
+		resp.RetiredAt = node.RetiredAt.Format(time.RFC3339Nano)
+	}
+	setETag(ctx, node.Revision)
 
 	return &resp, nil
 }
@@ -128,6 +246,22 @@ func (a *NodeAPI) ListByApplicationID(ctx context.Context, req *pb.ListNodeByApp
 		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
 	}
 
+	if req.FilterID != 0 {
+		filter, err := getSavedNodeFilterForApplication(req.ApplicationID, req.FilterID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		nodes, err := storage.GetNodesForFilter(common.DB, filter, int(req.Limit), int(req.Offset))
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		count, err := storage.GetNodesCountForFilter(common.DB, filter)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		return a.returnList(count, nodes)
+	}
+
 	nodes, err := storage.GetNodesForApplicationID(common.DB, req.ApplicationID, int(req.Limit), int(req.Offset))
 	if err != nil {
 		return nil, errToRPCError(err)
@@ -141,18 +275,23 @@ func (a *NodeAPI) ListByApplicationID(ctx context.Context, req *pb.ListNodeByApp
 
 // Update updates the node matching the given name.
 func (a *NodeAPI) Update(ctx context.Context, req *pb.UpdateNodeRequest) (*pb.UpdateNodeResponse, error) {
-	var appEUI, devEUI lorawan.EUI64
-	var appKey lorawan.AES128Key
+	return a.update(ctx, req, true)
+}
 
-	if err := appEUI.UnmarshalText([]byte(req.AppEUI)); err != nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+// Patch updates the fields listed in the update mask of the node matching
+// the given name. In contrast to Update, the If-Match header is optional.
+func (a *NodeAPI) Patch(ctx context.Context, req *pb.UpdateNodeRequest) (*pb.UpdateNodeResponse, error) {
+	if len(req.UpdateMask) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "updateMask must not be empty")
 	}
+	return a.update(ctx, req, false)
+}
+
+func (a *NodeAPI) update(ctx context.Context, req *pb.UpdateNodeRequest, requireIfMatch bool) (*pb.UpdateNodeResponse, error) {
+	var devEUI lorawan.EUI64
 	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
 		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
 	}
-	if err := appKey.UnmarshalText([]byte(req.AppKey)); err != nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
-	}
 
 	if err := a.validator.Validate(ctx,
 		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
@@ -164,25 +303,23 @@ func (a *NodeAPI) Update(ctx context.Context, req *pb.UpdateNodeRequest) (*pb.Up
 		return nil, errToRPCError(err)
 	}
 
-	node.Name = req.Name
-	node.Description = req.Description
-	node.AppEUI = appEUI
-	node.AppKey = appKey
-	node.IsABP = req.IsABP
-	node.IsClassC = req.IsClassC
-	node.RXDelay = uint8(req.RxDelay)
-	node.RX1DROffset = uint8(req.Rx1DROffset)
-	node.RXWindow = storage.RXWindow(req.RxWindow)
-	node.RX2DR = uint8(req.Rx2DR)
-	node.RelaxFCnt = req.RelaxFCnt
-	node.ADRInterval = req.AdrInterval
-	node.InstallationMargin = req.InstallationMargin
-	node.ApplicationID = req.ApplicationID
-	node.UseApplicationSettings = req.UseApplicationSettings
+	if requireIfMatch {
+		node.Revision, err = getIfMatchRevision(ctx, node.Revision)
+	} else {
+		node.Revision, err = getOptionalIfMatchRevision(ctx, node.Revision)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setNodeFields(&node, req); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
 
 	if err := storage.UpdateNode(common.DB, node); err != nil {
 		return nil, errToRPCError(err)
 	}
+	setETag(ctx, node.Revision+1)
 
 	log.WithFields(log.Fields{
 		"dev_eui":        node.DevEUI,
@@ -192,6 +329,101 @@ func (a *NodeAPI) Update(ctx context.Context, req *pb.UpdateNodeRequest) (*pb.Up
 	return &pb.UpdateNodeResponse{}, nil
 }
 
+// UpdateBatch updates the given nodes within a single transaction. The
+// result of each node is reported within the response. Like Update, the
+// revision of each node is required, a node which has been modified in the
+// meantime fails with FailedPrecondition.
+func (a *NodeAPI) UpdateBatch(ctx context.Context, req *pb.UpdateNodeBatchRequest) (*pb.UpdateNodeBatchResponse, error) {
+	if len(req.Nodes) > maxNodeBatchSize {
+		return nil, grpc.Errorf(codes.InvalidArgument, "batch exceeds max size of %d nodes", maxNodeBatchSize)
+	}
+
+	var resp pb.UpdateNodeBatchResponse
+	err := storage.Transaction(common.DB, func(tx *sqlx.Tx) error {
+		for i, nodeReq := range req.Nodes {
+			result := pb.NodeBatchResult{
+				DevEUI: nodeReq.DevEUI,
+			}
+
+			if err := a.updateBatchNode(ctx, tx, i, nodeReq); err != nil {
+				setNodeBatchError(&result, err)
+			}
+			resp.Result = append(resp.Result, &result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+func (a *NodeAPI) updateBatchNode(ctx context.Context, tx *sqlx.Tx, i int, req *pb.UpdateNodeRequest) error {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	return storage.Savepoint(tx, fmt.Sprintf("batch_node_%d", i), func() error {
+		node, err := storage.GetNode(tx, devEUI)
+		if err != nil {
+			return err
+		}
+
+		node.Revision, err = parseRevision(strings.TrimSpace(req.Revision), node.Revision, true, "revision")
+		if err != nil {
+			return err
+		}
+
+		if err := setNodeFields(&node, req); err != nil {
+			return grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		return storage.UpdateNode(tx, node)
+	})
+}
+
+// setNodeBatchError sets the error of the given node batch result, with the
+// gRPC status code of the error.
+func setNodeBatchError(result *pb.NodeBatchResult, err error) {
+	cause := errors.Cause(err)
+	if grpc.Code(cause) == codes.Unknown {
+		cause = errToRPCError(cause)
+	}
+	result.Code = grpc.Code(cause).String()
+	result.Error = grpc.ErrorDesc(cause)
+}
+
+// setNodeFields sets the fields of the given node to the values of the
+// given request, taking the update mask into account.
+func setNodeFields(node *storage.Node, req *pb.UpdateNodeRequest) error {
+	return applyUpdateMask(req.UpdateMask, map[string]func() error{
+		"name":                   func() error { node.Name = req.Name; return nil },
+		"description":            func() error { node.Description = req.Description; return nil },
+		"appEUI":                 func() error { return node.AppEUI.UnmarshalText([]byte(req.AppEUI)) },
+		"appKey":                 func() error { return node.AppKey.UnmarshalText([]byte(req.AppKey)) },
+		"isABP":                  func() error { node.IsABP = req.IsABP; return nil },
+		"isClassC":               func() error { node.IsClassC = req.IsClassC; return nil },
+		"rxDelay":                func() error { node.RXDelay = uint8(req.RxDelay); return nil },
+		"rx1DROffset":            func() error { node.RX1DROffset = uint8(req.Rx1DROffset); return nil },
+		"rxWindow":               func() error { node.RXWindow = storage.RXWindow(req.RxWindow); return nil },
+		"rx2DR":                  func() error { node.RX2DR = uint8(req.Rx2DR); return nil },
+		"relaxFCnt":              func() error { node.RelaxFCnt = req.RelaxFCnt; return nil },
+		"adrInterval":            func() error { node.ADRInterval = req.AdrInterval; return nil },
+		"installationMargin":     func() error { node.InstallationMargin = req.InstallationMargin; return nil },
+		"applicationID":          func() error { node.ApplicationID = req.ApplicationID; return nil },
+		"useApplicationSettings": func() error { node.UseApplicationSettings = req.UseApplicationSettings; return nil },
+		"tags":                   func() error { node.Tags = req.Tags; return nil },
+		"variables":              func() error { node.Variables = nodeVariablesFromPB(req.Variables); return nil },
+	})
+}
+
 // Delete deletes the node matching the given name.
 func (a *NodeAPI) Delete(ctx context.Context, req *pb.DeleteNodeRequest) (*pb.DeleteNodeResponse, error) {
 	var eui lorawan.EUI64
@@ -226,6 +458,68 @@ func (a *NodeAPI) Delete(ctx context.Context, req *pb.DeleteNodeRequest) (*pb.De
 	return &pb.DeleteNodeResponse{}, nil
 }
 
+// Decommission retires the given node. Disabling the node, flushing its
+// downlink queue, deleting its history (optional) and deleting its
+// node-session from the network-server are performed within a single
+// transaction, so that either all or none of these steps are performed.
+func (a *NodeAPI) Decommission(ctx context.Context, req *pb.DecommissionNodeRequest) (*pb.DecommissionNodeResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Delete)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	err = storage.Transaction(common.DB, func(tx *sqlx.Tx) error {
+		if err := storage.RetireNode(tx, node.DevEUI); err != nil {
+			return errToRPCError(err)
+		}
+		if err := storage.DeleteDownlinkQueueItemsForDevEUI(tx, node.DevEUI); err != nil {
+			return errToRPCError(err)
+		}
+		if req.DeleteHistory {
+			if err := storage.DeleteLinkQualityForDevEUI(tx, node.DevEUI); err != nil {
+				return errToRPCError(err)
+			}
+		}
+
+		// as the last step, so that a failing network-server rolls back
+		// the above changes
+		_, err := common.NetworkServer.DeleteNodeSession(ctx, &ns.DeleteNodeSessionRequest{
+			DevEUI: node.DevEUI[:],
+		})
+		if err != nil && grpc.Code(err) != codes.NotFound {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DeleteHistory {
+		if err := lastvalue.Delete(node.DevEUI); err != nil {
+			log.WithField("dev_eui", node.DevEUI).Errorf("delete last values error: %s", err)
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":        node.DevEUI,
+		"application_id": node.ApplicationID,
+		"delete_history": req.DeleteHistory,
+	}).Info("node decommissioned")
+
+	return &pb.DecommissionNodeResponse{}, nil
+}
+
 // Activate activates the node (ABP only).
 func (a *NodeAPI) Activate(ctx context.Context, req *pb.ActivateNodeRequest) (*pb.ActivateNodeResponse, error) {
 	var devAddr lorawan.DevAddr
@@ -255,6 +549,10 @@ func (a *NodeAPI) Activate(ctx context.Context, req *pb.ActivateNodeRequest) (*p
 		return nil, errToRPCError(err)
 	}
 
+	if node.RetiredAt != nil {
+		return nil, errToRPCError(storage.ErrNodeRetired)
+	}
+
 	if !node.IsABP {
 		return nil, grpc.Errorf(codes.FailedPrecondition, "node must be an ABP node")
 	}
@@ -426,6 +724,52 @@ func (a *NodeAPI) GetFrameLogs(ctx context.Context, req *pb.GetFrameLogsRequest)
 	return &out, nil
 }
 
+// GetNextEvent waits for the next event of the given node and returns it
+// (long-poll). An empty response is returned on timeout.
+func (a *NodeAPI) GetNextEvent(ctx context.Context, req *pb.GetNextNodeEventRequest) (*pb.GetNextNodeEventResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	timeout := defaultNextEventTimeout
+	if req.Timeout > 0 {
+		timeout = time.Duration(req.Timeout) * time.Second
+	}
+	if timeout > maxNextEventTimeout {
+		timeout = maxNextEventTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	eventsChan := make(chan eventlog.EventLog, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- eventlog.GetEventLogForDevice(ctx, devEUI, eventsChan)
+	}()
+
+	select {
+	case el := <-eventsChan:
+		return &pb.GetNextNodeEventResponse{
+			Type:        el.Type,
+			PayloadJSON: string(el.Payload),
+		}, nil
+	case err := <-errChan:
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		return &pb.GetNextNodeEventResponse{}, nil
+	case <-ctx.Done():
+		return &pb.GetNextNodeEventResponse{}, nil
+	}
+}
+
 // GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
 func (a *NodeAPI) GetRandomDevAddr(ctx context.Context, req *pb.GetRandomDevAddrRequest) (*pb.GetRandomDevAddrResponse, error) {
 	resp, err := common.NetworkServer.GetRandomDevAddr(context.Background(), &ns.GetRandomDevAddrRequest{})
@@ -441,6 +785,38 @@ func (a *NodeAPI) GetRandomDevAddr(ctx context.Context, req *pb.GetRandomDevAddr
 	}, nil
 }
 
+// nodeVariablesFromPB returns the given variables as NodeVariables. When
+// the same name is given multiple times, the last value is used.
+func nodeVariablesFromPB(vars []*pb.NodeVariable) storage.NodeVariables {
+	if len(vars) == 0 {
+		return nil
+	}
+
+	out := make(storage.NodeVariables)
+	for _, v := range vars {
+		out[v.Name] = v.Value
+	}
+	return out
+}
+
+// nodeVariablesToPB returns the given variables, sorted by name.
+func nodeVariablesToPB(vars storage.NodeVariables) []*pb.NodeVariable {
+	var names []string
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []*pb.NodeVariable
+	for _, name := range names {
+		out = append(out, &pb.NodeVariable{
+			Name:  name,
+			Value: vars[name],
+		})
+	}
+	return out
+}
+
 func (a *NodeAPI) returnList(count int, nodes []storage.Node) (*pb.ListNodeResponse, error) {
 	resp := pb.ListNodeResponse{
 		TotalCount: int64(count),
@@ -463,9 +839,1065 @@ func (a *NodeAPI) returnList(count int, nodes []storage.Node) (*pb.ListNodeRespo
 			InstallationMargin:     node.InstallationMargin,
 			ApplicationID:          node.ApplicationID,
 			UseApplicationSettings: node.UseApplicationSettings,
+			Tags:                   node.Tags,
+			Variables:              nodeVariablesToPB(node.Variables),
+		}
+		if node.RetiredAt != nil {
+			item.RetiredAt = node.RetiredAt.Format(time.RFC3339Nano)
 		}
 
 		resp.Result = append(resp.Result, &item)
 	}
 	return &resp, nil
 }
+
+// GetDownlinkAirtime returns the downlink airtime used by the given node
+// within the current window and the configured budget.
+func (a *NodeAPI) GetDownlinkAirtime(ctx context.Context, req *pb.GetNodeDownlinkAirtimeRequest) (*pb.GetNodeDownlinkAirtimeResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	app, err := storage.GetApplication(common.DB, node.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	used, err := airtime.GetDownlinkUsage(node.DevEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.GetNodeDownlinkAirtimeResponse{
+		WindowStart: airtime.GetWindowStart().Format(time.RFC3339),
+		Used:        uint32(used / time.Millisecond),
+		Budget:      uint32(app.DownlinkAirtimeBudget),
+		Enforce:     app.DownlinkAirtimeBudgetEnforce,
+	}, nil
+}
+
+// GetLinkQuality returns the link-quality score of the node and its hourly
+// history.
+func (a *NodeAPI) GetLinkQuality(ctx context.Context, req *pb.GetNodeLinkQualityRequest) (*pb.GetNodeLinkQualityResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, err := linkQualitySince(req.Hours)
+	if err != nil {
+		return nil, err
+	}
+
+	lqs, err := storage.GetLinkQualityForDevEUI(common.DB, devEUI, since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var total storage.LinkQuality
+	resp := pb.GetNodeLinkQualityResponse{
+		History: []*pb.LinkQualityBucket{},
+	}
+	for _, lq := range lqs {
+		total.Uplinks += lq.Uplinks
+		total.Missed += lq.Missed
+		total.Retransmissions += lq.Retransmissions
+		total.SNRMarginSum += lq.SNRMarginSum
+
+		resp.History = append(resp.History, &pb.LinkQualityBucket{
+			Bucket:      lq.Bucket.Format(time.RFC3339),
+			LinkQuality: linkQualityToPB(lq),
+		})
+	}
+	resp.LinkQuality = linkQualityToPB(total)
+
+	return &resp, nil
+}
+
+// ListLinkQuality returns the nodes of the given application having
+// received uplinks within the requested period, ranked by link-quality
+// score (worst first).
+func (a *NodeAPI) ListLinkQuality(ctx context.Context, req *pb.ListNodeLinkQualityRequest) (*pb.ListNodeLinkQualityResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, err := linkQualitySince(req.Hours)
+	if err != nil {
+		return nil, err
+	}
+
+	lqs, err := storage.GetLinkQualityForApplicationID(common.DB, req.ApplicationID, since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// the score is calculated in Go, therefore the sorting and pagination
+	// is done here too
+	sort.SliceStable(lqs, func(i, j int) bool {
+		return linkquality.Score(lqs[i].LinkQuality) < linkquality.Score(lqs[j].LinkQuality)
+	})
+
+	resp := pb.ListNodeLinkQualityResponse{
+		TotalCount: int64(len(lqs)),
+		Result:     []*pb.NodeLinkQuality{},
+	}
+
+	offset := int(req.Offset)
+	if offset > len(lqs) {
+		offset = len(lqs)
+	}
+	lqs = lqs[offset:]
+	if req.Limit > 0 && int(req.Limit) < len(lqs) {
+		lqs = lqs[:req.Limit]
+	}
+
+	for _, lq := range lqs {
+		resp.Result = append(resp.Result, &pb.NodeLinkQuality{
+			DevEUI:      lq.DevEUI.String(),
+			Name:        lq.Name,
+			LinkQuality: linkQualityToPB(lq.LinkQuality),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetAvailability returns the availability of the given node over the
+// requested period.
+func (a *NodeAPI) GetAvailability(ctx context.Context, req *pb.GetNodeAvailabilityRequest) (*pb.GetNodeAvailabilityResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, err := linkQualitySince(req.Hours)
+	if err != nil {
+		return nil, err
+	}
+
+	lqs, err := storage.GetLinkQualityForDevEUI(common.DB, devEUI, since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var total storage.LinkQuality
+	for _, lq := range lqs {
+		total.Uplinks += lq.Uplinks
+		total.Missed += lq.Missed
+		total.Retransmissions += lq.Retransmissions
+	}
+
+	return &pb.GetNodeAvailabilityResponse{
+		Availability: availabilityToPB(total, time.Since(since), time.Duration(req.UplinkInterval)*time.Second),
+	}, nil
+}
+
+// ListAvailability returns the availability of the (enabled) nodes of the
+// given application over the requested period, ranked by availability
+// (worst first), together with the availability of the application.
+func (a *NodeAPI) ListAvailability(ctx context.Context, req *pb.ListNodeAvailabilityRequest) (*pb.ListNodeAvailabilityResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	since, err := linkQualitySince(req.Hours)
+	if err != nil {
+		return nil, err
+	}
+	window := time.Since(since)
+	interval := time.Duration(req.UplinkInterval) * time.Second
+
+	lqs, err := storage.GetLinkQualityForApplicationNodes(common.DB, req.ApplicationID, since)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.ListNodeAvailabilityResponse{
+		TotalCount: int64(len(lqs)),
+	}
+
+	var received, expected int
+	result := make([]*pb.NodeAvailability, 0, len(lqs))
+	for _, lq := range lqs {
+		availability := availabilityToPB(lq.LinkQuality, window, interval)
+		received += int(availability.ReceivedUplinks)
+		expected += int(availability.ExpectedUplinks)
+
+		result = append(result, &pb.NodeAvailability{
+			DevEUI:       lq.DevEUI.String(),
+			Name:         lq.Name,
+			Availability: availability,
+		})
+	}
+	resp.ApplicationAvailability = &pb.Availability{
+		ExpectedUplinks: uint32(expected),
+		ReceivedUplinks: uint32(received),
+		Percentage:      linkquality.Availability(received, expected),
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Availability.Percentage < result[j].Availability.Percentage
+	})
+
+	offset := int(req.Offset)
+	if offset > len(result) {
+		offset = len(result)
+	}
+	result = result[offset:]
+	if req.Limit > 0 && int(req.Limit) < len(result) {
+		result = result[:req.Limit]
+	}
+	resp.Result = result
+
+	return &resp, nil
+}
+
+// GetLastValues returns the last received payload of the node per fPort.
+func (a *NodeAPI) GetLastValues(ctx context.Context, req *pb.GetNodeLastValuesRequest) (*pb.GetNodeLastValuesResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	vals, err := lastvalue.Get(devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.GetNodeLastValuesResponse{
+		Result: lastValuesToPB(vals),
+	}, nil
+}
+
+// ListLastValues returns the last received payloads of the nodes of the
+// given application.
+func (a *NodeAPI) ListLastValues(ctx context.Context, req *pb.ListNodeLastValuesRequest) (*pb.ListNodeLastValuesResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.List)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	nodes, err := storage.GetNodesForApplicationID(common.DB, req.ApplicationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	count, err := storage.GetNodesCountForApplicationID(common.DB, req.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var devEUIs []lorawan.EUI64
+	for _, node := range nodes {
+		devEUIs = append(devEUIs, node.DevEUI)
+	}
+	vals, err := lastvalue.GetMulti(devEUIs)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.ListNodeLastValuesResponse{
+		TotalCount: int64(count),
+		Result:     []*pb.NodeLastValues{},
+	}
+	for _, node := range nodes {
+		resp.Result = append(resp.Result, &pb.NodeLastValues{
+			DevEUI: node.DevEUI.String(),
+			Name:   node.Name,
+			Values: lastValuesToPB(vals[node.DevEUI]),
+		})
+	}
+
+	return &resp, nil
+}
+
+func lastValuesToPB(vals []lastvalue.Value) []*pb.NodeLastValue {
+	out := []*pb.NodeLastValue{}
+	for _, v := range vals {
+		out = append(out, &pb.NodeLastValue{
+			FPort:      uint32(v.FPort),
+			FCnt:       v.FCnt,
+			Data:       v.Data,
+			ReceivedAt: v.ReceivedAt.Format(time.RFC3339Nano),
+		})
+	}
+	return out
+}
+
+// AddTags adds the given tags to the nodes of the given application
+// matching the filter.
+func (a *NodeAPI) AddTags(ctx context.Context, req *pb.BulkNodeTagsRequest) (*pb.BulkNodeTagsResponse, error) {
+	return a.updateTags(ctx, req, storage.AddNodeTags)
+}
+
+// RemoveTags removes the given tags from the nodes of the given application
+// matching the filter.
+func (a *NodeAPI) RemoveTags(ctx context.Context, req *pb.BulkNodeTagsRequest) (*pb.BulkNodeTagsResponse, error) {
+	return a.updateTags(ctx, req, storage.RemoveNodeTags)
+}
+
+func (a *NodeAPI) updateTags(ctx context.Context, req *pb.BulkNodeTagsRequest, fn func(sqlx.Queryer, storage.NodeFilter, []string, bool) ([]lorawan.EUI64, error)) (*pb.BulkNodeTagsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodesAccess(req.ApplicationID, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	filter := storage.NodeFilter{
+		ApplicationID: req.ApplicationID,
+		Tags:          req.FilterTags,
+		NamePattern:   req.NamePattern,
+	}
+	if req.FilterID != 0 {
+		if len(req.FilterTags) != 0 || req.NamePattern != "" {
+			return nil, grpc.Errorf(codes.InvalidArgument, "filterID can not be combined with filterTags or namePattern")
+		}
+
+		var err error
+		filter, err = getSavedNodeFilterForApplication(req.ApplicationID, req.FilterID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	devEUIs, err := fn(common.DB, filter, req.Tags, req.DryRun)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.BulkNodeTagsResponse{
+		DevEUIs: []string{},
+	}
+	for _, devEUI := range devEUIs {
+		resp.DevEUIs = append(resp.DevEUIs, devEUI.String())
+	}
+
+	return &resp, nil
+}
+
+// GetEffectiveConfig returns the effective configuration of the node.
+func (a *NodeAPI) GetEffectiveConfig(ctx context.Context, req *pb.GetNodeEffectiveConfigRequest) (*pb.GetNodeEffectiveConfigResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	app, err := storage.GetApplication(common.DB, node.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.GetNodeEffectiveConfigResponse{
+		DevEUI:                       node.DevEUI.String(),
+		Name:                         node.Name,
+		ApplicationID:                app.ID,
+		ApplicationName:              app.Name,
+		Environment:                  app.Environment,
+		Tags:                         node.Tags,
+		NetworkSettingsSource:        "NODE",
+		GatewayFilterMACs:            []string{},
+		DownlinkAirtimeBudget:        uint32(app.DownlinkAirtimeBudget),
+		DownlinkAirtimeBudgetEnforce: app.DownlinkAirtimeBudgetEnforce,
+		ProprietaryPayloadPrefix:     hex.EncodeToString(app.ProprietaryPayloadPrefix),
+		NetworkSettings: &pb.NodeNetworkSettings{
+			IsABP:              node.IsABP,
+			IsClassC:           node.IsClassC,
+			RxWindow:           pb.RXWindow(node.RXWindow),
+			RxDelay:            uint32(node.RXDelay),
+			Rx1DROffset:        uint32(node.RX1DROffset),
+			Rx2DR:              uint32(node.RX2DR),
+			RelaxFCnt:          node.RelaxFCnt,
+			AdrInterval:        node.ADRInterval,
+			InstallationMargin: node.InstallationMargin,
+		},
+	}
+
+	if node.UseApplicationSettings {
+		resp.NetworkSettingsSource = "APPLICATION"
+		resp.NetworkSettings = &pb.NodeNetworkSettings{
+			IsABP:              app.IsABP,
+			IsClassC:           app.IsClassC,
+			RxWindow:           pb.RXWindow(app.RXWindow),
+			RxDelay:            uint32(app.RXDelay),
+			Rx1DROffset:        uint32(app.RX1DROffset),
+			Rx2DR:              uint32(app.RX2DR),
+			RelaxFCnt:          app.RelaxFCnt,
+			AdrInterval:        app.ADRInterval,
+			InstallationMargin: app.InstallationMargin,
+		}
+	}
+
+	resp.Integrations, err = getNodeIntegrationRoutes(app.ID, node)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	filter, err := storage.GetGatewayFilter(common.DB, app.ID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return nil, errToRPCError(err)
+	}
+	if err == nil {
+		resp.GatewayFilterMode = filter.Mode
+		for _, mac := range filter.GatewayMACs {
+			resp.GatewayFilterMACs = append(resp.GatewayFilterMACs, mac.String())
+		}
+	}
+
+	return &resp, nil
+}
+
+// GetMaintenance returns the maintenance mode of the given node.
+func (a *NodeAPI) GetMaintenance(ctx context.Context, req *pb.GetNodeMaintenanceRequest) (*pb.NodeMaintenance, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	app, err := storage.GetApplication(common.DB, node.ApplicationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.NodeMaintenance{
+		DevEUI:  node.DevEUI.String(),
+		Enabled: node.MaintenanceMode,
+		Until:   maintenanceUntilToPB(node.MaintenanceUntil),
+		Active:  storage.InMaintenance(app, node, time.Now()),
+	}, nil
+}
+
+// UpdateMaintenance updates the maintenance mode of the given node.
+func (a *NodeAPI) UpdateMaintenance(ctx context.Context, req *pb.NodeMaintenance) (*pb.UpdateNodeMaintenanceResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	m, err := maintenanceFromPB(req.Enabled, req.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.SetNodeMaintenance(common.DB, devEUI, m); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.UpdateNodeMaintenanceResponse{}, nil
+}
+
+// GetAlias returns the alias of the given node.
+func (a *NodeAPI) GetAlias(ctx context.Context, req *pb.GetNodeAliasRequest) (*pb.NodeAlias, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if _, err := storage.GetNode(common.DB, devEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+	alias, err := storage.GetNodeAlias(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.NodeAlias{
+		DevEUI: devEUI.String(),
+		Alias:  alias,
+	}, nil
+}
+
+// UpdateAlias sets or removes the alias of the given node.
+func (a *NodeAPI) UpdateAlias(ctx context.Context, req *pb.NodeAlias) (*pb.UpdateNodeAliasResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.SetNodeAlias(common.DB, devEUI, req.Alias); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.UpdateNodeAliasResponse{}, nil
+}
+
+// GetByAlias returns the node of the given organization matching the given
+// alias.
+func (a *NodeAPI) GetByAlias(ctx context.Context, req *pb.GetNodeByAliasRequest) (*pb.GetNodeResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Read, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	node, err := storage.GetNodeByAlias(common.DB, req.OrganizationID, req.Alias)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// the node access is validated by Get
+	return a.Get(ctx, &pb.GetNodeRequest{DevEUI: node.DevEUI.String()})
+}
+
+// Lookup returns the organization and application of the given node,
+// across all organizations.
+func (a *NodeAPI) Lookup(ctx context.Context, req *pb.LookupNodeRequest) (*pb.LookupNodeResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	l, err := storage.LookupNode(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.LookupNodeResponse{
+		DevEUI:           l.DevEUI.String(),
+		Name:             l.Name,
+		ApplicationID:    l.ApplicationID,
+		ApplicationName:  l.ApplicationName,
+		OrganizationID:   l.OrganizationID,
+		OrganizationName: l.OrganizationName,
+	}
+	if l.Alias != nil {
+		resp.Alias = *l.Alias
+	}
+
+	return &resp, nil
+}
+
+// CreateHTTPIntegration creates a device-level HTTP integration for the
+// given node.
+func (a *NodeAPI) CreateHTTPIntegration(ctx context.Context, req *pb.NodeHTTPIntegration) (*pb.CreateNodeHTTPIntegrationResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	confJSON, err := nodeHTTPIntegrationSettings(req, httphandler.HandlerConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	integration := storage.NodeIntegration{
+		DevEUI:   devEUI,
+		Kind:     handler.HTTPHandlerKind,
+		Settings: confJSON,
+	}
+	if err := storage.CreateNodeIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateNodeHTTPIntegrationResponse{}, nil
+}
+
+// GetHTTPIntegration returns the device-level HTTP integration of the given
+// node.
+func (a *NodeAPI) GetHTTPIntegration(ctx context.Context, req *pb.NodeIntegrationRequest) (*pb.NodeHTTPIntegration, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var conf httphandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &conf); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	var headers []*pb.HTTPIntegrationHeader
+	for k, v := range conf.Headers {
+		headers = append(headers, &pb.HTTPIntegrationHeader{
+			Key:   k,
+			Value: v,
+		})
+	}
+
+	return &pb.NodeHTTPIntegration{
+		DevEUI:                  integration.DevEUI.String(),
+		Headers:                 headers,
+		DataUpURL:               conf.DataUpURL,
+		JoinNotificationURL:     conf.JoinNotificationURL,
+		AckNotificationURL:      conf.ACKNotificationURL,
+		ErrorNotificationURL:    conf.ErrorNotificationURL,
+		SecurityNotificationURL: conf.SecurityNotificationURL,
+		BasicAuthUsername:       conf.BasicAuthUsername,
+		BasicAuthPassword:       maskSecret(conf.BasicAuthPassword),
+		BearerToken:             maskSecret(conf.BearerToken),
+		MaxAttempts:             uint32(conf.MaxAttempts),
+		SigningSecret:           maskSecret(conf.SigningSecret),
+		MaxPayloadSize:          uint32(conf.MaxPayloadSize),
+		Marshaler:               pb.IntegrationMarshaler(pb.IntegrationMarshaler_value[conf.Marshaler]),
+	}, nil
+}
+
+// UpdateHTTPIntegration updates the device-level HTTP integration of the
+// given node.
+func (a *NodeAPI) UpdateHTTPIntegration(ctx context.Context, req *pb.NodeHTTPIntegration) (*pb.UpdateNodeHTTPIntegrationResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Revision, err = getIfMatchRevision(ctx, integration.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored httphandler.HandlerConfig
+	if err = json.Unmarshal(integration.Settings, &stored); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	integration.Settings, err = nodeHTTPIntegrationSettings(req, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = storage.UpdateNodeIntegration(common.DB, &integration); err != nil {
+		return nil, errToRPCError(err)
+	}
+	setETag(ctx, integration.Revision)
+
+	return &pb.UpdateNodeHTTPIntegrationResponse{}, nil
+}
+
+// DeleteHTTPIntegration deletes the device-level HTTP integration of the
+// given node.
+func (a *NodeAPI) DeleteHTTPIntegration(ctx context.Context, req *pb.NodeIntegrationRequest) (*pb.DeleteNodeHTTPIntegrationResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err = storage.DeleteNodeIntegration(common.DB, integration.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.DeleteNodeHTTPIntegrationResponse{}, nil
+}
+
+// ListIntegrations lists the device-level integrations of the given node.
+func (a *NodeAPI) ListIntegrations(ctx context.Context, req *pb.NodeIntegrationRequest) (*pb.ListNodeIntegrationsResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integrations, err := storage.GetNodeIntegrationsForDevEUI(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out pb.ListNodeIntegrationsResponse
+	for _, integration := range integrations {
+		switch integration.Kind {
+		case handler.HTTPHandlerKind:
+			out.Kinds = append(out.Kinds, pb.IntegrationKind_HTTP)
+		default:
+			return nil, grpc.Errorf(codes.Internal, "unknown integration kind: %s", integration.Kind)
+		}
+	}
+
+	return &out, nil
+}
+
+// GetHTTPIntegrationChaos returns the failure simulation of the
+// device-level HTTP integration of the given node.
+func (a *NodeAPI) GetHTTPIntegrationChaos(ctx context.Context, req *pb.NodeIntegrationRequest) (*pb.NodeIntegrationChaos, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	c := integration.Chaos()
+	return &pb.NodeIntegrationChaos{
+		DevEUI:      integration.DevEUI.String(),
+		FailureRate: uint32(c.FailureRate),
+		Latency:     uint32(c.Latency / time.Millisecond),
+		Until:       maintenanceUntilToPB(c.Until),
+		Active:      c.Active(time.Now()),
+	}, nil
+}
+
+// UpdateHTTPIntegrationChaos updates the failure simulation of the
+// device-level HTTP integration of the given node. Only global admin users
+// are allowed to simulate integration failures.
+func (a *NodeAPI) UpdateHTTPIntegrationChaos(ctx context.Context, req *pb.NodeIntegrationChaos) (*pb.UpdateNodeIntegrationChaosResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	c := storage.IntegrationChaos{
+		FailureRate: int(req.FailureRate),
+		Latency:     time.Duration(req.Latency) * time.Millisecond,
+	}
+	if req.Until != "" {
+		t, err := time.Parse(time.RFC3339Nano, req.Until)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "until: %s", err)
+		}
+		c.Until = &t
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SetNodeIntegrationChaos(common.DB, integration.ID, c); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.UpdateNodeIntegrationChaosResponse{}, nil
+}
+
+// GetHTTPIntegrationFilter returns the event filter of the device-level HTTP
+// integration of the given node.
+func (a *NodeAPI) GetHTTPIntegrationFilter(ctx context.Context, req *pb.NodeIntegrationRequest) (*pb.NodeIntegrationFilter, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	f := integration.Filter()
+	resp := pb.NodeIntegrationFilter{
+		DevEUI:     integration.DevEUI.String(),
+		EventTypes: f.EventTypes,
+		Tags:       f.Tags,
+	}
+	for _, fPort := range f.FPorts {
+		resp.FPorts = append(resp.FPorts, uint32(fPort))
+	}
+
+	return &resp, nil
+}
+
+// UpdateHTTPIntegrationFilter updates the event filter of the device-level
+// HTTP integration of the given node.
+func (a *NodeAPI) UpdateHTTPIntegrationFilter(ctx context.Context, req *pb.NodeIntegrationFilter) (*pb.UpdateNodeIntegrationFilterResponse, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	f := storage.IntegrationFilter{
+		EventTypes: req.EventTypes,
+		Tags:       req.Tags,
+	}
+	for _, fPort := range req.FPorts {
+		f.FPorts = append(f.FPorts, int(fPort))
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SetNodeIntegrationFilter(common.DB, integration.ID, f); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.UpdateNodeIntegrationFilterResponse{}, nil
+}
+
+// GetHTTPIntegrationHealth returns the delivery health of the device-level
+// HTTP integration of the given node.
+func (a *NodeAPI) GetHTTPIntegrationHealth(ctx context.Context, req *pb.NodeIntegrationRequest) (*pb.IntegrationHealth, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(req.DevEUI)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI: %s", err)
+	}
+
+	if err := a.validator.Validate(ctx,
+		auth.ValidateNodeAccess(devEUI, auth.Update)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	integration, err := storage.GetNodeIntegration(common.DB, devEUI, handler.HTTPHandlerKind)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return getIntegrationHealth(pb.IntegrationKind_HTTP, integration.UUID)
+}
+
+// nodeHTTPIntegrationSettings validates the given device-level HTTP
+// integration and returns its JSON encoded handler configuration. Masked
+// secrets are replaced by those of the stored configuration.
+func nodeHTTPIntegrationSettings(req *pb.NodeHTTPIntegration, stored httphandler.HandlerConfig) ([]byte, error) {
+	headers := make(map[string]string)
+	for _, h := range req.Headers {
+		headers[h.Key] = h.Value
+	}
+
+	conf := httphandler.HandlerConfig{
+		Headers:                 headers,
+		DataUpURL:               req.DataUpURL,
+		JoinNotificationURL:     req.JoinNotificationURL,
+		ACKNotificationURL:      req.AckNotificationURL,
+		ErrorNotificationURL:    req.ErrorNotificationURL,
+		SecurityNotificationURL: req.SecurityNotificationURL,
+		BasicAuthUsername:       req.BasicAuthUsername,
+		BasicAuthPassword:       unmaskSecret(req.BasicAuthPassword, stored.BasicAuthPassword),
+		BearerToken:             unmaskSecret(req.BearerToken, stored.BearerToken),
+		MaxAttempts:             int(req.MaxAttempts),
+		SigningSecret:           unmaskSecret(req.SigningSecret, stored.SigningSecret),
+		MaxPayloadSize:          int(req.MaxPayloadSize),
+		Marshaler:               req.Marshaler.String(),
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	confJSON, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	return confJSON, nil
+}
+
+// getNodeIntegrationRoutes returns the integrations of the given
+// application and whether they receive the events of the given node (based
+// on the device subset and the tags of the event filter),
+// followed by the device-level integrations of the node. The MQTT
+// integration always receives the events of all nodes.
+func getNodeIntegrationRoutes(applicationID int64, node storage.Node) ([]*pb.NodeIntegrationRoute, error) {
+	routes := []*pb.NodeIntegrationRoute{
+		{Kind: "MQTT", Enabled: true},
+	}
+
+	integrations, err := storage.GetIntegrationsForApplicationID(common.DB, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// nil tags would not match any tag filter (event not related to a node)
+	tags := []string(node.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	for _, intg := range integrations {
+		route := pb.NodeIntegrationRoute{
+			Kind:    intg.Kind,
+			Enabled: true,
+		}
+
+		if intg.Kind == handler.HTTPHandlerKind {
+			var conf httphandler.HandlerConfig
+			if err := json.Unmarshal(intg.Settings, &conf); err != nil {
+				return nil, errors.Wrap(err, "decode http handler config error")
+			}
+			if !conf.IncludesDevEUI(node.DevEUI) {
+				route.Enabled = false
+				route.Reason = "the node is not within the device subset (devEUIs / devicePercentage) of the integration"
+			}
+		}
+
+		tagFilter := storage.IntegrationFilter{Tags: intg.Filter().Tags}
+		if route.Enabled && !tagFilter.Match("", nil, tags) {
+			route.Enabled = false
+			route.Reason = "the node does not have all tags of the event filter of the integration"
+		}
+
+		routes = append(routes, &route)
+	}
+
+	nodeIntegrations, err := storage.GetNodeIntegrationsForDevEUI(common.DB, node.DevEUI)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, intg := range nodeIntegrations {
+		routes = append(routes, &pb.NodeIntegrationRoute{
+			Kind:    intg.Kind,
+			Enabled: true,
+			Device:  true,
+		})
+	}
+
+	return routes, nil
+}
+
+// getSavedNodeFilterForApplication returns the node filter for the given
+// application, based on the saved node filter with the given ID. It returns
+// storage.ErrDoesNotExist when the saved filter belongs to an other
+// organization than the application.
+func getSavedNodeFilterForApplication(applicationID, filterID int64) (storage.NodeFilter, error) {
+	app, err := storage.GetApplication(common.DB, applicationID)
+	if err != nil {
+		return storage.NodeFilter{}, err
+	}
+	f, err := getSavedNodeFilter(app.OrganizationID, filterID)
+	if err != nil {
+		return storage.NodeFilter{}, err
+	}
+	return f.NodeFilter(applicationID), nil
+}
+
+// linkQualitySince returns the start of the link-quality period for the
+// given number of hours.
+func linkQualitySince(hours uint32) (time.Time, error) {
+	if hours == 0 {
+		hours = defaultLinkQualityHours
+	}
+	if hours > maxLinkQualityHours {
+		return time.Time{}, grpc.Errorf(codes.InvalidArgument, "hours must not exceed %d", maxLinkQualityHours)
+	}
+	return time.Now().Truncate(linkquality.BucketDuration).Add(-time.Duration(hours-1) * time.Hour), nil
+}
+
+func availabilityToPB(lq storage.LinkQuality, window, uplinkInterval time.Duration) *pb.Availability {
+	received := linkquality.ReceivedUplinks(lq)
+	expected := linkquality.ExpectedUplinks(lq, window, uplinkInterval)
+	return &pb.Availability{
+		ExpectedUplinks: uint32(expected),
+		ReceivedUplinks: uint32(received),
+		Percentage:      linkquality.Availability(received, expected),
+	}
+}
+
+func linkQualityToPB(lq storage.LinkQuality) *pb.LinkQuality {
+	return &pb.LinkQuality{
+		Score:           linkquality.Score(lq),
+		SnrMargin:       linkquality.SNRMargin(lq),
+		Uplinks:         uint32(lq.Uplinks),
+		Missed:          uint32(lq.Missed),
+		Retransmissions: uint32(lq.Retransmissions),
+	}
+}
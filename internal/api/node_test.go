@@ -2,13 +2,18 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/loraserver/api/ns"
@@ -69,6 +74,126 @@ func TestNodeAPI(t *testing.T) {
 			})
 		})
 
+		Convey("When creating a batch of nodes containing a duplicate", func() {
+			resp, err := api.CreateBatch(ctx, &pb.CreateNodeBatchRequest{
+				Nodes: []*pb.CreateNodeRequest{
+					{
+						ApplicationID: app.ID,
+						DevEUI:        "0807060504030201",
+						AppEUI:        "0102030405060708",
+						AppKey:        "01020304050607080102030405060708",
+					},
+					{
+						ApplicationID: app.ID,
+						DevEUI:        "0807060504030201",
+						AppEUI:        "0102030405060708",
+						AppKey:        "01020304050607080102030405060708",
+					},
+					{
+						ApplicationID: app.ID,
+						DevEUI:        "0807060504030202",
+						AppEUI:        "0102030405060708",
+						AppKey:        "01020304050607080102030405060708",
+					},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+			Convey("Then the result is reported per node", func() {
+				So(resp.Result, ShouldResemble, []*pb.NodeBatchResult{
+					{DevEUI: "0807060504030201"},
+					{DevEUI: "0807060504030201", Error: storage.ErrAlreadyExists.Error(), Code: codes.AlreadyExists.String()},
+					{DevEUI: "0807060504030202"},
+				})
+
+				count, err := storage.GetNodesCountForApplicationID(common.DB, app.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+			})
+
+			Convey("When updating a batch of nodes containing an unknown node", func() {
+				resp, err := api.UpdateBatch(ctx, &pb.UpdateNodeBatchRequest{
+					Nodes: []*pb.UpdateNodeRequest{
+						{
+							ApplicationID: app.ID,
+							DevEUI:        "0807060504030201",
+							Name:          "test-node-updated",
+							AppEUI:        "0102030405060708",
+							AppKey:        "01020304050607080102030405060708",
+							Revision:      "*",
+						},
+						{
+							ApplicationID: app.ID,
+							DevEUI:        "0807060504030203",
+							Name:          "test-node-unknown",
+							AppEUI:        "0102030405060708",
+							AppKey:        "01020304050607080102030405060708",
+							Revision:      "*",
+						},
+					},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the result is reported per node", func() {
+					So(resp.Result, ShouldResemble, []*pb.NodeBatchResult{
+						{DevEUI: "0807060504030201"},
+						{DevEUI: "0807060504030203", Error: storage.ErrDoesNotExist.Error(), Code: codes.NotFound.String()},
+					})
+
+					node, err := storage.GetNode(common.DB, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+					So(err, ShouldBeNil)
+					So(node.Name, ShouldEqual, "test-node-updated")
+				})
+			})
+
+			Convey("When updating a batch of nodes with a missing and a stale revision", func() {
+				node, err := storage.GetNode(common.DB, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+				So(err, ShouldBeNil)
+
+				nodeReq := func(name, revision string) *pb.UpdateNodeRequest {
+					return &pb.UpdateNodeRequest{
+						ApplicationID: app.ID,
+						DevEUI:        "0807060504030201",
+						Name:          name,
+						AppEUI:        "0102030405060708",
+						AppKey:        "01020304050607080102030405060708",
+						Revision:      revision,
+					}
+				}
+				resp, err := api.UpdateBatch(ctx, &pb.UpdateNodeBatchRequest{
+					Nodes: []*pb.UpdateNodeRequest{
+						nodeReq("test-node-missing", ""),
+						nodeReq("test-node-updated", fmt.Sprintf(`"%d"`, node.Revision)),
+						nodeReq("test-node-stale", fmt.Sprintf(`"%d"`, node.Revision)),
+					},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then only the node with the current revision is updated", func() {
+					So(resp.Result, ShouldResemble, []*pb.NodeBatchResult{
+						{DevEUI: "0807060504030201", Error: "revision is required", Code: codes.FailedPrecondition.String()},
+						{DevEUI: "0807060504030201"},
+						{DevEUI: "0807060504030201", Error: storage.ErrRevisionMismatch.Error(), Code: codes.FailedPrecondition.String()},
+					})
+
+					node, err := storage.GetNode(common.DB, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+					So(err, ShouldBeNil)
+					So(node.Name, ShouldEqual, "test-node-updated")
+				})
+			})
+		})
+
+		Convey("When creating a batch exceeding the max batch size", func() {
+			_, err := api.CreateBatch(ctx, &pb.CreateNodeBatchRequest{
+				Nodes: make([]*pb.CreateNodeRequest, maxNodeBatchSize+1),
+			})
+
+			Convey("Then an InvalidArgument error is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
 		Convey("When creating a node", func() {
 			_, err := api.Create(ctx, &pb.CreateNodeRequest{
 				ApplicationID:      app.ID,
@@ -85,6 +210,10 @@ func TestNodeAPI(t *testing.T) {
 				Rx2DR:              3,
 				AdrInterval:        20,
 				InstallationMargin: 5,
+				Tags:               []string{"outdoor"},
+				Variables: []*pb.NodeVariable{
+					{Name: "ThingsBoardAccessToken", Value: "secret-token"},
+				},
 			})
 			So(err, ShouldBeNil)
 			So(validator.ctx, ShouldResemble, ctx)
@@ -112,6 +241,10 @@ func TestNodeAPI(t *testing.T) {
 					AdrInterval:        20,
 					InstallationMargin: 5,
 					ApplicationID:      app.ID,
+					Tags:               []string{"outdoor"},
+					Variables: []*pb.NodeVariable{
+						{Name: "ThingsBoardAccessToken", Value: "secret-token"},
+					},
 				})
 			})
 
@@ -140,11 +273,16 @@ func TestNodeAPI(t *testing.T) {
 					AdrInterval:        20,
 					InstallationMargin: 5,
 					ApplicationID:      app.ID,
+					Tags:               []string{"outdoor"},
+					Variables: []*pb.NodeVariable{
+						{Name: "ThingsBoardAccessToken", Value: "secret-token"},
+					},
 				})
 			})
 
 			Convey("When updating the node", func() {
-				_, err := api.Update(ctx, &pb.UpdateNodeRequest{
+				updateCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `"0"`))
+				_, err := api.Update(updateCtx, &pb.UpdateNodeRequest{
 					ApplicationID:      app.ID,
 					DevEUI:             "0807060504030201",
 					Name:               "test-node-updated",
@@ -159,9 +297,10 @@ func TestNodeAPI(t *testing.T) {
 					Rx2DR:              4,
 					AdrInterval:        30,
 					InstallationMargin: 10,
+					Tags:               []string{"indoor"},
 				})
 				So(err, ShouldBeNil)
-				So(validator.ctx, ShouldResemble, ctx)
+				So(validator.ctx, ShouldResemble, updateCtx)
 				So(validator.validatorFuncs, ShouldHaveLength, 1)
 
 				Convey("Then the node has been updated", func() {
@@ -184,6 +323,358 @@ func TestNodeAPI(t *testing.T) {
 						AdrInterval:        30,
 						InstallationMargin: 10,
 						ApplicationID:      app.ID,
+						Tags:               []string{"indoor"},
+					})
+				})
+			})
+
+			Convey("When patching the name of the node", func() {
+				_, err := api.Patch(ctx, &pb.UpdateNodeRequest{
+					DevEUI:     "0807060504030201",
+					Name:       "test-node-patched",
+					UpdateMask: []string{"name"},
+				})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				Convey("Then only the name has been updated", func() {
+					node, err := api.Get(ctx, &pb.GetNodeRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(node.Name, ShouldEqual, "test-node-patched")
+					So(node.Description, ShouldEqual, "test node description")
+					So(node.AppKey, ShouldEqual, "01020304050607080102030405060708")
+					So(node.ApplicationID, ShouldEqual, app.ID)
+				})
+			})
+
+			Convey("When patching an unknown field", func() {
+				_, err := api.Patch(ctx, &pb.UpdateNodeRequest{
+					DevEUI:     "0807060504030201",
+					UpdateMask: []string{"foo"},
+				})
+
+				Convey("Then an InvalidArgument error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
+			Convey("When setting the alias of the node", func() {
+				_, err := api.UpdateAlias(ctx, &pb.NodeAlias{
+					DevEUI: "0807060504030201",
+					Alias:  "meter-1",
+				})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				Convey("Then the alias can be retrieved", func() {
+					alias, err := api.GetAlias(ctx, &pb.GetNodeAliasRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(alias.Alias, ShouldEqual, "meter-1")
+				})
+
+				Convey("Then the node can be retrieved by its alias", func() {
+					node, err := api.GetByAlias(ctx, &pb.GetNodeByAliasRequest{
+						OrganizationID: org.ID,
+						Alias:          "meter-1",
+					})
+					So(err, ShouldBeNil)
+					So(node.DevEUI, ShouldEqual, "0807060504030201")
+				})
+
+				Convey("Then the node lookup returns the organization and application", func() {
+					l, err := api.Lookup(ctx, &pb.LookupNodeRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(validator.validatorFuncs, ShouldHaveLength, 1)
+					So(l, ShouldResemble, &pb.LookupNodeResponse{
+						DevEUI:           "0807060504030201",
+						Name:             "test-node",
+						Alias:            "meter-1",
+						ApplicationID:    app.ID,
+						ApplicationName:  "test-app",
+						OrganizationID:   org.ID,
+						OrganizationName: "test-org",
+					})
+				})
+
+				Convey("Then setting an invalid alias returns an error", func() {
+					_, err := api.UpdateAlias(ctx, &pb.NodeAlias{
+						DevEUI: "0807060504030201",
+						Alias:  "Meter 1",
+					})
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
+			Convey("Given link-quality metrics of the node", func() {
+				So(storage.AddLinkQuality(common.DB, storage.LinkQuality{
+					DevEUI:          lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					Bucket:          time.Now().Truncate(time.Hour),
+					Uplinks:         10,
+					Missed:          2,
+					Retransmissions: 2,
+				}), ShouldBeNil)
+
+				Convey("Then the availability of the node is based on the missed uplinks", func() {
+					resp, err := api.GetAvailability(ctx, &pb.GetNodeAvailabilityRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(resp.Availability.ExpectedUplinks, ShouldEqual, 10)
+					So(resp.Availability.ReceivedUplinks, ShouldEqual, 8)
+					So(resp.Availability.Percentage, ShouldEqual, 80)
+				})
+
+				Convey("Then the availability of the application nodes can be listed", func() {
+					resp, err := api.ListAvailability(ctx, &pb.ListNodeAvailabilityRequest{
+						ApplicationID:  app.ID,
+						Hours:          720,
+						UplinkInterval: 3600,
+					})
+					So(err, ShouldBeNil)
+					So(resp.TotalCount, ShouldEqual, 1)
+					So(resp.Result, ShouldHaveLength, 1)
+					So(resp.Result[0].Availability.ExpectedUplinks, ShouldBeGreaterThanOrEqualTo, 719)
+					So(resp.ApplicationAvailability.ReceivedUplinks, ShouldEqual, 8)
+				})
+			})
+
+			Convey("Given a HTTP integration for a subset of the devices", func() {
+				So(storage.CreateIntegration(common.DB, &storage.Integration{
+					ApplicationID: app.ID,
+					Kind:          handler.HTTPHandlerKind,
+					Settings:      []byte(`{"devEUIs": ["0102030405060708"]}`),
+				}), ShouldBeNil)
+
+				Convey("When getting the effective config of the node", func() {
+					conf, err := api.GetEffectiveConfig(ctx, &pb.GetNodeEffectiveConfigRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+					Convey("Then the node settings and integration routing are returned", func() {
+						So(conf.ApplicationName, ShouldEqual, "test-app")
+						So(conf.NetworkSettingsSource, ShouldEqual, "NODE")
+						So(conf.NetworkSettings.RxDelay, ShouldEqual, 1)
+						So(conf.NetworkSettings.AdrInterval, ShouldEqual, 20)
+						So(conf.GatewayFilterMode, ShouldEqual, "")
+						So(conf.Integrations, ShouldHaveLength, 2)
+						So(conf.Integrations[0].Kind, ShouldEqual, "MQTT")
+						So(conf.Integrations[0].Enabled, ShouldBeTrue)
+						So(conf.Integrations[1].Kind, ShouldEqual, handler.HTTPHandlerKind)
+						So(conf.Integrations[1].Enabled, ShouldBeFalse)
+					})
+				})
+			})
+
+			Convey("When creating a device-level HTTP integration", func() {
+				integration := pb.NodeHTTPIntegration{
+					DevEUI: "0807060504030201",
+					Headers: []*pb.HTTPIntegrationHeader{
+						{Key: "Foo", Value: "bar"},
+					},
+					DataUpURL:           "http://up",
+					JoinNotificationURL: "http://join",
+				}
+				_, err := api.CreateHTTPIntegration(ctx, &integration)
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				Convey("Then the integration can be retrieved", func() {
+					i, err := api.GetHTTPIntegration(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(*i, ShouldResemble, integration)
+				})
+
+				Convey("Then the integrations can be listed", func() {
+					resp, err := api.ListIntegrations(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(resp.Kinds, ShouldResemble, []pb.IntegrationKind{pb.IntegrationKind_HTTP})
+				})
+
+				Convey("Then the integration is part of the effective config", func() {
+					conf, err := api.GetEffectiveConfig(ctx, &pb.GetNodeEffectiveConfigRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(conf.Integrations, ShouldHaveLength, 2)
+					So(conf.Integrations[1].Kind, ShouldEqual, handler.HTTPHandlerKind)
+					So(conf.Integrations[1].Enabled, ShouldBeTrue)
+					So(conf.Integrations[1].Device, ShouldBeTrue)
+				})
+
+				Convey("Then the integration can be updated", func() {
+					integration.DataUpURL = "http://up2"
+					updateCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", "*"))
+					_, err := api.UpdateHTTPIntegration(updateCtx, &integration)
+					So(err, ShouldBeNil)
+
+					i, err := api.GetHTTPIntegration(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(*i, ShouldResemble, integration)
+				})
+
+				Convey("Then a failure simulation can be set and retrieved", func() {
+					_, err := api.UpdateHTTPIntegrationChaos(ctx, &pb.NodeIntegrationChaos{
+						DevEUI:      "0807060504030201",
+						FailureRate: 50,
+						Until:       time.Now().Add(time.Hour).UTC().Format(time.RFC3339Nano),
+					})
+					So(err, ShouldBeNil)
+
+					c, err := api.GetHTTPIntegrationChaos(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(c.FailureRate, ShouldEqual, 50)
+					So(c.Active, ShouldBeTrue)
+				})
+
+				Convey("Then an event filter can be set and retrieved", func() {
+					filter := pb.NodeIntegrationFilter{
+						DevEUI:     "0807060504030201",
+						EventTypes: []string{"up"},
+						FPorts:     []uint32{10},
+					}
+					_, err := api.UpdateHTTPIntegrationFilter(ctx, &filter)
+					So(err, ShouldBeNil)
+
+					f, err := api.GetHTTPIntegrationFilter(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+					So(*f, ShouldResemble, filter)
+				})
+
+				Convey("Then an event filter with an invalid event type returns an error", func() {
+					_, err := api.UpdateHTTPIntegrationFilter(ctx, &pb.NodeIntegrationFilter{
+						DevEUI:     "0807060504030201",
+						EventTypes: []string{"downlink"},
+					})
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+
+				Convey("Then the integration can be deleted", func() {
+					_, err := api.DeleteHTTPIntegration(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(err, ShouldBeNil)
+
+					_, err = api.GetHTTPIntegration(ctx, &pb.NodeIntegrationRequest{DevEUI: "0807060504030201"})
+					So(grpc.Code(err), ShouldEqual, codes.NotFound)
+				})
+			})
+
+			Convey("When adding a tag to the nodes matching a name pattern (dry-run)", func() {
+				resp, err := api.AddTags(ctx, &pb.BulkNodeTagsRequest{
+					ApplicationID: app.ID,
+					NamePattern:   "test-*",
+					Tags:          []string{"floor-1"},
+					DryRun:        true,
+				})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				Convey("Then the node is returned, but not updated", func() {
+					So(resp.DevEUIs, ShouldResemble, []string{"0807060504030201"})
+
+					node, err := api.Get(ctx, &pb.GetNodeRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(node.Tags, ShouldResemble, []string{"outdoor"})
+				})
+			})
+
+			Convey("When removing a tag from the nodes having this tag", func() {
+				resp, err := api.RemoveTags(ctx, &pb.BulkNodeTagsRequest{
+					ApplicationID: app.ID,
+					FilterTags:    []string{"outdoor"},
+					Tags:          []string{"outdoor"},
+				})
+				So(err, ShouldBeNil)
+				So(resp.DevEUIs, ShouldResemble, []string{"0807060504030201"})
+
+				Convey("Then the tag has been removed", func() {
+					node, err := api.Get(ctx, &pb.GetNodeRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+					So(node.Tags, ShouldResemble, []string{})
+				})
+			})
+
+			Convey("Given a downlink queue item and link-quality metrics of the node", func() {
+				common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+				test.MustFlushRedis(common.RedisPool)
+
+				devEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+				So(storage.CreateDownlinkQueueItem(common.DB, &storage.DownlinkQueueItem{
+					DevEUI: devEUI,
+					FPort:  1,
+					Data:   []byte{1, 2, 3},
+				}), ShouldBeNil)
+				So(storage.AddLinkQuality(common.DB, storage.LinkQuality{
+					DevEUI:  devEUI,
+					Bucket:  time.Now().Truncate(time.Hour),
+					Uplinks: 10,
+				}), ShouldBeNil)
+
+				Convey("When decommissioning the node with deleteHistory", func() {
+					_, err := api.Decommission(ctx, &pb.DecommissionNodeRequest{
+						DevEUI:        "0807060504030201",
+						DeleteHistory: true,
+					})
+					So(err, ShouldBeNil)
+					So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+					Convey("Then the node-session was deleted", func() {
+						So(nsClient.DeleteNodeSessionChan, ShouldHaveLength, 1)
+						So(<-nsClient.DeleteNodeSessionChan, ShouldResemble, ns.DeleteNodeSessionRequest{
+							DevEUI: devEUI[:],
+						})
+					})
+
+					Convey("Then the node is retired and disabled", func() {
+						resp, err := api.Get(ctx, &pb.GetNodeRequest{DevEUI: "0807060504030201"})
+						So(err, ShouldBeNil)
+						So(resp.RetiredAt, ShouldNotEqual, "")
+
+						node, err := storage.GetNode(common.DB, devEUI)
+						So(err, ShouldBeNil)
+						So(node.Disabled, ShouldBeTrue)
+					})
+
+					Convey("Then the downlink queue and the history have been deleted", func() {
+						size, err := storage.GetDownlinkQueueSize(common.DB, devEUI)
+						So(err, ShouldBeNil)
+						So(size, ShouldEqual, 0)
+
+						lq, err := storage.GetLinkQualityForDevEUI(common.DB, devEUI, time.Now().Add(-time.Hour*24))
+						So(err, ShouldBeNil)
+						So(lq, ShouldHaveLength, 0)
+					})
+
+					Convey("Then decommissioning the node again fails", func() {
+						_, err := api.Decommission(ctx, &pb.DecommissionNodeRequest{DevEUI: "0807060504030201"})
+						So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+					})
+
+					Convey("Then the node can not be activated", func() {
+						_, err := api.Activate(ctx, &pb.ActivateNodeRequest{
+							DevEUI:  "0807060504030201",
+							DevAddr: "01020304",
+							AppSKey: "01020304050607080102030405060708",
+							NwkSKey: "08070605040302010807060504030201",
+						})
+						So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+					})
+				})
+
+				Convey("When decommissioning the node without deleteHistory", func() {
+					_, err := api.Decommission(ctx, &pb.DecommissionNodeRequest{
+						DevEUI: "0807060504030201",
+					})
+					So(err, ShouldBeNil)
+
+					Convey("Then the history is kept", func() {
+						lq, err := storage.GetLinkQualityForDevEUI(common.DB, devEUI, time.Now().Add(-time.Hour*24))
+						So(err, ShouldBeNil)
+						So(lq, ShouldHaveLength, 1)
 					})
 				})
 			})
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/nsevent"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// NetworkServerEventHandler implements a http.Handler (webhook) receiving
+// the network-server events which are not part of the application-server
+// API (e.g. gateways going offline and downlink NACKs). Requests must be
+// authenticated with the configured token as bearer token.
+type NetworkServerEventHandler struct {
+	token string
+}
+
+// NewNetworkServerEventHandler creates a new NetworkServerEventHandler.
+func NewNetworkServerEventHandler(token string) *NetworkServerEventHandler {
+	return &NetworkServerEventHandler{
+		token: token,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *NetworkServerEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+
+	var event nsevent.Event
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, "decode event error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := event.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := nsevent.Handle(event); err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.WithField("type", event.Type).Errorf("handle network-server event error: %s", err)
+		http.Error(w, "handle event error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/nsevent"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+	"github.com/brocaar/lorawan"
+)
+
+func TestNetworkServerEventHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database, a test handler and a network-server event handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		h := testhandler.NewTestHandler()
+		common.Handler = h
+
+		eventHandler := NewNetworkServerEventHandler("secret")
+		post := func(token, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/network-server/events", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			eventHandler.ServeHTTP(w, req)
+			return w
+		}
+
+		Convey("When posting a GATEWAY_OFFLINE event", func() {
+			w := post("secret", `{"type": "GATEWAY_OFFLINE", "mac": "0102030405060708", "message": "offline"}`)
+
+			Convey("Then the gateway notification was sent", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				pl := <-h.SendGatewayNotificationChan
+				So(pl.MAC, ShouldEqual, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+				So(pl.Type, ShouldEqual, nsevent.GatewayOffline)
+				So(pl.Message, ShouldEqual, "offline")
+			})
+		})
+
+		Convey("When posting an event with an invalid token", func() {
+			w := post("foo", `{"type": "GATEWAY_OFFLINE", "mac": "0102030405060708"}`)
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+
+		Convey("When posting an event of an unknown type", func() {
+			w := post("secret", `{"type": "FOO"}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When posting a DOWNLINK_NACK event for an unknown node", func() {
+			w := post("secret", `{"type": "DOWNLINK_NACK", "devEUI": "0102030405060708", "message": "too late"}`)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
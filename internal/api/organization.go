@@ -10,6 +10,7 @@ import (
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/digest"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/jmoiron/sqlx"
@@ -69,6 +70,7 @@ func (a *OrganizationAPI) Get(ctx context.Context, req *pb.OrganizationRequest)
 		CanHaveGateways: org.CanHaveGateways,
 		CreatedAt:       org.CreatedAt.Format(time.RFC3339Nano),
 		UpdatedAt:       org.UpdatedAt.Format(time.RFC3339Nano),
+		Uuid:            org.UUID.String(),
 	}, nil
 }
 
@@ -121,6 +123,7 @@ func (a *OrganizationAPI) List(ctx context.Context, req *pb.ListOrganizationRequ
 			CanHaveGateways: org.CanHaveGateways,
 			CreatedAt:       org.CreatedAt.Format(time.RFC3339Nano),
 			UpdatedAt:       org.UpdatedAt.Format(time.RFC3339Nano),
+			Uuid:            org.UUID.String(),
 		}
 	}
 
@@ -307,3 +310,428 @@ func (a *OrganizationAPI) GetUser(ctx context.Context, req *pb.GetOrganizationUs
 		UpdatedAt: user.UpdatedAt.Format(time.RFC3339Nano),
 	}, nil
 }
+
+// ListSavedNodeFilters returns the saved node filters of the organization.
+func (a *OrganizationAPI) ListSavedNodeFilters(ctx context.Context, req *pb.ListSavedNodeFiltersRequest) (*pb.ListSavedNodeFiltersResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Read, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	filters, err := storage.GetSavedNodeFilters(common.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	count, err := storage.GetSavedNodeFilterCount(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	result := make([]*pb.GetSavedNodeFilterResponse, len(filters))
+	for i, f := range filters {
+		result[i] = savedNodeFilterToPB(f)
+	}
+
+	return &pb.ListSavedNodeFiltersResponse{
+		TotalCount: int32(count),
+		Result:     result,
+	}, nil
+}
+
+// GetSavedNodeFilter returns the saved node filter for the given ID.
+func (a *OrganizationAPI) GetSavedNodeFilter(ctx context.Context, req *pb.SavedNodeFilterRequest) (*pb.GetSavedNodeFilterResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Read, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	f, err := getSavedNodeFilter(req.OrganizationID, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return savedNodeFilterToPB(f), nil
+}
+
+// CreateSavedNodeFilter creates the given saved node filter.
+func (a *OrganizationAPI) CreateSavedNodeFilter(ctx context.Context, req *pb.CreateSavedNodeFilterRequest) (*pb.CreateSavedNodeFilterResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	f := storage.SavedNodeFilter{
+		OrganizationID: req.OrganizationID,
+		Name:           req.Name,
+		Tags:           req.Tags,
+		NamePattern:    req.NamePattern,
+		NotSeenHours:   int(req.NotSeenHours),
+	}
+	if err := storage.CreateSavedNodeFilter(common.DB, &f); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateSavedNodeFilterResponse{
+		Id: f.ID,
+	}, nil
+}
+
+// UpdateSavedNodeFilter updates the given saved node filter.
+func (a *OrganizationAPI) UpdateSavedNodeFilter(ctx context.Context, req *pb.UpdateSavedNodeFilterRequest) (*pb.OrganizationEmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	f, err := getSavedNodeFilter(req.OrganizationID, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	f.Name = req.Name
+	f.Tags = req.Tags
+	f.NamePattern = req.NamePattern
+	f.NotSeenHours = int(req.NotSeenHours)
+
+	if err := storage.UpdateSavedNodeFilter(common.DB, &f); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.OrganizationEmptyResponse{}, nil
+}
+
+// DeleteSavedNodeFilter deletes the saved node filter for the given ID.
+func (a *OrganizationAPI) DeleteSavedNodeFilter(ctx context.Context, req *pb.SavedNodeFilterRequest) (*pb.OrganizationEmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if _, err := getSavedNodeFilter(req.OrganizationID, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.DeleteSavedNodeFilter(common.DB, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.OrganizationEmptyResponse{}, nil
+}
+
+// GetDigest returns the digest report configuration of the organization.
+func (a *OrganizationAPI) GetDigest(ctx context.Context, req *pb.OrganizationDigestRequest) (*pb.OrganizationDigest, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Read, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	d, err := storage.GetOrganizationDigest(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.OrganizationDigest{
+		OrganizationID: d.OrganizationID,
+		WebhookURL:     d.WebhookURL,
+		Template:       d.Template,
+	}
+	if d.Frequency == storage.DigestWeekly {
+		resp.Frequency = pb.DigestFrequency_WEEKLY
+	}
+	if d.LastSentAt != nil {
+		resp.LastSentAt = d.LastSentAt.Format(time.RFC3339Nano)
+	}
+
+	return &resp, nil
+}
+
+// UpdateDigest creates or updates the digest report configuration of the
+// organization.
+func (a *OrganizationAPI) UpdateDigest(ctx context.Context, req *pb.OrganizationDigest) (*pb.OrganizationEmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if _, err := digest.ParseTemplate(req.Template); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "template: %s", err)
+	}
+
+	d := storage.OrganizationDigest{
+		OrganizationID: req.OrganizationID,
+		Frequency:      storage.DigestDaily,
+		WebhookURL:     req.WebhookURL,
+		Template:       req.Template,
+	}
+	if req.Frequency == pb.DigestFrequency_WEEKLY {
+		d.Frequency = storage.DigestWeekly
+	}
+
+	if err := storage.UpdateOrganizationDigest(common.DB, &d); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.OrganizationEmptyResponse{}, nil
+}
+
+// DeleteDigest deletes the digest report configuration of the organization.
+func (a *OrganizationAPI) DeleteDigest(ctx context.Context, req *pb.OrganizationDigestRequest) (*pb.OrganizationEmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	if err := storage.DeleteOrganizationDigest(common.DB, req.OrganizationID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.OrganizationEmptyResponse{}, nil
+}
+
+// PreviewDigest renders the digest report of the organization for the
+// period ending now, without sending it.
+func (a *OrganizationAPI) PreviewDigest(ctx context.Context, req *pb.OrganizationDigestRequest) (*pb.PreviewOrganizationDigestResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Read, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	org, err := storage.GetOrganization(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	d, err := storage.GetOrganizationDigest(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	report, err := digest.Generate(org, d, time.Now())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	b, contentType, err := digest.Render(report, d.Template)
+	if err != nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "render digest error: %s", err)
+	}
+
+	return &pb.PreviewOrganizationDigestResponse{
+		ContentType: contentType,
+		Body:        string(b),
+	}, nil
+}
+
+// ListElevations returns the elevations of the organization, including the
+// expired and revoked ones (audit log).
+func (a *OrganizationAPI) ListElevations(ctx context.Context, req *pb.ListOrganizationElevationsRequest) (*pb.ListOrganizationElevationsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	elevations, err := storage.GetOrganizationElevations(common.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	count, err := storage.GetOrganizationElevationCount(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	now := time.Now()
+	result := make([]*pb.GetOrganizationElevationResponse, len(elevations))
+	for i, e := range elevations {
+		result[i] = organizationElevationToPB(e, now)
+	}
+
+	return &pb.ListOrganizationElevationsResponse{
+		TotalCount: int32(count),
+		Result:     result,
+	}, nil
+}
+
+// CreateElevation elevates the given user to organization admin for the
+// given number of hours. Only global admin users are able to grant
+// elevations.
+func (a *OrganizationAPI) CreateElevation(ctx context.Context, req *pb.CreateOrganizationElevationRequest) (*pb.CreateOrganizationElevationResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	username, err := a.validator.GetUsername(ctx)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	now := time.Now()
+	e := storage.OrganizationElevation{
+		CreatedAt:      now,
+		OrganizationID: req.OrganizationID,
+		UserID:         req.UserID,
+		GrantedBy:      username,
+		Justification:  req.Justification,
+		ExpiresAt:      now.Add(time.Duration(req.Hours) * time.Hour),
+	}
+	if err := storage.CreateOrganizationElevation(common.DB, &e); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.CreateOrganizationElevationResponse{
+		Id:        e.ID,
+		ExpiresAt: e.ExpiresAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// RevokeElevation revokes the given (active) elevation before it expires.
+func (a *OrganizationAPI) RevokeElevation(ctx context.Context, req *pb.OrganizationElevationRequest) (*pb.OrganizationEmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	e, err := storage.GetOrganizationElevation(common.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	if e.OrganizationID != req.OrganizationID {
+		return nil, errToRPCError(storage.ErrDoesNotExist)
+	}
+
+	username, err := a.validator.GetUsername(ctx)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.RevokeOrganizationElevation(common.DB, e.ID, username); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.OrganizationEmptyResponse{}, nil
+}
+
+// ListDeadLetters returns the dead-lettered events of the organization.
+func (a *OrganizationAPI) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	dls, err := storage.GetEventDeadLetters(common.DB, req.OrganizationID, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	count, err := storage.GetEventDeadLetterCount(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	result := make([]*pb.DeadLetter, len(dls))
+	for i, dl := range dls {
+		result[i] = &pb.DeadLetter{
+			Id:          dl.ID,
+			Type:        dl.Type,
+			PayloadJSON: string(dl.Payload),
+			Attempts:    uint32(dl.Attempts),
+			Error:       dl.Error,
+			CreatedAt:   dl.CreatedAt.Format(time.RFC3339Nano),
+			FailedAt:    dl.FailedAt.Format(time.RFC3339Nano),
+			Target:      dl.Target,
+		}
+	}
+
+	return &pb.ListDeadLettersResponse{
+		TotalCount: int32(count),
+		Result:     result,
+	}, nil
+}
+
+// RetryDeadLetter moves the given dead-lettered event back to the event
+// outbox.
+func (a *OrganizationAPI) RetryDeadLetter(ctx context.Context, req *pb.DeadLetterRequest) (*pb.OrganizationEmptyResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	err := storage.Transaction(common.DB, func(tx *sqlx.Tx) error {
+		dl, err := storage.GetEventDeadLetter(tx, req.Id)
+		if err != nil {
+			return err
+		}
+		if dl.OrganizationID != req.OrganizationID {
+			return storage.ErrDoesNotExist
+		}
+		return storage.RetryEventDeadLetter(tx, dl.ID)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.OrganizationEmptyResponse{}, nil
+}
+
+// PurgeDeadLetters deletes all dead-lettered events of the organization.
+func (a *OrganizationAPI) PurgeDeadLetters(ctx context.Context, req *pb.PurgeDeadLettersRequest) (*pb.PurgeDeadLettersResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateOrganizationAccess(auth.Update, req.OrganizationID)); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	count, err := storage.DeleteEventDeadLettersForOrganization(common.DB, req.OrganizationID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &pb.PurgeDeadLettersResponse{
+		Count: uint32(count),
+	}, nil
+}
+
+// getSavedNodeFilter returns the saved node filter for the given ID. It
+// returns storage.ErrDoesNotExist when the filter belongs to an other
+// organization.
+func getSavedNodeFilter(organizationID, id int64) (storage.SavedNodeFilter, error) {
+	f, err := storage.GetSavedNodeFilter(common.DB, id)
+	if err != nil {
+		return f, err
+	}
+	if f.OrganizationID != organizationID {
+		return f, storage.ErrDoesNotExist
+	}
+	return f, nil
+}
+
+func savedNodeFilterToPB(f storage.SavedNodeFilter) *pb.GetSavedNodeFilterResponse {
+	return &pb.GetSavedNodeFilterResponse{
+		Id:           f.ID,
+		Name:         f.Name,
+		Tags:         f.Tags,
+		NamePattern:  f.NamePattern,
+		NotSeenHours: uint32(f.NotSeenHours),
+		CreatedAt:    f.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:    f.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func organizationElevationToPB(e storage.OrganizationElevation, now time.Time) *pb.GetOrganizationElevationResponse {
+	resp := pb.GetOrganizationElevationResponse{
+		Id:            e.ID,
+		UserID:        e.UserID,
+		Username:      e.Username,
+		GrantedBy:     e.GrantedBy,
+		Justification: e.Justification,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339Nano),
+		ExpiresAt:     e.ExpiresAt.Format(time.RFC3339Nano),
+		Active:        e.Active(now),
+	}
+	if e.RevokedAt != nil {
+		resp.RevokedAt = e.RevokedAt.Format(time.RFC3339Nano)
+	}
+	if e.RevokedBy != nil {
+		resp.RevokedBy = *e.RevokedBy
+	}
+	return &resp
+}
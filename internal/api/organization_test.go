@@ -5,6 +5,8 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/common"
@@ -105,6 +107,140 @@ func TestOrganizationAPI(t *testing.T) {
 
 				})
 
+				Convey("When creating a saved node filter", func() {
+					filterResp, err := api.CreateSavedNodeFilter(ctx, &pb.CreateSavedNodeFilterRequest{
+						OrganizationID: orgId,
+						Name:           "offline-outdoor",
+						Tags:           []string{"outdoor"},
+						NotSeenHours:   24,
+					})
+					So(err, ShouldBeNil)
+					So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+					Convey("Then the filter has been created", func() {
+						f, err := api.GetSavedNodeFilter(ctx, &pb.SavedNodeFilterRequest{
+							OrganizationID: orgId,
+							Id:             filterResp.Id,
+						})
+						So(err, ShouldBeNil)
+						So(f.Name, ShouldEqual, "offline-outdoor")
+						So(f.Tags, ShouldResemble, []string{"outdoor"})
+						So(f.NotSeenHours, ShouldEqual, 24)
+
+						filters, err := api.ListSavedNodeFilters(ctx, &pb.ListSavedNodeFiltersRequest{
+							OrganizationID: orgId,
+							Limit:          10,
+						})
+						So(err, ShouldBeNil)
+						So(filters.TotalCount, ShouldEqual, 1)
+						So(filters.Result, ShouldHaveLength, 1)
+					})
+
+					Convey("Then the filter can not be retrieved through an other organization", func() {
+						_, err := api.GetSavedNodeFilter(ctx, &pb.SavedNodeFilterRequest{
+							OrganizationID: orgId + 1,
+							Id:             filterResp.Id,
+						})
+						So(grpc.Code(err), ShouldEqual, codes.NotFound)
+					})
+
+					Convey("When deleting the filter", func() {
+						_, err := api.DeleteSavedNodeFilter(ctx, &pb.SavedNodeFilterRequest{
+							OrganizationID: orgId,
+							Id:             filterResp.Id,
+						})
+						So(err, ShouldBeNil)
+
+						Convey("Then the filter has been deleted", func() {
+							_, err := api.GetSavedNodeFilter(ctx, &pb.SavedNodeFilterRequest{
+								OrganizationID: orgId,
+								Id:             filterResp.Id,
+							})
+							So(grpc.Code(err), ShouldEqual, codes.NotFound)
+						})
+					})
+				})
+
+				Convey("Given a dead-lettered event", func() {
+					item := storage.EventOutboxItem{
+						OrganizationID: orgId,
+						Type:           "data_up",
+						Payload:        []byte(`{"fCnt":10}`),
+						Attempts:       10,
+					}
+					So(storage.CreateEventOutboxItem(common.DB, &item), ShouldBeNil)
+					So(storage.DeadLetterEventOutboxItem(common.DB, item, "boom"), ShouldBeNil)
+
+					Convey("Then it can be listed", func() {
+						resp, err := api.ListDeadLetters(ctx, &pb.ListDeadLettersRequest{
+							OrganizationID: orgId,
+							Limit:          10,
+						})
+						So(err, ShouldBeNil)
+						So(validator.validatorFuncs, ShouldHaveLength, 1)
+						So(resp.TotalCount, ShouldEqual, 1)
+						So(resp.Result, ShouldHaveLength, 1)
+						So(resp.Result[0].Type, ShouldEqual, "data_up")
+						So(resp.Result[0].PayloadJSON, ShouldEqual, `{"fCnt": 10}`)
+						So(resp.Result[0].Attempts, ShouldEqual, 10)
+						So(resp.Result[0].Error, ShouldEqual, "boom")
+					})
+
+					Convey("Then it can not be retried through an other organization", func() {
+						resp, err := api.ListDeadLetters(ctx, &pb.ListDeadLettersRequest{
+							OrganizationID: orgId,
+							Limit:          10,
+						})
+						So(err, ShouldBeNil)
+						So(resp.Result, ShouldHaveLength, 1)
+
+						_, err = api.RetryDeadLetter(ctx, &pb.DeadLetterRequest{
+							OrganizationID: orgId + 1,
+							Id:             resp.Result[0].Id,
+						})
+						So(grpc.Code(err), ShouldEqual, codes.NotFound)
+					})
+
+					Convey("When retrying the event", func() {
+						resp, err := api.ListDeadLetters(ctx, &pb.ListDeadLettersRequest{
+							OrganizationID: orgId,
+							Limit:          10,
+						})
+						So(err, ShouldBeNil)
+						So(resp.Result, ShouldHaveLength, 1)
+
+						_, err = api.RetryDeadLetter(ctx, &pb.DeadLetterRequest{
+							OrganizationID: orgId,
+							Id:             resp.Result[0].Id,
+						})
+						So(err, ShouldBeNil)
+
+						Convey("Then the event is moved back to the outbox", func() {
+							count, err := storage.GetEventOutboxCount(common.DB)
+							So(err, ShouldBeNil)
+							So(count, ShouldEqual, 1)
+
+							count, err = storage.GetEventDeadLetterCount(common.DB, orgId)
+							So(err, ShouldBeNil)
+							So(count, ShouldEqual, 0)
+						})
+					})
+
+					Convey("When purging the dead-lettered events", func() {
+						resp, err := api.PurgeDeadLetters(ctx, &pb.PurgeDeadLettersRequest{
+							OrganizationID: orgId,
+						})
+						So(err, ShouldBeNil)
+						So(resp.Count, ShouldEqual, 1)
+
+						Convey("Then no dead-lettered events are left", func() {
+							count, err := storage.GetEventDeadLetterCount(common.DB, orgId)
+							So(err, ShouldBeNil)
+							So(count, ShouldEqual, 0)
+						})
+					})
+				})
+
 				// Add a new user for adding to the organization.
 				Convey("When adding a user", func() {
 					userReq := &pb.AddUserRequest{
@@ -217,6 +353,95 @@ func TestOrganizationAPI(t *testing.T) {
 						})
 					})
 
+					Convey("When elevating the user to organization admin", func() {
+						validator.returnUsername = "admin"
+						createResp, err := api.CreateElevation(ctx, &pb.CreateOrganizationElevationRequest{
+							OrganizationID: orgId,
+							UserID:         userResp.Id,
+							Hours:          2,
+							Justification:  "INC-123",
+						})
+						So(err, ShouldBeNil)
+						So(validator.validatorFuncs, ShouldHaveLength, 1)
+						So(createResp.ExpiresAt, ShouldNotEqual, "")
+
+						Convey("Then the elevation has been logged", func() {
+							resp, err := api.ListElevations(ctx, &pb.ListOrganizationElevationsRequest{
+								OrganizationID: orgId,
+								Limit:          10,
+							})
+							So(err, ShouldBeNil)
+							So(resp.TotalCount, ShouldEqual, 1)
+							So(resp.Result, ShouldHaveLength, 1)
+							So(resp.Result[0].Id, ShouldEqual, createResp.Id)
+							So(resp.Result[0].Username, ShouldEqual, userReq.Username)
+							So(resp.Result[0].GrantedBy, ShouldEqual, "admin")
+							So(resp.Result[0].Justification, ShouldEqual, "INC-123")
+							So(resp.Result[0].Active, ShouldBeTrue)
+						})
+
+						Convey("Then the user should see the organization", func() {
+							validator.returnUsername = userReq.Username
+							orgs, err := api.List(ctx, &pb.ListOrganizationRequest{
+								Limit: 10,
+							})
+							So(err, ShouldBeNil)
+							So(orgs.TotalCount, ShouldEqual, 1)
+						})
+
+						Convey("When revoking the elevation", func() {
+							_, err := api.RevokeElevation(ctx, &pb.OrganizationElevationRequest{
+								OrganizationID: orgId,
+								Id:             createResp.Id,
+							})
+							So(err, ShouldBeNil)
+
+							Convey("Then the elevation is no longer active", func() {
+								resp, err := api.ListElevations(ctx, &pb.ListOrganizationElevationsRequest{
+									OrganizationID: orgId,
+									Limit:          10,
+								})
+								So(err, ShouldBeNil)
+								So(resp.Result, ShouldHaveLength, 1)
+								So(resp.Result[0].Active, ShouldBeFalse)
+								So(resp.Result[0].RevokedBy, ShouldEqual, "admin")
+							})
+
+							Convey("Then it can not be revoked twice", func() {
+								_, err := api.RevokeElevation(ctx, &pb.OrganizationElevationRequest{
+									OrganizationID: orgId,
+									Id:             createResp.Id,
+								})
+								So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+							})
+						})
+					})
+
+					Convey("When elevating the user without justification", func() {
+						_, err := api.CreateElevation(ctx, &pb.CreateOrganizationElevationRequest{
+							OrganizationID: orgId,
+							UserID:         userResp.Id,
+							Hours:          2,
+						})
+
+						Convey("Then an error is returned", func() {
+							So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+						})
+					})
+
+					Convey("When elevating the user for more than 24 hours", func() {
+						_, err := api.CreateElevation(ctx, &pb.CreateOrganizationElevationRequest{
+							OrganizationID: orgId,
+							UserID:         userResp.Id,
+							Hours:          25,
+							Justification:  "INC-123",
+						})
+
+						Convey("Then an error is returned", func() {
+							So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+						})
+					})
+
 					Convey("When deleting the organization", func() {
 						validator.returnIsAdmin = true
 
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/report"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// defaultReportDays defines the default number of days covered by a report
+// when no period is given.
+const defaultReportDays = 30
+
+// ApplicationReportHandler implements a http.Handler which generates the
+// reports of an application (device inventory, uptime and data volumes) as
+// downloadable CSV or PDF document.
+type ApplicationReportHandler struct {
+	validator auth.Validator
+}
+
+// NewApplicationReportHandler creates a new ApplicationReportHandler.
+func NewApplicationReportHandler(validator auth.Validator) *ApplicationReportHandler {
+	return &ApplicationReportHandler{
+		validator: validator,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *ApplicationReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID, err := strconv.ParseInt(vars["applicationID"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+
+	reportType := vars["report"]
+	switch reportType {
+	case report.Inventory, report.Uptime, report.DataVolume:
+	default:
+		http.Error(w, report.ErrInvalidReportType.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	format := report.CSV
+	if s := query.Get("format"); s != "" {
+		format = s
+	}
+	if format != report.CSV && format != report.PDF {
+		http.Error(w, "format must be csv or pdf", http.StatusBadRequest)
+		return
+	}
+
+	to := time.Now()
+	if s := query.Get("to"); s != "" {
+		if to, err = time.Parse(time.RFC3339, s); err != nil {
+			http.Error(w, fmt.Sprintf("to: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -defaultReportDays)
+	if s := query.Get("from"); s != "" {
+		if from, err = time.Parse(time.RFC3339, s); err != nil {
+			http.Error(w, fmt.Sprintf("from: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if !from.Before(to) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	ctx := getContextFromHTTPRequest(r)
+	if err := h.validator.Validate(ctx,
+		auth.ValidateNodesAccess(applicationID, auth.List)); err != nil {
+		http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+		return
+	}
+
+	app, err := storage.GetApplication(common.DB, applicationID)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			http.Error(w, "application does not exist", http.StatusNotFound)
+			return
+		}
+		log.WithField("application_id", applicationID).Errorf("get application error: %s", err)
+		http.Error(w, "get application error", http.StatusInternalServerError)
+		return
+	}
+
+	rep, err := report.Generate(reportType, app, from, to)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"application_id": applicationID,
+			"report":         reportType,
+		}).Errorf("generate report error: %s", err)
+		http.Error(w, "generate report error", http.StatusInternalServerError)
+		return
+	}
+
+	// the document is rendered before writing the response so that an error
+	// can still be returned
+	var buf bytes.Buffer
+	contentType := "text/csv"
+	if format == report.PDF {
+		contentType = "application/pdf"
+		err = report.WritePDF(&buf, rep)
+	} else {
+		err = report.WriteCSV(&buf, rep)
+	}
+	if err != nil {
+		log.WithField("report", reportType).Errorf("render report error: %s", err)
+		http.Error(w, "render report error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="application-%d-%s.%s"`, applicationID, reportType, format))
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Errorf("write report error: %s", err)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/requestlog"
+)
+
+// requestLogExcludedMethods contains the (full) gRPC method names which are
+// never logged.
+var requestLogExcludedMethods = map[string]bool{
+	"/api.Internal/Login":           true,
+	"/api.Internal/ListRequestLogs": true,
+}
+
+// RequestLogUnaryServerInterceptor implements a grpc.UnaryServerInterceptor
+// which stores the (sampled) requests and responses in the request log
+// (see the requestlog package), with the secrets redacted.
+func RequestLogUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !requestlog.Enabled() || requestLogExcludedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	if !requestlog.Sample(err != nil) {
+		return resp, err
+	}
+
+	e := requestlog.Entry{
+		CreatedAt: start,
+		Method:    info.FullMethod,
+		Code:      grpc.Code(err).String(),
+		Duration:  time.Since(start),
+		Request:   redactedJSON(req),
+	}
+	if err != nil {
+		e.Error = grpc.ErrorDesc(err)
+	} else {
+		e.Response = redactedJSON(resp)
+	}
+	e.RemoteAddress, e.UserAgent = clientFromContext(ctx)
+
+	if logErr := requestlog.Log(e); logErr != nil {
+		log.WithField("method", info.FullMethod).Errorf("log request error: %s", logErr)
+	}
+
+	return resp, err
+}
+
+// redactedJSON returns the given message as JSON, with the secrets redacted.
+// It returns nil when the message could not be marshaled.
+func redactedJSON(v interface{}) []byte {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	var m jsonpb.Marshaler
+	str, err := m.MarshalToString(msg)
+	if err != nil {
+		log.Errorf("marshal request log message error: %s", err)
+		return nil
+	}
+
+	b, err := requestlog.Redact([]byte(str))
+	if err != nil {
+		log.Errorf("redact request log message error: %s", err)
+		return nil
+	}
+	return b
+}
+
+// clientFromContext returns the remote address and user-agent of the
+// client. For requests made through the REST API, these are taken from the
+// metadata set by the grpc-gateway.
+func clientFromContext(ctx context.Context) (string, string) {
+	var addr, userAgent string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return addr, userAgent
+	}
+	if values := md["x-forwarded-for"]; len(values) > 0 {
+		addr = strings.TrimSpace(strings.Split(values[0], ",")[0])
+	}
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md[key]; len(values) > 0 {
+			userAgent = values[0]
+			break
+		}
+	}
+	return addr, userAgent
+}
+
+// ListRequestLogs returns the logged API requests.
+func (a *InternalUserAPI) ListRequestLogs(ctx context.Context, req *pb.ListRequestLogsRequest) (*pb.ListRequestLogsResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateIsAdmin()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	entries, err := requestlog.List(requestlog.Filter{
+		Method:     req.Method,
+		ErrorsOnly: req.ErrorsOnly,
+		Limit:      int(req.Limit),
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := pb.ListRequestLogsResponse{
+		Result: make([]*pb.RequestLog, 0, len(entries)),
+	}
+	for _, e := range entries {
+		resp.Result = append(resp.Result, &pb.RequestLog{
+			CreatedAt:     e.CreatedAt.Format(time.RFC3339Nano),
+			Method:        e.Method,
+			Code:          e.Code,
+			Error:         e.Error,
+			DurationMS:    int64(e.Duration / time.Millisecond),
+			RemoteAddress: e.RemoteAddress,
+			UserAgent:     e.UserAgent,
+			RequestJSON:   string(e.Request),
+			ResponseJSON:  string(e.Response),
+		})
+	}
+
+	return &resp, nil
+}
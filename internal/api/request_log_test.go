@@ -0,0 +1,81 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/requestlog"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestRequestLog(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a request log logging the failed requests", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		requestlog.SetConfig(requestlog.Config{
+			LogErrors:  true,
+			TTL:        time.Hour,
+			MaxEntries: 10,
+		})
+		defer requestlog.SetConfig(requestlog.Config{})
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"grpcgateway-user-agent", "test-client",
+			"x-forwarded-for", "203.0.113.10",
+		))
+		info := grpc.UnaryServerInfo{FullMethod: "/api.Node/Create"}
+		validator := &TestValidator{}
+		api := NewInternalUserAPI(validator)
+
+		Convey("When a request succeeds and a request fails", func() {
+			req := pb.CreateNodeRequest{
+				DevEUI: "0102030405060708",
+				AppKey: "01020304050607080102030405060708",
+			}
+			_, err := RequestLogUnaryServerInterceptor(ctx, &req, &info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.CreateNodeResponse{}, nil
+			})
+			So(err, ShouldBeNil)
+			_, err = RequestLogUnaryServerInterceptor(ctx, &req, &info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, grpc.Errorf(codes.InvalidArgument, "invalid request")
+			})
+			So(err, ShouldNotBeNil)
+
+			Convey("Then listing the request log requires a global admin user", func() {
+				validator.returnIsAdmin = false
+				validator.returnError = grpc.Errorf(codes.Unauthenticated, "not admin")
+				_, err := api.ListRequestLogs(ctx, &pb.ListRequestLogsRequest{})
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then only the failed request has been logged, with the secrets redacted", func() {
+				validator.returnIsAdmin = true
+				resp, err := api.ListRequestLogs(ctx, &pb.ListRequestLogsRequest{})
+				So(err, ShouldBeNil)
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+				So(resp.Result, ShouldHaveLength, 1)
+
+				rl := resp.Result[0]
+				So(rl.Method, ShouldEqual, "/api.Node/Create")
+				So(rl.Code, ShouldEqual, "InvalidArgument")
+				So(rl.Error, ShouldEqual, "invalid request")
+				So(rl.UserAgent, ShouldEqual, "test-client")
+				So(rl.RemoteAddress, ShouldEqual, "203.0.113.10")
+				So(rl.RequestJSON, ShouldContainSubstring, `"devEUI":"0102030405060708"`)
+				So(rl.RequestJSON, ShouldNotContainSubstring, "01020304050607080102030405060708")
+			})
+		})
+	})
+}
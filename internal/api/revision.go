@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	etagMetadataKey    = "etag"
+	ifMatchMetadataKey = "if-match"
+)
+
+// setETag sets the ETag (containing the revision of the object) as
+// response header. It is forwarded by the grpc-gateway as ETag header.
+func setETag(ctx context.Context, revision int64) {
+	// this only fails when there is no server transport stream within the
+	// given context (e.g. when calling the API method directly)
+	grpc.SetHeader(ctx, metadata.Pairs(etagMetadataKey, fmt.Sprintf(`"%d"`, revision)))
+}
+
+// getIfMatchRevision returns the revision from the If-Match header. This
+// header is required for updates, to make sure that the update is based on
+// the latest revision of the object. In case of the "*" wildcard, the given
+// current revision is returned.
+func getIfMatchRevision(ctx context.Context, current int64) (int64, error) {
+	return ifMatchRevision(ctx, current, true)
+}
+
+// getOptionalIfMatchRevision returns the revision from the If-Match header
+// or the given current revision when this header is not set.
+func getOptionalIfMatchRevision(ctx context.Context, current int64) (int64, error) {
+	return ifMatchRevision(ctx, current, false)
+}
+
+func ifMatchRevision(ctx context.Context, current int64, required bool) (int64, error) {
+	var ifMatch string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md[ifMatchMetadataKey]; len(values) > 0 {
+			ifMatch = strings.TrimSpace(values[0])
+		}
+	}
+
+	return parseRevision(ifMatch, current, required, "If-Match header")
+}
+
+// parseRevision returns the revision from the given ETag value, which is
+// identified by name in the returned errors. In case of an empty value (when
+// not required) or the "*" wildcard, the given current revision is returned.
+func parseRevision(value string, current int64, required bool, name string) (int64, error) {
+	if value == "" {
+		if !required {
+			return current, nil
+		}
+		return 0, grpc.Errorf(codes.FailedPrecondition, "%s is required", name)
+	}
+	if value == "*" {
+		return current, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	revision, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	if err != nil {
+		return 0, grpc.Errorf(codes.InvalidArgument, "invalid %s: %s", name, value)
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,901 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// SCIM schemas.
+const (
+	scimUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// scimMaxResults defines the max number of resources returned by a list
+// request.
+const scimMaxResults = 100
+
+// scimPrefix defines the path prefix of the SCIM endpoints.
+const scimPrefix = "/scim/v2"
+
+var (
+	scimFilterRegexp       = regexp.MustCompile(`^(\w+) eq "([^"]*)"$`)
+	scimMemberFilterRegexp = regexp.MustCompile(`^members\[value eq "([^"]*)"\]$`)
+	scimNameInvalidRegexp  = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// scimMaxNameSuffix defines the max. suffix appended to the organization
+// name derived from the group displayName, when the name is already in use.
+const scimMaxNameSuffix = 100
+
+// scimError defines a SCIM error response.
+type scimError struct {
+	Status   int
+	SCIMType string
+	Detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.Detail
+}
+
+func newSCIMError(status int, scimType, format string, a ...interface{}) *scimError {
+	return &scimError{
+		Status:   status,
+		SCIMType: scimType,
+		Detail:   fmt.Sprintf(format, a...),
+	}
+}
+
+type scimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+type scimUser struct {
+	Schemas  []string  `json:"schemas"`
+	ID       string    `json:"id,omitempty"`
+	UserName string    `json:"userName"`
+	Password string    `json:"password,omitempty"`
+	Active   *bool     `json:"active,omitempty"`
+	Meta     *scimMeta `json:"meta,omitempty"`
+}
+
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+// SCIMHandler implements a http.Handler serving the SCIM 2.0 (RFC 7644)
+// endpoints for provisioning users and groups by an identity platform.
+// SCIM groups are mapped to organizations (the displayName of the group
+// is the name of the organization), the members of the group are the
+// (non-admin) users of the organization. Requests must be authenticated
+// with the configured token as bearer token.
+type SCIMHandler struct {
+	token string
+}
+
+// NewSCIMHandler creates a new SCIMHandler.
+func NewSCIMHandler(token string) *SCIMHandler {
+	return &SCIMHandler{
+		token: token,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *SCIMHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		writeSCIMError(w, newSCIMError(http.StatusUnauthorized, "", "authentication failed"))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, scimPrefix), "/"), "/")
+	var id int64
+	if len(parts) == 2 {
+		var err error
+		id, err = strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			writeSCIMError(w, newSCIMError(http.StatusNotFound, "", "resource %s not found", parts[1]))
+			return
+		}
+	} else if len(parts) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	var status int
+	var resp interface{}
+	var err error
+
+	switch {
+	case parts[0] == "ServiceProviderConfig" && len(parts) == 1 && r.Method == "GET":
+		status, resp = http.StatusOK, scimServiceProviderConfig()
+	case parts[0] == "Users" && len(parts) == 1 && r.Method == "GET":
+		status = http.StatusOK
+		resp, err = h.listUsers(r)
+	case parts[0] == "Users" && len(parts) == 1 && r.Method == "POST":
+		status = http.StatusCreated
+		resp, err = h.createUser(r)
+	case parts[0] == "Users" && len(parts) == 2 && r.Method == "GET":
+		status = http.StatusOK
+		resp, err = h.getUser(r, id)
+	case parts[0] == "Users" && len(parts) == 2 && r.Method == "PUT":
+		status = http.StatusOK
+		resp, err = h.replaceUser(r, id)
+	case parts[0] == "Users" && len(parts) == 2 && r.Method == "PATCH":
+		status = http.StatusOK
+		resp, err = h.patchUser(r, id)
+	case parts[0] == "Users" && len(parts) == 2 && r.Method == "DELETE":
+		status = http.StatusNoContent
+		err = storage.DeleteUser(common.DB, id)
+	case parts[0] == "Groups" && len(parts) == 1 && r.Method == "GET":
+		status = http.StatusOK
+		resp, err = h.listGroups(r)
+	case parts[0] == "Groups" && len(parts) == 1 && r.Method == "POST":
+		status = http.StatusCreated
+		resp, err = h.createGroup(r)
+	case parts[0] == "Groups" && len(parts) == 2 && r.Method == "GET":
+		status = http.StatusOK
+		resp, err = h.getGroup(r, id)
+	case parts[0] == "Groups" && len(parts) == 2 && r.Method == "PUT":
+		status = http.StatusOK
+		resp, err = h.replaceGroup(r, id)
+	case parts[0] == "Groups" && len(parts) == 2 && r.Method == "PATCH":
+		status = http.StatusOK
+		resp, err = h.patchGroup(r, id)
+	case parts[0] == "Groups" && len(parts) == 2 && r.Method == "DELETE":
+		// deleting an organization would delete all its applications,
+		// nodes and gateways
+		err = newSCIMError(http.StatusForbidden, "mutability", "organizations can't be deleted through scim")
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		writeSCIMError(w, scimErrorFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	if resp == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorf("encode scim response error: %s", err)
+	}
+}
+
+// listUsers returns the users, optionally filtered with a
+// userName eq "..." filter.
+func (h *SCIMHandler) listUsers(r *http.Request) (*scimListResponse, error) {
+	startIndex, count, err := scimPagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []storage.User
+	var total int
+
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		attr, value, err := parseSCIMFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		if attr != "userName" {
+			return nil, newSCIMError(http.StatusBadRequest, "invalidFilter", "filtering on %s is not supported", attr)
+		}
+		user, err := storage.GetUserByUsername(common.DB, value)
+		if err != nil && err != storage.ErrDoesNotExist {
+			return nil, err
+		}
+		if err == nil {
+			total = 1
+			if startIndex == 1 && count > 0 {
+				users = append(users, user)
+			}
+		}
+	} else {
+		c, err := storage.GetUserCount(common.DB, "")
+		if err != nil {
+			return nil, err
+		}
+		total = int(c)
+		users, err = storage.GetUsers(common.DB, int32(count), int32(startIndex-1), "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := newSCIMListResponse(total, startIndex)
+	for _, u := range users {
+		resp.Resources = append(resp.Resources, scimUserFromUser(r, u))
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	return resp, nil
+}
+
+// createUser creates the given user. When no password is given, a random
+// password is set.
+func (h *SCIMHandler) createUser(r *http.Request) (*scimUser, error) {
+	var su scimUser
+	if err := decodeSCIMRequest(r, &su); err != nil {
+		return nil, err
+	}
+
+	password := su.Password
+	if password == "" {
+		var err error
+		password, err = randomSCIMPassword()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	user := storage.User{
+		Username: su.UserName,
+		IsActive: su.Active == nil || *su.Active,
+	}
+	if _, err := storage.CreateUser(common.DB, &user, password); err != nil {
+		return nil, err
+	}
+
+	return scimUserFromUser(r, user), nil
+}
+
+// getUser returns the given user.
+func (h *SCIMHandler) getUser(r *http.Request, id int64) (*scimUser, error) {
+	user, err := storage.GetUser(common.DB, id)
+	if err != nil {
+		return nil, err
+	}
+	return scimUserFromUser(r, user), nil
+}
+
+// replaceUser replaces the userName, active flag and (when given) the
+// password of the given user.
+func (h *SCIMHandler) replaceUser(r *http.Request, id int64) (*scimUser, error) {
+	var su scimUser
+	if err := decodeSCIMRequest(r, &su); err != nil {
+		return nil, err
+	}
+
+	user, err := storage.GetUser(common.DB, id)
+	if err != nil {
+		return nil, err
+	}
+	user.Username = su.UserName
+	user.IsActive = su.Active == nil || *su.Active
+
+	if err := updateSCIMUser(user); err != nil {
+		return nil, err
+	}
+	if su.Password != "" {
+		if err := storage.UpdatePassword(common.DB, id, su.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	return h.getUser(r, id)
+}
+
+// patchUser applies the given patch operations to the given user. Only the
+// userName and active attributes can be patched.
+func (h *SCIMHandler) patchUser(r *http.Request, id int64) (*scimUser, error) {
+	var req scimPatchRequest
+	if err := decodeSCIMRequest(r, &req); err != nil {
+		return nil, err
+	}
+
+	user, err := storage.GetUser(common.DB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range req.Operations {
+		if !strings.EqualFold(op.Op, "replace") && !strings.EqualFold(op.Op, "add") {
+			return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "unsupported operation %s", op.Op)
+		}
+
+		values := make(map[string]json.RawMessage)
+		if op.Path != "" {
+			values[op.Path] = op.Value
+		} else if err := json.Unmarshal(op.Value, &values); err != nil {
+			return nil, newSCIMError(http.StatusBadRequest, "invalidSyntax", "invalid value: %s", err)
+		}
+
+		for path, value := range values {
+			switch path {
+			case "userName":
+				if err := json.Unmarshal(value, &user.Username); err != nil {
+					return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid userName: %s", err)
+				}
+			case "active":
+				active, err := parseSCIMBool(value)
+				if err != nil {
+					return nil, err
+				}
+				user.IsActive = active
+			default:
+				return nil, newSCIMError(http.StatusBadRequest, "invalidPath", "unsupported path %s", path)
+			}
+		}
+	}
+
+	if err := updateSCIMUser(user); err != nil {
+		return nil, err
+	}
+
+	return h.getUser(r, id)
+}
+
+// listGroups returns the groups, optionally filtered with a
+// displayName eq "..." filter.
+func (h *SCIMHandler) listGroups(r *http.Request) (*scimListResponse, error) {
+	startIndex, count, err := scimPagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgs []storage.Organization
+	var total int
+
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		attr, value, err := parseSCIMFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		if attr != "displayName" {
+			return nil, newSCIMError(http.StatusBadRequest, "invalidFilter", "filtering on %s is not supported", attr)
+		}
+		orgs, err = storage.GetOrganizationsByDisplayName(common.DB, value)
+		if err != nil {
+			return nil, err
+		}
+		total = len(orgs)
+		if startIndex > len(orgs) {
+			orgs = nil
+		} else {
+			orgs = orgs[startIndex-1:]
+		}
+		if len(orgs) > count {
+			orgs = orgs[:count]
+		}
+	} else {
+		total, err = storage.GetOrganizationCount(common.DB, "")
+		if err != nil {
+			return nil, err
+		}
+		orgs, err = storage.GetOrganizations(common.DB, count, startIndex-1, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := newSCIMListResponse(total, startIndex)
+	for _, org := range orgs {
+		g, err := scimGroupFromOrganization(r, org)
+		if err != nil {
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, g)
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	return resp, nil
+}
+
+// createGroup creates an organization for the given group and adds the
+// members as organization users.
+func (h *SCIMHandler) createGroup(r *http.Request) (*scimGroup, error) {
+	var sg scimGroup
+	if err := decodeSCIMRequest(r, &sg); err != nil {
+		return nil, err
+	}
+
+	var org storage.Organization
+	err := setSCIMOrganizationName(&org, sg.DisplayName, func() error {
+		return storage.CreateOrganization(common.DB, &org)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := setSCIMGroupMembers(org.ID, sg.Members); err != nil {
+		return nil, err
+	}
+
+	return h.getGroup(r, org.ID)
+}
+
+// getGroup returns the given group.
+func (h *SCIMHandler) getGroup(r *http.Request, id int64) (*scimGroup, error) {
+	org, err := storage.GetOrganization(common.DB, id)
+	if err != nil {
+		return nil, err
+	}
+	return scimGroupFromOrganization(r, org)
+}
+
+// replaceGroup replaces the displayName and members of the given group.
+func (h *SCIMHandler) replaceGroup(r *http.Request, id int64) (*scimGroup, error) {
+	var sg scimGroup
+	if err := decodeSCIMRequest(r, &sg); err != nil {
+		return nil, err
+	}
+
+	org, err := storage.GetOrganization(common.DB, id)
+	if err != nil {
+		return nil, err
+	}
+	if sg.DisplayName != "" && sg.DisplayName != org.DisplayName {
+		err := setSCIMOrganizationName(&org, sg.DisplayName, func() error {
+			return storage.UpdateOrganization(common.DB, &org)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := setSCIMGroupMembers(id, sg.Members); err != nil {
+		return nil, err
+	}
+
+	return h.getGroup(r, id)
+}
+
+// patchGroup applies the given patch operations to the given group. The
+// members can be added, removed and replaced and the displayName can be
+// replaced.
+func (h *SCIMHandler) patchGroup(r *http.Request, id int64) (*scimGroup, error) {
+	var req scimPatchRequest
+	if err := decodeSCIMRequest(r, &req); err != nil {
+		return nil, err
+	}
+
+	org, err := storage.GetOrganization(common.DB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range req.Operations {
+		switch {
+		case strings.EqualFold(op.Op, "add") && op.Path == "members":
+			var members []scimMember
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid members: %s", err)
+			}
+			for _, m := range members {
+				userID, err := parseSCIMMemberID(m.Value)
+				if err != nil {
+					return nil, err
+				}
+				err = storage.CreateOrganizationUser(common.DB, id, userID, false)
+				if err != nil && err != storage.ErrAlreadyExists {
+					return nil, err
+				}
+			}
+		case strings.EqualFold(op.Op, "remove") && scimMemberFilterRegexp.MatchString(op.Path):
+			userID, err := parseSCIMMemberID(scimMemberFilterRegexp.FindStringSubmatch(op.Path)[1])
+			if err != nil {
+				return nil, err
+			}
+			if err := deleteSCIMGroupMember(id, userID); err != nil {
+				return nil, err
+			}
+		case strings.EqualFold(op.Op, "remove") && op.Path == "members":
+			var members []scimMember
+			if len(op.Value) != 0 {
+				if err := json.Unmarshal(op.Value, &members); err != nil {
+					return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid members: %s", err)
+				}
+			}
+			// without value, all members are removed
+			if members == nil {
+				if err := setSCIMGroupMembers(id, nil); err != nil {
+					return nil, err
+				}
+			}
+			for _, m := range members {
+				userID, err := parseSCIMMemberID(m.Value)
+				if err != nil {
+					return nil, err
+				}
+				if err := deleteSCIMGroupMember(id, userID); err != nil {
+					return nil, err
+				}
+			}
+		case strings.EqualFold(op.Op, "replace") && op.Path == "members":
+			var members []scimMember
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid members: %s", err)
+			}
+			if err := setSCIMGroupMembers(id, members); err != nil {
+				return nil, err
+			}
+		case strings.EqualFold(op.Op, "replace") && (op.Path == "displayName" || op.Path == ""):
+			var name string
+			if op.Path == "" {
+				var v struct {
+					DisplayName string `json:"displayName"`
+				}
+				if err := json.Unmarshal(op.Value, &v); err != nil {
+					return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid value: %s", err)
+				}
+				name = v.DisplayName
+			} else if err := json.Unmarshal(op.Value, &name); err != nil {
+				return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid displayName: %s", err)
+			}
+			err := setSCIMOrganizationName(&org, name, func() error {
+				return storage.UpdateOrganization(common.DB, &org)
+			})
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, newSCIMError(http.StatusBadRequest, "invalidPath", "unsupported %s operation on path %s", op.Op, op.Path)
+		}
+	}
+
+	return h.getGroup(r, id)
+}
+
+// setSCIMOrganizationName sets the display name of the given organization to
+// the given group displayName and its name to a slug derived from it, after
+// which the organization is saved using the given function. When the name is
+// already in use, a numeric suffix is appended.
+func setSCIMOrganizationName(org *storage.Organization, displayName string, save func() error) error {
+	if displayName == "" {
+		return newSCIMError(http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+
+	name := strings.Trim(scimNameInvalidRegexp.ReplaceAllString(strings.ToLower(displayName), "-"), "-")
+	if name == "" {
+		name = "group"
+	}
+
+	org.DisplayName = displayName
+	for i := 1; i <= scimMaxNameSuffix; i++ {
+		org.Name = name
+		if i > 1 {
+			org.Name = fmt.Sprintf("%s-%d", name, i)
+		}
+
+		err := save()
+		if errors.Cause(err) != storage.ErrAlreadyExists {
+			return err
+		}
+	}
+	return newSCIMError(http.StatusConflict, "uniqueness", "no unique name available for displayName %s", displayName)
+}
+
+// setSCIMGroupMembers sets the (non-admin) users of the given organization
+// to the given members. Organization admins are not changed.
+func setSCIMGroupMembers(organizationID int64, members []scimMember) error {
+	wanted := make(map[int64]bool)
+	for _, m := range members {
+		userID, err := parseSCIMMemberID(m.Value)
+		if err != nil {
+			return err
+		}
+		wanted[userID] = true
+	}
+
+	users, err := getSCIMGroupUsers(organizationID)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if wanted[u.UserID] {
+			delete(wanted, u.UserID)
+			continue
+		}
+		if err := storage.DeleteOrganizationUser(common.DB, organizationID, u.UserID); err != nil {
+			return err
+		}
+	}
+
+	for userID := range wanted {
+		// the user might already be an organization admin
+		err := storage.CreateOrganizationUser(common.DB, organizationID, userID, false)
+		if err != nil && err != storage.ErrAlreadyExists {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteSCIMGroupMember removes the given user from the given organization,
+// unless the user is an organization admin.
+func deleteSCIMGroupMember(organizationID, userID int64) error {
+	user, err := storage.GetOrganizationUser(common.DB, organizationID, userID)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return err
+	}
+	if user.IsAdmin {
+		return nil
+	}
+
+	err = storage.DeleteOrganizationUser(common.DB, organizationID, userID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return err
+	}
+	return nil
+}
+
+// getSCIMGroupUsers returns the users of the given organization which are
+// not organization admin, as the admins are managed manually.
+func getSCIMGroupUsers(organizationID int64) ([]storage.OrganizationUser, error) {
+	count, err := storage.GetOrganizationUserCount(common.DB, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := storage.GetOrganizationUsers(common.DB, organizationID, count, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []storage.OrganizationUser
+	for _, u := range users {
+		if !u.IsAdmin {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+// updateSCIMUser updates the username and active flag of the given user.
+func updateSCIMUser(user storage.User) error {
+	// validated upfront as storage.UpdateUser does not return the
+	// validation error as such
+	if err := storage.ValidateUsername(user.Username); err != nil {
+		return err
+	}
+	return storage.UpdateUser(common.DB, storage.UserUpdate{
+		ID:         user.ID,
+		Username:   user.Username,
+		IsAdmin:    user.IsAdmin,
+		IsActive:   user.IsActive,
+		SessionTTL: user.SessionTTL,
+	})
+}
+
+func scimUserFromUser(r *http.Request, user storage.User) *scimUser {
+	active := user.IsActive
+	return &scimUser{
+		Schemas:  []string{scimUserSchema},
+		ID:       strconv.FormatInt(user.ID, 10),
+		UserName: user.Username,
+		Active:   &active,
+		Meta: &scimMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     scimLocation(r, "Users", user.ID),
+		},
+	}
+}
+
+func scimGroupFromOrganization(r *http.Request, org storage.Organization) (*scimGroup, error) {
+	users, err := getSCIMGroupUsers(org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	g := scimGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          strconv.FormatInt(org.ID, 10),
+		DisplayName: org.DisplayName,
+		Members:     []scimMember{},
+		Meta: &scimMeta{
+			ResourceType: "Group",
+			Created:      org.CreatedAt,
+			LastModified: org.UpdatedAt,
+			Location:     scimLocation(r, "Groups", org.ID),
+		},
+	}
+	for _, u := range users {
+		g.Members = append(g.Members, scimMember{
+			Value:   strconv.FormatInt(u.UserID, 10),
+			Display: u.Username,
+		})
+	}
+	return &g, nil
+}
+
+func scimServiceProviderConfig() interface{} {
+	supported := func(b bool) map[string]interface{} {
+		return map[string]interface{}{"supported": b}
+	}
+	return map[string]interface{}{
+		"schemas":        []string{scimServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimMaxResults},
+		"changePassword": supported(true),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication using the configured bearer token",
+			},
+		},
+	}
+}
+
+func newSCIMListResponse(total, startIndex int) *scimListResponse {
+	return &scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		Resources:    []interface{}{},
+	}
+}
+
+// scimLocation returns the location URI of the given resource.
+func scimLocation(r *http.Request, resource string, id int64) string {
+	return fmt.Sprintf("https://%s%s/%s/%d", r.Host, scimPrefix, resource, id)
+}
+
+// scimPagination returns the startIndex (1-based) and count of the given
+// request.
+func scimPagination(r *http.Request) (int, int, error) {
+	startIndex, count := 1, scimMaxResults
+	if s := r.URL.Query().Get("startIndex"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid startIndex: %s", err)
+		}
+		if i > 1 {
+			startIndex = i
+		}
+	}
+	if s := r.URL.Query().Get("count"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid count: %s", err)
+		}
+		if i < 0 {
+			i = 0
+		}
+		if i < count {
+			count = i
+		}
+	}
+	return startIndex, count, nil
+}
+
+// parseSCIMFilter parses the given filter. Only the [attribute] eq "[value]"
+// filter is supported.
+func parseSCIMFilter(filter string) (string, string, error) {
+	m := scimFilterRegexp.FindStringSubmatch(strings.TrimSpace(filter))
+	if m == nil {
+		return "", "", newSCIMError(http.StatusBadRequest, "invalidFilter", "unsupported filter, expected: [attribute] eq \"[value]\"")
+	}
+	return m[1], m[2], nil
+}
+
+// parseSCIMBool parses the given boolean value. Some identity platforms
+// send booleans as string (e.g. "False").
+func parseSCIMBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b, nil
+		}
+	}
+	return false, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid boolean value: %s", value)
+}
+
+func parseSCIMMemberID(value string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid member %s", value)
+	}
+	return id, nil
+}
+
+func decodeSCIMRequest(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newSCIMError(http.StatusBadRequest, "invalidSyntax", "decode request error: %s", err)
+	}
+	return nil
+}
+
+// randomSCIMPassword returns a random password, used for users provisioned
+// without password.
+func randomSCIMPassword() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random bytes error")
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// scimErrorFromError returns the SCIM error for the given error.
+func scimErrorFromError(err error) *scimError {
+	if e, ok := err.(*scimError); ok {
+		return e
+	}
+
+	switch errors.Cause(err) {
+	case storage.ErrDoesNotExist:
+		return newSCIMError(http.StatusNotFound, "", "resource not found")
+	case storage.ErrAlreadyExists:
+		return newSCIMError(http.StatusConflict, "uniqueness", "resource already exists")
+	case storage.ErrUserInvalidUsername, storage.ErrUserPasswordLength, storage.ErrOrganizationInvalidName:
+		return newSCIMError(http.StatusBadRequest, "invalidValue", "%s", errors.Cause(err))
+	}
+
+	log.Errorf("scim request error: %s", err)
+	return newSCIMError(http.StatusInternalServerError, "", "internal server error")
+}
+
+func writeSCIMError(w http.ResponseWriter, e *scimError) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(e.Status)
+	err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"schemas":  []string{scimErrorSchema},
+		"status":   strconv.Itoa(e.Status),
+		"scimType": e.SCIMType,
+		"detail":   e.Detail,
+	})
+	if err != nil {
+		log.Errorf("encode scim error response error: %s", err)
+	}
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestSCIMHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and a scim handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		scimHandler := NewSCIMHandler("secret")
+		do := func(method, path, token, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, "/scim/v2"+path, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			scimHandler.ServeHTTP(w, req)
+			return w
+		}
+
+		Convey("When sending a request with an invalid token", func() {
+			w := do("GET", "/Users", "foo", "")
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+
+		Convey("When creating a user without password", func() {
+			w := do("POST", "/Users", "secret", `{"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"], "userName": "jdoe", "active": true}`)
+			So(w.Code, ShouldEqual, http.StatusCreated)
+
+			var user scimUser
+			So(json.NewDecoder(w.Body).Decode(&user), ShouldBeNil)
+
+			Convey("Then the user was created", func() {
+				u, err := storage.GetUserByUsername(common.DB, "jdoe")
+				So(err, ShouldBeNil)
+				So(user.ID, ShouldEqual, fmt.Sprintf("%d", u.ID))
+				So(u.IsActive, ShouldBeTrue)
+				So(u.IsAdmin, ShouldBeFalse)
+			})
+
+			Convey("Then the user can be found by its userName", func() {
+				w := do("GET", "/Users?filter=userName+eq+%22jdoe%22", "secret", "")
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var resp scimListResponse
+				So(json.NewDecoder(w.Body).Decode(&resp), ShouldBeNil)
+				So(resp.TotalResults, ShouldEqual, 1)
+			})
+
+			Convey("When creating the same user again", func() {
+				w := do("POST", "/Users", "secret", `{"userName": "jdoe"}`)
+
+				Convey("Then a 409 is returned", func() {
+					So(w.Code, ShouldEqual, http.StatusConflict)
+				})
+			})
+
+			Convey("When deactivating the user", func() {
+				w := do("PATCH", "/Users/"+user.ID, "secret", `{"Operations": [{"op": "Replace", "path": "active", "value": "False"}]}`)
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				Convey("Then the user is inactive", func() {
+					u, err := storage.GetUserByUsername(common.DB, "jdoe")
+					So(err, ShouldBeNil)
+					So(u.IsActive, ShouldBeFalse)
+				})
+			})
+
+			Convey("When creating a group with the user as member", func() {
+				w := do("POST", "/Groups", "secret", fmt.Sprintf(`{"displayName": "Test Org", "members": [{"value": "%s"}]}`, user.ID))
+				So(w.Code, ShouldEqual, http.StatusCreated)
+
+				var group scimGroup
+				So(json.NewDecoder(w.Body).Decode(&group), ShouldBeNil)
+
+				Convey("Then the organization was created with the user", func() {
+					orgs, err := storage.GetOrganizationsByDisplayName(common.DB, "Test Org")
+					So(err, ShouldBeNil)
+					So(orgs, ShouldHaveLength, 1)
+					So(orgs[0].Name, ShouldEqual, "test-org")
+					So(group.ID, ShouldEqual, fmt.Sprintf("%d", orgs[0].ID))
+					So(group.DisplayName, ShouldEqual, "Test Org")
+					So(group.Members, ShouldHaveLength, 1)
+					So(group.Members[0].Display, ShouldEqual, "jdoe")
+				})
+
+				Convey("Then the group can be found by its displayName", func() {
+					w := do("GET", "/Groups?filter=displayName+eq+%22Test+Org%22", "secret", "")
+					So(w.Code, ShouldEqual, http.StatusOK)
+
+					var resp scimListResponse
+					So(json.NewDecoder(w.Body).Decode(&resp), ShouldBeNil)
+					So(resp.TotalResults, ShouldEqual, 1)
+				})
+
+				Convey("When creating a group with a displayName resulting in the same name", func() {
+					w := do("POST", "/Groups", "secret", `{"displayName": "Test / Org"}`)
+					So(w.Code, ShouldEqual, http.StatusCreated)
+
+					var group scimGroup
+					So(json.NewDecoder(w.Body).Decode(&group), ShouldBeNil)
+
+					Convey("Then a suffix is appended to the organization name", func() {
+						orgs, err := storage.GetOrganizationsByDisplayName(common.DB, "Test / Org")
+						So(err, ShouldBeNil)
+						So(orgs, ShouldHaveLength, 1)
+						So(orgs[0].Name, ShouldEqual, "test-org-2")
+						So(group.DisplayName, ShouldEqual, "Test / Org")
+					})
+				})
+
+				Convey("When renaming the group", func() {
+					w := do("PATCH", "/Groups/"+group.ID, "secret", `{"Operations": [{"op": "replace", "path": "displayName", "value": "Sales & Marketing"}]}`)
+					So(w.Code, ShouldEqual, http.StatusOK)
+
+					Convey("Then the display name and name are updated", func() {
+						orgs, err := storage.GetOrganizationsByDisplayName(common.DB, "Sales & Marketing")
+						So(err, ShouldBeNil)
+						So(orgs, ShouldHaveLength, 1)
+						So(orgs[0].Name, ShouldEqual, "sales-marketing")
+					})
+				})
+
+				Convey("Given an organization admin added by hand", func() {
+					admin := storage.User{
+						Username: "admin2",
+						IsActive: true,
+					}
+					adminID, err := storage.CreateUser(common.DB, &admin, "password123")
+					So(err, ShouldBeNil)
+					orgID, err := strconv.ParseInt(group.ID, 10, 64)
+					So(err, ShouldBeNil)
+					So(storage.CreateOrganizationUser(common.DB, orgID, adminID, true), ShouldBeNil)
+
+					Convey("Then the admin is not listed as member", func() {
+						w := do("GET", "/Groups/"+group.ID, "secret", "")
+						So(w.Code, ShouldEqual, http.StatusOK)
+
+						var group scimGroup
+						So(json.NewDecoder(w.Body).Decode(&group), ShouldBeNil)
+						So(group.Members, ShouldHaveLength, 1)
+						So(group.Members[0].Display, ShouldEqual, "jdoe")
+					})
+
+					Convey("When replacing the members", func() {
+						w := do("PUT", "/Groups/"+group.ID, "secret", `{"displayName": "Test Org", "members": []}`)
+						So(w.Code, ShouldEqual, http.StatusOK)
+
+						Convey("Then the admin is kept", func() {
+							u, err := storage.GetOrganizationUser(common.DB, orgID, adminID)
+							So(err, ShouldBeNil)
+							So(u.IsAdmin, ShouldBeTrue)
+
+							count, err := storage.GetOrganizationUserCount(common.DB, orgID)
+							So(err, ShouldBeNil)
+							So(count, ShouldEqual, 1)
+						})
+					})
+
+					Convey("When removing the admin as member", func() {
+						w := do("PATCH", "/Groups/"+group.ID, "secret", fmt.Sprintf(`{"Operations": [{"op": "remove", "path": "members[value eq \"%d\"]"}]}`, adminID))
+						So(w.Code, ShouldEqual, http.StatusOK)
+
+						Convey("Then the admin is kept", func() {
+							_, err := storage.GetOrganizationUser(common.DB, orgID, adminID)
+							So(err, ShouldBeNil)
+						})
+					})
+				})
+
+				Convey("When removing the member", func() {
+					w := do("PATCH", "/Groups/"+group.ID, "secret", fmt.Sprintf(`{"Operations": [{"op": "remove", "path": "members[value eq \"%s\"]"}]}`, user.ID))
+					So(w.Code, ShouldEqual, http.StatusOK)
+
+					Convey("Then the group has no members", func() {
+						var group scimGroup
+						So(json.NewDecoder(w.Body).Decode(&group), ShouldBeNil)
+						So(group.Members, ShouldHaveLength, 0)
+					})
+				})
+
+				Convey("When deleting the group", func() {
+					w := do("DELETE", "/Groups/"+group.ID, "secret", "")
+
+					Convey("Then a 403 is returned", func() {
+						So(w.Code, ShouldEqual, http.StatusForbidden)
+					})
+				})
+			})
+		})
+
+		Convey("When creating a user with an invalid userName", func() {
+			w := do("POST", "/Users", "secret", `{"userName": "j.doe@example.com"}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When getting an unknown user", func() {
+			w := do("GET", "/Users/1234", "secret", "")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/linkquality"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// StatusPageResponse contains the anonymized, aggregated health data of an
+// application, as returned by the StatusPageHandler.
+type StatusPageResponse struct {
+	Name         string             `json:"name"`
+	GeneratedAt  time.Time          `json:"generatedAt"`
+	Hours        uint32             `json:"hours"`
+	Devices      StatusPageDevices  `json:"devices"`
+	Uplinks      StatusPageUplinks  `json:"uplinks"`
+	Availability float64            `json:"availability"`
+	History      []StatusPageBucket `json:"history"`
+}
+
+// StatusPageDevices contains the number of (enabled) devices and the number
+// of devices from which uplinks were received within the period.
+type StatusPageDevices struct {
+	Total            int     `json:"total"`
+	Online           int     `json:"online"`
+	OnlinePercentage float64 `json:"onlinePercentage"`
+}
+
+// StatusPageUplinks contains the number of uplinks received within the
+// period and the average number of uplinks per hour.
+type StatusPageUplinks struct {
+	Total   int     `json:"total"`
+	PerHour float64 `json:"perHour"`
+}
+
+// StatusPageBucket contains the number of uplinks and online devices within
+// one hour of the period.
+type StatusPageBucket struct {
+	Time          time.Time `json:"time"`
+	Uplinks       int       `json:"uplinks"`
+	DevicesOnline int       `json:"devicesOnline"`
+}
+
+// StatusPageHandler implements a http.Handler which returns the aggregated
+// health data of an application, for embedding in (customer-facing) status
+// pages. The application is selected by the token of its status page, no
+// further authentication is required. The returned data does not contain
+// any device identities.
+type StatusPageHandler struct{}
+
+// NewStatusPageHandler creates a new StatusPageHandler.
+func NewStatusPageHandler() *StatusPageHandler {
+	return &StatusPageHandler{}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *StatusPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	hours := uint32(defaultLinkQualityHours)
+	if s := r.URL.Query().Get("hours"); s != "" {
+		i, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || i == 0 || i > maxLinkQualityHours {
+			http.Error(w, fmt.Sprintf("hours must be between 1 and %d", maxLinkQualityHours), http.StatusBadRequest)
+			return
+		}
+		hours = uint32(i)
+	}
+
+	sp, err := storage.GetApplicationStatusPageByToken(common.DB, mux.Vars(r)["token"])
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			http.Error(w, "status page does not exist", http.StatusNotFound)
+			return
+		}
+		log.Errorf("api: get status page error: %s", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := getStatusPage(sp.ApplicationID, hours)
+	if err != nil {
+		log.WithField("application_id", sp.ApplicationID).Errorf("api: get status page data error: %s", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorf("api: encode status page error: %s", err)
+	}
+}
+
+// getStatusPage returns the aggregated health data of the given application
+// over the given number of hours.
+func getStatusPage(applicationID int64, hours uint32) (StatusPageResponse, error) {
+	resp := StatusPageResponse{
+		GeneratedAt: time.Now(),
+		Hours:       hours,
+		History:     []StatusPageBucket{},
+	}
+
+	since, err := linkQualitySince(hours)
+	if err != nil {
+		return resp, err
+	}
+
+	app, err := storage.GetApplication(common.DB, applicationID)
+	if err != nil {
+		return resp, err
+	}
+	resp.Name = app.Name
+
+	lqs, err := storage.GetLinkQualityForApplicationNodes(common.DB, applicationID, since)
+	if err != nil {
+		return resp, err
+	}
+
+	var received, expected int
+	for _, lq := range lqs {
+		if lq.Uplinks > 0 {
+			resp.Devices.Online++
+		}
+		resp.Uplinks.Total += lq.Uplinks
+		received += linkquality.ReceivedUplinks(lq.LinkQuality)
+		expected += linkquality.ExpectedUplinks(lq.LinkQuality, 0, 0)
+	}
+	resp.Devices.Total = len(lqs)
+	if resp.Devices.Total > 0 {
+		resp.Devices.OnlinePercentage = float64(resp.Devices.Online) / float64(resp.Devices.Total) * 100
+	}
+	resp.Uplinks.PerHour = float64(resp.Uplinks.Total) / float64(hours)
+	resp.Availability = linkquality.Availability(received, expected)
+
+	buckets, err := storage.GetApplicationUplinkBuckets(common.DB, applicationID, since)
+	if err != nil {
+		return resp, err
+	}
+	for _, b := range buckets {
+		resp.History = append(resp.History, StatusPageBucket{
+			Time:          b.Bucket,
+			Uplinks:       b.Uplinks,
+			DevicesOnline: b.Nodes,
+		})
+	}
+
+	return resp, nil
+}
+
+// statusPagePath returns the path of the status page data endpoint for the
+// given token.
+func statusPagePath(token string) string {
+	return "/api/status/" + token
+}
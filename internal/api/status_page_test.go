@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestStatusPageHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application with two nodes and a status page", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-organization",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		for i, name := range []string{"node-1", "node-2"} {
+			So(storage.CreateNode(db, storage.Node{
+				ApplicationID: app.ID,
+				Name:          name,
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, byte(i)},
+			}), ShouldBeNil)
+		}
+
+		So(storage.AddLinkQuality(db, storage.LinkQuality{
+			DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 0},
+			Bucket:  time.Now().Truncate(time.Hour),
+			Uplinks: 12,
+			Missed:  4,
+		}), ShouldBeNil)
+
+		sp, err := storage.EnableApplicationStatusPage(db, app.ID)
+		So(err, ShouldBeNil)
+
+		r := mux.NewRouter()
+		r.Handle("/api/status/{token}", NewStatusPageHandler())
+
+		get := func(path string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+			return rec
+		}
+
+		Convey("When requesting the status page data", func() {
+			rec := get(statusPagePath(sp.Token))
+			So(rec.Code, ShouldEqual, http.StatusOK)
+
+			var resp StatusPageResponse
+			So(json.NewDecoder(rec.Body).Decode(&resp), ShouldBeNil)
+
+			Convey("Then the aggregated health data is returned", func() {
+				So(resp.Name, ShouldEqual, "test-app")
+				So(resp.Hours, ShouldEqual, defaultLinkQualityHours)
+				So(resp.Devices, ShouldResemble, StatusPageDevices{Total: 2, Online: 1, OnlinePercentage: 50})
+				So(resp.Uplinks, ShouldResemble, StatusPageUplinks{Total: 12, PerHour: 0.5})
+				So(resp.Availability, ShouldEqual, 75)
+				So(resp.History, ShouldHaveLength, 1)
+				So(resp.History[0].Uplinks, ShouldEqual, 12)
+				So(resp.History[0].DevicesOnline, ShouldEqual, 1)
+			})
+
+			Convey("Then the data does not contain any device identities", func() {
+				body := rec.Body.String()
+				So(strings.Contains(body, "0102030405060700"), ShouldBeFalse)
+				So(strings.Contains(body, "node-1"), ShouldBeFalse)
+			})
+		})
+
+		Convey("When requesting the status page data with an invalid number of hours", func() {
+			rec := get(statusPagePath(sp.Token) + "?hours=0")
+
+			Convey("Then a bad request is returned", func() {
+				So(rec.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When requesting the status page data with an unknown token", func() {
+			rec := get(statusPagePath("unknown"))
+
+			Convey("Then not found is returned", func() {
+				So(rec.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When the status page has been disabled", func() {
+			So(storage.DisableApplicationStatusPage(db, app.ID), ShouldBeNil)
+
+			Convey("Then the token stops working", func() {
+				So(get(statusPagePath(sp.Token)).Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
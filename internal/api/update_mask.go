@@ -0,0 +1,29 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+)
+
+// applyUpdateMask calls the setter of each field in the given update mask.
+// When the update mask is empty, the setters of all fields are called.
+func applyUpdateMask(mask []string, setters map[string]func() error) error {
+	if len(mask) == 0 {
+		for name := range setters {
+			mask = append(mask, name)
+		}
+		sort.Strings(mask)
+	}
+
+	for _, name := range mask {
+		set, ok := setters[name]
+		if !ok {
+			return fmt.Errorf("unknown field in update mask: %s", name)
+		}
+		if err := set(); err != nil {
+			return fmt.Errorf("invalid value for field %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	pb "github.com/brocaar/lora-app-server/api"
 	"github.com/brocaar/lora-app-server/internal/api/auth"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/egress"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/jmoiron/sqlx"
 )
@@ -296,3 +297,24 @@ func (a *InternalUserAPI) Profile(ctx context.Context, req *pb.ProfileRequest) (
 
 	return &resp, nil
 }
+
+// Egress returns the egress configuration of the outbound connections.
+func (a *InternalUserAPI) Egress(ctx context.Context, req *pb.EgressRequest) (*pb.EgressResponse, error) {
+	if err := a.validator.Validate(ctx,
+		auth.ValidateActiveUser()); err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "authentication failed: %s", err)
+	}
+
+	conf := egress.GetConfig()
+	resp := pb.EgressResponse{
+		IpAddresses: conf.IPAddresses,
+	}
+	if conf.ProxyURL != nil {
+		resp.Proxy = conf.ProxyURL.Host
+	}
+	if conf.BindAddress != nil {
+		resp.BindAddress = conf.BindAddress.String()
+	}
+
+	return &resp, nil
+}
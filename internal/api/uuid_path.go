@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// uuidPathRegexp matches the REST API paths starting with an application or
+// organization UUID.
+var uuidPathRegexp = regexp.MustCompile(`^/api/(applications|organizations)/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})(/.*)?$`)
+
+// UUIDPathHandler implements a http.Handler which replaces the application
+// or organization UUID in the REST API paths by the (serial) ID, before
+// passing the request to the next handler. This makes it possible to use
+// e.g. /api/applications/{uuid}/nodes instead of
+// /api/applications/{id}/nodes.
+type UUIDPathHandler struct {
+	next http.Handler
+}
+
+// NewUUIDPathHandler creates a new UUIDPathHandler.
+func NewUUIDPathHandler(next http.Handler) *UUIDPathHandler {
+	return &UUIDPathHandler{next: next}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *UUIDPathHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	match := uuidPathRegexp.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	u, err := storage.ParseUUID(match[2])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var id int64
+	switch match[1] {
+	case "applications":
+		var app storage.Application
+		app, err = storage.GetApplicationByUUID(common.DB, u)
+		id = app.ID
+	case "organizations":
+		var org storage.Organization
+		org, err = storage.GetOrganizationByUUID(common.DB, u)
+		id = org.ID
+	}
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			http.Error(w, "object does not exist", http.StatusNotFound)
+			return
+		}
+		log.WithField("uuid", u).Errorf("get %s by uuid error: %s", match[1], err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	r.URL.Path = "/api/" + match[1] + "/" + strconv.FormatInt(id, 10) + match[3]
+	r.URL.RawPath = ""
+	h.next.ServeHTTP(w, r)
+}
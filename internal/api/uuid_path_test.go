@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestUUIDPathHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization and application", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-organization",
+		}
+		So(storage.CreateOrganization(db, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(db, &app), ShouldBeNil)
+
+		var path string
+		h := NewUUIDPathHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+		}))
+
+		get := func(p string) *httptest.ResponseRecorder {
+			path = ""
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", p, nil))
+			return rec
+		}
+
+		tests := []struct {
+			Name         string
+			Path         string
+			ExpectedCode int
+			ExpectedPath string
+		}{
+			{"application uuid", "/api/applications/" + app.UUID.String(), http.StatusOK, fmt.Sprintf("/api/applications/%d", app.ID)},
+			{"application uuid with sub-path", "/api/applications/" + app.UUID.String() + "/nodes", http.StatusOK, fmt.Sprintf("/api/applications/%d/nodes", app.ID)},
+			{"organization uuid with sub-path", "/api/organizations/" + org.UUID.String() + "/users", http.StatusOK, fmt.Sprintf("/api/organizations/%d/users", org.ID)},
+			{"application id", "/api/applications/123/nodes", http.StatusOK, "/api/applications/123/nodes"},
+			{"unrelated path", "/api/users/" + app.UUID.String(), http.StatusOK, "/api/users/" + app.UUID.String()},
+			{"unknown application uuid", "/api/applications/00000000-0000-4000-8000-000000000000/nodes", http.StatusNotFound, ""},
+			{"organization uuid used for application", "/api/applications/" + org.UUID.String(), http.StatusNotFound, ""},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				rec := get(test.Path)
+				So(rec.Code, ShouldEqual, test.ExpectedCode)
+				So(path, ShouldEqual, test.ExpectedPath)
+			})
+		}
+	})
+}
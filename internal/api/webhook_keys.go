@@ -0,0 +1,29 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/webhooksign"
+)
+
+// WebhookKeysHandler implements a http.Handler which returns the public
+// keys used for signing the webhook payloads as JSON Web Key Set. As these
+// are public keys, no authentication is required.
+type WebhookKeysHandler struct{}
+
+// NewWebhookKeysHandler creates a new WebhookKeysHandler.
+func NewWebhookKeysHandler() *WebhookKeysHandler {
+	return &WebhookKeysHandler{}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *WebhookKeysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	if err := json.NewEncoder(w).Encode(webhooksign.GetJWKS()); err != nil {
+		log.Errorf("encode webhook keys error: %s", err)
+	}
+}
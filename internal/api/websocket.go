@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/brocaar/lora-app-server/internal/api/auth"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// eventStreamBufferSize defines the number of events buffered per
+	// (WebSocket or gRPC stream) client.
+	eventStreamBufferSize = 64
+
+	// webSocketWriteTimeout defines the max. duration of writing an event
+	// to the client, after which the client is disconnected.
+	webSocketWriteTimeout = 10 * time.Second
+)
+
+// eventStreamTypes contains the event types streamed to the (WebSocket or
+// gRPC stream) clients.
+var eventStreamTypes = map[string]bool{
+	eventlog.Uplink: true,
+	eventlog.Join:   true,
+	eventlog.ACK:    true,
+	eventlog.Error:  true,
+}
+
+// EventWebSocketHandler implements a http.Handler which streams the uplink,
+// join, ack and error events of an application (when the route contains
+// the applicationID variable) or node (devEUI variable) as JSON messages
+// over a WebSocket connection. Each message has the same structure as the
+// events returned by the long-poll API ({"type": ..., "payload": ...}).
+// Only events received while the client is connected are streamed.
+type EventWebSocketHandler struct {
+	validator auth.Validator
+}
+
+// NewEventWebSocketHandler creates a new EventWebSocketHandler.
+func NewEventWebSocketHandler(validator auth.Validator) *EventWebSocketHandler {
+	return &EventWebSocketHandler{
+		validator: validator,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *EventWebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ctx := getContextFromWebSocketRequest(r)
+
+	var subscribe func(context.Context, chan eventlog.EventLog) error
+	var logFields log.Fields
+
+	if s, ok := vars["applicationID"]; ok {
+		applicationID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid application id", http.StatusBadRequest)
+			return
+		}
+
+		if err := h.validator.Validate(ctx,
+			auth.ValidateNodesAccess(applicationID, auth.List)); err != nil {
+			http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+			return
+		}
+
+		subscribe = func(ctx context.Context, eventsChan chan eventlog.EventLog) error {
+			return eventlog.GetEventLogForApplication(ctx, applicationID, eventsChan)
+		}
+		logFields = log.Fields{"application_id": applicationID}
+	} else {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(vars["devEUI"])); err != nil {
+			http.Error(w, "invalid dev_eui", http.StatusBadRequest)
+			return
+		}
+
+		if err := h.validator.Validate(ctx,
+			auth.ValidateNodeAccess(devEUI, auth.Read)); err != nil {
+			http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+			return
+		}
+
+		subscribe = func(ctx context.Context, eventsChan chan eventlog.EventLog) error {
+			return eventlog.GetEventLogForDevice(ctx, devEUI, eventsChan)
+		}
+		logFields = log.Fields{"dev_eui": devEUI}
+	}
+
+	// The Origin header is not validated (websocket.Handler would require
+	// it to be a valid URL), as the client is authenticated by its token and
+	// not by cookies.
+	websocket.Server{
+		Handler: func(ws *websocket.Conn) {
+			h.stream(ws, subscribe, logFields)
+		},
+	}.ServeHTTP(w, r)
+}
+
+// stream writes the events to the given connection until the client
+// disconnects or writing fails.
+func (h *EventWebSocketHandler) stream(ws *websocket.Conn, subscribe func(context.Context, chan eventlog.EventLog) error, logFields log.Fields) {
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log.WithFields(logFields).Info("websocket client connected")
+
+	// detect the disconnection of the client, messages sent by the client
+	// are ignored
+	go func() {
+		var msg []byte
+		for {
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	eventsChan := make(chan eventlog.EventLog, eventStreamBufferSize)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- subscribe(ctx, eventsChan)
+	}()
+
+	for {
+		select {
+		case el := <-eventsChan:
+			if !eventStreamTypes[el.Type] {
+				continue
+			}
+
+			ws.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+			if err := websocket.JSON.Send(ws, el); err != nil {
+				log.WithFields(logFields).Errorf("websocket write error: %s", err)
+				cancel()
+				<-errChan
+				return
+			}
+		case err := <-errChan:
+			if err != nil {
+				log.WithFields(logFields).Errorf("get event log error: %s", err)
+			}
+			return
+		case <-ctx.Done():
+			<-errChan
+			log.WithFields(logFields).Info("websocket client disconnected")
+			return
+		}
+	}
+}
+
+// getContextFromWebSocketRequest returns the context of the given request,
+// holding the authorization metadata. As browsers can not set headers on
+// WebSocket requests, the token can also be given by the jwt query
+// parameter.
+func getContextFromWebSocketRequest(r *http.Request) context.Context {
+	if r.Header.Get("Grpc-Metadata-Authorization") != "" || r.Header.Get("Authorization") != "" {
+		return getContextFromHTTPRequest(r)
+	}
+	return metadata.NewIncomingContext(r.Context(), metadata.Pairs("authorization", r.URL.Query().Get("jwt")))
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/websocket"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestEventWebSocketHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a websocket handler", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		validator := &TestValidator{}
+		r := mux.NewRouter()
+		r.Handle("/api/applications/{applicationID:[0-9]+}/events/ws", NewEventWebSocketHandler(validator))
+		r.Handle("/api/nodes/{devEUI}/events/ws", NewEventWebSocketHandler(validator))
+		server := httptest.NewServer(r)
+		defer server.Close()
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+		tests := []struct {
+			Name string
+			Path string
+			Log  func(typ string, pl interface{}) error
+		}{
+			{
+				"application events",
+				"/api/applications/1/events/ws?jwt=token",
+				func(typ string, pl interface{}) error { return eventlog.LogEventForApplication(1, typ, pl) },
+			},
+			{
+				"node events",
+				fmt.Sprintf("/api/nodes/%s/events/ws?jwt=token", devEUI),
+				func(typ string, pl interface{}) error { return eventlog.LogEventForDevice(devEUI, typ, pl) },
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Given a websocket client subscribed to the "+test.Name, func() {
+				ws, err := websocket.Dial(wsURL+test.Path, "", server.URL)
+				So(err, ShouldBeNil)
+				defer ws.Close()
+				So(validator.validatorFuncs, ShouldHaveLength, 1)
+
+				// some time to subscribe
+				time.Sleep(100 * time.Millisecond)
+
+				Convey("When logging a security and an uplink event", func() {
+					So(test.Log(eventlog.Security, handler.SecurityNotification{ApplicationID: 1, DevEUI: devEUI}), ShouldBeNil)
+					So(test.Log(eventlog.Uplink, handler.DataUpPayload{ApplicationID: 1, DevEUI: devEUI}), ShouldBeNil)
+
+					Convey("Then only the uplink event is received", func() {
+						ws.SetReadDeadline(time.Now().Add(time.Second))
+						var el eventlog.EventLog
+						So(websocket.JSON.Receive(ws, &el), ShouldBeNil)
+						So(el.Type, ShouldEqual, eventlog.Uplink)
+						So(string(el.Payload), ShouldContainSubstring, `"devEUI":"0102030405060708"`)
+					})
+				})
+			})
+		}
+
+		Convey("When the validator returns an error", func() {
+			validator.returnError = fmt.Errorf("boom")
+			_, err := websocket.Dial(wsURL+"/api/applications/1/events/ws", "", server.URL)
+
+			Convey("Then the connection is refused", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When requesting the events without websocket upgrade", func() {
+			resp, err := http.Get(server.URL + "/api/nodes/0102030405060708/events/ws")
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
@@ -0,0 +1,156 @@
+// Package assets provides the static assets of the web-interface (and API
+// documentation). These can be embedded in the binary, served from an
+// external directory or from an external URL (e.g. a CDN), so that the
+// assets can be updated independently from the binary.
+package assets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	assetfs "github.com/elazarl/go-bindata-assetfs"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/egress"
+)
+
+// ErrNotFound is returned when the requested asset does not exist.
+var ErrNotFound = errors.New("asset does not exist")
+
+// Provider defines the interface of a static assets provider.
+type Provider interface {
+	// Handler returns the http.Handler serving the assets.
+	Handler() http.Handler
+
+	// ReadFile returns the content of the given asset (e.g.
+	// swagger/index.html).
+	ReadFile(name string) ([]byte, error)
+}
+
+// embedded implements a Provider serving the assets embedded in the binary
+// (using go-bindata).
+type embedded struct {
+	fs *assetfs.AssetFS
+}
+
+// NewEmbedded returns a Provider serving the assets embedded in the binary,
+// using the given go-bindata functions.
+func NewEmbedded(asset func(string) ([]byte, error), assetDir func(string) ([]string, error), assetInfo func(string) (os.FileInfo, error)) Provider {
+	return &embedded{
+		fs: &assetfs.AssetFS{
+			Asset:     asset,
+			AssetDir:  assetDir,
+			AssetInfo: assetInfo,
+			Prefix:    "",
+		},
+	}
+}
+
+func (p *embedded) Handler() http.Handler {
+	return http.FileServer(p.fs)
+}
+
+func (p *embedded) ReadFile(name string) ([]byte, error) {
+	b, err := p.fs.Asset(cleanName(name))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return b, nil
+}
+
+// dir implements a Provider serving the assets from a directory.
+type dir struct {
+	path string
+}
+
+// NewDir returns a Provider serving the assets from the given directory.
+func NewDir(path string) Provider {
+	return &dir{
+		path: path,
+	}
+}
+
+func (p *dir) Handler() http.Handler {
+	return http.FileServer(http.Dir(p.path))
+}
+
+func (p *dir) ReadFile(name string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Join(p.path, filepath.FromSlash(cleanName(name))))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return b, nil
+}
+
+// remote implements a Provider serving the assets from an external URL.
+type remote struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewURL returns a Provider serving the assets from the given base URL
+// (e.g. a CDN). The requests for the assets are redirected to this URL,
+// except for the index page, which is fetched from this URL and served by
+// LoRa App Server, so that the web-interface keeps the origin of the API.
+func NewURL(baseURL string) (Provider, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("assets url must be a http or https url, got: %s", baseURL)
+	}
+
+	return &remote{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: egress.NewClient(10 * time.Second),
+	}, nil
+}
+
+func (p *remote) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := cleanName(r.URL.Path)
+		if name == "" || name == "index.html" {
+			b, err := p.ReadFile("index.html")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write(b)
+			return
+		}
+
+		http.Redirect(w, r, p.baseURL+"/"+name, http.StatusFound)
+	})
+}
+
+func (p *remote) ReadFile(name string) ([]byte, error) {
+	resp, err := p.httpClient.Get(p.baseURL + "/" + cleanName(name))
+	if err != nil {
+		return nil, errors.Wrap(err, "get asset error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get asset error: expected 200 response, got: %d", resp.StatusCode)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read asset error")
+	}
+	return b, nil
+}
+
+// cleanName returns the cleaned asset name, without leading slash.
+func cleanName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
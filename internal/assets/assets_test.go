@@ -0,0 +1,86 @@
+package assets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDir(t *testing.T) {
+	Convey("Given a directory with static assets", t, func() {
+		dir, err := ioutil.TempDir("", "assets")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(os.MkdirAll(filepath.Join(dir, "swagger"), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "swagger", "index.html"), []byte("swagger"), 0644), ShouldBeNil)
+
+		p := NewDir(dir)
+
+		Convey("Then ReadFile returns the content of an asset", func() {
+			b, err := p.ReadFile("swagger/index.html")
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, "swagger")
+		})
+
+		Convey("Then ReadFile does not read outside the directory", func() {
+			_, err := p.ReadFile("../../etc/passwd")
+			So(err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("Then the handler serves the asset", func() {
+			rec := httptest.NewRecorder()
+			p.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/swagger/index.html", nil))
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Body.String(), ShouldEqual, "swagger")
+		})
+	})
+}
+
+func TestURL(t *testing.T) {
+	Convey("Given a test server serving the static assets", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/index.html" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, "index")
+		}))
+		defer server.Close()
+
+		Convey("Then an invalid url returns an error", func() {
+			_, err := NewURL("ftp://example.com/assets")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Given a provider for the assets url", func() {
+			p, err := NewURL(server.URL + "/v1/")
+			So(err, ShouldBeNil)
+
+			Convey("Then the index page is served", func() {
+				rec := httptest.NewRecorder()
+				p.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+				So(rec.Code, ShouldEqual, http.StatusOK)
+				So(rec.Body.String(), ShouldEqual, "index")
+			})
+
+			Convey("Then the other assets are redirected", func() {
+				rec := httptest.NewRecorder()
+				p.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/static/js/main.js", nil))
+				So(rec.Code, ShouldEqual, http.StatusFound)
+				So(rec.Header().Get("Location"), ShouldEqual, server.URL+"/v1/static/js/main.js")
+			})
+
+			Convey("Then ReadFile returns ErrNotFound for an unknown asset", func() {
+				_, err := p.ReadFile("swagger/index.html")
+				So(err, ShouldEqual, ErrNotFound)
+			})
+		})
+	})
+}
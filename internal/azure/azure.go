@@ -0,0 +1,277 @@
+// Package azure implements a minimal client for sending messages to Azure
+// Service Bus queues and topics and as IoT Hub device-to-cloud messages,
+// using the HTTPS APIs authenticated with a shared access signature (SAS).
+package azure
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/egress"
+)
+
+const (
+	iotHubAPIVersion = "2018-06-30"
+	tokenValidity    = time.Hour
+)
+
+// ErrInvalidConnectionString is returned when the connection string is
+// invalid or of an unsupported type.
+var ErrInvalidConnectionString = errors.New("invalid connection string")
+
+var httpClient = egress.NewClient(30 * time.Second)
+
+// Error contains an error returned by the Azure API.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("azure error (status: %d): %s", e.StatusCode, e.Message)
+}
+
+// Client defines the interface of an Azure client.
+type Client interface {
+	// Send sends the given message with the given content-type and
+	// (string) application properties.
+	Send(message []byte, contentType string, properties map[string]string) error
+}
+
+// ConnectionString contains the fields of an Azure connection string.
+type ConnectionString struct {
+	// Service Bus
+	Endpoint            string
+	SharedAccessKeyName string
+	EntityPath          string
+
+	// IoT Hub device
+	HostName string
+	DeviceID string
+
+	SharedAccessKey string
+}
+
+// ParseConnectionString parses the given Service Bus
+// (Endpoint=sb://...;SharedAccessKeyName=...;SharedAccessKey=...[;EntityPath=...])
+// or IoT Hub device (HostName=...;DeviceId=...;SharedAccessKey=...)
+// connection string.
+func ParseConnectionString(s string) (ConnectionString, error) {
+	var cs ConnectionString
+	for _, part := range strings.Split(s, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return cs, ErrInvalidConnectionString
+		}
+
+		switch strings.TrimSpace(kv[0]) {
+		case "Endpoint":
+			cs.Endpoint = kv[1]
+		case "SharedAccessKeyName":
+			cs.SharedAccessKeyName = kv[1]
+		case "SharedAccessKey":
+			cs.SharedAccessKey = kv[1]
+		case "EntityPath":
+			cs.EntityPath = kv[1]
+		case "HostName":
+			cs.HostName = kv[1]
+		case "DeviceId":
+			cs.DeviceID = kv[1]
+		}
+	}
+
+	if cs.SharedAccessKey == "" {
+		return cs, ErrInvalidConnectionString
+	}
+
+	switch {
+	case cs.IsServiceBus():
+		u, err := url.Parse(cs.Endpoint)
+		if err != nil || u.Scheme != "sb" || u.Host == "" || cs.SharedAccessKeyName == "" {
+			return cs, ErrInvalidConnectionString
+		}
+	case cs.IsIoTHub():
+		if _, err := base64.StdEncoding.DecodeString(cs.SharedAccessKey); err != nil {
+			return cs, ErrInvalidConnectionString
+		}
+	default:
+		return cs, ErrInvalidConnectionString
+	}
+
+	return cs, nil
+}
+
+// IsServiceBus returns true when the connection string is a Service Bus
+// connection string.
+func (cs ConnectionString) IsServiceBus() bool {
+	return cs.Endpoint != "" && cs.HostName == ""
+}
+
+// IsIoTHub returns true when the connection string is an IoT Hub device
+// connection string.
+func (cs ConnectionString) IsIoTHub() bool {
+	return cs.HostName != "" && cs.DeviceID != "" && cs.Endpoint == ""
+}
+
+// ServiceBusClient implements a client sending messages to a Service Bus
+// queue or topic.
+type ServiceBusClient struct {
+	// URL of the queue or topic (e.g.
+	// https://example.servicebus.windows.net/events).
+	URL     string
+	KeyName string
+	Key     string
+}
+
+// NewServiceBusClient creates a new ServiceBusClient for the given
+// connection string and queue or topic. The entity path of the connection
+// string is used when the given entity is empty.
+func NewServiceBusClient(cs ConnectionString, entity string) (*ServiceBusClient, error) {
+	if !cs.IsServiceBus() {
+		return nil, ErrInvalidConnectionString
+	}
+	if entity == "" {
+		entity = cs.EntityPath
+	}
+	if entity == "" {
+		return nil, errors.New("queue or topic name must be set")
+	}
+
+	u, err := url.Parse(cs.Endpoint)
+	if err != nil {
+		return nil, ErrInvalidConnectionString
+	}
+
+	return &ServiceBusClient{
+		URL:     "https://" + u.Host + "/" + strings.Trim(entity, "/"),
+		KeyName: cs.SharedAccessKeyName,
+		Key:     cs.SharedAccessKey,
+	}, nil
+}
+
+// Send sends the given message with the given content-type to the queue or
+// topic. The properties are set as custom message properties.
+func (c *ServiceBusClient) Send(message []byte, contentType string, properties map[string]string) error {
+	req, err := http.NewRequest("POST", c.URL+"/messages", bytes.NewReader(message))
+	if err != nil {
+		return errors.Wrap(err, "new request error")
+	}
+
+	bp, err := json.Marshal(map[string]string{"ContentType": contentType})
+	if err != nil {
+		return errors.Wrap(err, "marshal broker properties error")
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("BrokerProperties", string(bp))
+	for k, v := range properties {
+		req.Header.Set(k, strconv.Quote(v))
+	}
+	req.Header.Set("Authorization", sasToken(c.URL, c.KeyName, []byte(c.Key), time.Now().Add(tokenValidity)))
+
+	return do(req)
+}
+
+// IoTHubClient implements a client sending device-to-cloud messages to an
+// IoT Hub.
+type IoTHubClient struct {
+	// URL of the IoT Hub (e.g. https://example.azure-devices.net).
+	URL      string
+	HostName string
+	DeviceID string
+	Key      []byte
+}
+
+// NewIoTHubClient creates a new IoTHubClient for the given device
+// connection string.
+func NewIoTHubClient(cs ConnectionString) (*IoTHubClient, error) {
+	if !cs.IsIoTHub() {
+		return nil, ErrInvalidConnectionString
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cs.SharedAccessKey)
+	if err != nil {
+		return nil, ErrInvalidConnectionString
+	}
+
+	return &IoTHubClient{
+		URL:      "https://" + cs.HostName,
+		HostName: cs.HostName,
+		DeviceID: cs.DeviceID,
+		Key:      key,
+	}, nil
+}
+
+// Send sends the given message with the given content-type as
+// device-to-cloud message. The properties are set as application
+// properties.
+func (c *IoTHubClient) Send(message []byte, contentType string, properties map[string]string) error {
+	u := fmt.Sprintf("%s/devices/%s/messages/events?api-version=%s", c.URL, url.PathEscape(c.DeviceID), iotHubAPIVersion)
+	req, err := http.NewRequest("POST", u, bytes.NewReader(message))
+	if err != nil {
+		return errors.Wrap(err, "new request error")
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("iothub-contenttype", contentType)
+	if contentType == "application/json" {
+		req.Header.Set("iothub-contentencoding", "utf-8")
+	}
+	for k, v := range properties {
+		req.Header.Set("iothub-app-"+k, v)
+	}
+	req.Header.Set("Authorization", sasToken(c.HostName+"/devices/"+c.DeviceID, "", c.Key, time.Now().Add(tokenValidity)))
+
+	return do(req)
+}
+
+// sasToken returns a shared access signature token for the given resource
+// URI, valid until the given expiry time. The key name is omitted when
+// empty (e.g. for IoT Hub device keys).
+func sasToken(resourceURI, keyName string, key []byte, expiry time.Time) string {
+	sr := url.QueryEscape(resourceURI)
+	se := strconv.FormatInt(expiry.Unix(), 10)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(sr + "\n" + se))
+	sig := url.QueryEscape(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	token := fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=%s", sr, sig, se)
+	if keyName != "" {
+		token += "&skn=" + url.QueryEscape(keyName)
+	}
+	return token
+}
+
+// do performs the given request and returns an Error on a non 2xx response
+// (Service Bus returns 201, IoT Hub 204).
+func do(req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http request error")
+	}
+	defer resp.Body.Close()
+
+	b, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(b))}
+	}
+	return nil
+}
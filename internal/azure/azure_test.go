@@ -0,0 +1,125 @@
+package azure
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseConnectionString(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			String        string
+			Expected      ConnectionString
+			ExpectedError error
+		}{
+			{
+				Name:   "service bus",
+				String: "Endpoint=sb://example.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=secret=;EntityPath=events",
+				Expected: ConnectionString{
+					Endpoint:            "sb://example.servicebus.windows.net/",
+					SharedAccessKeyName: "send",
+					SharedAccessKey:     "secret=",
+					EntityPath:          "events",
+				},
+			},
+			{
+				Name:   "iot hub device",
+				String: "HostName=example.azure-devices.net;DeviceId=device;SharedAccessKey=c2VjcmV0",
+				Expected: ConnectionString{
+					HostName:        "example.azure-devices.net",
+					DeviceID:        "device",
+					SharedAccessKey: "c2VjcmV0",
+				},
+			},
+			{
+				Name:          "missing key",
+				String:        "Endpoint=sb://example.servicebus.windows.net/;SharedAccessKeyName=send",
+				ExpectedError: ErrInvalidConnectionString,
+			},
+			{
+				Name:          "iot hub service connection string",
+				String:        "HostName=example.azure-devices.net;SharedAccessKeyName=iothubowner;SharedAccessKey=c2VjcmV0",
+				ExpectedError: ErrInvalidConnectionString,
+			},
+			{
+				Name:          "invalid",
+				String:        "example",
+				ExpectedError: ErrInvalidConnectionString,
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				cs, err := ParseConnectionString(test.String)
+				So(err, ShouldEqual, test.ExpectedError)
+				if err == nil {
+					So(cs, ShouldResemble, test.Expected)
+				}
+			})
+		}
+	})
+}
+
+func TestSASToken(t *testing.T) {
+	Convey("Given a resource, key and expiry time", t, func() {
+		expiry := time.Unix(1500000000, 0)
+
+		Convey("Then the token contains the escaped resource, signature, expiry and key name", func() {
+			So(sasToken("https://example.servicebus.windows.net/events", "send", []byte("secret"), expiry), ShouldEqual, "SharedAccessSignature sr=https%3A%2F%2Fexample.servicebus.windows.net%2Fevents&sig=Wl0ZP0dP6WPRidrVyk1gZO%2BjNeeCpoI5Uz%2FNEp%2BFAA8%3D&se=1500000000&skn=send")
+		})
+	})
+}
+
+func TestSend(t *testing.T) {
+	Convey("Given a test server", t, func() {
+		requests := make(chan *http.Request, 1)
+		bodies := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			requests <- r
+			bodies <- string(b)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		Convey("When sending a message to a Service Bus queue", func() {
+			c := ServiceBusClient{
+				URL:     server.URL + "/events",
+				KeyName: "send",
+				Key:     "secret",
+			}
+			So(c.Send([]byte("{}"), "application/json", map[string]string{"eventType": "rx"}), ShouldBeNil)
+
+			Convey("Then the message and properties are sent", func() {
+				r := <-requests
+				So(r.URL.Path, ShouldEqual, "/events/messages")
+				So(r.Header.Get("eventType"), ShouldEqual, `"rx"`)
+				So(r.Header.Get("Authorization"), ShouldStartWith, "SharedAccessSignature ")
+				So(<-bodies, ShouldEqual, "{}")
+			})
+		})
+
+		Convey("When sending a device-to-cloud message to an IoT Hub", func() {
+			c := IoTHubClient{
+				URL:      server.URL,
+				HostName: "example.azure-devices.net",
+				DeviceID: "device",
+				Key:      []byte("secret"),
+			}
+			So(c.Send([]byte("{}"), "application/json", map[string]string{"eventType": "rx"}), ShouldBeNil)
+
+			Convey("Then the message and properties are sent", func() {
+				r := <-requests
+				So(r.URL.Path, ShouldEqual, "/devices/device/messages/events")
+				So(r.Header.Get("iothub-app-eventType"), ShouldEqual, "rx")
+				So(<-bodies, ShouldEqual, "{}")
+			})
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/brocaar/lora-app-server/internal/handler"
@@ -15,6 +16,16 @@ var DB *sqlx.DB
 // RedisPool holds the Redis connection pool.
 var RedisPool *redis.Pool
 
+// RedisKeyPrefix holds the prefix of all Redis keys, so that multiple
+// instances can share one Redis database.
+var RedisKeyPrefix = "lora:as:"
+
+// RedisKey returns the Redis key for the given format and arguments,
+// prefixed with RedisKeyPrefix.
+func RedisKey(format string, a ...interface{}) string {
+	return RedisKeyPrefix + fmt.Sprintf(format, a...)
+}
+
 // NetworkServer holds the connection to the network-server API.
 var NetworkServer ns.NetworkServerClient
 
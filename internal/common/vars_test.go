@@ -0,0 +1,28 @@
+package common
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedisKey(t *testing.T) {
+	Convey("Given a Redis key prefix", t, func() {
+		prefix := RedisKeyPrefix
+		defer func() { RedisKeyPrefix = prefix }()
+
+		RedisKeyPrefix = "site-a:"
+
+		Convey("Then the formatted key is prefixed", func() {
+			So(RedisKey("integration-health:%s", "abc"), ShouldEqual, "site-a:integration-health:abc")
+		})
+
+		Convey("Given an empty prefix", func() {
+			RedisKeyPrefix = ""
+
+			Convey("Then the formatted key is returned as-is", func() {
+				So(RedisKey("lock:%d:%s", 10, "x"), ShouldEqual, "lock:10:x")
+			})
+		})
+	})
+}
@@ -0,0 +1,206 @@
+// Package coverage aggregates the RSSI and SNR of the received gateway
+// pings per gateway and geohash cell into GeoJSON coverage map tiles.
+package coverage
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/geohash"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// MaxZoom defines the max supported tile zoom level.
+const MaxZoom = 22
+
+// maxLatitude defines the max latitude of the web mercator projection.
+const maxLatitude = 85.0511287798066
+
+// ErrInvalidTile is returned when the tile coordinates are invalid.
+var ErrInvalidTile = errors.New("invalid tile coordinates")
+
+// Tile defines a (web mercator / slippy map) tile.
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// Validate validates the tile coordinates.
+func (t Tile) Validate() error {
+	if t.Z < 0 || t.Z > MaxZoom {
+		return ErrInvalidTile
+	}
+	n := 1 << uint(t.Z)
+	if t.X < 0 || t.X >= n || t.Y < 0 || t.Y >= n {
+		return ErrInvalidTile
+	}
+	return nil
+}
+
+// BoundingBox returns the bounding box of the tile.
+func (t Tile) BoundingBox() geohash.Box {
+	n := float64(uint64(1) << uint(t.Z))
+	return geohash.Box{
+		MinLatitude:  tileLatitude(float64(t.Y+1), n),
+		MinLongitude: float64(t.X)/n*360 - 180,
+		MaxLatitude:  tileLatitude(float64(t.Y), n),
+		MaxLongitude: float64(t.X+1)/n*360 - 180,
+	}
+}
+
+// Precision returns the geohash precision to use for the tile, resulting
+// in roughly 16 - 32 cells over the width of the tile.
+func (t Tile) Precision() int {
+	p := int(math.Floor(float64(2*(t.Z+5))/5 + 0.5))
+	if p < 1 {
+		return 1
+	}
+	if p > geohash.MaxPrecision {
+		return geohash.MaxPrecision
+	}
+	return p
+}
+
+// Cell contains the aggregated RSSI and SNR of the pings sent by a gateway
+// and received within a geohash cell.
+type Cell struct {
+	GatewayMAC lorawan.EUI64
+	Geohash    string
+	Count      int
+	RSSIMin    int
+	RSSIMax    int
+	RSSISum    int
+	LoRaSNRMin float64
+	LoRaSNRMax float64
+	LoRaSNRSum float64
+}
+
+// Aggregate aggregates the given pings per gateway and geohash cell of the
+// given precision. The cells are sorted by gateway MAC and geohash.
+func Aggregate(rxs []storage.GatewayCoverageRX, precision int) []Cell {
+	type key struct {
+		mac  lorawan.EUI64
+		hash string
+	}
+	cells := make(map[key]*Cell)
+
+	for _, rx := range rxs {
+		k := key{
+			mac:  rx.GatewayMAC,
+			hash: geohash.Encode(rx.Location.Latitude, rx.Location.Longitude, precision),
+		}
+
+		c, ok := cells[k]
+		if !ok {
+			c = &Cell{
+				GatewayMAC: k.mac,
+				Geohash:    k.hash,
+				RSSIMin:    rx.RSSI,
+				RSSIMax:    rx.RSSI,
+				LoRaSNRMin: rx.LoRaSNR,
+				LoRaSNRMax: rx.LoRaSNR,
+			}
+			cells[k] = c
+		}
+
+		c.Count++
+		c.RSSISum += rx.RSSI
+		c.LoRaSNRSum += rx.LoRaSNR
+		if rx.RSSI < c.RSSIMin {
+			c.RSSIMin = rx.RSSI
+		}
+		if rx.RSSI > c.RSSIMax {
+			c.RSSIMax = rx.RSSI
+		}
+		if rx.LoRaSNR < c.LoRaSNRMin {
+			c.LoRaSNRMin = rx.LoRaSNR
+		}
+		if rx.LoRaSNR > c.LoRaSNRMax {
+			c.LoRaSNRMax = rx.LoRaSNR
+		}
+	}
+
+	out := make([]Cell, 0, len(cells))
+	for _, c := range cells {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].GatewayMAC != out[j].GatewayMAC {
+			return out[i].GatewayMAC.String() < out[j].GatewayMAC.String()
+		}
+		return out[i].Geohash < out[j].Geohash
+	})
+
+	return out
+}
+
+// GetTile returns the coverage cells of the given tile, based on the pings
+// of the gateways of the given organization since the given time. When mac
+// is not nil, only the pings sent by this gateway are taken into account.
+// A precision of 0 means the precision is based on the zoom level of the
+// tile.
+//
+// Only the cells of which the center is within the tile are returned, so
+// that every cell is part of exactly one tile (containing all the pings of
+// that cell).
+func GetTile(organizationID int64, mac *lorawan.EUI64, since time.Time, tile Tile, precision int) (FeatureCollection, error) {
+	if err := tile.Validate(); err != nil {
+		return FeatureCollection{}, err
+	}
+	if precision == 0 {
+		precision = tile.Precision()
+	}
+
+	box := tile.BoundingBox()
+	height, width := geohash.CellSize(precision)
+
+	rxs, err := storage.GetGatewayCoverageRX(common.DB, organizationID, mac, since,
+		storage.GPSPoint{Latitude: box.MinLatitude - height, Longitude: box.MinLongitude - width},
+		storage.GPSPoint{Latitude: box.MaxLatitude + height, Longitude: box.MaxLongitude + width},
+	)
+	if err != nil {
+		return FeatureCollection{}, err
+	}
+
+	fc := FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []Feature{},
+	}
+	for _, c := range Aggregate(rxs, precision) {
+		cellBox, err := geohash.BoundingBox(c.Geohash)
+		if err != nil {
+			return FeatureCollection{}, err
+		}
+		lat, lon := cellBox.Center()
+		if lat < box.MinLatitude || lat >= box.MaxLatitude || lon < box.MinLongitude || lon >= box.MaxLongitude {
+			continue
+		}
+		fc.Features = append(fc.Features, cellFeature(c, cellBox))
+	}
+
+	return fc, nil
+}
+
+func tileLatitude(y, n float64) float64 {
+	lat := math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+	// the first and last tile rows are extended to the poles, so that
+	// no pings are left out
+	if y == 0 {
+		return 90
+	}
+	if y == n {
+		return -90
+	}
+	if lat > maxLatitude {
+		return maxLatitude
+	}
+	if lat < -maxLatitude {
+		return -maxLatitude
+	}
+	return lat
+}
@@ -0,0 +1,78 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+func TestTile(t *testing.T) {
+	Convey("Given tile 0/0/0", t, func() {
+		tile := Tile{}
+
+		Convey("Then the bounding box covers the whole world", func() {
+			box := tile.BoundingBox()
+			So(box.MinLatitude, ShouldEqual, -90)
+			So(box.MaxLatitude, ShouldEqual, 90)
+			So(box.MinLongitude, ShouldEqual, -180)
+			So(box.MaxLongitude, ShouldEqual, 180)
+		})
+
+		Convey("Then the precision is 2", func() {
+			So(tile.Precision(), ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given tile 10/528/336", t, func() {
+		tile := Tile{Z: 10, X: 528, Y: 336}
+		So(tile.Validate(), ShouldBeNil)
+
+		Convey("Then the bounding box is as expected", func() {
+			box := tile.BoundingBox()
+			So(box.MinLongitude, ShouldAlmostEqual, 5.625)
+			So(box.MaxLongitude, ShouldAlmostEqual, 5.9765625)
+			So(box.MinLatitude, ShouldAlmostEqual, 52.26815737376817)
+			So(box.MaxLatitude, ShouldAlmostEqual, 52.48278022207821)
+		})
+
+		Convey("Then the precision is 6", func() {
+			So(tile.Precision(), ShouldEqual, 6)
+		})
+	})
+
+	Convey("Given a set of invalid tiles", t, func() {
+		for _, tile := range []Tile{{Z: -1}, {Z: MaxZoom + 1}, {Z: 1, X: 2}, {Z: 1, Y: -1}} {
+			So(tile.Validate(), ShouldEqual, ErrInvalidTile)
+		}
+	})
+}
+
+func TestAggregate(t *testing.T) {
+	Convey("Given a set of pings received by two gateways", t, func() {
+		mac1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		mac2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		loc := storage.GPSPoint{Latitude: 57.64911, Longitude: 10.40744}
+
+		rxs := []storage.GatewayCoverageRX{
+			{GatewayMAC: mac2, RSSI: -100, LoRaSNR: 2.5, Location: loc},
+			{GatewayMAC: mac1, RSSI: -80, LoRaSNR: 7, Location: loc},
+			{GatewayMAC: mac1, RSSI: -90, LoRaSNR: 5, Location: loc},
+			{GatewayMAC: mac1, RSSI: -110, LoRaSNR: -5, Location: storage.GPSPoint{Latitude: 52.3, Longitude: 5.7}},
+		}
+
+		Convey("When aggregating with precision 5", func() {
+			cells := Aggregate(rxs, 5)
+
+			Convey("Then the pings are aggregated per gateway and cell", func() {
+				So(cells, ShouldResemble, []Cell{
+					{GatewayMAC: mac1, Geohash: "u1k19", Count: 1, RSSIMin: -110, RSSIMax: -110, RSSISum: -110, LoRaSNRMin: -5, LoRaSNRMax: -5, LoRaSNRSum: -5},
+					{GatewayMAC: mac1, Geohash: "u4pru", Count: 2, RSSIMin: -90, RSSIMax: -80, RSSISum: -170, LoRaSNRMin: 5, LoRaSNRMax: 7, LoRaSNRSum: 12},
+					{GatewayMAC: mac2, Geohash: "u4pru", Count: 1, RSSIMin: -100, RSSIMax: -100, RSSISum: -100, LoRaSNRMin: 2.5, LoRaSNRMax: 2.5, LoRaSNRSum: 2.5},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package coverage
+
+import (
+	"math"
+
+	"github.com/brocaar/lora-app-server/internal/geohash"
+)
+
+// FeatureCollection implements a GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature implements a GeoJSON Feature.
+type Feature struct {
+	Type       string            `json:"type"`
+	Geometry   Geometry          `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+// Geometry implements a GeoJSON Polygon geometry.
+type Geometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// FeatureProperties contains the properties of a coverage cell.
+type FeatureProperties struct {
+	GatewayMAC string  `json:"gatewayMAC"`
+	Geohash    string  `json:"geohash"`
+	Count      int     `json:"count"`
+	RSSIMin    int     `json:"rssiMin"`
+	RSSIMax    int     `json:"rssiMax"`
+	RSSIAvg    float64 `json:"rssiAvg"`
+	LoRaSNRMin float64 `json:"loRaSNRMin"`
+	LoRaSNRMax float64 `json:"loRaSNRMax"`
+	LoRaSNRAvg float64 `json:"loRaSNRAvg"`
+}
+
+// cellFeature returns the GeoJSON Feature for the given cell.
+func cellFeature(c Cell, box geohash.Box) Feature {
+	return Feature{
+		Type: "Feature",
+		Geometry: Geometry{
+			Type: "Polygon",
+			// GeoJSON positions are [longitude, latitude], the exterior
+			// ring is counterclockwise
+			Coordinates: [][][2]float64{{
+				{box.MinLongitude, box.MinLatitude},
+				{box.MaxLongitude, box.MinLatitude},
+				{box.MaxLongitude, box.MaxLatitude},
+				{box.MinLongitude, box.MaxLatitude},
+				{box.MinLongitude, box.MinLatitude},
+			}},
+		},
+		Properties: FeatureProperties{
+			GatewayMAC: c.GatewayMAC.String(),
+			Geohash:    c.Geohash,
+			Count:      c.Count,
+			RSSIMin:    c.RSSIMin,
+			RSSIMax:    c.RSSIMax,
+			RSSIAvg:    round(float64(c.RSSISum)/float64(c.Count), 1),
+			LoRaSNRMin: c.LoRaSNRMin,
+			LoRaSNRMax: c.LoRaSNRMax,
+			LoRaSNRAvg: round(c.LoRaSNRSum/float64(c.Count), 1),
+		},
+	}
+}
+
+func round(f float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Floor(f*p+0.5) / p
+}
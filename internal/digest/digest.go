@@ -0,0 +1,233 @@
+// Package digest generates the periodic (daily or weekly) digest reports of
+// the organizations, summarizing the health of their nodes, and posts them
+// to the configured webhook. The report is posted as JSON, or rendered with
+// the Go template configured for the organization.
+package digest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/egress"
+	"github.com/brocaar/lora-app-server/internal/leader"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/webhooksign"
+	"github.com/brocaar/lorawan"
+)
+
+// OfflineAfter defines the duration without uplinks after which a node is
+// considered offline.
+var OfflineAfter = 24 * time.Hour
+
+// checkInterval defines the interval at which the due digests are sent.
+var checkInterval = 10 * time.Minute
+
+var httpClient = egress.NewClient(30 * time.Second)
+
+// Report contains the digest report of an organization.
+type Report struct {
+	OrganizationID   int64               `json:"organizationID,string"`
+	OrganizationName string              `json:"organizationName"`
+	Frequency        string              `json:"frequency"`
+	From             time.Time           `json:"from"`
+	To               time.Time           `json:"to"`
+	Nodes            int                 `json:"nodes"`
+	OfflineNodes     int                 `json:"offlineNodes"`
+	Uplinks          int                 `json:"uplinks"`
+	Missed           int                 `json:"missed"`
+	NewOfflineNodes  []Node              `json:"newOfflineNodes"`
+	Applications     []ApplicationReport `json:"applications"`
+}
+
+// ApplicationReport contains the digest report of an application.
+type ApplicationReport struct {
+	ID              int64  `json:"id,string"`
+	Name            string `json:"name"`
+	Nodes           int    `json:"nodes"`
+	OfflineNodes    int    `json:"offlineNodes"`
+	NewOfflineNodes int    `json:"newOfflineNodes"`
+	Uplinks         int    `json:"uplinks"`
+	Missed          int    `json:"missed"`
+}
+
+// Node contains a node of the digest report.
+type Node struct {
+	ApplicationName string        `json:"applicationName"`
+	Name            string        `json:"name"`
+	DevEUI          lorawan.EUI64 `json:"devEUI"`
+	LastSeen        *time.Time    `json:"lastSeen"`
+}
+
+// ParseTemplate parses the given digest template.
+func ParseTemplate(s string) (*template.Template, error) {
+	return template.New("digest").Parse(s)
+}
+
+// Generate generates the digest report of the given organization for the
+// period of the digest ending at the given time.
+func Generate(org storage.Organization, d storage.OrganizationDigest, to time.Time) (Report, error) {
+	report := Report{
+		OrganizationID:   org.ID,
+		OrganizationName: org.Name,
+		Frequency:        d.Frequency,
+		From:             to.Add(-d.Period()),
+		To:               to,
+		NewOfflineNodes:  []Node{},
+		Applications:     []ApplicationReport{},
+	}
+
+	stats, err := storage.GetDigestNodeStats(common.DB, org.ID, report.From)
+	if err != nil {
+		return report, errors.Wrap(err, "get node stats error")
+	}
+
+	for _, s := range stats {
+		if len(report.Applications) == 0 || report.Applications[len(report.Applications)-1].ID != s.ApplicationID {
+			report.Applications = append(report.Applications, ApplicationReport{
+				ID:   s.ApplicationID,
+				Name: s.ApplicationName,
+			})
+		}
+		app := &report.Applications[len(report.Applications)-1]
+
+		app.Nodes++
+		app.Uplinks += s.Uplinks
+		app.Missed += s.Missed
+
+		// nodes which never sent an uplink are not counted as offline
+		if s.LastSeen == nil || s.LastSeen.Add(OfflineAfter).After(to) {
+			continue
+		}
+		app.OfflineNodes++
+
+		// the node went offline within the period of the report
+		if !s.LastSeen.Add(OfflineAfter).Before(report.From) {
+			app.NewOfflineNodes++
+			report.NewOfflineNodes = append(report.NewOfflineNodes, Node{
+				ApplicationName: s.ApplicationName,
+				Name:            s.Name,
+				DevEUI:          s.DevEUI,
+				LastSeen:        s.LastSeen,
+			})
+		}
+	}
+
+	for _, app := range report.Applications {
+		report.Nodes += app.Nodes
+		report.OfflineNodes += app.OfflineNodes
+		report.Uplinks += app.Uplinks
+		report.Missed += app.Missed
+	}
+
+	return report, nil
+}
+
+// Render renders the given report with the given template. When the
+// template is empty, the report is rendered as JSON. It returns the
+// rendered report and its content-type.
+func Render(report Report, tmpl string) ([]byte, string, error) {
+	if tmpl == "" {
+		b, err := json.Marshal(report)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "marshal json error")
+		}
+		return b, "application/json", nil
+	}
+
+	t, err := ParseTemplate(tmpl)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "parse template error")
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, report); err != nil {
+		return nil, "", errors.Wrap(err, "execute template error")
+	}
+	return buf.Bytes(), "text/plain; charset=utf-8", nil
+}
+
+// Send generates, renders and posts the digest report of the given
+// organization for the period ending at the given time.
+func Send(d storage.OrganizationDigest, to time.Time) error {
+	org, err := storage.GetOrganization(common.DB, d.OrganizationID)
+	if err != nil {
+		return errors.Wrap(err, "get organization error")
+	}
+
+	report, err := Generate(org, d, to)
+	if err != nil {
+		return errors.Wrap(err, "generate report error")
+	}
+
+	b, contentType, err := Render(report, d.Template)
+	if err != nil {
+		return errors.Wrap(err, "render report error")
+	}
+
+	req, err := http.NewRequest("POST", d.WebhookURL, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	sig, err := webhooksign.Sign(b)
+	if err != nil {
+		return errors.Wrap(err, "sign report error")
+	}
+	if sig != "" {
+		req.Header.Set(webhooksign.Header, sig)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http post error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("expected 2XX response, got: %d", resp.StatusCode)
+	}
+
+	if err := storage.SetOrganizationDigestSent(common.DB, d.OrganizationID, to); err != nil {
+		return errors.Wrap(err, "set digest sent error")
+	}
+
+	log.WithFields(log.Fields{
+		"organization_id": d.OrganizationID,
+		"frequency":       d.Frequency,
+	}).Info("organization digest sent")
+	return nil
+}
+
+// SendLoop sends the due digest reports. Failed digests are retried at the
+// next check. The digests are only sent by the instance being the leader
+// of the digest job. This function never returns.
+func SendLoop() {
+	for {
+		if leader.IsLeader(leader.OrganizationDigests) {
+			sendDueDigests()
+		}
+		time.Sleep(checkInterval)
+	}
+}
+
+// sendDueDigests sends the digest reports which are due.
+func sendDueDigests() {
+	now := time.Now()
+	digests, err := storage.GetDueOrganizationDigests(common.DB, now)
+	if err != nil {
+		log.Errorf("get due organization digests error: %s", err)
+	}
+	for _, d := range digests {
+		if err := Send(d, now); err != nil {
+			log.WithField("organization_id", d.OrganizationID).Errorf("send organization digest error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package digest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestRender(t *testing.T) {
+	Convey("Given a report", t, func() {
+		report := Report{
+			OrganizationName: "test-org",
+			Nodes:            3,
+			OfflineNodes:     1,
+		}
+
+		Convey("When rendering without template", func() {
+			b, contentType, err := Render(report, "")
+			So(err, ShouldBeNil)
+
+			Convey("Then the report is rendered as JSON", func() {
+				So(contentType, ShouldEqual, "application/json")
+				var r Report
+				So(json.Unmarshal(b, &r), ShouldBeNil)
+				So(r.Nodes, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When rendering with a template", func() {
+			b, contentType, err := Render(report, "{{ .OrganizationName }}: {{ .OfflineNodes }}/{{ .Nodes }} offline")
+			So(err, ShouldBeNil)
+
+			Convey("Then the template is rendered", func() {
+				So(contentType, ShouldEqual, "text/plain; charset=utf-8")
+				So(string(b), ShouldEqual, "test-org: 1/3 offline")
+			})
+		})
+
+		Convey("When rendering with an invalid template", func() {
+			_, _, err := Render(report, "{{ .Foo")
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization, application and nodes", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		now := time.Now().Truncate(time.Hour)
+		nodes := []struct {
+			Name     string
+			DevEUI   lorawan.EUI64
+			LastSeen time.Duration
+		}{
+			{"online-node", lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, time.Hour},
+			{"new-offline-node", lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, 30 * time.Hour},
+			{"offline-node", lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, 72 * time.Hour},
+		}
+		for _, n := range nodes {
+			So(storage.CreateNode(common.DB, storage.Node{
+				ApplicationID: app.ID,
+				Name:          n.Name,
+				DevEUI:        n.DevEUI,
+			}), ShouldBeNil)
+			So(storage.AddLinkQuality(common.DB, storage.LinkQuality{
+				DevEUI:  n.DevEUI,
+				Bucket:  now.Add(-n.LastSeen),
+				Uplinks: 5,
+				Missed:  1,
+			}), ShouldBeNil)
+		}
+		So(storage.CreateNode(common.DB, storage.Node{
+			ApplicationID: app.ID,
+			Name:          "never-seen-node",
+			DevEUI:        lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4},
+		}), ShouldBeNil)
+
+		Convey("When generating the daily report", func() {
+			report, err := Generate(org, storage.OrganizationDigest{Frequency: storage.DigestDaily}, now)
+			So(err, ShouldBeNil)
+
+			Convey("Then the report contains the expected summary", func() {
+				So(report.From, ShouldResemble, now.Add(-24*time.Hour))
+				So(report.Nodes, ShouldEqual, 4)
+				So(report.OfflineNodes, ShouldEqual, 2)
+				So(report.Uplinks, ShouldEqual, 5)
+				So(report.Missed, ShouldEqual, 1)
+				So(report.NewOfflineNodes, ShouldHaveLength, 1)
+				So(report.NewOfflineNodes[0].Name, ShouldEqual, "new-offline-node")
+				So(report.Applications, ShouldHaveLength, 1)
+				So(report.Applications[0].NewOfflineNodes, ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,395 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/brocaar/lora-app-server/internal/handler/mqtthandler"
+	"github.com/brocaar/loraserver/api/ns"
+)
+
+// CertificateExpiryWarning defines the remaining validity of a certificate
+// below which a warning is reported.
+var CertificateExpiryWarning = 30 * 24 * time.Hour
+
+// ClockSkewWarning and ClockSkewError define the clock skew (between LoRa
+// App Server and PostgreSQL / Redis) above which a warning or error is
+// reported.
+var (
+	ClockSkewWarning = time.Second
+	ClockSkewError   = time.Minute
+)
+
+// PostgreSQL returns a check connecting to the PostgreSQL database.
+func PostgreSQL(dsn string) Check {
+	return Check{
+		Name: "postgresql",
+		Run: func() (Status, string) {
+			db, err := openDatabase(dsn)
+			if err != nil {
+				return Error, err.Error()
+			}
+			defer db.Close()
+
+			var version string
+			if err := db.Get(&version, "show server_version"); err != nil {
+				return Error, fmt.Sprintf("get server version error: %s", err)
+			}
+			return OK, fmt.Sprintf("connected (version %s)", version)
+		},
+	}
+}
+
+// Migrations returns a check comparing the migrations applied to the
+// database with the migrations of this version. Pending migrations are not
+// considered an error when autoMigrate is set, as these will be applied on
+// startup.
+func Migrations(dsn string, source migrate.MigrationSource, autoMigrate bool) Check {
+	return Check{
+		Name: "migrations",
+		Run: func() (Status, string) {
+			migrations, err := source.FindMigrations()
+			if err != nil {
+				return Error, fmt.Sprintf("read migrations error: %s", err)
+			}
+
+			db, err := openDatabase(dsn)
+			if err != nil {
+				return Error, err.Error()
+			}
+			defer db.Close()
+
+			var ids []string
+			if err := db.Select(&ids, "select id from gorp_migrations order by id"); err != nil {
+				if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code.Name() != "undefined_table" {
+					return Error, fmt.Sprintf("get applied migrations error: %s", err)
+				}
+			}
+
+			applied := make(map[string]bool)
+			for _, id := range ids {
+				applied[id] = true
+			}
+			known := make(map[string]bool)
+			var pending []string
+			for _, m := range migrations {
+				known[m.Id] = true
+				if !applied[m.Id] {
+					pending = append(pending, m.Id)
+				}
+			}
+			var unknown []string
+			for _, id := range ids {
+				if !known[id] {
+					unknown = append(unknown, id)
+				}
+			}
+
+			switch {
+			case len(unknown) != 0:
+				return Error, fmt.Sprintf("database contains %d unknown migration(s) (%s), the database has been migrated by a newer version", len(unknown), strings.Join(unknown, ", "))
+			case len(pending) != 0 && autoMigrate:
+				return OK, fmt.Sprintf("%d pending migration(s), these will be applied on startup", len(pending))
+			case len(pending) != 0:
+				return Error, fmt.Sprintf("%d pending migration(s) (%s), enable db-automigrate to apply these on startup", len(pending), strings.Join(pending, ", "))
+			default:
+				return OK, fmt.Sprintf("up to date (%d migrations applied)", len(ids))
+			}
+		},
+	}
+}
+
+// Redis returns a check connecting to Redis.
+func Redis(redisURL string) Check {
+	return Check{
+		Name: "redis",
+		Run: func() (Status, string) {
+			c, err := dialRedis(redisURL)
+			if err != nil {
+				return Error, err.Error()
+			}
+			defer c.Close()
+
+			info, err := redis.String(c.Do("INFO", "server"))
+			if err != nil {
+				return Error, fmt.Sprintf("get server info error: %s", err)
+			}
+			for _, line := range strings.Split(info, "\n") {
+				if strings.HasPrefix(line, "redis_version:") {
+					return OK, fmt.Sprintf("connected (version %s)", strings.TrimSpace(strings.TrimPrefix(line, "redis_version:")))
+				}
+			}
+			return OK, "connected"
+		},
+	}
+}
+
+// ClockSkew returns a check comparing the local clock with the clocks of
+// the PostgreSQL and Redis servers.
+func ClockSkew(dsn, redisURL string) Check {
+	return Check{
+		Name: "clock skew",
+		Run: func() (Status, string) {
+			var skews []string
+			var max time.Duration
+
+			add := func(name string, remote func() (time.Time, error)) error {
+				start := time.Now()
+				t, err := remote()
+				if err != nil {
+					return fmt.Errorf("get %s time error: %s", name, err)
+				}
+				rtt := time.Since(start)
+
+				skew := t.Sub(start.Add(rtt / 2))
+				if skew < 0 {
+					skew = -skew
+				}
+				if skew > max {
+					max = skew
+				}
+				skews = append(skews, fmt.Sprintf("%s %s", name, skew.Round(time.Millisecond)))
+				return nil
+			}
+
+			db, err := openDatabase(dsn)
+			if err != nil {
+				return Error, err.Error()
+			}
+			defer db.Close()
+			err = add("postgresql", func() (time.Time, error) {
+				var t time.Time
+				err := db.Get(&t, "select clock_timestamp()")
+				return t, err
+			})
+			if err != nil {
+				return Error, err.Error()
+			}
+
+			c, err := dialRedis(redisURL)
+			if err != nil {
+				return Error, err.Error()
+			}
+			defer c.Close()
+			err = add("redis", func() (time.Time, error) {
+				vals, err := redis.Values(c.Do("TIME"))
+				if err != nil {
+					return time.Time{}, err
+				}
+				var sec, usec int64
+				if _, err := redis.Scan(vals, &sec, &usec); err != nil {
+					return time.Time{}, err
+				}
+				return time.Unix(sec, usec*int64(time.Microsecond)), nil
+			})
+			if err != nil {
+				return Error, err.Error()
+			}
+
+			msg := strings.Join(skews, ", ")
+			switch {
+			case max > ClockSkewError:
+				return Error, msg + ", make sure the clocks are synchronized (e.g. using NTP)"
+			case max > ClockSkewWarning:
+				return Warning, msg + ", make sure the clocks are synchronized (e.g. using NTP)"
+			default:
+				return OK, msg
+			}
+		},
+	}
+}
+
+// MQTT returns a check connecting to the given MQTT broker.
+func MQTT(name string, conf mqtthandler.Broker) Check {
+	return Check{
+		Name: name,
+		Run: func() (Status, string) {
+			if err := mqtthandler.CheckBroker(conf, Timeout); err != nil {
+				return Error, fmt.Sprintf("connect to %s error: %s", conf.Server, err)
+			}
+			return OK, fmt.Sprintf("connected to %s", conf.Server)
+		},
+	}
+}
+
+// NetworkServer returns a check connecting to the network-server API, using
+// TLS when a client certificate is given.
+func NetworkServer(server, caCert, tlsCert, tlsKey string) Check {
+	return Check{
+		Name: "network-server",
+		Run: func() (Status, string) {
+			opts := []grpc.DialOption{grpc.WithBlock()}
+			if tlsCert != "" && tlsKey != "" {
+				conf, err := newTLSConfig(caCert, tlsCert, tlsKey)
+				if err != nil {
+					return Error, err.Error()
+				}
+				opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(conf)))
+			} else {
+				opts = append(opts, grpc.WithInsecure())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+			defer cancel()
+
+			conn, err := grpc.DialContext(ctx, server, opts...)
+			if err != nil {
+				return Error, fmt.Sprintf("dial %s error: %s", server, err)
+			}
+			defer conn.Close()
+
+			_, err = ns.NewNetworkServerClient(conn).ListChannelConfigurations(ctx, &ns.ListChannelConfigurationsRequest{})
+			if err != nil {
+				return Error, fmt.Sprintf("api request to %s error: %s", server, err)
+			}
+			return OK, fmt.Sprintf("connected to %s", server)
+		},
+	}
+}
+
+// Certificate returns a check validating the given certificate and key
+// files and the validity period of the certificate. The check is skipped
+// when both files are not set.
+func Certificate(name, certFile, keyFile string) Check {
+	return Check{
+		Name: name,
+		Run: func() (Status, string) {
+			if certFile == "" && keyFile == "" {
+				return Skipped, "not configured"
+			}
+			if certFile == "" || keyFile == "" {
+				return Error, "both the certificate and key must be set"
+			}
+
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return Error, fmt.Sprintf("load key-pair error: %s", err)
+			}
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				return Error, fmt.Sprintf("parse certificate error: %s", err)
+			}
+			return certificateValidity(leaf, time.Now())
+		},
+	}
+}
+
+// CACertificate returns a check validating the given CA certificate file
+// and the validity period of the certificates it contains. The check is
+// skipped when the file is not set.
+func CACertificate(name, caFile string) Check {
+	return Check{
+		Name: name,
+		Run: func() (Status, string) {
+			if caFile == "" {
+				return Skipped, "not configured"
+			}
+
+			b, err := ioutil.ReadFile(caFile)
+			if err != nil {
+				return Error, fmt.Sprintf("read ca certificate error: %s", err)
+			}
+
+			status, msg := Error, fmt.Sprintf("no pem encoded certificates found in %s", caFile)
+			for first := true; ; first = false {
+				var block *pem.Block
+				block, b = pem.Decode(b)
+				if block == nil {
+					break
+				}
+				if block.Type != "CERTIFICATE" {
+					continue
+				}
+
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return Error, fmt.Sprintf("parse certificate error: %s", err)
+				}
+
+				// report the certificate with the worst status
+				s, m := certificateValidity(cert, time.Now())
+				if first || s > status {
+					status, msg = s, m
+				}
+			}
+			return status, msg
+		},
+	}
+}
+
+func certificateValidity(cert *x509.Certificate, now time.Time) (Status, string) {
+	cn := cert.Subject.CommonName
+	switch {
+	case now.Before(cert.NotBefore):
+		return Error, fmt.Sprintf("%s is not valid before %s", cn, cert.NotBefore.Format(time.RFC3339))
+	case now.After(cert.NotAfter):
+		return Error, fmt.Sprintf("%s expired at %s", cn, cert.NotAfter.Format(time.RFC3339))
+	case cert.NotAfter.Sub(now) < CertificateExpiryWarning:
+		return Warning, fmt.Sprintf("%s expires at %s (in %d days)", cn, cert.NotAfter.Format(time.RFC3339), int(cert.NotAfter.Sub(now).Hours()/24))
+	default:
+		return OK, fmt.Sprintf("%s valid until %s", cn, cert.NotAfter.Format(time.RFC3339))
+	}
+}
+
+func openDatabase(dsn string) (*sqlx.DB, error) {
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("database connection error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database error: %s", err)
+	}
+	return db, nil
+}
+
+func dialRedis(redisURL string) (redis.Conn, error) {
+	c, err := redis.DialURL(redisURL,
+		redis.DialConnectTimeout(Timeout),
+		redis.DialReadTimeout(Timeout),
+		redis.DialWriteTimeout(Timeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("redis connection error: %s", err)
+	}
+	return c, nil
+}
+
+func newTLSConfig(caCert, tlsCert, tlsKey string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+	if err != nil {
+		return nil, fmt.Errorf("load key-pair error: %s", err)
+	}
+	conf := tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if caCert != "" {
+		b, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("read ca certificate error: %s", err)
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no pem encoded certificates found in %s", caCert)
+		}
+	}
+
+	return &conf, nil
+}
@@ -0,0 +1,104 @@
+// Package doctor implements the checks of the check command, which
+// validates the environment LoRa App Server depends on (databases, MQTT
+// broker, network-server, certificates, clock and database migrations) and
+// prints a diagnostic report.
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Timeout defines the timeout of each connectivity check.
+var Timeout = 5 * time.Second
+
+// Status defines the outcome of a check.
+type Status int
+
+// Possible check outcomes.
+const (
+	OK Status = iota
+	Warning
+	Error
+	Skipped
+)
+
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Warning:
+		return "WARNING"
+	case Error:
+		return "ERROR"
+	case Skipped:
+		return "SKIPPED"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Check defines a single check.
+type Check struct {
+	// Name of the check (e.g. "postgresql").
+	Name string
+
+	// Run performs the check and returns its outcome and a human readable
+	// description of it.
+	Run func() (Status, string)
+}
+
+// Result holds the result of a check.
+type Result struct {
+	Name     string
+	Status   Status
+	Message  string
+	Duration time.Duration
+}
+
+// Run runs the given checks in order and returns their results.
+func Run(checks []Check) []Result {
+	var out []Result
+	for _, c := range checks {
+		start := time.Now()
+		status, msg := c.Run()
+		out = append(out, Result{
+			Name:     c.Name,
+			Status:   status,
+			Message:  msg,
+			Duration: time.Since(start),
+		})
+	}
+	return out
+}
+
+// Failed returns true when one of the results has the Error status.
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteReport writes the given results as a table to w, followed by a
+// summary line.
+func WriteReport(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tDURATION\tMESSAGE")
+
+	counts := make(map[Status]int)
+	for _, r := range results {
+		counts[r.Status]++
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, r.Status, r.Duration.Round(time.Millisecond), r.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d check(s): %d ok, %d warning(s), %d error(s), %d skipped\n", len(results), counts[OK], counts[Warning], counts[Error], counts[Skipped])
+	return err
+}
@@ -0,0 +1,125 @@
+package doctor
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func writeCertificate(dir string, notBefore, notAfter time.Time) (string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		return "", "", err
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return "", "", err
+	}
+	return certFile, keyFile, nil
+}
+
+func TestCertificate(t *testing.T) {
+	Convey("Given a temporary directory", t, func() {
+		dir, err := ioutil.TempDir("", "doctor")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		now := time.Now()
+
+		tests := []struct {
+			Name           string
+			NotBefore      time.Time
+			NotAfter       time.Time
+			ExpectedStatus Status
+		}{
+			{"valid certificate", now.Add(-time.Hour), now.Add(365 * 24 * time.Hour), OK},
+			{"certificate expiring soon", now.Add(-time.Hour), now.Add(24 * time.Hour), Warning},
+			{"expired certificate", now.Add(-2 * time.Hour), now.Add(-time.Hour), Error},
+			{"certificate not yet valid", now.Add(time.Hour), now.Add(365 * 24 * time.Hour), Error},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				certFile, keyFile, err := writeCertificate(dir, test.NotBefore, test.NotAfter)
+				So(err, ShouldBeNil)
+
+				status, _ := Certificate("test", certFile, keyFile).Run()
+				So(status, ShouldEqual, test.ExpectedStatus)
+
+				status, _ = CACertificate("test", certFile).Run()
+				So(status, ShouldEqual, test.ExpectedStatus)
+			})
+		}
+
+		Convey("Then the check is skipped when not configured", func() {
+			status, _ := Certificate("test", "", "").Run()
+			So(status, ShouldEqual, Skipped)
+		})
+
+		Convey("Then a certificate without key is an error", func() {
+			status, _ := Certificate("test", filepath.Join(dir, "cert.pem"), "").Run()
+			So(status, ShouldEqual, Error)
+		})
+	})
+}
+
+func TestRun(t *testing.T) {
+	Convey("Given a set of checks", t, func() {
+		checks := []Check{
+			{Name: "a", Run: func() (Status, string) { return OK, "fine" }},
+			{Name: "b", Run: func() (Status, string) { return Warning, "hmm" }},
+		}
+
+		Convey("Then Run returns the result of each check", func() {
+			results := Run(checks)
+			So(results, ShouldHaveLength, 2)
+			So(results[0].Name, ShouldEqual, "a")
+			So(results[1].Status, ShouldEqual, Warning)
+			So(Failed(results), ShouldBeFalse)
+
+			Convey("Then the report contains the results and a summary", func() {
+				var buf bytes.Buffer
+				So(WriteReport(&buf, results), ShouldBeNil)
+				So(buf.String(), ShouldContainSubstring, "WARNING")
+				So(buf.String(), ShouldContainSubstring, "2 check(s): 1 ok, 1 warning(s), 0 error(s), 0 skipped")
+			})
+		})
+
+		Convey("Then Failed returns true when a check failed", func() {
+			checks = append(checks, Check{Name: "c", Run: func() (Status, string) { return Error, "broken" }})
+			So(Failed(Run(checks)), ShouldBeTrue)
+		})
+	})
+}
@@ -3,10 +3,13 @@ package downlink
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/garyburd/redigo/redis"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 
+	"github.com/brocaar/lora-app-server/internal/airtime"
 	"github.com/brocaar/lora-app-server/internal/common"
 	"github.com/brocaar/lora-app-server/internal/handler"
 	"github.com/brocaar/lora-app-server/internal/storage"
@@ -14,6 +17,44 @@ import (
 	"github.com/brocaar/lorawan"
 )
 
+const referenceKeyTempl = "device:%s:downlink:reference:%s"
+
+// ErrAirtimeBudgetExceeded is returned when the downlink airtime budget of
+// the node would be exceeded and the budget is enforced.
+var ErrAirtimeBudgetExceeded = errors.New("downlink airtime budget exceeded")
+
+// ReferenceTTL defines the duration for which the reference of an enqueued
+// downlink payload is stored. Within this duration, payloads with the same
+// reference (for the same node) are ignored so that enqueues can be retried
+// safely.
+var ReferenceTTL = 24 * time.Hour
+
+// Advisory contains the advisory metadata of an enqueued downlink payload,
+// so that the expectations about its transmission can be set.
+type Advisory struct {
+	// Airtime holds the estimated airtime of the transmission.
+	Airtime time.Duration
+
+	// QueuePosition holds the position of the payload in the queue (1 = next
+	// to be transmitted), 0 when it was pushed directly to the
+	// network-server (class-C).
+	QueuePosition int
+
+	// WaitForUplink is true when the payload is transmitted in the receive
+	// window following an uplink (class-A). As one payload is transmitted
+	// per uplink, QueuePosition uplinks are needed.
+	WaitForUplink bool
+
+	// TransmitAfter holds the earliest time of transmission, taking the
+	// airtime and the duty-cycle off-period of the payloads ahead in the
+	// queue into account.
+	TransmitAfter time.Time
+
+	// DutyCycle holds the max. duty-cycle (0 - 1) of the band, 0 meaning
+	// unrestricted.
+	DutyCycle float64
+}
+
 // HandleDataDownPayloads handles received downlink payloads to be emitted to the
 // nodes.
 func HandleDataDownPayloads() {
@@ -58,7 +99,42 @@ func handleDataDownPayload(pl handler.DataDownPayload) error {
 // HandleDownlinkQueueItem handles a DownlinkQueueItem to be emitted to the node.
 // In case of class-c, it will send the payload directly to the network-server.
 // In any other case, it will be enqueued.
+// When a payload with the same reference has already been handled for the
+// node (see ReferenceTTL), the item is ignored.
 func HandleDownlinkQueueItem(node storage.Node, qi *storage.DownlinkQueueItem) error {
+	if qi.Reference == "" {
+		return handleDownlinkQueueItem(node, qi)
+	}
+
+	ok, err := registerReference(node.DevEUI, qi.Reference)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.WithFields(log.Fields{
+			"dev_eui":   node.DevEUI,
+			"reference": qi.Reference,
+		}).Info("downlink payload with same reference already handled, ignoring")
+		return nil
+	}
+
+	if err := handleDownlinkQueueItem(node, qi); err != nil {
+		// remove the reference so that the enqueue can be retried
+		if delErr := deleteReference(node.DevEUI, qi.Reference); delErr != nil {
+			log.Errorf("delete downlink reference error: %s", delErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func handleDownlinkQueueItem(node storage.Node, qi *storage.DownlinkQueueItem) error {
+	d, err := checkAirtimeBudget(node, qi)
+	if err != nil {
+		return err
+	}
+
 	if node.IsClassC && qi.Confirmed {
 		qi.Pending = true
 	}
@@ -85,9 +161,96 @@ func HandleDownlinkQueueItem(node storage.Node, qi *storage.DownlinkQueueItem) e
 		}
 	}
 
+	if err := airtime.AddDownlinkUsage(node.DevEUI, d); err != nil {
+		log.WithField("dev_eui", node.DevEUI).Errorf("add downlink airtime usage error: %s", err)
+	}
+
 	return nil
 }
 
+// GetAdvisory returns the Advisory for the given (handled) queue-item.
+// For an item not stored in the queue (e.g. ignored because of its
+// reference), the advisory is returned as if it was appended to the queue.
+func GetAdvisory(node storage.Node, qi storage.DownlinkQueueItem) (Advisory, error) {
+	adv := Advisory{
+		TransmitAfter: time.Now(),
+		DutyCycle:     airtime.DutyCycle(),
+	}
+
+	d, err := airtime.EstimateDownlinkAirtime(node.DevEUI, len(qi.Data))
+	if err != nil {
+		return adv, fmt.Errorf("estimate downlink airtime error: %s", err)
+	}
+	adv.Airtime = d
+
+	// class-c payloads are pushed directly to the network-server
+	if node.IsClassC {
+		return adv, nil
+	}
+	adv.WaitForUplink = true
+
+	items, err := storage.GetDownlinkQueueItems(common.DB, node.DevEUI)
+	if err != nil {
+		return adv, fmt.Errorf("get downlink queue items error: %s", err)
+	}
+	for _, item := range items {
+		if qi.ID != 0 && item.ID >= qi.ID {
+			break
+		}
+
+		d, err := airtime.EstimateDownlinkAirtime(node.DevEUI, len(item.Data))
+		if err != nil {
+			return adv, fmt.Errorf("estimate downlink airtime error: %s", err)
+		}
+		adv.TransmitAfter = adv.TransmitAfter.Add(d + airtime.OffPeriod(d))
+		adv.QueuePosition++
+	}
+	adv.QueuePosition++
+
+	return adv, nil
+}
+
+// checkAirtimeBudget returns the estimated airtime of the given queue-item.
+// When this would exceed the downlink airtime budget of the node, a warning
+// is logged or ErrAirtimeBudgetExceeded is returned when the budget is
+// enforced.
+func checkAirtimeBudget(node storage.Node, qi *storage.DownlinkQueueItem) (time.Duration, error) {
+	d, err := airtime.EstimateDownlinkAirtime(node.DevEUI, len(qi.Data))
+	if err != nil {
+		return 0, fmt.Errorf("estimate downlink airtime error: %s", err)
+	}
+
+	app, err := storage.GetApplication(common.DB, node.ApplicationID)
+	if err != nil {
+		return 0, fmt.Errorf("get application error: %s", err)
+	}
+	if app.DownlinkAirtimeBudget == 0 {
+		return d, nil
+	}
+
+	used, err := airtime.GetDownlinkUsage(node.DevEUI)
+	if err != nil {
+		return 0, fmt.Errorf("get downlink airtime usage error: %s", err)
+	}
+
+	budget := time.Duration(app.DownlinkAirtimeBudget) * time.Millisecond
+	if used+d > budget {
+		logFields := log.Fields{
+			"dev_eui": node.DevEUI,
+			"airtime": d,
+			"used":    used,
+			"budget":  budget,
+		}
+		if app.DownlinkAirtimeBudgetEnforce {
+			log.WithFields(logFields).Warning("downlink airtime budget exceeded, rejecting downlink payload")
+			return 0, ErrAirtimeBudgetExceeded
+		}
+		log.WithFields(logFields).Warning("downlink airtime budget exceeded")
+	}
+
+	return d, nil
+}
+
 func pushDataDown(node storage.Node, qi *storage.DownlinkQueueItem) error {
 	nsResp, err := common.NetworkServer.GetNodeSession(context.Background(), &ns.GetNodeSessionRequest{
 		DevEUI: node.DevEUI[:],
@@ -113,3 +276,32 @@ func pushDataDown(node storage.Node, qi *storage.DownlinkQueueItem) error {
 	}
 	return nil
 }
+
+// registerReference registers the given reference for the given node.
+// It returns false when the reference was already registered.
+func registerReference(devEUI lorawan.EUI64, reference string) (bool, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(referenceKeyTempl, devEUI, reference)
+	_, err := redis.String(c.Do("SET", key, "", "PX", int64(ReferenceTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, fmt.Errorf("set downlink reference error: %s", err)
+	}
+	return true, nil
+}
+
+// deleteReference deletes the given reference for the given node.
+func deleteReference(devEUI lorawan.EUI64, reference string) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", common.RedisKey(referenceKeyTempl, devEUI, reference))
+	if err != nil {
+		return fmt.Errorf("delete downlink reference error: %s", err)
+	}
+	return nil
+}
@@ -20,6 +20,8 @@ func TestHandleDownlinkQueueItem(t *testing.T) {
 		So(err, ShouldBeNil)
 		common.DB = db
 		test.MustResetDB(common.DB)
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
 
 		nsClient := test.NewNetworkServerClient()
 		nsClient.GetNodeSessionResponse = ns.GetNodeSessionResponse{
@@ -69,6 +71,49 @@ func TestHandleDownlinkQueueItem(t *testing.T) {
 			Convey("Then nothing was sent to the network-server", func() {
 				So(nsClient.PushDataDownChan, ShouldHaveLength, 0)
 			})
+
+			Convey("When calling HandleDownlinkQueueItem again with the same reference", func() {
+				qi2 := qi
+				qi2.ID = 0
+				So(HandleDownlinkQueueItem(node, &qi2), ShouldBeNil)
+
+				Convey("Then the item was not added to the queue again", func() {
+					items, err := storage.GetDownlinkQueueItems(common.DB, node.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When calling HandleDownlinkQueueItem with a different reference", func() {
+				qi2 := qi
+				qi2.ID = 0
+				qi2.Reference = "test2"
+				So(HandleDownlinkQueueItem(node, &qi2), ShouldBeNil)
+
+				Convey("Then the item was added to the queue", func() {
+					items, err := storage.GetDownlinkQueueItems(common.DB, node.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 2)
+				})
+			})
+		})
+
+		Convey("Given the application has an enforced downlink airtime budget of 100ms", func() {
+			app.DownlinkAirtimeBudget = 100
+			app.DownlinkAirtimeBudgetEnforce = true
+			So(storage.UpdateApplication(common.DB, app), ShouldBeNil)
+
+			Convey("When calling HandleDownlinkQueueItem (the data-rate is unknown, so SF12 is assumed)", func() {
+				err := HandleDownlinkQueueItem(node, &qi)
+
+				Convey("Then ErrAirtimeBudgetExceeded is returned and the item was not added to the queue", func() {
+					So(err, ShouldEqual, ErrAirtimeBudgetExceeded)
+
+					items, err := storage.GetDownlinkQueueItems(common.DB, node.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+			})
 		})
 
 		Convey("When calling HandleDownlinkQueueItem for a class-c device", func() {
@@ -0,0 +1,181 @@
+// Package egress configures the outbound HTTP connections made by LoRa App
+// Server (e.g. the HTTP integration, digest reports and registry sync), so
+// that they originate from a known source address or egress proxy, and
+// holds the egress addresses to communicate to the receivers (e.g. for
+// firewalling inbound webhooks by source).
+package egress
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Transport holds the http.Transport used for all outbound HTTP
+// connections. Use NewClient for creating a client using this transport.
+var Transport = &http.Transport{
+	Proxy:                 http.ProxyFromEnvironment,
+	DialContext:           newDialer(nil).DialContext,
+	MaxIdleConns:          100,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: time.Second,
+}
+
+// Config contains the egress configuration.
+type Config struct {
+	// BindAddress defines the local address from which the outbound
+	// connections are made (optional).
+	BindAddress net.IP
+
+	// ProxyURL defines the proxy used for the outbound connections
+	// (optional).
+	ProxyURL *url.URL
+
+	// IPAddresses contains the public (source) IP addresses of the outbound
+	// connections, as seen by the receivers.
+	IPAddresses []string
+}
+
+var (
+	configMu sync.RWMutex
+	config   Config
+)
+
+// NewClient returns a new http.Client using the egress Transport and the
+// given timeout (0 for no timeout).
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: Transport,
+	}
+}
+
+// NewTLSClient returns a new http.Client with its own transport, using the
+// egress settings of Transport and the given TLS configuration (e.g. for
+// connections requiring a client certificate). As the connections are
+// pooled per transport, the returned client should be re-used.
+func NewTLSClient(timeout time.Duration, tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 Transport.Proxy,
+			DialContext:           Transport.DialContext,
+			MaxIdleConns:          Transport.MaxIdleConns,
+			IdleConnTimeout:       Transport.IdleConnTimeout,
+			TLSHandshakeTimeout:   Transport.TLSHandshakeTimeout,
+			ExpectContinueTimeout: Transport.ExpectContinueTimeout,
+			TLSClientConfig:       tlsConfig,
+		},
+	}
+}
+
+// Configure configures the outbound connections. The bind value can be an
+// IP address or a network interface name, in which case the first address
+// of this interface is used (IPv4 preferred). The proxy must be a HTTP(S)
+// proxy URL. The given IP addresses are the public egress addresses to
+// communicate to the receivers. This function must be called before making
+// any outbound requests.
+func Configure(bind, proxy string, ipAddresses []string) error {
+	var conf Config
+
+	if bind != "" {
+		ip, err := resolveBindAddress(bind)
+		if err != nil {
+			return err
+		}
+		conf.BindAddress = ip
+	}
+
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil {
+			return errors.Wrap(err, "parse proxy url error")
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("proxy url must be a http or https url, got: %s", proxy)
+		}
+		conf.ProxyURL = u
+	}
+
+	for _, s := range ipAddresses {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return fmt.Errorf("invalid egress ip address: %s", s)
+		}
+		conf.IPAddresses = append(conf.IPAddresses, ip.String())
+	}
+
+	Transport.DialContext = newDialer(conf.BindAddress).DialContext
+	if conf.ProxyURL != nil {
+		Transport.Proxy = http.ProxyURL(conf.ProxyURL)
+	} else {
+		Transport.Proxy = http.ProxyFromEnvironment
+	}
+	Transport.CloseIdleConnections()
+
+	configMu.Lock()
+	config = conf
+	configMu.Unlock()
+
+	return nil
+}
+
+// GetConfig returns the egress configuration.
+func GetConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config
+}
+
+func newDialer(bindAddress net.IP) *net.Dialer {
+	d := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if bindAddress != nil {
+		d.LocalAddr = &net.TCPAddr{IP: bindAddress}
+	}
+	return d
+}
+
+// resolveBindAddress returns the IP address of the given IP address or
+// interface name.
+func resolveBindAddress(bind string) (net.IP, error) {
+	if ip := net.ParseIP(bind); ip != nil {
+		return ip, nil
+	}
+
+	iface, err := net.InterfaceByName(bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "bind address must be an ip address or interface name")
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, errors.Wrap(err, "get interface addresses error")
+	}
+
+	var out net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if out == nil {
+			out = ipNet.IP
+		}
+	}
+	if out == nil {
+		return nil, fmt.Errorf("interface %s has no ip address", bind)
+	}
+	return out, nil
+}
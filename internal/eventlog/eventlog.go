@@ -0,0 +1,132 @@
+// Package eventlog provides a Redis pub/sub based log of the events sent
+// for each device and application, so that these can be consumed through
+// the API.
+package eventlog
+
+import (
+	"encoding/json"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	deviceEventPubSubKeyTempl      = "device:%s:pubsub:event"
+	applicationEventPubSubKeyTempl = "application:%d:pubsub:event"
+)
+
+// Event types.
+const (
+	Uplink   = "uplink"
+	Join     = "join"
+	ACK      = "ack"
+	Error    = "error"
+	Security = "security"
+	Custom   = "custom"
+)
+
+// EventLog contains an event log.
+type EventLog struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// LogEventForDevice logs an event for the given device.
+func LogEventForDevice(devEUI lorawan.EUI64, typ string, payload interface{}) error {
+	return logEvent(common.RedisKey(deviceEventPubSubKeyTempl, devEUI), typ, payload)
+}
+
+// LogEventForApplication logs an event for the given application.
+func LogEventForApplication(applicationID int64, typ string, payload interface{}) error {
+	return logEvent(common.RedisKey(applicationEventPubSubKeyTempl, applicationID), typ, payload)
+}
+
+func logEvent(key, typ string, payload interface{}) error {
+	pl, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal payload error")
+	}
+	b, err := json.Marshal(EventLog{
+		Type:    typ,
+		Payload: pl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal event log error")
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	if _, err := c.Do("PUBLISH", key, b); err != nil {
+		return errors.Wrap(err, "publish event log error")
+	}
+	return nil
+}
+
+// GetEventLogForDevice subscribes to the device events for the given DevEUI
+// and sends these to the given channel. It blocks until the given context
+// has been cancelled.
+func GetEventLogForDevice(ctx context.Context, devEUI lorawan.EUI64, eventsChan chan EventLog) error {
+	return getEventLog(ctx, common.RedisKey(deviceEventPubSubKeyTempl, devEUI), eventsChan)
+}
+
+// GetEventLogForApplication subscribes to the events of the given
+// application and sends these to the given channel. It blocks until the
+// given context has been cancelled.
+func GetEventLogForApplication(ctx context.Context, applicationID int64, eventsChan chan EventLog) error {
+	return getEventLog(ctx, common.RedisKey(applicationEventPubSubKeyTempl, applicationID), eventsChan)
+}
+
+func getEventLog(ctx context.Context, key string, eventsChan chan EventLog) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.Subscribe(key); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				var el EventLog
+				if err := json.Unmarshal(v.Data, &el); err != nil {
+					log.WithField("key", key).Errorf("decode event log error: %s", err)
+					continue
+				}
+				select {
+				case eventsChan <- el:
+				case <-ctx.Done():
+				}
+			case redis.Subscription:
+				if v.Count == 0 {
+					done <- nil
+					return
+				}
+			case error:
+				done <- v
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-done:
+		return errors.Wrap(err, "receive error")
+	}
+
+	if err := psc.Unsubscribe(); err != nil {
+		return errors.Wrap(err, "unsubscribe error")
+	}
+
+	return <-done
+}
@@ -0,0 +1,105 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestEventLog(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Given a subscriber for the device events", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			eventsChan := make(chan EventLog, 1)
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- GetEventLogForDevice(ctx, devEUI, eventsChan)
+			}()
+
+			// some time to subscribe
+			time.Sleep(100 * time.Millisecond)
+
+			Convey("When logging an event for the device", func() {
+				So(LogEventForDevice(devEUI, Join, handler.JoinNotification{
+					ApplicationID: 1,
+					DevEUI:        devEUI,
+				}), ShouldBeNil)
+
+				Convey("Then the event is received by the subscriber", func() {
+					el := <-eventsChan
+					So(el.Type, ShouldEqual, Join)
+					So(string(el.Payload), ShouldEqual, `{"applicationID":"1","applicationName":"","nodeName":"","devEUI":"0102030405060708","devAddr":"00000000"}`)
+
+					cancel()
+					So(<-errChan, ShouldBeNil)
+				})
+			})
+
+			Convey("When logging an event for an other device", func() {
+				So(LogEventForDevice(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, Join, handler.JoinNotification{}), ShouldBeNil)
+
+				Convey("Then no event is received by the subscriber", func() {
+					time.Sleep(100 * time.Millisecond)
+					So(eventsChan, ShouldHaveLength, 0)
+
+					cancel()
+					So(<-errChan, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("Given a subscriber for the application events", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			eventsChan := make(chan EventLog, 1)
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- GetEventLogForApplication(ctx, 1, eventsChan)
+			}()
+
+			// some time to subscribe
+			time.Sleep(100 * time.Millisecond)
+
+			Convey("When logging an event for the application", func() {
+				So(LogEventForApplication(1, Uplink, handler.DataUpPayload{
+					ApplicationID: 1,
+					DevEUI:        devEUI,
+				}), ShouldBeNil)
+
+				Convey("Then the event is received by the subscriber", func() {
+					el := <-eventsChan
+					So(el.Type, ShouldEqual, Uplink)
+
+					cancel()
+					So(<-errChan, ShouldBeNil)
+				})
+			})
+
+			Convey("When logging an event for an other application", func() {
+				So(LogEventForApplication(2, Uplink, handler.DataUpPayload{}), ShouldBeNil)
+
+				Convey("Then no event is received by the subscriber", func() {
+					time.Sleep(100 * time.Millisecond)
+					So(eventsChan, ShouldHaveLength, 0)
+
+					cancel()
+					So(<-errChan, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
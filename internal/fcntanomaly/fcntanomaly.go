@@ -0,0 +1,127 @@
+// Package fcntanomaly detects frame-counter anomalies (resets, large jumps
+// and potential replays) in the uplinks of the nodes.
+package fcntanomaly
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	lastUplinkKeyTempl = "device:%s:fcnt:last"
+	lastUplinkTTL      = 30 * 24 * time.Hour
+)
+
+// Anomaly types.
+const (
+	FCntReset  = "FCNT_RESET"
+	FCntJump   = "FCNT_JUMP"
+	FCntReplay = "FCNT_REPLAY"
+)
+
+var (
+	// JumpThreshold defines the frame-counter gap above which an uplink
+	// is reported as a frame-counter jump (0 = disabled).
+	JumpThreshold uint32 = 1000
+
+	// ResetThreshold defines the max frame-counter value of an uplink
+	// with a lower frame-counter than the previous uplink, for it to be
+	// reported as a frame-counter reset. Lower frame-counters above
+	// this value are reported as potential replay.
+	ResetThreshold uint32 = 10
+)
+
+// Anomaly defines a detected frame-counter anomaly.
+type Anomaly struct {
+	Type    string
+	Message string
+}
+
+// Check checks the given uplink against the previous uplink of the node
+// and returns the detected anomaly (or nil). An uplink with the same
+// frame-counter as the previous uplink, but with a different payload is
+// reported as potential replay. With the same payload it is considered a
+// retransmission.
+func Check(devEUI lorawan.EUI64, fCnt uint32, data []byte) (*Anomaly, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:8])
+
+	lastFCnt, lastHash, ok, err := getSetLastUplink(devEUI, fCnt, hash)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	switch {
+	case fCnt == lastFCnt:
+		if hash != lastHash {
+			return &Anomaly{
+				Type:    FCntReplay,
+				Message: fmt.Sprintf("received a different payload with the same frame-counter (%d) as the previous uplink", fCnt),
+			}, nil
+		}
+	case fCnt < lastFCnt:
+		if fCnt <= ResetThreshold {
+			return &Anomaly{
+				Type:    FCntReset,
+				Message: fmt.Sprintf("frame-counter was reset from %d to %d", lastFCnt, fCnt),
+			}, nil
+		}
+		return &Anomaly{
+			Type:    FCntReplay,
+			Message: fmt.Sprintf("frame-counter decreased from %d to %d", lastFCnt, fCnt),
+		}, nil
+	case JumpThreshold != 0 && fCnt-lastFCnt > JumpThreshold:
+		return &Anomaly{
+			Type:    FCntJump,
+			Message: fmt.Sprintf("frame-counter jumped from %d to %d", lastFCnt, fCnt),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// getSetLastUplink stores the given frame-counter and payload hash as the
+// last uplink of the given node and returns the previous values. The
+// returned bool is false when there was no previous uplink.
+func getSetLastUplink(devEUI lorawan.EUI64, fCnt uint32, hash string) (uint32, string, bool, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(lastUplinkKeyTempl, devEUI)
+	c.Send("MULTI")
+	c.Send("GETSET", key, fmt.Sprintf("%d:%s", fCnt, hash))
+	c.Send("PEXPIRE", key, int64(lastUplinkTTL/time.Millisecond))
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return 0, "", false, errors.Wrap(err, "get / set last uplink error")
+	}
+
+	last, err := redis.String(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, "", false, nil
+		}
+		return 0, "", false, errors.Wrap(err, "read last uplink error")
+	}
+
+	parts := strings.SplitN(last, ":", 2)
+	if len(parts) != 2 {
+		return 0, "", false, fmt.Errorf("invalid last uplink value: %s", last)
+	}
+	lastFCnt, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, "", false, errors.Wrap(err, "parse last frame-counter error")
+	}
+
+	return uint32(lastFCnt), parts[1], true, nil
+}
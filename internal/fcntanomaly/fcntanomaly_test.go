@@ -0,0 +1,68 @@
+package fcntanomaly
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestCheck(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Given an uplink with frame-counter 100", func() {
+			a, err := Check(devEUI, 100, []byte{1, 2, 3})
+			So(err, ShouldBeNil)
+			So(a, ShouldBeNil)
+
+			tests := []struct {
+				Name         string
+				FCnt         uint32
+				Data         []byte
+				ExpectedType string
+			}{
+				{"next frame-counter", 101, []byte{1}, ""},
+				{"small gap", 200, []byte{1}, ""},
+				{"retransmission", 100, []byte{1, 2, 3}, ""},
+				{"same frame-counter with a different payload", 100, []byte{3, 2, 1}, FCntReplay},
+				{"reset", 0, []byte{1}, FCntReset},
+				{"decreased frame-counter", 50, []byte{1}, FCntReplay},
+				{"jump", 1101, []byte{1}, FCntJump},
+			}
+
+			for _, test := range tests {
+				Convey("Testing: "+test.Name, func() {
+					a, err := Check(devEUI, test.FCnt, test.Data)
+					So(err, ShouldBeNil)
+					if test.ExpectedType == "" {
+						So(a, ShouldBeNil)
+					} else {
+						So(a, ShouldNotBeNil)
+						So(a.Type, ShouldEqual, test.ExpectedType)
+					}
+				})
+			}
+
+			Convey("Given the jump detection is disabled", func() {
+				JumpThreshold = 0
+				defer func() { JumpThreshold = 1000 }()
+
+				Convey("Then a large gap is not reported", func() {
+					a, err := Check(devEUI, 100000, []byte{1})
+					So(err, ShouldBeNil)
+					So(a, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,131 @@
+// Package geohash implements the encoding of GPS coordinates into geohash
+// cells.
+package geohash
+
+import (
+	"errors"
+	"strings"
+)
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxPrecision defines the max supported precision (number of characters).
+const MaxPrecision = 12
+
+// ErrInvalidGeohash is returned when the given geohash is invalid.
+var ErrInvalidGeohash = errors.New("invalid geohash")
+
+// Box defines the bounding box of a geohash cell.
+type Box struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Center returns the center of the box.
+func (b Box) Center() (float64, float64) {
+	return (b.MinLatitude + b.MaxLatitude) / 2, (b.MinLongitude + b.MaxLongitude) / 2
+}
+
+// Encode returns the geohash (with the given precision) of the cell
+// containing the given coordinates.
+func Encode(latitude, longitude float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	out := make([]byte, 0, precision)
+	var bit, ch int
+	even := true
+
+	for len(out) < precision {
+		if even {
+			mid := (lonRange[0] + lonRange[1]) / 2
+			if longitude >= mid {
+				ch |= 1 << uint(4-bit)
+				lonRange[0] = mid
+			} else {
+				lonRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if latitude >= mid {
+				ch |= 1 << uint(4-bit)
+				latRange[0] = mid
+			} else {
+				latRange[1] = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			out = append(out, base32[ch])
+			bit = 0
+			ch = 0
+		}
+	}
+
+	return string(out)
+}
+
+// BoundingBox returns the bounding box of the given geohash cell.
+func BoundingBox(hash string) (Box, error) {
+	if hash == "" || len(hash) > MaxPrecision {
+		return Box{}, ErrInvalidGeohash
+	}
+
+	box := Box{
+		MinLatitude:  -90,
+		MinLongitude: -180,
+		MaxLatitude:  90,
+		MaxLongitude: 180,
+	}
+	even := true
+
+	for _, c := range hash {
+		i := strings.IndexRune(base32, c)
+		if i == -1 {
+			return Box{}, ErrInvalidGeohash
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			set := i&(1<<uint(bit)) != 0
+			if even {
+				mid := (box.MinLongitude + box.MaxLongitude) / 2
+				if set {
+					box.MinLongitude = mid
+				} else {
+					box.MaxLongitude = mid
+				}
+			} else {
+				mid := (box.MinLatitude + box.MaxLatitude) / 2
+				if set {
+					box.MinLatitude = mid
+				} else {
+					box.MaxLatitude = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return box, nil
+}
+
+// CellSize returns the height (latitude) and width (longitude) in degrees
+// of the cells with the given precision.
+func CellSize(precision int) (float64, float64) {
+	latBits := (5 * precision) / 2
+	lonBits := 5*precision - latBits
+
+	return 180 / float64(uint64(1)<<uint(latBits)), 360 / float64(uint64(1)<<uint(lonBits))
+}
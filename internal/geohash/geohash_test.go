@@ -0,0 +1,55 @@
+package geohash
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEncode(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Latitude  float64
+			Longitude float64
+			Precision int
+			Expected  string
+		}{
+			{57.64911, 10.40744, 11, "u4pruydqqvj"},
+			{57.64911, 10.40744, 5, "u4pru"},
+			{-25.382708, -49.265506, 7, "6gkzwgj"},
+			{0, 0, 1, "s"},
+		}
+
+		for _, test := range tests {
+			So(Encode(test.Latitude, test.Longitude, test.Precision), ShouldEqual, test.Expected)
+		}
+	})
+}
+
+func TestBoundingBox(t *testing.T) {
+	Convey("Given the geohash u4pru", t, func() {
+		box, err := BoundingBox("u4pru")
+		So(err, ShouldBeNil)
+
+		Convey("Then the bounding box contains the encoded coordinates", func() {
+			So(box.MinLatitude, ShouldBeLessThanOrEqualTo, 57.64911)
+			So(box.MaxLatitude, ShouldBeGreaterThan, 57.64911)
+			So(box.MinLongitude, ShouldBeLessThanOrEqualTo, 10.40744)
+			So(box.MaxLongitude, ShouldBeGreaterThan, 10.40744)
+		})
+
+		Convey("Then the size of the box equals the cell size", func() {
+			height, width := CellSize(5)
+			So(box.MaxLatitude-box.MinLatitude, ShouldAlmostEqual, height)
+			So(box.MaxLongitude-box.MinLongitude, ShouldAlmostEqual, width)
+		})
+	})
+
+	Convey("Given an invalid geohash", t, func() {
+		_, err := BoundingBox("u4pra")
+
+		Convey("Then ErrInvalidGeohash is returned", func() {
+			So(err, ShouldEqual, ErrInvalidGeohash)
+		})
+	})
+}
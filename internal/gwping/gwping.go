@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
-	"fmt"
 	"time"
 
 	"github.com/garyburd/redigo/redis"
@@ -13,6 +12,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/leader"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/api/ns"
@@ -21,14 +21,21 @@ import (
 
 const (
 	micLookupExpire = time.Second * 10
-	micLookupTempl  = "lora:as:gwping:%s"
+	micLookupTempl  = "gwping:%s"
 )
 
-// SendPingLoop is a never returning function sending the gateway pings.
+// ErrUnknownPing is returned when the received proprietary frame does not
+// match a sent gateway ping.
+var ErrUnknownPing = errors.New("unknown gateway ping")
+
+// SendPingLoop is a never returning function sending the gateway pings
+// (when this instance is the leader of the gateway ping job).
 func SendPingLoop() {
 	for {
-		if err := sendGatewayPing(); err != nil {
-			log.Errorf("send gateway ping error: %s", err)
+		if leader.IsLeader(leader.GatewayPing) {
+			if err := sendGatewayPing(); err != nil {
+				log.Errorf("send gateway ping error: %s", err)
+			}
 		}
 		time.Sleep(time.Second)
 	}
@@ -41,6 +48,9 @@ func HandleReceivedPing(req *as.HandleProprietaryUpRequest) error {
 
 	id, err := getPingLookup(mic)
 	if err != nil {
+		if err == ErrUnknownPing {
+			return err
+		}
 		return errors.Wrap(err, "get ping lookup error")
 	}
 
@@ -197,7 +207,7 @@ func CreatePingLookup(mic lorawan.MIC, id int64) error {
 	c := common.RedisPool.Get()
 	defer c.Close()
 
-	_, err := redis.String(c.Do("PSETEX", fmt.Sprintf(micLookupTempl, mic), int64(micLookupExpire)/int64(time.Millisecond), id))
+	_, err := redis.String(c.Do("PSETEX", common.RedisKey(micLookupTempl, mic), int64(micLookupExpire)/int64(time.Millisecond), id))
 	if err != nil {
 		return errors.Wrap(err, "set mic lookup error")
 	}
@@ -208,8 +218,11 @@ func getPingLookup(mic lorawan.MIC) (int64, error) {
 	c := common.RedisPool.Get()
 	defer c.Close()
 
-	id, err := redis.Int64(c.Do("GET", fmt.Sprintf(micLookupTempl, mic)))
+	id, err := redis.Int64(c.Do("GET", common.RedisKey(micLookupTempl, mic)))
 	if err != nil {
+		if err == redis.ErrNil {
+			return 0, ErrUnknownPing
+		}
 		return 0, errors.Wrap(err, "get ping lookup error")
 	}
 
@@ -220,7 +233,7 @@ func deletePingLookup(mic lorawan.MIC) error {
 	c := common.RedisPool.Get()
 	defer c.Close()
 
-	_, err := redis.Int(c.Do("DEL", fmt.Sprintf(micLookupTempl, mic)))
+	_, err := redis.Int(c.Do("DEL", common.RedisKey(micLookupTempl, mic)))
 	if err != nil {
 		return errors.Wrap(err, "delete ping lookup error")
 	}
@@ -109,7 +109,7 @@ func TestGatewayPing(t *testing.T) {
 
 						Convey("Then the ping lookup has been deleted", func() {
 							_, err := getPingLookup(mic)
-							So(err, ShouldNotBeNil)
+							So(err, ShouldEqual, ErrUnknownPing)
 						})
 
 						Convey("Then the received ping has been stored to the database", func() {
@@ -0,0 +1,315 @@
+// Package amqphandler implements a handler publishing the events to an
+// AMQP exchange (e.g. RabbitMQ).
+package amqphandler
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/amqp"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+// DefaultRoutingKeyTemplate defines the routing-key template used when no
+// template is configured.
+const DefaultRoutingKeyTemplate = "application.{{ .ApplicationID }}.device.{{ .DevEUI }}.{{ .EventType }}"
+
+// Connect modes.
+const (
+	// ConnectLazy establishes the connection on the first event (default).
+	ConnectLazy = "LAZY"
+
+	// ConnectEager establishes the connection when the integration is
+	// configured and keeps it established in the background.
+	ConnectEager = "EAGER"
+)
+
+// Max. values of the connection settings (in seconds).
+const (
+	maxKeepAlive = 3600
+	maxMaxIdle   = 86400
+)
+
+// keepWarmInterval defines the interval at which the connections of the
+// eager integrations are checked and re-established when needed.
+const keepWarmInterval = 10 * time.Second
+
+// event types
+const (
+	uplinkEvent      = "rx"
+	joinEvent        = "join"
+	ackEvent         = "ack"
+	errorEvent       = "error"
+	securityEvent    = "security"
+	proprietaryEvent = "proprietary"
+	customEvent      = "event"
+)
+
+// HandlerConfig contains the configuration for an AMQP handler. The URL
+// contains the credentials and is therefore stored encrypted (see the secret
+// package).
+type HandlerConfig struct {
+	URL                secret.String `json:"url"`
+	Exchange           string        `json:"exchange"`
+	RoutingKeyTemplate string        `json:"routingKeyTemplate"`
+	ConnectMode        string        `json:"connectMode,omitempty"`
+	KeepAlive          int           `json:"keepAlive,omitempty"`
+	MaxIdle            int           `json:"maxIdle,omitempty"`
+	Marshaler          string        `json:"marshaler,omitempty"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	if _, err := amqp.ParseURI(string(c.URL)); err != nil {
+		return ErrInvalidURL
+	}
+	if !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+	if len(c.Exchange) > 255 {
+		return ErrInvalidExchange
+	}
+	if _, err := c.routingKeyTemplate(); err != nil {
+		return ErrInvalidRoutingKeyTemplate
+	}
+	switch c.ConnectMode {
+	case "", ConnectLazy, ConnectEager:
+	default:
+		return ErrInvalidConnectMode
+	}
+	if c.KeepAlive < 0 || c.KeepAlive > maxKeepAlive {
+		return ErrInvalidKeepAlive
+	}
+	if c.MaxIdle < 0 || c.MaxIdle > maxMaxIdle {
+		return ErrInvalidMaxIdle
+	}
+	if err := marshaler.Validate(c.Marshaler); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c HandlerConfig) routingKeyTemplate() (*template.Template, error) {
+	s := c.RoutingKeyTemplate
+	if s == "" {
+		s = DefaultRoutingKeyTemplate
+	}
+	return template.New("routing-key").Option("missingkey=error").Parse(s)
+}
+
+// RoutingKeyData contains the data available to the routing-key template.
+// For custom events, EventType is event.{name} and EventName the name of
+// the event.
+type RoutingKeyData struct {
+	ApplicationID   int64
+	ApplicationName string
+	DevEUI          lorawan.EUI64
+	NodeName        string
+	EventType       string
+	EventName       string
+}
+
+// publisherKey identifies a publisher by URL and connection settings.
+type publisherKey struct {
+	url         string
+	connectMode string
+	keepAlive   int
+	maxIdle     int
+}
+
+// publishers contains the AMQP publishers per URL and connection settings,
+// so that the connections are re-used across the events.
+var (
+	publishersMu sync.Mutex
+	publishers   = make(map[publisherKey]*amqp.Publisher)
+)
+
+// Handler implements an AMQP handler.
+type Handler struct {
+	config     HandlerConfig
+	routingKey *template.Template
+	publisher  *amqp.Publisher
+	marshaler  marshaler.Marshaler
+}
+
+// NewHandler creates a new AMQP Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	routingKey, err := conf.routingKeyTemplate()
+	if err != nil {
+		return nil, errors.Wrap(err, "parse routing-key template error")
+	}
+
+	m, err := marshaler.New(conf.Marshaler)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := getPublisher(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		config:     conf,
+		routingKey: routingKey,
+		publisher:  p,
+		marshaler:  m,
+	}, nil
+}
+
+// Prewarm sets up the publisher of the given configuration, so that for
+// eager integrations the connection is established before the first event.
+// For lazy integrations this is a no-op.
+func Prewarm(conf HandlerConfig) error {
+	if conf.ConnectMode != ConnectEager {
+		return nil
+	}
+	_, err := getPublisher(conf)
+	return err
+}
+
+// getPublisher returns the (shared) publisher for the given configuration.
+// For eager integrations, the connection of a new publisher is kept
+// established in the background.
+func getPublisher(conf HandlerConfig) (*amqp.Publisher, error) {
+	key := publisherKey{
+		url:         string(conf.URL),
+		connectMode: conf.ConnectMode,
+		keepAlive:   conf.KeepAlive,
+		maxIdle:     conf.MaxIdle,
+	}
+
+	publishersMu.Lock()
+	defer publishersMu.Unlock()
+
+	if p, ok := publishers[key]; ok {
+		return p, nil
+	}
+
+	p, err := amqp.NewPublisher(string(conf.URL), amqp.Options{
+		KeepAlive: time.Duration(conf.KeepAlive) * time.Second,
+		MaxIdle:   time.Duration(conf.MaxIdle) * time.Second,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "new amqp publisher error")
+	}
+	publishers[key] = p
+
+	if conf.ConnectMode == ConnectEager {
+		go keepWarm(p)
+	}
+
+	return p, nil
+}
+
+// keepWarm (re-)establishes the connection of the given publisher when it
+// is not connected or exceeded the max. idle duration.
+func keepWarm(p *amqp.Publisher) {
+	for {
+		if err := p.Connect(); err != nil {
+			log.Errorf("handler/amqp: pre-warming connection error, will retry in %s: %s", keepWarmInterval, err)
+		}
+		time.Sleep(keepWarmInterval)
+	}
+}
+
+// Close closes the handler. As the connection is shared with the other
+// handlers using the same URL, it is not closed.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.publish(eventData(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI, uplinkEvent), pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.publish(eventData(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI, joinEvent), pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.publish(eventData(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI, ackEvent), pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.publish(eventData(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI, errorEvent), pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.publish(eventData(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI, securityEvent), pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload. As this payload is
+// not related to a device, the DevEUI of the routing-key data is zero.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.publish(eventData(pl.ApplicationID, pl.ApplicationName, "", lorawan.EUI64{}, proprietaryEvent), pl)
+}
+
+// SendCustomEvent sends a custom event. For events not related to a
+// device, the DevEUI of the routing-key data is zero.
+func (h *Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	var devEUI lorawan.EUI64
+	if pl.DevEUI != nil {
+		devEUI = *pl.DevEUI
+	}
+	data := eventData(pl.ApplicationID, pl.ApplicationName, pl.NodeName, devEUI, customEvent+"."+pl.Name)
+	data.EventName = pl.Name
+	return h.publish(data, pl)
+}
+
+func eventData(applicationID int64, applicationName, nodeName string, devEUI lorawan.EUI64, eventType string) RoutingKeyData {
+	return RoutingKeyData{
+		ApplicationID:   applicationID,
+		ApplicationName: applicationName,
+		DevEUI:          devEUI,
+		NodeName:        nodeName,
+		EventType:       eventType,
+	}
+}
+
+// publish publishes the given event encoded by the configured marshaler,
+// using the routing-key rendered from the given data.
+func (h *Handler) publish(data RoutingKeyData, pl interface{}) error {
+	routingKey, err := h.getRoutingKey(data)
+	if err != nil {
+		return err
+	}
+
+	b, err := h.marshaler.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	log.WithFields(log.Fields{
+		"exchange":    h.config.Exchange,
+		"routing_key": routingKey,
+	}).Info("handler/amqp: publishing event")
+
+	if err := h.publisher.Publish(h.config.Exchange, routingKey, h.marshaler.ContentType(), b); err != nil {
+		return fmt.Errorf("handler/amqp: publish %s event error: %s", data.EventType, err)
+	}
+	return nil
+}
+
+// getRoutingKey returns the routing-key rendered from the given data.
+func (h *Handler) getRoutingKey(data RoutingKeyData) (string, error) {
+	var b bytes.Buffer
+	if err := h.routingKey.Execute(&b, data); err != nil {
+		return "", errors.Wrap(err, "execute routing-key template error")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,169 @@
+// Package azurehandler implements a handler sending the events to an Azure
+// Service Bus queue or topic, or as IoT Hub device-to-cloud messages.
+package azurehandler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/azure"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+// event types
+const (
+	uplinkEvent      = "rx"
+	joinEvent        = "join"
+	ackEvent         = "ack"
+	errorEvent       = "error"
+	securityEvent    = "security"
+	proprietaryEvent = "proprietary"
+)
+
+// message property names
+const (
+	applicationIDProperty = "applicationID"
+	devEUIProperty        = "devEUI"
+	eventTypeProperty     = "eventType"
+)
+
+// HandlerConfig contains the configuration for an Azure handler. The
+// ConnectionString contains the shared access key and is therefore stored
+// encrypted (see the secret package).
+type HandlerConfig struct {
+	ConnectionString secret.String `json:"connectionString"`
+	QueueOrTopic     string        `json:"queueOrTopic"`
+	Marshaler        string        `json:"marshaler,omitempty"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	cs, err := azure.ParseConnectionString(string(c.ConnectionString))
+	if err != nil {
+		return ErrInvalidConnectionString
+	}
+	if !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+
+	// the queue or topic only applies to Service Bus and can be given by
+	// the EntityPath of the connection string
+	if cs.IsServiceBus() && c.QueueOrTopic == "" && cs.EntityPath == "" {
+		return ErrInvalidQueueOrTopic
+	}
+	if cs.IsIoTHub() && c.QueueOrTopic != "" {
+		return ErrInvalidQueueOrTopic
+	}
+	if err := marshaler.Validate(c.Marshaler); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Handler implements an Azure handler.
+type Handler struct {
+	client    azure.Client
+	marshaler marshaler.Marshaler
+}
+
+// NewHandler creates a new Azure Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	cs, err := azure.ParseConnectionString(string(conf.ConnectionString))
+	if err != nil {
+		return nil, ErrInvalidConnectionString
+	}
+
+	m, err := marshaler.New(conf.Marshaler)
+	if err != nil {
+		return nil, err
+	}
+
+	var client azure.Client
+	if cs.IsIoTHub() {
+		client, err = azure.NewIoTHubClient(cs)
+	} else {
+		client, err = azure.NewServiceBusClient(cs, conf.QueueOrTopic)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "new azure client error")
+	}
+
+	return &Handler{
+		client:    client,
+		marshaler: m,
+	}, nil
+}
+
+// Close closes the handler.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.send(pl.ApplicationID, &pl.DevEUI, uplinkEvent, pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.send(pl.ApplicationID, &pl.DevEUI, joinEvent, pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.send(pl.ApplicationID, &pl.DevEUI, ackEvent, pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.send(pl.ApplicationID, &pl.DevEUI, errorEvent, pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.send(pl.ApplicationID, &pl.DevEUI, securityEvent, pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload. As this payload is
+// not related to a device, the devEUI property is omitted.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.send(pl.ApplicationID, nil, proprietaryEvent, pl)
+}
+
+// send sends the given event encoded by the configured marshaler, with the application ID, DevEUI and
+// event type as message properties (e.g. for Service Bus subscription
+// filters or IoT Hub message routing).
+func (h *Handler) send(applicationID int64, devEUI *lorawan.EUI64, eventType string, pl interface{}) error {
+	b, err := h.marshaler.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	if err := h.client.Send(b, h.marshaler.ContentType(), messageProperties(applicationID, devEUI, eventType)); err != nil {
+		return fmt.Errorf("handler/azure: send %s event error: %s", eventType, err)
+	}
+
+	log.WithFields(log.Fields{
+		"application_id": applicationID,
+		"event_type":     eventType,
+	}).Info("handler/azure: event sent")
+	return nil
+}
+
+// messageProperties returns the message properties of the given event.
+func messageProperties(applicationID int64, devEUI *lorawan.EUI64, eventType string) map[string]string {
+	properties := map[string]string{
+		applicationIDProperty: strconv.FormatInt(applicationID, 10),
+		eventTypeProperty:     eventType,
+	}
+	if devEUI != nil {
+		properties[devEUIProperty] = devEUI.String()
+	}
+	return properties
+}
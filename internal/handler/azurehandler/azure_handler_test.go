@@ -0,0 +1,58 @@
+package azurehandler
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		serviceBus := secret.String("Endpoint=sb://example.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=secret")
+		iotHub := secret.String("HostName=example.azure-devices.net;DeviceId=device;SharedAccessKey=c2VjcmV0")
+
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"service bus queue", HandlerConfig{ConnectionString: serviceBus, QueueOrTopic: "events"}, nil},
+			{"service bus entity path", HandlerConfig{ConnectionString: serviceBus + ";EntityPath=events"}, nil},
+			{"service bus without queue or topic", HandlerConfig{ConnectionString: serviceBus}, ErrInvalidQueueOrTopic},
+			{"iot hub device", HandlerConfig{ConnectionString: iotHub}, nil},
+			{"iot hub device with queue", HandlerConfig{ConnectionString: iotHub, QueueOrTopic: "events"}, ErrInvalidQueueOrTopic},
+			{"invalid connection string", HandlerConfig{ConnectionString: "events"}, ErrInvalidConnectionString},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+
+		Convey("Then a valid config without secret key returns an error", func() {
+			So(secret.SetKey(""), ShouldBeNil)
+			So(HandlerConfig{ConnectionString: iotHub}.Validate(), ShouldEqual, ErrSecretKeyNotConfigured)
+		})
+	})
+}
+
+func TestMessageProperties(t *testing.T) {
+	Convey("Given an uplink event of a device", t, func() {
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then the properties contain the application ID, DevEUI and event type", func() {
+			So(messageProperties(123, &devEUI, uplinkEvent), ShouldResemble, map[string]string{
+				"applicationID": "123",
+				"devEUI":        "0102030405060708",
+				"eventType":     "rx",
+			})
+		})
+	})
+}
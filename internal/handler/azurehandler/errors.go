@@ -0,0 +1,10 @@
+package azurehandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidConnectionString = errors.New("Connection string must be a valid Service Bus or IoT Hub device connection string")
+	ErrInvalidQueueOrTopic     = errors.New("Queue or topic must be set for Service Bus (unless given by the EntityPath of the connection string) and must be empty for IoT Hub")
+	ErrSecretKeyNotConfigured  = errors.New("Storing credentials requires the integration-secret-key to be configured")
+)
@@ -0,0 +1,113 @@
+// Package chaoshandler implements a handler simulating the failure of the
+// wrapped integration handler (e.g. an outage or a slow endpoint), so that
+// the retry and backfill strategies can be tested.
+package chaoshandler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// ErrSimulatedFailure is returned for the deliveries failed by the failure
+// simulation.
+var ErrSimulatedFailure = errors.New("simulated integration failure")
+
+// sleep is used for delaying the deliveries (replaced in the tests).
+var sleep = time.Sleep
+
+// Handler implements a handler.IntegrationHandler which delays and fails
+// the deliveries to the wrapped handler according to the configured
+// failure simulation.
+type Handler struct {
+	handler handler.IntegrationHandler
+	chaos   storage.IntegrationChaos
+}
+
+// NewHandler creates a new Handler wrapping the given handler.
+func NewHandler(h handler.IntegrationHandler, c storage.IntegrationChaos) *Handler {
+	return &Handler{
+		handler: h,
+		chaos:   c,
+	}
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return h.handler.SendDataUp(pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return h.handler.SendJoinNotification(pl)
+}
+
+// SendACKNotification sends an ack notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return h.handler.SendACKNotification(pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return h.handler.SendErrorNotification(pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return h.handler.SendSecurityNotification(pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return h.handler.SendProprietaryUp(pl)
+}
+
+// SendCustomEvent sends a custom event, when supported by the wrapped
+// handler.
+func (h *Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	ch, ok := h.handler.(handler.CustomEventHandler)
+	if !ok {
+		return nil
+	}
+	if err := h.simulate(); err != nil {
+		return err
+	}
+	return ch.SendCustomEvent(pl)
+}
+
+// Close closes the wrapped handler.
+func (h *Handler) Close() error {
+	return h.handler.Close()
+}
+
+// simulate applies the latency and returns ErrSimulatedFailure when the
+// delivery must fail.
+func (h *Handler) simulate() error {
+	if h.chaos.Latency > 0 {
+		sleep(h.chaos.Latency)
+	}
+	if h.chaos.Fail() {
+		return ErrSimulatedFailure
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package chaoshandler
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+)
+
+func TestHandler(t *testing.T) {
+	Convey("Given a test handler", t, func() {
+		var slept []time.Duration
+		sleep = func(d time.Duration) {
+			slept = append(slept, d)
+		}
+		defer func() {
+			sleep = time.Sleep
+		}()
+
+		th := testhandler.NewTestHandler()
+		until := time.Now().Add(time.Hour)
+
+		Convey("Given a chaos handler with a failure rate of 100% and latency of 5 seconds", func() {
+			h := NewHandler(th, storage.IntegrationChaos{
+				FailureRate: 100,
+				Latency:     5 * time.Second,
+				Until:       &until,
+			})
+
+			Convey("Then SendDataUp is delayed and fails", func() {
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldEqual, ErrSimulatedFailure)
+				So(slept, ShouldResemble, []time.Duration{5 * time.Second})
+				So(th.SendDataUpChan, ShouldHaveLength, 0)
+			})
+
+			Convey("Then SendJoinNotification fails", func() {
+				So(h.SendJoinNotification(handler.JoinNotification{}), ShouldEqual, ErrSimulatedFailure)
+				So(th.SendJoinNotificationChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Given a chaos handler with only a latency of 1 second", func() {
+			h := NewHandler(th, storage.IntegrationChaos{
+				Latency: time.Second,
+				Until:   &until,
+			})
+
+			Convey("Then SendDataUp is delayed and forwarded to the wrapped handler", func() {
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+				So(slept, ShouldResemble, []time.Duration{time.Second})
+				So(th.SendDataUpChan, ShouldHaveLength, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"sort"
+	"sync"
+)
+
+// DeliveryHandler defines the interface of a handler delivering an event to
+// multiple targets (e.g. the integrations of an application), which tracks
+// the delivery per target.
+type DeliveryHandler interface {
+	// WithDelivery returns the handler tracking the delivery of the next
+	// event in the given Delivery.
+	WithDelivery(d *Delivery) Handler
+}
+
+// Delivery tracks the delivery of an event to the targets of a
+// DeliveryHandler, so that a retried event is only sent to the targets
+// which did not receive it yet. A target is identified by an ID which is
+// stable over retries (e.g. the UUID of an integration). The methods of a
+// nil Delivery send the event to all targets, without tracking.
+type Delivery struct {
+	mu             sync.Mutex
+	target         string
+	delivered      map[string]bool
+	deadLettered   map[string]error
+	deadLetterFunc func(target string, err error)
+}
+
+// NewDelivery returns a new Delivery, given the targets which already
+// received the event. When target is set, the event is only sent to this
+// target (e.g. when retrying the event dead-lettered by this target).
+// The given deadLetterFunc is used to dead-letter the event for a target
+// after the delivery has completed (see DeadLetterFunc).
+func NewDelivery(target string, delivered []string, deadLetterFunc func(target string, err error)) *Delivery {
+	d := Delivery{
+		target:         target,
+		delivered:      make(map[string]bool),
+		deadLettered:   make(map[string]error),
+		deadLetterFunc: deadLetterFunc,
+	}
+	for _, t := range delivered {
+		d.delivered[t] = true
+	}
+	return &d
+}
+
+// Pending returns true when the event must be sent to the given target.
+func (d *Delivery) Pending(target string) bool {
+	if d == nil {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.target != "" && d.target != target {
+		return false
+	}
+	return !d.delivered[target] && d.deadLettered[target] == nil
+}
+
+// SetDelivered marks the event as delivered to the given target.
+func (d *Delivery) SetDelivered(target string) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delivered[target] = true
+}
+
+// SetDeadLettered marks the event as dead-lettered by the given target. The
+// event is not sent to this target again, but must be moved to the
+// dead-letter store for this target.
+func (d *Delivery) SetDeadLettered(target string, err error) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deadLettered[target] = err
+}
+
+// Delivered returns the (sorted) targets which received or dead-lettered
+// the event.
+func (d *Delivery) Delivered() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make([]string, 0, len(d.delivered)+len(d.deadLettered))
+	for t := range d.delivered {
+		out = append(out, t)
+	}
+	for t := range d.deadLettered {
+		if !d.delivered[t] {
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// DeadLettered returns the targets which dead-lettered the event, with
+// their error.
+func (d *Delivery) DeadLettered() map[string]error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make(map[string]error, len(d.deadLettered))
+	for t, err := range d.deadLettered {
+		out[t] = err
+	}
+	return out
+}
+
+// DeadLetterFunc returns the function dead-lettering the event for the
+// given target after the delivery has completed, e.g. when a target
+// acknowledged the event but failed to send it later on. It returns nil
+// when the delivery is not tracked.
+func (d *Delivery) DeadLetterFunc(target string) func(err error) {
+	if d == nil || d.deadLetterFunc == nil {
+		return nil
+	}
+	return func(err error) {
+		d.deadLetterFunc(target, err)
+	}
+}
@@ -0,0 +1,200 @@
+// Package elasticsearchhandler implements a handler indexing the events of
+// an application into Elasticsearch, e.g. to search and visualize these in
+// Kibana.
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/egress"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+)
+
+const (
+	uplinkEvent      = "rx"
+	joinEvent        = "join"
+	ackEvent         = "ack"
+	errorEvent       = "error"
+	securityEvent    = "security"
+	proprietaryEvent = "proprietary"
+)
+
+// DefaultIndexPattern defines the index pattern used when IndexPattern is
+// not set (an index per day).
+const DefaultIndexPattern = "lora-events-YYYY.MM.DD"
+
+var indexRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9\-_+.]{0,254}$`)
+
+var httpClient = egress.NewClient(10 * time.Second)
+
+// HandlerConfig contains the configuration for an Elasticsearch handler.
+// The index of each event is based on IndexPattern (default
+// DefaultIndexPattern), of which the YYYY, MM, DD and HH placeholders are
+// replaced by the (UTC) year, month, day and hour of the event. For
+// clusters requiring authentication, Username and Password (stored
+// encrypted, see the secret package) can be set.
+type HandlerConfig struct {
+	Server       string        `json:"server"`
+	IndexPattern string        `json:"indexPattern,omitempty"`
+	Username     string        `json:"username,omitempty"`
+	Password     secret.String `json:"password,omitempty"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	u, err := url.Parse(c.Server)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidServer
+	}
+	if !indexRegexp.MatchString(indexName(c.indexPattern(), time.Now())) {
+		return ErrInvalidIndexPattern
+	}
+	if c.Password != "" && c.Username == "" {
+		return ErrBasicAuthUsernameRequired
+	}
+	if c.Password != "" && !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+	return nil
+}
+
+func (c HandlerConfig) indexPattern() string {
+	if c.IndexPattern == "" {
+		return DefaultIndexPattern
+	}
+	return c.IndexPattern
+}
+
+// Handler implements an Elasticsearch handler.
+type Handler struct {
+	config HandlerConfig
+	server string
+	client *http.Client
+}
+
+// NewHandler creates a new Elasticsearch Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		config: conf,
+		server: strings.TrimRight(conf.Server, "/"),
+		client: httpClient,
+	}, nil
+}
+
+// Close closes the handler.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp indexes a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.index(uplinkEvent, pl)
+}
+
+// SendJoinNotification indexes a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.index(joinEvent, pl)
+}
+
+// SendACKNotification indexes an ack notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.index(ackEvent, pl)
+}
+
+// SendErrorNotification indexes an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.index(errorEvent, pl)
+}
+
+// SendSecurityNotification indexes a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.index(securityEvent, pl)
+}
+
+// SendProprietaryUp indexes a proprietary uplink payload.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.index(proprietaryEvent, pl)
+}
+
+// index indexes the given payload as a document, extended with the
+// @timestamp and eventType fields.
+func (h *Handler) index(eventType string, pl interface{}) error {
+	now := time.Now()
+	doc, err := newDocument(eventType, now, pl)
+	if err != nil {
+		return err
+	}
+
+	index := indexName(h.config.indexPattern(), now)
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/_doc", h.server, index), bytes.NewReader(doc))
+	if err != nil {
+		return errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.config.Username != "" {
+		req.SetBasicAuth(h.config.Username, string(h.config.Password))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("handler/elasticsearch: index %s event error: %s", eventType, errors.Cause(err))
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("handler/elasticsearch: index %s event error: expected 2XX response, got: %d", eventType, resp.StatusCode)
+	}
+
+	log.WithFields(log.Fields{
+		"server":     h.server,
+		"index":      index,
+		"event_type": eventType,
+	}).Info("handler/elasticsearch: event indexed")
+	return nil
+}
+
+// newDocument returns the JSON document for the given event.
+func newDocument(eventType string, t time.Time, pl interface{}) ([]byte, error) {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, errors.Wrap(err, "unmarshal json error")
+	}
+	doc["@timestamp"] = t.UTC().Format(time.RFC3339Nano)
+	doc["eventType"] = eventType
+
+	return json.Marshal(doc)
+}
+
+// indexName returns the name of the index for the given pattern and time.
+func indexName(pattern string, t time.Time) string {
+	t = t.UTC()
+	return strings.NewReplacer(
+		"YYYY", t.Format("2006"),
+		"MM", t.Format("01"),
+		"DD", t.Format("02"),
+		"HH", t.Format("15"),
+	).Replace(pattern)
+}
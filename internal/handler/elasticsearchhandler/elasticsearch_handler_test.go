@@ -0,0 +1,114 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+type testElasticsearchHandler struct {
+	requests chan *http.Request
+}
+
+func (h *testElasticsearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	h.requests <- r
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"valid server", HandlerConfig{Server: "http://localhost:9200"}, nil},
+			{"valid index pattern", HandlerConfig{Server: "http://localhost:9200", IndexPattern: "lora-YYYY.MM"}, nil},
+			{"missing server", HandlerConfig{}, ErrInvalidServer},
+			{"invalid scheme", HandlerConfig{Server: "ftp://localhost:9200"}, ErrInvalidServer},
+			{"upper case index pattern", HandlerConfig{Server: "http://localhost:9200", IndexPattern: "Lora-YYYY"}, ErrInvalidIndexPattern},
+			{"index pattern with slash", HandlerConfig{Server: "http://localhost:9200", IndexPattern: "lora/events"}, ErrInvalidIndexPattern},
+			{"password without username", HandlerConfig{Server: "http://localhost:9200", Password: "secret"}, ErrBasicAuthUsernameRequired},
+			{"password without secret key", HandlerConfig{Server: "http://localhost:9200", Username: "user", Password: "secret"}, ErrSecretKeyNotConfigured},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestIndexName(t *testing.T) {
+	Convey("Given a time", t, func() {
+		ts := time.Date(2017, 11, 2, 9, 30, 0, 0, time.UTC)
+
+		Convey("Then the placeholders of the index pattern are replaced", func() {
+			So(indexName(DefaultIndexPattern, ts), ShouldEqual, "lora-events-2017.11.02")
+			So(indexName("lora-1-YYYY.MM.DD.HH", ts), ShouldEqual, "lora-1-2017.11.02.09")
+		})
+	})
+}
+
+func TestHandler(t *testing.T) {
+	Convey("Given a secret key, a test HTTP server and a Handler instance", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		httpHandler := testElasticsearchHandler{
+			requests: make(chan *http.Request, 100),
+		}
+		server := httptest.NewServer(&httpHandler)
+		defer server.Close()
+
+		h, err := NewHandler(HandlerConfig{
+			Server:       server.URL + "/",
+			IndexPattern: "lora-YYYY",
+			Username:     "user",
+			Password:     "secret",
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When sending an error notification", func() {
+			So(h.SendErrorNotification(handler.ErrorNotification{
+				ApplicationID: 1,
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Type:          "BOOM",
+				Error:         "boom",
+			}), ShouldBeNil)
+
+			Convey("Then the event was indexed", func() {
+				So(httpHandler.requests, ShouldHaveLength, 1)
+				req := <-httpHandler.requests
+				So(req.Method, ShouldEqual, "POST")
+				So(req.URL.Path, ShouldEqual, "/lora-"+time.Now().UTC().Format("2006")+"/_doc")
+
+				username, password, ok := req.BasicAuth()
+				So(ok, ShouldBeTrue)
+				So(username, ShouldEqual, "user")
+				So(password, ShouldEqual, "secret")
+
+				var doc map[string]interface{}
+				So(json.NewDecoder(req.Body).Decode(&doc), ShouldBeNil)
+				So(doc["eventType"], ShouldEqual, "error")
+				So(doc["applicationID"], ShouldEqual, "1")
+				So(doc["devEUI"], ShouldEqual, "0102030405060708")
+				So(doc["error"], ShouldEqual, "boom")
+				So(doc["@timestamp"], ShouldNotBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,11 @@
+package elasticsearchhandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidServer             = errors.New("Server must be a valid http or https URL")
+	ErrInvalidIndexPattern       = errors.New("Index pattern may only contain lower case characters, digits, -, _, + and . next to the YYYY, MM, DD and HH placeholders")
+	ErrBasicAuthUsernameRequired = errors.New("Username is required when a password is set")
+	ErrSecretKeyNotConfigured    = errors.New("Storing credentials requires the integration-secret-key to be configured")
+)
@@ -1,21 +1,79 @@
 package handler
 
+import (
+	"errors"
+	"regexp"
+)
+
 // Handler kinds
 const (
-	HTTPHandlerKind = "HTTP"
+	HTTPHandlerKind          = "HTTP"
+	SyslogHandlerKind        = "SYSLOG"
+	AMQPHandlerKind          = "AMQP"
+	PostgreSQLHandlerKind    = "POSTGRESQL"
+	AWSSNSHandlerKind        = "AWS_SNS"
+	AzureHandlerKind         = "AZURE"
+	GCPPubSubHandlerKind     = "GCP_PUB_SUB"
+	ThingsBoardHandlerKind   = "THINGSBOARD"
+	MyDevicesHandlerKind     = "MY_DEVICES"
+	ElasticsearchHandlerKind = "ELASTICSEARCH"
 )
 
+// ErrDeadLetter is returned (wrapped) by a handler for an event which must
+// not be retried, but moved to the dead-letter store directly (e.g. as the
+// endpoint is temporarily skipped).
+var ErrDeadLetter = errors.New("event dead-lettered by handler")
+
+// ErrDropped is returned (wrapped) by a handler for an event which it
+// dropped (e.g. as the endpoint is temporarily skipped). The event is not
+// retried, but the delivery is accounted as failed.
+var ErrDropped = errors.New("event dropped by handler")
+
+// ErrInvalidCustomEventName is returned when the name of a custom event is
+// invalid.
+var ErrInvalidCustomEventName = errors.New("Custom event name must consist of 1 - 64 letters, digits, - or _")
+
+var customEventNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ValidateCustomEventName validates the name of a custom event. As the name
+// is used in MQTT topics, NATS subjects, routing-keys and URLs, it is
+// restricted to letters, digits, - and _.
+func ValidateCustomEventName(name string) error {
+	if !customEventNameRegexp.MatchString(name) {
+		return ErrInvalidCustomEventName
+	}
+	return nil
+}
+
 // Handler defines the interface of a handler backend.
 type Handler interface {
 	IntegrationHandler
+	GatewayNotificationHandler
+	CustomEventHandler
 	DataDownChan() chan DataDownPayload // returns DataDownPayload channel
 }
 
+// CustomEventHandler defines the interface of a handler supporting custom
+// events. The application integrations not implementing this interface do
+// not receive the custom events.
+type CustomEventHandler interface {
+	SendCustomEvent(payload CustomEvent) error // send custom event
+}
+
+// GatewayNotificationHandler defines the interface of a handler supporting
+// gateway notifications. As gateways are not part of an application, these
+// are not sent to the application integrations.
+type GatewayNotificationHandler interface {
+	SendGatewayNotification(payload GatewayNotification) error // send gateway notification
+}
+
 // IntegrationHandler defines the interface of an integration handler.
 type IntegrationHandler interface {
-	SendDataUp(payload DataUpPayload) error                // send data-up payload
-	SendJoinNotification(payload JoinNotification) error   // send join notification
-	SendACKNotification(payload ACKNotification) error     // send ack notification
-	SendErrorNotification(payload ErrorNotification) error // send error notification
-	Close() error                                          // closes the handler
+	SendDataUp(payload DataUpPayload) error                      // send data-up payload
+	SendJoinNotification(payload JoinNotification) error         // send join notification
+	SendACKNotification(payload ACKNotification) error           // send ack notification
+	SendErrorNotification(payload ErrorNotification) error       // send error notification
+	SendSecurityNotification(payload SecurityNotification) error // send security notification
+	SendProprietaryUp(payload ProprietaryUpPayload) error        // send proprietary uplink payload
+	Close() error                                                // closes the handler
 }
@@ -0,0 +1,220 @@
+package httphandler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+)
+
+const (
+	// DefaultBatchInterval defines the max. time an uplink is buffered when
+	// BatchInterval is not set.
+	DefaultBatchInterval = 10 * time.Second
+
+	// maxBatchSize defines the max. configurable batch size.
+	maxBatchSize = 1000
+
+	// maxBatchInterval defines the max. configurable batch interval (in
+	// seconds).
+	maxBatchInterval = 300
+)
+
+// batch contains the buffered (encoded) uplinks of a handler configuration.
+type batch struct {
+	handler *Handler
+	url     string
+	items   []batchItem
+	size    int
+	timer   *time.Timer
+}
+
+// batchItem contains a buffered (encoded) uplink and the function handling
+// its failed delivery (see Handler.SetBatchFailureFunc).
+type batchItem struct {
+	event     []byte
+	onFailure func(err error)
+}
+
+// body returns the buffered uplinks as JSON array.
+func (b *batch) body() []byte {
+	events := make([][]byte, len(b.items))
+	for i := range b.items {
+		events[i] = b.items[i].event
+	}
+	return append(append([]byte{'['}, bytes.Join(events, []byte{','})...), ']')
+}
+
+// batches contains the pending batches per handler configuration, as the
+// handlers are created per event.
+var (
+	batchesMu sync.Mutex
+	batches   = make(map[[sha256.Size]byte]*batch)
+)
+
+// configKey returns the key identifying the given configuration, so that a
+// changed configuration starts a new batch (or circuit breaker). The secrets
+// are hashed in plaintext, as these are encrypted with a random nonce when
+// marshaled to JSON.
+func configKey(conf HandlerConfig) ([sha256.Size]byte, error) {
+	secrets := []secret.String{conf.BasicAuthPassword, conf.BearerToken, conf.SigningSecret, conf.TLSKey}
+	conf.BasicAuthPassword, conf.BearerToken, conf.SigningSecret, conf.TLSKey = "", "", "", ""
+
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	h := sha256.New()
+	h.Write(b)
+	for _, s := range secrets {
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
+
+// addToBatch buffers the given (encoded) uplink. When the batch is full,
+// it is sent by the calling goroutine (returning the delivery error), else
+// it is sent when the batch interval expires. When adding the uplink would
+// make the batch exceed the max. payload size, the pending uplinks are sent
+// first. The failure of the uplinks buffered before (which have already been
+// acknowledged) is handled by their failure function (see fail).
+func (h *Handler) addToBatch(url string, event []byte) error {
+	key, err := configKey(h.config)
+	if err != nil {
+		return err
+	}
+
+	var flush []*batch
+
+	batchesMu.Lock()
+	b := batches[key]
+	if b != nil && h.config.MaxPayloadSize != 0 && b.size+len(event)+1 > h.config.MaxPayloadSize {
+		b.timer.Stop()
+		delete(batches, key)
+		flush = append(flush, b)
+		b = nil
+	}
+	if b == nil {
+		b = newBatch(key, h, url)
+		batches[key] = b
+	}
+	b.items = append(b.items, batchItem{event: event, onFailure: h.batchFailureFunc})
+	b.size += len(event) + 1
+	if len(b.items) >= h.config.BatchSize {
+		b.timer.Stop()
+		delete(batches, key)
+		flush = append(flush, b)
+	}
+	batchesMu.Unlock()
+
+	var sendErr error
+	for _, fb := range flush {
+		err := fb.send()
+		if err == nil {
+			continue
+		}
+
+		// the last uplink of the current batch is the given uplink, of
+		// which the failure is returned
+		if fb == b {
+			fb.fail(fb.items[:len(fb.items)-1], err)
+			sendErr = err
+		} else {
+			fb.fail(fb.items, err)
+		}
+	}
+	return sendErr
+}
+
+// newBatch returns a new batch, which is sent when the batch interval
+// expires (unless flushed before).
+func newBatch(key [sha256.Size]byte, h *Handler, url string) *batch {
+	b := batch{
+		handler: h,
+		url:     url,
+		size:    1, // the brackets, minus the separator of the first uplink
+	}
+	b.timer = time.AfterFunc(h.config.batchInterval(), func() {
+		if !flushBatch(key, &b) {
+			return
+		}
+		if err := b.send(); err != nil {
+			b.fail(b.items, err)
+		}
+	})
+	return &b
+}
+
+// flushBatch removes the given batch from the pending batches. It returns
+// false when the batch has already been removed (e.g. as it was full).
+func flushBatch(key [sha256.Size]byte, b *batch) bool {
+	batchesMu.Lock()
+	defer batchesMu.Unlock()
+
+	if batches[key] != b {
+		return false
+	}
+	delete(batches, key)
+	return true
+}
+
+// send sends the batch.
+func (b *batch) send() error {
+	log.WithFields(log.Fields{
+		"url":    b.url,
+		"events": len(b.items),
+	}).Info("handler/http: publishing data-up batch")
+	return b.handler.deliver(b.url, b.body())
+}
+
+// fail handles the failed delivery of the given (acknowledged) uplinks of
+// the batch, by calling their failure function (e.g. dead-lettering the
+// uplink). Uplinks without failure function are lost, as are the uplinks
+// dropped by the circuit breaker (handler.ErrDropped).
+func (b *batch) fail(items []batchItem, err error) {
+	var lost int
+	for _, item := range items {
+		if item.onFailure == nil || errors.Cause(err) == handler.ErrDropped {
+			lost++
+			continue
+		}
+		item.onFailure(err)
+	}
+
+	log.WithFields(log.Fields{
+		"url":    b.url,
+		"events": len(items),
+		"lost":   lost,
+	}).Errorf("handler/http: send batch error: %s", err)
+}
+
+// FlushBatches sends the pending batches of all the HTTP handlers, e.g. on
+// shutdown.
+func FlushBatches() {
+	batchesMu.Lock()
+	var pending []*batch
+	for key, b := range batches {
+		b.timer.Stop()
+		delete(batches, key)
+		pending = append(pending, b)
+	}
+	batchesMu.Unlock()
+
+	for _, b := range pending {
+		if err := b.send(); err != nil {
+			b.fail(b.items, err)
+		}
+	}
+}
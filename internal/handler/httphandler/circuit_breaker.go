@@ -0,0 +1,148 @@
+package httphandler
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCircuitBreakerCooldown defines the time an endpoint is skipped
+	// when CircuitBreakerCooldown is not set.
+	DefaultCircuitBreakerCooldown = time.Minute
+
+	// maxCircuitBreakerThreshold defines the max. configurable number of
+	// consecutive failures.
+	maxCircuitBreakerThreshold = 100
+
+	// maxCircuitBreakerCooldown defines the max. configurable cooldown (in
+	// seconds).
+	maxCircuitBreakerCooldown = 3600
+
+	// circuitBreakerIdleTimeout defines after which time without deliveries
+	// a circuit breaker is removed (e.g. of a changed or deleted
+	// integration). It exceeds the max. cooldown, so that an open circuit
+	// is not closed by its removal.
+	circuitBreakerIdleTimeout = 2 * maxCircuitBreakerCooldown * time.Second
+
+	// circuitBreakerCleanupInterval defines the interval in which the idle
+	// circuit breakers are removed.
+	circuitBreakerCleanupInterval = time.Minute
+)
+
+// circuitBreaker tracks the consecutive failed requests of an endpoint.
+// After threshold consecutive failures the circuit opens and the endpoint
+// is skipped until the cooldown has expired. Then a single (trial) request
+// is allowed, which closes the circuit on success or re-opens it on
+// failure.
+type circuitBreaker struct {
+	sync.Mutex
+	failures int
+	openedAt time.Time
+	open     bool
+	trial    bool
+
+	// lastUsed is protected by circuitBreakersMu.
+	lastUsed time.Time
+}
+
+// circuitBreakerKey identifies the circuit breaker of an endpoint URL of a
+// handler configuration.
+type circuitBreakerKey struct {
+	url  string
+	conf [sha256.Size]byte
+}
+
+// circuitBreakers contains the circuit breakers per endpoint URL and
+// handler configuration, as the handlers are created per event.
+var (
+	circuitBreakersMu          sync.Mutex
+	circuitBreakers            = make(map[circuitBreakerKey]*circuitBreaker)
+	circuitBreakersLastCleanup time.Time
+)
+
+// getCircuitBreaker returns the circuit breaker of the given endpoint URL
+// of the given handler configuration. The breaker is keyed by the
+// configuration too (see configKey), so that integrations of other
+// applications (e.g. with other credentials) posting to the same URL do
+// not share the circuit breaker, and a changed configuration starts with a
+// closed circuit.
+func getCircuitBreaker(url string, conf HandlerConfig) (*circuitBreaker, error) {
+	confKey, err := configKey(conf)
+	if err != nil {
+		return nil, err
+	}
+	key := circuitBreakerKey{url: url, conf: confKey}
+	now := time.Now()
+
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+
+	if now.Sub(circuitBreakersLastCleanup) >= circuitBreakerCleanupInterval {
+		for k, cb := range circuitBreakers {
+			if now.Sub(cb.lastUsed) >= circuitBreakerIdleTimeout {
+				delete(circuitBreakers, k)
+			}
+		}
+		circuitBreakersLastCleanup = now
+	}
+
+	cb, ok := circuitBreakers[key]
+	if !ok {
+		cb = &circuitBreaker{}
+		circuitBreakers[key] = cb
+	}
+	cb.lastUsed = now
+	return cb, nil
+}
+
+// allow returns true when a delivery to the endpoint is allowed. When the
+// cooldown of an open circuit has expired, a single trial delivery is
+// allowed. It must be called once per delivery (not per attempt), so that
+// the trial delivery is retried like any other delivery.
+func (cb *circuitBreaker) allow(cooldown time.Duration) bool {
+	cb.Lock()
+	defer cb.Unlock()
+
+	if !cb.open {
+		return true
+	}
+	if cb.trial || time.Since(cb.openedAt) < cooldown {
+		return false
+	}
+	cb.trial = true
+	return true
+}
+
+// success records a successful request, closing the circuit.
+func (cb *circuitBreaker) success(url string) {
+	cb.Lock()
+	defer cb.Unlock()
+
+	if cb.open {
+		log.WithField("url", url).Info("handler/http: endpoint recovered, closing circuit breaker")
+	}
+	cb.failures = 0
+	cb.open = false
+	cb.trial = false
+}
+
+// failure records a failed request. The circuit opens when the threshold
+// has been reached, or re-opens when the trial request failed.
+func (cb *circuitBreaker) failure(url string, threshold int) {
+	cb.Lock()
+	defer cb.Unlock()
+
+	cb.failures++
+	if cb.trial || (!cb.open && cb.failures >= threshold) {
+		log.WithFields(log.Fields{
+			"url":      url,
+			"failures": cb.failures,
+		}).Warning("handler/http: endpoint failing, opening circuit breaker")
+		cb.open = true
+		cb.openedAt = time.Now()
+		cb.trial = false
+	}
+}
@@ -4,5 +4,21 @@ import "errors"
 
 // errors
 var (
-	ErrInvalidHeaderName = errors.New("Invalid header name")
+	ErrInvalidHeaderName         = errors.New("Invalid header name")
+	ErrInvalidDevicePercentage   = errors.New("Device percentage must be between 0 and 100")
+	ErrBasicAuthUsernameRequired = errors.New("Basic auth username is required when a password is set")
+	ErrMultipleAuthMethods       = errors.New("Only one of basic auth, bearer token or an Authorization header can be set")
+	ErrSecretKeyNotConfigured    = errors.New("Storing credentials requires the integration-secret-key to be configured")
+	ErrInvalidMaxAttempts        = errors.New("Max attempts must be between 0 and 10")
+	ErrTLSCertKeyRequired        = errors.New("Both the TLS certificate and key must be set")
+	ErrInvalidTLSCert            = errors.New("Invalid TLS certificate or key")
+	ErrInvalidCACert             = errors.New("Invalid CA certificate")
+	ErrInvalidMaxPayloadSize     = errors.New("Max payload size must be 0 (no limit) or at least 512 bytes")
+	ErrPayloadTooLarge           = errors.New("Payload exceeds the max payload size")
+	ErrInvalidBatchSize          = errors.New("Batch size must be between 0 and 1000")
+	ErrInvalidBatchInterval      = errors.New("Batch interval must be between 0 and 300 seconds")
+	ErrBatchingRequiresJSON      = errors.New("Batching requires the JSON or JSON_HEX marshaler")
+	ErrInvalidCircuitBreaker     = errors.New("Circuit breaker threshold must be between 0 and 100 and cooldown between 0 and 3600 seconds")
+	ErrCircuitBreakerMode        = errors.New("Only one of dead-lettering or dropping the skipped events can be set")
+	ErrCircuitOpen               = errors.New("Circuit breaker open")
 )
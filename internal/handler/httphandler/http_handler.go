@@ -3,26 +3,145 @@ package httphandler
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/lora-app-server/internal/egress"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lora-app-server/internal/webhooksign"
+	"github.com/brocaar/lorawan"
 )
 
+// HMACHeader defines the HTTP header containing the HMAC-SHA256 signature
+// of the request body (when a SigningSecret is configured).
+const HMACHeader = "X-LoRa-HMAC-SHA256"
+
 var headerNameValidator = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
 
+var httpClient = egress.NewClient(0)
+
+// tlsClients contains the http clients per TLS configuration (client
+// certificate, key and CA certificate), so that the connections are re-used
+// across the events.
+var (
+	tlsClientsMu sync.Mutex
+	tlsClients   = make(map[[sha256.Size]byte]*http.Client)
+)
+
+const (
+	// DefaultMaxAttempts defines the number of delivery attempts of an event
+	// when MaxAttempts is not set.
+	DefaultMaxAttempts = 3
+
+	// maxMaxAttempts defines the max. configurable number of attempts.
+	maxMaxAttempts = 10
+)
+
+// RetryBackoff defines the backoff before the first retry of a failed
+// delivery, the backoff is doubled on each next retry (up to
+// MaxRetryBackoff).
+var RetryBackoff = time.Second
+
+// MaxRetryBackoff defines the max. backoff between two retries.
+var MaxRetryBackoff = 30 * time.Second
+
+// minMaxPayloadSize defines the min. configurable max. payload size (in
+// bytes).
+const minMaxPayloadSize = 512
+
+// PayloadLimitStats contains the number of payloads exceeding the
+// MaxPayloadSize of a handler, by the action taken to reduce their size.
+type PayloadLimitStats struct {
+	RXInfoDropped int64 `json:"rxInfoDropped"`
+	DataTruncated int64 `json:"dataTruncated"`
+	Dropped       int64 `json:"dropped"`
+}
+
+var payloadLimitStats PayloadLimitStats
+
+// GetPayloadLimitStats returns the payload limit statistics (since startup)
+// of all the HTTP handlers.
+func GetPayloadLimitStats() PayloadLimitStats {
+	return PayloadLimitStats{
+		RXInfoDropped: atomic.LoadInt64(&payloadLimitStats.RXInfoDropped),
+		DataTruncated: atomic.LoadInt64(&payloadLimitStats.DataTruncated),
+		Dropped:       atomic.LoadInt64(&payloadLimitStats.Dropped),
+	}
+}
+
 // HandlerConfig contains the configuration for a HTTP handler.
+// With DevicePercentage and / or DevEUIs the handler can be restricted to a
+// subset of the devices of the application (e.g. to validate a new endpoint
+// before the full cutover). When both are empty, all devices are included.
+// For endpoints requiring authentication, either BasicAuthUsername (and
+// BasicAuthPassword) or BearerToken can be set. When SigningSecret is set,
+// each request body is signed using HMAC-SHA256 (see HMACHeader). For
+// endpoints requiring mutual TLS, TLSCert and TLSKey (PEM encoded) can be
+// set, CACert (PEM encoded) replaces the system CA certificates for
+// verifying the endpoint certificate. The secrets are stored encrypted
+// (see the secret package). Failed deliveries (transport errors
+// or non-2XX responses) are retried with an exponential backoff, until
+// MaxAttempts (default DefaultMaxAttempts) has been reached. When
+// MaxPayloadSize is set, payloads exceeding this size (in bytes) are reduced
+// before sending, by first dropping the rxInfo and then truncating the data
+// (setting the truncated flag, JSON marshaler only). Payloads which can not
+// be reduced are dropped. Marshaler defines the encoding of the payloads
+// (see the marshaler package). When BatchSize is set (JSON marshalers only),
+// the data-up payloads are buffered and sent as a JSON array every BatchSize
+// uplinks or BatchInterval seconds (default DefaultBatchInterval), whichever
+// comes first. Custom events are posted to CustomEventURL, in which {name}
+// is replaced by the name of the event. When CircuitBreakerThreshold is set,
+// an endpoint is skipped for CircuitBreakerCooldown seconds (default
+// DefaultCircuitBreakerCooldown) after this number of consecutive failed
+// requests. The events for a skipped endpoint fail with ErrCircuitOpen (so
+// that these are retried), are dead-lettered when CircuitBreakerDeadLetter
+// is set or are dropped when CircuitBreakerDrop is set.
 type HandlerConfig struct {
-	Headers              map[string]string `json:"headers"`
-	DataUpURL            string            `json:"dataUpURL"`
-	JoinNotificationURL  string            `json:"joinNotificationURL"`
-	ACKNotificationURL   string            `json:"ackNotificationURL"`
-	ErrorNotificationURL string            `json:"errorNotificationURL"`
+	Headers                  map[string]string `json:"headers"`
+	DataUpURL                string            `json:"dataUpURL"`
+	JoinNotificationURL      string            `json:"joinNotificationURL"`
+	ACKNotificationURL       string            `json:"ackNotificationURL"`
+	ErrorNotificationURL     string            `json:"errorNotificationURL"`
+	SecurityNotificationURL  string            `json:"securityNotificationURL,omitempty"`
+	ProprietaryUpURL         string            `json:"proprietaryUpURL,omitempty"`
+	CustomEventURL           string            `json:"customEventURL,omitempty"`
+	DevicePercentage         int               `json:"devicePercentage,omitempty"`
+	DevEUIs                  []lorawan.EUI64   `json:"devEUIs,omitempty"`
+	BasicAuthUsername        string            `json:"basicAuthUsername,omitempty"`
+	BasicAuthPassword        secret.String     `json:"basicAuthPassword,omitempty"`
+	BearerToken              secret.String     `json:"bearerToken,omitempty"`
+	MaxAttempts              int               `json:"maxAttempts,omitempty"`
+	SigningSecret            secret.String     `json:"signingSecret,omitempty"`
+	TLSCert                  string            `json:"tlsCert,omitempty"`
+	TLSKey                   secret.String     `json:"tlsKey,omitempty"`
+	CACert                   string            `json:"caCert,omitempty"`
+	MaxPayloadSize           int               `json:"maxPayloadSize,omitempty"`
+	Marshaler                string            `json:"marshaler,omitempty"`
+	BatchSize                int               `json:"batchSize,omitempty"`
+	BatchInterval            int               `json:"batchInterval,omitempty"`
+	CircuitBreakerThreshold  int               `json:"circuitBreakerThreshold,omitempty"`
+	CircuitBreakerCooldown   int               `json:"circuitBreakerCooldown,omitempty"`
+	CircuitBreakerDeadLetter bool              `json:"circuitBreakerDeadLetter,omitempty"`
+	CircuitBreakerDrop       bool              `json:"circuitBreakerDrop,omitempty"`
 }
 
 // Validate validates the HandlerConfig data.
@@ -31,43 +150,426 @@ func (c HandlerConfig) Validate() error {
 		if !headerNameValidator.MatchString(k) {
 			return ErrInvalidHeaderName
 		}
+		if http.CanonicalHeaderKey(k) == "Authorization" && (c.BasicAuthUsername != "" || c.BearerToken != "") {
+			return ErrMultipleAuthMethods
+		}
+	}
+	if c.DevicePercentage < 0 || c.DevicePercentage > 100 {
+		return ErrInvalidDevicePercentage
+	}
+	if c.MaxAttempts < 0 || c.MaxAttempts > maxMaxAttempts {
+		return ErrInvalidMaxAttempts
+	}
+	if c.MaxPayloadSize != 0 && c.MaxPayloadSize < minMaxPayloadSize {
+		return ErrInvalidMaxPayloadSize
+	}
+	if c.BasicAuthPassword != "" && c.BasicAuthUsername == "" {
+		return ErrBasicAuthUsernameRequired
+	}
+	if c.BasicAuthUsername != "" && c.BearerToken != "" {
+		return ErrMultipleAuthMethods
+	}
+	if (c.TLSCert != "") != (c.TLSKey != "") {
+		return ErrTLSCertKeyRequired
+	}
+	if (c.BasicAuthPassword != "" || c.BearerToken != "" || c.SigningSecret != "" || c.TLSKey != "") && !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+	if _, err := c.tlsConfig(); err != nil {
+		return err
+	}
+	if err := marshaler.Validate(c.Marshaler); err != nil {
+		return err
+	}
+	if c.BatchSize < 0 || c.BatchSize > maxBatchSize {
+		return ErrInvalidBatchSize
+	}
+	if c.BatchInterval < 0 || c.BatchInterval > maxBatchInterval {
+		return ErrInvalidBatchInterval
+	}
+	if c.BatchSize > 1 && c.Marshaler == marshaler.Protobuf {
+		return ErrBatchingRequiresJSON
+	}
+	if c.CircuitBreakerThreshold < 0 || c.CircuitBreakerThreshold > maxCircuitBreakerThreshold || c.CircuitBreakerCooldown < 0 || c.CircuitBreakerCooldown > maxCircuitBreakerCooldown {
+		return ErrInvalidCircuitBreaker
+	}
+	if c.CircuitBreakerDeadLetter && c.CircuitBreakerDrop {
+		return ErrCircuitBreakerMode
 	}
 	return nil
 }
 
+// circuitBreakerCooldown returns the time an endpoint is skipped after the
+// circuit breaker opened.
+func (c HandlerConfig) circuitBreakerCooldown() time.Duration {
+	if c.CircuitBreakerCooldown == 0 {
+		return DefaultCircuitBreakerCooldown
+	}
+	return time.Duration(c.CircuitBreakerCooldown) * time.Second
+}
+
+// batchInterval returns the max. time an uplink is buffered.
+func (c HandlerConfig) batchInterval() time.Duration {
+	if c.BatchInterval == 0 {
+		return DefaultBatchInterval
+	}
+	return time.Duration(c.BatchInterval) * time.Second
+}
+
+// tlsConfig returns the TLS configuration for the client certificate and
+// CA certificate, or nil when these are not set.
+func (c HandlerConfig) tlsConfig() (*tls.Config, error) {
+	if c.TLSCert == "" && c.CACert == "" {
+		return nil, nil
+	}
+
+	var conf tls.Config
+	if c.TLSCert != "" {
+		cert, err := tls.X509KeyPair([]byte(c.TLSCert), []byte(c.TLSKey))
+		if err != nil {
+			return nil, ErrInvalidTLSCert
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	if c.CACert != "" {
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, ErrInvalidCACert
+		}
+	}
+	return &conf, nil
+}
+
+// IncludesDevEUI returns true when events of the given device must be sent
+// to this handler. A device is included when it is in the DevEUIs list or
+// when it falls within the DevicePercentage. The latter is based on a hash
+// of the DevEUI, so that the same devices are selected every time.
+func (c HandlerConfig) IncludesDevEUI(devEUI lorawan.EUI64) bool {
+	if len(c.DevEUIs) == 0 && (c.DevicePercentage == 0 || c.DevicePercentage == 100) {
+		return true
+	}
+
+	for _, d := range c.DevEUIs {
+		if d == devEUI {
+			return true
+		}
+	}
+
+	h := fnv.New32a()
+	h.Write(devEUI[:])
+	return int(h.Sum32()%100) < c.DevicePercentage
+}
+
 // Handler implements a HTTP handler for sending and notifying a HTTP
 // endpoint.
 type Handler struct {
-	config HandlerConfig
+	config           HandlerConfig
+	client           *http.Client
+	marshaler        marshaler.Marshaler
+	batchFailureFunc func(err error)
 }
 
 // NewHandler creates a new HTTPHandler.
 func NewHandler(conf HandlerConfig) (*Handler, error) {
+	client, err := getClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := marshaler.New(conf.Marshaler)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Handler{
-		config: conf,
+		config:    conf,
+		client:    client,
+		marshaler: m,
 	}, nil
 }
 
+// SetBatchFailureFunc sets the function which is called with the delivery
+// error when the batch containing the data-up payloads sent by this handler
+// could not be delivered (see BatchSize). As these payloads were already
+// acknowledged when buffered, the function must take care of them (e.g.
+// dead-letter the event). Without function, these payloads are lost.
+func (h *Handler) SetBatchFailureFunc(f func(err error)) {
+	h.batchFailureFunc = f
+}
+
+// getClient returns the http client for the given configuration. For
+// configurations with a client certificate or CA certificate, a client
+// with its own transport is returned (shared with the handlers using the
+// same certificates).
+func getClient(conf HandlerConfig) (*http.Client, error) {
+	tlsConfig, err := conf.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return httpClient, nil
+	}
+
+	h := sha256.New()
+	for _, s := range []string{conf.TLSCert, string(conf.TLSKey), conf.CACert} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+
+	tlsClientsMu.Lock()
+	defer tlsClientsMu.Unlock()
+
+	c, ok := tlsClients[key]
+	if !ok {
+		c = egress.NewTLSClient(0, tlsConfig)
+		tlsClients[key] = c
+	}
+	return c, nil
+}
+
 func (h *Handler) send(url string, payload interface{}) error {
+	b, err := h.encode(url, payload)
+	if err != nil || b == nil {
+		return err
+	}
+	return h.deliver(url, b)
+}
+
+// encode returns the encoded payload, reduced to the max. payload size when
+// set. When the payload can not be reduced, nil is returned.
+func (h *Handler) encode(url string, payload interface{}) ([]byte, error) {
+	b, err := h.marshaler.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal payload error")
+	}
+
+	if h.config.MaxPayloadSize != 0 && len(b) > h.config.MaxPayloadSize {
+		log.WithFields(log.Fields{
+			"url":              url,
+			"size":             len(b),
+			"max_payload_size": h.config.MaxPayloadSize,
+		}).Warning("handler/http: payload exceeds max payload size, reducing payload")
+		b, err = limitPayload(h.marshaler, payload, h.config.MaxPayloadSize)
+		if err == ErrPayloadTooLarge {
+			// retrying will not make the payload any smaller
+			atomic.AddInt64(&payloadLimitStats.Dropped, 1)
+			log.WithField("url", url).Error("handler/http: payload can not be reduced to the max payload size, dropping payload")
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// deliver signs and posts the given body, retrying failed deliveries. When
+// the circuit breaker of the endpoint is open, the body is not posted (see
+// skip).
+func (h *Handler) deliver(url string, b []byte) error {
+	sig, err := webhooksign.Sign(b)
+	if err != nil {
+		return errors.Wrap(err, "sign payload error")
+	}
+
+	var mac string
+	if h.config.SigningSecret != "" {
+		mac = signHMAC([]byte(h.config.SigningSecret), b)
+	}
+
+	attempts := h.config.MaxAttempts
+	if attempts == 0 {
+		attempts = DefaultMaxAttempts
+	}
+
+	var cb *circuitBreaker
+	if h.config.CircuitBreakerThreshold != 0 {
+		if cb, err = getCircuitBreaker(url, h.config); err != nil {
+			return errors.Wrap(err, "get circuit breaker error")
+		}
+		if !cb.allow(h.config.circuitBreakerCooldown()) {
+			return h.skip(url)
+		}
+	}
+
+	backoff := RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err = h.post(url, b, sig, mac)
+		if cb != nil {
+			if err == nil {
+				cb.success(url)
+			} else {
+				cb.failure(url, h.config.CircuitBreakerThreshold)
+			}
+		}
+		if err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return errors.Wrapf(err, "delivery failed after %d attempt(s)", attempt)
+		}
+
+		log.WithFields(log.Fields{
+			"url":     url,
+			"attempt": attempt,
+			"backoff": backoff,
+		}).Warningf("handler/http: delivery error, will retry: %s", err)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > MaxRetryBackoff {
+			backoff = MaxRetryBackoff
+		}
+	}
+}
+
+// skip handles an event for an endpoint of which the circuit breaker is
+// open. By default the event fails (and is retried later on), it is
+// dead-lettered when CircuitBreakerDeadLetter is set and dropped when
+// CircuitBreakerDrop is set.
+func (h *Handler) skip(url string) error {
+	if h.config.CircuitBreakerDeadLetter {
+		return errors.Wrapf(handler.ErrDeadLetter, "circuit breaker of %s open", url)
+	}
+	if h.config.CircuitBreakerDrop {
+		return errors.Wrapf(handler.ErrDropped, "circuit breaker of %s open", url)
+	}
+	return errors.Wrapf(ErrCircuitOpen, "circuit breaker of %s open", url)
+}
+
+// truncatedDataUpPayload is a data-up payload of which the data has been
+// truncated.
+type truncatedDataUpPayload struct {
+	handler.DataUpPayload
+	Truncated bool `json:"truncated"`
+}
+
+// truncatedProprietaryUpPayload is a proprietary uplink payload of which
+// the MAC payload has been truncated.
+type truncatedProprietaryUpPayload struct {
+	handler.ProprietaryUpPayload
+	Truncated bool `json:"truncated"`
+}
+
+// limitPayload returns the payload encoded by the given marshaler, reduced
+// to the given max. size. First the rxInfo is dropped, when the payload is
+// still too large, the data is truncated (JSON marshaler only).
+// ErrPayloadTooLarge is returned when the payload can't be reduced to the
+// max. size.
+func limitPayload(m marshaler.Marshaler, payload interface{}, maxSize int) ([]byte, error) {
+	// the truncated flag and length are specific to the (base64) JSON
+	// encoding
+	_, truncate := m.(marshaler.JSONMarshaler)
+
+	switch pl := payload.(type) {
+	case handler.DataUpPayload:
+		pl.RXInfo = []handler.RXInfo{}
+		b, err := m.Marshal(pl)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal payload error")
+		}
+		if len(b) <= maxSize {
+			atomic.AddInt64(&payloadLimitStats.RXInfoDropped, 1)
+			return b, nil
+		}
+		if !truncate {
+			return nil, ErrPayloadTooLarge
+		}
+
+		truncated := truncatedDataUpPayload{DataUpPayload: pl, Truncated: true}
+		truncated.Data = []byte{}
+		n, err := truncatedDataLength(truncated, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		if n < len(pl.Data) {
+			truncated.Data = pl.Data[:n]
+		} else {
+			truncated.Data = pl.Data
+		}
+		atomic.AddInt64(&payloadLimitStats.DataTruncated, 1)
+		return json.Marshal(truncated)
+	case handler.ProprietaryUpPayload:
+		pl.RXInfo = nil
+		b, err := m.Marshal(pl)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal payload error")
+		}
+		if len(b) <= maxSize {
+			atomic.AddInt64(&payloadLimitStats.RXInfoDropped, 1)
+			return b, nil
+		}
+		if !truncate {
+			return nil, ErrPayloadTooLarge
+		}
+
+		truncated := truncatedProprietaryUpPayload{ProprietaryUpPayload: pl, Truncated: true}
+		truncated.MACPayload = []byte{}
+		n, err := truncatedDataLength(truncated, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		if n < len(pl.MACPayload) {
+			truncated.MACPayload = pl.MACPayload[:n]
+		} else {
+			truncated.MACPayload = pl.MACPayload
+		}
+		atomic.AddInt64(&payloadLimitStats.DataTruncated, 1)
+		return json.Marshal(truncated)
+	default:
+		return nil, ErrPayloadTooLarge
+	}
+}
+
+// truncatedDataLength returns the max. number of data bytes that can be
+// added to the given payload (of which the data is empty), without exceeding
+// the max. size when JSON (base64) encoded.
+func truncatedDataLength(payload interface{}, maxSize int) (int, error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
-		return errors.Wrap(err, "marshal json error")
+		return 0, errors.Wrap(err, "marshal json error")
 	}
+	if len(b) > maxSize {
+		return 0, ErrPayloadTooLarge
+	}
+
+	// each 3 bytes of data are encoded as 4 base64 characters
+	return (maxSize - len(b)) / 4 * 3, nil
+}
 
+// post makes a single POST request with the given body and signatures.
+func (h *Handler) post(url string, b []byte, sig, mac string) error {
 	req, err := http.NewRequest("POST", url, bytes.NewReader(b))
 	if err != nil {
 		return errors.Wrap(err, "new request error")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", h.marshaler.ContentType())
 	for k, v := range h.config.Headers {
 		req.Header.Set(k, v)
 	}
+	if h.config.BasicAuthUsername != "" {
+		req.SetBasicAuth(h.config.BasicAuthUsername, string(h.config.BasicAuthPassword))
+	}
+	if h.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+string(h.config.BearerToken))
+	}
+	if sig != "" {
+		req.Header.Set(webhooksign.Header, sig)
+	}
+	if mac != "" {
+		req.Header.Set(HMACHeader, mac)
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := h.client.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "http request error")
 	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
 
 	// check that response is in 200 range
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -77,6 +579,13 @@ func (h *Handler) send(url string, payload interface{}) error {
 	return nil
 }
 
+// signHMAC returns the hex encoded HMAC-SHA256 of the given body.
+func signHMAC(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // Close closes the handler.
 func (h *Handler) Close() error {
 	return nil
@@ -92,6 +601,14 @@ func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
 		"url":     h.config.DataUpURL,
 		"dev_eui": pl.DevEUI,
 	}).Info("handler/http: publishing data-up payload")
+
+	if h.config.BatchSize > 1 {
+		b, err := h.encode(h.config.DataUpURL, pl)
+		if err != nil || b == nil {
+			return err
+		}
+		return h.addToBatch(h.config.DataUpURL, b)
+	}
 	return h.send(h.config.DataUpURL, pl)
 }
 
@@ -133,3 +650,44 @@ func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
 	}).Info("handler/http: publishing error notification")
 	return h.send(h.config.ErrorNotificationURL, pl)
 }
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	if h.config.SecurityNotificationURL == "" {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"url":     h.config.SecurityNotificationURL,
+		"dev_eui": pl.DevEUI,
+	}).Info("handler/http: publishing security notification")
+	return h.send(h.config.SecurityNotificationURL, pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	if h.config.ProprietaryUpURL == "" {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"url":            h.config.ProprietaryUpURL,
+		"application_id": pl.ApplicationID,
+	}).Info("handler/http: publishing proprietary up payload")
+	return h.send(h.config.ProprietaryUpURL, pl)
+}
+
+// SendCustomEvent sends a custom event.
+func (h *Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	if h.config.CustomEventURL == "" {
+		return nil
+	}
+
+	url := strings.Replace(h.config.CustomEventURL, "{name}", pl.Name, -1)
+	log.WithFields(log.Fields{
+		"url":            url,
+		"application_id": pl.ApplicationID,
+		"name":           pl.Name,
+	}).Info("handler/http: publishing custom event")
+	return h.send(url, pl)
+}
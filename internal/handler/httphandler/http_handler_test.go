@@ -2,16 +2,31 @@ package httphandler
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/secret"
 	"github.com/brocaar/lorawan"
 )
 
@@ -52,6 +67,162 @@ func TestHandlerConfig(t *testing.T) {
 				},
 				Valid: false,
 			},
+			{
+				Name: "Valid device percentage",
+				HandlerConfig: HandlerConfig{
+					DevicePercentage: 10,
+				},
+				Valid: true,
+			},
+			{
+				Name: "Invalid device percentage",
+				HandlerConfig: HandlerConfig{
+					DevicePercentage: 101,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Basic auth password without username",
+				HandlerConfig: HandlerConfig{
+					BasicAuthPassword: "secret",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Basic auth and bearer token",
+				HandlerConfig: HandlerConfig{
+					BasicAuthUsername: "user",
+					BearerToken:       "token",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Authorization header and bearer token",
+				HandlerConfig: HandlerConfig{
+					Headers: map[string]string{
+						"authorization": "Bearer other",
+					},
+					BearerToken: "token",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Bearer token without secret key",
+				HandlerConfig: HandlerConfig{
+					BearerToken: "token",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Signing secret without secret key",
+				HandlerConfig: HandlerConfig{
+					SigningSecret: "secret",
+				},
+				Valid: false,
+			},
+			{
+				Name: "TLS certificate without key",
+				HandlerConfig: HandlerConfig{
+					TLSCert: "cert",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Invalid CA certificate",
+				HandlerConfig: HandlerConfig{
+					CACert: "invalid",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Valid max attempts",
+				HandlerConfig: HandlerConfig{
+					MaxAttempts: 5,
+				},
+				Valid: true,
+			},
+			{
+				Name: "Too many max attempts",
+				HandlerConfig: HandlerConfig{
+					MaxAttempts: 11,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Negative max attempts",
+				HandlerConfig: HandlerConfig{
+					MaxAttempts: -1,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Valid max payload size",
+				HandlerConfig: HandlerConfig{
+					MaxPayloadSize: 1024,
+				},
+				Valid: true,
+			},
+			{
+				Name: "Too small max payload size",
+				HandlerConfig: HandlerConfig{
+					MaxPayloadSize: 100,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Valid batch size and interval",
+				HandlerConfig: HandlerConfig{
+					BatchSize:     100,
+					BatchInterval: 30,
+				},
+				Valid: true,
+			},
+			{
+				Name: "Too large batch size",
+				HandlerConfig: HandlerConfig{
+					BatchSize: 1001,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Too large batch interval",
+				HandlerConfig: HandlerConfig{
+					BatchSize:     10,
+					BatchInterval: 301,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Batching with the protobuf marshaler",
+				HandlerConfig: HandlerConfig{
+					BatchSize: 10,
+					Marshaler: "PROTOBUF",
+				},
+				Valid: false,
+			},
+			{
+				Name: "Valid circuit breaker",
+				HandlerConfig: HandlerConfig{
+					CircuitBreakerThreshold: 5,
+					CircuitBreakerCooldown:  120,
+				},
+				Valid: true,
+			},
+			{
+				Name: "Too large circuit breaker threshold",
+				HandlerConfig: HandlerConfig{
+					CircuitBreakerThreshold: 101,
+				},
+				Valid: false,
+			},
+			{
+				Name: "Too large circuit breaker cooldown",
+				HandlerConfig: HandlerConfig{
+					CircuitBreakerThreshold: 5,
+					CircuitBreakerCooldown:  3601,
+				},
+				Valid: false,
+			},
 		}
 
 		for i, test := range testTable {
@@ -67,6 +238,587 @@ func TestHandlerConfig(t *testing.T) {
 	})
 }
 
+func TestHandlerAuthentication(t *testing.T) {
+	Convey("Given a secret key and a test HTTP server", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		httpHandler := testHTTPHandler{
+			requests: make(chan *http.Request, 100),
+		}
+		server := httptest.NewServer(&httpHandler)
+		defer server.Close()
+
+		Convey("Given a config with basic auth", func() {
+			conf := HandlerConfig{
+				DataUpURL:         server.URL + "/dataup",
+				BasicAuthUsername: "user",
+				BasicAuthPassword: "secret",
+			}
+			So(conf.Validate(), ShouldBeNil)
+
+			Convey("Then the password is stored encrypted", func() {
+				b, err := json.Marshal(conf)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldNotContainSubstring, "secret")
+
+				var decoded HandlerConfig
+				So(json.Unmarshal(b, &decoded), ShouldBeNil)
+				So(decoded.BasicAuthPassword, ShouldEqual, conf.BasicAuthPassword)
+			})
+
+			Convey("Then the requests contain the basic auth credentials", func() {
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+
+				req := <-httpHandler.requests
+				username, password, ok := req.BasicAuth()
+				So(ok, ShouldBeTrue)
+				So(username, ShouldEqual, "user")
+				So(password, ShouldEqual, "secret")
+			})
+		})
+
+		Convey("Given a config with a bearer token", func() {
+			conf := HandlerConfig{
+				DataUpURL:   server.URL + "/dataup",
+				BearerToken: "token",
+			}
+			So(conf.Validate(), ShouldBeNil)
+
+			Convey("Then the requests contain the bearer token", func() {
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+
+				req := <-httpHandler.requests
+				So(req.Header.Get("Authorization"), ShouldEqual, "Bearer token")
+			})
+		})
+
+		Convey("Given a config with a signing secret", func() {
+			conf := HandlerConfig{
+				DataUpURL:     server.URL + "/dataup",
+				SigningSecret: "secret",
+			}
+			So(conf.Validate(), ShouldBeNil)
+
+			Convey("Then the requests contain the HMAC-SHA256 of the body", func() {
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+
+				req := <-httpHandler.requests
+				b, err := ioutil.ReadAll(req.Body)
+				So(err, ShouldBeNil)
+
+				mac := hmac.New(sha256.New, []byte("secret"))
+				mac.Write(b)
+				So(req.Header.Get(HMACHeader), ShouldEqual, hex.EncodeToString(mac.Sum(nil)))
+			})
+		})
+	})
+}
+
+func TestHandlerMutualTLS(t *testing.T) {
+	Convey("Given a secret key, a client certificate and a test HTTPS server requiring this certificate", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		So(err, ShouldBeNil)
+		tmpl := x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "lora-app-server"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+		So(err, ShouldBeNil)
+		cert, err := x509.ParseCertificate(certDER)
+		So(err, ShouldBeNil)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		So(err, ShouldBeNil)
+
+		var commonName string
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			commonName = r.TLS.PeerCertificates[0].Subject.CommonName
+		}))
+		server.TLS = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  x509.NewCertPool(),
+		}
+		server.TLS.ClientCAs.AddCert(cert)
+		server.StartTLS()
+		defer server.Close()
+
+		conf := HandlerConfig{
+			DataUpURL: server.URL,
+			CACert:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		}
+
+		Convey("Then without client certificate the request fails", func() {
+			conf.MaxAttempts = 1
+			So(conf.Validate(), ShouldBeNil)
+			h, err := NewHandler(conf)
+			So(err, ShouldBeNil)
+			So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+		})
+
+		Convey("Then with the client certificate the request succeeds", func() {
+			conf.TLSCert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+			conf.TLSKey = secret.String(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+			So(conf.Validate(), ShouldBeNil)
+			h, err := NewHandler(conf)
+			So(err, ShouldBeNil)
+			So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+			So(commonName, ShouldEqual, "lora-app-server")
+		})
+	})
+}
+
+func TestHandlerRetries(t *testing.T) {
+	Convey("Given a test HTTP server failing the first two requests", t, func() {
+		backoff := RetryBackoff
+		RetryBackoff = time.Millisecond
+		defer func() { RetryBackoff = backoff }()
+
+		var count int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+			if count <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		Convey("Then with the default max attempts the event is delivered on the third attempt", func() {
+			h, err := NewHandler(HandlerConfig{
+				DataUpURL: server.URL,
+			})
+			So(err, ShouldBeNil)
+			So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+			So(count, ShouldEqual, 3)
+		})
+
+		Convey("Then with max attempts set to 2 an error is returned", func() {
+			h, err := NewHandler(HandlerConfig{
+				DataUpURL:   server.URL,
+				MaxAttempts: 2,
+			})
+			So(err, ShouldBeNil)
+			So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+			So(count, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestHandlerCircuitBreaker(t *testing.T) {
+	Convey("Given a failing test HTTP server", t, func() {
+		var count int
+		fail := true
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+			if fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		Convey("Given a handler with a circuit breaker threshold of 2", func() {
+			conf := HandlerConfig{
+				DataUpURL:               server.URL,
+				MaxAttempts:             1,
+				CircuitBreakerThreshold: 2,
+			}
+			h, err := NewHandler(conf)
+			So(err, ShouldBeNil)
+
+			So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+			So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+			So(count, ShouldEqual, 2)
+
+			Convey("Then the endpoint is skipped after 2 failures and the event fails", func() {
+				So(errors.Cause(h.SendDataUp(handler.DataUpPayload{})), ShouldEqual, ErrCircuitOpen)
+				So(count, ShouldEqual, 2)
+			})
+
+			Convey("Then the circuit breaker is shared by the handlers of a configuration with secrets", func() {
+				So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+				defer secret.SetKey("")
+
+				conf.BearerToken = "secret-token"
+				cb1, err := getCircuitBreaker(server.URL, conf)
+				So(err, ShouldBeNil)
+				cb2, err := getCircuitBreaker(server.URL, conf)
+				So(err, ShouldBeNil)
+				So(cb2, ShouldEqual, cb1)
+			})
+
+			Convey("Then the idle circuit breakers are removed", func() {
+				cb, err := getCircuitBreaker(server.URL, conf)
+				So(err, ShouldBeNil)
+				confKey, err := configKey(conf)
+				So(err, ShouldBeNil)
+
+				circuitBreakersMu.Lock()
+				cb.lastUsed = time.Now().Add(-circuitBreakerIdleTimeout)
+				circuitBreakersLastCleanup = time.Time{}
+				circuitBreakersMu.Unlock()
+
+				conf.Headers = map[string]string{"X-Other": "other"}
+				_, err = getCircuitBreaker(server.URL, conf)
+				So(err, ShouldBeNil)
+
+				circuitBreakersMu.Lock()
+				_, ok := circuitBreakers[circuitBreakerKey{url: server.URL, conf: confKey}]
+				circuitBreakersMu.Unlock()
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then with dropping the skipped event is dropped", func() {
+				conf.CircuitBreakerDrop = true
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+				So(count, ShouldEqual, 4)
+
+				So(errors.Cause(h.SendDataUp(handler.DataUpPayload{})), ShouldEqual, handler.ErrDropped)
+				So(count, ShouldEqual, 4)
+			})
+
+			Convey("Then the circuit breaker is not shared with other configurations", func() {
+				conf.Headers = map[string]string{"Authorization": "other"}
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+
+				So(errors.Cause(h.SendDataUp(handler.DataUpPayload{})), ShouldNotEqual, ErrCircuitOpen)
+				So(count, ShouldEqual, 3)
+			})
+
+			Convey("Then with dead-lettering the skipped event is dead-lettered", func() {
+				conf.CircuitBreakerDeadLetter = true
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+				So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+				So(count, ShouldEqual, 4)
+
+				err = h.SendDataUp(handler.DataUpPayload{})
+				So(errors.Cause(err), ShouldEqual, handler.ErrDeadLetter)
+				So(count, ShouldEqual, 4)
+			})
+
+			Convey("When the cooldown has expired", func() {
+				cb, err := getCircuitBreaker(server.URL, conf)
+				So(err, ShouldBeNil)
+				cb.openedAt = time.Now().Add(-DefaultCircuitBreakerCooldown)
+
+				Convey("Then a failing trial request re-opens the circuit", func() {
+					So(h.SendDataUp(handler.DataUpPayload{}), ShouldNotBeNil)
+					So(errors.Cause(h.SendDataUp(handler.DataUpPayload{})), ShouldEqual, ErrCircuitOpen)
+					So(count, ShouldEqual, 3)
+				})
+
+				Convey("Then a successful trial request closes the circuit", func() {
+					fail = false
+					So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+					So(h.SendDataUp(handler.DataUpPayload{}), ShouldBeNil)
+					So(count, ShouldEqual, 4)
+				})
+
+				Convey("Then a failing trial delivery is retried", func() {
+					RetryBackoff = time.Millisecond
+					defer func() { RetryBackoff = time.Second }()
+
+					conf.MaxAttempts = 3
+					h, err := NewHandler(conf)
+					So(err, ShouldBeNil)
+					cb, err := getCircuitBreaker(server.URL, conf)
+					So(err, ShouldBeNil)
+					cb.open = true
+					cb.openedAt = time.Now().Add(-DefaultCircuitBreakerCooldown)
+
+					So(errors.Cause(h.SendDataUp(handler.DataUpPayload{})), ShouldNotEqual, ErrCircuitOpen)
+					So(count, ShouldEqual, 5)
+				})
+			})
+		})
+	})
+}
+
+func TestHandlerMaxPayloadSize(t *testing.T) {
+	Convey("Given a test HTTP server and a data-up payload with rxInfo", t, func() {
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		pl := handler.DataUpPayload{
+			ApplicationName: "test-app",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Data:            make([]byte, 100),
+		}
+		for i := 0; i < 10; i++ {
+			pl.RXInfo = append(pl.RXInfo, handler.RXInfo{Name: fmt.Sprintf("gateway-%d", i)})
+		}
+		full, err := json.Marshal(pl)
+		So(err, ShouldBeNil)
+
+		newHandler := func(maxSize int) *Handler {
+			h, err := NewHandler(HandlerConfig{
+				DataUpURL:      server.URL,
+				MaxPayloadSize: maxSize,
+			})
+			So(err, ShouldBeNil)
+			return h
+		}
+
+		Convey("Then a payload within the max payload size is sent as-is", func() {
+			So(newHandler(len(full)).SendDataUp(pl), ShouldBeNil)
+			So(string(body), ShouldEqual, string(full))
+		})
+
+		Convey("Then for a payload exceeding the max payload size the rxInfo is dropped first", func() {
+			stats := GetPayloadLimitStats()
+			So(newHandler(len(full)-1).SendDataUp(pl), ShouldBeNil)
+
+			var out map[string]interface{}
+			So(json.Unmarshal(body, &out), ShouldBeNil)
+			So(out["rxInfo"], ShouldHaveLength, 0)
+			So(out["data"], ShouldEqual, base64.StdEncoding.EncodeToString(pl.Data))
+			So(out, ShouldNotContainKey, "truncated")
+			So(GetPayloadLimitStats().RXInfoDropped, ShouldEqual, stats.RXInfoDropped+1)
+		})
+
+		Convey("Then when still too large, the data is truncated and flagged", func() {
+			stats := GetPayloadLimitStats()
+			So(newHandler(minMaxPayloadSize).SendDataUp(handler.DataUpPayload{Data: make([]byte, 1000)}), ShouldBeNil)
+			So(len(body), ShouldBeLessThanOrEqualTo, minMaxPayloadSize)
+
+			var out truncatedDataUpPayload
+			So(json.Unmarshal(body, &out), ShouldBeNil)
+			So(out.Truncated, ShouldBeTrue)
+			So(len(out.Data), ShouldBeGreaterThan, 0)
+			So(len(out.Data), ShouldBeLessThan, 1000)
+			So(GetPayloadLimitStats().DataTruncated, ShouldEqual, stats.DataTruncated+1)
+		})
+
+		Convey("Then a payload which can not be reduced is dropped", func() {
+			stats := GetPayloadLimitStats()
+			body = nil
+			h, err := NewHandler(HandlerConfig{
+				ErrorNotificationURL: server.URL,
+				MaxPayloadSize:       minMaxPayloadSize,
+			})
+			So(err, ShouldBeNil)
+			So(h.SendErrorNotification(handler.ErrorNotification{Error: string(make([]byte, 1000))}), ShouldBeNil)
+			So(body, ShouldBeNil)
+			So(GetPayloadLimitStats().Dropped, ShouldEqual, stats.Dropped+1)
+		})
+	})
+}
+
+func TestHandlerBatching(t *testing.T) {
+	Convey("Given a test HTTP server", t, func() {
+		bodies := make(chan []byte, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies <- b
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		Convey("Given a handler with a batch size of 3", func() {
+			h, err := NewHandler(HandlerConfig{
+				DataUpURL:            server.URL,
+				ErrorNotificationURL: server.URL,
+				BatchSize:            3,
+				BatchInterval:        1,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the uplinks are sent as a JSON array when the batch is full", func() {
+				for i := 0; i < 3; i++ {
+					So(h.SendDataUp(handler.DataUpPayload{FCnt: uint32(i)}), ShouldBeNil)
+					if i < 2 {
+						So(bodies, ShouldHaveLength, 0)
+					}
+				}
+
+				var out []handler.DataUpPayload
+				So(json.Unmarshal(<-bodies, &out), ShouldBeNil)
+				So(out, ShouldHaveLength, 3)
+				So(out[2].FCnt, ShouldEqual, 2)
+			})
+
+			Convey("Then a partial batch is sent when the batch interval expires", func() {
+				So(h.SendDataUp(handler.DataUpPayload{FCnt: 10}), ShouldBeNil)
+
+				var out []handler.DataUpPayload
+				select {
+				case b := <-bodies:
+					So(json.Unmarshal(b, &out), ShouldBeNil)
+				case <-time.After(5 * time.Second):
+				}
+				So(out, ShouldHaveLength, 1)
+				So(out[0].FCnt, ShouldEqual, 10)
+			})
+
+			Convey("Then a partial batch is sent by FlushBatches", func() {
+				So(h.SendDataUp(handler.DataUpPayload{FCnt: 20}), ShouldBeNil)
+				FlushBatches()
+
+				var out []handler.DataUpPayload
+				So(json.Unmarshal(<-bodies, &out), ShouldBeNil)
+				So(out, ShouldHaveLength, 1)
+			})
+
+			Convey("Then the other events are not batched", func() {
+				So(h.SendErrorNotification(handler.ErrorNotification{Error: "test"}), ShouldBeNil)
+
+				var out handler.ErrorNotification
+				So(json.Unmarshal(<-bodies, &out), ShouldBeNil)
+				So(out.Error, ShouldEqual, "test")
+			})
+		})
+	})
+
+	Convey("Given a secret key, a test HTTP server and a config with a bearer token and a batch size of 2", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		bodies := make(chan []byte, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies <- b
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		conf := HandlerConfig{
+			DataUpURL:     server.URL,
+			BearerToken:   "secret-token",
+			BatchSize:     2,
+			BatchInterval: 60,
+		}
+
+		Convey("Then the uplinks of the handlers created per event are batched", func() {
+			for i := 0; i < 2; i++ {
+				h, err := NewHandler(conf)
+				So(err, ShouldBeNil)
+				So(h.SendDataUp(handler.DataUpPayload{FCnt: uint32(i)}), ShouldBeNil)
+			}
+
+			So(bodies, ShouldHaveLength, 1)
+			var out []handler.DataUpPayload
+			So(json.Unmarshal(<-bodies, &out), ShouldBeNil)
+			So(out, ShouldHaveLength, 2)
+		})
+	})
+
+	Convey("Given a failing test HTTP server and a handler with a batch size of 3", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		conf := HandlerConfig{
+			DataUpURL:     server.URL,
+			BatchSize:     3,
+			BatchInterval: 1,
+			MaxAttempts:   1,
+		}
+
+		failed := make(chan uint32, 10)
+		send := func(fCnt uint32) error {
+			h, err := NewHandler(conf)
+			So(err, ShouldBeNil)
+			h.SetBatchFailureFunc(func(err error) {
+				failed <- fCnt
+			})
+			return h.SendDataUp(handler.DataUpPayload{FCnt: fCnt})
+		}
+
+		Convey("Then the failure of a full batch is returned for the last uplink and handled for the other uplinks", func() {
+			So(send(1), ShouldBeNil)
+			So(send(2), ShouldBeNil)
+			So(send(3), ShouldNotBeNil)
+
+			So(failed, ShouldHaveLength, 2)
+			So(<-failed, ShouldEqual, 1)
+			So(<-failed, ShouldEqual, 2)
+		})
+
+		Convey("Then the failure of a partial batch sent by FlushBatches is handled for all uplinks", func() {
+			So(send(1), ShouldBeNil)
+			So(send(2), ShouldBeNil)
+			FlushBatches()
+
+			So(failed, ShouldHaveLength, 2)
+		})
+
+		Convey("Then the failure of a partial batch sent when the batch interval expires is handled", func() {
+			So(send(1), ShouldBeNil)
+
+			var fCnt uint32
+			select {
+			case fCnt = <-failed:
+			case <-time.After(5 * time.Second):
+			}
+			So(fCnt, ShouldEqual, 1)
+		})
+	})
+}
+
+func TestHandlerConfigIncludesDevEUI(t *testing.T) {
+	Convey("Given a set of DevEUIs", t, func() {
+		var devEUIs []lorawan.EUI64
+		for i := 0; i < 1000; i++ {
+			devEUIs = append(devEUIs, lorawan.EUI64{1, 2, 3, 4, 5, 6, byte(i >> 8), byte(i)})
+		}
+
+		count := func(conf HandlerConfig) int {
+			var n int
+			for _, devEUI := range devEUIs {
+				if conf.IncludesDevEUI(devEUI) {
+					n++
+				}
+			}
+			return n
+		}
+
+		Convey("Then without device percentage and DevEUIs all devices are included", func() {
+			So(count(HandlerConfig{}), ShouldEqual, 1000)
+			So(count(HandlerConfig{DevicePercentage: 100}), ShouldEqual, 1000)
+		})
+
+		Convey("Then with a device percentage of 10 about 10% of the devices are included", func() {
+			n := count(HandlerConfig{DevicePercentage: 10})
+			So(n, ShouldBeBetween, 50, 150)
+
+			Convey("Then the same devices are included every time", func() {
+				So(count(HandlerConfig{DevicePercentage: 10}), ShouldEqual, n)
+			})
+		})
+
+		Convey("Then with a DevEUIs list only these devices are included", func() {
+			So(count(HandlerConfig{DevEUIs: devEUIs[:3]}), ShouldEqual, 3)
+		})
+	})
+}
+
 func TestHandler(t *testing.T) {
 	Convey("Given a test HTTP server and a Handler instance", t, func() {
 		httpHandler := testHTTPHandler{
@@ -79,10 +831,12 @@ func TestHandler(t *testing.T) {
 			Headers: map[string]string{
 				"Foo": "Bar",
 			},
-			DataUpURL:            server.URL + "/dataup",
-			JoinNotificationURL:  server.URL + "/join",
-			ACKNotificationURL:   server.URL + "/ack",
-			ErrorNotificationURL: server.URL + "/error",
+			DataUpURL:               server.URL + "/dataup",
+			JoinNotificationURL:     server.URL + "/join",
+			ACKNotificationURL:      server.URL + "/ack",
+			ErrorNotificationURL:    server.URL + "/error",
+			SecurityNotificationURL: server.URL + "/security",
+			CustomEventURL:          server.URL + "/events/{name}",
 		}
 		h, err := NewHandler(conf)
 		So(err, ShouldBeNil)
@@ -150,5 +904,39 @@ func TestHandler(t *testing.T) {
 			So(req.Header.Get("Foo"), ShouldEqual, "Bar")
 			So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
 		})
+
+		Convey("Then SendSecurityNotification sends the correct notification", func() {
+			reqPL := handler.SecurityNotification{
+				Type:    "MIC_FAILURE",
+				Message: "invalid MIC",
+			}
+			So(h.SendSecurityNotification(reqPL), ShouldBeNil)
+
+			req := <-httpHandler.requests
+			So(req.URL.Path, ShouldEqual, "/security")
+
+			var pl handler.SecurityNotification
+			So(json.NewDecoder(req.Body).Decode(&pl), ShouldBeNil)
+			So(pl, ShouldResemble, reqPL)
+			So(req.Header.Get("Foo"), ShouldEqual, "Bar")
+			So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+		})
+
+		Convey("Then SendCustomEvent sends the event to the URL of the event name", func() {
+			reqPL := handler.CustomEvent{
+				ApplicationID: 1,
+				Name:          "door_opened",
+				Payload:       json.RawMessage(`{"door":"north"}`),
+			}
+			So(h.SendCustomEvent(reqPL), ShouldBeNil)
+
+			req := <-httpHandler.requests
+			So(req.URL.Path, ShouldEqual, "/events/door_opened")
+
+			var pl handler.CustomEvent
+			So(json.NewDecoder(req.Body).Decode(&pl), ShouldBeNil)
+			So(pl.Name, ShouldEqual, "door_opened")
+			So(string(pl.Payload), ShouldEqual, `{"door":"north"}`)
+		})
 	})
 }
@@ -0,0 +1,262 @@
+// Package marshaler implements the wire formats in which the integration
+// handlers encode the events.
+package marshaler
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/handler"
+)
+
+// Marshaler types.
+const (
+	// JSON encodes the events as JSON, the byte fields base64 encoded
+	// (default).
+	JSON = "JSON"
+
+	// JSONHex encodes the events as JSON, the byte fields (e.g. data) hex
+	// encoded.
+	JSONHex = "JSON_HEX"
+
+	// Protobuf encodes the events using the messages defined in
+	// api/integration.proto.
+	Protobuf = "PROTOBUF"
+)
+
+// ErrUnknownMarshaler is returned when the marshaler type is unknown.
+var ErrUnknownMarshaler = errors.New("unknown marshaler")
+
+// Marshaler defines the interface of a wire format.
+type Marshaler interface {
+	// Marshal encodes the given event (one of the handler payload types).
+	Marshal(v interface{}) ([]byte, error)
+
+	// ContentType returns the content-type of the encoded events.
+	ContentType() string
+}
+
+// New returns the Marshaler for the given type. An empty type returns the
+// JSON marshaler.
+func New(t string) (Marshaler, error) {
+	switch t {
+	case "", JSON:
+		return JSONMarshaler{}, nil
+	case JSONHex:
+		return jsonHexMarshaler{}, nil
+	case Protobuf:
+		return protobufMarshaler{}, nil
+	default:
+		return nil, ErrUnknownMarshaler
+	}
+}
+
+// Validate returns ErrUnknownMarshaler when the given type is not a known
+// marshaler type.
+func Validate(t string) error {
+	_, err := New(t)
+	return err
+}
+
+// JSONMarshaler implements the JSON (default) marshaler.
+type JSONMarshaler struct{}
+
+// Marshal encodes the given event as JSON.
+func (JSONMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// ContentType returns the content-type of the encoded events.
+func (JSONMarshaler) ContentType() string {
+	return "application/json"
+}
+
+// hexBytes is a byte slice which is hex encoded when marshaled to JSON.
+type hexBytes []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+type jsonHexMarshaler struct{}
+
+// Marshal encodes the given event as JSON. The byte fields of the event are
+// shadowed by hex encoded fields with the same JSON name.
+func (jsonHexMarshaler) Marshal(v interface{}) ([]byte, error) {
+	switch pl := v.(type) {
+	case handler.DataUpPayload:
+		return json.Marshal(struct {
+			handler.DataUpPayload
+			Data hexBytes `json:"data"`
+		}{pl, pl.Data})
+	case handler.ProprietaryUpPayload:
+		return json.Marshal(struct {
+			handler.ProprietaryUpPayload
+			MACPayload hexBytes `json:"macPayload"`
+			MIC        hexBytes `json:"mic"`
+		}{pl, pl.MACPayload, pl.MIC})
+	case handler.GatewayNotification:
+		return json.Marshal(struct {
+			handler.GatewayNotification
+			MACPayload hexBytes `json:"macPayload,omitempty"`
+			MIC        hexBytes `json:"mic,omitempty"`
+		}{pl, pl.MACPayload, pl.MIC})
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func (jsonHexMarshaler) ContentType() string {
+	return "application/json"
+}
+
+type protobufMarshaler struct{}
+
+func (protobufMarshaler) Marshal(v interface{}) ([]byte, error) {
+	var msg proto.Message
+	switch pl := v.(type) {
+	case handler.DataUpPayload:
+		msg = &pb.UplinkEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			NodeName:        pl.NodeName,
+			DevEUI:          pl.DevEUI[:],
+			RxInfo:          rxInfoToPB(pl.RXInfo),
+			TxInfo:          txInfoToPB(&pl.TXInfo),
+			FCnt:            pl.FCnt,
+			FPort:           uint32(pl.FPort),
+			Data:            pl.Data,
+			Maintenance:     pl.Maintenance,
+		}
+	case handler.JoinNotification:
+		msg = &pb.JoinEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			NodeName:        pl.NodeName,
+			DevEUI:          pl.DevEUI[:],
+			DevAddr:         pl.DevAddr[:],
+		}
+	case handler.ACKNotification:
+		msg = &pb.AckEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			NodeName:        pl.NodeName,
+			DevEUI:          pl.DevEUI[:],
+			Reference:       pl.Reference,
+		}
+	case handler.ErrorNotification:
+		msg = &pb.ErrorEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			NodeName:        pl.NodeName,
+			DevEUI:          pl.DevEUI[:],
+			Type:            pl.Type,
+			Error:           pl.Error,
+			Reference:       pl.Reference,
+		}
+	case handler.SecurityNotification:
+		msg = &pb.SecurityEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			NodeName:        pl.NodeName,
+			DevEUI:          pl.DevEUI[:],
+			Type:            pl.Type,
+			Message:         pl.Message,
+		}
+	case handler.ProprietaryUpPayload:
+		msg = &pb.ProprietaryUplinkEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			MacPayload:      pl.MACPayload,
+			Mic:             pl.MIC,
+			RxInfo:          rxInfoToPB(pl.RXInfo),
+			TxInfo:          txInfoToPB(pl.TXInfo),
+		}
+	case handler.CustomEvent:
+		ev := pb.CustomEvent{
+			ApplicationID:   pl.ApplicationID,
+			ApplicationName: pl.ApplicationName,
+			Environment:     pl.Environment,
+			NodeName:        pl.NodeName,
+			Name:            pl.Name,
+			PayloadJSON:     string(pl.Payload),
+			Time:            pl.Time.Format(time.RFC3339Nano),
+		}
+		if pl.DevEUI != nil {
+			ev.DevEUI = pl.DevEUI[:]
+		}
+		msg = &ev
+	case handler.GatewayNotification:
+		msg = &pb.GatewayEvent{
+			OrganizationID: pl.OrganizationID,
+			Mac:            pl.MAC[:],
+			Name:           pl.Name,
+			Type:           pl.Type,
+			Message:        pl.Message,
+			MacPayload:     pl.MACPayload,
+			Mic:            pl.MIC,
+			RxInfo:         rxInfoToPB(pl.RXInfo),
+			TxInfo:         txInfoToPB(pl.TXInfo),
+		}
+	default:
+		return nil, fmt.Errorf("marshaler: unsupported event type %T", v)
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal protobuf error")
+	}
+	return b, nil
+}
+
+func (protobufMarshaler) ContentType() string {
+	return "application/x-protobuf"
+}
+
+func rxInfoToPB(rxInfo []handler.RXInfo) []*pb.IntegrationRXInfo {
+	var out []*pb.IntegrationRXInfo
+	for i := range rxInfo {
+		rx := pb.IntegrationRXInfo{
+			Mac:       rxInfo[i].MAC[:],
+			Rssi:      int32(rxInfo[i].RSSI),
+			LoRaSNR:   rxInfo[i].LoRaSNR,
+			Name:      rxInfo[i].Name,
+			Latitude:  rxInfo[i].Latitude,
+			Longitude: rxInfo[i].Longitude,
+			Altitude:  rxInfo[i].Altitude,
+		}
+		if rxInfo[i].Time != nil {
+			rx.Time = rxInfo[i].Time.Format(time.RFC3339Nano)
+		}
+		out = append(out, &rx)
+	}
+	return out
+}
+
+func txInfoToPB(txInfo *handler.TXInfo) *pb.IntegrationTXInfo {
+	if txInfo == nil {
+		return nil
+	}
+	return &pb.IntegrationTXInfo{
+		Frequency:    uint32(txInfo.Frequency),
+		Modulation:   txInfo.DataRate.Modulation,
+		Bandwidth:    uint32(txInfo.DataRate.Bandwidth),
+		SpreadFactor: uint32(txInfo.DataRate.SpreadFactor),
+		Bitrate:      uint32(txInfo.DataRate.Bitrate),
+		Adr:          txInfo.ADR,
+		CodeRate:     txInfo.CodeRate,
+	}
+}
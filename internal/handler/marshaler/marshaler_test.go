@@ -0,0 +1,91 @@
+package marshaler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/smartystreets/goconvey/convey"
+
+	pb "github.com/brocaar/lora-app-server/api"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+func TestMarshaler(t *testing.T) {
+	Convey("Given a data-up payload", t, func() {
+		pl := handler.DataUpPayload{
+			ApplicationID:   1,
+			ApplicationName: "test-app",
+			NodeName:        "test-node",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			RXInfo: []handler.RXInfo{
+				{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, RSSI: -60, LoRaSNR: 5.5},
+			},
+			TXInfo: handler.TXInfo{
+				Frequency: 868100000,
+				DataRate: handler.DataRate{
+					Modulation:   "LORA",
+					Bandwidth:    125,
+					SpreadFactor: 7,
+				},
+			},
+			FCnt:  10,
+			FPort: 2,
+			Data:  []byte{1, 2, 3},
+		}
+
+		Convey("Then the empty type returns the JSON marshaler", func() {
+			m, err := New("")
+			So(err, ShouldBeNil)
+			So(m.ContentType(), ShouldEqual, "application/json")
+
+			b, err := m.Marshal(pl)
+			So(err, ShouldBeNil)
+			var out map[string]interface{}
+			So(json.Unmarshal(b, &out), ShouldBeNil)
+			So(out["data"], ShouldEqual, "AQID")
+		})
+
+		Convey("Then the JSON_HEX marshaler hex encodes the data", func() {
+			m, err := New(JSONHex)
+			So(err, ShouldBeNil)
+
+			b, err := m.Marshal(pl)
+			So(err, ShouldBeNil)
+			var out map[string]interface{}
+			So(json.Unmarshal(b, &out), ShouldBeNil)
+			So(out["data"], ShouldEqual, "010203")
+			So(out["devEUI"], ShouldEqual, "0102030405060708")
+			So(out["nodeName"], ShouldEqual, "test-node")
+		})
+
+		Convey("Then the PROTOBUF marshaler encodes an UplinkEvent", func() {
+			m, err := New(Protobuf)
+			So(err, ShouldBeNil)
+			So(m.ContentType(), ShouldEqual, "application/x-protobuf")
+
+			b, err := m.Marshal(pl)
+			So(err, ShouldBeNil)
+			var out pb.UplinkEvent
+			So(proto.Unmarshal(b, &out), ShouldBeNil)
+			So(out.ApplicationID, ShouldEqual, 1)
+			So(out.DevEUI, ShouldResemble, pl.DevEUI[:])
+			So(out.Data, ShouldResemble, pl.Data)
+			So(out.RxInfo, ShouldHaveLength, 1)
+			So(out.RxInfo[0].Rssi, ShouldEqual, -60)
+			So(out.TxInfo.SpreadFactor, ShouldEqual, 7)
+		})
+	})
+
+	Convey("Then the PROTOBUF marshaler returns an error for unsupported types", t, func() {
+		m, err := New(Protobuf)
+		So(err, ShouldBeNil)
+		_, err = m.Marshal(struct{}{})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Then an unknown marshaler type returns an error", t, func() {
+		So(Validate("XML"), ShouldEqual, ErrUnknownMarshaler)
+	})
+}
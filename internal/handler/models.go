@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/brocaar/lorawan"
@@ -38,6 +39,7 @@ type TXInfo struct {
 type DataUpPayload struct {
 	ApplicationID   int64         `json:"applicationID,string"`
 	ApplicationName string        `json:"applicationName"`
+	Environment     string        `json:"environment,omitempty"`
 	NodeName        string        `json:"nodeName"`
 	DevEUI          lorawan.EUI64 `json:"devEUI"`
 	RXInfo          []RXInfo      `json:"rxInfo"`
@@ -45,6 +47,7 @@ type DataUpPayload struct {
 	FCnt            uint32        `json:"fCnt"`
 	FPort           uint8         `json:"fPort"`
 	Data            []byte        `json:"data"`
+	Maintenance     bool          `json:"maintenance,omitempty"`
 }
 
 // DataDownPayload represents a data-down payload.
@@ -62,6 +65,7 @@ type DataDownPayload struct {
 type JoinNotification struct {
 	ApplicationID   int64           `json:"applicationID,string"`
 	ApplicationName string          `json:"applicationName"`
+	Environment     string          `json:"environment,omitempty"`
 	NodeName        string          `json:"nodeName"`
 	DevEUI          lorawan.EUI64   `json:"devEUI"`
 	DevAddr         lorawan.DevAddr `json:"devAddr"`
@@ -72,6 +76,7 @@ type JoinNotification struct {
 type ACKNotification struct {
 	ApplicationID   int64         `json:"applicationID,string"`
 	ApplicationName string        `json:"applicationName"`
+	Environment     string        `json:"environment,omitempty"`
 	NodeName        string        `json:"nodeName"`
 	DevEUI          lorawan.EUI64 `json:"devEUI"`
 	Reference       string        `json:"reference"`
@@ -82,8 +87,63 @@ type ACKNotification struct {
 type ErrorNotification struct {
 	ApplicationID   int64         `json:"applicationID,string"`
 	ApplicationName string        `json:"applicationName"`
+	Environment     string        `json:"environment,omitempty"`
 	NodeName        string        `json:"nodeName"`
 	DevEUI          lorawan.EUI64 `json:"devEUI"`
 	Type            string        `json:"type"`
 	Error           string        `json:"error"`
+	Reference       string        `json:"reference,omitempty"`
+}
+
+// SecurityNotification defines the payload sent to the application on
+// a security event.
+type SecurityNotification struct {
+	ApplicationID   int64         `json:"applicationID,string"`
+	ApplicationName string        `json:"applicationName"`
+	Environment     string        `json:"environment,omitempty"`
+	NodeName        string        `json:"nodeName"`
+	DevEUI          lorawan.EUI64 `json:"devEUI"`
+	Type            string        `json:"type"`
+	Message         string        `json:"message"`
+}
+
+// ProprietaryUpPayload defines the payload sent to the application on
+// receiving a proprietary frame matching its proprietary payload prefix.
+type ProprietaryUpPayload struct {
+	ApplicationID   int64    `json:"applicationID,string"`
+	ApplicationName string   `json:"applicationName"`
+	Environment     string   `json:"environment,omitempty"`
+	MACPayload      []byte   `json:"macPayload"`
+	MIC             []byte   `json:"mic"`
+	RXInfo          []RXInfo `json:"rxInfo,omitempty"`
+	TXInfo          *TXInfo  `json:"txInfo,omitempty"`
+}
+
+// GatewayNotification defines the payload sent on a gateway (network-layer)
+// event, e.g. when a gateway goes offline or when a proprietary frame has
+// been received.
+type GatewayNotification struct {
+	OrganizationID int64         `json:"organizationID,string,omitempty"`
+	MAC            lorawan.EUI64 `json:"mac"`
+	Name           string        `json:"name,omitempty"`
+	Type           string        `json:"type"`
+	Message        string        `json:"message,omitempty"`
+	MACPayload     []byte        `json:"macPayload,omitempty"`
+	MIC            []byte        `json:"mic,omitempty"`
+	RXInfo         []RXInfo      `json:"rxInfo,omitempty"`
+	TXInfo         *TXInfo       `json:"txInfo,omitempty"`
+}
+
+// CustomEvent defines the payload of a user-defined (named) event, e.g.
+// emitted by an integration after decoding an uplink. DevEUI is nil when the
+// event is not related to a node.
+type CustomEvent struct {
+	ApplicationID   int64           `json:"applicationID,string"`
+	ApplicationName string          `json:"applicationName"`
+	Environment     string          `json:"environment,omitempty"`
+	NodeName        string          `json:"nodeName,omitempty"`
+	DevEUI          *lorawan.EUI64  `json:"devEUI,omitempty"`
+	Name            string          `json:"name"`
+	Payload         json.RawMessage `json:"payload,omitempty"`
+	Time            time.Time       `json:"time"`
 }
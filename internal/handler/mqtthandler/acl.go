@@ -0,0 +1,44 @@
+package mqtthandler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Access types, as used by the MQTT broker ACL checks.
+const (
+	AccessRead      = 1
+	AccessWrite     = 2
+	AccessReadWrite = 3
+	AccessSubscribe = 4
+)
+
+// ApplicationTopicPrefix returns the topic prefix to which an application
+// (authenticated with its own credentials) is isolated. An empty string is
+// returned when the configured topic templates do not share a common prefix
+// containing the application ID.
+func ApplicationTopicPrefix(applicationID int64) string {
+	if topics.applicationPrefix == "" {
+		return ""
+	}
+	return strings.Replace(topics.applicationPrefix, markerApplicationID, fmt.Sprintf("%d", applicationID), -1)
+}
+
+// ApplicationTopicAllowed returns true when the given application may access
+// the given topic (or topic filter in case of a subscription). Applications
+// may subscribe to and read all topics within their topic prefix and may
+// only publish to the tx topics of their nodes.
+func ApplicationTopicAllowed(applicationID int64, topic string, access int) bool {
+	switch access {
+	case AccessRead, AccessSubscribe:
+		prefix := ApplicationTopicPrefix(applicationID)
+		return prefix != "" && strings.HasPrefix(topic, prefix)
+	case AccessWrite:
+		id, _, err := topics.parseDownlinkTopic(topic)
+		return err == nil && id == applicationID
+	case AccessReadWrite:
+		return ApplicationTopicAllowed(applicationID, topic, AccessRead) && ApplicationTopicAllowed(applicationID, topic, AccessWrite)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,198 @@
+package mqtthandler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	log "github.com/sirupsen/logrus"
+)
+
+const bridgeConnectRetryInterval = 2 * time.Second
+
+// errNotConnected is returned when publishing to a broker which is not
+// connected.
+var errNotConnected = errors.New("not connected")
+
+// Broker contains the configuration of a MQTT broker.
+type Broker struct {
+	Server   string
+	Username string
+	Password string
+	CACert   string
+
+	// TLSCert and TLSKey define the client certificate and key files for
+	// brokers requiring mutual TLS (optional).
+	TLSCert string
+	TLSKey  string
+
+	// Compression defines the compression type of the published payloads
+	// (see GzipCompression, optional).
+	Compression string
+}
+
+// BrokerStats contains the connection state and metrics of a broker.
+type BrokerStats struct {
+	Server          string    `json:"server"`
+	Connected       bool      `json:"connected"`
+	Connects        uint64    `json:"connects"`
+	ConnectionLost  uint64    `json:"connectionLost"`
+	LastConnectedAt time.Time `json:"lastConnectedAt"`
+	Published       uint64    `json:"published"`
+	PublishErrors   uint64    `json:"publishErrors"`
+}
+
+// broker holds the connection and metrics of a single broker.
+type broker struct {
+	server      string
+	compression string
+	conn        mqtt.Client
+	done        chan struct{}
+
+	mu    sync.Mutex
+	stats BrokerStats
+}
+
+func newBroker(server, compression string) *broker {
+	return &broker{
+		server:      server,
+		compression: compression,
+		done:        make(chan struct{}),
+		stats: BrokerStats{
+			Server: server,
+		},
+	}
+}
+
+// newBridge creates a publish-only broker connection. The connection is
+// setup in the background so that an unavailable bridge broker does not
+// block the startup.
+func newBridge(conf Broker) (*broker, error) {
+	if err := ValidateCompression(conf.Compression); err != nil {
+		return nil, err
+	}
+
+	opts, err := newClientOptions(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newBroker(conf.Server, conf.Compression)
+	opts.SetOnConnectHandler(func(c mqtt.Client) {
+		log.WithField("server", b.server).Info("handler/mqtt: connected to mqtt bridge broker")
+		b.onConnected()
+	})
+	opts.SetConnectionLostHandler(func(c mqtt.Client, reason error) {
+		log.WithField("server", b.server).Errorf("handler/mqtt: mqtt bridge connection error: %s", reason)
+		b.onConnectionLost()
+	})
+	b.conn = mqtt.NewClient(opts)
+
+	log.WithField("server", b.server).Info("handler/mqtt: connecting to mqtt bridge broker")
+	go b.connectLoop()
+
+	return b, nil
+}
+
+// connectLoop connects to the broker until successful. Once connected,
+// reconnecting is handled by the MQTT client.
+func (b *broker) connectLoop() {
+	for {
+		token := b.conn.Connect()
+		if token.Wait() && token.Error() == nil {
+			return
+		}
+		log.WithField("server", b.server).Errorf("handler/mqtt: connecting to bridge broker error, will retry in %s: %s", bridgeConnectRetryInterval, token.Error())
+
+		select {
+		case <-b.done:
+			return
+		case <-time.After(bridgeConnectRetryInterval):
+		}
+	}
+}
+
+func (b *broker) close() {
+	close(b.done)
+	b.conn.Disconnect(250)
+}
+
+func (b *broker) publish(topic string, opts PublishOptions, payload []byte) error {
+	var err error
+	if !b.conn.IsConnected() {
+		err = errNotConnected
+	} else if token := b.conn.Publish(topic, opts.QoS, opts.Retained, payload); token.Wait() && token.Error() != nil {
+		err = token.Error()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.stats.PublishErrors++
+	} else {
+		b.stats.Published++
+	}
+	return err
+}
+
+func (b *broker) onConnected() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Connected = true
+	b.stats.Connects++
+	b.stats.LastConnectedAt = time.Now()
+}
+
+func (b *broker) onConnectionLost() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Connected = false
+	b.stats.ConnectionLost++
+}
+
+// Stats returns a copy of the broker metrics.
+func (b *broker) Stats() BrokerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// CheckBroker connects to the given broker (a single attempt) and
+// disconnects again. It returns the connection error, if any.
+func CheckBroker(conf Broker, timeout time.Duration) error {
+	opts, err := newClientOptions(conf)
+	if err != nil {
+		return err
+	}
+	opts.SetConnectTimeout(timeout)
+	opts.SetAutoReconnect(false)
+
+	conn := mqtt.NewClient(opts)
+	token := conn.Connect()
+	if !token.WaitTimeout(timeout) {
+		return errors.New("connect timeout")
+	}
+	if err := token.Error(); err != nil {
+		return err
+	}
+	conn.Disconnect(250)
+	return nil
+}
+
+func newClientOptions(conf Broker) (*mqtt.ClientOptions, error) {
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(conf.Server)
+	opts.SetUsername(conf.Username)
+	opts.SetPassword(conf.Password)
+
+	if conf.CACert != "" || conf.TLSCert != "" || conf.TLSKey != "" {
+		tlsconfig, err := newTLSConfig(conf.CACert, conf.TLSCert, conf.TLSKey)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsconfig)
+	}
+
+	return opts, nil
+}
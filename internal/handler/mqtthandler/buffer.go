@@ -0,0 +1,299 @@
+package mqtthandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+const (
+	bufferKeyTempl        = "mqtt:buffer:%d"
+	bufferLockKeyTempl    = "mqtt:buffer:%d:lock"
+	bufferApplicationsKey = "mqtt:buffer:applications"
+	bufferLockTTL         = time.Minute
+)
+
+// DefaultBufferReplayInterval defines the interval in which the buffered
+// events are published when ReplayInterval is not set.
+const DefaultBufferReplayInterval = 10 * time.Second
+
+// BufferConfig defines the buffering of the events which could not be
+// published to the (primary) broker, e.g. during a broker outage.
+type BufferConfig struct {
+	// TTL defines how long the events are buffered, unless the application
+	// defines its own TTL (see storage.Application.MQTTBufferTTL). 0
+	// disables the buffering.
+	TTL time.Duration
+
+	// MaxEvents defines the max. number of buffered events per application,
+	// when exceeded the oldest events are dropped (0 = unlimited).
+	MaxEvents int
+
+	// ReplayInterval defines the interval in which the buffered events are
+	// published while connected (0 = DefaultBufferReplayInterval).
+	ReplayInterval time.Duration
+}
+
+var bufferConfig BufferConfig
+
+// SetBufferConfig sets the buffer configuration. This must be called before
+// creating the handler.
+func SetBufferConfig(c BufferConfig) {
+	bufferConfig = c
+}
+
+// bufferedEvent contains an event buffered for the primary broker.
+type bufferedEvent struct {
+	Event     string    `json:"event"`
+	Topic     string    `json:"topic"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// expiresAt returns the expiration time of the event. Events buffered
+// without expiration time expire after the given TTL.
+func (e bufferedEvent) expiresAt(ttl time.Duration) time.Time {
+	if e.ExpiresAt.IsZero() {
+		return e.CreatedAt.Add(ttl)
+	}
+	return e.ExpiresAt
+}
+
+// removeBufferApplicationScript removes the application from the set of
+// applications with buffered events, when its buffer is empty.
+var removeBufferApplicationScript = redis.NewScript(2, `
+	if redis.call("LLEN", KEYS[1]) == 0 then
+		redis.call("SREM", KEYS[2], ARGV[1])
+	end
+	return 0
+`)
+
+// publishApplicationEvent publishes the given event of the given
+// application. When buffering is enabled and the event can not be published
+// to the primary broker, the event is buffered until the connection has
+// been restored (see replayLoop). While events are buffered for the
+// application, new events are appended to the buffer so that the events
+// are published in order. The bridge brokers always receive the events
+// directly.
+func (h *MQTTHandler) publishApplicationEvent(applicationID int64, event, topic string, b []byte) error {
+	if h.buffer.TTL == 0 {
+		return h.publish(event, topic, b)
+	}
+
+	n, err := bufferLen(applicationID)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		err := h.publish(event, topic, b)
+		if err == nil {
+			return nil
+		}
+		log.WithFields(log.Fields{
+			"application_id": applicationID,
+			"topic":          topic,
+		}).Warningf("handler/mqtt: publish error, buffering event: %s", err)
+	} else {
+		h.publishTo(nil, h.bridges, event, topic, b)
+	}
+
+	ttl, err := h.applicationBufferTTL(applicationID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return h.bufferEvent(applicationID, ttl, bufferedEvent{
+		Event:     event,
+		Topic:     topic,
+		Payload:   b,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+}
+
+// applicationBufferTTL returns the buffer TTL of the given application, which
+// overrides the configured TTL when set.
+func (h *MQTTHandler) applicationBufferTTL(applicationID int64) (time.Duration, error) {
+	app, err := storage.GetApplication(common.DB, applicationID)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return h.buffer.TTL, nil
+		}
+		return 0, errors.Wrap(err, "get application error")
+	}
+	if app.MQTTBufferTTL > 0 {
+		return time.Duration(app.MQTTBufferTTL) * time.Second, nil
+	}
+	return h.buffer.TTL, nil
+}
+
+// bufferEvent appends the given event to the buffer of the application,
+// which expires after the given TTL.
+func (h *MQTTHandler) bufferEvent(applicationID int64, ttl time.Duration, e bufferedEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal buffered event error")
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(bufferKeyTempl, applicationID)
+
+	// the application is removed from the set of applications with
+	// buffered events when its (expired) buffer is replayed
+	c.Send("MULTI")
+	c.Send("RPUSH", key, b)
+	if h.buffer.MaxEvents > 0 {
+		c.Send("LTRIM", key, -h.buffer.MaxEvents, -1)
+	}
+	c.Send("PEXPIRE", key, int64(ttl/time.Millisecond))
+	c.Send("SADD", common.RedisKey(bufferApplicationsKey), applicationID)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "buffer event error")
+	}
+	return nil
+}
+
+// bufferLen returns the number of buffered events of the given application.
+func bufferLen(applicationID int64) (int, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	n, err := redis.Int(c.Do("LLEN", common.RedisKey(bufferKeyTempl, applicationID)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get buffer length error")
+	}
+	return n, nil
+}
+
+// replayLoop publishes the buffered events every replay interval while
+// connected to the primary broker, until the handler is closed. Next to
+// replaying the buffers on reconnect, this publishes the buffers of which
+// an event could not be published while the connection was not lost, and
+// the events buffered by other instances (e.g. which lost their connection
+// to the broker).
+func (h *MQTTHandler) replayLoop() {
+	interval := h.buffer.ReplayInterval
+	if interval == 0 {
+		interval = DefaultBufferReplayInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			if h.primary.conn.IsConnected() {
+				h.replayBuffers()
+			}
+		}
+	}
+}
+
+// replayBuffers publishes the buffered events of all applications to the
+// primary broker, in order. Expired events are dropped. It stops when
+// publishing fails (e.g. the connection has been lost again), the remaining
+// events are published by the next replay.
+func (h *MQTTHandler) replayBuffers() {
+	if h.buffer.TTL == 0 {
+		return
+	}
+
+	c := common.RedisPool.Get()
+	ids, err := redis.Strings(c.Do("SMEMBERS", common.RedisKey(bufferApplicationsKey)))
+	c.Close()
+	if err != nil {
+		log.Errorf("handler/mqtt: get buffered applications error: %s", err)
+		return
+	}
+
+	for _, idStr := range ids {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Errorf("handler/mqtt: invalid buffered application id: %s", idStr)
+			continue
+		}
+
+		n, err := h.replayBuffer(id)
+		if n > 0 {
+			log.WithFields(log.Fields{
+				"application_id": id,
+				"count":          n,
+			}).Info("handler/mqtt: buffered events published")
+		}
+		if err != nil {
+			log.WithField("application_id", id).Errorf("handler/mqtt: publish buffered events error: %s", err)
+			return
+		}
+	}
+}
+
+// replayBuffer publishes the buffered events of the given application and
+// returns the number of published events. The buffer is locked while
+// publishing, so that only one instance publishes the events.
+func (h *MQTTHandler) replayBuffer(applicationID int64) (int, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(bufferKeyTempl, applicationID)
+	lockKey := common.RedisKey(bufferLockKeyTempl, applicationID)
+
+	_, err := redis.String(c.Do("SET", lockKey, "lock", "PX", int64(bufferLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the buffer is being published by an other instance
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "acquire buffer lock error")
+	}
+	defer c.Do("DEL", lockKey)
+
+	var count int
+	for {
+		b, err := redis.Bytes(c.Do("LINDEX", key, 0))
+		if err != nil {
+			if err == redis.ErrNil {
+				break
+			}
+			return count, errors.Wrap(err, "get buffered event error")
+		}
+
+		var e bufferedEvent
+		if err := json.Unmarshal(b, &e); err != nil {
+			log.WithField("application_id", applicationID).Errorf("handler/mqtt: unmarshal buffered event error: %s", err)
+		} else if e.expiresAt(h.buffer.TTL).After(time.Now()) {
+			if err := h.publishTo(h.primary, nil, e.Event, e.Topic, e.Payload); err != nil {
+				return count, fmt.Errorf("publish error: %s", err)
+			}
+			count++
+		}
+
+		// the event is only removed after it has been published, so that
+		// new events are buffered until the buffer is empty
+		if _, err := c.Do("LPOP", key); err != nil {
+			return count, errors.Wrap(err, "remove buffered event error")
+		}
+		if _, err := c.Do("PEXPIRE", lockKey, int64(bufferLockTTL/time.Millisecond)); err != nil {
+			return count, errors.Wrap(err, "refresh buffer lock error")
+		}
+	}
+
+	if _, err := removeBufferApplicationScript.Do(c, key, common.RedisKey(bufferApplicationsKey), applicationID); err != nil {
+		return count, errors.Wrap(err, "remove buffered application error")
+	}
+	return count, nil
+}
@@ -0,0 +1,55 @@
+package mqtthandler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+)
+
+// Compression types. When compression is enabled, the compression type is
+// appended to the topic of the event (e.g. application/1/node/0102030405060708/rx/gzip)
+// so that subscribers can distinguish compressed from uncompressed
+// payloads.
+const (
+	NoCompression   = ""
+	GzipCompression = "gzip"
+)
+
+// ValidateCompression returns an error when the given compression type is
+// not supported.
+func ValidateCompression(compression string) error {
+	switch compression {
+	case NoCompression, GzipCompression:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression type: %s (supported: %s)", compression, GzipCompression)
+	}
+}
+
+// compressedTopic returns the topic for the given compression type.
+func compressedTopic(topic, compression string) string {
+	if compression == NoCompression {
+		return topic
+	}
+	return topic + "/" + compression
+}
+
+// compress compresses the given payload using the given compression type.
+func compress(compression string, b []byte) ([]byte, error) {
+	switch compression {
+	case NoCompression:
+		return b, nil
+	case GzipCompression:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(b); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, ValidateCompression(compression)
+	}
+}
@@ -6,10 +6,9 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"regexp"
-	"strconv"
 	"sync"
 	"time"
 
@@ -17,48 +16,77 @@ import (
 
 	"github.com/brocaar/lora-app-server/internal/common"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/garyburd/redigo/redis"
 )
 
-const txTopic = "application/+/node/+/tx"
 const downlinkLockTTL = time.Millisecond * 100
 
-var txTopicRegex = regexp.MustCompile(`application/(\w+)/node/(\w+)/tx`)
+var eventMarshaler marshaler.Marshaler = marshaler.JSONMarshaler{}
+
+// SetMarshaler sets the marshaler (see the marshaler package) used to encode
+// the published events. The downlink payloads are always JSON encoded. This
+// must be called before creating the handler.
+func SetMarshaler(t string) error {
+	m, err := marshaler.New(t)
+	if err != nil {
+		return err
+	}
+	eventMarshaler = m
+	return nil
+}
 
 // MQTTHandler implements a MQTT handler for sending and receiving data by
-// an application.
+// an application. Next to the primary broker (used for publishing and
+// receiving data), the events can be published to additional (bridge)
+// brokers.
 type MQTTHandler struct {
 	conn         mqtt.Client
+	primary      *broker
+	bridges      []*broker
 	dataDownChan chan handler.DataDownPayload
 	wg           sync.WaitGroup
 	redisPool    *redis.Pool
+	topics       *topicSet
+	publishOpts  map[string]PublishOptions
+	buffer       BufferConfig
+	marshaler    marshaler.Marshaler
+	done         chan struct{}
 }
 
-// NewHandler creates a new MQTTHandler.
-func NewHandler(server, username, password, cafile string) (handler.Handler, error) {
+// NewHandler creates a new MQTTHandler connecting to the given (primary)
+// broker. The given bridge brokers are only used for publishing events,
+// failing to publish to these brokers does not fail the publication of the
+// event. Each broker has its own compression setting. The topics are
+// rendered using the templates set by SetTopicTemplates and published with
+// the options set by SetPublishOptions. Events which can not be published
+// to the primary broker are buffered according to SetBufferConfig.
+func NewHandler(conf Broker, bridges ...Broker) (handler.Handler, error) {
 	h := MQTTHandler{
 		dataDownChan: make(chan handler.DataDownPayload),
+		topics:       topics,
+		publishOpts:  publishOptions,
+		buffer:       bufferConfig,
+		marshaler:    eventMarshaler,
+		done:         make(chan struct{}),
 	}
 
-	opts := mqtt.NewClientOptions()
-	opts.AddBroker(server)
-	opts.SetUsername(username)
-	opts.SetPassword(password)
-	opts.SetOnConnectHandler(h.onConnected)
-	opts.SetConnectionLostHandler(h.onConnectionLost)
+	if err := ValidateCompression(conf.Compression); err != nil {
+		return nil, fmt.Errorf("handler/mqtt: %s", err)
+	}
 
-	if cafile != "" {
-		tlsconfig, err := newTLSConfig(cafile)
-		if err != nil {
-			log.Fatalf("Error with the mqtt CA certificate: %s", err)
-		} else {
-			opts.SetTLSConfig(tlsconfig)
-		}
+	opts, err := newClientOptions(conf)
+	if err != nil {
+		return nil, fmt.Errorf("handler/mqtt: setup client options error: %s", err)
 	}
+	h.primary = newBroker(conf.Server, conf.Compression)
+	opts.SetOnConnectHandler(h.onConnected)
+	opts.SetConnectionLostHandler(h.onConnectionLost)
 
-	log.WithField("server", server).Info("handler/mqtt: connecting to mqtt broker")
+	log.WithField("server", conf.Server).Info("handler/mqtt: connecting to mqtt broker")
 	h.conn = mqtt.NewClient(opts)
+	h.primary.conn = h.conn
 	for {
 		if token := h.conn.Connect(); token.Wait() && token.Error() != nil {
 			log.Errorf("handler/mqtt: connecting to broker error, will retry in 2s: %s", token.Error())
@@ -67,51 +95,142 @@ func NewHandler(server, username, password, cafile string) (handler.Handler, err
 			break
 		}
 	}
+
+	for _, conf := range bridges {
+		b, err := newBridge(conf)
+		if err != nil {
+			return nil, fmt.Errorf("handler/mqtt: setup bridge %s error: %s", conf.Server, err)
+		}
+		h.bridges = append(h.bridges, b)
+	}
+
+	if h.buffer.TTL != 0 {
+		go h.replayLoop()
+	}
+
 	return &h, nil
 }
 
-func newTLSConfig(cafile string) (*tls.Config, error) {
-	// Import trusted certificates from CAfile.pem.
+// Stats returns the metrics of the primary and bridge brokers.
+func (h *MQTTHandler) Stats() []BrokerStats {
+	out := []BrokerStats{h.primary.Stats()}
+	for _, b := range h.bridges {
+		out = append(out, b.Stats())
+	}
+	return out
+}
+
+// newTLSConfig returns the TLS configuration for the given CA certificate
+// (used to verify the broker certificate) and client certificate and key
+// (for mutual TLS). All files are optional, but the client certificate
+// requires the key and vice versa.
+func newTLSConfig(cafile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := tls.Config{}
 
-	cert, err := ioutil.ReadFile(cafile)
-	if err != nil {
-		log.Errorf("backend: couldn't load cafile: %s", err)
-		return nil, err
+	if cafile != "" {
+		cert, err := ioutil.ReadFile(cafile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca certificate error: %s", err)
+		}
+
+		certpool := x509.NewCertPool()
+		if !certpool.AppendCertsFromPEM(cert) {
+			return nil, fmt.Errorf("no pem encoded certificates found in %s", cafile)
+		}
+		tlsConfig.RootCAs = certpool
 	}
 
-	certpool := x509.NewCertPool()
-	certpool.AppendCertsFromPEM(cert)
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both the client certificate and key must be set")
+		}
 
-	// Create tls.Config with desired tls properties
-	return &tls.Config{
-		// RootCAs = certs used to verify server cert.
-		RootCAs: certpool,
-	}, nil
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate error: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &tlsConfig, nil
 }
 
 // Close stops the handler.
 func (h *MQTTHandler) Close() error {
 	log.Info("handler/mqtt: closing handler")
-	log.WithField("topic", txTopic).Info("handler/mqtt: unsubscribing from tx topic")
-	if token := h.conn.Unsubscribe(txTopic); token.Wait() && token.Error() != nil {
-		return fmt.Errorf("handler/mqtt: unsubscribe from %s error: %s", txTopic, token.Error())
+	log.WithField("topic", h.topics.downlinkFilter).Info("handler/mqtt: unsubscribing from tx topic")
+	if token := h.conn.Unsubscribe(h.topics.downlinkFilter); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("handler/mqtt: unsubscribe from %s error: %s", h.topics.downlinkFilter, token.Error())
 	}
 	log.Info("handler/mqtt: handling last items in queue")
 	h.wg.Wait()
 	close(h.dataDownChan)
+	close(h.done)
+
+	for _, b := range h.bridges {
+		b.close()
+	}
 	return nil
 }
 
+// publish publishes the given payload to the primary and bridge brokers,
+// compressed according to the compression setting of each broker. Only an
+// error publishing to the primary broker is returned.
+func (h *MQTTHandler) publish(event, topic string, b []byte) error {
+	return h.publishTo(h.primary, h.bridges, event, topic, b)
+}
+
+// publishTo publishes the given payload to the given primary (optional)
+// and bridge brokers. Only an error publishing to the primary broker is
+// returned.
+func (h *MQTTHandler) publishTo(primary *broker, bridges []*broker, event, topic string, b []byte) error {
+	opts := getPublishOptions(h.publishOpts, event)
+	payloads := map[string][]byte{
+		NoCompression: b,
+	}
+	publishToBroker := func(br *broker) error {
+		pl, ok := payloads[br.compression]
+		if !ok {
+			var err error
+			pl, err = compress(br.compression, b)
+			if err != nil {
+				return fmt.Errorf("compress payload error: %s", err)
+			}
+			payloads[br.compression] = pl
+		}
+		return br.publish(compressedTopic(topic, br.compression), opts, pl)
+	}
+
+	var err error
+	if primary != nil {
+		err = publishToBroker(primary)
+	}
+
+	for _, bridge := range bridges {
+		if err := publishToBroker(bridge); err != nil {
+			log.WithFields(log.Fields{
+				"server": bridge.server,
+				"topic":  topic,
+			}).Errorf("handler/mqtt: publish to bridge error: %s", err)
+		}
+	}
+
+	return err
+}
+
 // SendDataUp sends a DataUpPayload.
 func (h *MQTTHandler) SendDataUp(payload handler.DataUpPayload) error {
-	b, err := json.Marshal(payload)
+	b, err := h.marshaler.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("handler/mqtt: data-up payload marshal error: %s", err)
 	}
 
-	topic := fmt.Sprintf("application/%d/node/%s/rx", payload.ApplicationID, payload.DevEUI)
+	topic, err := h.topics.uplink.nodeTopic(payload.ApplicationID, payload.ApplicationName, payload.NodeName, payload.DevEUI)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: data-up payload topic error: %s", err)
+	}
 	log.WithField("topic", topic).Info("handler/mqtt: publishing data-up payload")
-	if token := h.conn.Publish(topic, 0, false, b); token.Wait() && token.Error() != nil {
+	if err := h.publishApplicationEvent(payload.ApplicationID, UplinkEvent, topic, b); err != nil {
 		return fmt.Errorf("handler/mqtt: publish data-up payload error: %s", err)
 	}
 	return nil
@@ -119,13 +238,16 @@ func (h *MQTTHandler) SendDataUp(payload handler.DataUpPayload) error {
 
 // SendJoinNotification sends a JoinNotification.
 func (h *MQTTHandler) SendJoinNotification(payload handler.JoinNotification) error {
-	b, err := json.Marshal(payload)
+	b, err := h.marshaler.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("handler/mqtt: join notification marshal error: %s", err)
 	}
-	topic := fmt.Sprintf("application/%d/node/%s/join", payload.ApplicationID, payload.DevEUI)
+	topic, err := h.topics.join.nodeTopic(payload.ApplicationID, payload.ApplicationName, payload.NodeName, payload.DevEUI)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: join notification topic error: %s", err)
+	}
 	log.WithField("topic", topic).Info("handler/mqtt: publishing join notification")
-	if token := h.conn.Publish(topic, 0, false, b); token.Wait() && token.Error() != nil {
+	if err := h.publishApplicationEvent(payload.ApplicationID, JoinEvent, topic, b); err != nil {
 		return fmt.Errorf("handler/mqtt: publish join notification error: %s", err)
 	}
 	return nil
@@ -133,13 +255,16 @@ func (h *MQTTHandler) SendJoinNotification(payload handler.JoinNotification) err
 
 // SendACKNotification sends an ACKNotification.
 func (h *MQTTHandler) SendACKNotification(payload handler.ACKNotification) error {
-	b, err := json.Marshal(payload)
+	b, err := h.marshaler.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("handler/mqtt: ack notification marshal error: %s", err)
 	}
-	topic := fmt.Sprintf("application/%d/node/%s/ack", payload.ApplicationID, payload.DevEUI)
+	topic, err := h.topics.ack.nodeTopic(payload.ApplicationID, payload.ApplicationName, payload.NodeName, payload.DevEUI)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: ack notification topic error: %s", err)
+	}
 	log.WithField("topic", topic).Info("handler/mqtt: publishing ack notification")
-	if token := h.conn.Publish(topic, 0, false, b); token.Wait() && token.Error() != nil {
+	if err := h.publishApplicationEvent(payload.ApplicationID, ACKEvent, topic, b); err != nil {
 		return fmt.Errorf("handler/mqtt: publish ack notification error: %s", err)
 	}
 	return nil
@@ -147,18 +272,92 @@ func (h *MQTTHandler) SendACKNotification(payload handler.ACKNotification) error
 
 // SendErrorNotification sends an ErrorNotification.
 func (h *MQTTHandler) SendErrorNotification(payload handler.ErrorNotification) error {
-	b, err := json.Marshal(payload)
+	b, err := h.marshaler.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("handler/mqtt: error notification marshal error: %s", err)
 	}
-	topic := fmt.Sprintf("application/%d/node/%s/error", payload.ApplicationID, payload.DevEUI)
+	topic, err := h.topics.error.nodeTopic(payload.ApplicationID, payload.ApplicationName, payload.NodeName, payload.DevEUI)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: error notification topic error: %s", err)
+	}
 	log.WithField("topic", topic).Info("handler/mqtt: publishing error notification")
-	if token := h.conn.Publish(topic, 0, false, b); token.Wait() && token.Error() != nil {
+	if err := h.publishApplicationEvent(payload.ApplicationID, ErrorEvent, topic, b); err != nil {
 		return fmt.Errorf("handler/mqtt: publish error notification error: %s", err)
 	}
 	return nil
 }
 
+// SendSecurityNotification sends a SecurityNotification.
+func (h *MQTTHandler) SendSecurityNotification(payload handler.SecurityNotification) error {
+	b, err := h.marshaler.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: security notification marshal error: %s", err)
+	}
+	topic, err := h.topics.security.nodeTopic(payload.ApplicationID, payload.ApplicationName, payload.NodeName, payload.DevEUI)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: security notification topic error: %s", err)
+	}
+	log.WithField("topic", topic).Info("handler/mqtt: publishing security notification")
+	if err := h.publishApplicationEvent(payload.ApplicationID, SecurityEvent, topic, b); err != nil {
+		return fmt.Errorf("handler/mqtt: publish security notification error: %s", err)
+	}
+	return nil
+}
+
+// SendProprietaryUp sends a ProprietaryUpPayload.
+func (h *MQTTHandler) SendProprietaryUp(payload handler.ProprietaryUpPayload) error {
+	b, err := h.marshaler.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: proprietary up payload marshal error: %s", err)
+	}
+	topic, err := h.topics.proprietary.applicationTopic(payload.ApplicationID, payload.ApplicationName)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: proprietary up payload topic error: %s", err)
+	}
+	log.WithField("topic", topic).Info("handler/mqtt: publishing proprietary up payload")
+	if err := h.publishApplicationEvent(payload.ApplicationID, ProprietaryEvent, topic, b); err != nil {
+		return fmt.Errorf("handler/mqtt: publish proprietary up payload error: %s", err)
+	}
+	return nil
+}
+
+// SendCustomEvent sends a CustomEvent.
+func (h *MQTTHandler) SendCustomEvent(payload handler.CustomEvent) error {
+	b, err := h.marshaler.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: custom event marshal error: %s", err)
+	}
+	topic, err := h.topics.custom.customTopic(payload.ApplicationID, payload.ApplicationName, payload.Name)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: custom event topic error: %s", err)
+	}
+	log.WithField("topic", topic).Info("handler/mqtt: publishing custom event")
+	if err := h.publishApplicationEvent(payload.ApplicationID, CustomEvent, topic, b); err != nil {
+		return fmt.Errorf("handler/mqtt: publish custom event error: %s", err)
+	}
+	return nil
+}
+
+// SendGatewayNotification sends a GatewayNotification.
+func (h *MQTTHandler) SendGatewayNotification(payload handler.GatewayNotification) error {
+	b, err := h.marshaler.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: gateway notification marshal error: %s", err)
+	}
+	topic, err := h.topics.gateway.execute(map[string]interface{}{
+		"MAC":            payload.MAC.String(),
+		"OrganizationID": payload.OrganizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("handler/mqtt: gateway notification topic error: %s", err)
+	}
+	log.WithField("topic", topic).Info("handler/mqtt: publishing gateway notification")
+	if err := h.publish(GatewayEvent, topic, b); err != nil {
+		return fmt.Errorf("handler/mqtt: publish gateway notification error: %s", err)
+	}
+	return nil
+}
+
 // DataDownChan returns the channel containing the received DataDownPayload.
 func (h *MQTTHandler) DataDownChan() chan handler.DataDownPayload {
 	return h.dataDownChan
@@ -170,10 +369,10 @@ func (h *MQTTHandler) txPayloadHandler(c mqtt.Client, msg mqtt.Message) {
 
 	log.WithField("topic", msg.Topic()).Info("handler/mqtt: data-down payload received")
 
-	// get the name of the application and node from the topic
-	match := txTopicRegex.FindStringSubmatch(msg.Topic())
-	if len(match) != 3 {
-		log.WithField("topic", msg.Topic()).Error("handler/mqtt: topic regex match error")
+	// get the application ID and DevEUI from the topic
+	applicationID, devEUI, err := h.topics.parseDownlinkTopic(msg.Topic())
+	if err != nil {
+		log.WithField("topic", msg.Topic()).Errorf("handler/mqtt: parse topic error: %s", err)
 		return
 	}
 
@@ -187,21 +386,8 @@ func (h *MQTTHandler) txPayloadHandler(c mqtt.Client, msg mqtt.Message) {
 	}
 
 	// set ApplicationID and DevEUI from topic
-	var err error
-	pl.ApplicationID, err = strconv.ParseInt(match[1], 10, 64)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"topic": msg.Topic(),
-		}).Errorf("handler/mqtt: parse application id error: %s", err)
-		return
-	}
-
-	if err = pl.DevEUI.UnmarshalText([]byte(match[2])); err != nil {
-		log.WithFields(log.Fields{
-			"topic": msg.Topic(),
-		}).Errorf("handler/mqtt: parse dev_eui error: %s", err)
-		return
-	}
+	pl.ApplicationID = applicationID
+	pl.DevEUI = devEUI
 
 	if pl.FPort == 0 || pl.FPort > 224 {
 		log.WithFields(log.Fields{
@@ -216,7 +402,7 @@ func (h *MQTTHandler) txPayloadHandler(c mqtt.Client, msg mqtt.Message) {
 	// by the application, the first instance receiving the message must lock it,
 	// so that other instances can ignore the message.
 	// As an unique id, the Reference field is used.
-	key := fmt.Sprintf("lora:as:downlink:lock:%d:%s:%s", pl.ApplicationID, pl.DevEUI, pl.Reference)
+	key := common.RedisKey("downlink:lock:%d:%s:%s", pl.ApplicationID, pl.DevEUI, pl.Reference)
 	redisConn := common.RedisPool.Get()
 	defer redisConn.Close()
 
@@ -235,17 +421,21 @@ func (h *MQTTHandler) txPayloadHandler(c mqtt.Client, msg mqtt.Message) {
 
 func (h *MQTTHandler) onConnected(c mqtt.Client) {
 	log.Info("handler/mqtt: connected to mqtt broker")
+	h.primary.onConnected()
 	for {
-		log.WithField("topic", txTopic).Info("handler/mqtt: subscribling to tx topic")
-		if token := h.conn.Subscribe(txTopic, 2, h.txPayloadHandler); token.Wait() && token.Error() != nil {
-			log.WithField("topic", txTopic).Errorf("handler/mqtt: subscribe error: %s", token.Error())
+		log.WithField("topic", h.topics.downlinkFilter).Info("handler/mqtt: subscribling to tx topic")
+		if token := h.conn.Subscribe(h.topics.downlinkFilter, 2, h.txPayloadHandler); token.Wait() && token.Error() != nil {
+			log.WithField("topic", h.topics.downlinkFilter).Errorf("handler/mqtt: subscribe error: %s", token.Error())
 			time.Sleep(time.Second)
 			continue
 		}
-		return
+		break
 	}
+
+	go h.replayBuffers()
 }
 
 func (h *MQTTHandler) onConnectionLost(c mqtt.Client, reason error) {
 	log.Errorf("handler/mqtt: mqtt connection error: %s", reason)
+	h.primary.onConnectionLost()
 }
@@ -1,7 +1,19 @@
 package mqtthandler
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -28,7 +40,7 @@ func TestMQTTHandler(t *testing.T) {
 		test.MustFlushRedis(common.RedisPool)
 
 		Convey("Given a new MQTTHandler", func() {
-			h, err := NewHandler(conf.MQTTServer, conf.MQTTUsername, conf.MQTTPassword, "")
+			h, err := NewHandler(Broker{Server: conf.MQTTServer, Username: conf.MQTTUsername, Password: conf.MQTTPassword})
 			So(err, ShouldBeNil)
 			defer h.Close()
 			time.Sleep(time.Millisecond * 100) // give the backend some time to connect
@@ -143,6 +155,35 @@ func TestMQTTHandler(t *testing.T) {
 				})
 			})
 
+			Convey("Given the MQTT client is subscribed to application/123/node/0102030405060708/security", func() {
+				securityChan := make(chan handler.SecurityNotification)
+				token := c.Subscribe("application/123/node/0102030405060708/security", 0, func(c mqtt.Client, msg mqtt.Message) {
+					var pl handler.SecurityNotification
+					if err := json.Unmarshal(msg.Payload(), &pl); err != nil {
+						t.Fatal(err)
+					}
+					securityChan <- pl
+				})
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+
+				Convey("When sending a security notification (from the handler)", func() {
+					pl := handler.SecurityNotification{
+						ApplicationID:   123,
+						ApplicationName: "test-app",
+						NodeName:        "test-node",
+						DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						Type:            "MIC_FAILURE",
+						Message:         "invalid MIC",
+					}
+					So(h.SendSecurityNotification(pl), ShouldBeNil)
+
+					Convey("Then the same notification is received by the MQTT client", func() {
+						So(<-securityChan, ShouldResemble, pl)
+					})
+				})
+			})
+
 			Convey("Given a DataDownPayload", func() {
 				pl := handler.DataDownPayload{
 					Confirmed: false,
@@ -191,5 +232,502 @@ func TestMQTTHandler(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("Given a new MQTTHandler with a bridge to the same broker", func() {
+			h, err := NewHandler(Broker{Server: conf.MQTTServer, Username: conf.MQTTUsername, Password: conf.MQTTPassword}, Broker{
+				Server:   conf.MQTTServer,
+				Username: conf.MQTTUsername,
+				Password: conf.MQTTPassword,
+			})
+			So(err, ShouldBeNil)
+			defer h.Close()
+			time.Sleep(time.Millisecond * 100) // give the bridge some time to connect
+
+			Convey("Given the MQTT client is subscribed to application/123/node/0102030405060708/rx", func() {
+				dataUpChan := make(chan handler.DataUpPayload, 2)
+				token := c.Subscribe("application/123/node/0102030405060708/rx", 0, func(c mqtt.Client, msg mqtt.Message) {
+					var pl handler.DataUpPayload
+					if err := json.Unmarshal(msg.Payload(), &pl); err != nil {
+						t.Fatal(err)
+					}
+					dataUpChan <- pl
+				})
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+
+				Convey("When sending a DataUpPayload (from the handler)", func() {
+					pl := handler.DataUpPayload{
+						ApplicationID: 123,
+						DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					}
+					So(h.SendDataUp(pl), ShouldBeNil)
+
+					Convey("Then the payload is published by the primary and the bridge connection", func() {
+						So(<-dataUpChan, ShouldResemble, pl)
+						So(<-dataUpChan, ShouldResemble, pl)
+
+						stats := h.(*MQTTHandler).Stats()
+						So(stats, ShouldHaveLength, 2)
+						for _, s := range stats {
+							So(s.Server, ShouldEqual, conf.MQTTServer)
+							So(s.Connected, ShouldBeTrue)
+							So(s.Connects, ShouldEqual, 1)
+							So(s.Published, ShouldEqual, 1)
+							So(s.PublishErrors, ShouldEqual, 0)
+						}
+					})
+				})
+			})
+		})
+
+		Convey("Given a new MQTTHandler with a gzip compressed bridge to the same broker", func() {
+			h, err := NewHandler(Broker{Server: conf.MQTTServer, Username: conf.MQTTUsername, Password: conf.MQTTPassword}, Broker{
+				Server:      conf.MQTTServer,
+				Username:    conf.MQTTUsername,
+				Password:    conf.MQTTPassword,
+				Compression: GzipCompression,
+			})
+			So(err, ShouldBeNil)
+			defer h.Close()
+			time.Sleep(time.Millisecond * 100) // give the bridge some time to connect
+
+			Convey("Given the MQTT client is subscribed to application/123/node/0102030405060708/rx/gzip", func() {
+				dataUpChan := make(chan handler.DataUpPayload, 1)
+				token := c.Subscribe("application/123/node/0102030405060708/rx/gzip", 0, func(c mqtt.Client, msg mqtt.Message) {
+					r, err := gzip.NewReader(bytes.NewReader(msg.Payload()))
+					if err != nil {
+						t.Fatal(err)
+					}
+					var pl handler.DataUpPayload
+					if err := json.NewDecoder(r).Decode(&pl); err != nil {
+						t.Fatal(err)
+					}
+					dataUpChan <- pl
+				})
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+
+				Convey("When sending a DataUpPayload (from the handler)", func() {
+					pl := handler.DataUpPayload{
+						ApplicationID: 123,
+						DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					}
+					So(h.SendDataUp(pl), ShouldBeNil)
+
+					Convey("Then the bridge connection publishes the compressed payload", func() {
+						So(<-dataUpChan, ShouldResemble, pl)
+					})
+				})
+			})
+		})
+
+		Convey("Then creating a MQTTHandler with an unsupported compression type returns an error", func() {
+			_, err := NewHandler(Broker{Server: conf.MQTTServer, Username: conf.MQTTUsername, Password: conf.MQTTPassword, Compression: "zstd"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestApplicationTopicAllowed(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Topic    string
+			Access   int
+			Expected bool
+		}{
+			{"application/1/node/0102030405060708/rx", AccessRead, true},
+			{"application/1/node/0102030405060708/rx/gzip", AccessRead, true},
+			{"application/1/#", AccessSubscribe, true},
+			{"application/1/node/+/rx", AccessSubscribe, true},
+			{"application/10/node/0102030405060708/rx", AccessRead, false},
+			{"application/+/node/+/rx", AccessSubscribe, false},
+			{"#", AccessSubscribe, false},
+			{"application/1/node/0102030405060708/tx", AccessWrite, true},
+			{"application/1/node/+/tx", AccessWrite, false},
+			{"application/2/node/0102030405060708/tx", AccessWrite, false},
+			{"application/1/node/0102030405060708/rx", AccessWrite, false},
+			{"application/1/node/0102030405060708/tx", AccessReadWrite, true},
+			{"application/1/node/0102030405060708/rx", AccessReadWrite, false},
+			{"application/1/node/0102030405060708/rx", 0, false},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Topic, func() {
+				So(ApplicationTopicAllowed(1, test.Topic, test.Access), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestTopicTemplates(t *testing.T) {
+	Convey("Given the default topic templates", t, func() {
+		ts, err := parseTopicTemplates(DefaultTopicTemplates)
+		So(err, ShouldBeNil)
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then the node topics are rendered as documented", func() {
+			topic, err := ts.uplink.nodeTopic(1, "test-app", "test-node", devEUI)
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "application/1/node/0102030405060708/rx")
+
+			topic, err = ts.security.nodeTopic(1, "test-app", "test-node", devEUI)
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "application/1/node/0102030405060708/security")
+		})
+
+		Convey("Then the gateway topic is rendered as documented", func() {
+			topic, err := ts.gateway.execute(map[string]interface{}{"MAC": "0102030405060708", "OrganizationID": int64(1)})
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "gateway/0102030405060708/event")
+		})
+
+		Convey("Then the downlink topic filter and application prefix are set", func() {
+			So(ts.downlinkFilter, ShouldEqual, "application/+/node/+/tx")
+			So(ts.applicationPrefix, ShouldEqual, "application/"+markerApplicationID+"/")
+
+			appID, eui, err := ts.parseDownlinkTopic("application/1/node/0102030405060708/tx")
+			So(err, ShouldBeNil)
+			So(appID, ShouldEqual, 1)
+			So(eui, ShouldEqual, devEUI)
+
+			_, _, err = ts.parseDownlinkTopic("application/1/node/0102030405060708/rx")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given topic templates with a tenant prefix", t, func() {
+		templates := TopicTemplates{
+			Uplink:      "tenant/{{ .ApplicationID }}/devices/{{ .DevEUI }}/up",
+			Join:        "tenant/{{ .ApplicationID }}/devices/{{ .DevEUI }}/join",
+			ACK:         "tenant/{{ .ApplicationID }}/devices/{{ .DevEUI }}/ack",
+			Error:       "tenant/{{ .ApplicationID }}/devices/{{ .DevEUI }}/error",
+			Security:    "tenant/{{ .ApplicationID }}/devices/{{ .DevEUI }}/security",
+			Proprietary: "tenant/{{ .ApplicationID }}/proprietary",
+			Custom:      "tenant/{{ .ApplicationID }}/events/{{ .EventName }}",
+			Gateway:     "tenant/gateways/{{ .OrganizationID }}/{{ .MAC }}",
+			Downlink:    "tenant/{{ .ApplicationID }}/devices/{{ .DevEUI }}/down",
+		}
+		ts, err := parseTopicTemplates(templates)
+		So(err, ShouldBeNil)
+
+		Convey("Then the topics are rendered using these templates", func() {
+			topic, err := ts.uplink.nodeTopic(1, "test-app", "test-node", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "tenant/1/devices/0102030405060708/up")
+
+			topic, err = ts.custom.customTopic(1, "test-app", "door_opened")
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "tenant/1/events/door_opened")
+		})
+
+		Convey("Then the downlink topic is parsed using this template", func() {
+			So(ts.downlinkFilter, ShouldEqual, "tenant/+/devices/+/down")
+
+			appID, _, err := ts.parseDownlinkTopic("tenant/2/devices/0102030405060708/down")
+			So(err, ShouldBeNil)
+			So(appID, ShouldEqual, 2)
+		})
+
+		Convey("Then the application prefix is set", func() {
+			So(ts.applicationPrefix, ShouldEqual, "tenant/"+markerApplicationID+"/")
+		})
+
+		Convey("When the application topics do not share a prefix containing the application ID", func() {
+			templates.Proprietary = "proprietary/{{ .ApplicationID }}"
+			ts, err := parseTopicTemplates(templates)
+			So(err, ShouldBeNil)
+
+			Convey("Then the application prefix is empty", func() {
+				So(ts.applicationPrefix, ShouldEqual, "")
+			})
+		})
+	})
+
+	Convey("Given a clean database with an application and topic templates using the application UUID", t, func() {
+		conf := test.GetConfig()
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		templates := DefaultTopicTemplates
+		templates.Uplink = "application/{{ .ApplicationUUID }}/node/{{ .DevEUI }}/rx"
+		templates.Proprietary = "application/{{ .ApplicationUUID }}/proprietary/rx"
+		templates.Downlink = "application/{{ .ApplicationUUID }}/node/{{ .DevEUI }}/tx"
+		ts, err := parseTopicTemplates(templates)
+		So(err, ShouldBeNil)
+
+		Convey("Then the topics are rendered with the application UUID", func() {
+			topic, err := ts.uplink.nodeTopic(app.ID, app.Name, "test-node", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "application/"+app.UUID.String()+"/node/0102030405060708/rx")
+
+			topic, err = ts.proprietary.applicationTopic(app.ID, app.Name)
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, "application/"+app.UUID.String()+"/proprietary/rx")
+		})
+
+		Convey("Then the application ID is looked up from the downlink topic", func() {
+			appID, devEUI, err := ts.parseDownlinkTopic("application/" + app.UUID.String() + "/node/0102030405060708/tx")
+			So(err, ShouldBeNil)
+			So(appID, ShouldEqual, app.ID)
+			So(devEUI, ShouldEqual, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+
+			_, _, err = ts.parseDownlinkTopic("application/1/node/0102030405060708/tx")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then the application prefix is empty", func() {
+			So(ts.applicationPrefix, ShouldEqual, "")
+		})
+	})
+
+	Convey("Given a set of invalid topic templates", t, func() {
+		tests := []struct {
+			Name     string
+			Modifier func(*TopicTemplates)
+		}{
+			{"empty template", func(t *TopicTemplates) { t.Uplink = "" }},
+			{"invalid syntax", func(t *TopicTemplates) { t.Uplink = "application/{{ .ApplicationID" }},
+			{"unknown field", func(t *TopicTemplates) { t.Join = "application/{{ .Foo }}/join" }},
+			{"node field in gateway template", func(t *TopicTemplates) { t.Gateway = "gateway/{{ .DevEUI }}" }},
+			{"downlink without deveui", func(t *TopicTemplates) { t.Downlink = "application/{{ .ApplicationID }}/tx" }},
+			{"downlink with partial level", func(t *TopicTemplates) { t.Downlink = "application/app-{{ .ApplicationID }}/node/{{ .DevEUI }}/tx" }},
+			{"downlink with application name", func(t *TopicTemplates) { t.Downlink = "{{ .ApplicationName }}/{{ .ApplicationID }}/{{ .DevEUI }}" }},
+			{"downlink with application id and uuid", func(t *TopicTemplates) { t.Downlink = "{{ .ApplicationUUID }}/{{ .ApplicationID }}/{{ .DevEUI }}" }},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				templates := DefaultTopicTemplates
+				test.Modifier(&templates)
+				_, err := parseTopicTemplates(templates)
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+}
+
+func TestPublishOptions(t *testing.T) {
+	Convey("Given publish options for the uplink and gateway events", t, func() {
+		So(SetPublishOptions(map[string]PublishOptions{
+			UplinkEvent:  {QoS: 1},
+			GatewayEvent: {QoS: 2, Retained: true},
+		}), ShouldBeNil)
+		defer SetPublishOptions(nil)
+
+		Convey("Then the configured options are returned for these events", func() {
+			So(getPublishOptions(publishOptions, UplinkEvent), ShouldResemble, PublishOptions{QoS: 1})
+			So(getPublishOptions(publishOptions, GatewayEvent), ShouldResemble, PublishOptions{QoS: 2, Retained: true})
+		})
+
+		Convey("Then the default options are returned for the other events", func() {
+			So(getPublishOptions(publishOptions, JoinEvent), ShouldResemble, DefaultPublishOptions)
+		})
+	})
+
+	Convey("Given a set of invalid publish options", t, func() {
+		tests := []struct {
+			Name    string
+			Options map[string]PublishOptions
+		}{
+			{"unknown event type", map[string]PublishOptions{"downlink": {QoS: 1}}},
+			{"invalid qos", map[string]PublishOptions{UplinkEvent: {QoS: 3}}},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(SetPublishOptions(test.Options), ShouldNotBeNil)
+			})
+		}
+	})
+}
+
+func TestBuffer(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a MQTT client, a clean Redis database and a handler with buffering enabled which is not connected", t, func() {
+		opts := mqtt.NewClientOptions().AddBroker(conf.MQTTServer).SetUsername(conf.MQTTUsername).SetPassword(conf.MQTTPassword)
+		c := mqtt.NewClient(opts)
+		token := c.Connect()
+		token.Wait()
+		So(token.Error(), ShouldBeNil)
+		defer c.Disconnect(0)
+
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		h := MQTTHandler{
+			primary:     newBroker(conf.MQTTServer, NoCompression),
+			topics:      topics,
+			publishOpts: publishOptions,
+			buffer:      BufferConfig{TTL: time.Hour, MaxEvents: 2, ReplayInterval: 10 * time.Millisecond},
+			done:        make(chan struct{}),
+		}
+		h.primary.conn = mqtt.NewClient(mqtt.NewClientOptions().AddBroker(conf.MQTTServer).SetUsername(conf.MQTTUsername).SetPassword(conf.MQTTPassword))
+
+		Convey("When sending three uplinks", func() {
+			for i := 1; i <= 3; i++ {
+				So(h.SendDataUp(handler.DataUpPayload{
+					ApplicationID: 123,
+					DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					FCnt:          uint32(i),
+				}), ShouldBeNil)
+			}
+
+			Convey("Then the last two uplinks are buffered", func() {
+				n, err := bufferLen(123)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 2)
+			})
+
+			Convey("When the handler is connected and the buffers are replayed", func() {
+				fCntChan := make(chan uint32, 10)
+				token := c.Subscribe("application/123/node/0102030405060708/rx", 0, func(c mqtt.Client, msg mqtt.Message) {
+					var pl handler.DataUpPayload
+					if err := json.Unmarshal(msg.Payload(), &pl); err != nil {
+						t.Fatal(err)
+					}
+					fCntChan <- pl.FCnt
+				})
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+
+				token = h.primary.conn.Connect()
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+				defer h.primary.conn.Disconnect(0)
+
+				h.replayBuffers()
+
+				Convey("Then the buffered uplinks are published in order", func() {
+					So(<-fCntChan, ShouldEqual, 2)
+					So(<-fCntChan, ShouldEqual, 3)
+
+					n, err := bufferLen(123)
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, 0)
+				})
+			})
+
+			Convey("When the handler is connected without reconnect", func() {
+				fCntChan := make(chan uint32, 10)
+				token := c.Subscribe("application/123/node/0102030405060708/rx", 0, func(c mqtt.Client, msg mqtt.Message) {
+					var pl handler.DataUpPayload
+					if err := json.Unmarshal(msg.Payload(), &pl); err != nil {
+						t.Fatal(err)
+					}
+					fCntChan <- pl.FCnt
+				})
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+
+				token = h.primary.conn.Connect()
+				token.Wait()
+				So(token.Error(), ShouldBeNil)
+				defer h.primary.conn.Disconnect(0)
+
+				go h.replayLoop()
+				defer close(h.done)
+
+				Convey("Then the buffered uplinks are published by the replay loop", func() {
+					So(<-fCntChan, ShouldEqual, 2)
+					So(<-fCntChan, ShouldEqual, 3)
+				})
+			})
+		})
+
+		Convey("Given an application with a MQTT buffer TTL", func() {
+			org := storage.Organization{
+				Name: "test-org",
+			}
+			So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+			app := storage.Application{
+				OrganizationID: org.ID,
+				Name:           "test-app",
+				MQTTBufferTTL:  60,
+			}
+			So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+			Convey("Then the events of the application are buffered with this TTL", func() {
+				ttl, err := h.applicationBufferTTL(app.ID)
+				So(err, ShouldBeNil)
+				So(ttl, ShouldEqual, time.Minute)
+			})
+
+			Convey("Then the events of other applications are buffered with the configured TTL", func() {
+				ttl, err := h.applicationBufferTTL(app.ID + 1)
+				So(err, ShouldBeNil)
+				So(ttl, ShouldEqual, time.Hour)
+			})
+		})
+	})
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	Convey("Given a self-signed certificate and key", t, func() {
+		dir, err := ioutil.TempDir("", "mqtthandler")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		So(err, ShouldBeNil)
+		tmpl := x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "lora-app-server"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+			IsCA:         true,
+			KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+		So(err, ShouldBeNil)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		So(err, ShouldBeNil)
+
+		certFile := filepath.Join(dir, "cert.pem")
+		keyFile := filepath.Join(dir, "key.pem")
+		So(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600), ShouldBeNil)
+		So(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600), ShouldBeNil)
+
+		Convey("Then a config with CA certificate and client certificate can be created", func() {
+			conf, err := newTLSConfig(certFile, certFile, keyFile)
+			So(err, ShouldBeNil)
+			So(conf.RootCAs, ShouldNotBeNil)
+			So(conf.Certificates, ShouldHaveLength, 1)
+		})
+
+		Convey("Then a config with only a client certificate can be created", func() {
+			conf, err := newTLSConfig("", certFile, keyFile)
+			So(err, ShouldBeNil)
+			So(conf.RootCAs, ShouldBeNil)
+			So(conf.Certificates, ShouldHaveLength, 1)
+		})
+
+		Convey("Then a client certificate without key returns an error", func() {
+			_, err := newTLSConfig("", certFile, "")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then a CA certificate file without certificates returns an error", func() {
+			_, err := newTLSConfig(keyFile, "", "")
+			So(err, ShouldNotBeNil)
+		})
 	})
 }
@@ -0,0 +1,72 @@
+package mqtthandler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Event types, used to configure the publish options per event type.
+const (
+	UplinkEvent      = "uplink"
+	JoinEvent        = "join"
+	ACKEvent         = "ack"
+	ErrorEvent       = "error"
+	SecurityEvent    = "security"
+	ProprietaryEvent = "proprietary"
+	CustomEvent      = "custom"
+	GatewayEvent     = "gateway"
+)
+
+// EventTypes contains all event types.
+var EventTypes = []string{UplinkEvent, JoinEvent, ACKEvent, ErrorEvent, SecurityEvent, ProprietaryEvent, CustomEvent, GatewayEvent}
+
+// PublishOptions defines the QoS and retained flag with which the events
+// are published.
+type PublishOptions struct {
+	QoS      byte
+	Retained bool
+}
+
+// DefaultPublishOptions contains the publish options of the event types
+// without configured publish options (QoS 0, not retained).
+var DefaultPublishOptions = PublishOptions{}
+
+var publishOptions = map[string]PublishOptions{}
+
+// SetPublishOptions sets the publish options per event type. Event types
+// which are not set are published using DefaultPublishOptions. This must be
+// called before creating the handler.
+func SetPublishOptions(opts map[string]PublishOptions) error {
+	for event, o := range opts {
+		if err := ValidateEventType(event); err != nil {
+			return err
+		}
+		if o.QoS > 2 {
+			return fmt.Errorf("invalid qos %d for %s events: qos must be 0, 1 or 2", o.QoS, event)
+		}
+	}
+
+	publishOptions = make(map[string]PublishOptions)
+	for event, o := range opts {
+		publishOptions[event] = o
+	}
+	return nil
+}
+
+// ValidateEventType returns an error when the given event type is unknown.
+func ValidateEventType(event string) error {
+	for _, e := range EventTypes {
+		if e == event {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown event type: %s (supported: %s)", event, strings.Join(EventTypes, ", "))
+}
+
+// getPublishOptions returns the publish options of the given event type.
+func getPublishOptions(opts map[string]PublishOptions, event string) PublishOptions {
+	if o, ok := opts[event]; ok {
+		return o
+	}
+	return DefaultPublishOptions
+}
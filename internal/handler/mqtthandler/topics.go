@@ -0,0 +1,401 @@
+package mqtthandler
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// TopicTemplates contains the (Go text/template) templates of the MQTT
+// topics. The node event templates can use .ApplicationID,
+// .ApplicationUUID, .ApplicationName, .NodeName, .DevEUI and .AppEUI, the
+// proprietary uplink template .ApplicationID, .ApplicationUUID and
+// .ApplicationName, the custom event template .ApplicationID,
+// .ApplicationUUID, .ApplicationName and .EventName, the gateway template
+// .MAC and .OrganizationID. The
+// downlink template can only use .ApplicationID (or .ApplicationUUID) and
+// .DevEUI, each as a complete topic level, as these are parsed from the
+// topic of the received downlink payloads.
+type TopicTemplates struct {
+	Uplink      string
+	Join        string
+	ACK         string
+	Error       string
+	Security    string
+	Proprietary string
+	Custom      string
+	Gateway     string
+	Downlink    string
+}
+
+// DefaultTopicTemplates contains the default topic templates.
+var DefaultTopicTemplates = TopicTemplates{
+	Uplink:      "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/rx",
+	Join:        "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/join",
+	ACK:         "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/ack",
+	Error:       "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/error",
+	Security:    "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/security",
+	Proprietary: "application/{{ .ApplicationID }}/proprietary/rx",
+	Custom:      "application/{{ .ApplicationID }}/event/{{ .EventName }}",
+	Gateway:     "gateway/{{ .MAC }}/event",
+	Downlink:    "application/{{ .ApplicationID }}/node/{{ .DevEUI }}/tx",
+}
+
+// topicTemplate holds a parsed topic template.
+type topicTemplate struct {
+	tmpl                *template.Template
+	usesAppEUI          bool
+	usesApplicationUUID bool
+}
+
+// topicSet holds the parsed topic templates.
+type topicSet struct {
+	uplink      *topicTemplate
+	join        *topicTemplate
+	ack         *topicTemplate
+	error       *topicTemplate
+	security    *topicTemplate
+	proprietary *topicTemplate
+	custom      *topicTemplate
+	gateway     *topicTemplate
+
+	// downlinkFilter contains the topic filter to subscribe to the downlink
+	// payloads and downlinkRegexp the regexp to parse the received topics
+	downlinkFilter      string
+	downlinkRegexp      *regexp.Regexp
+	downlinkAppIDGroup  int
+	downlinkDevEUIGroup int
+
+	// downlinkAppUUID is set when the downlink topic contains the
+	// application UUID instead of the application ID
+	downlinkAppUUID bool
+
+	// applicationPrefix contains the topic prefix of the application topics
+	// with markerApplicationID as placeholder (empty when the topics can
+	// not be isolated per application)
+	applicationPrefix string
+}
+
+// markers are used as placeholders to render the templates for parsing the
+// downlink topic and determining the topic prefix of the application.
+const (
+	markerApplicationID   = "\x00ApplicationID\x00"
+	markerApplicationUUID = "\x00ApplicationUUID\x00"
+	markerApplicationName = "\x00ApplicationName\x00"
+	markerNodeName        = "\x00NodeName\x00"
+	markerDevEUI          = "\x00DevEUI\x00"
+	markerAppEUI          = "\x00AppEUI\x00"
+	markerMAC             = "\x00MAC\x00"
+	markerOrganizationID  = "\x00OrganizationID\x00"
+	markerEventName       = "\x00EventName\x00"
+)
+
+var topics = mustParseTopicTemplates(DefaultTopicTemplates)
+
+// SetTopicTemplates sets the templates of the MQTT topics. This must be
+// called before creating the handler.
+func SetTopicTemplates(t TopicTemplates) error {
+	ts, err := parseTopicTemplates(t)
+	if err != nil {
+		return err
+	}
+	topics = ts
+	return nil
+}
+
+func mustParseTopicTemplates(t TopicTemplates) *topicSet {
+	ts, err := parseTopicTemplates(t)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+func parseTopicTemplates(t TopicTemplates) (*topicSet, error) {
+	var ts topicSet
+	var err error
+
+	nodeData := map[string]interface{}{
+		"ApplicationID":   markerApplicationID,
+		"ApplicationUUID": markerApplicationUUID,
+		"ApplicationName": markerApplicationName,
+		"NodeName":        markerNodeName,
+		"DevEUI":          markerDevEUI,
+		"AppEUI":          markerAppEUI,
+	}
+	proprietaryData := map[string]interface{}{
+		"ApplicationID":   markerApplicationID,
+		"ApplicationUUID": markerApplicationUUID,
+		"ApplicationName": markerApplicationName,
+	}
+	customData := map[string]interface{}{
+		"ApplicationID":   markerApplicationID,
+		"ApplicationUUID": markerApplicationUUID,
+		"ApplicationName": markerApplicationName,
+		"EventName":       markerEventName,
+	}
+	gatewayData := map[string]interface{}{
+		"MAC":            markerMAC,
+		"OrganizationID": markerOrganizationID,
+	}
+
+	// rendered contains the application topics rendered with markers
+	var rendered []string
+	for _, tt := range []struct {
+		name   string
+		text   string
+		data   map[string]interface{}
+		target **topicTemplate
+	}{
+		{"uplink", t.Uplink, nodeData, &ts.uplink},
+		{"join", t.Join, nodeData, &ts.join},
+		{"ack", t.ACK, nodeData, &ts.ack},
+		{"error", t.Error, nodeData, &ts.error},
+		{"security", t.Security, nodeData, &ts.security},
+		{"proprietary", t.Proprietary, proprietaryData, &ts.proprietary},
+		{"custom", t.Custom, customData, &ts.custom},
+		{"gateway", t.Gateway, gatewayData, &ts.gateway},
+	} {
+		if *tt.target, err = parseTopicTemplate(tt.name, tt.text); err != nil {
+			return nil, err
+		}
+		topic, err := (*tt.target).execute(tt.data)
+		if err != nil {
+			return nil, fmt.Errorf("execute %s topic template error: %s", tt.name, err)
+		}
+		if tt.name != "gateway" {
+			rendered = append(rendered, topic)
+		}
+	}
+
+	downlink, err := ts.parseDownlink(t.Downlink)
+	if err != nil {
+		return nil, err
+	}
+	rendered = append(rendered, downlink)
+
+	ts.applicationPrefix = applicationPrefix(rendered)
+	return &ts, nil
+}
+
+func parseTopicTemplate(name, text string) (*topicTemplate, error) {
+	if text == "" {
+		return nil, fmt.Errorf("%s topic template must not be empty", name)
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s topic template error: %s", name, err)
+	}
+	return &topicTemplate{
+		tmpl:                tmpl,
+		usesAppEUI:          strings.Contains(text, "AppEUI"),
+		usesApplicationUUID: strings.Contains(text, "ApplicationUUID"),
+	}, nil
+}
+
+// parseDownlink parses the downlink template into a topic filter (with
+// the application ID or UUID and DevEUI replaced by a single-level wildcard)
+// and a regexp for extracting the application ID or UUID and DevEUI from the
+// topic. It returns the topic rendered with markers.
+func (ts *topicSet) parseDownlink(text string) (string, error) {
+	t, err := parseTopicTemplate("downlink", text)
+	if err != nil {
+		return "", err
+	}
+	topic, err := t.execute(map[string]interface{}{
+		"ApplicationID":   markerApplicationID,
+		"ApplicationUUID": markerApplicationUUID,
+		"DevEUI":          markerDevEUI,
+	})
+	if err != nil {
+		return "", fmt.Errorf("downlink topic template can only use .ApplicationID, .ApplicationUUID and .DevEUI: %s", err)
+	}
+
+	var filter, exprs []string
+	var group int
+	for _, level := range strings.Split(topic, "/") {
+		switch level {
+		case markerApplicationID, markerApplicationUUID, markerDevEUI:
+			group++
+			target := &ts.downlinkAppIDGroup
+			if level == markerDevEUI {
+				target = &ts.downlinkDevEUIGroup
+			}
+			if *target != 0 {
+				return "", fmt.Errorf("downlink topic template must use .ApplicationID (or .ApplicationUUID) and .DevEUI only once")
+			}
+			*target = group
+			if level == markerApplicationUUID {
+				ts.downlinkAppUUID = true
+			}
+			filter = append(filter, "+")
+			exprs = append(exprs, `([^/]+)`)
+		default:
+			if strings.Contains(level, "\x00") {
+				return "", fmt.Errorf("downlink topic template must use .ApplicationID (or .ApplicationUUID) and .DevEUI as complete topic levels")
+			}
+			if strings.ContainsAny(level, "+#") {
+				return "", fmt.Errorf("downlink topic template must not contain wildcards")
+			}
+			filter = append(filter, level)
+			exprs = append(exprs, regexp.QuoteMeta(level))
+		}
+	}
+	if ts.downlinkAppIDGroup == 0 || ts.downlinkDevEUIGroup == 0 {
+		return "", fmt.Errorf("downlink topic template must contain .ApplicationID (or .ApplicationUUID) and .DevEUI")
+	}
+
+	ts.downlinkFilter = strings.Join(filter, "/")
+	ts.downlinkRegexp = regexp.MustCompile("^" + strings.Join(exprs, "/") + "$")
+	return topic, nil
+}
+
+// parseDownlinkTopic returns the application ID and DevEUI of the given
+// downlink topic. When the topic contains the application UUID, the
+// application is looked up by its UUID.
+func (ts *topicSet) parseDownlinkTopic(topic string) (int64, lorawan.EUI64, error) {
+	var devEUI lorawan.EUI64
+
+	match := ts.downlinkRegexp.FindStringSubmatch(topic)
+	if match == nil {
+		return 0, devEUI, fmt.Errorf("topic does not match %s", ts.downlinkFilter)
+	}
+
+	var applicationID int64
+	if ts.downlinkAppUUID {
+		u, err := storage.ParseUUID(match[ts.downlinkAppIDGroup])
+		if err != nil {
+			return 0, devEUI, fmt.Errorf("parse application uuid error: %s", err)
+		}
+		app, err := storage.GetApplicationByUUID(common.DB, u)
+		if err != nil {
+			return 0, devEUI, fmt.Errorf("get application error: %s", err)
+		}
+		applicationID = app.ID
+	} else {
+		var err error
+		applicationID, err = strconv.ParseInt(match[ts.downlinkAppIDGroup], 10, 64)
+		if err != nil {
+			return 0, devEUI, fmt.Errorf("parse application id error: %s", err)
+		}
+	}
+	if err := devEUI.UnmarshalText([]byte(match[ts.downlinkDevEUIGroup])); err != nil {
+		return 0, devEUI, fmt.Errorf("parse dev_eui error: %s", err)
+	}
+	return applicationID, devEUI, nil
+}
+
+// applicationPrefix returns the common prefix (in topic levels) of the
+// given topics, up to the first level containing a marker other than the
+// application ID. An empty string is returned when this prefix does not
+// contain the application ID, meaning the topics of the applications can
+// not be isolated by prefix.
+func applicationPrefix(topics []string) string {
+	var prefix []string
+	for i, topic := range topics {
+		levels := strings.Split(topic, "/")
+		if i == 0 {
+			prefix = levels
+			continue
+		}
+		n := 0
+		for n < len(prefix) && n < len(levels) && prefix[n] == levels[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	for i, level := range prefix {
+		if level != markerApplicationID && strings.Contains(level, "\x00") {
+			prefix = prefix[:i]
+			break
+		}
+	}
+
+	for _, level := range prefix {
+		if level == markerApplicationID {
+			return strings.Join(prefix, "/") + "/"
+		}
+	}
+	return ""
+}
+
+func (t *topicTemplate) execute(data map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// applicationTopic returns the topic for an event of the given application.
+func (t *topicTemplate) applicationTopic(applicationID int64, applicationName string) (string, error) {
+	data := map[string]interface{}{
+		"ApplicationID":   applicationID,
+		"ApplicationUUID": "",
+		"ApplicationName": applicationName,
+	}
+	if err := t.setApplicationUUID(data, applicationID); err != nil {
+		return "", err
+	}
+	return t.execute(data)
+}
+
+// customTopic returns the topic for a custom event of the given
+// application.
+func (t *topicTemplate) customTopic(applicationID int64, applicationName, eventName string) (string, error) {
+	data := map[string]interface{}{
+		"ApplicationID":   applicationID,
+		"ApplicationUUID": "",
+		"ApplicationName": applicationName,
+		"EventName":       eventName,
+	}
+	if err := t.setApplicationUUID(data, applicationID); err != nil {
+		return "", err
+	}
+	return t.execute(data)
+}
+
+// nodeTopic returns the topic for an event of the given node.
+func (t *topicTemplate) nodeTopic(applicationID int64, applicationName, nodeName string, devEUI lorawan.EUI64) (string, error) {
+	data := map[string]interface{}{
+		"ApplicationID":   applicationID,
+		"ApplicationUUID": "",
+		"ApplicationName": applicationName,
+		"NodeName":        nodeName,
+		"DevEUI":          devEUI.String(),
+		"AppEUI":          "",
+	}
+	if err := t.setApplicationUUID(data, applicationID); err != nil {
+		return "", err
+	}
+	if t.usesAppEUI {
+		node, err := storage.GetNode(common.DB, devEUI)
+		if err != nil {
+			return "", fmt.Errorf("get node error: %s", err)
+		}
+		data["AppEUI"] = node.AppEUI.String()
+	}
+	return t.execute(data)
+}
+
+// setApplicationUUID sets the ApplicationUUID of the given template data
+// when it is used by the template.
+func (t *topicTemplate) setApplicationUUID(data map[string]interface{}, applicationID int64) error {
+	if !t.usesApplicationUUID {
+		return nil
+	}
+	app, err := storage.GetApplication(common.DB, applicationID)
+	if err != nil {
+		return fmt.Errorf("get application error: %s", err)
+	}
+	data["ApplicationUUID"] = app.UUID.String()
+	return nil
+}
@@ -0,0 +1,102 @@
+package multihandler
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+)
+
+// Workers defines the max. number of handlers of an event which are called
+// concurrently. The bound is per event (thus per organization, see the
+// outboxhandler package), so that the slow or failing handlers of one
+// organization do not delay the delivery of the events of other
+// organizations. With 0 workers, the handlers of an event are called one
+// after the other.
+var Workers = 20
+
+// dispatch calls fn for each of the given targets pending in the given
+// delivery and waits until all calls have returned. The handlers are called
+// concurrently (max. Workers at a time), so that a slow handler does not
+// delay the other handlers. The errors of the handlers are logged and the
+// outcome is recorded per target in the delivery, so that a retry of the
+// event is only sent to the failed targets (the handlers which succeeded are
+// not called again). Events dropped by a handler
+// (handler.ErrDropped) are not retried. When a handler fails with a
+// handler.ErrDeadLetter error, the event is dead-lettered for this target.
+// Without delivery (nil), the handler.ErrDeadLetter error is returned when
+// no other handler failed.
+func dispatch(d *handler.Delivery, targets []target, fn func(h handler.IntegrationHandler) error) error {
+	var pending []target
+	for _, t := range targets {
+		if d.Pending(t.id) {
+			pending = append(pending, t)
+		}
+	}
+	errs := make([]error, len(pending))
+
+	workers := Workers
+	if workers == 0 || len(pending) == 1 {
+		for i, t := range pending {
+			errs[i] = call(t.handler, fn)
+		}
+	} else {
+		sem := make(chan struct{}, workers)
+
+		var wg sync.WaitGroup
+		wg.Add(len(pending))
+		for i := range pending {
+			sem <- struct{}{}
+			go func(i int) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				errs[i] = call(pending[i].handler, fn)
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	var sendErr, deadLetterErr error
+	for i, err := range errs {
+		t := pending[i]
+		if err == nil {
+			d.SetDelivered(t.id)
+			continue
+		}
+		if errors.Cause(err) == handler.ErrDropped {
+			log.WithField("target", t.id).Warningf("handler %T dropped event: %s", t.handler, err)
+			d.SetDelivered(t.id)
+			continue
+		}
+		log.WithField("target", t.id).Errorf("handler %T error: %s", t.handler, err)
+		if errors.Cause(err) == handler.ErrDeadLetter {
+			if d != nil {
+				d.SetDeadLettered(t.id, err)
+				continue
+			}
+			deadLetterErr = errors.Wrapf(err, "handler %T error", t.handler)
+		} else {
+			sendErr = errors.Wrapf(err, "handler %T error", t.handler)
+		}
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	return deadLetterErr
+}
+
+// call calls fn for the given handler. A panic of the handler is returned
+// as error, so that it does not affect the other handlers.
+func call(h handler.IntegrationHandler, fn func(h handler.IntegrationHandler) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+	return fn(h)
+}
@@ -0,0 +1,184 @@
+package multihandler
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+)
+
+// dispatchTestHandler is a handler of which SendDataUp blocks for the
+// given delay and then returns the given error (or panics).
+type dispatchTestHandler struct {
+	*testhandler.TestHandler
+	delay time.Duration
+	err   error
+	panic bool
+	calls *int32
+}
+
+func (h dispatchTestHandler) SendDataUp(pl handler.DataUpPayload) error {
+	atomic.AddInt32(h.calls, 1)
+	time.Sleep(h.delay)
+	if h.panic {
+		panic("boom")
+	}
+	return h.err
+}
+
+func TestDispatch(t *testing.T) {
+	Convey("Given a set of handlers", t, func() {
+		var calls int32
+		newHandler := func(delay time.Duration, err error) dispatchTestHandler {
+			return dispatchTestHandler{
+				TestHandler: testhandler.NewTestHandler(),
+				delay:       delay,
+				err:         err,
+				calls:       &calls,
+			}
+		}
+		sendDataUp := func(h handler.IntegrationHandler) error {
+			return h.SendDataUp(handler.DataUpPayload{})
+		}
+
+		Convey("Then slow handlers are called concurrently", func() {
+			handlers := newTargets(
+				newHandler(100*time.Millisecond, nil),
+				newHandler(100*time.Millisecond, nil),
+				newHandler(100*time.Millisecond, nil),
+			)
+
+			start := time.Now()
+			So(dispatch(nil, handlers, sendDataUp), ShouldBeNil)
+			So(time.Since(start), ShouldBeLessThan, 250*time.Millisecond)
+			So(calls, ShouldEqual, 3)
+		})
+
+		Convey("Then with 0 workers the handlers are called one after the other", func() {
+			workers := Workers
+			Workers = 0
+			defer func() { Workers = workers }()
+
+			handlers := newTargets(
+				newHandler(50*time.Millisecond, nil),
+				newHandler(50*time.Millisecond, nil),
+			)
+
+			start := time.Now()
+			So(dispatch(nil, handlers, sendDataUp), ShouldBeNil)
+			So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+		})
+
+		Convey("Then the number of concurrent handlers is bounded per event", func() {
+			workers := Workers
+			Workers = 2
+			defer func() { Workers = workers }()
+
+			handlers := newTargets(
+				newHandler(50*time.Millisecond, nil),
+				newHandler(50*time.Millisecond, nil),
+				newHandler(50*time.Millisecond, nil),
+				newHandler(50*time.Millisecond, nil),
+			)
+
+			start := time.Now()
+			So(dispatch(nil, handlers, sendDataUp), ShouldBeNil)
+			So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+			So(time.Since(start), ShouldBeLessThan, 150*time.Millisecond)
+		})
+
+		Convey("Then a slow handler does not delay the handlers of other events", func() {
+			workers := Workers
+			Workers = 1
+			defer func() { Workers = workers }()
+
+			done := make(chan struct{})
+			go func() {
+				dispatch(nil, newTargets(newHandler(200*time.Millisecond, nil), newHandler(200*time.Millisecond, nil)), sendDataUp)
+				close(done)
+			}()
+			time.Sleep(10 * time.Millisecond)
+
+			start := time.Now()
+			So(dispatch(nil, newTargets(newHandler(0, nil), newHandler(0, nil)), sendDataUp), ShouldBeNil)
+			So(time.Since(start), ShouldBeLessThan, 100*time.Millisecond)
+			<-done
+		})
+
+		Convey("Then a failing or panicking handler does not affect the other handlers", func() {
+			h := newHandler(0, nil)
+			h.panic = true
+			handlers := newTargets(
+				newHandler(0, errors.New("failed")),
+				h,
+				newHandler(0, nil),
+			)
+
+			So(dispatch(nil, handlers, sendDataUp), ShouldNotBeNil)
+			So(calls, ShouldEqual, 3)
+		})
+
+		Convey("Then a dead-letter error is only returned when no other handler failed", func() {
+			deadLetter := newHandler(0, handler.ErrDeadLetter)
+
+			err := dispatch(nil, newTargets(deadLetter, newHandler(0, nil)), sendDataUp)
+			So(err, ShouldNotBeNil)
+			So(errors.Cause(err), ShouldEqual, handler.ErrDeadLetter)
+
+			err = dispatch(nil, newTargets(deadLetter, newHandler(0, errors.New("failed"))), sendDataUp)
+			So(err, ShouldNotBeNil)
+			So(errors.Cause(err), ShouldNotEqual, handler.ErrDeadLetter)
+		})
+
+		Convey("Then an event dropped by a handler is not retried", func() {
+			err := dispatch(nil, newTargets(newHandler(0, handler.ErrDropped), newHandler(0, nil)), sendDataUp)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Given a tracked delivery", func() {
+			d := handler.NewDelivery("", nil, nil)
+			failing := newHandler(0, errors.New("failed"))
+			targets := newTargets(newHandler(0, nil), failing, newHandler(0, handler.ErrDeadLetter))
+
+			Convey("Then a retry is only sent to the failed targets", func() {
+				So(dispatch(d, targets, sendDataUp), ShouldNotBeNil)
+				So(calls, ShouldEqual, 3)
+				So(d.Delivered(), ShouldResemble, []string{"test:0", "test:2"})
+				So(d.DeadLettered(), ShouldContainKey, "test:2")
+
+				failing.err = nil
+				targets[1].handler = failing
+				So(dispatch(d, targets, sendDataUp), ShouldBeNil)
+				So(calls, ShouldEqual, 4)
+				So(d.Delivered(), ShouldResemble, []string{"test:0", "test:1", "test:2"})
+			})
+
+			Convey("Then a dead-letter error is not returned", func() {
+				So(dispatch(d, newTargets(newHandler(0, handler.ErrDeadLetter)), sendDataUp), ShouldBeNil)
+				So(d.DeadLettered(), ShouldContainKey, "test:0")
+			})
+
+			Convey("Then the event for a dead-lettered target is only sent to that target", func() {
+				d := handler.NewDelivery("test:1", nil, nil)
+				So(dispatch(d, targets, sendDataUp), ShouldNotBeNil)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+// newTargets returns the targets for the given handlers, identified by
+// their index.
+func newTargets(handlers ...handler.IntegrationHandler) []target {
+	var targets []target
+	for i, h := range handlers {
+		targets = append(targets, target{id: fmt.Sprintf("test:%d", i), handler: h})
+	}
+	return targets
+}
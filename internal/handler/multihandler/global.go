@@ -0,0 +1,49 @@
+package multihandler
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+)
+
+// GlobalIntegration defines an integration configured by the operator,
+// receiving the events of all applications. The settings are the (JSON
+// encoded) settings of the integration of the given kind, as stored for
+// application integrations.
+type GlobalIntegration struct {
+	Kind     string          `json:"kind"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+// ReadGlobalIntegrations reads the global integrations from the given JSON
+// file, containing an array of GlobalIntegration objects.
+func ReadGlobalIntegrations(path string) ([]GlobalIntegration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open file error")
+	}
+	defer f.Close()
+
+	var integrations []GlobalIntegration
+	if err := json.NewDecoder(f).Decode(&integrations); err != nil {
+		return nil, errors.Wrap(err, "decode global integrations error")
+	}
+	return integrations, nil
+}
+
+// NewGlobalHandlers returns the handlers for the given global integrations,
+// to be passed to NewHandler.
+func NewGlobalHandlers(integrations []GlobalIntegration) ([]handler.IntegrationHandler, error) {
+	var handlers []handler.IntegrationHandler
+	for i, intg := range integrations {
+		h, err := newIntegrationHandler(intg.Kind, intg.Settings, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "global integration %d (%s)", i, intg.Kind)
+		}
+		handlers = append(handlers, h)
+	}
+	return handlers, nil
+}
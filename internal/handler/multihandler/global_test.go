@@ -0,0 +1,48 @@
+package multihandler
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
+)
+
+func TestGlobalIntegrations(t *testing.T) {
+	Convey("Given a file with a global HTTP integration", t, func() {
+		f, err := ioutil.TempFile("", "global-integrations")
+		So(err, ShouldBeNil)
+		defer os.Remove(f.Name())
+
+		_, err = f.WriteString(`[{"kind": "HTTP", "settings": {"dataUpURL": "http://localhost:8080/rx"}}]`)
+		So(err, ShouldBeNil)
+		So(f.Close(), ShouldBeNil)
+
+		Convey("Then the integrations can be read", func() {
+			integrations, err := ReadGlobalIntegrations(f.Name())
+			So(err, ShouldBeNil)
+			So(integrations, ShouldHaveLength, 1)
+			So(integrations[0].Kind, ShouldEqual, HTTPHandlerKind)
+
+			Convey("Then the HTTP handler is returned", func() {
+				handlers, err := NewGlobalHandlers(integrations)
+				So(err, ShouldBeNil)
+				So(handlers, ShouldHaveLength, 1)
+				So(handlers[0], ShouldHaveSameTypeAs, &httphandler.Handler{})
+			})
+		})
+	})
+
+	Convey("Given a global integration of an unknown kind", t, func() {
+		integrations := []GlobalIntegration{
+			{Kind: "FOO", Settings: []byte(`{}`)},
+		}
+
+		Convey("Then NewGlobalHandlers returns an error", func() {
+			_, err := NewGlobalHandlers(integrations)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -5,100 +5,333 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/brocaar/lora-app-server/internal/adminstats"
 	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/eventlog"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/amqphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/azurehandler"
+	"github.com/brocaar/lora-app-server/internal/handler/chaoshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/elasticsearchhandler"
 	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/mydeviceshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/postgresqlhandler"
+	"github.com/brocaar/lora-app-server/internal/handler/pubsubhandler"
+	"github.com/brocaar/lora-app-server/internal/handler/snshandler"
+	"github.com/brocaar/lora-app-server/internal/handler/sysloghandler"
+	"github.com/brocaar/lora-app-server/internal/handler/thingsboardhandler"
+	"github.com/brocaar/lora-app-server/internal/residency"
+	"github.com/brocaar/lora-app-server/internal/secret"
 	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
 // Handler kinds
 const (
-	HTTPHandlerKind = "HTTP"
+	HTTPHandlerKind          = "HTTP"
+	SyslogHandlerKind        = "SYSLOG"
+	AMQPHandlerKind          = "AMQP"
+	PostgreSQLHandlerKind    = "POSTGRESQL"
+	AWSSNSHandlerKind        = "AWS_SNS"
+	AzureHandlerKind         = "AZURE"
+	GCPPubSubHandlerKind     = "GCP_PUB_SUB"
+	ThingsBoardHandlerKind   = "THINGSBOARD"
+	MyDevicesHandlerKind     = "MY_DEVICES"
+	ElasticsearchHandlerKind = "ELASTICSEARCH"
 )
 
+// Delivery targets (see handler.Delivery). The targets of the global,
+// application and device-level integrations are suffixed by their index,
+// UUID or ID.
+const (
+	defaultTarget                = "default"
+	eventLogTarget               = "eventlog"
+	residencyTarget              = "residency"
+	globalIntegrationTarget      = "global:%d"
+	applicationIntegrationTarget = "integration:%s"
+	nodeIntegrationTarget        = "node-integration:%d"
+)
+
+// event describes the dispatched event, used for filtering the integrations
+// (see storage.IntegrationFilter).
+type event struct {
+	eventType string
+	fPort     *int
+}
+
+// target is a handler to which an event is delivered, identified by an ID
+// which is stable over retries of the event.
+type target struct {
+	id      string
+	handler handler.IntegrationHandler
+}
+
 // Handler wraps multiple handlers inside a single handler so that
-// data can be sent to multiple endpoints simultaneously.
-// Note that errors are logged, but not returned.
+// data can be sent to multiple endpoints simultaneously. The handlers of an
+// event are called concurrently (see Workers).
+// Note that errors are logged and an error is returned when one of the
+// handlers failed. Use WithDelivery to track the delivery per handler, so
+// that a retry of the event is only sent to the handlers which failed.
 type Handler struct {
 	defaultHandler handler.Handler
+	globalHandlers []handler.IntegrationHandler
+	delivery       *handler.Delivery
+}
+
+// WithDelivery returns the handler tracking the delivery of the next event
+// in the given Delivery.
+func (w Handler) WithDelivery(d *handler.Delivery) handler.Handler {
+	w.delivery = d
+	return w
 }
 
 // SendDataUp sends a data-up payload.
 func (w Handler) SendDataUp(pl handler.DataUpPayload) error {
-	handlers, err := w.getHandlersForApplicationID(pl.ApplicationID)
+	fPort := int(pl.FPort)
+	handlers, err := w.getHandlers(pl.ApplicationID, &pl.DevEUI, event{eventType: storage.IntegrationEventUp, fPort: &fPort})
 	if err != nil {
 		log.Errorf("get handlers for application-id error: %s", err)
-		handlers = []handler.IntegrationHandler{w.defaultHandler}
+		handlers = w.getGlobalHandlers()
 	}
 
-	for _, h := range handlers {
-		if err := h.SendDataUp(pl); err != nil {
-			log.Errorf("handler %T error: %s", h, err)
-		}
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		return h.SendDataUp(pl)
+	})
+
+	sendErr = handlersError(sendErr, err)
+	if !w.eventLogPending() {
+		return sendErr
 	}
-	return nil
+	if err := eventlog.LogEventForDevice(pl.DevEUI, eventlog.Uplink, pl); err != nil {
+		log.Errorf("log event for device error: %s", err)
+	}
+	if err := eventlog.LogEventForApplication(pl.ApplicationID, eventlog.Uplink, pl); err != nil {
+		log.Errorf("log event for application error: %s", err)
+	}
+	return sendErr
 }
 
 // SendJoinNotification sends a join notification.
 func (w Handler) SendJoinNotification(pl handler.JoinNotification) error {
-	handlers, err := w.getHandlersForApplicationID(pl.ApplicationID)
+	handlers, err := w.getHandlers(pl.ApplicationID, &pl.DevEUI, event{eventType: storage.IntegrationEventJoin})
 	if err != nil {
 		log.Errorf("get handlers for application-id error: %s", err)
-		handlers = []handler.IntegrationHandler{w.defaultHandler}
+		handlers = w.getGlobalHandlers()
 	}
 
-	for _, h := range handlers {
-		if err := h.SendJoinNotification(pl); err != nil {
-			log.Errorf("handler %T error: %s", h, err)
-		}
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		return h.SendJoinNotification(pl)
+	})
+
+	sendErr = handlersError(sendErr, err)
+	if !w.eventLogPending() {
+		return sendErr
 	}
-	return nil
+	if err := eventlog.LogEventForDevice(pl.DevEUI, eventlog.Join, pl); err != nil {
+		log.Errorf("log event for device error: %s", err)
+	}
+	if err := eventlog.LogEventForApplication(pl.ApplicationID, eventlog.Join, pl); err != nil {
+		log.Errorf("log event for application error: %s", err)
+	}
+	return sendErr
 }
 
 // SendACKNotification sends an ACK notification.
 func (w Handler) SendACKNotification(pl handler.ACKNotification) error {
-	handlers, err := w.getHandlersForApplicationID(pl.ApplicationID)
+	handlers, err := w.getHandlers(pl.ApplicationID, &pl.DevEUI, event{eventType: storage.IntegrationEventACK})
 	if err != nil {
 		log.Errorf("get handlers for application-id error: %s", err)
-		handlers = []handler.IntegrationHandler{w.defaultHandler}
+		handlers = w.getGlobalHandlers()
 	}
 
-	for _, h := range handlers {
-		if err := h.SendACKNotification(pl); err != nil {
-			log.Errorf("handler %T error: %s", h, err)
-		}
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		return h.SendACKNotification(pl)
+	})
+
+	sendErr = handlersError(sendErr, err)
+	if !w.eventLogPending() {
+		return sendErr
 	}
-	return nil
+	if err := eventlog.LogEventForDevice(pl.DevEUI, eventlog.ACK, pl); err != nil {
+		log.Errorf("log event for device error: %s", err)
+	}
+	if err := eventlog.LogEventForApplication(pl.ApplicationID, eventlog.ACK, pl); err != nil {
+		log.Errorf("log event for application error: %s", err)
+	}
+	return sendErr
 }
 
 // SendErrorNotification sends an error notification.
 func (w Handler) SendErrorNotification(pl handler.ErrorNotification) error {
-	handlers, err := w.getHandlersForApplicationID(pl.ApplicationID)
+	handlers, err := w.getHandlers(pl.ApplicationID, &pl.DevEUI, event{eventType: storage.IntegrationEventError})
+	if err != nil {
+		log.Errorf("get handlers for application-id error: %s", err)
+		handlers = w.getGlobalHandlers()
+	}
+
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		return h.SendErrorNotification(pl)
+	})
+
+	sendErr = handlersError(sendErr, err)
+	if !w.eventLogPending() {
+		return sendErr
+	}
+	if err := eventlog.LogEventForDevice(pl.DevEUI, eventlog.Error, pl); err != nil {
+		log.Errorf("log event for device error: %s", err)
+	}
+	if err := eventlog.LogEventForApplication(pl.ApplicationID, eventlog.Error, pl); err != nil {
+		log.Errorf("log event for application error: %s", err)
+	}
+	if err := adminstats.RecordError(pl.ApplicationID); err != nil {
+		log.Errorf("record error notification error: %s", err)
+	}
+	return sendErr
+}
+
+// SendSecurityNotification sends a security notification.
+func (w Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	handlers, err := w.getHandlers(pl.ApplicationID, &pl.DevEUI, event{eventType: storage.IntegrationEventSecurity})
+	if err != nil {
+		log.Errorf("get handlers for application-id error: %s", err)
+		handlers = w.getGlobalHandlers()
+	}
+
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		return h.SendSecurityNotification(pl)
+	})
+
+	sendErr = handlersError(sendErr, err)
+	if !w.eventLogPending() {
+		return sendErr
+	}
+	if err := eventlog.LogEventForDevice(pl.DevEUI, eventlog.Security, pl); err != nil {
+		log.Errorf("log event for device error: %s", err)
+	}
+	return sendErr
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (w Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	handlers, err := w.getHandlers(pl.ApplicationID, nil, event{eventType: storage.IntegrationEventProprietary})
+	if err != nil {
+		log.Errorf("get handlers for application-id error: %s", err)
+		handlers = w.getGlobalHandlers()
+	}
+
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		return h.SendProprietaryUp(pl)
+	})
+	return handlersError(sendErr, err)
+}
+
+// SendCustomEvent sends a custom event to the handlers supporting custom
+// events.
+func (w Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	handlers, err := w.getHandlers(pl.ApplicationID, pl.DevEUI, event{eventType: storage.IntegrationEventCustom})
 	if err != nil {
 		log.Errorf("get handlers for application-id error: %s", err)
-		handlers = []handler.IntegrationHandler{w.defaultHandler}
+		handlers = w.getGlobalHandlers()
 	}
 
-	for _, h := range handlers {
-		if err := h.SendErrorNotification(pl); err != nil {
-			log.Errorf("handler %T error: %s", h, err)
+	sendErr := dispatch(w.delivery, handlers, func(h handler.IntegrationHandler) error {
+		ch, ok := h.(handler.CustomEventHandler)
+		if !ok {
+			return nil
 		}
+		return ch.SendCustomEvent(pl)
+	})
+
+	sendErr = handlersError(sendErr, err)
+	if !w.eventLogPending() {
+		return sendErr
 	}
-	return nil
+	if err := eventlog.LogEventForApplication(pl.ApplicationID, eventlog.Custom, pl); err != nil {
+		log.Errorf("log event for application error: %s", err)
+	}
+	if pl.DevEUI != nil {
+		if err := eventlog.LogEventForDevice(*pl.DevEUI, eventlog.Custom, pl); err != nil {
+			log.Errorf("log event for device error: %s", err)
+		}
+	}
+	return sendErr
+}
+
+// SendGatewayNotification sends a gateway notification to the default
+// handler and the global handlers supporting gateway notifications.
+func (w Handler) SendGatewayNotification(pl handler.GatewayNotification) error {
+	return dispatch(w.delivery, w.getGlobalHandlers(), func(h handler.IntegrationHandler) error {
+		gh, ok := h.(handler.GatewayNotificationHandler)
+		if !ok {
+			return nil
+		}
+		return gh.SendGatewayNotification(pl)
+	})
+}
+
+// handlersError returns the given send error, or the given error of
+// getHandlers when the handlers did not fail. In the latter case, the event
+// was only sent to the global handlers, thus it must be retried for the
+// integrations of the application.
+func handlersError(sendErr, handlersErr error) error {
+	if sendErr == nil && handlersErr != nil {
+		return errors.Wrap(handlersErr, "get handlers error")
+	}
+	return sendErr
+}
+
+// eventLogPending returns true when the event must be logged in the
+// event-log, marking it as logged as the event-log is not retried.
+func (w Handler) eventLogPending() bool {
+	if !w.delivery.Pending(eventLogTarget) {
+		return false
+	}
+	w.delivery.SetDelivered(eventLogTarget)
+	return true
 }
 
 // Close closes the handlers.
 func (w Handler) Close() error {
+	for _, h := range w.globalHandlers {
+		if err := h.Close(); err != nil {
+			log.Errorf("close handler %T error: %s", h, err)
+		}
+	}
 	return w.defaultHandler.Close()
 }
 
-// getHandlersForApplicationID returns all handlers (including the default
-// handler for the given application ID.
-func (w Handler) getHandlersForApplicationID(id int64) ([]handler.IntegrationHandler, error) {
-	handlers := []handler.IntegrationHandler{w.defaultHandler}
+// getGlobalHandlers returns the default handler and the handlers receiving
+// the events of all applications.
+func (w Handler) getGlobalHandlers() []target {
+	targets := []target{{id: defaultTarget, handler: w.defaultHandler}}
+	for i, h := range w.globalHandlers {
+		targets = append(targets, target{id: fmt.Sprintf(globalIntegrationTarget, i), handler: h})
+	}
+	return targets
+}
+
+// getHandlers returns all handlers (including the default and global
+// handlers) for the given application ID, DevEUI and event. Integrations
+// configured for a subset of the devices are only returned when they include
+// the given DevEUI and integrations with an event filter are only returned
+// when the event matches the filter. When devEUI is nil (the event is not related to a device), all
+// integrations are returned, else the device-level integrations of the
+// node are included. For applications with a residency region, the handler
+// storing the event history in the database of that region is included.
+func (w Handler) getHandlers(id int64, devEUI *lorawan.EUI64, ev event) ([]target, error) {
+	handlers := w.getGlobalHandlers()
+
+	rh, err := getResidencyHandler(id)
+	if err != nil {
+		return nil, err
+	}
+	if rh != nil {
+		handlers = append(handlers, target{id: residencyTarget, handler: rh})
+	}
 
 	// read integrations
 	integrations, err := storage.GetIntegrationsForApplicationID(common.DB, id)
@@ -107,34 +340,289 @@ func (w Handler) getHandlersForApplicationID(id int64) ([]handler.IntegrationHan
 	}
 
 	// map integration to handler + config
+	tags := nodeTagsLoader(devEUI)
 	for _, intg := range integrations {
-		switch intg.Kind {
-		case HTTPHandlerKind:
-			var conf httphandler.HandlerConfig
-			if err := json.NewDecoder(bytes.NewReader(intg.Settings)).Decode(&conf); err != nil {
-				return nil, errors.Wrap(err, "decode http handler config error")
-			}
-			h, err := httphandler.NewHandler(conf)
-			if err != nil {
+		t, err := w.newTarget(id, ev, tags, integration{
+			targetID:           fmt.Sprintf(applicationIntegrationTarget, intg.UUID.String()),
+			uuid:               intg.UUID.String(),
+			kind:               intg.Kind,
+			settings:           intg.Settings,
+			devEUI:             devEUI,
+			IntegrationOptions: intg.IntegrationOptions,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			handlers = append(handlers, *t)
+		}
+	}
+
+	if devEUI != nil {
+		nodeHandlers, err := w.getNodeHandlers(id, *devEUI, ev, tags)
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, nodeHandlers...)
+	}
+
+	return handlers, nil
+}
+
+// integration contains the configuration of an application or device-level
+// integration.
+type integration struct {
+	targetID string
+	uuid     string
+	kind     string
+	settings []byte
+
+	// devEUI is set when the integration must only be returned when it is
+	// configured for this device (see newIntegrationHandler).
+	devEUI *lorawan.EUI64
+
+	storage.IntegrationOptions
+}
+
+// newTarget returns the target for the given integration of the given
+// application, or nil when the event does not match the event filter of the
+// integration. The returned handler simulates the failure of the integration
+// when configured and accounts the deliveries in the admin statistics and
+// the integration health.
+func (w Handler) newTarget(applicationID int64, ev event, tags func() ([]string, error), intg integration) (*target, error) {
+	if filter := intg.Filter(); filter.Enabled() {
+		var nodeTags []string
+		if len(filter.Tags) > 0 {
+			var err error
+			if nodeTags, err = tags(); err != nil {
 				return nil, err
 			}
-			handlers = append(handlers, h)
-		default:
-			return nil, fmt.Errorf("unknown integration %s", intg.Kind)
+		}
+		if !filter.Match(ev.eventType, ev.fPort, nodeTags) {
+			return nil, nil
+		}
+	}
+
+	h, err := newIntegrationHandler(intg.kind, intg.settings, intg.devEUI)
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return nil, nil
+	}
+
+	w.setBatchFailureFunc(h, intg.targetID)
+
+	// simulate the failure of the integration (testing)
+	if chaos := intg.Chaos(); chaos.Active(time.Now()) {
+		h = chaoshandler.NewHandler(h, chaos)
+	}
+
+	h = newStatsHandler(h, applicationID, intg.kind, intg.uuid)
+
+	return &target{id: intg.targetID, handler: h}, nil
+}
+
+// nodeTagsLoader returns a function returning the tags of the given node,
+// so that these are only read once and only when needed. For events not
+// related to a node or of an unknown node, nil is returned.
+func nodeTagsLoader(devEUI *lorawan.EUI64) func() ([]string, error) {
+	var loaded bool
+	var tags []string
+
+	return func() ([]string, error) {
+		if loaded || devEUI == nil {
+			return tags, nil
+		}
+
+		node, err := storage.GetNode(common.DB, *devEUI)
+		if err != nil && err != storage.ErrDoesNotExist {
+			return nil, errors.Wrap(err, "get node error")
+		}
+		if err == nil {
+			tags = []string(node.Tags)
+			if tags == nil {
+				tags = []string{}
+			}
+		}
+		loaded = true
+		return tags, nil
+	}
+}
+
+// newIntegrationHandler returns the handler for the given integration kind
+// and (JSON encoded) settings. When devEUI is set and the integration is
+// configured for a subset of the devices not including it, nil is returned.
+func newIntegrationHandler(kind string, settings []byte, devEUI *lorawan.EUI64) (handler.IntegrationHandler, error) {
+	switch kind {
+	case HTTPHandlerKind:
+		var conf httphandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode http handler config error")
+		}
+		if devEUI != nil && !conf.IncludesDevEUI(*devEUI) {
+			return nil, nil
+		}
+		return httphandler.NewHandler(conf)
+	case SyslogHandlerKind:
+		var conf sysloghandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode syslog handler config error")
+		}
+		return sysloghandler.NewHandler(conf)
+	case AMQPHandlerKind:
+		var conf amqphandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode amqp handler config error")
+		}
+		return amqphandler.NewHandler(conf)
+	case PostgreSQLHandlerKind:
+		var conf postgresqlhandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode postgresql handler config error")
+		}
+		return postgresqlhandler.NewHandler(conf)
+	case AWSSNSHandlerKind:
+		var conf snshandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode aws sns handler config error")
+		}
+		return snshandler.NewHandler(conf)
+	case AzureHandlerKind:
+		var conf azurehandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode azure handler config error")
+		}
+		return azurehandler.NewHandler(conf)
+	case GCPPubSubHandlerKind:
+		var conf pubsubhandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode gcp pub/sub handler config error")
+		}
+		return pubsubhandler.NewHandler(conf)
+	case ThingsBoardHandlerKind:
+		var conf thingsboardhandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode thingsboard handler config error")
+		}
+		return thingsboardhandler.NewHandler(conf)
+	case MyDevicesHandlerKind:
+		var conf mydeviceshandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode mydevices handler config error")
+		}
+		return mydeviceshandler.NewHandler(conf)
+	case ElasticsearchHandlerKind:
+		var conf elasticsearchhandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(settings)).Decode(&conf); err != nil {
+			return nil, errors.Wrap(err, "decode elasticsearch handler config error")
+		}
+		return elasticsearchhandler.NewHandler(conf)
+	default:
+		return nil, fmt.Errorf("unknown integration %s", kind)
+	}
+}
+
+// PrewarmIntegrations establishes the connections of the integrations
+// configured to connect eagerly (instead of on the first event), e.g. on
+// startup.
+func PrewarmIntegrations() error {
+	integrations, err := storage.GetIntegrationsForKind(common.DB, AMQPHandlerKind)
+	if err != nil {
+		return errors.Wrap(err, "get amqp integrations error")
+	}
+
+	for _, intg := range integrations {
+		var conf amqphandler.HandlerConfig
+		if err := json.NewDecoder(bytes.NewReader(intg.Settings)).Decode(&conf); err != nil {
+			return errors.Wrap(err, "decode amqp handler config error")
+		}
+		if err := amqphandler.Prewarm(conf); err != nil {
+			return errors.Wrapf(err, "prewarm amqp integration %d error", intg.ID)
+		}
+	}
+
+	return nil
+}
+
+// getNodeHandlers returns the handlers for the device-level integrations of
+// the given node. These are wrapped like the integrations of the application
+// (see newTarget).
+func (w Handler) getNodeHandlers(applicationID int64, devEUI lorawan.EUI64, ev event, tags func() ([]string, error)) ([]target, error) {
+	integrations, err := storage.GetNodeIntegrationsForDevEUI(common.DB, devEUI)
+	if err != nil {
+		return nil, errors.Wrap(err, "get integrations for deveui error")
+	}
+
+	var handlers []target
+	for _, intg := range integrations {
+		if intg.Kind != HTTPHandlerKind {
+			return nil, fmt.Errorf("unknown node integration %s", intg.Kind)
+		}
+
+		t, err := w.newTarget(applicationID, ev, tags, integration{
+			targetID:           fmt.Sprintf(nodeIntegrationTarget, intg.ID),
+			uuid:               intg.UUID.String(),
+			kind:               intg.Kind,
+			settings:           intg.Settings,
+			IntegrationOptions: intg.IntegrationOptions,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			handlers = append(handlers, *t)
 		}
 	}
 
 	return handlers, nil
 }
 
+// setBatchFailureFunc sets the function dead-lettering the data-up payloads
+// of the given target which could not be delivered after these were
+// buffered (see httphandler.HandlerConfig.BatchSize).
+func (w Handler) setBatchFailureFunc(h handler.IntegrationHandler, targetID string) {
+	if hh, ok := h.(*httphandler.Handler); ok {
+		hh.SetBatchFailureFunc(w.delivery.DeadLetterFunc(targetID))
+	}
+}
+
+// getResidencyHandler returns the handler storing the event history in the
+// database of the residency region of the given application, or nil when the
+// application has no residency region. An error is returned when the region
+// is not configured, so that the events are kept in the outbox instead of
+// being delivered without storing them in the region.
+func getResidencyHandler(id int64) (handler.IntegrationHandler, error) {
+	app, err := storage.GetApplication(common.DB, id)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get application error")
+	}
+	if app.ResidencyRegion == "" {
+		return nil, nil
+	}
+
+	region, err := residency.GetRegion(app.ResidencyRegion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "residency region %s", app.ResidencyRegion)
+	}
+	return postgresqlhandler.NewHandler(postgresqlhandler.HandlerConfig{
+		DSN: secret.String(region.DSN),
+	})
+}
+
 // DataDownChan returns the channel containing the received DataDownPayload.
 func (w Handler) DataDownChan() chan handler.DataDownPayload {
 	return w.defaultHandler.DataDownChan()
 }
 
-// NewHandler returns a new MultiHandler.
-func NewHandler(defaultHandler handler.Handler) handler.Handler {
+// NewHandler returns a new MultiHandler. The given global handlers receive
+// the events of all applications, next to the default handler.
+func NewHandler(defaultHandler handler.Handler, globalHandlers ...handler.IntegrationHandler) handler.Handler {
 	return Handler{
 		defaultHandler: defaultHandler,
+		globalHandlers: globalHandlers,
 	}
 }
@@ -3,19 +3,25 @@ package multihandler
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/brocaar/lora-app-server/internal/common"
 	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/chaoshandler"
 	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
 	"github.com/brocaar/lora-app-server/internal/handler/mqtthandler"
+	"github.com/brocaar/lora-app-server/internal/handler/postgresqlhandler"
+	"github.com/brocaar/lora-app-server/internal/residency"
 	"github.com/brocaar/lora-app-server/internal/storage"
 	"github.com/brocaar/lora-app-server/internal/test"
 	"github.com/brocaar/lorawan"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -61,7 +67,7 @@ func TestHandler(t *testing.T) {
 		token.Wait()
 		So(token.Error(), ShouldBeNil)
 
-		mqttHandler, err := mqtthandler.NewHandler(conf.MQTTServer, conf.MQTTUsername, conf.MQTTPassword, "")
+		mqttHandler, err := mqtthandler.NewHandler(mqtthandler.Broker{Server: conf.MQTTServer, Username: conf.MQTTUsername, Password: conf.MQTTPassword})
 		So(err, ShouldBeNil)
 
 		Convey("Given an organization, application with http integration and node", func() {
@@ -175,3 +181,225 @@ func TestHandler(t *testing.T) {
 		})
 	})
 }
+
+func TestResidencyHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization and application", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		Convey("Then no residency handler is returned for the application", func() {
+			h, err := getResidencyHandler(app.ID)
+			So(err, ShouldBeNil)
+			So(h, ShouldBeNil)
+		})
+
+		Convey("Given the application is tagged with a residency region", func() {
+			app.ResidencyRegion = "eu"
+			So(storage.UpdateApplication(common.DB, app), ShouldBeNil)
+
+			Convey("Then an error is returned when the region is not configured", func() {
+				_, err := getResidencyHandler(app.ID)
+				So(errors.Cause(err), ShouldEqual, residency.ErrUnknownRegion)
+			})
+
+			Convey("Then the PostgreSQL handler of the region is returned when configured", func() {
+				So(residency.SetRegions([]residency.Region{
+					{Name: "eu", DSN: conf.PostgresDSN},
+				}), ShouldBeNil)
+				defer residency.SetRegions(nil)
+
+				h, err := getResidencyHandler(app.ID)
+				So(err, ShouldBeNil)
+				So(h, ShouldHaveSameTypeAs, &postgresqlhandler.Handler{})
+			})
+		})
+	})
+}
+
+func TestNodeHandlers(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a node", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		node := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateNode(common.DB, node), ShouldBeNil)
+
+		h := Handler{}
+
+		Convey("Given the node has a device-level HTTP integration", func() {
+			settings, err := json.Marshal(httphandler.HandlerConfig{
+				DataUpURL: "http://localhost:8080/rx",
+			})
+			So(err, ShouldBeNil)
+			intg := storage.NodeIntegration{
+				DevEUI:   node.DevEUI,
+				Kind:     HTTPHandlerKind,
+				Settings: settings,
+			}
+			So(storage.CreateNodeIntegration(common.DB, &intg), ShouldBeNil)
+
+			Convey("Then the HTTP handler is returned for events of the node", func() {
+				handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventJoin})
+				So(err, ShouldBeNil)
+				So(handlers, ShouldHaveLength, 2)
+				So(handlers[1].id, ShouldEqual, fmt.Sprintf(nodeIntegrationTarget, intg.ID))
+				So(handlers[1].handler, ShouldHaveSameTypeAs, &statsHandler{})
+
+				sh := handlers[1].handler.(*statsHandler)
+				So(sh.uuid, ShouldEqual, intg.UUID.String())
+				So(sh.handler, ShouldHaveSameTypeAs, &httphandler.Handler{})
+			})
+
+			Convey("Given the integration has an event filter", func() {
+				So(storage.SetNodeIntegrationFilter(common.DB, intg.ID, storage.IntegrationFilter{
+					EventTypes: []string{storage.IntegrationEventUp},
+				}), ShouldBeNil)
+
+				Convey("Then it is returned for matching events", func() {
+					handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventUp})
+					So(err, ShouldBeNil)
+					So(handlers, ShouldHaveLength, 2)
+				})
+
+				Convey("Then it is not returned for other event types", func() {
+					handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventJoin})
+					So(err, ShouldBeNil)
+					So(handlers, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("Given the integration has an active failure simulation", func() {
+				until := time.Now().Add(time.Hour)
+				So(storage.SetNodeIntegrationChaos(common.DB, intg.ID, storage.IntegrationChaos{
+					FailureRate: 100,
+					Until:       &until,
+				}), ShouldBeNil)
+
+				Convey("Then the HTTP handler is wrapped by the chaos handler", func() {
+					handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventJoin})
+					So(err, ShouldBeNil)
+					So(handlers, ShouldHaveLength, 2)
+					So(handlers[1].handler.(*statsHandler).handler, ShouldHaveSameTypeAs, &chaoshandler.Handler{})
+				})
+			})
+
+			Convey("Then it is not returned for events of other nodes", func() {
+				devEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+				handlers, err := h.getHandlers(app.ID, &devEUI, event{eventType: storage.IntegrationEventJoin})
+				So(err, ShouldBeNil)
+				So(handlers, ShouldHaveLength, 1)
+			})
+
+			Convey("Then it is not returned for events not related to a node", func() {
+				handlers, err := h.getHandlers(app.ID, nil, event{eventType: storage.IntegrationEventProprietary})
+				So(err, ShouldBeNil)
+				So(handlers, ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestIntegrationFilter(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a tagged node and a filtered HTTP integration", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		node := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Tags:          []string{"building-a"},
+		}
+		So(storage.CreateNode(common.DB, node), ShouldBeNil)
+
+		intg := storage.Integration{
+			ApplicationID: app.ID,
+			Kind:          HTTPHandlerKind,
+			Settings:      []byte(`{"dataUpURL": "http://localhost:8080/rx"}`),
+		}
+		So(storage.CreateIntegration(common.DB, &intg), ShouldBeNil)
+		So(storage.SetIntegrationFilter(common.DB, intg.ID, storage.IntegrationFilter{
+			EventTypes: []string{storage.IntegrationEventUp},
+			FPorts:     []int{10},
+			Tags:       []string{"building-a"},
+		}), ShouldBeNil)
+
+		h := Handler{}
+		fPort10 := 10
+		fPort20 := 20
+
+		Convey("Then the integration is returned for matching uplinks", func() {
+			handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventUp, fPort: &fPort10})
+			So(err, ShouldBeNil)
+			So(handlers, ShouldHaveLength, 2)
+		})
+
+		Convey("Then the integration is not returned for uplinks on other fPorts", func() {
+			handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventUp, fPort: &fPort20})
+			So(err, ShouldBeNil)
+			So(handlers, ShouldHaveLength, 1)
+		})
+
+		Convey("Then the integration is not returned for other event types", func() {
+			handlers, err := h.getHandlers(app.ID, &node.DevEUI, event{eventType: storage.IntegrationEventJoin})
+			So(err, ShouldBeNil)
+			So(handlers, ShouldHaveLength, 1)
+		})
+
+		Convey("Then the integration is not returned for nodes without the tag", func() {
+			devEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+			handlers, err := h.getHandlers(app.ID, &devEUI, event{eventType: storage.IntegrationEventUp, fPort: &fPort10})
+			So(err, ShouldBeNil)
+			So(handlers, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package multihandler
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/adminstats"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/integrationhealth"
+)
+
+// statsHandler wraps an application integration handler and accounts the
+// (failed) deliveries in the admin statistics and the integration health.
+type statsHandler struct {
+	handler       handler.IntegrationHandler
+	applicationID int64
+	kind          string
+	uuid          string
+}
+
+func newStatsHandler(h handler.IntegrationHandler, applicationID int64, kind, uuid string) *statsHandler {
+	return &statsHandler{
+		handler:       h,
+		applicationID: applicationID,
+		kind:          kind,
+		uuid:          uuid,
+	}
+}
+
+// SendDataUp sends a data-up payload.
+func (h *statsHandler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.record(h.handler.SendDataUp(pl))
+}
+
+// SendJoinNotification sends a join notification.
+func (h *statsHandler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.record(h.handler.SendJoinNotification(pl))
+}
+
+// SendACKNotification sends an ack notification.
+func (h *statsHandler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.record(h.handler.SendACKNotification(pl))
+}
+
+// SendErrorNotification sends an error notification.
+func (h *statsHandler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.record(h.handler.SendErrorNotification(pl))
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *statsHandler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.record(h.handler.SendSecurityNotification(pl))
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (h *statsHandler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.record(h.handler.SendProprietaryUp(pl))
+}
+
+// SendCustomEvent sends a custom event, when supported by the wrapped
+// handler.
+func (h *statsHandler) SendCustomEvent(pl handler.CustomEvent) error {
+	ch, ok := h.handler.(handler.CustomEventHandler)
+	if !ok {
+		return nil
+	}
+	return h.record(ch.SendCustomEvent(pl))
+}
+
+// Close closes the wrapped handler.
+func (h *statsHandler) Close() error {
+	return h.handler.Close()
+}
+
+// record accounts the delivery and returns the given (delivery) error.
+func (h *statsHandler) record(err error) error {
+	if recordErr := adminstats.RecordDelivery(h.applicationID, h.kind, err != nil); recordErr != nil {
+		log.WithFields(log.Fields{
+			"application_id": h.applicationID,
+			"kind":           h.kind,
+		}).Errorf("record integration delivery error: %s", recordErr)
+	}
+	if recordErr := integrationhealth.RecordDelivery(h.uuid, err); recordErr != nil {
+		log.WithFields(log.Fields{
+			"application_id": h.applicationID,
+			"kind":           h.kind,
+		}).Errorf("record integration health error: %s", recordErr)
+	}
+	return err
+}
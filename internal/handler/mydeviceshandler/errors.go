@@ -0,0 +1,8 @@
+package mydeviceshandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidEndpoint = errors.New("Endpoint must be a valid http or https URL")
+)
@@ -0,0 +1,185 @@
+// Package mydeviceshandler implements a handler sending the uplinks of the
+// nodes to the myDevices (Cayenne) cloud.
+package mydeviceshandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/egress"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+var httpClient = egress.NewClient(10 * time.Second)
+
+// HandlerConfig contains the configuration for a myDevices handler.
+type HandlerConfig struct {
+	// Endpoint contains the myDevices uplink endpoint URL (as provided by
+	// myDevices for the network).
+	Endpoint string `json:"endpoint"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidEndpoint
+	}
+	return nil
+}
+
+// uplinkPayload defines the uplink payload as expected by myDevices.
+type uplinkPayload struct {
+	ApplicationID   int64         `json:"applicationID,string"`
+	ApplicationName string        `json:"applicationName"`
+	DeviceName      string        `json:"deviceName"`
+	DevEUI          lorawan.EUI64 `json:"devEUI"`
+	RXInfo          []rxInfo      `json:"rxInfo"`
+	TXInfo          txInfo        `json:"txInfo"`
+	FCnt            uint32        `json:"fCnt"`
+	FPort           uint8         `json:"fPort"`
+	Data            []byte        `json:"data"`
+}
+
+type rxInfo struct {
+	GatewayID lorawan.EUI64 `json:"gatewayID"`
+	Name      string        `json:"name"`
+	Time      *time.Time    `json:"time,omitempty"`
+	RSSI      int           `json:"rssi"`
+	LoRaSNR   float64       `json:"loRaSNR"`
+	Location  location      `json:"location"`
+}
+
+type location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"`
+}
+
+type txInfo struct {
+	Frequency int              `json:"frequency"`
+	DataRate  handler.DataRate `json:"dataRate"`
+	ADR       bool             `json:"adr"`
+	CodeRate  string           `json:"codeRate"`
+}
+
+// Handler implements a myDevices handler.
+type Handler struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHandler creates a new myDevices Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		endpoint: conf.Endpoint,
+		client:   httpClient,
+	}, nil
+}
+
+// Close closes the handler.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends the uplink to the myDevices endpoint.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	b, err := json.Marshal(newUplinkPayload(pl))
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	resp, err := h.client.Post(h.endpoint, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("handler/mydevices: post uplink error: %s", errors.Cause(err))
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("handler/mydevices: post uplink error: expected 2XX response, got: %d", resp.StatusCode)
+	}
+
+	log.WithFields(log.Fields{
+		"endpoint": h.endpoint,
+		"dev_eui":  pl.DevEUI,
+	}).Info("handler/mydevices: uplink sent")
+	return nil
+}
+
+// SendJoinNotification is not implemented.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return nil
+}
+
+// SendACKNotification is not implemented.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return nil
+}
+
+// SendErrorNotification is not implemented.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return nil
+}
+
+// SendSecurityNotification is not implemented.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return nil
+}
+
+// SendProprietaryUp is not implemented (proprietary payloads are not
+// related to a node).
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return nil
+}
+
+// newUplinkPayload returns the myDevices payload for the given uplink.
+func newUplinkPayload(pl handler.DataUpPayload) uplinkPayload {
+	out := uplinkPayload{
+		ApplicationID:   pl.ApplicationID,
+		ApplicationName: pl.ApplicationName,
+		DeviceName:      pl.NodeName,
+		DevEUI:          pl.DevEUI,
+		RXInfo:          make([]rxInfo, 0, len(pl.RXInfo)),
+		TXInfo: txInfo{
+			Frequency: pl.TXInfo.Frequency,
+			DataRate:  pl.TXInfo.DataRate,
+			ADR:       pl.TXInfo.ADR,
+			CodeRate:  pl.TXInfo.CodeRate,
+		},
+		FCnt:  pl.FCnt,
+		FPort: pl.FPort,
+		Data:  pl.Data,
+	}
+
+	for _, r := range pl.RXInfo {
+		out.RXInfo = append(out.RXInfo, rxInfo{
+			GatewayID: r.MAC,
+			Name:      r.Name,
+			Time:      r.Time,
+			RSSI:      r.RSSI,
+			LoRaSNR:   r.LoRaSNR,
+			Location: location{
+				Latitude:  r.Latitude,
+				Longitude: r.Longitude,
+				Altitude:  r.Altitude,
+			},
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,110 @@
+package mydeviceshandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+type testMyDevicesHandler struct {
+	requests chan *http.Request
+}
+
+func (h *testMyDevicesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	h.requests <- r
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"valid https endpoint", HandlerConfig{Endpoint: "https://lora.mydevices.com/v1/networks/example/uplink"}, nil},
+			{"missing endpoint", HandlerConfig{}, ErrInvalidEndpoint},
+			{"invalid scheme", HandlerConfig{Endpoint: "ftp://lora.mydevices.com"}, ErrInvalidEndpoint},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestHandler(t *testing.T) {
+	Convey("Given a test HTTP server and a Handler instance", t, func() {
+		httpHandler := testMyDevicesHandler{
+			requests: make(chan *http.Request, 100),
+		}
+		server := httptest.NewServer(&httpHandler)
+		defer server.Close()
+
+		h, err := NewHandler(HandlerConfig{Endpoint: server.URL + "/uplink"})
+		So(err, ShouldBeNil)
+
+		Convey("When sending an uplink", func() {
+			So(h.SendDataUp(handler.DataUpPayload{
+				ApplicationID:   1,
+				ApplicationName: "test-app",
+				NodeName:        "test-node",
+				DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				RXInfo: []handler.RXInfo{
+					{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, RSSI: -80, LoRaSNR: 7.5, Latitude: 1.5},
+				},
+				TXInfo: handler.TXInfo{Frequency: 868100000},
+				FCnt:   10,
+				FPort:  2,
+				Data:   []byte{1, 2, 3},
+			}), ShouldBeNil)
+
+			Convey("Then the uplink was posted in the myDevices format", func() {
+				So(httpHandler.requests, ShouldHaveLength, 1)
+				req := <-httpHandler.requests
+				So(req.URL.Path, ShouldEqual, "/uplink")
+				So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+
+				var pl map[string]interface{}
+				So(json.NewDecoder(req.Body).Decode(&pl), ShouldBeNil)
+				So(pl["applicationID"], ShouldEqual, "1")
+				So(pl["deviceName"], ShouldEqual, "test-node")
+				So(pl["devEUI"], ShouldEqual, "0102030405060708")
+				So(pl["data"], ShouldEqual, "AQID")
+				So(pl["rxInfo"], ShouldResemble, []interface{}{
+					map[string]interface{}{
+						"gatewayID": "0807060504030201",
+						"name":      "",
+						"rssi":      float64(-80),
+						"loRaSNR":   7.5,
+						"location": map[string]interface{}{
+							"latitude":  1.5,
+							"longitude": float64(0),
+							"altitude":  float64(0),
+						},
+					},
+				})
+			})
+		})
+
+		Convey("When sending a join notification", func() {
+			So(h.SendJoinNotification(handler.JoinNotification{}), ShouldBeNil)
+
+			Convey("Then nothing was posted", func() {
+				So(httpHandler.requests, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,121 @@
+// Package natshandler implements a handler publishing the events to NATS
+// subjects, for (internal) consumers which don't want to use MQTT or HTTP.
+package natshandler
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/nats"
+)
+
+// DefaultSubjectPrefix defines the default prefix of the subjects.
+const DefaultSubjectPrefix = "lora"
+
+// Handler implements a NATS handler. Events are published to the subjects
+// {prefix}.application.{id}.device.{devEUI}.{rx|join|ack|error|security},
+// {prefix}.application.{id}.proprietary.rx, {prefix}.application.{id}.event.{name}
+// (custom events) and {prefix}.gateway.{mac}.event.
+type Handler struct {
+	prefix    string
+	publisher *nats.Publisher
+	marshaler marshaler.Marshaler
+}
+
+// NewHandler creates a new Handler publishing to the given NATS server,
+// using the given subject prefix and marshaler type (see the marshaler
+// package). The connection is (re-)established on publishing.
+func NewHandler(server, prefix, marshalerType string) (*Handler, error) {
+	if prefix == "" || !nats.ValidSubject(prefix) {
+		return nil, fmt.Errorf("handler/nats: invalid subject prefix: %s", prefix)
+	}
+
+	m, err := marshaler.New(marshalerType)
+	if err != nil {
+		return nil, errors.Wrap(err, "handler/nats: new marshaler error")
+	}
+
+	p, err := nats.NewPublisher(server)
+	if err != nil {
+		return nil, errors.Wrap(err, "handler/nats: new publisher error")
+	}
+
+	log.WithFields(log.Fields{
+		"server": p.Host(),
+		"prefix": prefix,
+	}).Info("handler/nats: publishing events to nats")
+
+	return &Handler{
+		prefix:    prefix,
+		publisher: p,
+		marshaler: m,
+	}, nil
+}
+
+// Close closes the connection with the NATS server.
+func (h *Handler) Close() error {
+	log.Info("handler/nats: closing handler")
+	return h.publisher.Close()
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.publish(fmt.Sprintf("application.%d.device.%s.rx", pl.ApplicationID, pl.DevEUI), pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.publish(fmt.Sprintf("application.%d.device.%s.join", pl.ApplicationID, pl.DevEUI), pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.publish(fmt.Sprintf("application.%d.device.%s.ack", pl.ApplicationID, pl.DevEUI), pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.publish(fmt.Sprintf("application.%d.device.%s.error", pl.ApplicationID, pl.DevEUI), pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.publish(fmt.Sprintf("application.%d.device.%s.security", pl.ApplicationID, pl.DevEUI), pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.publish(fmt.Sprintf("application.%d.proprietary.rx", pl.ApplicationID), pl)
+}
+
+// SendCustomEvent sends a custom event.
+func (h *Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	return h.publish(fmt.Sprintf("application.%d.event.%s", pl.ApplicationID, pl.Name), pl)
+}
+
+// SendGatewayNotification sends a gateway notification.
+func (h *Handler) SendGatewayNotification(pl handler.GatewayNotification) error {
+	return h.publish(fmt.Sprintf("gateway.%s.event", pl.MAC), pl)
+}
+
+func (h *Handler) subject(s string) string {
+	return h.prefix + "." + s
+}
+
+func (h *Handler) publish(subject string, pl interface{}) error {
+	b, err := h.marshaler.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "handler/nats: marshal event error")
+	}
+
+	subject = h.subject(subject)
+	log.WithField("subject", subject).Info("handler/nats: publishing event")
+	if err := h.publisher.Publish(subject, b); err != nil {
+		return errors.Wrapf(err, "handler/nats: publish to %s error", subject)
+	}
+	return nil
+}
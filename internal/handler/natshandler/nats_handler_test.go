@@ -0,0 +1,84 @@
+package natshandler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+// testServer accepts a single connection and sends the subjects of the
+// published messages to the subjects channel.
+func testServer(ln net.Listener, subjects chan string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {}\r\n")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		parts := strings.Split(strings.TrimRight(line, "\r\n"), " ")
+		switch parts[0] {
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "PUB":
+			size, _ := strconv.Atoi(parts[2])
+			if _, err := io.ReadFull(r, make([]byte, size+2)); err != nil {
+				return
+			}
+			subjects <- parts[1]
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	Convey("Given a NATS server", t, func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer ln.Close()
+
+		subjects := make(chan string, 10)
+		go testServer(ln, subjects)
+
+		Convey("Then creating a handler with an invalid prefix fails", func() {
+			_, err := NewHandler("nats://"+ln.Addr().String(), "lora.*", "")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Given a handler", func() {
+			h, err := NewHandler("nats://"+ln.Addr().String(), DefaultSubjectPrefix, "")
+			So(err, ShouldBeNil)
+			defer h.Close()
+
+			devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+			Convey("Then the events are published to the expected subjects", func() {
+				So(h.SendDataUp(handler.DataUpPayload{ApplicationID: 1, DevEUI: devEUI}), ShouldBeNil)
+				So(<-subjects, ShouldEqual, "lora.application.1.device.0102030405060708.rx")
+
+				So(h.SendJoinNotification(handler.JoinNotification{ApplicationID: 1, DevEUI: devEUI}), ShouldBeNil)
+				So(<-subjects, ShouldEqual, "lora.application.1.device.0102030405060708.join")
+
+				So(h.SendProprietaryUp(handler.ProprietaryUpPayload{ApplicationID: 1}), ShouldBeNil)
+				So(<-subjects, ShouldEqual, "lora.application.1.proprietary.rx")
+
+				So(h.SendGatewayNotification(handler.GatewayNotification{MAC: devEUI}), ShouldBeNil)
+				So(<-subjects, ShouldEqual, "lora.gateway.0102030405060708.event")
+			})
+		})
+	})
+}
@@ -0,0 +1,468 @@
+// Package outboxhandler implements a handler which first persists all
+// events in the event outbox (PostgreSQL), before these are delivered to the
+// wrapped handler. Events are only removed from the outbox after successful
+// delivery, which guarantees at-least-once delivery (also across crashes or
+// restarts). Events which could not be delivered within the max number of
+// attempts are moved to the dead-letter store, from which these can be
+// retried. For handlers tracking the delivery per target (e.g. the
+// integrations), a retry is only delivered to the failed targets.
+//
+// The delivery is partitioned per organization, so that a burst of events
+// (or a broken integration endpoint) of one organization does not delay the
+// delivery of events of other organizations.
+package outboxhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// event types as stored in the outbox
+const (
+	dataUpType               = "data_up"
+	joinNotificationType     = "join"
+	ackNotificationType      = "ack"
+	errorNotificationType    = "error"
+	securityNotificationType = "security"
+	gatewayNotificationType  = "gateway"
+	proprietaryUpType        = "proprietary_up"
+	customEventType          = "custom"
+	deliverBatchSize         = 100
+	deliverPollInterval      = time.Second
+	deliverMaxRetryBackoff   = 10 * time.Minute
+
+	// deliverClaimTimeout defines how long the claimed events are not
+	// claimed again. Events not completed within this timeout (e.g. after a
+	// crash) are delivered again. The claimed events of which the delivery
+	// did not start within half of this timeout are released, thus the
+	// max. duration of a single delivery (including the retries of the
+	// handlers) must not exceed the other half.
+	deliverClaimTimeout = 30 * time.Minute
+)
+
+var (
+	// MaxAttempts defines the max number of delivery attempts of an event,
+	// after which the event is moved to the dead-letter store
+	// (0 = unlimited).
+	MaxAttempts = 10
+
+	// Workers defines the max number of organizations for which events
+	// are delivered concurrently.
+	Workers = 10
+
+	// OrganizationWeights contains the delivery weight per organization ID.
+	// An organization with weight n delivers n times more events per
+	// batch than an organization with the default weight of 1.
+	OrganizationWeights = map[int64]int{}
+)
+
+// Handler implements a handler.Handler which stores the events in the
+// event outbox. Use DeliverLoop to deliver these events to the wrapped
+// handler.
+type Handler struct {
+	handler handler.Handler
+
+	mu     sync.Mutex
+	active map[int64]bool
+}
+
+// NewHandler creates a new Handler wrapping the given handler.
+func NewHandler(h handler.Handler) *Handler {
+	return &Handler{
+		handler: h,
+		active:  make(map[int64]bool),
+	}
+}
+
+// SendDataUp stores the data-up payload in the outbox.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, dataUpType, pl)
+}
+
+// SendJoinNotification stores the join notification in the outbox.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, joinNotificationType, pl)
+}
+
+// SendACKNotification stores the ACK notification in the outbox.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, ackNotificationType, pl)
+}
+
+// SendErrorNotification stores the error notification in the outbox.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, errorNotificationType, pl)
+}
+
+// SendSecurityNotification stores the security notification in the outbox.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, securityNotificationType, pl)
+}
+
+// SendProprietaryUp stores the proprietary uplink payload in the outbox.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, proprietaryUpType, pl)
+}
+
+// SendCustomEvent stores the custom event in the outbox.
+func (h *Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	return createOutboxItem(common.DB, pl.ApplicationID, customEventType, pl)
+}
+
+// SendGatewayNotification stores the gateway notification in the outbox.
+func (h *Handler) SendGatewayNotification(pl handler.GatewayNotification) error {
+	return createOrganizationOutboxItem(common.DB, pl.OrganizationID, gatewayNotificationType, pl)
+}
+
+// DataDownChan returns the DataDownPayload channel of the wrapped handler.
+func (h *Handler) DataDownChan() chan handler.DataDownPayload {
+	return h.handler.DataDownChan()
+}
+
+// Close closes the wrapped handler.
+func (h *Handler) Close() error {
+	return h.handler.Close()
+}
+
+// DeliverLoop delivers the events stored in the outbox to the wrapped
+// handler. For every organization having due events, a worker is started
+// which delivers the events of this organization. This function never
+// returns.
+func (h *Handler) DeliverLoop() {
+	sem := make(chan struct{}, Workers)
+
+	for {
+		ids, err := storage.GetDueEventOutboxOrganizationIDs(common.DB)
+		if err != nil {
+			log.Errorf("get due event outbox organization ids error: %s", err)
+		}
+
+		for _, id := range ids {
+			if !h.setActive(id) {
+				// a worker is already running for this organization
+				continue
+			}
+
+			go func(id int64) {
+				defer h.unsetActive(id)
+				h.deliverOrganizationEvents(sem, id)
+			}(id)
+		}
+
+		time.Sleep(deliverPollInterval)
+	}
+}
+
+// DeliverOrganization delivers a single batch of (max. limit) due events of
+// the given organization and returns the number of processed events. In
+// contrast to DeliverLoop, only the events of the given organization are
+// delivered (e.g. to isolate the events of a load-test).
+func (h *Handler) DeliverOrganization(organizationID int64, limit int) (int, error) {
+	return h.deliverEvents(organizationID, limit)
+}
+
+// deliverOrganizationEvents delivers the due events of the given
+// organization until no due events are left. The given semaphore limits
+// the number of concurrent deliveries.
+func (h *Handler) deliverOrganizationEvents(sem chan struct{}, organizationID int64) {
+	limit := deliverBatchSize * organizationWeight(organizationID)
+
+	for {
+		sem <- struct{}{}
+		count, err := h.deliverEvents(organizationID, limit)
+		<-sem
+
+		if err != nil {
+			log.WithField("organization_id", organizationID).Errorf("deliver outbox events error: %s", err)
+			return
+		}
+
+		// in case of a full batch, there are probably more events to deliver
+		if count < limit {
+			return
+		}
+	}
+}
+
+// setActive marks the worker of the given organization as active.
+// It returns false when the worker was already active.
+func (h *Handler) setActive(organizationID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.active[organizationID] {
+		return false
+	}
+	h.active[organizationID] = true
+	return true
+}
+
+// unsetActive marks the worker of the given organization as inactive.
+func (h *Handler) unsetActive(organizationID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.active, organizationID)
+}
+
+// deliverEvents delivers the due events of the given organization to the
+// wrapped handler and returns the number of processed events. The events
+// are claimed first (see deliverClaimTimeout), so that no database rows are
+// locked while delivering. Events that could not be delivered are scheduled
+// for retry, or dead-lettered when the max number of attempts has been
+// reached. When the wrapped handler tracks the delivery per target (see
+// handler.DeliveryHandler), a retry is only sent to the failed targets and
+// only the event of the failed target is dead-lettered.
+func (h *Handler) deliverEvents(organizationID int64, limit int) (int, error) {
+	claimedAt := time.Now()
+	items, err := storage.ClaimDueEventOutboxItems(common.DB, organizationID, limit, claimedAt.Add(deliverClaimTimeout))
+	if err != nil {
+		return 0, errors.Wrap(err, "claim due event outbox items error")
+	}
+
+	for i, item := range items {
+		if time.Since(claimedAt) > deliverClaimTimeout/2 {
+			return i, releaseOutboxItems(items[i:])
+		}
+
+		d := handler.NewDelivery(item.Target, item.Delivered, deadLetterFunc(item))
+		deliverErr := h.deliver(h.deliveryHandler(d), item)
+
+		if err := storage.Transaction(common.DB, func(tx *sqlx.Tx) error {
+			return completeOutboxItem(tx, item, d, deliverErr)
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(items), nil
+}
+
+// releaseOutboxItems releases the given claimed outbox items, so that these
+// are due again.
+func releaseOutboxItems(items []storage.EventOutboxItem) error {
+	for _, item := range items {
+		item.NextAttemptAt = time.Now()
+		if err := storage.UpdateEventOutboxItem(common.DB, item); err != nil {
+			return errors.Wrap(err, "update event outbox item error")
+		}
+	}
+	return nil
+}
+
+// deliveryHandler returns the wrapped handler, tracking the delivery in
+// the given Delivery when supported.
+func (h *Handler) deliveryHandler(d *handler.Delivery) handler.Handler {
+	if dh, ok := h.handler.(handler.DeliveryHandler); ok {
+		return dh.WithDelivery(d)
+	}
+	return h.handler
+}
+
+// completeOutboxItem completes the delivery of the given outbox item,
+// given the tracked delivery and the delivery error. The event is
+// dead-lettered for the targets which dead-lettered it. When the delivery
+// failed, the event is scheduled for retry (of the failed targets) or
+// dead-lettered when the max number of attempts has been reached, else it
+// is removed from the outbox.
+func completeOutboxItem(tx *sqlx.Tx, item storage.EventOutboxItem, d *handler.Delivery, deliverErr error) error {
+	item.Attempts++
+	logFields := log.Fields{
+		"id":              item.ID,
+		"organization_id": item.OrganizationID,
+		"type":            item.Type,
+		"attempts":        item.Attempts,
+	}
+
+	for target, err := range d.DeadLettered() {
+		log.WithFields(logFields).WithField("target", target).Errorf("deliver outbox event error, moving event to dead-letter store: %s", err)
+		if err := storage.CreateEventDeadLetter(tx, &storage.EventDeadLetter{
+			OrganizationID: item.OrganizationID,
+			CreatedAt:      item.CreatedAt,
+			Type:           item.Type,
+			Payload:        item.Payload,
+			Attempts:       item.Attempts,
+			Error:          err.Error(),
+			Target:         target,
+		}); err != nil {
+			return errors.Wrap(err, "create event dead letter error")
+		}
+	}
+
+	if deliverErr == nil {
+		if err := storage.DeleteEventOutboxItem(tx, item.ID); err != nil {
+			return errors.Wrap(err, "delete event outbox item error")
+		}
+		return nil
+	}
+
+	item.Delivered = d.Delivered()
+
+	if errors.Cause(deliverErr) == handler.ErrDeadLetter {
+		log.WithFields(logFields).Errorf("deliver outbox event error, moving event to dead-letter store: %s", deliverErr)
+		if err := storage.DeadLetterEventOutboxItem(tx, item, deliverErr.Error()); err != nil {
+			return errors.Wrap(err, "dead-letter event outbox item error")
+		}
+		return nil
+	}
+
+	if MaxAttempts > 0 && item.Attempts >= MaxAttempts {
+		log.WithFields(logFields).Errorf("deliver outbox event error, max attempts reached, moving event to dead-letter store: %s", deliverErr)
+		if err := storage.DeadLetterEventOutboxItem(tx, item, deliverErr.Error()); err != nil {
+			return errors.Wrap(err, "dead-letter event outbox item error")
+		}
+		return nil
+	}
+
+	item.NextAttemptAt = time.Now().Add(retryBackoff(item.Attempts))
+	log.WithFields(logFields).Warningf("deliver outbox event error, will retry at %s: %s", item.NextAttemptAt, deliverErr)
+	if err := storage.UpdateEventOutboxItem(tx, item); err != nil {
+		return errors.Wrap(err, "update event outbox item error")
+	}
+	return nil
+}
+
+// deadLetterFunc returns the function dead-lettering the given outbox item
+// for a target, after its delivery has completed (see
+// handler.Delivery.DeadLetterFunc).
+func deadLetterFunc(item storage.EventOutboxItem) func(target string, err error) {
+	return func(target string, err error) {
+		if err := storage.CreateEventDeadLetter(common.DB, &storage.EventDeadLetter{
+			OrganizationID: item.OrganizationID,
+			CreatedAt:      item.CreatedAt,
+			Type:           item.Type,
+			Payload:        item.Payload,
+			Attempts:       item.Attempts + 1,
+			Error:          err.Error(),
+			Target:         target,
+		}); err != nil {
+			log.WithFields(log.Fields{
+				"id":     item.ID,
+				"target": target,
+			}).Errorf("create event dead letter error: %s", err)
+		}
+	}
+}
+
+// deliver delivers the given outbox item to the given handler.
+func (h *Handler) deliver(hh handler.Handler, item storage.EventOutboxItem) error {
+	switch item.Type {
+	case dataUpType:
+		var pl handler.DataUpPayload
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendDataUp(pl)
+	case joinNotificationType:
+		var pl handler.JoinNotification
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendJoinNotification(pl)
+	case ackNotificationType:
+		var pl handler.ACKNotification
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendACKNotification(pl)
+	case errorNotificationType:
+		var pl handler.ErrorNotification
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendErrorNotification(pl)
+	case securityNotificationType:
+		var pl handler.SecurityNotification
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendSecurityNotification(pl)
+	case proprietaryUpType:
+		var pl handler.ProprietaryUpPayload
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendProprietaryUp(pl)
+	case customEventType:
+		var pl handler.CustomEvent
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendCustomEvent(pl)
+	case gatewayNotificationType:
+		var pl handler.GatewayNotification
+		if err := json.Unmarshal(item.Payload, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal payload error")
+		}
+		return hh.SendGatewayNotification(pl)
+	default:
+		return fmt.Errorf("unknown event type: %s", item.Type)
+	}
+}
+
+// createOutboxItem stores the given payload in the outbox, partitioned by
+// the organization of the given application.
+func createOutboxItem(db sqlx.Queryer, applicationID int64, typ string, pl interface{}) error {
+	// in case the application does not exist (anymore), the event is
+	// stored without organization
+	var organizationID int64
+	app, err := storage.GetApplication(db, applicationID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get application error")
+	}
+	if err == nil {
+		organizationID = app.OrganizationID
+	}
+
+	return createOrganizationOutboxItem(db, organizationID, typ, pl)
+}
+
+// createOrganizationOutboxItem stores the given payload in the outbox,
+// partitioned by the given organization.
+func createOrganizationOutboxItem(db sqlx.Queryer, organizationID int64, typ string, pl interface{}) error {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal payload error")
+	}
+
+	item := storage.EventOutboxItem{
+		OrganizationID: organizationID,
+		Type:           typ,
+		Payload:        b,
+	}
+	if err := storage.CreateEventOutboxItem(db, &item); err != nil {
+		return errors.Wrap(err, "create event outbox item error")
+	}
+	return nil
+}
+
+// organizationWeight returns the delivery weight of the given organization.
+func organizationWeight(organizationID int64) int {
+	if w, ok := OrganizationWeights[organizationID]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// retryBackoff returns the (exponential) backoff duration for the given
+// number of attempts.
+func retryBackoff(attempts int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempts && backoff < deliverMaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > deliverMaxRetryBackoff {
+		backoff = deliverMaxRetryBackoff
+	}
+	return backoff
+}
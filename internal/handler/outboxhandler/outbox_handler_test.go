@@ -0,0 +1,336 @@
+package outboxhandler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type testHandler struct {
+	sendErr           error
+	dataUp            []handler.DataUpPayload
+	joinNotifications []handler.JoinNotification
+}
+
+func (h *testHandler) SendDataUp(pl handler.DataUpPayload) error {
+	if h.sendErr != nil {
+		return h.sendErr
+	}
+	h.dataUp = append(h.dataUp, pl)
+	return nil
+}
+
+func (h *testHandler) SendJoinNotification(pl handler.JoinNotification) error {
+	if h.sendErr != nil {
+		return h.sendErr
+	}
+	h.joinNotifications = append(h.joinNotifications, pl)
+	return nil
+}
+
+func (h *testHandler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.sendErr
+}
+
+func (h *testHandler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.sendErr
+}
+
+func (h *testHandler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.sendErr
+}
+
+func (h *testHandler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.sendErr
+}
+
+func (h *testHandler) SendGatewayNotification(pl handler.GatewayNotification) error {
+	return h.sendErr
+}
+
+func (h *testHandler) SendCustomEvent(pl handler.CustomEvent) error {
+	return h.sendErr
+}
+
+func (h *testHandler) DataDownChan() chan handler.DataDownPayload {
+	return nil
+}
+
+func (h *testHandler) Close() error {
+	return nil
+}
+
+// deliveryTestHandler is a handler delivering the data-up payloads to the
+// targets "ok", "failing" and "dead-letter", tracking the delivery.
+type deliveryTestHandler struct {
+	*testHandler
+	delivery *handler.Delivery
+	failing  *bool
+	calls    map[string]int
+}
+
+func (h deliveryTestHandler) WithDelivery(d *handler.Delivery) handler.Handler {
+	h.delivery = d
+	return h
+}
+
+func (h deliveryTestHandler) SendDataUp(pl handler.DataUpPayload) error {
+	var sendErr error
+	for _, target := range []string{"ok", "failing", "dead-letter"} {
+		if !h.delivery.Pending(target) {
+			continue
+		}
+		h.calls[target]++
+
+		switch {
+		case target == "failing" && *h.failing:
+			sendErr = errors.New("boom")
+		case target == "dead-letter":
+			h.delivery.SetDeadLettered(target, errors.New("dead-letter boom"))
+		default:
+			h.delivery.SetDelivered(target)
+		}
+	}
+	return sendErr
+}
+
+func TestHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and an outbox handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		th := testHandler{}
+		h := NewHandler(&th)
+
+		Convey("When sending a data-up payload and a join notification", func() {
+			pl := handler.DataUpPayload{
+				ApplicationID: app.ID,
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:          10,
+				Data:          []byte{1, 2, 3},
+			}
+			So(h.SendDataUp(pl), ShouldBeNil)
+			So(h.SendJoinNotification(handler.JoinNotification{
+				ApplicationID: app.ID,
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			}), ShouldBeNil)
+
+			Convey("Then the events are stored in the outbox and not yet delivered", func() {
+				count, err := storage.GetEventOutboxCount(common.DB)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+				So(th.dataUp, ShouldHaveLength, 0)
+			})
+
+			Convey("Then no events are due for other organizations", func() {
+				ids, err := storage.GetDueEventOutboxOrganizationIDs(common.DB)
+				So(err, ShouldBeNil)
+				So(ids, ShouldResemble, []int64{org.ID})
+
+				count, err := h.deliverEvents(org.ID+1, deliverBatchSize)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("When delivering the events", func() {
+				count, err := h.deliverEvents(org.ID, deliverBatchSize)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+
+				Convey("Then the events were delivered to the wrapped handler", func() {
+					So(th.dataUp, ShouldResemble, []handler.DataUpPayload{pl})
+					So(th.joinNotifications, ShouldHaveLength, 1)
+				})
+
+				Convey("Then the outbox is empty", func() {
+					count, err := storage.GetEventOutboxCount(common.DB)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+			})
+
+			Convey("When delivering the events fails", func() {
+				th.sendErr = errors.New("boom")
+				_, err := h.deliverEvents(org.ID, deliverBatchSize)
+				So(err, ShouldBeNil)
+
+				Convey("Then the events are scheduled for retry", func() {
+					count, err := storage.GetEventOutboxCount(common.DB)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 2)
+
+					count, err = h.deliverEvents(org.ID, deliverBatchSize)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+			})
+
+			Convey("When the max number of attempts has been reached", func() {
+				MaxAttempts = 1
+				th.sendErr = errors.New("boom")
+				_, err := h.deliverEvents(org.ID, deliverBatchSize)
+				So(err, ShouldBeNil)
+				MaxAttempts = 10
+
+				Convey("Then the events are moved to the dead-letter store", func() {
+					count, err := storage.GetEventOutboxCount(common.DB)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+
+					dls, err := storage.GetEventDeadLetters(common.DB, org.ID, 10, 0)
+					So(err, ShouldBeNil)
+					So(dls, ShouldHaveLength, 2)
+					So(dls[0].Attempts, ShouldEqual, 1)
+					So(dls[0].Error, ShouldEqual, "boom")
+				})
+
+				Convey("When retrying a dead-lettered event", func() {
+					dls, err := storage.GetEventDeadLetters(common.DB, org.ID, 10, 0)
+					So(err, ShouldBeNil)
+					So(dls, ShouldHaveLength, 2)
+					So(storage.RetryEventDeadLetter(common.DB, dls[0].ID), ShouldBeNil)
+
+					Convey("Then the event is delivered again", func() {
+						th.sendErr = nil
+						count, err := h.deliverEvents(org.ID, deliverBatchSize)
+						So(err, ShouldBeNil)
+						So(count, ShouldEqual, 1)
+						So(len(th.dataUp)+len(th.joinNotifications), ShouldEqual, 1)
+
+						count, err = storage.GetEventDeadLetterCount(common.DB, org.ID)
+						So(err, ShouldBeNil)
+						So(count, ShouldEqual, 1)
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestHandlerDelivery(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and an outbox handler tracking the delivery", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		failing := true
+		th := deliveryTestHandler{
+			testHandler: &testHandler{},
+			failing:     &failing,
+			calls:       make(map[string]int),
+		}
+		h := NewHandler(th)
+
+		Convey("When delivering a data-up payload of which one target fails", func() {
+			So(h.SendDataUp(handler.DataUpPayload{ApplicationID: app.ID}), ShouldBeNil)
+			count, err := h.deliverEvents(org.ID, deliverBatchSize)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+			So(th.calls, ShouldResemble, map[string]int{"ok": 1, "failing": 1, "dead-letter": 1})
+
+			Convey("Then the event is dead-lettered for the dead-letter target", func() {
+				dls, err := storage.GetEventDeadLetters(common.DB, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(dls, ShouldHaveLength, 1)
+				So(dls[0].Target, ShouldEqual, "dead-letter")
+				So(dls[0].Error, ShouldEqual, "dead-letter boom")
+			})
+
+			Convey("Then the retry is only delivered to the failed target", func() {
+				_, err := common.DB.Exec("update event_outbox set next_attempt_at = now()")
+				So(err, ShouldBeNil)
+
+				failing = false
+				count, err := h.deliverEvents(org.ID, deliverBatchSize)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+				So(th.calls, ShouldResemble, map[string]int{"ok": 1, "failing": 2, "dead-letter": 1})
+
+				count, err = storage.GetEventOutboxCount(common.DB)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("Then retrying the dead-lettered event only delivers it to the dead-letter target", func() {
+				dls, err := storage.GetEventDeadLetters(common.DB, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(dls, ShouldHaveLength, 1)
+				So(storage.RetryEventDeadLetter(common.DB, dls[0].ID), ShouldBeNil)
+				_, err = common.DB.Exec("delete from event_outbox where target = ''")
+				So(err, ShouldBeNil)
+
+				_, err = h.deliverEvents(org.ID, deliverBatchSize)
+				So(err, ShouldBeNil)
+				So(th.calls, ShouldResemble, map[string]int{"ok": 1, "failing": 1, "dead-letter": 2})
+			})
+		})
+	})
+}
+
+func TestOrganizationWeight(t *testing.T) {
+	Convey("Given organization 1 has weight 5", t, func() {
+		OrganizationWeights = map[int64]int{1: 5}
+		defer func() { OrganizationWeights = map[int64]int{} }()
+
+		Convey("Then the weight of organization 1 is 5", func() {
+			So(organizationWeight(1), ShouldEqual, 5)
+		})
+
+		Convey("Then the weight of organization 2 is 1", func() {
+			So(organizationWeight(2), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Attempts int
+			Expected time.Duration
+		}{
+			{1, time.Second},
+			{2, 2 * time.Second},
+			{5, 16 * time.Second},
+			{20, 10 * time.Minute},
+		}
+
+		for _, test := range tests {
+			So(retryBackoff(test.Attempts), ShouldEqual, test.Expected)
+		}
+	})
+}
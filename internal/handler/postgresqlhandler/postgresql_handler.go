@@ -0,0 +1,343 @@
+// Package postgresqlhandler implements a handler storing the events in
+// dedicated PostgreSQL tables (device_up, device_join, device_ack,
+// device_error and device_security), so that the event history can be
+// queried using SQL.
+package postgresqlhandler
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+)
+
+// maxOpenConns defines the max number of open connections per database.
+const maxOpenConns = 5
+
+// schema contains the tables (and indices) in which the events are stored.
+// These are created on the first event stored in the database.
+const schema = `
+create table if not exists device_up (
+	id bigserial primary key,
+	received_at timestamp with time zone not null,
+	application_id bigint not null,
+	application_name text not null,
+	device_name text not null,
+	dev_eui bytea not null,
+	f_cnt bigint not null,
+	f_port smallint not null,
+	adr boolean not null,
+	frequency bigint not null,
+	data_rate jsonb not null,
+	rx_info jsonb not null,
+	data bytea
+);
+create index if not exists idx_device_up_dev_eui_received_at on device_up(dev_eui, received_at);
+create index if not exists idx_device_up_application_id_received_at on device_up(application_id, received_at);
+
+create table if not exists device_join (
+	id bigserial primary key,
+	received_at timestamp with time zone not null,
+	application_id bigint not null,
+	application_name text not null,
+	device_name text not null,
+	dev_eui bytea not null,
+	dev_addr bytea not null
+);
+create index if not exists idx_device_join_dev_eui_received_at on device_join(dev_eui, received_at);
+create index if not exists idx_device_join_application_id_received_at on device_join(application_id, received_at);
+
+create table if not exists device_ack (
+	id bigserial primary key,
+	received_at timestamp with time zone not null,
+	application_id bigint not null,
+	application_name text not null,
+	device_name text not null,
+	dev_eui bytea not null,
+	reference text not null
+);
+create index if not exists idx_device_ack_dev_eui_received_at on device_ack(dev_eui, received_at);
+create index if not exists idx_device_ack_application_id_received_at on device_ack(application_id, received_at);
+
+create table if not exists device_error (
+	id bigserial primary key,
+	received_at timestamp with time zone not null,
+	application_id bigint not null,
+	application_name text not null,
+	device_name text not null,
+	dev_eui bytea not null,
+	type text not null,
+	error text not null,
+	reference text not null
+);
+create index if not exists idx_device_error_dev_eui_received_at on device_error(dev_eui, received_at);
+create index if not exists idx_device_error_application_id_received_at on device_error(application_id, received_at);
+
+create table if not exists device_security (
+	id bigserial primary key,
+	received_at timestamp with time zone not null,
+	application_id bigint not null,
+	application_name text not null,
+	device_name text not null,
+	dev_eui bytea not null,
+	type text not null,
+	message text not null
+);
+create index if not exists idx_device_security_dev_eui_received_at on device_security(dev_eui, received_at);
+create index if not exists idx_device_security_application_id_received_at on device_security(application_id, received_at);
+`
+
+// HandlerConfig contains the configuration for a PostgreSQL handler. The
+// DSN contains the credentials and is therefore stored encrypted (see the
+// secret package).
+type HandlerConfig struct {
+	DSN secret.String `json:"dsn"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	if err := ValidateDSN(string(c.DSN)); err != nil {
+		return err
+	}
+	if !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+	return nil
+}
+
+// ValidateDSN validates the format of the given DSN.
+func ValidateDSN(dsn string) error {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if _, err := pq.ParseURL(dsn); err != nil {
+			return ErrInvalidDSN
+		}
+		return nil
+	}
+	if !strings.Contains(dsn, "=") {
+		return ErrInvalidDSN
+	}
+	return nil
+}
+
+// database contains a database connection (pool) shared by the handlers
+// using the same DSN.
+type database struct {
+	db *sqlx.DB
+
+	mu            sync.Mutex
+	schemaCreated bool
+}
+
+// databases contains the databases per DSN, so that the connections are
+// re-used across the events.
+var (
+	databasesMu sync.Mutex
+	databases   = make(map[string]*database)
+)
+
+// Handler implements a PostgreSQL handler.
+type Handler struct {
+	db *database
+}
+
+// NewHandler creates a new PostgreSQL Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	databasesMu.Lock()
+	defer databasesMu.Unlock()
+
+	d, ok := databases[string(conf.DSN)]
+	if !ok {
+		db, err := sqlx.Open("postgres", string(conf.DSN))
+		if err != nil {
+			return nil, errors.Wrap(err, "open database error")
+		}
+		db.SetMaxOpenConns(maxOpenConns)
+		d = &database{db: db}
+		databases[string(conf.DSN)] = d
+	}
+
+	return &Handler{
+		db: d,
+	}, nil
+}
+
+// Close closes the handler. As the connection is shared with the other
+// handlers using the same DSN, it is not closed.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp stores the data-up payload in the device_up table.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	dataRate, err := json.Marshal(pl.TXInfo.DataRate)
+	if err != nil {
+		return errors.Wrap(err, "marshal data-rate error")
+	}
+	rxInfo, err := json.Marshal(pl.RXInfo)
+	if err != nil {
+		return errors.Wrap(err, "marshal rx-info error")
+	}
+
+	return h.insert("device_up", `
+		insert into device_up (
+			received_at,
+			application_id,
+			application_name,
+			device_name,
+			dev_eui,
+			f_cnt,
+			f_port,
+			adr,
+			frequency,
+			data_rate,
+			rx_info,
+			data
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		time.Now(),
+		pl.ApplicationID,
+		pl.ApplicationName,
+		pl.NodeName,
+		pl.DevEUI[:],
+		pl.FCnt,
+		pl.FPort,
+		pl.TXInfo.ADR,
+		pl.TXInfo.Frequency,
+		dataRate,
+		rxInfo,
+		pl.Data,
+	)
+}
+
+// SendJoinNotification stores the join notification in the device_join
+// table.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.insert("device_join", `
+		insert into device_join (
+			received_at,
+			application_id,
+			application_name,
+			device_name,
+			dev_eui,
+			dev_addr
+		) values ($1, $2, $3, $4, $5, $6)`,
+		time.Now(),
+		pl.ApplicationID,
+		pl.ApplicationName,
+		pl.NodeName,
+		pl.DevEUI[:],
+		pl.DevAddr[:],
+	)
+}
+
+// SendACKNotification stores the ACK notification in the device_ack table.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.insert("device_ack", `
+		insert into device_ack (
+			received_at,
+			application_id,
+			application_name,
+			device_name,
+			dev_eui,
+			reference
+		) values ($1, $2, $3, $4, $5, $6)`,
+		time.Now(),
+		pl.ApplicationID,
+		pl.ApplicationName,
+		pl.NodeName,
+		pl.DevEUI[:],
+		pl.Reference,
+	)
+}
+
+// SendErrorNotification stores the error notification in the device_error
+// table.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.insert("device_error", `
+		insert into device_error (
+			received_at,
+			application_id,
+			application_name,
+			device_name,
+			dev_eui,
+			type,
+			error,
+			reference
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		time.Now(),
+		pl.ApplicationID,
+		pl.ApplicationName,
+		pl.NodeName,
+		pl.DevEUI[:],
+		pl.Type,
+		pl.Error,
+		pl.Reference,
+	)
+}
+
+// SendSecurityNotification stores the security notification in the
+// device_security table.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.insert("device_security", `
+		insert into device_security (
+			received_at,
+			application_id,
+			application_name,
+			device_name,
+			dev_eui,
+			type,
+			message
+		) values ($1, $2, $3, $4, $5, $6, $7)`,
+		time.Now(),
+		pl.ApplicationID,
+		pl.ApplicationName,
+		pl.NodeName,
+		pl.DevEUI[:],
+		pl.Type,
+		pl.Message,
+	)
+}
+
+// SendProprietaryUp is not implemented, as proprietary payloads are not
+// related to a device.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return nil
+}
+
+// insert creates the schema (when not yet created) and executes the given
+// insert query.
+func (h *Handler) insert(table, query string, args ...interface{}) error {
+	if err := h.db.createSchema(); err != nil {
+		return err
+	}
+
+	if _, err := h.db.db.Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "handler/postgresql: insert into %s error", table)
+	}
+
+	log.WithField("table", table).Info("handler/postgresql: event stored")
+	return nil
+}
+
+// createSchema creates the tables in the database, when not yet created.
+func (d *database) createSchema() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.schemaCreated {
+		return nil
+	}
+
+	if _, err := d.db.Exec(schema); err != nil {
+		return errors.Wrap(err, "handler/postgresql: create schema error")
+	}
+	d.schemaCreated = true
+	return nil
+}
@@ -0,0 +1,11 @@
+package pubsubhandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidCredentials     = errors.New("Credentials file must be a valid service account JSON key file")
+	ErrInvalidProjectID       = errors.New("Project ID must be a valid GCP project ID")
+	ErrInvalidTopicName       = errors.New("Topic name must be a valid Pub/Sub topic name")
+	ErrSecretKeyNotConfigured = errors.New("Storing credentials requires the integration-secret-key to be configured")
+)
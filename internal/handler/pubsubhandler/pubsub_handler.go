@@ -0,0 +1,184 @@
+// Package pubsubhandler implements a handler publishing the events to a GCP
+// Pub/Sub topic.
+package pubsubhandler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/pubsub"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+// event types
+const (
+	uplinkEvent      = "rx"
+	joinEvent        = "join"
+	ackEvent         = "ack"
+	errorEvent       = "error"
+	securityEvent    = "security"
+	proprietaryEvent = "proprietary"
+)
+
+// message attribute names
+const (
+	applicationIDAttribute = "applicationID"
+	devEUIAttribute        = "devEUI"
+	eventTypeAttribute     = "eventType"
+)
+
+var (
+	// project ID, optionally prefixed by a domain (example.com:my-project)
+	projectIDRegexp = regexp.MustCompile(`^([a-z0-9.-]+:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	topicNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-_.~+%]{2,254}$`)
+)
+
+// HandlerConfig contains the configuration for a GCP Pub/Sub handler.
+type HandlerConfig struct {
+	// CredentialsFile contains the service account JSON key file (stored
+	// encrypted, see the secret package).
+	CredentialsFile secret.String `json:"credentialsFile"`
+
+	// ProjectID of the topic, defaults to the project of the service
+	// account.
+	ProjectID string `json:"projectID"`
+	TopicName string `json:"topicName"`
+
+	// Marshaler defines the encoding of the events (see the marshaler
+	// package).
+	Marshaler string `json:"marshaler,omitempty"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	cred, err := pubsub.ParseCredentials([]byte(c.CredentialsFile))
+	if err != nil {
+		return ErrInvalidCredentials
+	}
+	if !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+	if !projectIDRegexp.MatchString(c.projectID(cred)) {
+		return ErrInvalidProjectID
+	}
+	if !topicNameRegexp.MatchString(c.TopicName) || strings.HasPrefix(c.TopicName, "goog") {
+		return ErrInvalidTopicName
+	}
+	if err := marshaler.Validate(c.Marshaler); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c HandlerConfig) projectID(cred pubsub.Credentials) string {
+	if c.ProjectID != "" {
+		return c.ProjectID
+	}
+	return cred.ProjectID
+}
+
+// Handler implements a GCP Pub/Sub handler.
+type Handler struct {
+	topic     string
+	client    *pubsub.Client
+	marshaler marshaler.Marshaler
+}
+
+// NewHandler creates a new GCP Pub/Sub Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	cred, err := pubsub.ParseCredentials([]byte(conf.CredentialsFile))
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	m, err := marshaler.New(conf.Marshaler)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		topic: fmt.Sprintf("projects/%s/topics/%s", conf.projectID(cred), conf.TopicName),
+		client: &pubsub.Client{
+			Credentials: cred,
+		},
+		marshaler: m,
+	}, nil
+}
+
+// Close closes the handler.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, uplinkEvent, pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, joinEvent, pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, ackEvent, pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, errorEvent, pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, securityEvent, pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload. As this payload is
+// not related to a device, the devEUI attribute is omitted.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.publish(pl.ApplicationID, nil, proprietaryEvent, pl)
+}
+
+// publish publishes the given event encoded by the configured marshaler, with the application ID,
+// DevEUI and event type as message attributes (e.g. for subscription
+// filtering).
+func (h *Handler) publish(applicationID int64, devEUI *lorawan.EUI64, eventType string, pl interface{}) error {
+	b, err := h.marshaler.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	messageID, err := h.client.Publish(h.topic, b, messageAttributes(applicationID, devEUI, eventType))
+	if err != nil {
+		return fmt.Errorf("handler/pubsub: publish %s event error: %s", eventType, err)
+	}
+
+	log.WithFields(log.Fields{
+		"topic":      h.topic,
+		"event_type": eventType,
+		"message_id": messageID,
+	}).Info("handler/pubsub: event published")
+	return nil
+}
+
+// messageAttributes returns the message attributes of the given event.
+func messageAttributes(applicationID int64, devEUI *lorawan.EUI64, eventType string) map[string]string {
+	attributes := map[string]string{
+		applicationIDAttribute: strconv.FormatInt(applicationID, 10),
+		eventTypeAttribute:     eventType,
+	}
+	if devEUI != nil {
+		attributes[devEUIAttribute] = devEUI.String()
+	}
+	return attributes
+}
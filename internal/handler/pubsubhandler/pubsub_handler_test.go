@@ -0,0 +1,92 @@
+package pubsubhandler
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		So(err, ShouldBeNil)
+		credentials, err := json.Marshal(map[string]string{
+			"type":         "service_account",
+			"project_id":   "my-project",
+			"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+			"client_email": "lora-app-server@my-project.iam.gserviceaccount.com",
+		})
+		So(err, ShouldBeNil)
+
+		valid := HandlerConfig{
+			CredentialsFile: secret.String(credentials),
+			TopicName:       "lora-events",
+		}
+
+		otherProject := valid
+		otherProject.ProjectID = "other-project"
+
+		invalidCredentials := valid
+		invalidCredentials.CredentialsFile = "{}"
+
+		invalidProjectID := valid
+		invalidProjectID.ProjectID = "My Project"
+
+		invalidTopicName := valid
+		invalidTopicName.TopicName = "goog-events"
+
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"valid config", valid, nil},
+			{"topic of other project", otherProject, nil},
+			{"invalid credentials", invalidCredentials, ErrInvalidCredentials},
+			{"invalid project id", invalidProjectID, ErrInvalidProjectID},
+			{"reserved topic name", invalidTopicName, ErrInvalidTopicName},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+
+		Convey("Then a valid config without secret key returns an error", func() {
+			So(secret.SetKey(""), ShouldBeNil)
+			So(valid.Validate(), ShouldEqual, ErrSecretKeyNotConfigured)
+		})
+
+		Convey("Then the handler publishes to the topic of the project of the service account", func() {
+			h, err := NewHandler(valid)
+			So(err, ShouldBeNil)
+			So(h.topic, ShouldEqual, "projects/my-project/topics/lora-events")
+		})
+	})
+}
+
+func TestMessageAttributes(t *testing.T) {
+	Convey("Given an uplink event of a device", t, func() {
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then the attributes contain the application ID, DevEUI and event type", func() {
+			So(messageAttributes(123, &devEUI, uplinkEvent), ShouldResemble, map[string]string{
+				"applicationID": "123",
+				"devEUI":        "0102030405060708",
+				"eventType":     "rx",
+			})
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package snshandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidRegion          = errors.New("Region must be a valid AWS region (e.g. eu-west-1)")
+	ErrInvalidTopicARN        = errors.New("Topic ARN must be formatted as arn:aws:sns:region:account-id:topic-name")
+	ErrInvalidCredentials     = errors.New("Access key ID and secret access key must be set")
+	ErrInvalidMarshaler       = errors.New("The PROTOBUF marshaler is not supported (SNS messages must be valid UTF-8)")
+	ErrSecretKeyNotConfigured = errors.New("Storing credentials requires the integration-secret-key to be configured")
+)
@@ -0,0 +1,171 @@
+// Package snshandler implements a handler publishing the events to an AWS
+// SNS topic.
+package snshandler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/marshaler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lora-app-server/internal/sns"
+	"github.com/brocaar/lorawan"
+)
+
+// event types
+const (
+	uplinkEvent      = "rx"
+	joinEvent        = "join"
+	ackEvent         = "ack"
+	errorEvent       = "error"
+	securityEvent    = "security"
+	proprietaryEvent = "proprietary"
+)
+
+// message attribute names
+const (
+	applicationIDAttribute = "applicationID"
+	devEUIAttribute        = "devEUI"
+	eventTypeAttribute     = "eventType"
+)
+
+// HandlerConfig contains the configuration for an AWS SNS handler. The
+// SecretAccessKey is stored encrypted (see the secret package).
+type HandlerConfig struct {
+	Region          string        `json:"region"`
+	AccessKeyID     string        `json:"accessKeyID"`
+	SecretAccessKey secret.String `json:"secretAccessKey"`
+	TopicARN        string        `json:"topicARN"`
+	Marshaler       string        `json:"marshaler,omitempty"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	if !sns.ValidRegion(c.Region) {
+		return ErrInvalidRegion
+	}
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return ErrInvalidCredentials
+	}
+	if !secret.Enabled() {
+		return ErrSecretKeyNotConfigured
+	}
+
+	// arn:partition:sns:region:account-id:topic-name
+	parts := strings.Split(c.TopicARN, ":")
+	if len(parts) != 6 || parts[0] != "arn" || !strings.HasPrefix(parts[1], "aws") || parts[2] != "sns" || parts[3] != c.Region || parts[5] == "" {
+		return ErrInvalidTopicARN
+	}
+	if err := marshaler.Validate(c.Marshaler); err != nil {
+		return err
+	}
+	// SNS messages must be valid UTF-8
+	if c.Marshaler == marshaler.Protobuf {
+		return ErrInvalidMarshaler
+	}
+	return nil
+}
+
+// Handler implements an AWS SNS handler.
+type Handler struct {
+	config    HandlerConfig
+	client    *sns.Client
+	marshaler marshaler.Marshaler
+}
+
+// NewHandler creates a new AWS SNS Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	if conf.Marshaler == marshaler.Protobuf {
+		return nil, ErrInvalidMarshaler
+	}
+	m, err := marshaler.New(conf.Marshaler)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		config: conf,
+		client: &sns.Client{
+			Region:          conf.Region,
+			AccessKeyID:     conf.AccessKeyID,
+			SecretAccessKey: string(conf.SecretAccessKey),
+		},
+		marshaler: m,
+	}, nil
+}
+
+// Close closes the handler.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, uplinkEvent, pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, joinEvent, pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, ackEvent, pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, errorEvent, pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.publish(pl.ApplicationID, &pl.DevEUI, securityEvent, pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload. As this payload is
+// not related to a device, the devEUI attribute is omitted.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.publish(pl.ApplicationID, nil, proprietaryEvent, pl)
+}
+
+// publish publishes the given event encoded by the configured marshaler, with the application ID,
+// DevEUI and event type as message attributes (for SNS subscription
+// filtering).
+func (h *Handler) publish(applicationID int64, devEUI *lorawan.EUI64, eventType string, pl interface{}) error {
+	b, err := h.marshaler.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	attributes := messageAttributes(applicationID, devEUI, eventType)
+	messageID, err := h.client.Publish(h.config.TopicARN, string(b), attributes)
+	if err != nil {
+		return fmt.Errorf("handler/sns: publish %s event error: %s", eventType, err)
+	}
+
+	log.WithFields(log.Fields{
+		"topic_arn":  h.config.TopicARN,
+		"event_type": eventType,
+		"message_id": messageID,
+	}).Info("handler/sns: event published")
+	return nil
+}
+
+// messageAttributes returns the message attributes of the given event.
+func messageAttributes(applicationID int64, devEUI *lorawan.EUI64, eventType string) map[string]string {
+	attributes := map[string]string{
+		applicationIDAttribute: strconv.FormatInt(applicationID, 10),
+		eventTypeAttribute:     eventType,
+	}
+	if devEUI != nil {
+		attributes[devEUIAttribute] = devEUI.String()
+	}
+	return attributes
+}
@@ -0,0 +1,82 @@
+package snshandler
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lorawan"
+)
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		So(secret.SetKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), ShouldBeNil)
+		defer secret.SetKey("")
+
+		valid := HandlerConfig{
+			Region:          "eu-west-1",
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+			TopicARN:        "arn:aws:sns:eu-west-1:123456789012:lora-events",
+		}
+
+		invalidRegion := valid
+		invalidRegion.Region = "eu-west"
+
+		noCredentials := valid
+		noCredentials.SecretAccessKey = ""
+
+		otherRegionARN := valid
+		otherRegionARN.TopicARN = "arn:aws:sns:us-east-1:123456789012:lora-events"
+
+		sqsARN := valid
+		sqsARN.TopicARN = "arn:aws:sqs:eu-west-1:123456789012:lora-events"
+
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"valid config", valid, nil},
+			{"invalid region", invalidRegion, ErrInvalidRegion},
+			{"missing secret access key", noCredentials, ErrInvalidCredentials},
+			{"topic arn of other region", otherRegionARN, ErrInvalidTopicARN},
+			{"non sns arn", sqsARN, ErrInvalidTopicARN},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+
+		Convey("Then a valid config without secret key returns an error", func() {
+			So(secret.SetKey(""), ShouldBeNil)
+			So(valid.Validate(), ShouldEqual, ErrSecretKeyNotConfigured)
+		})
+	})
+}
+
+func TestMessageAttributes(t *testing.T) {
+	Convey("Given an uplink event of a device", t, func() {
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then the attributes contain the application ID, DevEUI and event type", func() {
+			So(messageAttributes(123, &devEUI, uplinkEvent), ShouldResemble, map[string]string{
+				"applicationID": "123",
+				"devEUI":        "0102030405060708",
+				"eventType":     "rx",
+			})
+		})
+	})
+
+	Convey("Given a proprietary uplink event", t, func() {
+		Convey("Then the attributes do not contain the DevEUI", func() {
+			So(messageAttributes(123, nil, proprietaryEvent), ShouldResemble, map[string]string{
+				"applicationID": "123",
+				"eventType":     "proprietary",
+			})
+		})
+	})
+}
@@ -0,0 +1,258 @@
+// Package sockethandler implements a handler publishing the events as
+// newline delimited JSON (NDJSON) on a UNIX domain socket, for consumers
+// running on the same host.
+package sockethandler
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+)
+
+// event types
+const (
+	uplinkType   = "uplink"
+	joinType     = "join"
+	ackType      = "ack"
+	errorType    = "error"
+	securityType = "security"
+	gatewayType  = "gateway"
+	propUpType   = "proprietary"
+	customType   = "custom"
+)
+
+const (
+	// clientBufferSize defines the number of events buffered per client.
+	// When the buffer of a (slow) client is full, events are dropped for
+	// this client.
+	clientBufferSize = 256
+	writeTimeout     = time.Second
+)
+
+// Event defines the structure of an event written to the socket.
+type Event struct {
+	Type    string      `json:"type"`
+	Payload interface{} `json:"payload"`
+}
+
+// Handler implements a UNIX domain socket handler. All connected clients
+// receive all events. As events are only written to the clients connected
+// at the time of the event, publishing never fails.
+type Handler struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	closed  bool
+	clients map[*client]struct{}
+}
+
+type client struct {
+	conn   net.Conn
+	events chan []byte
+}
+
+// NewHandler creates a new Handler listening on the given socket path.
+// An existing (stale) socket file at the given path is removed.
+func NewHandler(path string) (*Handler, error) {
+	if fi, err := os.Stat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, errors.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Wrap(err, "remove socket error")
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen error")
+	}
+
+	h := Handler{
+		listener: ln,
+		clients:  make(map[*client]struct{}),
+	}
+
+	h.wg.Add(1)
+	go h.acceptLoop()
+
+	log.WithField("path", path).Info("handler/socket: listening for clients")
+	return &h, nil
+}
+
+// Close closes the listener and disconnects all clients.
+func (h *Handler) Close() error {
+	log.Info("handler/socket: closing handler")
+	err := h.listener.Close()
+
+	h.mu.Lock()
+	h.closed = true
+	for c := range h.clients {
+		h.removeClient(c)
+	}
+	h.mu.Unlock()
+
+	h.wg.Wait()
+	return err
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	return h.publish(uplinkType, pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	return h.publish(joinType, pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return h.publish(ackType, pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return h.publish(errorType, pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return h.publish(securityType, pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return h.publish(propUpType, pl)
+}
+
+// SendCustomEvent sends a custom event.
+func (h *Handler) SendCustomEvent(pl handler.CustomEvent) error {
+	return h.publish(customType, pl)
+}
+
+// SendGatewayNotification sends a gateway notification.
+func (h *Handler) SendGatewayNotification(pl handler.GatewayNotification) error {
+	return h.publish(gatewayType, pl)
+}
+
+func (h *Handler) publish(typ string, pl interface{}) error {
+	b, err := json.Marshal(Event{
+		Type:    typ,
+		Payload: pl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "handler/socket: marshal json error")
+	}
+	b = append(b, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.clients {
+		select {
+		case c.events <- b:
+		default:
+			log.WithField("type", typ).Warning("handler/socket: client buffer is full, dropping event")
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) acceptLoop() {
+	defer h.wg.Done()
+
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				log.Errorf("handler/socket: accept error: %s", err)
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			// the listener has been closed
+			return
+		}
+
+		c := client{
+			conn:   conn,
+			events: make(chan []byte, clientBufferSize),
+		}
+
+		h.mu.Lock()
+		if h.closed {
+			h.mu.Unlock()
+			conn.Close()
+			return
+		}
+		h.clients[&c] = struct{}{}
+		h.mu.Unlock()
+
+		log.Info("handler/socket: client connected")
+
+		h.wg.Add(2)
+		go h.writeLoop(&c)
+		go h.readLoop(&c)
+	}
+}
+
+// writeLoop writes the events of the client until the client has been
+// removed.
+func (h *Handler) writeLoop(c *client) {
+	defer h.wg.Done()
+
+	for b := range c.events {
+		c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := c.conn.Write(b); err != nil {
+			log.Errorf("handler/socket: write error: %s", err)
+
+			h.mu.Lock()
+			h.removeClient(c)
+			h.mu.Unlock()
+
+			// drain the remaining events
+			for range c.events {
+			}
+			return
+		}
+	}
+}
+
+// readLoop detects the disconnection of the client. Data sent by the client
+// is ignored.
+func (h *Handler) readLoop(c *client) {
+	defer h.wg.Done()
+
+	buf := make([]byte, 512)
+	for {
+		if _, err := c.conn.Read(buf); err != nil {
+			break
+		}
+	}
+
+	h.mu.Lock()
+	h.removeClient(c)
+	h.mu.Unlock()
+}
+
+// removeClient closes and removes the given client. The caller must hold
+// the lock.
+func (h *Handler) removeClient(c *client) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+
+	delete(h.clients, c)
+	close(c.events)
+	c.conn.Close()
+	log.Info("handler/socket: client disconnected")
+}
@@ -0,0 +1,90 @@
+package sockethandler
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+func TestHandler(t *testing.T) {
+	Convey("Given a socket handler", t, func() {
+		dir, err := ioutil.TempDir("", "sockethandler")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		h, err := NewHandler(filepath.Join(dir, "events.sock"))
+		So(err, ShouldBeNil)
+		defer h.Close()
+
+		Convey("Given a connected client", func() {
+			conn, err := net.Dial("unix", filepath.Join(dir, "events.sock"))
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			// wait until the client has been accepted
+			for i := 0; i < 100 && h.clientCount() == 0; i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+			So(h.clientCount(), ShouldEqual, 1)
+			r := bufio.NewReader(conn)
+
+			Convey("When sending a data-up payload", func() {
+				pl := handler.DataUpPayload{
+					ApplicationID: 123,
+					DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					FCnt:          10,
+				}
+				So(h.SendDataUp(pl), ShouldBeNil)
+
+				Convey("Then the event is received as a JSON line", func() {
+					line, err := r.ReadBytes('\n')
+					So(err, ShouldBeNil)
+
+					var event struct {
+						Type    string                `json:"type"`
+						Payload handler.DataUpPayload `json:"payload"`
+					}
+					So(json.Unmarshal(line, &event), ShouldBeNil)
+					So(event.Type, ShouldEqual, "uplink")
+					So(event.Payload, ShouldResemble, pl)
+				})
+			})
+
+			Convey("When the client disconnects", func() {
+				conn.Close()
+				for i := 0; i < 100 && h.clientCount() != 0; i++ {
+					time.Sleep(10 * time.Millisecond)
+				}
+
+				Convey("Then the client is removed", func() {
+					So(h.clientCount(), ShouldEqual, 0)
+					So(h.SendJoinNotification(handler.JoinNotification{}), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("When sending a payload without connected clients", func() {
+			err := h.SendErrorNotification(handler.ErrorNotification{})
+
+			Convey("Then no error is returned", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func (h *Handler) clientCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
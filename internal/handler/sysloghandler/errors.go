@@ -0,0 +1,11 @@
+package sysloghandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidNetwork  = errors.New("Network must be udp, tcp or tls")
+	ErrInvalidServer   = errors.New("Server must be formatted as hostname:port")
+	ErrInvalidFacility = errors.New("Facility must be between 0 and 23")
+	ErrInvalidCACert   = errors.New("Invalid CA certificate")
+)
@@ -0,0 +1,199 @@
+// Package sysloghandler implements a handler forwarding the events as
+// RFC5424 syslog messages.
+package sysloghandler
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/syslog"
+	"github.com/brocaar/lorawan"
+)
+
+// sdID defines the ID of the structured data element holding the event
+// fields.
+const sdID = "lora@32473"
+
+// message IDs
+const (
+	uplinkMsgID   = "UPLINK"
+	joinMsgID     = "JOIN"
+	ackMsgID      = "ACK"
+	errorMsgID    = "ERROR"
+	securityMsgID = "SECURITY"
+	propUpMsgID   = "PROPRIETARY"
+)
+
+// HandlerConfig contains the configuration for a syslog handler.
+type HandlerConfig struct {
+	Network  string `json:"network"`
+	Server   string `json:"server"`
+	CACert   string `json:"caCert,omitempty"`
+	Facility int    `json:"facility"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	switch c.Network {
+	case syslog.UDP, syslog.TCP, syslog.TLS:
+	default:
+		return ErrInvalidNetwork
+	}
+	if _, port, err := net.SplitHostPort(c.Server); err != nil || port == "" {
+		return ErrInvalidServer
+	}
+	if c.Facility < 0 || c.Facility > 23 {
+		return ErrInvalidFacility
+	}
+	if c.CACert != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(c.CACert)) {
+			return ErrInvalidCACert
+		}
+	}
+	return nil
+}
+
+// writers contains the syslog writers per configuration, so that the
+// connections are re-used across the events.
+var (
+	writersMu sync.Mutex
+	writers   = make(map[HandlerConfig]*syslog.Writer)
+)
+
+// Handler implements a syslog handler.
+type Handler struct {
+	config HandlerConfig
+	writer *syslog.Writer
+}
+
+// NewHandler creates a new syslog Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+
+	w, ok := writers[conf]
+	if !ok {
+		var tlsConfig *tls.Config
+		if conf.CACert != "" {
+			certPool := x509.NewCertPool()
+			if !certPool.AppendCertsFromPEM([]byte(conf.CACert)) {
+				return nil, ErrInvalidCACert
+			}
+			tlsConfig = &tls.Config{RootCAs: certPool}
+		}
+
+		var err error
+		w, err = syslog.NewWriter(conf.Network, conf.Server, tlsConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "new syslog writer error")
+		}
+		writers[conf] = w
+	}
+
+	return &Handler{
+		config: conf,
+		writer: w,
+	}, nil
+}
+
+// Close closes the handler. As the connection is shared with the other
+// handlers using the same configuration, it is not closed.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends a data-up payload.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	params := eventParams(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI)
+	params = append(params,
+		syslog.SDParam{Name: "fCnt", Value: strconv.FormatUint(uint64(pl.FCnt), 10)},
+		syslog.SDParam{Name: "fPort", Value: strconv.Itoa(int(pl.FPort))},
+	)
+	return h.send(uplinkMsgID, syslog.Informational, pl.DevEUI, params, pl)
+}
+
+// SendJoinNotification sends a join notification.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	params := eventParams(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI)
+	params = append(params, syslog.SDParam{Name: "devAddr", Value: pl.DevAddr.String()})
+	return h.send(joinMsgID, syslog.Informational, pl.DevEUI, params, pl)
+}
+
+// SendACKNotification sends an ACK notification.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	params := eventParams(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI)
+	params = append(params, syslog.SDParam{Name: "reference", Value: pl.Reference})
+	return h.send(ackMsgID, syslog.Informational, pl.DevEUI, params, pl)
+}
+
+// SendErrorNotification sends an error notification.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	params := eventParams(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI)
+	params = append(params, syslog.SDParam{Name: "type", Value: pl.Type})
+	return h.send(errorMsgID, syslog.Error, pl.DevEUI, params, pl)
+}
+
+// SendSecurityNotification sends a security notification.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	params := eventParams(pl.ApplicationID, pl.ApplicationName, pl.NodeName, pl.DevEUI)
+	params = append(params, syslog.SDParam{Name: "type", Value: pl.Type})
+	return h.send(securityMsgID, syslog.Warning, pl.DevEUI, params, pl)
+}
+
+// SendProprietaryUp sends a proprietary uplink payload.
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	params := []syslog.SDParam{
+		{Name: "applicationID", Value: strconv.FormatInt(pl.ApplicationID, 10)},
+		{Name: "applicationName", Value: pl.ApplicationName},
+	}
+	return h.send(propUpMsgID, syslog.Informational, lorawan.EUI64{}, params, pl)
+}
+
+// send sends the given event, with the JSON encoded payload as message.
+func (h *Handler) send(msgID string, severity syslog.Severity, devEUI lorawan.EUI64, params []syslog.SDParam, pl interface{}) error {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	log.WithFields(log.Fields{
+		"server":  h.config.Server,
+		"dev_eui": devEUI,
+		"msg_id":  msgID,
+	}).Info("handler/syslog: publishing event")
+
+	err = h.writer.Write(syslog.Message{
+		Facility:  syslog.Facility(h.config.Facility),
+		Severity:  severity,
+		Timestamp: time.Now(),
+		AppName:   "lora-app-server",
+		MsgID:     msgID,
+		StructuredData: []syslog.SDElement{
+			{ID: sdID, Params: params},
+		},
+		Message: string(b),
+	})
+	if err != nil {
+		return fmt.Errorf("handler/syslog: write %s event error: %s", msgID, err)
+	}
+	return nil
+}
+
+func eventParams(applicationID int64, applicationName, nodeName string, devEUI lorawan.EUI64) []syslog.SDParam {
+	return []syslog.SDParam{
+		{Name: "applicationID", Value: strconv.FormatInt(applicationID, 10)},
+		{Name: "applicationName", Value: applicationName},
+		{Name: "nodeName", Value: nodeName},
+		{Name: "devEUI", Value: devEUI.String()},
+	}
+}
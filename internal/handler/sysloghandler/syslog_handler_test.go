@@ -0,0 +1,84 @@
+package sysloghandler
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lorawan"
+)
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"valid config", HandlerConfig{Network: "udp", Server: "localhost:514", Facility: 16}, nil},
+			{"invalid network", HandlerConfig{Network: "foo", Server: "localhost:514"}, ErrInvalidNetwork},
+			{"server without port", HandlerConfig{Network: "tcp", Server: "localhost"}, ErrInvalidServer},
+			{"invalid facility", HandlerConfig{Network: "tcp", Server: "localhost:514", Facility: 24}, ErrInvalidFacility},
+			{"invalid ca cert", HandlerConfig{Network: "tls", Server: "localhost:6514", CACert: "foo"}, ErrInvalidCACert},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestHandler(t *testing.T) {
+	Convey("Given a UDP listener and a syslog handler", t, func() {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer conn.Close()
+
+		h, err := NewHandler(HandlerConfig{
+			Network:  "udp",
+			Server:   conn.LocalAddr().String(),
+			Facility: 16,
+		})
+		So(err, ShouldBeNil)
+
+		readMessage := func() string {
+			b := make([]byte, 4096)
+			n, _, err := conn.ReadFrom(b)
+			So(err, ShouldBeNil)
+			return string(b[:n])
+		}
+
+		Convey("Then SendDataUp sends the expected syslog message", func() {
+			So(h.SendDataUp(handler.DataUpPayload{
+				ApplicationID:   123,
+				ApplicationName: "test-app",
+				NodeName:        "test-node",
+				DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:            10,
+				FPort:           2,
+			}), ShouldBeNil)
+
+			msg := readMessage()
+			So(strings.HasPrefix(msg, "<134>1 "), ShouldBeTrue)
+			So(msg, ShouldContainSubstring, ` lora-app-server - UPLINK [lora@32473 applicationID="123" applicationName="test-app" nodeName="test-node" devEUI="0102030405060708" fCnt="10" fPort="2"] {"applicationID":"123"`)
+		})
+
+		Convey("Then SendSecurityNotification sends the expected syslog message", func() {
+			So(h.SendSecurityNotification(handler.SecurityNotification{
+				ApplicationID: 123,
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Type:          "MIC_FAILURE",
+			}), ShouldBeNil)
+
+			msg := readMessage()
+			So(strings.HasPrefix(msg, "<132>1 "), ShouldBeTrue)
+			So(msg, ShouldContainSubstring, ` SECURITY [lora@32473 `)
+			So(msg, ShouldContainSubstring, ` type="MIC_FAILURE"]`)
+		})
+	})
+}
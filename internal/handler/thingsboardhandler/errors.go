@@ -0,0 +1,8 @@
+package thingsboardhandler
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidServer = errors.New("Server must be a valid http or https URL")
+)
@@ -0,0 +1,194 @@
+// Package thingsboardhandler implements a handler sending the uplinks of
+// the nodes to ThingsBoard, using the ThingsBoard HTTP device API.
+package thingsboardhandler
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/egress"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// AccessTokenVariable defines the name of the node variable containing the
+// ThingsBoard access token of the node. Events of nodes without this
+// variable are not sent to ThingsBoard.
+const AccessTokenVariable = "ThingsBoardAccessToken"
+
+var httpClient = egress.NewClient(10 * time.Second)
+
+// HandlerConfig contains the configuration for a ThingsBoard handler.
+type HandlerConfig struct {
+	// Server contains the URL of the ThingsBoard server (e.g.
+	// https://thingsboard.example.com).
+	Server string `json:"server"`
+}
+
+// Validate validates the HandlerConfig data.
+func (c HandlerConfig) Validate() error {
+	u, err := url.Parse(c.Server)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidServer
+	}
+	return nil
+}
+
+// Handler implements a ThingsBoard handler. For each uplink, the node
+// (and application) information is sent as client attributes and the
+// uplink data and radio metadata as telemetry.
+type Handler struct {
+	server string
+	client *http.Client
+}
+
+// NewHandler creates a new ThingsBoard Handler.
+func NewHandler(conf HandlerConfig) (*Handler, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		server: strings.TrimRight(conf.Server, "/"),
+		client: httpClient,
+	}, nil
+}
+
+// Close closes the handler.
+func (h *Handler) Close() error {
+	return nil
+}
+
+// SendDataUp sends the uplink as attributes and telemetry.
+func (h *Handler) SendDataUp(pl handler.DataUpPayload) error {
+	token, err := getAccessToken(pl.DevEUI)
+	if err != nil || token == "" {
+		return err
+	}
+
+	if err := h.send(token, "attributes", nodeAttributes(pl.ApplicationID, pl.ApplicationName, pl.DevEUI, pl.NodeName)); err != nil {
+		return err
+	}
+	return h.send(token, "telemetry", uplinkTelemetry(pl))
+}
+
+// SendJoinNotification sends the node attributes, including the DevAddr.
+func (h *Handler) SendJoinNotification(pl handler.JoinNotification) error {
+	token, err := getAccessToken(pl.DevEUI)
+	if err != nil || token == "" {
+		return err
+	}
+
+	attributes := nodeAttributes(pl.ApplicationID, pl.ApplicationName, pl.DevEUI, pl.NodeName)
+	attributes["devAddr"] = pl.DevAddr.String()
+	return h.send(token, "attributes", attributes)
+}
+
+// SendACKNotification is not implemented.
+func (h *Handler) SendACKNotification(pl handler.ACKNotification) error {
+	return nil
+}
+
+// SendErrorNotification is not implemented.
+func (h *Handler) SendErrorNotification(pl handler.ErrorNotification) error {
+	return nil
+}
+
+// SendSecurityNotification is not implemented.
+func (h *Handler) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return nil
+}
+
+// SendProprietaryUp is not implemented (proprietary payloads are not
+// related to a node).
+func (h *Handler) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return nil
+}
+
+// send posts the given values to the attributes or telemetry endpoint of the
+// device identified by the given access token.
+func (h *Handler) send(token, endpoint string, values map[string]interface{}) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	u := fmt.Sprintf("%s/api/v1/%s/%s", h.server, url.PathEscape(token), endpoint)
+	resp, err := h.client.Post(u, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("handler/thingsboard: post %s error: %s", endpoint, errors.Cause(err))
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("handler/thingsboard: post %s error: expected 2XX response, got: %d", endpoint, resp.StatusCode)
+	}
+
+	log.WithFields(log.Fields{
+		"server":   h.server,
+		"endpoint": endpoint,
+	}).Info("handler/thingsboard: values sent")
+	return nil
+}
+
+// getAccessToken returns the ThingsBoard access token of the given node. An
+// empty token is returned when the node does not have the
+// AccessTokenVariable.
+func getAccessToken(devEUI lorawan.EUI64) (string, error) {
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return "", errors.Wrap(err, "get node error")
+	}
+
+	token := node.Variables[AccessTokenVariable]
+	if token == "" {
+		log.WithField("dev_eui", devEUI).Debugf("handler/thingsboard: node has no %s variable, skipping event", AccessTokenVariable)
+	}
+	return token, nil
+}
+
+// nodeAttributes returns the client attributes of the given node.
+func nodeAttributes(applicationID int64, applicationName string, devEUI lorawan.EUI64, nodeName string) map[string]interface{} {
+	return map[string]interface{}{
+		"applicationID":   applicationID,
+		"applicationName": applicationName,
+		"devEUI":          devEUI.String(),
+		"nodeName":        nodeName,
+	}
+}
+
+// uplinkTelemetry returns the telemetry of the given uplink. The RSSI and
+// SNR are those of the gateway with the best reception.
+func uplinkTelemetry(pl handler.DataUpPayload) map[string]interface{} {
+	telemetry := map[string]interface{}{
+		"fCnt":         pl.FCnt,
+		"fPort":        pl.FPort,
+		"data":         hex.EncodeToString(pl.Data),
+		"frequency":    pl.TXInfo.Frequency,
+		"spreadFactor": pl.TXInfo.DataRate.SpreadFactor,
+		"gateways":     len(pl.RXInfo),
+	}
+
+	for i, rxInfo := range pl.RXInfo {
+		if i == 0 || rxInfo.LoRaSNR > telemetry["loRaSNR"].(float64) {
+			telemetry["rssi"] = rxInfo.RSSI
+			telemetry["loRaSNR"] = rxInfo.LoRaSNR
+		}
+	}
+
+	return telemetry
+}
@@ -0,0 +1,152 @@
+package thingsboardhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type testThingsBoardHandler struct {
+	requests chan *http.Request
+}
+
+func (h *testThingsBoardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	h.requests <- r
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestHandlerConfig(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Config        HandlerConfig
+			ExpectedError error
+		}{
+			{"valid https server", HandlerConfig{Server: "https://thingsboard.example.com"}, nil},
+			{"valid http server with port", HandlerConfig{Server: "http://localhost:8080/"}, nil},
+			{"missing server", HandlerConfig{}, ErrInvalidServer},
+			{"invalid scheme", HandlerConfig{Server: "ftp://thingsboard.example.com"}, ErrInvalidServer},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Config.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestUplinkTelemetry(t *testing.T) {
+	Convey("Given an uplink received by two gateways", t, func() {
+		pl := handler.DataUpPayload{
+			FCnt:  10,
+			FPort: 2,
+			Data:  []byte{1, 2, 3},
+			RXInfo: []handler.RXInfo{
+				{RSSI: -120, LoRaSNR: -5},
+				{RSSI: -80, LoRaSNR: 7.5},
+			},
+			TXInfo: handler.TXInfo{
+				Frequency: 868100000,
+				DataRate:  handler.DataRate{SpreadFactor: 12},
+			},
+		}
+
+		Convey("Then the telemetry contains the metadata of the best gateway", func() {
+			So(uplinkTelemetry(pl), ShouldResemble, map[string]interface{}{
+				"fCnt":         uint32(10),
+				"fPort":        uint8(2),
+				"data":         "010203",
+				"frequency":    868100000,
+				"spreadFactor": 12,
+				"gateways":     2,
+				"rssi":         -80,
+				"loRaSNR":      7.5,
+			})
+		})
+	})
+}
+
+func TestHandler(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a node, a ThingsBoard server and handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{Name: "test-org"}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{Name: "test-app", OrganizationID: org.ID}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+		node := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateNode(common.DB, node), ShouldBeNil)
+
+		httpHandler := testThingsBoardHandler{
+			requests: make(chan *http.Request, 100),
+		}
+		server := httptest.NewServer(&httpHandler)
+		defer server.Close()
+
+		h, err := NewHandler(HandlerConfig{Server: server.URL + "/"})
+		So(err, ShouldBeNil)
+
+		pl := handler.DataUpPayload{
+			ApplicationID:   app.ID,
+			ApplicationName: "test-app",
+			NodeName:        "test-node",
+			DevEUI:          node.DevEUI,
+			FCnt:            10,
+			Data:            []byte{1, 2, 3},
+		}
+
+		Convey("When the node does not have an access token", func() {
+			So(h.SendDataUp(pl), ShouldBeNil)
+
+			Convey("Then nothing was sent", func() {
+				So(httpHandler.requests, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the node has an access token", func() {
+			node.Variables = storage.NodeVariables{AccessTokenVariable: "secret-token"}
+			So(storage.UpdateNode(common.DB, node), ShouldBeNil)
+
+			So(h.SendDataUp(pl), ShouldBeNil)
+
+			Convey("Then the attributes and telemetry were sent", func() {
+				req := <-httpHandler.requests
+				So(req.URL.Path, ShouldEqual, "/api/v1/secret-token/attributes")
+				var attributes map[string]interface{}
+				So(json.NewDecoder(req.Body).Decode(&attributes), ShouldBeNil)
+				So(attributes["devEUI"], ShouldEqual, "0102030405060708")
+				So(attributes["nodeName"], ShouldEqual, "test-node")
+
+				req = <-httpHandler.requests
+				So(req.URL.Path, ShouldEqual, "/api/v1/secret-token/telemetry")
+				var telemetry map[string]interface{}
+				So(json.NewDecoder(req.Body).Decode(&telemetry), ShouldBeNil)
+				So(telemetry["fCnt"], ShouldEqual, 10)
+				So(telemetry["data"], ShouldEqual, "010203")
+			})
+		})
+	})
+}
@@ -0,0 +1,217 @@
+// Package i18n implements the message catalogs used to translate the API
+// error messages and the UI strings. English is the source language, other
+// languages are loaded from JSON catalog files.
+package i18n
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultLanguage defines the (source) language of the messages.
+const DefaultLanguage = "en"
+
+// errorSeparator separates the parts of composed error messages, e.g.
+// "authentication failed: object does not exist".
+const errorSeparator = ": "
+
+// Catalog contains the translations of a single language. The keys are the
+// English messages (errors) or the UI string identifiers (ui).
+type Catalog struct {
+	Errors map[string]string `json:"errors"`
+	UI     map[string]string `json:"ui"`
+}
+
+var (
+	mux      sync.RWMutex
+	catalogs = map[string]Catalog{}
+)
+
+// LoadCatalogs loads the catalogs from the given directory. Each catalog is
+// a JSON file named after the language tag, e.g. de.json or pt-BR.json. The
+// previously loaded catalogs are replaced.
+func LoadCatalogs(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return errors.Wrap(err, "list catalogs error")
+	}
+
+	loaded := make(map[string]Catalog)
+	for _, f := range files {
+		lang := normalize(strings.TrimSuffix(filepath.Base(f), ".json"))
+		if lang == "" {
+			continue
+		}
+
+		c, err := readCatalog(f)
+		if err != nil {
+			return errors.Wrapf(err, "read catalog %s error", f)
+		}
+		loaded[lang] = c
+	}
+
+	SetCatalogs(loaded)
+	return nil
+}
+
+// SetCatalogs sets the catalogs, keyed by language tag.
+func SetCatalogs(c map[string]Catalog) {
+	normalized := make(map[string]Catalog)
+	for lang, catalog := range c {
+		normalized[normalize(lang)] = catalog
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	catalogs = normalized
+}
+
+// Languages returns the available languages, including the default
+// language.
+func Languages() []string {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	langs := []string{DefaultLanguage}
+	for lang := range catalogs {
+		if lang != DefaultLanguage {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Match returns the available language best matching the given
+// Accept-Language header value (e.g. "de-CH, de;q=0.9, en;q=0.8"). A
+// regional tag falls back to its base language (de-CH to de). When none of
+// the languages is available, DefaultLanguage is returned.
+func Match(acceptLanguage string) string {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == DefaultLanguage || tag == "*" {
+			return DefaultLanguage
+		}
+		if _, ok := catalogs[tag]; ok {
+			return tag
+		}
+		if i := strings.Index(tag, "-"); i > 0 {
+			base := tag[:i]
+			if base == DefaultLanguage {
+				return DefaultLanguage
+			}
+			if _, ok := catalogs[base]; ok {
+				return base
+			}
+		}
+	}
+	return DefaultLanguage
+}
+
+// TranslateError returns the translation of the given error message. When
+// there is no translation for the complete message, each part of a composed
+// message (separated by ": ") is translated separately. Untranslated
+// (parts of) messages are returned as-is.
+func TranslateError(lang, msg string) string {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	c, ok := catalogs[normalize(lang)]
+	if !ok || len(c.Errors) == 0 {
+		return msg
+	}
+
+	if t, ok := c.Errors[msg]; ok {
+		return t
+	}
+
+	parts := strings.Split(msg, errorSeparator)
+	for i := range parts {
+		if t, ok := c.Errors[parts[i]]; ok {
+			parts[i] = t
+		}
+	}
+	return strings.Join(parts, errorSeparator)
+}
+
+// UIStrings returns the UI strings of the given language.
+func UIStrings(lang string) map[string]string {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	out := make(map[string]string)
+	for k, v := range catalogs[normalize(lang)].UI {
+		out[k] = v
+	}
+	return out
+}
+
+// parseAcceptLanguage returns the (normalized) language tags of the given
+// Accept-Language header value, ordered by quality. Tags with q=0 are
+// omitted.
+func parseAcceptLanguage(s string) []string {
+	type tagQ struct {
+		tag string
+		q   float64
+	}
+
+	var tags []tagQ
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Split(part, ";")
+		tag := normalize(fields[0])
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, tagQ{tag: tag, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	out := make([]string, len(tags))
+	for i := range tags {
+		out[i] = tags[i].tag
+	}
+	return out
+}
+
+// normalize returns the lowercase language tag, using - as separator.
+func normalize(tag string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(tag), "_", "-", -1))
+}
+
+func readCatalog(path string) (Catalog, error) {
+	var c Catalog
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, errors.Wrap(err, "unmarshal json error")
+	}
+	return c, nil
+}
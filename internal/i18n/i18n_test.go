@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestI18n(t *testing.T) {
+	Convey("Given a directory with a de and pt-BR catalog", t, func() {
+		dir, err := ioutil.TempDir("", "i18n")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(ioutil.WriteFile(filepath.Join(dir, "de.json"), []byte(`{
+			"errors": {
+				"authentication failed": "Authentifizierung fehlgeschlagen",
+				"object does not exist": "Objekt existiert nicht"
+			},
+			"ui": {
+				"login.username": "Benutzername"
+			}
+		}`), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "pt-BR.json"), []byte(`{}`), 0644), ShouldBeNil)
+
+		Convey("When loading the catalogs", func() {
+			So(LoadCatalogs(dir), ShouldBeNil)
+			defer SetCatalogs(nil)
+
+			Convey("Then the languages are returned", func() {
+				So(Languages(), ShouldResemble, []string{"en", "de", "pt-br"})
+			})
+
+			Convey("Then Match returns the best matching language", func() {
+				tests := []struct {
+					AcceptLanguage string
+					Expected       string
+				}{
+					{"", "en"},
+					{"de", "de"},
+					{"de-CH, fr;q=0.9", "de"},
+					{"fr, en;q=0.8, de;q=0.9", "de"},
+					{"en-US, de;q=0.5", "en"},
+					{"pt-BR", "pt-br"},
+					{"pt", "en"},
+					{"de;q=0, fr", "en"},
+				}
+
+				for _, test := range tests {
+					So(Match(test.AcceptLanguage), ShouldEqual, test.Expected)
+				}
+			})
+
+			Convey("Then error messages are translated", func() {
+				So(TranslateError("de", "object does not exist"), ShouldEqual, "Objekt existiert nicht")
+				So(TranslateError("de", "authentication failed: object does not exist"), ShouldEqual, "Authentifizierung fehlgeschlagen: Objekt existiert nicht")
+				So(TranslateError("de", "unknown error"), ShouldEqual, "unknown error")
+				So(TranslateError("en", "object does not exist"), ShouldEqual, "object does not exist")
+			})
+
+			Convey("Then the ui strings are returned", func() {
+				So(UIStrings("de"), ShouldResemble, map[string]string{"login.username": "Benutzername"})
+				So(UIStrings("en"), ShouldResemble, map[string]string{})
+			})
+		})
+	})
+}
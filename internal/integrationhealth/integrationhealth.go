@@ -0,0 +1,115 @@
+// Package integrationhealth keeps track of the delivery health of the
+// application integrations (time of the last delivery, consecutive failures
+// and the last error), so that users can see whether the endpoint of an
+// integration is actually receiving the events.
+package integrationhealth
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+)
+
+// healthKeyTempl is keyed by the integration UUID, so that the health of a
+// deleted integration is not inherited by a re-created integration.
+const healthKeyTempl = "integration-health:%s"
+
+// maxErrorLength defines the max. length of the stored last error.
+const maxErrorLength = 500
+
+// Retention defines how long the health of an integration without
+// deliveries is kept.
+var Retention = 30 * 24 * time.Hour
+
+// Health contains the delivery health of an integration.
+type Health struct {
+	// LastDeliveryAt contains the time of the last successful delivery.
+	LastDeliveryAt *time.Time
+
+	// LastFailureAt contains the time of the last failed delivery.
+	LastFailureAt *time.Time
+
+	// ConsecutiveFailures contains the number of failed deliveries since
+	// the last successful delivery.
+	ConsecutiveFailures int
+
+	// LastError contains the error of the last failed delivery.
+	LastError string
+}
+
+// Healthy returns true when the last delivery of the integration did not
+// fail.
+func (h Health) Healthy() bool {
+	return h.ConsecutiveFailures == 0
+}
+
+// RecordDelivery records a delivery to the given integration. A nil error
+// records a successful delivery, resetting the consecutive failures.
+func RecordDelivery(integrationUUID string, deliveryErr error) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(healthKeyTempl, integrationUUID)
+	now := time.Now().Format(time.RFC3339Nano)
+
+	c.Send("MULTI")
+	if deliveryErr == nil {
+		c.Send("HMSET", key, "last_delivery_at", now, "consecutive_failures", 0)
+	} else {
+		msg := deliveryErr.Error()
+		if len(msg) > maxErrorLength {
+			msg = msg[:maxErrorLength]
+		}
+		c.Send("HINCRBY", key, "consecutive_failures", 1)
+		c.Send("HMSET", key, "last_failure_at", now, "last_error", msg)
+	}
+	c.Send("PEXPIRE", key, int64(Retention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record integration health error")
+	}
+	return nil
+}
+
+// GetHealth returns the health of the given integration. For an integration
+// without (recent) deliveries, the zero value is returned.
+func GetHealth(integrationUUID string) (Health, error) {
+	var h Health
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	fields, err := redis.StringMap(c.Do("HGETALL", common.RedisKey(healthKeyTempl, integrationUUID)))
+	if err != nil {
+		return h, errors.Wrap(err, "get integration health error")
+	}
+
+	if h.LastDeliveryAt, err = parseTime(fields["last_delivery_at"]); err != nil {
+		return h, err
+	}
+	if h.LastFailureAt, err = parseTime(fields["last_failure_at"]); err != nil {
+		return h, err
+	}
+	if s, ok := fields["consecutive_failures"]; ok {
+		if h.ConsecutiveFailures, err = strconv.Atoi(s); err != nil {
+			return h, errors.Wrap(err, "parse consecutive failures error")
+		}
+	}
+	h.LastError = fields["last_error"]
+
+	return h, nil
+}
+
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse time error")
+	}
+	return &t, nil
+}
@@ -0,0 +1,59 @@
+package integrationhealth
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestIntegrationHealth(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		uuid := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+		Convey("Then the health of an integration without deliveries is empty", func() {
+			h, err := GetHealth(uuid)
+			So(err, ShouldBeNil)
+			So(h, ShouldResemble, Health{})
+			So(h.Healthy(), ShouldBeTrue)
+		})
+
+		Convey("When recording two failed deliveries", func() {
+			So(RecordDelivery(uuid, errors.New("timeout")), ShouldBeNil)
+			So(RecordDelivery(uuid, errors.New("connection refused")), ShouldBeNil)
+
+			Convey("Then the integration is not healthy", func() {
+				h, err := GetHealth(uuid)
+				So(err, ShouldBeNil)
+				So(h.LastDeliveryAt, ShouldBeNil)
+				So(h.LastFailureAt, ShouldNotBeNil)
+				So(h.ConsecutiveFailures, ShouldEqual, 2)
+				So(h.LastError, ShouldEqual, "connection refused")
+				So(h.Healthy(), ShouldBeFalse)
+			})
+
+			Convey("When recording a successful delivery", func() {
+				So(RecordDelivery(uuid, nil), ShouldBeNil)
+
+				Convey("Then the consecutive failures are reset", func() {
+					h, err := GetHealth(uuid)
+					So(err, ShouldBeNil)
+					So(h.LastDeliveryAt, ShouldNotBeNil)
+					So(h.LastFailureAt, ShouldNotBeNil)
+					So(h.ConsecutiveFailures, ShouldEqual, 0)
+					So(h.LastError, ShouldEqual, "connection refused")
+					So(h.Healthy(), ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,117 @@
+// Package lastvalue keeps the last received payload of the nodes per fPort,
+// so that the current readings of a node can be retrieved without consuming
+// the uplink stream.
+package lastvalue
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+const lastValueKeyTempl = "device:%s:lastvalue"
+
+// TTL defines the time after which the last values of a node expire when
+// no new uplinks are received.
+var TTL = 30 * 24 * time.Hour
+
+// Value defines the last received payload on a fPort.
+type Value struct {
+	FPort      uint8     `json:"fPort"`
+	FCnt       uint32    `json:"fCnt"`
+	Data       []byte    `json:"data"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// Set stores the given value as the last value of the given node for the
+// fPort of the value.
+func Set(devEUI lorawan.EUI64, v Value) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshal last value error")
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(lastValueKeyTempl, devEUI)
+	c.Send("MULTI")
+	c.Send("HSET", key, v.FPort, b)
+	c.Send("PEXPIRE", key, int64(TTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "set last value error")
+	}
+	return nil
+}
+
+// Get returns the last values of the given node, sorted by fPort.
+func Get(devEUI lorawan.EUI64) ([]Value, error) {
+	vals, err := GetMulti([]lorawan.EUI64{devEUI})
+	if err != nil {
+		return nil, err
+	}
+	return vals[devEUI], nil
+}
+
+// Delete deletes the last values of the given node.
+func Delete(devEUI lorawan.EUI64) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", common.RedisKey(lastValueKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "delete last values error")
+	}
+	return nil
+}
+
+// GetMulti returns the last values (sorted by fPort) of the given nodes.
+// Nodes without last values are omitted from the returned map.
+func GetMulti(devEUIs []lorawan.EUI64) (map[lorawan.EUI64][]Value, error) {
+	out := make(map[lorawan.EUI64][]Value)
+	if len(devEUIs) == 0 {
+		return out, nil
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	for _, devEUI := range devEUIs {
+		if err := c.Send("HGETALL", common.RedisKey(lastValueKeyTempl, devEUI)); err != nil {
+			return nil, errors.Wrap(err, "get last values error")
+		}
+	}
+	if err := c.Flush(); err != nil {
+		return nil, errors.Wrap(err, "get last values error")
+	}
+
+	for _, devEUI := range devEUIs {
+		fields, err := redis.StringMap(c.Receive())
+		if err != nil {
+			return nil, errors.Wrap(err, "get last values error")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		vals := make([]Value, 0, len(fields))
+		for fPort, b := range fields {
+			var v Value
+			if err := json.Unmarshal([]byte(b), &v); err != nil {
+				return nil, errors.Wrapf(err, "unmarshal last value for fPort %s error", fPort)
+			}
+			vals = append(vals, v)
+		}
+		sort.Slice(vals, func(i, j int) bool {
+			return vals[i].FPort < vals[j].FPort
+		})
+		out[devEUI] = vals
+	}
+
+	return out, nil
+}
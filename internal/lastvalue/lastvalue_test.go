@@ -0,0 +1,60 @@
+package lastvalue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestLastValue(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		receivedAt := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+
+		Convey("When setting values on multiple fPorts", func() {
+			So(Set(devEUI, Value{FPort: 2, FCnt: 1, Data: []byte{1}, ReceivedAt: receivedAt}), ShouldBeNil)
+			So(Set(devEUI, Value{FPort: 1, FCnt: 2, Data: []byte{2}, ReceivedAt: receivedAt}), ShouldBeNil)
+			So(Set(devEUI, Value{FPort: 2, FCnt: 3, Data: []byte{3}, ReceivedAt: receivedAt}), ShouldBeNil)
+
+			Convey("Then the last value per fPort is returned", func() {
+				vals, err := Get(devEUI)
+				So(err, ShouldBeNil)
+				So(vals, ShouldHaveLength, 2)
+				So(vals[0].FPort, ShouldEqual, 1)
+				So(vals[0].Data, ShouldResemble, []byte{2})
+				So(vals[1].FPort, ShouldEqual, 2)
+				So(vals[1].FCnt, ShouldEqual, 3)
+				So(vals[1].Data, ShouldResemble, []byte{3})
+				So(vals[1].ReceivedAt.Equal(receivedAt), ShouldBeTrue)
+			})
+
+			Convey("Then GetMulti omits the nodes without values", func() {
+				vals, err := GetMulti([]lorawan.EUI64{devEUI, {8, 7, 6, 5, 4, 3, 2, 1}})
+				So(err, ShouldBeNil)
+				So(vals, ShouldHaveLength, 1)
+				So(vals[devEUI], ShouldHaveLength, 2)
+			})
+
+			Convey("When deleting the values", func() {
+				So(Delete(devEUI), ShouldBeNil)
+
+				Convey("Then no values are returned", func() {
+					vals, err := Get(devEUI)
+					So(err, ShouldBeNil)
+					So(vals, ShouldHaveLength, 0)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,246 @@
+// Package leader implements a Redis based leader election, so that when
+// running multiple LoRa App Server instances, the background jobs run on a
+// single instance. When the leader of a job stops, its leadership expires
+// and an other instance takes over.
+package leader
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+)
+
+// Background jobs.
+const (
+	LinkQualityCleanup  = "link-quality-cleanup"
+	OrganizationDigests = "organization-digests"
+	RegistrySync        = "registry-sync"
+	GatewayPing         = "gateway-ping"
+)
+
+const leaderKeyTempl = "leader:%s"
+
+// InstanceID identifies this instance (must be unique for each instance).
+var InstanceID = defaultInstanceID()
+
+// TTL defines the time after which the leadership expires when it is not
+// renewed (e.g. as the leader stopped). The leadership is renewed every
+// TTL / 3.
+var TTL = 15 * time.Second
+
+// campaignScript acquires the leadership when there is no leader, or
+// renews it when the given instance is the leader. It returns 1 when the
+// given instance is the leader.
+var campaignScript = redis.NewScript(1, `
+	local leader = redis.call("GET", KEYS[1])
+	if leader == false then
+		redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+		return 1
+	end
+	if leader == ARGV[1] then
+		redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		return 1
+	end
+	return 0
+`)
+
+// resignScript removes the leadership when the given instance is the leader.
+var resignScript = redis.NewScript(1, `
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// election contains the leader election state of a job.
+type election struct {
+	sync.RWMutex
+	job      string
+	leader   bool
+	resigned bool
+}
+
+var (
+	electionsMu sync.Mutex
+	elections   = make(map[string]*election)
+)
+
+// Status contains the leader election status of a job.
+type Status struct {
+	Job       string
+	LeaderID  string
+	IsLeader  bool
+	ExpiresAt *time.Time
+}
+
+// IsLeader returns true when this instance is the leader of the given job.
+// On the first call for a job, the leadership is requested and the
+// election is joined (in the background).
+func IsLeader(job string) bool {
+	electionsMu.Lock()
+	e, ok := elections[job]
+	if !ok {
+		e = &election{job: job}
+		elections[job] = e
+	}
+	electionsMu.Unlock()
+
+	if !ok {
+		e.campaign()
+		go e.campaignLoop()
+	}
+
+	e.RLock()
+	defer e.RUnlock()
+	return e.leader
+}
+
+// Resign gives up the leadership of the jobs of this instance (e.g. on
+// shutdown), so that an other instance can take over without waiting for
+// the leadership to expire.
+func Resign() {
+	electionsMu.Lock()
+	defer electionsMu.Unlock()
+
+	for _, e := range elections {
+		e.Lock()
+		if e.leader {
+			if err := resign(e.job); err != nil {
+				log.WithField("job", e.job).Errorf("leader: resign error: %s", err)
+			}
+		}
+		e.leader = false
+		e.resigned = true
+		e.Unlock()
+	}
+}
+
+// GetStatus returns the leader election status of the jobs joined by this
+// instance, ordered by job.
+func GetStatus() ([]Status, error) {
+	electionsMu.Lock()
+	var jobs []string
+	for job := range elections {
+		jobs = append(jobs, job)
+	}
+	electionsMu.Unlock()
+	sort.Strings(jobs)
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	var out []Status
+	for _, job := range jobs {
+		key := common.RedisKey(leaderKeyTempl, job)
+		s := Status{
+			Job:      job,
+			IsLeader: IsLeader(job),
+		}
+
+		leaderID, err := redis.String(c.Do("GET", key))
+		if err != nil && err != redis.ErrNil {
+			return nil, errors.Wrap(err, "get leader error")
+		}
+		s.LeaderID = leaderID
+
+		ttl, err := redis.Int64(c.Do("PTTL", key))
+		if err != nil {
+			return nil, errors.Wrap(err, "get leader ttl error")
+		}
+		if ttl > 0 {
+			expiresAt := time.Now().Add(time.Duration(ttl) * time.Millisecond)
+			s.ExpiresAt = &expiresAt
+		}
+
+		out = append(out, s)
+	}
+
+	return out, nil
+}
+
+// campaignLoop renews or acquires the leadership every TTL / 3.
+func (e *election) campaignLoop() {
+	for {
+		time.Sleep(TTL / 3)
+
+		e.RLock()
+		resigned := e.resigned
+		e.RUnlock()
+		if resigned {
+			return
+		}
+
+		e.campaign()
+	}
+}
+
+// campaign acquires or renews the leadership. On error, the leadership is
+// given up as it can not be guaranteed that it is still held.
+func (e *election) campaign() {
+	leader, err := campaign(e.job)
+	if err != nil {
+		log.WithField("job", e.job).Errorf("leader: campaign error: %s", err)
+		leader = false
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	if e.resigned {
+		return
+	}
+	if leader != e.leader {
+		log.WithFields(log.Fields{
+			"job":         e.job,
+			"instance_id": InstanceID,
+			"leader":      leader,
+		}).Info("leader: leadership changed")
+	}
+	e.leader = leader
+}
+
+func campaign(job string) (bool, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	leader, err := redis.Int(campaignScript.Do(c, common.RedisKey(leaderKeyTempl, job), InstanceID, int64(TTL/time.Millisecond)))
+	if err != nil {
+		return false, errors.Wrap(err, "campaign error")
+	}
+	return leader == 1, nil
+}
+
+func resign(job string) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	if _, err := resignScript.Do(c, common.RedisKey(leaderKeyTempl, job), InstanceID); err != nil {
+		return errors.Wrap(err, "resign error")
+	}
+	return nil
+}
+
+// defaultInstanceID returns the hostname, suffixed by a random string as
+// multiple instances could run on the same host.
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "lora-app-server"
+	}
+
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return hostname
+	}
+	return fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(b))
+}
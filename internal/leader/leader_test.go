@@ -0,0 +1,72 @@
+package leader
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestLeaderElection(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		Convey("Then the first instance requesting the leadership becomes the leader", func() {
+			e := &election{job: "test-job"}
+			electionsMu.Lock()
+			elections[e.job] = e
+			electionsMu.Unlock()
+
+			e.campaign()
+			So(IsLeader("test-job"), ShouldBeTrue)
+
+			Convey("Then an other instance is not the leader", func() {
+				leader, err := campaign("test-job")
+				So(err, ShouldBeNil)
+				So(leader, ShouldBeTrue)
+
+				id := InstanceID
+				InstanceID = "other-instance"
+				leader, err = campaign("test-job")
+				InstanceID = id
+				So(err, ShouldBeNil)
+				So(leader, ShouldBeFalse)
+			})
+
+			Convey("Then the status contains the job and this instance as leader", func() {
+				status, err := GetStatus()
+				So(err, ShouldBeNil)
+
+				var found bool
+				for _, s := range status {
+					if s.Job == "test-job" {
+						found = true
+						So(s.LeaderID, ShouldEqual, InstanceID)
+						So(s.IsLeader, ShouldBeTrue)
+						So(s.ExpiresAt, ShouldNotBeNil)
+					}
+				}
+				So(found, ShouldBeTrue)
+			})
+
+			Convey("When the leader resigns", func() {
+				So(resign("test-job"), ShouldBeNil)
+
+				Convey("Then an other instance becomes the leader", func() {
+					id := InstanceID
+					InstanceID = "other-instance"
+					leader, err := campaign("test-job")
+					InstanceID = id
+					So(err, ShouldBeNil)
+					So(leader, ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,281 @@
+// Package linkquality keeps track of the link-quality of the nodes, based on
+// the SNR margin, the missed uplinks (frame-counter gaps) and the
+// retransmissions of the received uplinks.
+package linkquality
+
+import (
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/leader"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const lastUplinkKeyTempl = "device:%s:linkquality:last"
+
+const (
+	// BucketDuration defines the duration over which the metrics are
+	// aggregated.
+	BucketDuration = time.Hour
+
+	// maxFCntGap defines the max frame-counter gap which is accounted as
+	// missed uplinks, larger gaps are considered a frame-counter reset.
+	maxFCntGap = 16384
+
+	// maxSNRMargin defines the SNR margin (dB) at which the SNR margin
+	// part of the score is at its max.
+	maxSNRMargin = 10.0
+
+	// score weights
+	deliveryWeight       = 0.5
+	snrMarginWeight      = 0.3
+	retransmissionWeight = 0.2
+
+	cleanupInterval = time.Hour
+)
+
+// Retention defines how long the link-quality history is kept.
+var Retention = 30 * 24 * time.Hour
+
+// MaxLateness defines how late an uplink may arrive (e.g. when forwarded by
+// a store-and-forward gateway) to be accounted in the bucket of its event
+// time (the gateway timestamp). Later uplinks, uplinks without gateway
+// timestamp or all uplinks when set to 0 are accounted at their time of
+// arrival.
+var MaxLateness = 24 * time.Hour
+
+// requiredSNR contains the min. SNR (dB) needed to demodulate a LoRa frame
+// per spread-factor.
+var requiredSNR = map[int]float64{
+	6:  -5,
+	7:  -7.5,
+	8:  -10,
+	9:  -12.5,
+	10: -15,
+	11: -17.5,
+	12: -20,
+}
+
+// HandleUplink accounts the given uplink in the link-quality metrics of
+// the given node. Uplinks arriving out of order (having an event time
+// before the last uplink) are accounted in the bucket of their event time
+// and correct the missed uplinks previously accounted for their
+// frame-counter.
+func HandleUplink(devEUI lorawan.EUI64, fCnt uint32, dr handler.DataRate, rxInfo []handler.RXInfo) error {
+	eventTime := EventTime(rxInfo, time.Now())
+	lq := storage.LinkQuality{
+		DevEUI:       devEUI,
+		Bucket:       eventTime.Truncate(BucketDuration),
+		Uplinks:      1,
+		SNRMarginSum: snrMargin(dr, rxInfo),
+	}
+
+	last, ok, err := getLastUplink(devEUI)
+	if err != nil {
+		return err
+	}
+
+	if ok && eventTime.Before(last.Time) {
+		log.WithFields(log.Fields{
+			"dev_eui":    devEUI,
+			"f_cnt":      fCnt,
+			"event_time": eventTime,
+		}).Info("out-of-order uplink accounted in link-quality")
+
+		if fCnt < last.FCnt && last.FCnt-fCnt <= maxFCntGap {
+			if err := storage.CorrectLinkQualityMissed(common.DB, devEUI, lq.Bucket); err != nil {
+				return errors.Wrap(err, "correct link-quality error")
+			}
+		}
+	} else {
+		if err := setLastUplink(devEUI, lastUplink{FCnt: fCnt, Time: eventTime}); err != nil {
+			return err
+		}
+
+		if ok {
+			switch {
+			case fCnt == last.FCnt:
+				lq.Retransmissions = 1
+			case fCnt > last.FCnt && fCnt-last.FCnt <= maxFCntGap:
+				lq.Missed = int(fCnt - last.FCnt - 1)
+			}
+		}
+	}
+
+	if err := storage.AddLinkQuality(common.DB, lq); err != nil {
+		return errors.Wrap(err, "add link-quality error")
+	}
+
+	return nil
+}
+
+// EventTime returns the event time of an uplink received at the given time.
+// This is the earliest gateway timestamp when within MaxLateness of the
+// time of arrival, else the time of arrival. Gateway timestamps after the
+// time of arrival (clock skew) are ignored.
+func EventTime(rxInfo []handler.RXInfo, receivedAt time.Time) time.Time {
+	eventTime := receivedAt
+	for _, rx := range rxInfo {
+		if rx.Time == nil || rx.Time.After(receivedAt) || receivedAt.Sub(*rx.Time) > MaxLateness {
+			continue
+		}
+		if rx.Time.Before(eventTime) {
+			eventTime = *rx.Time
+		}
+	}
+	return eventTime
+}
+
+// Score returns the link-quality score (0 - 100, higher is better) for the
+// given metrics. It is composed of the ratio of received uplinks (50%), the
+// average SNR margin (30%, max at 10dB) and the ratio of uplinks that were
+// not retransmissions (20%).
+func Score(lq storage.LinkQuality) float64 {
+	if lq.Uplinks == 0 {
+		return 0
+	}
+
+	delivery := float64(lq.Uplinks) / float64(lq.Uplinks+lq.Missed)
+	margin := SNRMargin(lq) / maxSNRMargin
+	if margin < 0 {
+		margin = 0
+	}
+	if margin > 1 {
+		margin = 1
+	}
+	retransmission := 1 - float64(lq.Retransmissions)/float64(lq.Uplinks)
+
+	return 100 * (deliveryWeight*delivery + snrMarginWeight*margin + retransmissionWeight*retransmission)
+}
+
+// SNRMargin returns the average SNR margin (dB) of the given metrics.
+func SNRMargin(lq storage.LinkQuality) float64 {
+	if lq.Uplinks == 0 {
+		return 0
+	}
+	return lq.SNRMarginSum / float64(lq.Uplinks)
+}
+
+// ReceivedUplinks returns the number of received uplinks of the given
+// metrics, not counting the retransmissions.
+func ReceivedUplinks(lq storage.LinkQuality) int {
+	return lq.Uplinks - lq.Retransmissions
+}
+
+// ExpectedUplinks returns the number of uplinks expected within the given
+// window. When an uplink interval is given, this is the number of uplinks
+// that should have been sent within the window, else (or when more uplinks
+// were sent) it is the number of received plus missed uplinks (based on the
+// frame-counter gaps).
+func ExpectedUplinks(lq storage.LinkQuality, window, uplinkInterval time.Duration) int {
+	expected := ReceivedUplinks(lq) + lq.Missed
+	if uplinkInterval > 0 {
+		if n := int(window / uplinkInterval); n > expected {
+			expected = n
+		}
+	}
+	return expected
+}
+
+// Availability returns the availability (0 - 100) of the node, being the
+// percentage of the expected uplinks that were received. When no uplinks
+// were expected, 0 is returned.
+func Availability(received, expected int) float64 {
+	if expected == 0 {
+		return 0
+	}
+	return 100 * float64(received) / float64(expected)
+}
+
+// CleanupLoop removes the link-quality history older than the configured
+// retention (when this instance is the leader of the cleanup job). This
+// function never returns.
+func CleanupLoop() {
+	for {
+		if leader.IsLeader(leader.LinkQualityCleanup) {
+			count, err := storage.DeleteLinkQualityBefore(common.DB, time.Now().Add(-Retention))
+			if err != nil {
+				log.Errorf("delete link-quality history error: %s", err)
+			} else if count > 0 {
+				log.WithField("count", count).Info("link-quality history cleaned up")
+			}
+		}
+		time.Sleep(cleanupInterval)
+	}
+}
+
+// snrMargin returns the SNR margin (of the best receiving gateway) above
+// the min. SNR needed for the used spread-factor. For FSK modulation,
+// the max margin is returned as the SNR does not apply.
+func snrMargin(dr handler.DataRate, rxInfo []handler.RXInfo) float64 {
+	required, ok := requiredSNR[dr.SpreadFactor]
+	if dr.Modulation == "FSK" || !ok || len(rxInfo) == 0 {
+		return maxSNRMargin
+	}
+
+	best := rxInfo[0].LoRaSNR
+	for _, rx := range rxInfo[1:] {
+		if rx.LoRaSNR > best {
+			best = rx.LoRaSNR
+		}
+	}
+	return best - required
+}
+
+// lastUplink contains the frame-counter and event time of the last
+// (in-order) uplink of a node.
+type lastUplink struct {
+	FCnt uint32
+	Time time.Time
+}
+
+// getLastUplink returns the last uplink of the given node. The returned
+// bool is false when there is no last uplink.
+func getLastUplink(devEUI lorawan.EUI64) (lastUplink, bool, error) {
+	var last lastUplink
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("HMGET", common.RedisKey(lastUplinkKeyTempl, devEUI), "fcnt", "time"))
+	if err != nil {
+		return last, false, errors.Wrap(err, "get last uplink error")
+	}
+	if values[0] == nil || values[1] == nil {
+		return last, false, nil
+	}
+
+	fCnt, err := redis.Uint64(values[0], nil)
+	if err != nil {
+		return last, false, errors.Wrap(err, "read frame-counter error")
+	}
+	ns, err := redis.Int64(values[1], nil)
+	if err != nil {
+		return last, false, errors.Wrap(err, "read event time error")
+	}
+
+	last.FCnt = uint32(fCnt)
+	last.Time = time.Unix(0, ns)
+	return last, true, nil
+}
+
+// setLastUplink stores the given uplink as the last uplink of the given
+// node.
+func setLastUplink(devEUI lorawan.EUI64, last lastUplink) error {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(lastUplinkKeyTempl, devEUI)
+	c.Send("MULTI")
+	c.Send("HMSET", key, "fcnt", last.FCnt, "time", last.Time.UnixNano())
+	c.Send("PEXPIRE", key, int64(Retention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "set last uplink error")
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package linkquality
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestScore(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name        string
+			LinkQuality storage.LinkQuality
+			Expected    float64
+		}{
+			{
+				Name:        "no uplinks",
+				LinkQuality: storage.LinkQuality{},
+				Expected:    0,
+			},
+			{
+				Name:        "perfect link",
+				LinkQuality: storage.LinkQuality{Uplinks: 10, SNRMarginSum: 150},
+				Expected:    100,
+			},
+			{
+				Name:        "half of the uplinks missed, no margin and all retransmitted",
+				LinkQuality: storage.LinkQuality{Uplinks: 10, Missed: 10, Retransmissions: 10, SNRMarginSum: -20},
+				Expected:    25,
+			},
+			{
+				Name:        "5dB margin",
+				LinkQuality: storage.LinkQuality{Uplinks: 4, SNRMarginSum: 20},
+				Expected:    85,
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(Score(test.LinkQuality), ShouldAlmostEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestExpectedUplinks(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name           string
+			LinkQuality    storage.LinkQuality
+			UplinkInterval time.Duration
+			Expected       int
+		}{
+			{
+				Name:        "no uplink interval",
+				LinkQuality: storage.LinkQuality{Uplinks: 10, Missed: 5, Retransmissions: 2},
+				Expected:    13,
+			},
+			{
+				Name:           "uplink interval, uplinks missing",
+				LinkQuality:    storage.LinkQuality{Uplinks: 10},
+				UplinkInterval: time.Hour,
+				Expected:       24,
+			},
+			{
+				Name:           "uplink interval, more uplinks than expected",
+				LinkQuality:    storage.LinkQuality{Uplinks: 30},
+				UplinkInterval: time.Hour,
+				Expected:       30,
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(ExpectedUplinks(test.LinkQuality, 24*time.Hour, test.UplinkInterval), ShouldEqual, test.Expected)
+			})
+		}
+
+		Convey("Then the availability is the percentage of received uplinks", func() {
+			So(Availability(12, 24), ShouldEqual, 50)
+			So(Availability(0, 0), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestEventTime(t *testing.T) {
+	Convey("Given a time of arrival and a set of tests", t, func() {
+		receivedAt := time.Now()
+		late := receivedAt.Add(-time.Hour)
+		tooLate := receivedAt.Add(-MaxLateness - time.Hour)
+		future := receivedAt.Add(time.Hour)
+
+		tests := []struct {
+			Name     string
+			RXInfo   []handler.RXInfo
+			Expected time.Time
+		}{
+			{"no gateway timestamp", []handler.RXInfo{{}}, receivedAt},
+			{"earliest gateway timestamp", []handler.RXInfo{{Time: &receivedAt}, {Time: &late}}, late},
+			{"gateway timestamp beyond max. lateness", []handler.RXInfo{{Time: &tooLate}}, receivedAt},
+			{"gateway timestamp in the future", []handler.RXInfo{{Time: &future}}, receivedAt},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(EventTime(test.RXInfo, receivedAt).Equal(test.Expected), ShouldBeTrue)
+			})
+		}
+	})
+}
+
+func TestHandleUplink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and Redis database with a node", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+		node := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(storage.CreateNode(common.DB, node), ShouldBeNil)
+
+		dr := handler.DataRate{Modulation: "LORA", SpreadFactor: 7, Bandwidth: 125}
+		rxInfo := []handler.RXInfo{
+			{LoRaSNR: -2.5},
+			{LoRaSNR: 2.5},
+		}
+
+		Convey("When handling uplinks with a retransmission and a frame-counter gap", func() {
+			for _, fCnt := range []uint32{1, 1, 4} {
+				So(HandleUplink(node.DevEUI, fCnt, dr, rxInfo), ShouldBeNil)
+			}
+
+			Convey("Then the link-quality was stored", func() {
+				lqs, err := storage.GetLinkQualityForDevEUI(common.DB, node.DevEUI, time.Now().Add(-BucketDuration))
+				So(err, ShouldBeNil)
+				So(lqs, ShouldHaveLength, 1)
+				So(lqs[0].Uplinks, ShouldEqual, 3)
+				So(lqs[0].Missed, ShouldEqual, 2)
+				So(lqs[0].Retransmissions, ShouldEqual, 1)
+				So(SNRMargin(lqs[0]), ShouldAlmostEqual, 10)
+			})
+
+			Convey("Then the node is returned for the application", func() {
+				lqs, err := storage.GetLinkQualityForApplicationID(common.DB, app.ID, time.Now().Add(-BucketDuration))
+				So(err, ShouldBeNil)
+				So(lqs, ShouldHaveLength, 1)
+				So(lqs[0].Name, ShouldEqual, "test-node")
+				So(lqs[0].Uplinks, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When handling an uplink arriving out of order", func() {
+			now := time.Now()
+			eventTime := now.Add(-2 * BucketDuration)
+			So(HandleUplink(node.DevEUI, 1, dr, rxInfo), ShouldBeNil)
+			So(HandleUplink(node.DevEUI, 3, dr, rxInfo), ShouldBeNil)
+			So(HandleUplink(node.DevEUI, 2, dr, []handler.RXInfo{{LoRaSNR: 2.5, Time: &eventTime}}), ShouldBeNil)
+
+			Convey("Then it is accounted in the bucket of its event time and the missed uplink is corrected", func() {
+				lqs, err := storage.GetLinkQualityForDevEUI(common.DB, node.DevEUI, eventTime.Add(-BucketDuration))
+				So(err, ShouldBeNil)
+				So(lqs, ShouldHaveLength, 2)
+				So(lqs[0].Bucket.Equal(eventTime.Truncate(BucketDuration)), ShouldBeTrue)
+				So(lqs[0].Uplinks, ShouldEqual, 1)
+				So(lqs[1].Bucket.Equal(now.Truncate(BucketDuration)), ShouldBeTrue)
+				So(lqs[1].Uplinks, ShouldEqual, 2)
+				So(lqs[1].Missed, ShouldEqual, 0)
+			})
+
+			Convey("Then the next uplink is accounted against the last in-order uplink", func() {
+				So(HandleUplink(node.DevEUI, 4, dr, rxInfo), ShouldBeNil)
+				lqs, err := storage.GetLinkQualityForDevEUI(common.DB, node.DevEUI, now.Truncate(BucketDuration))
+				So(err, ShouldBeNil)
+				So(lqs, ShouldHaveLength, 1)
+				So(lqs[0].Uplinks, ShouldEqual, 3)
+				So(lqs[0].Missed, ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,529 @@
+// Package loadtest implements a load generator simulating a number of
+// devices sending uplinks through the uplink pipeline (HandleDataUp, the
+// event outbox and the integrations) against the configured PostgreSQL and
+// Redis databases. It reports the throughput and latencies, so that the
+// capacity can be planned and performance regressions can be measured.
+package loadtest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/lora-app-server/internal/api"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/handler/httphandler"
+	"github.com/brocaar/lora-app-server/internal/handler/multihandler"
+	"github.com/brocaar/lora-app-server/internal/handler/outboxhandler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// deliverBatchSize defines the max number of outbox events delivered
+	// per batch.
+	deliverBatchSize = 100
+
+	// drainTimeout defines how long to wait for the delivery of the
+	// remaining outbox events after the last uplink has been sent.
+	drainTimeout = time.Minute
+)
+
+// errors
+var (
+	ErrInvalidDevices     = errors.New("the number of devices must be at least 1")
+	ErrInvalidRate        = errors.New("the uplink rate must be greater than 0")
+	ErrInvalidDuration    = errors.New("the duration must be greater than 0")
+	ErrInvalidPayloadSize = errors.New("the payload size must be between 0 and 242 bytes")
+	ErrInvalidConcurrency = errors.New("the concurrency must be at least 1")
+)
+
+// Config defines the load-test configuration.
+type Config struct {
+	// Devices defines the number of simulated devices.
+	Devices int
+
+	// Rate defines the number of uplinks per second (of all devices).
+	Rate float64
+
+	// Duration defines for how long uplinks are sent.
+	Duration time.Duration
+
+	// PayloadSize defines the size (in bytes) of the uplink payloads.
+	PayloadSize int
+
+	// Concurrency defines the max number of uplinks handled concurrently.
+	Concurrency int
+
+	// HTTPEndpoint defines the (optional) endpoint of the HTTP integration
+	// configured for the load-test application.
+	HTTPEndpoint string
+}
+
+// Validate validates the Config data.
+func (c Config) Validate() error {
+	if c.Devices < 1 {
+		return ErrInvalidDevices
+	}
+	if c.Rate <= 0 {
+		return ErrInvalidRate
+	}
+	if c.Duration <= 0 {
+		return ErrInvalidDuration
+	}
+	if c.PayloadSize < 0 || c.PayloadSize > 242 {
+		return ErrInvalidPayloadSize
+	}
+	if c.Concurrency < 1 {
+		return ErrInvalidConcurrency
+	}
+	return nil
+}
+
+// Latencies contains the percentiles of a set of latencies.
+type Latencies struct {
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// newLatencies returns the Latencies of the given durations. Note that the
+// given slice is sorted.
+func newLatencies(durations []time.Duration) Latencies {
+	if len(durations) == 0 {
+		return Latencies{}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	percentile := func(p int) time.Duration {
+		i := (len(durations)*p+99)/100 - 1
+		if i < 0 {
+			i = 0
+		}
+		return durations[i]
+	}
+
+	return Latencies{
+		P50: percentile(50),
+		P95: percentile(95),
+		P99: percentile(99),
+		Max: durations[len(durations)-1],
+	}
+}
+
+// Result contains the result of a load-test.
+type Result struct {
+	// Sent contains the number of uplinks handled successfully.
+	Sent int
+
+	// Errors contains the number of uplinks which returned an error.
+	Errors int
+
+	// Delivered contains the number of uplinks delivered through the event
+	// outbox.
+	Delivered int
+
+	// Duration contains the duration of sending the uplinks.
+	Duration time.Duration
+
+	// Uplink contains the latencies of handling the uplinks
+	// (HandleDataUp).
+	Uplink Latencies
+
+	// EndToEnd contains the latencies from handling the uplink until its
+	// delivery through the event outbox.
+	EndToEnd Latencies
+}
+
+// Throughput returns the number of uplinks handled per second.
+func (r Result) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Sent) / r.Duration.Seconds()
+}
+
+// WriteReport writes the result as a table to w.
+func (r Result) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "uplinks sent\t%d\n", r.Sent)
+	fmt.Fprintf(tw, "uplink errors\t%d\n", r.Errors)
+	fmt.Fprintf(tw, "uplinks delivered\t%d\n", r.Delivered)
+	fmt.Fprintf(tw, "duration\t%s\n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(tw, "throughput\t%.1f uplinks/sec\n", r.Throughput())
+	fmt.Fprintln(tw, "")
+	fmt.Fprintln(tw, "LATENCY\tP50\tP95\tP99\tMAX")
+	for _, l := range []struct {
+		name      string
+		latencies Latencies
+	}{
+		{"uplink", r.Uplink},
+		{"end-to-end", r.EndToEnd},
+	} {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			l.name,
+			l.latencies.P50.Round(time.Microsecond),
+			l.latencies.P95.Round(time.Microsecond),
+			l.latencies.P99.Round(time.Microsecond),
+			l.latencies.Max.Round(time.Microsecond),
+		)
+	}
+	return tw.Flush()
+}
+
+// Fixture contains the organization, application and nodes used by the
+// load-test.
+type Fixture struct {
+	Organization storage.Organization
+	Application  storage.Application
+	Nodes        []storage.Node
+}
+
+// Setup creates a new organization, application and the given number of
+// nodes for the load-test. When a HTTP endpoint is configured, a HTTP
+// integration is created for the application. Use Teardown to remove these
+// again.
+func Setup(db *sqlx.DB, conf Config) (*Fixture, error) {
+	// the random prefix avoids conflicts with the existing nodes and
+	// load-tests running in parallel
+	var prefix [4]byte
+	if _, err := rand.Read(prefix[:]); err != nil {
+		return nil, errors.Wrap(err, "read random bytes error")
+	}
+	name := fmt.Sprintf("loadtest-%x", prefix)
+
+	f := Fixture{
+		Organization: storage.Organization{
+			Name:        name,
+			DisplayName: "Load-test",
+		},
+	}
+	if err := storage.CreateOrganization(db, &f.Organization); err != nil {
+		return nil, errors.Wrap(err, "create organization error")
+	}
+
+	f.Application = storage.Application{
+		OrganizationID: f.Organization.ID,
+		Name:           name,
+		Description:    "Load-test",
+	}
+	if err := storage.CreateApplication(db, &f.Application); err != nil {
+		f.Teardown(db)
+		return nil, errors.Wrap(err, "create application error")
+	}
+
+	if conf.HTTPEndpoint != "" {
+		b, err := json.Marshal(httphandler.HandlerConfig{
+			DataUpURL: conf.HTTPEndpoint,
+		})
+		if err != nil {
+			f.Teardown(db)
+			return nil, errors.Wrap(err, "marshal http integration config error")
+		}
+		if err := storage.CreateIntegration(db, &storage.Integration{
+			ApplicationID: f.Application.ID,
+			Kind:          handler.HTTPHandlerKind,
+			Settings:      b,
+		}); err != nil {
+			f.Teardown(db)
+			return nil, errors.Wrap(err, "create http integration error")
+		}
+	}
+
+	for i := 0; i < conf.Devices; i++ {
+		n := storage.Node{
+			ApplicationID: f.Application.ID,
+			Name:          fmt.Sprintf("device-%d", i),
+			IsABP:         true,
+		}
+		copy(n.DevEUI[:], prefix[:])
+		n.DevEUI[4] = byte(i >> 24)
+		n.DevEUI[5] = byte(i >> 16)
+		n.DevEUI[6] = byte(i >> 8)
+		n.DevEUI[7] = byte(i)
+		copy(n.DevAddr[:], n.DevEUI[4:])
+		if _, err := rand.Read(n.AppSKey[:]); err != nil {
+			f.Teardown(db)
+			return nil, errors.Wrap(err, "read random bytes error")
+		}
+
+		if err := storage.CreateNode(db, n); err != nil {
+			f.Teardown(db)
+			return nil, errors.Wrap(err, "create node error")
+		}
+		f.Nodes = append(f.Nodes, n)
+	}
+
+	log.WithFields(log.Fields{
+		"organization_id": f.Organization.ID,
+		"application_id":  f.Application.ID,
+		"devices":         len(f.Nodes),
+	}).Info("loadtest: fixture created")
+
+	return &f, nil
+}
+
+// Teardown deletes the organization of the load-test (and with it the
+// application, nodes and the remaining outbox events).
+func (f *Fixture) Teardown(db *sqlx.DB) error {
+	if _, err := db.Exec("delete from event_outbox where organization_id = $1", f.Organization.ID); err != nil {
+		return errors.Wrap(err, "delete outbox events error")
+	}
+	if err := storage.DeleteOrganization(db, f.Organization.ID); err != nil {
+		return errors.Wrap(err, "delete organization error")
+	}
+
+	log.WithField("organization_id", f.Organization.ID).Info("loadtest: fixture deleted")
+	return nil
+}
+
+// Run runs the load-test using the given fixture. It sends the uplinks
+// at the configured rate to the application-server API, while delivering
+// the events of the load-test organization through the event outbox.
+// Note that Run replaces common.Handler for the duration of the test.
+func Run(f *Fixture, conf Config) (Result, error) {
+	var result Result
+	if err := conf.Validate(); err != nil {
+		return result, err
+	}
+	if len(f.Nodes) == 0 {
+		return result, ErrInvalidDevices
+	}
+
+	rec := newRecorder()
+	outbox := outboxhandler.NewHandler(multihandler.NewHandler(rec))
+
+	prevHandler := common.Handler
+	common.Handler = outbox
+	defer func() { common.Handler = prevHandler }()
+
+	stopDelivery := make(chan struct{})
+	deliveryDone := make(chan struct{})
+	go func() {
+		defer close(deliveryDone)
+		deliver(outbox, f.Organization.ID, stopDelivery)
+	}()
+
+	asAPI := api.NewApplicationServerAPI()
+	interval := time.Duration(float64(time.Second) / conf.Rate)
+	sem := make(chan struct{}, conf.Concurrency)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		latencies []time.Duration
+	)
+
+	start := time.Now()
+	for i := 0; ; i++ {
+		next := start.Add(time.Duration(i) * interval)
+		if next.Sub(start) >= conf.Duration {
+			break
+		}
+		time.Sleep(next.Sub(time.Now()))
+
+		node := f.Nodes[i%len(f.Nodes)]
+		fCnt := uint32(i / len(f.Nodes))
+		data := make([]byte, conf.PayloadSize)
+		mrand.Read(data)
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			sentAt := time.Now()
+			rec.setSent(node.DevEUI, fCnt, sentAt)
+			_, err := asAPI.HandleDataUp(context.Background(), uplinkRequest(node, fCnt, data))
+			latency := time.Since(sentAt)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors++
+				rec.unsetSent(node.DevEUI, fCnt)
+				log.WithField("dev_eui", node.DevEUI).Errorf("loadtest: handle uplink error: %s", err)
+				return
+			}
+			result.Sent++
+			latencies = append(latencies, latency)
+		}()
+	}
+	wg.Wait()
+	result.Duration = time.Since(start)
+	result.Uplink = newLatencies(latencies)
+
+	// wait for the remaining events to be delivered
+	deadline := time.Now().Add(drainTimeout)
+	for rec.deliveredCount() < result.Sent && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(stopDelivery)
+	<-deliveryDone
+
+	result.Delivered = rec.deliveredCount()
+	result.EndToEnd = newLatencies(rec.latencies())
+
+	return result, nil
+}
+
+// deliver delivers the outbox events of the given organization until the
+// stop channel is closed.
+func deliver(outbox *outboxhandler.Handler, organizationID int64, stop chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		count, err := outbox.DeliverOrganization(organizationID, deliverBatchSize)
+		if err != nil {
+			log.WithField("organization_id", organizationID).Errorf("loadtest: deliver outbox events error: %s", err)
+		}
+		if err != nil || count == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+// uplinkRequest returns the HandleDataUpRequest for the given node.
+func uplinkRequest(node storage.Node, fCnt uint32, data []byte) *as.HandleDataUpRequest {
+	return &as.HandleDataUpRequest{
+		DevEUI: node.DevEUI[:],
+		AppEUI: node.AppEUI[:],
+		FCnt:   fCnt,
+		FPort:  1,
+		Data:   data,
+		RxInfo: []*as.RXInfo{
+			{
+				Mac:     []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Name:    "loadtest-gateway",
+				Time:    time.Now().UTC().Format(time.RFC3339Nano),
+				Rssi:    -60,
+				LoRaSNR: 7,
+			},
+		},
+		TxInfo: &as.TXInfo{
+			Frequency: 868100000,
+			DataRate: &as.DataRate{
+				Modulation:   "LORA",
+				BandWidth:    125,
+				SpreadFactor: 7,
+			},
+			Adr:      true,
+			CodeRate: "4/5",
+		},
+	}
+}
+
+// uplinkKey identifies an uplink of a device.
+type uplinkKey struct {
+	devEUI lorawan.EUI64
+	fCnt   uint32
+}
+
+// recorder implements a handler.Handler which records the end-to-end
+// latency of the delivered uplinks. Other events are discarded.
+type recorder struct {
+	mu        sync.Mutex
+	sent      map[uplinkKey]time.Time
+	delivered []time.Duration
+	dataDown  chan handler.DataDownPayload
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		sent:     make(map[uplinkKey]time.Time),
+		dataDown: make(chan handler.DataDownPayload),
+	}
+}
+
+func (r *recorder) setSent(devEUI lorawan.EUI64, fCnt uint32, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent[uplinkKey{devEUI, fCnt}] = t
+}
+
+func (r *recorder) unsetSent(devEUI lorawan.EUI64, fCnt uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sent, uplinkKey{devEUI, fCnt})
+}
+
+func (r *recorder) deliveredCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.delivered)
+}
+
+func (r *recorder) latencies() []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Duration(nil), r.delivered...)
+}
+
+func (r *recorder) SendDataUp(pl handler.DataUpPayload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := uplinkKey{pl.DevEUI, pl.FCnt}
+	if sentAt, ok := r.sent[key]; ok {
+		r.delivered = append(r.delivered, time.Since(sentAt))
+		delete(r.sent, key)
+	}
+	return nil
+}
+
+func (r *recorder) SendJoinNotification(pl handler.JoinNotification) error {
+	return nil
+}
+
+func (r *recorder) SendACKNotification(pl handler.ACKNotification) error {
+	return nil
+}
+
+func (r *recorder) SendErrorNotification(pl handler.ErrorNotification) error {
+	return nil
+}
+
+func (r *recorder) SendSecurityNotification(pl handler.SecurityNotification) error {
+	return nil
+}
+
+func (r *recorder) SendProprietaryUp(pl handler.ProprietaryUpPayload) error {
+	return nil
+}
+
+func (r *recorder) SendGatewayNotification(pl handler.GatewayNotification) error {
+	return nil
+}
+
+func (r *recorder) SendCustomEvent(pl handler.CustomEvent) error {
+	return nil
+}
+
+func (r *recorder) DataDownChan() chan handler.DataDownPayload {
+	return r.dataDown
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
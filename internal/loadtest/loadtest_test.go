@@ -0,0 +1,161 @@
+package loadtest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/lora-app-server/internal/api"
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler/multihandler"
+	"github.com/brocaar/lora-app-server/internal/handler/outboxhandler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestConfig(t *testing.T) {
+	Convey("Given a valid configuration", t, func() {
+		conf := Config{
+			Devices:     10,
+			Rate:        100,
+			Duration:    time.Second,
+			PayloadSize: 10,
+			Concurrency: 10,
+		}
+		So(conf.Validate(), ShouldBeNil)
+
+		Convey("Then an invalid number of devices is rejected", func() {
+			conf.Devices = 0
+			So(conf.Validate(), ShouldEqual, ErrInvalidDevices)
+		})
+
+		Convey("Then an invalid rate is rejected", func() {
+			conf.Rate = 0
+			So(conf.Validate(), ShouldEqual, ErrInvalidRate)
+		})
+
+		Convey("Then an invalid payload size is rejected", func() {
+			conf.PayloadSize = 243
+			So(conf.Validate(), ShouldEqual, ErrInvalidPayloadSize)
+		})
+	})
+}
+
+func TestLatencies(t *testing.T) {
+	Convey("Given 100 latencies", t, func() {
+		var durations []time.Duration
+		for i := 100; i > 0; i-- {
+			durations = append(durations, time.Duration(i)*time.Millisecond)
+		}
+
+		Convey("Then the percentiles are returned", func() {
+			So(newLatencies(durations), ShouldResemble, Latencies{
+				P50: 50 * time.Millisecond,
+				P95: 95 * time.Millisecond,
+				P99: 99 * time.Millisecond,
+				Max: 100 * time.Millisecond,
+			})
+		})
+
+		Convey("Then no latencies results in zero percentiles", func() {
+			So(newLatencies(nil), ShouldResemble, Latencies{})
+		})
+	})
+}
+
+func TestRun(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and a load-test fixture", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+
+		ltConf := Config{
+			Devices:     5,
+			Rate:        100,
+			Duration:    200 * time.Millisecond,
+			PayloadSize: 10,
+			Concurrency: 5,
+		}
+		f, err := Setup(common.DB, ltConf)
+		So(err, ShouldBeNil)
+		So(f.Nodes, ShouldHaveLength, 5)
+
+		Convey("When running the load-test", func() {
+			result, err := Run(f, ltConf)
+			So(err, ShouldBeNil)
+
+			Convey("Then all uplinks have been handled and delivered", func() {
+				So(result.Errors, ShouldEqual, 0)
+				So(result.Sent, ShouldEqual, 20)
+				So(result.Delivered, ShouldEqual, 20)
+				So(result.EndToEnd.Max, ShouldBeGreaterThan, 0)
+
+				var buf bytes.Buffer
+				So(result.WriteReport(&buf), ShouldBeNil)
+				So(buf.String(), ShouldContainSubstring, "uplinks delivered  20")
+			})
+		})
+
+		Convey("When tearing down the fixture", func() {
+			So(f.Teardown(common.DB), ShouldBeNil)
+
+			Convey("Then the organization has been deleted", func() {
+				_, err := storage.GetOrganization(common.DB, f.Organization.ID)
+				So(err, ShouldEqual, storage.ErrDoesNotExist)
+			})
+		})
+	})
+}
+
+// BenchmarkUplinkPipeline benchmarks the handling of an uplink, including
+// its delivery through the event outbox.
+func BenchmarkUplinkPipeline(b *testing.B) {
+	conf := test.GetConfig()
+
+	db, err := storage.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		b.Fatal(err)
+	}
+	common.DB = db
+	test.MustResetDB(common.DB)
+
+	common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+	test.MustFlushRedis(common.RedisPool)
+
+	f, err := Setup(common.DB, Config{Devices: 100})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Teardown(common.DB)
+
+	outbox := outboxhandler.NewHandler(multihandler.NewHandler(newRecorder()))
+	common.Handler = outbox
+	asAPI := api.NewApplicationServerAPI()
+	data := make([]byte, 10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		node := f.Nodes[i%len(f.Nodes)]
+		if _, err := asAPI.HandleDataUp(context.Background(), uplinkRequest(node, uint32(i/len(f.Nodes)), data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for {
+		count, err := outbox.DeliverOrganization(f.Organization.ID, deliverBatchSize)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count == 0 {
+			break
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Package nats implements a minimal NATS client for publishing messages.
+// After each publish, a PING is sent and the PONG is awaited, so that a
+// message is only considered published once it has been processed by the
+// server (e.g. permission errors are returned by Publish).
+package nats
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	timeout     = 10 * time.Second
+	defaultPort = "4222"
+)
+
+// Error contains an error (-ERR) returned by the server.
+type Error struct {
+	Message string
+}
+
+func (e Error) Error() string {
+	return "nats error: " + e.Message
+}
+
+// serverInfo contains the fields of the INFO message used by the client.
+type serverInfo struct {
+	TLSRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+// connectOptions contains the CONNECT message options.
+type connectOptions struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// Publisher implements a NATS publisher. In case of an error, the
+// connection is re-established and the publish is retried once, e.g. when
+// the server closed an idle connection.
+type Publisher struct {
+	uri *url.URL
+
+	mu         sync.Mutex
+	conn       net.Conn
+	reader     *bufio.Reader
+	maxPayload int64
+}
+
+// NewPublisher creates a new Publisher for the given NATS URI, formatted as
+// nats://[user:password@]host[:port] or nats://token@host[:port] (use
+// tls:// for TLS). The connection is established on the first publish.
+func NewPublisher(uri string) (*Publisher, error) {
+	u, err := ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{uri: u}, nil
+}
+
+// ParseURI parses and validates the given NATS URI.
+func ParseURI(uri string) (*url.URL, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse uri error")
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid nats uri scheme: %s", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("nats uri must contain a hostname")
+	}
+	return u, nil
+}
+
+// ValidSubject returns true when the given subject is a valid subject to
+// publish to (no wildcards or whitespace and no empty tokens).
+func ValidSubject(subject string) bool {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n*>") {
+		return false
+	}
+	for _, token := range strings.Split(subject, ".") {
+		if token == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Host returns the host of the server (without credentials).
+func (p *Publisher) Host() string {
+	return p.uri.Host
+}
+
+// Publish publishes the given payload to the given subject and waits until
+// it has been processed by the server.
+func (p *Publisher) Publish(subject string, payload []byte) error {
+	if !ValidSubject(subject) {
+		return fmt.Errorf("invalid subject: %s", subject)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	reconnected := false
+	if p.conn == nil {
+		if err := p.connect(); err != nil {
+			return err
+		}
+		reconnected = true
+	}
+
+	if p.maxPayload > 0 && int64(len(payload)) > p.maxPayload {
+		return fmt.Errorf("payload size of %d bytes exceeds max payload of %d bytes", len(payload), p.maxPayload)
+	}
+
+	err := p.publish(subject, payload)
+	if err == nil {
+		return nil
+	}
+	p.disconnect()
+
+	// a server error (e.g. permissions violation) will not be solved by
+	// reconnecting
+	if _, ok := err.(Error); ok || reconnected {
+		return err
+	}
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+	if err := p.publish(subject, payload); err != nil {
+		p.disconnect()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection with the server.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+func (p *Publisher) disconnect() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+func (p *Publisher) connect() error {
+	host := p.uri.Host
+	if p.uri.Port() == "" {
+		host = net.JoinHostPort(p.uri.Hostname(), defaultPort)
+	}
+
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return errors.Wrap(err, "dial error")
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	if err := p.handshake(); err != nil {
+		p.disconnect()
+		return errors.Wrap(err, "handshake error")
+	}
+	return nil
+}
+
+// handshake reads the INFO message, upgrades the connection to TLS when
+// required and sends the CONNECT message.
+func (p *Publisher) handshake() error {
+	p.conn.SetDeadline(time.Now().Add(timeout))
+	defer p.conn.SetDeadline(time.Time{})
+
+	line, err := p.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("expected INFO, got: %s", line)
+	}
+	var info serverInfo
+	if err := json.Unmarshal([]byte(line[len("INFO "):]), &info); err != nil {
+		return errors.Wrap(err, "unmarshal info error")
+	}
+	p.maxPayload = info.MaxPayload
+
+	if p.uri.Scheme == "tls" || info.TLSRequired {
+		tlsConn := tls.Client(p.conn, &tls.Config{ServerName: p.uri.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			return errors.Wrap(err, "tls handshake error")
+		}
+		p.conn = tlsConn
+		p.reader = bufio.NewReader(tlsConn)
+	}
+
+	opts := connectOptions{
+		Name:    "lora-app-server",
+		Lang:    "go",
+		Version: "1.0.0",
+	}
+	if p.uri.User != nil {
+		if pass, ok := p.uri.User.Password(); ok {
+			opts.User = p.uri.User.Username()
+			opts.Pass = pass
+		} else {
+			opts.AuthToken = p.uri.User.Username()
+		}
+	}
+	b, err := json.Marshal(opts)
+	if err != nil {
+		return errors.Wrap(err, "marshal connect error")
+	}
+
+	if _, err := fmt.Fprintf(p.conn, "CONNECT %s\r\nPING\r\n", b); err != nil {
+		return errors.Wrap(err, "write connect error")
+	}
+	return p.waitPong()
+}
+
+func (p *Publisher) publish(subject string, payload []byte) error {
+	p.conn.SetDeadline(time.Now().Add(timeout))
+	defer p.conn.SetDeadline(time.Time{})
+
+	msg := make([]byte, 0, len(subject)+len(payload)+32)
+	msg = append(msg, fmt.Sprintf("PUB %s %d\r\n", subject, len(payload))...)
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\nPING\r\n"...)
+	if _, err := p.conn.Write(msg); err != nil {
+		return errors.Wrap(err, "write error")
+	}
+	return p.waitPong()
+}
+
+// waitPong reads until the PONG is received, answering the PINGs of the
+// server. An -ERR is returned as Error.
+func (p *Publisher) waitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return errors.Wrap(err, "write error")
+			}
+		case line == "+OK" || strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			return Error{Message: strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")}
+		default:
+			return fmt.Errorf("unexpected message: %s", line)
+		}
+	}
+}
+
+func (p *Publisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", errors.Wrap(err, "read error")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
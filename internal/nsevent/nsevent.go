@@ -0,0 +1,236 @@
+// Package nsevent dispatches the network-server events not related to
+// uplink data (e.g. gateways going offline, proprietary frames and
+// downlink NACKs) to the handlers.
+package nsevent
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/lorawan"
+)
+
+// Event types.
+const (
+	GatewayOffline = "GATEWAY_OFFLINE"
+	ProprietaryUp  = "PROPRIETARY_UP"
+	DownlinkNACK   = "DOWNLINK_NACK"
+)
+
+// errors
+var (
+	ErrUnknownType    = errors.New("unknown event type")
+	ErrMACRequired    = errors.New("mac is required")
+	ErrDevEUIRequired = errors.New("devEUI is required")
+)
+
+// Event defines a network-server event as received by the webhook.
+type Event struct {
+	Type    string         `json:"type"`
+	MAC     *lorawan.EUI64 `json:"mac,omitempty"`
+	DevEUI  *lorawan.EUI64 `json:"devEUI,omitempty"`
+	Message string         `json:"message"`
+}
+
+// Validate validates the event.
+func (e Event) Validate() error {
+	switch e.Type {
+	case GatewayOffline:
+		if e.MAC == nil {
+			return ErrMACRequired
+		}
+	case DownlinkNACK:
+		if e.DevEUI == nil {
+			return ErrDevEUIRequired
+		}
+	default:
+		return ErrUnknownType
+	}
+	return nil
+}
+
+// Handle validates and dispatches the given event. Gateway events are sent
+// as gateway notification, device events as error notification to the
+// application of the device.
+func Handle(e Event) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"type":    e.Type,
+		"mac":     e.MAC,
+		"dev_eui": e.DevEUI,
+	}).Warning(e.Message)
+
+	switch e.Type {
+	case GatewayOffline:
+		pl, err := gatewayNotification(*e.MAC, e.Type)
+		if err != nil {
+			return err
+		}
+		pl.Message = e.Message
+		return common.Handler.SendGatewayNotification(pl)
+	case DownlinkNACK:
+		return handleDownlinkNACK(*e.DevEUI, e.Message)
+	}
+	return nil
+}
+
+// HandleProprietaryUp dispatches the given proprietary uplink frame. When
+// the MAC payload matches the proprietary payload prefix of an application,
+// it is sent to the integrations of this application. Otherwise it is sent
+// as gateway notification for the gateway with the best reception (the
+// network-server sorts the RX info by signal quality).
+func HandleProprietaryUp(req *as.HandleProprietaryUpRequest) error {
+	if len(req.RxInfo) == 0 {
+		return errors.New("rxInfo must have length > 0")
+	}
+
+	txInfo := getTXInfo(req.TxInfo)
+	rxInfo := getRXInfo(req.RxInfo)
+
+	app, err := storage.GetApplicationForProprietaryPayload(common.DB, req.MacPayload)
+	if err == nil {
+		return common.Handler.SendProprietaryUp(handler.ProprietaryUpPayload{
+			ApplicationID:   app.ID,
+			ApplicationName: app.Name,
+			Environment:     app.Environment,
+			MACPayload:      req.MacPayload,
+			MIC:             req.Mic,
+			RXInfo:          rxInfo,
+			TXInfo:          txInfo,
+		})
+	}
+	if errors.Cause(err) != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get application for proprietary payload error")
+	}
+
+	var mac lorawan.EUI64
+	copy(mac[:], req.RxInfo[0].Mac)
+
+	pl, err := gatewayNotification(mac, ProprietaryUp)
+	if err != nil {
+		return err
+	}
+	pl.MACPayload = req.MacPayload
+	pl.MIC = req.Mic
+	pl.TXInfo = txInfo
+	pl.RXInfo = rxInfo
+
+	return common.Handler.SendGatewayNotification(pl)
+}
+
+// getTXInfo converts the given network-server TX info.
+func getTXInfo(in *as.TXInfo) *handler.TXInfo {
+	if in == nil {
+		return nil
+	}
+
+	out := handler.TXInfo{
+		Frequency: int(in.Frequency),
+		CodeRate:  in.CodeRate,
+	}
+	if in.DataRate != nil {
+		out.DataRate = handler.DataRate{
+			Modulation:   in.DataRate.Modulation,
+			Bandwidth:    int(in.DataRate.BandWidth),
+			SpreadFactor: int(in.DataRate.SpreadFactor),
+			Bitrate:      int(in.DataRate.Bitrate),
+		}
+	}
+	return &out
+}
+
+// getRXInfo converts the given network-server RX info.
+func getRXInfo(in []*as.RXInfo) []handler.RXInfo {
+	var out []handler.RXInfo
+	for _, rxInfo := range in {
+		var timestamp *time.Time
+		var mac lorawan.EUI64
+		copy(mac[:], rxInfo.Mac)
+
+		if rxInfo.Time != "" {
+			ts, err := time.Parse(time.RFC3339Nano, rxInfo.Time)
+			if err != nil {
+				log.WithField("time_str", rxInfo.Time).Errorf("unmarshal time error: %s", err)
+			} else {
+				timestamp = &ts
+			}
+		}
+
+		out = append(out, handler.RXInfo{
+			MAC:       mac,
+			Time:      timestamp,
+			RSSI:      int(rxInfo.Rssi),
+			LoRaSNR:   rxInfo.LoRaSNR,
+			Name:      rxInfo.Name,
+			Latitude:  rxInfo.Latitude,
+			Longitude: rxInfo.Longitude,
+			Altitude:  rxInfo.Altitude,
+		})
+	}
+	return out
+}
+
+// gatewayNotification returns a gateway notification for the given
+// gateway. The organization and name are only set when the gateway is
+// known by LoRa App Server.
+func gatewayNotification(mac lorawan.EUI64, typ string) (handler.GatewayNotification, error) {
+	pl := handler.GatewayNotification{
+		MAC:  mac,
+		Type: typ,
+	}
+
+	gw, err := storage.GetGateway(common.DB, mac, false)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return pl, nil
+		}
+		return pl, errors.Wrap(err, "get gateway error")
+	}
+	pl.OrganizationID = gw.OrganizationID
+	pl.Name = gw.Name
+
+	return pl, nil
+}
+
+// handleDownlinkNACK sends an error notification for the given device,
+// including the reference of the pending downlink queue item (if any).
+func handleDownlinkNACK(devEUI lorawan.EUI64, message string) error {
+	node, err := storage.GetNode(common.DB, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get node error")
+	}
+	app, err := storage.GetApplication(common.DB, node.ApplicationID)
+	if err != nil {
+		return errors.Wrap(err, "get application error")
+	}
+
+	var reference string
+	qi, err := storage.GetPendingDownlinkQueueItem(common.DB, devEUI)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return errors.Wrap(err, "get pending downlink queue item error")
+	}
+	if err == nil {
+		reference = qi.Reference
+	}
+
+	return common.Handler.SendErrorNotification(handler.ErrorNotification{
+		ApplicationID:   app.ID,
+		ApplicationName: app.Name,
+		Environment:     app.Environment,
+		NodeName:        node.Name,
+		DevEUI:          devEUI,
+		Type:            DownlinkNACK,
+		Error:           message,
+		Reference:       reference,
+	})
+}
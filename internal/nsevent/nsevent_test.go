@@ -0,0 +1,200 @@
+package nsevent
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/lorawan"
+)
+
+func TestEventValidate(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		tests := []struct {
+			Name          string
+			Event         Event
+			ExpectedError error
+		}{
+			{"valid gateway offline", Event{Type: GatewayOffline, MAC: &mac}, nil},
+			{"gateway offline without mac", Event{Type: GatewayOffline}, ErrMACRequired},
+			{"valid downlink nack", Event{Type: DownlinkNACK, DevEUI: &mac}, nil},
+			{"downlink nack without deveui", Event{Type: DownlinkNACK}, ErrDevEUIRequired},
+			{"proprietary uplink", Event{Type: ProprietaryUp, MAC: &mac}, ErrUnknownType},
+			{"unknown type", Event{Type: "FOO"}, ErrUnknownType},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Event.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestHandle(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a gateway, node and test handler", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		h := testhandler.NewTestHandler()
+		common.Handler = h
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+		gw := storage.Gateway{
+			MAC:            lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Name:           "test-gw",
+			OrganizationID: org.ID,
+		}
+		So(storage.CreateGateway(common.DB, &gw), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		node := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+		}
+		So(storage.CreateNode(common.DB, node), ShouldBeNil)
+
+		Convey("When handling a GATEWAY_OFFLINE event", func() {
+			So(Handle(Event{
+				Type:    GatewayOffline,
+				MAC:     &gw.MAC,
+				Message: "no stats received for 5 minutes",
+			}), ShouldBeNil)
+
+			Convey("Then a gateway notification was sent", func() {
+				So(<-h.SendGatewayNotificationChan, ShouldResemble, handler.GatewayNotification{
+					OrganizationID: org.ID,
+					MAC:            gw.MAC,
+					Name:           "test-gw",
+					Type:           GatewayOffline,
+					Message:        "no stats received for 5 minutes",
+				})
+			})
+		})
+
+		Convey("Given a pending downlink queue item", func() {
+			So(storage.CreateDownlinkQueueItem(common.DB, &storage.DownlinkQueueItem{
+				Reference: "abc123",
+				DevEUI:    node.DevEUI,
+				Confirmed: true,
+				Pending:   true,
+				FPort:     1,
+				Data:      []byte{1, 2, 3},
+			}), ShouldBeNil)
+
+			Convey("When handling a DOWNLINK_NACK event", func() {
+				So(Handle(Event{
+					Type:    DownlinkNACK,
+					DevEUI:  &node.DevEUI,
+					Message: "too late",
+				}), ShouldBeNil)
+
+				Convey("Then an error notification including the reference was sent", func() {
+					So(<-h.SendErrorNotificationChan, ShouldResemble, handler.ErrorNotification{
+						ApplicationID:   app.ID,
+						ApplicationName: "test-app",
+						NodeName:        "test-node",
+						DevEUI:          node.DevEUI,
+						Type:            DownlinkNACK,
+						Error:           "too late",
+						Reference:       "abc123",
+					})
+				})
+			})
+		})
+
+		Convey("When handling a proprietary uplink", func() {
+			So(HandleProprietaryUp(&as.HandleProprietaryUpRequest{
+				MacPayload: []byte{1, 2, 3},
+				Mic:        []byte{4, 5, 6, 7},
+				TxInfo: &as.TXInfo{
+					Frequency: 868100000,
+					DataRate: &as.DataRate{
+						Modulation:   "LORA",
+						BandWidth:    125,
+						SpreadFactor: 7,
+					},
+					CodeRate: "4/5",
+				},
+				RxInfo: []*as.RXInfo{
+					{Mac: gw.MAC[:], Rssi: -60, LoRaSNR: 5.5, Name: "test-gw"},
+					{Mac: []byte{3, 3, 3, 3, 3, 3, 3, 3}, Rssi: -100, LoRaSNR: -3},
+				},
+			}), ShouldBeNil)
+
+			Convey("Then a gateway notification was sent for the first gateway", func() {
+				So(<-h.SendGatewayNotificationChan, ShouldResemble, handler.GatewayNotification{
+					OrganizationID: org.ID,
+					MAC:            gw.MAC,
+					Name:           "test-gw",
+					Type:           ProprietaryUp,
+					MACPayload:     []byte{1, 2, 3},
+					MIC:            []byte{4, 5, 6, 7},
+					TXInfo: &handler.TXInfo{
+						Frequency: 868100000,
+						DataRate: handler.DataRate{
+							Modulation:   "LORA",
+							Bandwidth:    125,
+							SpreadFactor: 7,
+						},
+						CodeRate: "4/5",
+					},
+					RXInfo: []handler.RXInfo{
+						{MAC: gw.MAC, RSSI: -60, LoRaSNR: 5.5, Name: "test-gw"},
+						{MAC: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, RSSI: -100, LoRaSNR: -3},
+					},
+				})
+			})
+
+			Convey("Given the application has a matching proprietary payload prefix", func() {
+				app.ProprietaryPayloadPrefix = []byte{1, 2}
+				So(storage.UpdateApplication(common.DB, app), ShouldBeNil)
+
+				Convey("When handling a proprietary uplink", func() {
+					So(HandleProprietaryUp(&as.HandleProprietaryUpRequest{
+						MacPayload: []byte{1, 2, 3},
+						Mic:        []byte{4, 5, 6, 7},
+						RxInfo: []*as.RXInfo{
+							{Mac: gw.MAC[:], Rssi: -60, LoRaSNR: 5.5},
+						},
+					}), ShouldBeNil)
+
+					Convey("Then the payload was sent to the application", func() {
+						So(<-h.SendProprietaryUpChan, ShouldResemble, handler.ProprietaryUpPayload{
+							ApplicationID:   app.ID,
+							ApplicationName: "test-app",
+							MACPayload:      []byte{1, 2, 3},
+							MIC:             []byte{4, 5, 6, 7},
+							RXInfo: []handler.RXInfo{
+								{MAC: gw.MAC, RSSI: -60, LoRaSNR: 5.5},
+							},
+						})
+						So(h.SendGatewayNotificationChan, ShouldHaveLength, 0)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,210 @@
+// Package pubsub implements a minimal Google Cloud Pub/Sub client,
+// publishing messages to a topic using the REST API authenticated with
+// (self-signed JWT) service account credentials.
+package pubsub
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/egress"
+)
+
+const (
+	defaultEndpoint = "https://pubsub.googleapis.com"
+
+	// audience of the self-signed JWT
+	audience = "https://pubsub.googleapis.com/"
+
+	tokenValidity = time.Hour
+
+	// tokens are renewed when they expire within this duration
+	tokenRenewBefore = 5 * time.Minute
+)
+
+// ErrInvalidCredentials is returned when the service account credentials
+// are invalid.
+var ErrInvalidCredentials = errors.New("invalid service account credentials")
+
+var httpClient = egress.NewClient(30 * time.Second)
+
+// Error contains an error returned by the Pub/Sub API.
+type Error struct {
+	StatusCode int
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("pubsub error (status: %d, code: %s): %s", e.StatusCode, e.Status, e.Message)
+}
+
+// Credentials contains the service account credentials.
+type Credentials struct {
+	ProjectID    string
+	ClientEmail  string
+	PrivateKeyID string
+	PrivateKey   *rsa.PrivateKey
+}
+
+// ParseCredentials parses the given service account JSON key file.
+func ParseCredentials(b []byte) (Credentials, error) {
+	var out Credentials
+	var key struct {
+		Type         string `json:"type"`
+		ProjectID    string `json:"project_id"`
+		PrivateKeyID string `json:"private_key_id"`
+		PrivateKey   string `json:"private_key"`
+		ClientEmail  string `json:"client_email"`
+	}
+	if err := json.Unmarshal(b, &key); err != nil {
+		return out, ErrInvalidCredentials
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" {
+		return out, ErrInvalidCredentials
+	}
+
+	pk, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key.PrivateKey))
+	if err != nil {
+		return out, ErrInvalidCredentials
+	}
+
+	return Credentials{
+		ProjectID:    key.ProjectID,
+		ClientEmail:  key.ClientEmail,
+		PrivateKeyID: key.PrivateKeyID,
+		PrivateKey:   pk,
+	}, nil
+}
+
+// Client implements a Pub/Sub client.
+type Client struct {
+	Credentials Credentials
+
+	// Endpoint overrides the Pub/Sub endpoint (optional).
+	Endpoint string
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// Publish publishes the given message to the given topic (e.g.
+// projects/my-project/topics/my-topic), with the given attributes. It
+// returns the message ID assigned by Pub/Sub.
+func (c *Client) Publish(topic string, data []byte, attributes map[string]string) (string, error) {
+	type message struct {
+		Data       string            `json:"data"`
+		Attributes map[string]string `json:"attributes,omitempty"`
+	}
+	body, err := json.Marshal(struct {
+		Messages []message `json:"messages"`
+	}{
+		Messages: []message{
+			{Data: base64.StdEncoding.EncodeToString(data), Attributes: attributes},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal request error")
+	}
+
+	token, err := c.getToken()
+	if err != nil {
+		return "", errors.Wrap(err, "get token error")
+	}
+
+	req, err := http.NewRequest("POST", c.endpoint()+"/v1/"+topic+":publish", bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "http request error")
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "read response error")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error Error `json:"error"`
+		}
+		json.Unmarshal(b, &e)
+		e.Error.StatusCode = resp.StatusCode
+		return "", e.Error
+	}
+
+	var out struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return "", errors.Wrap(err, "unmarshal response error")
+	}
+	if len(out.MessageIDs) == 0 {
+		return "", errors.New("no message id returned")
+	}
+	return out.MessageIDs[0], nil
+}
+
+// getToken returns the (cached) self-signed JWT used as bearer token.
+func (c *Client) getToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.token != "" && now.Add(tokenRenewBefore).Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	token, err := signToken(c.Credentials, now)
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+	c.tokenExpiry = now.Add(tokenValidity)
+	return c.token, nil
+}
+
+// signToken returns a JWT, signed by the service account, which is accepted
+// by the Google APIs as bearer token (without OAuth 2.0 token exchange).
+func signToken(cred Credentials, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": cred.ClientEmail,
+		"sub": cred.ClientEmail,
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(tokenValidity).Unix(),
+	})
+	if cred.PrivateKeyID != "" {
+		token.Header["kid"] = cred.PrivateKeyID
+	}
+
+	s, err := token.SignedString(cred.PrivateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "sign token error")
+	}
+	return s, nil
+}
+
+func (c *Client) endpoint() string {
+	if c.Endpoint != "" {
+		return c.Endpoint
+	}
+	return defaultEndpoint
+}
@@ -0,0 +1,119 @@
+package pubsub
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testCredentialsFile(key *rsa.PrivateKey) []byte {
+	b, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "my-project",
+		"private_key_id": "test-key",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"client_email":   "lora-app-server@my-project.iam.gserviceaccount.com",
+	})
+	return b
+}
+
+func TestParseCredentials(t *testing.T) {
+	Convey("Given a service account key file", t, func() {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		So(err, ShouldBeNil)
+
+		Convey("Then the credentials are parsed", func() {
+			cred, err := ParseCredentials(testCredentialsFile(key))
+			So(err, ShouldBeNil)
+			So(cred.ProjectID, ShouldEqual, "my-project")
+			So(cred.ClientEmail, ShouldEqual, "lora-app-server@my-project.iam.gserviceaccount.com")
+			So(cred.PrivateKeyID, ShouldEqual, "test-key")
+			So(cred.PrivateKey, ShouldResemble, key)
+		})
+
+		Convey("Then an invalid key file returns an error", func() {
+			_, err := ParseCredentials([]byte(`{"type": "authorized_user"}`))
+			So(err, ShouldEqual, ErrInvalidCredentials)
+		})
+	})
+}
+
+func TestPublish(t *testing.T) {
+	Convey("Given a test server and client", t, func() {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		So(err, ShouldBeNil)
+		cred, err := ParseCredentials(testCredentialsFile(key))
+		So(err, ShouldBeNil)
+
+		type request struct {
+			Path          string
+			Authorization string
+			Body          map[string][]map[string]interface{}
+		}
+		requests := make(chan request, 1)
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := request{
+				Path:          r.URL.Path,
+				Authorization: r.Header.Get("Authorization"),
+			}
+			json.NewDecoder(r.Body).Decode(&req.Body)
+			requests <- req
+
+			w.WriteHeader(status)
+			if status == http.StatusOK {
+				fmt.Fprint(w, `{"messageIds": ["123"]}`)
+			} else {
+				fmt.Fprint(w, `{"error": {"code": 403, "message": "permission denied", "status": "PERMISSION_DENIED"}}`)
+			}
+		}))
+		defer server.Close()
+
+		c := Client{
+			Credentials: cred,
+			Endpoint:    server.URL,
+		}
+
+		Convey("When publishing a message with attributes", func() {
+			id, err := c.Publish("projects/my-project/topics/events", []byte("{}"), map[string]string{"eventType": "rx"})
+			So(err, ShouldBeNil)
+
+			Convey("Then the message id is returned and the message is published with a signed token", func() {
+				So(id, ShouldEqual, "123")
+
+				req := <-requests
+				So(req.Path, ShouldEqual, "/v1/projects/my-project/topics/events:publish")
+				So(req.Body["messages"][0]["data"], ShouldEqual, "e30=")
+				So(req.Body["messages"][0]["attributes"], ShouldResemble, map[string]interface{}{"eventType": "rx"})
+
+				token, err := jwt.Parse(strings.TrimPrefix(req.Authorization, "Bearer "), func(token *jwt.Token) (interface{}, error) {
+					return &key.PublicKey, nil
+				})
+				So(err, ShouldBeNil)
+				So(token.Header["kid"], ShouldEqual, "test-key")
+				claims := token.Claims.(jwt.MapClaims)
+				So(claims["iss"], ShouldEqual, cred.ClientEmail)
+				So(claims["aud"], ShouldEqual, "https://pubsub.googleapis.com/")
+			})
+		})
+
+		Convey("When the API returns an error", func() {
+			status = http.StatusForbidden
+			_, err := c.Publish("projects/my-project/topics/events", []byte("{}"), nil)
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldResemble, Error{StatusCode: http.StatusForbidden, Status: "PERMISSION_DENIED", Message: "permission denied"})
+			})
+		})
+	})
+}
@@ -0,0 +1,365 @@
+// Package registrysync implements the periodic synchronization of the nodes
+// of an application with an external device registry (e.g. an asset
+// management system). The registry is the source of truth: missing nodes are
+// created, changed nodes are updated and nodes which are no longer in the
+// registry are disabled.
+package registrysync
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/egress"
+	"github.com/brocaar/lora-app-server/internal/leader"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// Supported device registry formats.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+var (
+	// URL defines the URL of the device registry (REST endpoint or CSV
+	// file).
+	URL string
+
+	// Format defines the format of the device registry.
+	Format = FormatJSON
+
+	// ApplicationID defines the application of which the nodes are
+	// synchronized with the device registry.
+	ApplicationID int64
+
+	// Interval defines the synchronization interval.
+	Interval = time.Hour
+
+	// DryRun only reports the differences without applying them.
+	DryRun bool
+)
+
+var httpClient = egress.NewClient(30 * time.Second)
+
+var (
+	lastReport   *Report
+	lastReportMu sync.RWMutex
+)
+
+// Device defines a device of the device registry.
+type Device struct {
+	DevEUI      lorawan.EUI64     `json:"devEUI"`
+	AppEUI      lorawan.EUI64     `json:"appEUI"`
+	AppKey      lorawan.AES128Key `json:"appKey"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+}
+
+// Report contains the differences between the device registry and the
+// nodes of the application, applied by (or in case of a dry run, found by)
+// a synchronization.
+type Report struct {
+	Time      time.Time       `json:"time"`
+	DryRun    bool            `json:"dryRun"`
+	Created   []lorawan.EUI64 `json:"created"`
+	Updated   []lorawan.EUI64 `json:"updated"`
+	Disabled  []lorawan.EUI64 `json:"disabled"`
+	Unchanged int             `json:"unchanged"`
+	Errors    []string        `json:"errors"`
+}
+
+// LastReport returns the report of the last synchronization (or nil when
+// no synchronization has been completed yet).
+func LastReport() *Report {
+	lastReportMu.RLock()
+	defer lastReportMu.RUnlock()
+	return lastReport
+}
+
+// SyncLoop synchronizes the nodes of the configured application with the
+// device registry every Interval (when this instance is the leader of the
+// sync job). This function never returns.
+func SyncLoop() {
+	for {
+		if leader.IsLeader(leader.RegistrySync) {
+			if err := SyncOnce(); err != nil {
+				log.WithField("url", URL).Errorf("device registry sync error: %s", err)
+			}
+		}
+		time.Sleep(Interval)
+	}
+}
+
+// SyncOnce fetches the devices from the device registry and synchronizes
+// the nodes of the configured application.
+func SyncOnce() error {
+	devices, err := Fetch(URL, Format)
+	if err != nil {
+		return errors.Wrap(err, "fetch devices error")
+	}
+
+	report, err := Sync(ApplicationID, devices, DryRun)
+	if err != nil {
+		return errors.Wrap(err, "sync error")
+	}
+
+	lastReportMu.Lock()
+	lastReport = &report
+	lastReportMu.Unlock()
+
+	log.WithFields(log.Fields{
+		"application_id": ApplicationID,
+		"dry_run":        report.DryRun,
+		"created":        len(report.Created),
+		"updated":        len(report.Updated),
+		"disabled":       len(report.Disabled),
+		"unchanged":      report.Unchanged,
+		"errors":         len(report.Errors),
+	}).Info("device registry synchronized")
+	return nil
+}
+
+// Fetch fetches the devices from the given device registry URL.
+func Fetch(url, format string) ([]Device, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "http get error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected 200, got: %d", resp.StatusCode)
+	}
+
+	switch format {
+	case FormatJSON:
+		var devices []Device
+		if err := json.NewDecoder(resp.Body).Decode(&devices); err != nil {
+			return nil, errors.Wrap(err, "decode json error")
+		}
+		return devices, nil
+	case FormatCSV:
+		return decodeCSV(resp.Body)
+	default:
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// decodeCSV decodes the devices from the given CSV. The first row must
+// contain the column names, of which devEUI is required. The other columns
+// (appEUI, appKey, name, description and tags) are optional, unknown columns
+// are ignored. Tags are separated by a space.
+func decodeCSV(r io.Reader) ([]Device, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "read csv error")
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("csv header is missing")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["devEUI"]; !ok {
+		return nil, errors.New("csv devEUI column is missing")
+	}
+
+	var devices []Device
+	for i, row := range rows[1:] {
+		get := func(name string) string {
+			if j, ok := columns[name]; ok && j < len(row) {
+				return strings.TrimSpace(row[j])
+			}
+			return ""
+		}
+
+		d := Device{
+			Name:        get("name"),
+			Description: get("description"),
+			Tags:        strings.Fields(get("tags")),
+		}
+		if err := d.DevEUI.UnmarshalText([]byte(get("devEUI"))); err != nil {
+			return nil, errors.Wrapf(err, "row %d: devEUI", i+2)
+		}
+		if s := get("appEUI"); s != "" {
+			if err := d.AppEUI.UnmarshalText([]byte(s)); err != nil {
+				return nil, errors.Wrapf(err, "row %d: appEUI", i+2)
+			}
+		}
+		if s := get("appKey"); s != "" {
+			if err := d.AppKey.UnmarshalText([]byte(s)); err != nil {
+				return nil, errors.Wrapf(err, "row %d: appKey", i+2)
+			}
+		}
+		devices = append(devices, d)
+	}
+
+	return devices, nil
+}
+
+// Sync synchronizes the nodes of the given application with the given
+// devices. Errors of individual nodes are added to the report. When dryRun
+// is set, the differences are reported but not applied.
+func Sync(applicationID int64, devices []Device, dryRun bool) (Report, error) {
+	report := Report{
+		Time:     time.Now(),
+		DryRun:   dryRun,
+		Created:  []lorawan.EUI64{},
+		Updated:  []lorawan.EUI64{},
+		Disabled: []lorawan.EUI64{},
+		Errors:   []string{},
+	}
+
+	// an empty registry is most likely caused by an issue at the side of
+	// the registry and would disable all nodes
+	if len(devices) == 0 {
+		return report, errors.New("the device registry contains no devices")
+	}
+
+	var nodes []storage.Node
+	err := storage.StreamNodesForApplicationID(common.DB, applicationID, func(n storage.Node) error {
+		nodes = append(nodes, n)
+		return nil
+	})
+	if err != nil {
+		return report, errors.Wrap(err, "get nodes error")
+	}
+	existing := make(map[lorawan.EUI64]storage.Node)
+	for _, n := range nodes {
+		existing[n.DevEUI] = n
+	}
+
+	seen := make(map[lorawan.EUI64]bool)
+	for _, d := range devices {
+		if seen[d.DevEUI] {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: duplicate devEUI", d.DevEUI))
+			continue
+		}
+		seen[d.DevEUI] = true
+
+		if d.Name == "" {
+			d.Name = d.DevEUI.String()
+		}
+
+		node, ok := existing[d.DevEUI]
+		if !ok {
+			if !dryRun {
+				if err := createNode(applicationID, d); err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("%s: %s", d.DevEUI, errors.Cause(err)))
+					continue
+				}
+			}
+			report.Created = append(report.Created, d.DevEUI)
+			continue
+		}
+
+		if node.RetiredAt != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %s", d.DevEUI, storage.ErrNodeRetired))
+			continue
+		}
+
+		if !nodeChanged(node, d) {
+			report.Unchanged++
+			continue
+		}
+		if !dryRun {
+			if err := updateNode(node, d); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %s", d.DevEUI, errors.Cause(err)))
+				continue
+			}
+		}
+		report.Updated = append(report.Updated, d.DevEUI)
+	}
+
+	for _, n := range nodes {
+		if seen[n.DevEUI] || n.Disabled {
+			continue
+		}
+		if !dryRun {
+			if err := storage.SetNodeDisabled(common.DB, n.DevEUI, true); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %s", n.DevEUI, errors.Cause(err)))
+				continue
+			}
+		}
+		report.Disabled = append(report.Disabled, n.DevEUI)
+	}
+
+	return report, nil
+}
+
+// createNode creates the node for the given device, using the settings of
+// the application.
+func createNode(applicationID int64, d Device) error {
+	return storage.CreateNode(common.DB, storage.Node{
+		ApplicationID:          applicationID,
+		UseApplicationSettings: true,
+		Name:                   d.Name,
+		Description:            d.Description,
+		DevEUI:                 d.DevEUI,
+		AppEUI:                 d.AppEUI,
+		AppKey:                 d.AppKey,
+		Tags:                   d.Tags,
+	})
+}
+
+// updateNode updates the given node with the given device and re-enables
+// the node when it was disabled.
+func updateNode(n storage.Node, d Device) error {
+	n.Name = d.Name
+	n.Description = d.Description
+	n.AppEUI = d.AppEUI
+	n.AppKey = d.AppKey
+	n.Tags = d.Tags
+	if err := storage.UpdateNode(common.DB, n); err != nil {
+		return err
+	}
+
+	if n.Disabled {
+		return storage.SetNodeDisabled(common.DB, n.DevEUI, false)
+	}
+	return nil
+}
+
+// nodeChanged returns true when the given node differs from the given
+// device (or when the node is disabled).
+func nodeChanged(n storage.Node, d Device) bool {
+	return n.Disabled ||
+		n.Name != d.Name ||
+		n.Description != d.Description ||
+		n.AppEUI != d.AppEUI ||
+		n.AppKey != d.AppKey ||
+		!sameTags(n.Tags, d.Tags)
+}
+
+// sameTags returns true when both sets of tags are equal (ignoring the
+// order).
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package registrysync
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestFetch(t *testing.T) {
+	Convey("Given a device registry serving JSON and CSV", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/devices.json":
+				fmt.Fprint(w, `[{"devEUI": "0102030405060708", "appEUI": "0807060504030201", "appKey": "01020304050607080102030405060708", "name": "garden-sensor", "tags": ["garden"]}]`)
+			case "/devices.csv":
+				fmt.Fprint(w, "devEUI,name,tags,location\n0102030405060708,garden-sensor,garden outdoor,somewhere\n")
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		Convey("When fetching the JSON devices", func() {
+			devices, err := Fetch(server.URL+"/devices.json", FormatJSON)
+			So(err, ShouldBeNil)
+
+			Convey("Then the expected devices are returned", func() {
+				So(devices, ShouldResemble, []Device{
+					{
+						DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						AppEUI: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+						AppKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+						Name:   "garden-sensor",
+						Tags:   []string{"garden"},
+					},
+				})
+			})
+		})
+
+		Convey("When fetching the CSV devices", func() {
+			devices, err := Fetch(server.URL+"/devices.csv", FormatCSV)
+			So(err, ShouldBeNil)
+
+			Convey("Then the expected devices are returned", func() {
+				So(devices, ShouldResemble, []Device{
+					{
+						DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						Name:   "garden-sensor",
+						Tags:   []string{"garden", "outdoor"},
+					},
+				})
+			})
+		})
+
+		Convey("When fetching an unknown URL", func() {
+			_, err := Fetch(server.URL+"/foo", FormatJSON)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestDecodeCSV(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			CSV           string
+			ExpectedError string
+		}{
+			{"valid", "devEUI\n0102030405060708\n", ""},
+			{"no header", "", "csv header is missing"},
+			{"no devEUI column", "name\nfoo\n", "csv devEUI column is missing"},
+			{"invalid devEUI", "devEUI\nfoo\n", "row 2: devEUI"},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				_, err := decodeCSV(strings.NewReader(test.CSV))
+				if test.ExpectedError == "" {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldStartWith, test.ExpectedError)
+				}
+			})
+		}
+	})
+}
+
+func TestSync(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application and nodes", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		unchanged := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "unchanged-node",
+			DevEUI:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Tags:          []string{},
+		}
+		changed := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "changed-node",
+			DevEUI:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+			Tags:          []string{},
+		}
+		removed := storage.Node{
+			ApplicationID: app.ID,
+			Name:          "removed-node",
+			DevEUI:        lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3},
+			Tags:          []string{},
+		}
+		for _, n := range []storage.Node{unchanged, changed, removed} {
+			So(storage.CreateNode(common.DB, n), ShouldBeNil)
+		}
+
+		devices := []Device{
+			{DevEUI: unchanged.DevEUI, Name: "unchanged-node", Tags: []string{}},
+			{DevEUI: changed.DevEUI, Name: "changed-node", Description: "new description", Tags: []string{"garden"}},
+			{DevEUI: lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}, Name: "new-node"},
+		}
+
+		Convey("When running a dry run", func() {
+			report, err := Sync(app.ID, devices, true)
+			So(err, ShouldBeNil)
+
+			Convey("Then the differences are reported", func() {
+				So(report.Created, ShouldResemble, []lorawan.EUI64{{4, 4, 4, 4, 4, 4, 4, 4}})
+				So(report.Updated, ShouldResemble, []lorawan.EUI64{changed.DevEUI})
+				So(report.Disabled, ShouldResemble, []lorawan.EUI64{removed.DevEUI})
+				So(report.Unchanged, ShouldEqual, 1)
+				So(report.Errors, ShouldHaveLength, 0)
+			})
+
+			Convey("Then the nodes are not changed", func() {
+				_, err := storage.GetNode(common.DB, lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4})
+				So(err, ShouldEqual, storage.ErrDoesNotExist)
+
+				n, err := storage.GetNode(common.DB, removed.DevEUI)
+				So(err, ShouldBeNil)
+				So(n.Disabled, ShouldBeFalse)
+			})
+		})
+
+		Convey("When synchronizing", func() {
+			report, err := Sync(app.ID, devices, false)
+			So(err, ShouldBeNil)
+			So(report.Errors, ShouldHaveLength, 0)
+
+			Convey("Then the missing node was created", func() {
+				n, err := storage.GetNode(common.DB, lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4})
+				So(err, ShouldBeNil)
+				So(n.Name, ShouldEqual, "new-node")
+				So(n.ApplicationID, ShouldEqual, app.ID)
+			})
+
+			Convey("Then the changed node was updated", func() {
+				n, err := storage.GetNode(common.DB, changed.DevEUI)
+				So(err, ShouldBeNil)
+				So(n.Description, ShouldEqual, "new description")
+				So([]string(n.Tags), ShouldResemble, []string{"garden"})
+			})
+
+			Convey("Then the removed node was disabled", func() {
+				n, err := storage.GetNode(common.DB, removed.DevEUI)
+				So(err, ShouldBeNil)
+				So(n.Disabled, ShouldBeTrue)
+			})
+
+			Convey("When the removed node is added to the registry again", func() {
+				devices = append(devices, Device{DevEUI: removed.DevEUI, Name: "removed-node", Tags: []string{}})
+				report, err := Sync(app.ID, devices, false)
+				So(err, ShouldBeNil)
+
+				Convey("Then the node was re-enabled", func() {
+					So(report.Updated, ShouldResemble, []lorawan.EUI64{removed.DevEUI})
+
+					n, err := storage.GetNode(common.DB, removed.DevEUI)
+					So(err, ShouldBeNil)
+					So(n.Disabled, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("When synchronizing with an empty registry", func() {
+			_, err := Sync(app.ID, nil, false)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
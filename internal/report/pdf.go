@@ -0,0 +1,152 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The PDF is rendered as plain (monospace) text on A4 landscape pages,
+// which only needs the standard Courier font and no external dependencies.
+const (
+	pdfPageWidth    = 842
+	pdfPageHeight   = 595
+	pdfMargin       = 30
+	pdfFontSize     = 8
+	pdfLineHeight   = 10
+	pdfMaxLineChars = (pdfPageWidth - 2*pdfMargin) * 10 / (6 * pdfFontSize) // courier is 0.6 em wide
+	pdfPageLines    = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+	pdfMaxColChars  = 40
+)
+
+// WritePDF writes the given report as PDF document. The rows are rendered
+// as text table, with the column header repeated on every page.
+func WritePDF(w io.Writer, report Report) error {
+	columns := pdfText(report.Columns)
+	rows := make([][]string, len(report.Rows))
+	for i := range report.Rows {
+		rows[i] = pdfText(report.Rows[i])
+	}
+
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = len(c)
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			if i < len(widths) && len(v) > widths[i] {
+				widths[i] = len(v)
+			}
+		}
+	}
+	for i := range widths {
+		if widths[i] > pdfMaxColChars {
+			widths[i] = pdfMaxColChars
+		}
+	}
+
+	header := []string{formatPDFRow(columns, widths)}
+	header = append(header, strings.Repeat("-", len(header[0])))
+
+	var pages [][]string
+	page := append(pdfText([]string{report.Title, report.Period}), "")
+	page = append(page, header...)
+	for _, row := range rows {
+		if len(page) == pdfPageLines {
+			pages = append(pages, page)
+			page = append([]string{}, header...)
+		}
+		page = append(page, formatPDFRow(row, widths))
+	}
+	pages = append(pages, page)
+
+	return writePDFDocument(w, pages)
+}
+
+func formatPDFRow(row []string, widths []int) string {
+	cols := make([]string, len(widths))
+	for i := range widths {
+		var v string
+		if i < len(row) {
+			v = row[i]
+		}
+		if len(v) > widths[i] {
+			v = v[:widths[i]-1] + "~"
+		}
+		cols[i] = v + strings.Repeat(" ", widths[i]-len(v))
+	}
+	line := strings.TrimRight(strings.Join(cols, "  "), " ")
+	if len(line) > pdfMaxLineChars {
+		line = line[:pdfMaxLineChars]
+	}
+	return line
+}
+
+// writePDFDocument writes a PDF document containing the given pages of
+// text lines.
+func writePDFDocument(w io.Writer, pages [][]string) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	// objects are numbered starting at 1: the catalog, the page tree, the
+	// font and then a page and content object for every page
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFString(line))
+		}
+		content.WriteString("ET")
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// pdfText returns a copy of the given values in which the characters
+// outside the printable ASCII range are replaced by a question mark, so that
+// every character is rendered with the same width.
+func pdfText(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.Map(func(r rune) rune {
+			if r < 0x20 || r > 0x7e {
+				return '?'
+			}
+			return r
+		}, v)
+	}
+	return out
+}
+
+// escapePDFString escapes the given string for usage within a PDF string
+// literal.
+func escapePDFString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s)
+}
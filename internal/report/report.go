@@ -0,0 +1,125 @@
+// Package report generates the downloadable application reports (device
+// inventory, uptime and data volumes) and renders them as CSV or PDF.
+package report
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// Report types.
+const (
+	Inventory  = "inventory"
+	Uptime     = "uptime"
+	DataVolume = "data-volume"
+)
+
+// Report formats.
+const (
+	CSV = "csv"
+	PDF = "pdf"
+)
+
+// ErrInvalidReportType is returned when the requested report type does not
+// exist.
+var ErrInvalidReportType = errors.New("invalid report type")
+
+// Report contains a generated report.
+type Report struct {
+	Title   string
+	Period  string
+	Columns []string
+	Rows    [][]string
+}
+
+// Generate generates the report of the given type for the given application
+// and period.
+func Generate(reportType string, app storage.Application, from, to time.Time) (Report, error) {
+	report := Report{
+		Period: fmt.Sprintf("%s - %s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339)),
+	}
+
+	switch reportType {
+	case Inventory:
+		report.Title = fmt.Sprintf("Device inventory of application %s", app.Name)
+		report.Columns = []string{"DevEUI", "Name", "Description", "Activation", "Class", "Tags", "Status", "Last seen"}
+	case Uptime:
+		report.Title = fmt.Sprintf("Uptime of application %s", app.Name)
+		report.Columns = []string{"DevEUI", "Name", "Last seen", "Active hours", "Hours", "Uptime %"}
+	case DataVolume:
+		report.Title = fmt.Sprintf("Data volumes of application %s", app.Name)
+		report.Columns = []string{"DevEUI", "Name", "Uplinks", "Missed", "Retransmissions", "Packet loss %"}
+	default:
+		return report, ErrInvalidReportType
+	}
+
+	stats, err := storage.GetNodeReportStatsForApplicationID(common.DB, app.ID, from, to)
+	if err != nil {
+		return report, err
+	}
+
+	hours := int(math.Ceil(to.Sub(from).Hours()))
+	for _, s := range stats {
+		var row []string
+
+		switch reportType {
+		case Inventory:
+			activation := "OTAA"
+			if s.IsABP {
+				activation = "ABP"
+			}
+			class := "A"
+			if s.IsClassC {
+				class = "C"
+			}
+			status := "enabled"
+			if s.Disabled {
+				status = "disabled"
+			}
+			row = []string{s.DevEUI.String(), s.Name, s.Description, activation, class, strings.Join(s.Tags, ","), status, formatTime(s.LastSeen)}
+		case Uptime:
+			row = []string{s.DevEUI.String(), s.Name, formatTime(s.LastSeen), fmt.Sprint(s.ActiveHours), fmt.Sprint(hours), formatPercentage(s.ActiveHours, hours)}
+		case DataVolume:
+			row = []string{s.DevEUI.String(), s.Name, fmt.Sprint(s.Uplinks), fmt.Sprint(s.Missed), fmt.Sprint(s.Retransmissions), formatPercentage(s.Missed, s.Uplinks+s.Missed)}
+		}
+
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
+
+// WriteCSV writes the given report as CSV, the first line containing the
+// column names.
+func WriteCSV(w io.Writer, report Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(report.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(report.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatPercentage(n, total int) string {
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", float64(n)/float64(total)*100)
+}
@@ -0,0 +1,171 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestWrite(t *testing.T) {
+	Convey("Given a report", t, func() {
+		report := Report{
+			Title:   "Test report",
+			Period:  "2017-01-01T00:00:00Z - 2017-01-02T00:00:00Z",
+			Columns: []string{"Name", "Description"},
+			Rows: [][]string{
+				{"node-1", "with, comma"},
+				{"node-2", "with (parentheses) and ünicode"},
+			},
+		}
+
+		Convey("When writing the report as CSV", func() {
+			var buf bytes.Buffer
+			So(WriteCSV(&buf, report), ShouldBeNil)
+
+			Convey("Then the expected CSV is written", func() {
+				So(buf.String(), ShouldEqual, "Name,Description\nnode-1,\"with, comma\"\nnode-2,with (parentheses) and ünicode\n")
+			})
+		})
+
+		Convey("When writing the report as PDF", func() {
+			var buf bytes.Buffer
+			So(WritePDF(&buf, report), ShouldBeNil)
+			b := buf.Bytes()
+
+			Convey("Then a single page PDF document is written", func() {
+				So(bytes.HasPrefix(b, []byte("%PDF-1.4\n")), ShouldBeTrue)
+				So(bytes.HasSuffix(b, []byte("%%EOF\n")), ShouldBeTrue)
+				So(string(b), ShouldContainSubstring, "/Count 1")
+				So(string(b), ShouldContainSubstring, `(node-2  with \(parentheses\) and ?nicode) Tj`)
+			})
+
+			Convey("Then the cross-reference table points to the objects", func() {
+				m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(b)
+				So(m, ShouldHaveLength, 2)
+				xref, err := strconv.Atoi(string(m[1]))
+				So(err, ShouldBeNil)
+				So(string(b[xref:]), ShouldStartWith, "xref\n0 6\n")
+
+				entries := strings.Split(string(b[xref:]), "\n")[3:8]
+				for i, e := range entries {
+					offset, err := strconv.Atoi(e[:10])
+					So(err, ShouldBeNil)
+					So(string(b[offset:]), ShouldStartWith, fmt.Sprintf("%d 0 obj\n", i+1))
+				}
+			})
+		})
+
+		Convey("Given a report which does not fit on a single page", func() {
+			for i := 0; i < 2*pdfPageLines; i++ {
+				report.Rows = append(report.Rows, []string{fmt.Sprintf("node-%d", i), ""})
+			}
+
+			Convey("Then the PDF contains multiple pages", func() {
+				var buf bytes.Buffer
+				So(WritePDF(&buf, report), ShouldBeNil)
+				So(buf.String(), ShouldContainSubstring, "/Count 3")
+			})
+		})
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application and nodes", t, func() {
+		db, err := storage.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		common.DB = db
+		test.MustResetDB(common.DB)
+
+		org := storage.Organization{
+			Name: "test-org",
+		}
+		So(storage.CreateOrganization(common.DB, &org), ShouldBeNil)
+		app := storage.Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(storage.CreateApplication(common.DB, &app), ShouldBeNil)
+
+		So(storage.CreateNode(common.DB, storage.Node{
+			ApplicationID: app.ID,
+			Name:          "node-1",
+			DevEUI:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Tags:          []string{"a", "b"},
+		}), ShouldBeNil)
+		So(storage.CreateNode(common.DB, storage.Node{
+			ApplicationID: app.ID,
+			Name:          "node-2",
+			DevEUI:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+			IsABP:         true,
+		}), ShouldBeNil)
+
+		to := time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)
+		from := to.Add(-24 * time.Hour)
+		for _, b := range []time.Time{from.Add(-time.Hour), from, from.Add(time.Hour), to} {
+			So(storage.AddLinkQuality(common.DB, storage.LinkQuality{
+				DevEUI:          lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+				Bucket:          b,
+				Uplinks:         3,
+				Missed:          1,
+				Retransmissions: 2,
+			}), ShouldBeNil)
+		}
+
+		Convey("When generating an invalid report type", func() {
+			_, err := Generate("foo", app, from, to)
+
+			Convey("Then ErrInvalidReportType is returned", func() {
+				So(err, ShouldEqual, ErrInvalidReportType)
+			})
+		})
+
+		Convey("When generating the inventory report", func() {
+			rep, err := Generate(Inventory, app, from, to)
+			So(err, ShouldBeNil)
+
+			Convey("Then it contains all nodes", func() {
+				So(rep.Rows, ShouldResemble, [][]string{
+					{"0101010101010101", "node-1", "", "OTAA", "A", "a,b", "enabled", "2017-01-01T01:00:00Z"},
+					{"0202020202020202", "node-2", "", "ABP", "A", "", "enabled", ""},
+				})
+			})
+		})
+
+		Convey("When generating the uptime report", func() {
+			rep, err := Generate(Uptime, app, from, to)
+			So(err, ShouldBeNil)
+
+			Convey("Then only the buckets within the period are taken into account", func() {
+				So(rep.Rows, ShouldResemble, [][]string{
+					{"0101010101010101", "node-1", "2017-01-01T01:00:00Z", "2", "24", "8.3"},
+					{"0202020202020202", "node-2", "", "0", "24", "0.0"},
+				})
+			})
+		})
+
+		Convey("When generating the data-volume report", func() {
+			rep, err := Generate(DataVolume, app, from, to)
+			So(err, ShouldBeNil)
+
+			Convey("Then only the buckets within the period are taken into account", func() {
+				So(rep.Rows, ShouldResemble, [][]string{
+					{"0101010101010101", "node-1", "6", "2", "4", "25.0"},
+					{"0202020202020202", "node-2", "0", "0", "0", ""},
+				})
+			})
+		})
+	})
+}
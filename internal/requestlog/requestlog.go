@@ -0,0 +1,206 @@
+// Package requestlog provides a short-term log of (sampled) API requests
+// and responses, so that global admins can inspect the requests made by
+// integration authors when debugging malformed requests. Secrets (e.g.
+// passwords, device keys and tokens) are redacted before the requests are
+// stored.
+package requestlog
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+)
+
+const requestLogKey = "request-log"
+
+// Redacted is the value replacing the redacted fields.
+const Redacted = "[REDACTED]"
+
+// redactedFields contains the (lowercase) sub-strings of the field names
+// of which the values are redacted.
+var redactedFields = []string{"password", "secret", "token", "key", "jwt", "headers"}
+
+// redactedNames contains the (lowercase) names of the fields of which the
+// values are redacted, for the fields containing credentials of which the
+// name does not match redactedFields (e.g. the AMQP URL and the PostgreSQL
+// DSN).
+var redactedNames = map[string]bool{
+	"url":              true,
+	"dsn":              true,
+	"connectionstring": true,
+	"credentialsfile":  true,
+}
+
+// Config contains the request log configuration.
+type Config struct {
+	// SampleRate defines the fraction (0 - 1) of the requests which are
+	// logged. 0 disables the sampling.
+	SampleRate float64
+
+	// LogErrors defines if all failed requests are logged, regardless of
+	// the SampleRate.
+	LogErrors bool
+
+	// TTL defines how long the requests are stored.
+	TTL time.Duration
+
+	// MaxEntries defines the max. number of stored requests.
+	MaxEntries int
+}
+
+var config = Config{
+	TTL:        24 * time.Hour,
+	MaxEntries: 1000,
+}
+
+// SetConfig sets the request log configuration.
+func SetConfig(c Config) {
+	config = c
+}
+
+// Enabled returns true when requests are logged.
+func Enabled() bool {
+	return config.SampleRate > 0 || config.LogErrors
+}
+
+// Sample returns true when the request must be logged, given if it failed.
+func Sample(failed bool) bool {
+	if failed && config.LogErrors {
+		return true
+	}
+	return config.SampleRate > 0 && rand.Float64() < config.SampleRate
+}
+
+// Entry contains a logged request.
+type Entry struct {
+	CreatedAt     time.Time       `json:"createdAt"`
+	Method        string          `json:"method"`
+	Code          string          `json:"code"`
+	Error         string          `json:"error,omitempty"`
+	Duration      time.Duration   `json:"duration"`
+	RemoteAddress string          `json:"remoteAddress,omitempty"`
+	UserAgent     string          `json:"userAgent,omitempty"`
+	Request       json.RawMessage `json:"request,omitempty"`
+	Response      json.RawMessage `json:"response,omitempty"`
+}
+
+// Filter contains the filters for listing the logged requests.
+type Filter struct {
+	// Method filters on the (full) gRPC method names containing the given
+	// string (optional).
+	Method string
+
+	// ErrorsOnly only returns the failed requests.
+	ErrorsOnly bool
+
+	// Limit defines the max. number of returned requests (optional).
+	Limit int
+}
+
+// Log stores the given entry. The oldest entries are removed when the max.
+// number of entries has been reached.
+func Log(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal entry error")
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(requestLogKey)
+	c.Send("MULTI")
+	c.Send("LPUSH", key, b)
+	c.Send("LTRIM", key, 0, config.MaxEntries-1)
+	c.Send("PEXPIRE", key, int64(config.TTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "store entry error")
+	}
+	return nil
+}
+
+// List returns the logged requests matching the given filter, newest
+// first. Entries older than the configured TTL are skipped.
+func List(f Filter) ([]Entry, error) {
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	vals, err := redis.ByteSlices(c.Do("LRANGE", common.RedisKey(requestLogKey), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "get entries error")
+	}
+
+	out := []Entry{}
+	minCreatedAt := time.Now().Add(-config.TTL)
+	for _, b := range vals {
+		var e Entry
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, errors.Wrap(err, "unmarshal entry error")
+		}
+
+		if e.CreatedAt.Before(minCreatedAt) {
+			continue
+		}
+		if f.Method != "" && !strings.Contains(e.Method, f.Method) {
+			continue
+		}
+		if f.ErrorsOnly && e.Error == "" {
+			continue
+		}
+
+		out = append(out, e)
+		if f.Limit > 0 && len(out) >= f.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Redact returns the given JSON document with the values of the fields of
+// which the name contains password, secret, token, key or jwt (case
+// insensitive), of the headers fields and of the url, dsn, connectionString
+// and credentialsFile fields replaced by Redacted.
+func Redact(b []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, errors.Wrap(err, "unmarshal json error")
+	}
+	return json.Marshal(redact(v))
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if isRedacted(k) {
+				v[k] = Redacted
+			} else {
+				v[k] = redact(val)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return v
+}
+
+func isRedacted(field string) bool {
+	field = strings.ToLower(field)
+	if redactedNames[field] {
+		return true
+	}
+	for _, f := range redactedFields {
+		if strings.Contains(field, f) {
+			return true
+		}
+	}
+	return false
+}
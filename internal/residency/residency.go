@@ -0,0 +1,84 @@
+// Package residency implements the data residency regions. The stored event
+// history of an application tagged with a residency region is routed to the
+// PostgreSQL database of that region, so that the data of e.g. EU and US
+// customers can be kept in separate databases.
+package residency
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/handler/postgresqlhandler"
+)
+
+var regionNameRegexp = regexp.MustCompile(`^[a-z0-9-]{1,32}$`)
+
+// errors
+var (
+	ErrInvalidRegion = errors.New("residency region must be formatted as NAME=DSN, the name may only be composed of lower case characters, digits and - (max 32 characters)")
+	ErrUnknownRegion = errors.New("unknown residency region")
+)
+
+// Region defines a residency region and the database in which the event
+// history of the applications within this region is stored.
+type Region struct {
+	Name string
+	DSN  string
+}
+
+// regions contains the configured regions by name.
+var regions = make(map[string]Region)
+
+// ParseRegion parses the given NAME=DSN formatted region.
+func ParseRegion(s string) (Region, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || !regionNameRegexp.MatchString(parts[0]) {
+		return Region{}, ErrInvalidRegion
+	}
+
+	if err := postgresqlhandler.ValidateDSN(parts[1]); err != nil {
+		return Region{}, errors.Wrapf(err, "region %s", parts[0])
+	}
+
+	return Region{
+		Name: parts[0],
+		DSN:  parts[1],
+	}, nil
+}
+
+// SetRegions sets the configured regions. It must be called before
+// handling any events.
+func SetRegions(rr []Region) error {
+	m := make(map[string]Region)
+	for _, r := range rr {
+		if _, ok := m[r.Name]; ok {
+			return errors.Errorf("residency region %s is configured more than once", r.Name)
+		}
+		m[r.Name] = r
+	}
+	regions = m
+	return nil
+}
+
+// GetRegion returns the region for the given name. It returns
+// ErrUnknownRegion when the region has not been configured.
+func GetRegion(name string) (Region, error) {
+	r, ok := regions[name]
+	if !ok {
+		return Region{}, ErrUnknownRegion
+	}
+	return r, nil
+}
+
+// RegionNames returns the names of the configured regions, sorted by name.
+func RegionNames() []string {
+	var names []string
+	for name := range regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
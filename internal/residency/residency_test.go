@@ -0,0 +1,64 @@
+package residency
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/handler/postgresqlhandler"
+)
+
+func TestParseRegion(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name           string
+			Value          string
+			ExpectedRegion Region
+			ExpectedError  error
+		}{
+			{"url dsn", "eu=postgres://localhost/events_eu?sslmode=disable", Region{Name: "eu", DSN: "postgres://localhost/events_eu?sslmode=disable"}, nil},
+			{"key-value dsn", "us-east=host=localhost dbname=events_us", Region{Name: "us-east", DSN: "host=localhost dbname=events_us"}, nil},
+			{"missing dsn", "eu", Region{}, ErrInvalidRegion},
+			{"invalid name", "EU=postgres://localhost/events_eu", Region{}, ErrInvalidRegion},
+			{"invalid dsn", "eu=localhost", Region{}, postgresqlhandler.ErrInvalidDSN},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				r, err := ParseRegion(test.Value)
+				So(errors.Cause(err), ShouldEqual, test.ExpectedError)
+				So(r, ShouldResemble, test.ExpectedRegion)
+			})
+		}
+	})
+}
+
+func TestRegions(t *testing.T) {
+	Convey("Given a set of configured regions", t, func() {
+		So(SetRegions([]Region{
+			{Name: "us", DSN: "postgres://localhost/events_us"},
+			{Name: "eu", DSN: "postgres://localhost/events_eu"},
+		}), ShouldBeNil)
+		defer SetRegions(nil)
+
+		Convey("Then the region names are returned sorted", func() {
+			So(RegionNames(), ShouldResemble, []string{"eu", "us"})
+		})
+
+		Convey("Then a configured region can be retrieved", func() {
+			r, err := GetRegion("eu")
+			So(err, ShouldBeNil)
+			So(r.DSN, ShouldEqual, "postgres://localhost/events_eu")
+		})
+
+		Convey("Then an unknown region returns an error", func() {
+			_, err := GetRegion("apac")
+			So(err, ShouldEqual, ErrUnknownRegion)
+		})
+
+		Convey("Then configuring a region twice returns an error", func() {
+			So(SetRegions([]Region{{Name: "eu"}, {Name: "eu"}}), ShouldNotBeNil)
+		})
+	})
+}
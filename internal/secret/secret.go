@@ -0,0 +1,154 @@
+// Package secret implements the encryption of secrets (e.g. the credentials
+// of the integrations) before these are stored in the database. The secrets
+// are encrypted with AES-256-GCM using the configured key.
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// prefix is prepended to the encrypted (and base64 encoded) secrets.
+const prefix = "enc:v1:"
+
+// KeySize defines the size (in bytes) of the encryption key.
+const KeySize = 32
+
+// errors
+var (
+	ErrNoKey      = errors.New("no secret encryption key configured")
+	ErrInvalidKey = fmt.Errorf("secret encryption key must be %d hex encoded bytes", KeySize)
+)
+
+var (
+	mu   sync.RWMutex
+	aead cipher.AEAD
+)
+
+// SetKey sets the hex encoded encryption key. An empty key disables the
+// encryption, in which case storing secrets fails.
+func SetKey(key string) error {
+	if key == "" {
+		mu.Lock()
+		aead = nil
+		mu.Unlock()
+		return nil
+	}
+
+	b, err := hex.DecodeString(key)
+	if err != nil || len(b) != KeySize {
+		return ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(b)
+	if err != nil {
+		return err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	aead = gcm
+	mu.Unlock()
+	return nil
+}
+
+// Enabled returns true when an encryption key has been configured.
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return aead != nil
+}
+
+// Encrypt encrypts the given plaintext. An empty plaintext is returned as-is.
+func Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if aead == nil {
+		return "", ErrNoKey
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("read random bytes error: %s", err)
+	}
+	b := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return prefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Encrypted returns true when the given value has been encrypted by
+// Encrypt.
+func Encrypted(s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+// Decrypt decrypts the given ciphertext, as returned by Encrypt. Values
+// without the encryption prefix (e.g. stored before the encryption was
+// introduced) are returned as-is.
+func Decrypt(ciphertext string) (string, error) {
+	if !Encrypted(ciphertext) {
+		return ciphertext, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, prefix))
+	if err != nil {
+		return "", fmt.Errorf("decode secret error: %s", err)
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if aead == nil {
+		return "", ErrNoKey
+	}
+	if len(b) < aead.NonceSize() {
+		return "", errors.New("decrypt secret error: ciphertext too short")
+	}
+
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt secret error: %s", err)
+	}
+	return string(plaintext), nil
+}
+
+// String implements a secret string which is encrypted when marshaled to
+// JSON and decrypted when unmarshaled from JSON, so that it is only stored
+// in encrypted form.
+type String string
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s String) MarshalJSON() ([]byte, error) {
+	ciphertext, err := Encrypt(string(s))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ciphertext)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *String) UnmarshalJSON(b []byte) error {
+	var ciphertext string
+	if err := json.Unmarshal(b, &ciphertext); err != nil {
+		return err
+	}
+	plaintext, err := Decrypt(ciphertext)
+	if err != nil {
+		return err
+	}
+	*s = String(plaintext)
+	return nil
+}
@@ -0,0 +1,77 @@
+package secret
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestSecret(t *testing.T) {
+	Convey("Given no encryption key", t, func() {
+		So(SetKey(""), ShouldBeNil)
+
+		Convey("Then encrypting a secret returns ErrNoKey", func() {
+			_, err := Encrypt("secret")
+			So(err, ShouldEqual, ErrNoKey)
+		})
+
+		Convey("Then empty and plaintext values are returned as-is", func() {
+			s, err := Encrypt("")
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, "")
+
+			s, err = Decrypt("secret")
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, "secret")
+		})
+	})
+
+	Convey("Given an invalid encryption key", t, func() {
+		So(SetKey("0102"), ShouldEqual, ErrInvalidKey)
+		So(SetKey(strings.Repeat("x", 64)), ShouldEqual, ErrInvalidKey)
+	})
+
+	Convey("Given an encryption key", t, func() {
+		So(SetKey(testKey), ShouldBeNil)
+		defer SetKey("")
+
+		Convey("When encrypting a secret", func() {
+			ciphertext, err := Encrypt("secret")
+			So(err, ShouldBeNil)
+			So(ciphertext, ShouldStartWith, prefix)
+			So(ciphertext, ShouldNotContainSubstring, "secret")
+			So(Encrypted(ciphertext), ShouldBeTrue)
+			So(Encrypted("secret"), ShouldBeFalse)
+
+			Convey("Then it can be decrypted", func() {
+				plaintext, err := Decrypt(ciphertext)
+				So(err, ShouldBeNil)
+				So(plaintext, ShouldEqual, "secret")
+			})
+
+			Convey("Then it can not be decrypted with an other key", func() {
+				So(SetKey(strings.Repeat("ff", KeySize)), ShouldBeNil)
+				_, err := Decrypt(ciphertext)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("Then a String is encrypted in JSON", func() {
+			b, err := json.Marshal(struct {
+				Token String `json:"token"`
+			}{"secret"})
+			So(err, ShouldBeNil)
+			So(string(b), ShouldNotContainSubstring, "secret")
+
+			var out struct {
+				Token String `json:"token"`
+			}
+			So(json.Unmarshal(b, &out), ShouldBeNil)
+			So(out.Token, ShouldEqual, String("secret"))
+		})
+	})
+}
@@ -0,0 +1,182 @@
+// Package security handles the security events (e.g. MIC failures, join
+// floods and DevNonce re-use) of the nodes. These events are sent to the
+// handler as security notifications and optionally to a syslog server in
+// CEF format.
+package security
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/syslog"
+	"github.com/brocaar/lorawan"
+)
+
+// Security event types.
+const (
+	MICFailure             = "MIC_FAILURE"
+	JoinFlood              = "JOIN_FLOOD"
+	DevNonceReuse          = "DEV_NONCE_REUSE"
+	GatewayFilterViolation = "GATEWAY_FILTER_VIOLATION"
+)
+
+const (
+	joinCountKeyTempl = "device:%s:security:join:count"
+
+	cefVendor  = "LoRa Server"
+	cefProduct = "LoRa App Server"
+)
+
+// cefSeverity contains the CEF severity (0 - 10) per event type.
+var cefSeverity = map[string]int{
+	MICFailure:             5,
+	JoinFlood:              6,
+	DevNonceReuse:          8,
+	GatewayFilterViolation: 4,
+}
+
+// cefName contains the CEF name per event type.
+var cefName = map[string]string{
+	MICFailure:             "MIC failure",
+	JoinFlood:              "Join flood",
+	DevNonceReuse:          "DevNonce re-use",
+	GatewayFilterViolation: "Gateway filter violation",
+}
+
+var (
+	// JoinFloodThreshold defines the max number of join-requests per node
+	// within the JoinFloodWindow, above which a join flood is reported
+	// (0 = disabled).
+	JoinFloodThreshold = 10
+
+	// JoinFloodWindow defines the window over which the join-requests are
+	// counted.
+	JoinFloodWindow = 10 * time.Minute
+
+	// Syslog holds the (optional) syslog writer to which the security
+	// events are written in CEF format.
+	Syslog *syslog.Writer
+
+	// Version holds the LoRa App Server version, used in the CEF header.
+	Version string
+)
+
+// Notify sends a security notification for the given node to the handler
+// and when configured, to the syslog server.
+func Notify(app storage.Application, node storage.Node, typ, message string) error {
+	log.WithFields(log.Fields{
+		"application_name": app.Name,
+		"node_name":        node.Name,
+		"type":             typ,
+		"dev_eui":          node.DevEUI,
+	}).Warning(message)
+
+	pl := handler.SecurityNotification{
+		ApplicationID:   app.ID,
+		ApplicationName: app.Name,
+		Environment:     app.Environment,
+		NodeName:        node.Name,
+		DevEUI:          node.DevEUI,
+		Type:            typ,
+		Message:         message,
+	}
+
+	if Syslog != nil {
+		now := time.Now()
+		err := Syslog.Write(syslog.Message{
+			Facility:  syslog.Security,
+			Severity:  syslog.Warning,
+			Timestamp: now,
+			AppName:   "lora-app-server",
+			MsgID:     typ,
+			Message:   CEF(pl, now),
+		})
+		if err != nil {
+			log.Errorf("write security event to syslog error: %s", err)
+		}
+	}
+
+	if err := common.Handler.SendSecurityNotification(pl); err != nil {
+		return errors.Wrap(err, "send security notification error")
+	}
+	return nil
+}
+
+// CheckJoinFlood counts the join-request of the given node and returns true
+// when the number of join-requests within the window exceeds the threshold.
+// It only returns true for the first join-request exceeding the threshold,
+// so that a flood is reported once per window.
+func CheckJoinFlood(devEUI lorawan.EUI64) (bool, error) {
+	if JoinFloodThreshold == 0 {
+		return false, nil
+	}
+
+	c := common.RedisPool.Get()
+	defer c.Close()
+
+	key := common.RedisKey(joinCountKeyTempl, devEUI)
+	count, err := redis.Int(c.Do("INCR", key))
+	if err != nil {
+		return false, errors.Wrap(err, "increment join-request count error")
+	}
+	if count == 1 {
+		if _, err := c.Do("PEXPIRE", key, int64(JoinFloodWindow/time.Millisecond)); err != nil {
+			return false, errors.Wrap(err, "set join-request count expire error")
+		}
+	}
+
+	return count == JoinFloodThreshold+1, nil
+}
+
+// CEF returns the given security notification formatted as CEF (Common
+// Event Format) event.
+func CEF(pl handler.SecurityNotification, t time.Time) string {
+	name, ok := cefName[pl.Type]
+	if !ok {
+		name = pl.Type
+	}
+
+	ext := []struct {
+		key   string
+		value string
+	}{
+		{"rt", strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)},
+		{"msg", pl.Message},
+		{"cs1Label", "applicationID"},
+		{"cs1", strconv.FormatInt(pl.ApplicationID, 10)},
+		{"cs2Label", "applicationName"},
+		{"cs2", pl.ApplicationName},
+		{"cs3Label", "nodeName"},
+		{"cs3", pl.NodeName},
+		{"cs4Label", "devEUI"},
+		{"cs4", pl.DevEUI.String()},
+	}
+	var extStr []string
+	for _, e := range ext {
+		extStr = append(extStr, e.key+"="+cefExtensionReplacer.Replace(e.value))
+	}
+
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s",
+		cefHeaderReplacer.Replace(cefVendor),
+		cefHeaderReplacer.Replace(cefProduct),
+		cefHeaderReplacer.Replace(Version),
+		cefHeaderReplacer.Replace(pl.Type),
+		cefHeaderReplacer.Replace(name),
+		cefSeverity[pl.Type],
+		strings.Join(extStr, " "),
+	)
+}
+
+var (
+	cefHeaderReplacer    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtensionReplacer = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
@@ -0,0 +1,81 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/storage"
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lora-app-server/internal/test/testhandler"
+	"github.com/brocaar/lorawan"
+)
+
+func TestCheckJoinFlood(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a join flood threshold of 3", t, func() {
+		common.RedisPool = storage.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(common.RedisPool)
+		JoinFloodThreshold = 3
+		defer func() { JoinFloodThreshold = 10 }()
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then only the 4th join-request is reported as flood", func() {
+			var floods []bool
+			for i := 0; i < 6; i++ {
+				flood, err := CheckJoinFlood(devEUI)
+				So(err, ShouldBeNil)
+				floods = append(floods, flood)
+			}
+			So(floods, ShouldResemble, []bool{false, false, false, true, false, false})
+		})
+	})
+}
+
+func TestNotify(t *testing.T) {
+	Convey("Given a test handler", t, func() {
+		h := testhandler.NewTestHandler()
+		common.Handler = h
+
+		app := storage.Application{ID: 1, Name: "test-app"}
+		node := storage.Node{Name: "test-node", DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}}
+
+		Convey("When calling Notify", func() {
+			So(Notify(app, node, MICFailure, "invalid MIC"), ShouldBeNil)
+
+			Convey("Then a security notification was sent to the handler", func() {
+				So(<-h.SendSecurityNotificationChan, ShouldResemble, handler.SecurityNotification{
+					ApplicationID:   1,
+					ApplicationName: "test-app",
+					NodeName:        "test-node",
+					DevEUI:          node.DevEUI,
+					Type:            MICFailure,
+					Message:         "invalid MIC",
+				})
+			})
+		})
+	})
+}
+
+func TestCEF(t *testing.T) {
+	Convey("Given a security notification", t, func() {
+		Version = "0.14.0"
+		pl := handler.SecurityNotification{
+			ApplicationID:   123,
+			ApplicationName: "test-app",
+			NodeName:        "test=node",
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Type:            DevNonceReuse,
+			Message:         "DevNonce 0102 has already been used",
+		}
+
+		Convey("Then CEF returns the expected CEF event", func() {
+			So(CEF(pl, time.Unix(1507760055, 0)), ShouldEqual, `CEF:0|LoRa Server|LoRa App Server|0.14.0|DEV_NONCE_REUSE|DevNonce re-use|8|rt=1507760055000 msg=DevNonce 0102 has already been used cs1Label=applicationID cs1=123 cs2Label=applicationName cs2=test-app cs3Label=nodeName cs3=test\=node cs4Label=devEUI cs4=0102030405060708`)
+		})
+	})
+}
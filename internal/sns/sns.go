@@ -0,0 +1,124 @@
+// Package sns implements a minimal AWS Simple Notification Service (SNS)
+// client, publishing messages to a topic using the SNS Query API signed
+// with AWS Signature Version 4.
+package sns
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lora-app-server/internal/egress"
+)
+
+const (
+	service    = "sns"
+	apiVersion = "2010-03-31"
+)
+
+var regionRegexp = regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-[0-9]$`)
+
+var httpClient = egress.NewClient(30 * time.Second)
+
+// Error contains an error returned by the SNS API.
+type Error struct {
+	StatusCode int
+	Code       string `xml:"Error>Code"`
+	Message    string `xml:"Error>Message"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("sns error (status: %d, code: %s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Client implements a SNS client.
+type Client struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Endpoint overrides the endpoint of the region (optional).
+	Endpoint string
+}
+
+// ValidRegion returns true when the given region is a valid AWS region
+// name (e.g. eu-west-1).
+func ValidRegion(region string) bool {
+	return regionRegexp.MatchString(region)
+}
+
+// Publish publishes the given message to the given topic, with the given
+// (string) message attributes. It returns the message ID assigned by SNS.
+func (c *Client) Publish(topicARN, message string, attributes map[string]string) (string, error) {
+	form := url.Values{}
+	form.Set("Action", "Publish")
+	form.Set("Version", apiVersion)
+	form.Set("TopicArn", topicARN)
+	form.Set("Message", message)
+
+	var names []string
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		prefix := "MessageAttributes.entry." + strconv.Itoa(i+1) + "."
+		form.Set(prefix+"Name", name)
+		form.Set(prefix+"Value.DataType", "String")
+		form.Set(prefix+"Value.StringValue", attributes[name])
+	}
+
+	body := []byte(form.Encode())
+	req, err := http.NewRequest("POST", c.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signRequest(req, body, c.AccessKeyID, c.SecretAccessKey, c.Region, service, time.Now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "http request error")
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "read response error")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		e := Error{StatusCode: resp.StatusCode}
+		xml.Unmarshal(b, &e)
+		return "", e
+	}
+
+	var out struct {
+		MessageID string `xml:"PublishResult>MessageId"`
+	}
+	if err := xml.Unmarshal(b, &out); err != nil {
+		return "", errors.Wrap(err, "unmarshal response error")
+	}
+	return out.MessageID, nil
+}
+
+// endpoint returns the SNS endpoint of the configured region.
+func (c *Client) endpoint() string {
+	if c.Endpoint != "" {
+		return c.Endpoint
+	}
+	if strings.HasPrefix(c.Region, "cn-") {
+		return "https://sns." + c.Region + ".amazonaws.com.cn/"
+	}
+	return "https://sns." + c.Region + ".amazonaws.com/"
+}
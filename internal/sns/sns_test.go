@@ -0,0 +1,105 @@
+package sns
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSignRequest(t *testing.T) {
+	Convey("Given the get-vanilla request of the AWS Signature Version 4 test suite", t, func() {
+		req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+		So(err, ShouldBeNil)
+		ts := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+		Convey("Then the signature matches the test suite", func() {
+			signRequest(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", ts)
+			So(req.Header.Get("X-Amz-Date"), ShouldEqual, "20150830T123600Z")
+			So(req.Header.Get("Authorization"), ShouldEqual, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31")
+		})
+	})
+}
+
+func TestValidRegion(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Region string
+			Valid  bool
+		}{
+			{"eu-west-1", true},
+			{"us-gov-west-1", true},
+			{"cn-north-1", true},
+			{"eu-west", false},
+			{"https://sns.eu-west-1.amazonaws.com", false},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Region, func() {
+				So(ValidRegion(test.Region), ShouldEqual, test.Valid)
+			})
+		}
+	})
+}
+
+func TestPublish(t *testing.T) {
+	Convey("Given a test server", t, func() {
+		forms := make(chan url.Values, 1)
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			form, _ := url.ParseQuery(string(b))
+			forms <- form
+
+			w.WriteHeader(status)
+			if status == http.StatusOK {
+				fmt.Fprint(w, `<PublishResponse><PublishResult><MessageId>test-message-id</MessageId></PublishResult></PublishResponse>`)
+			} else {
+				fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>AuthorizationError</Code><Message>not authorized</Message></Error></ErrorResponse>`)
+			}
+		}))
+		defer server.Close()
+
+		c := Client{
+			Region:          "eu-west-1",
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+			Endpoint:        server.URL,
+		}
+
+		Convey("When publishing a message with attributes", func() {
+			id, err := c.Publish("arn:aws:sns:eu-west-1:123456789012:events", "{}", map[string]string{
+				"eventType": "rx",
+				"devEUI":    "0102030405060708",
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the message id is returned and the message and attributes are sent", func() {
+				So(id, ShouldEqual, "test-message-id")
+
+				form := <-forms
+				So(form.Get("Action"), ShouldEqual, "Publish")
+				So(form.Get("TopicArn"), ShouldEqual, "arn:aws:sns:eu-west-1:123456789012:events")
+				So(form.Get("Message"), ShouldEqual, "{}")
+				So(form.Get("MessageAttributes.entry.1.Name"), ShouldEqual, "devEUI")
+				So(form.Get("MessageAttributes.entry.1.Value.StringValue"), ShouldEqual, "0102030405060708")
+				So(form.Get("MessageAttributes.entry.2.Name"), ShouldEqual, "eventType")
+				So(form.Get("MessageAttributes.entry.2.Value.StringValue"), ShouldEqual, "rx")
+			})
+		})
+
+		Convey("When the API returns an error", func() {
+			status = http.StatusForbidden
+			_, err := c.Publish("arn:aws:sns:eu-west-1:123456789012:events", "{}", nil)
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldResemble, Error{StatusCode: http.StatusForbidden, Code: "AuthorizationError", Message: "not authorized"})
+			})
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ApplicationSummary contains the application and organization name of an
+// application, as used by the (global admin) statistics.
+type ApplicationSummary struct {
+	ApplicationID    int64  `db:"application_id"`
+	ApplicationName  string `db:"application_name"`
+	OrganizationID   int64  `db:"organization_id"`
+	OrganizationName string `db:"organization_name"`
+}
+
+// ApplicationTraffic contains the number of uplinks of an application and
+// the number of nodes from which these were received.
+type ApplicationTraffic struct {
+	ApplicationSummary
+	Uplinks int `db:"uplinks"`
+	Nodes   int `db:"nodes"`
+}
+
+// OrganizationTraffic contains the number of uplinks of an organization.
+type OrganizationTraffic struct {
+	OrganizationID   int64  `db:"organization_id"`
+	OrganizationName string `db:"organization_name"`
+	Uplinks          int    `db:"uplinks"`
+}
+
+// GetApplicationSummaries returns the ApplicationSummary of the given
+// application ids. Unknown ids are ignored.
+func GetApplicationSummaries(db sqlx.Queryer, ids []int64) ([]ApplicationSummary, error) {
+	var out []ApplicationSummary
+	err := sqlx.Select(db, &out, `
+		select
+			a.id as application_id,
+			a.name as application_name,
+			o.id as organization_id,
+			o.name as organization_name
+		from application a
+		inner join organization o
+			on o.id = a.organization_id
+		where
+			a.id = any($1)
+		order by a.id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return out, nil
+}
+
+// GetTopApplicationsByUplinks returns the applications with the most
+// uplinks since the given time (based on the link-quality buckets), ordered
+// by the number of uplinks.
+func GetTopApplicationsByUplinks(db sqlx.Queryer, since time.Time, limit int) ([]ApplicationTraffic, error) {
+	var out []ApplicationTraffic
+	err := sqlx.Select(db, &out, `
+		select
+			a.id as application_id,
+			a.name as application_name,
+			o.id as organization_id,
+			o.name as organization_name,
+			sum(lq.uplinks) as uplinks,
+			count(distinct lq.dev_eui) as nodes
+		from node_link_quality lq
+		inner join node n
+			on n.dev_eui = lq.dev_eui
+		inner join application a
+			on a.id = n.application_id
+		inner join organization o
+			on o.id = a.organization_id
+		where
+			lq.bucket >= $1
+			and lq.uplinks > 0
+		group by a.id, o.id
+		order by uplinks desc, a.id
+		limit $2`,
+		since,
+		limit,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return out, nil
+}
+
+// GetOrganizationUplinks returns the number of uplinks since the given time
+// (based on the link-quality buckets) of all organizations, ordered by
+// organization name.
+func GetOrganizationUplinks(db sqlx.Queryer, since time.Time) ([]OrganizationTraffic, error) {
+	var out []OrganizationTraffic
+	err := sqlx.Select(db, &out, `
+		select
+			o.id as organization_id,
+			o.name as organization_name,
+			coalesce(sum(lq.uplinks), 0) as uplinks
+		from organization o
+		left join application a
+			on a.organization_id = o.id
+		left join node n
+			on n.application_id = a.id
+		left join node_link_quality lq
+			on lq.dev_eui = n.dev_eui
+			and lq.bucket >= $1
+		group by o.id
+		order by o.name`,
+		since,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return out, nil
+}
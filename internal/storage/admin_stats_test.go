@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestAdminStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with two organizations, applications and nodes", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		var apps []Application
+		for i, name := range []string{"org-a", "org-b"} {
+			org := Organization{Name: name}
+			So(CreateOrganization(db, &org), ShouldBeNil)
+
+			app := Application{Name: "app-" + name, OrganizationID: org.ID}
+			So(CreateApplication(db, &app), ShouldBeNil)
+			apps = append(apps, app)
+
+			So(CreateNode(db, Node{
+				ApplicationID: app.ID,
+				Name:          "node-" + name,
+				DevEUI:        lorawan.EUI64{byte(i + 1)},
+				RXWindow:      RX1,
+			}), ShouldBeNil)
+		}
+
+		now := time.Now().Truncate(time.Hour)
+		So(AddLinkQuality(db, LinkQuality{DevEUI: lorawan.EUI64{1}, Bucket: now, Uplinks: 5}), ShouldBeNil)
+		So(AddLinkQuality(db, LinkQuality{DevEUI: lorawan.EUI64{2}, Bucket: now, Uplinks: 10}), ShouldBeNil)
+		So(AddLinkQuality(db, LinkQuality{DevEUI: lorawan.EUI64{2}, Bucket: now.Add(-48 * time.Hour), Uplinks: 100}), ShouldBeNil)
+
+		Convey("Then GetApplicationSummaries returns the requested applications", func() {
+			summaries, err := GetApplicationSummaries(db, []int64{apps[1].ID, 12345})
+			So(err, ShouldBeNil)
+			So(summaries, ShouldResemble, []ApplicationSummary{
+				{ApplicationID: apps[1].ID, ApplicationName: "app-org-b", OrganizationID: apps[1].OrganizationID, OrganizationName: "org-b"},
+			})
+		})
+
+		Convey("Then GetTopApplicationsByUplinks returns the applications ordered by uplinks", func() {
+			top, err := GetTopApplicationsByUplinks(db, now.Add(-time.Hour), 10)
+			So(err, ShouldBeNil)
+			So(top, ShouldHaveLength, 2)
+			So(top[0].ApplicationID, ShouldEqual, apps[1].ID)
+			So(top[0].Uplinks, ShouldEqual, 10)
+			So(top[0].Nodes, ShouldEqual, 1)
+			So(top[1].ApplicationID, ShouldEqual, apps[0].ID)
+			So(top[1].Uplinks, ShouldEqual, 5)
+
+			top, err = GetTopApplicationsByUplinks(db, now.Add(-time.Hour), 1)
+			So(err, ShouldBeNil)
+			So(top, ShouldHaveLength, 1)
+		})
+
+		Convey("Then GetOrganizationUplinks returns the uplinks of all organizations", func() {
+			orgs, err := GetOrganizationUplinks(db, now.Add(-time.Hour))
+			So(err, ShouldBeNil)
+			So(orgs, ShouldResemble, []OrganizationTraffic{
+				{OrganizationID: apps[0].OrganizationID, OrganizationName: "org-a", Uplinks: 5},
+				{OrganizationID: apps[1].OrganizationID, OrganizationName: "org-b", Uplinks: 10},
+			})
+		})
+	})
+}
@@ -6,20 +6,34 @@ import (
 	"regexp"
 	"time"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 )
 
-var applicationNameRegexp = regexp.MustCompile(`^[\w-]+$`)
+var (
+	applicationNameRegexp        = regexp.MustCompile(`^[\w-]+$`)
+	applicationEnvironmentRegexp = regexp.MustCompile(`^[\w-]{0,20}$`)
+	applicationResidencyRegexp   = regexp.MustCompile(`^[a-z0-9-]{0,32}$`)
+)
+
+// MaxApplicationMQTTBufferTTL defines the max. MQTT buffer TTL (in seconds)
+// of an application.
+const MaxApplicationMQTTBufferTTL = 7 * 24 * 3600
 
 // Application represents an application.
 type Application struct {
 	ID             int64  `db:"id"`
+	UUID           UUID   `db:"uuid"`
 	Name           string `db:"name"`
 	Description    string `db:"description"`
 	OrganizationID int64  `db:"organization_id"`
+	Environment    string `db:"environment"`
+
+	// ResidencyRegion defines the data residency region of the application
+	// (empty when not set). See the residency package.
+	ResidencyRegion string `db:"residency_region"`
 
 	IsABP              bool     `db:"is_abp"`
 	IsClassC           bool     `db:"is_class_c"`
@@ -30,6 +44,27 @@ type Application struct {
 	RX2DR              uint8    `db:"rx2_dr"`
 	ADRInterval        uint32   `db:"adr_interval"`
 	InstallationMargin float64  `db:"installation_margin"`
+
+	// DownlinkAirtimeBudget defines the downlink airtime budget (in ms)
+	// per device per hour (0 = no budget).
+	DownlinkAirtimeBudget        int32 `db:"downlink_airtime_budget"`
+	DownlinkAirtimeBudgetEnforce bool  `db:"downlink_airtime_budget_enforce"`
+
+	// ProprietaryPayloadPrefix defines the MACPayload prefix (e.g. vendor
+	// identifier) of the proprietary frames routed to the application.
+	ProprietaryPayloadPrefix []byte `db:"proprietary_payload_prefix"`
+
+	// MaintenanceMode and MaintenanceUntil define the maintenance mode of
+	// the application (see SetApplicationMaintenance).
+	MaintenanceMode  bool       `db:"maintenance_mode"`
+	MaintenanceUntil *time.Time `db:"maintenance_until"`
+
+	// MQTTBufferTTL defines how long (in seconds) the events which could
+	// not be published to the MQTT broker are buffered, when buffering is
+	// enabled (0 = the configured TTL).
+	MQTTBufferTTL int32 `db:"mqtt_buffer_ttl"`
+
+	Revision int64 `db:"revision"`
 }
 
 // UserAccess represents the users that have access to an application
@@ -47,6 +82,26 @@ func (a Application) Validate() error {
 		return ErrApplicationInvalidName
 	}
 
+	if !applicationEnvironmentRegexp.MatchString(a.Environment) {
+		return ErrApplicationInvalidEnvironment
+	}
+
+	if !applicationResidencyRegexp.MatchString(a.ResidencyRegion) {
+		return ErrApplicationInvalidResidencyRegion
+	}
+
+	if a.MQTTBufferTTL < 0 || a.MQTTBufferTTL > MaxApplicationMQTTBufferTTL {
+		return ErrApplicationInvalidMQTTBufferTTL
+	}
+
+	if a.DownlinkAirtimeBudget < 0 {
+		return errors.New("DownlinkAirtimeBudget must not be negative")
+	}
+
+	if len(a.ProprietaryPayloadPrefix) > 16 {
+		return errors.New("max length of ProprietaryPayloadPrefix is 16 bytes")
+	}
+
 	if a.RXDelay > 15 {
 		return errors.New("max value of RXDelay is 15")
 	}
@@ -60,8 +115,14 @@ func CreateApplication(db *sqlx.DB, item *Application) error {
 		return errors.Wrap(err, "validate error")
 	}
 
-	err := db.Get(&item.ID, `
+	var err error
+	if item.UUID, err = NewUUID(); err != nil {
+		return err
+	}
+
+	err = db.Get(&item.ID, `
 		insert into application (
+			uuid,
 			name,
 			description,
 			rx_delay,
@@ -73,8 +134,15 @@ func CreateApplication(db *sqlx.DB, item *Application) error {
 			installation_margin,
 			is_abp,
 			is_class_c,
-			organization_id
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) returning id`,
+			organization_id,
+			environment,
+			downlink_airtime_budget,
+			downlink_airtime_budget_enforce,
+			proprietary_payload_prefix,
+			residency_region,
+			mqtt_buffer_ttl
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19) returning id`,
+		item.UUID,
 		item.Name,
 		item.Description,
 		item.RXDelay,
@@ -87,6 +155,12 @@ func CreateApplication(db *sqlx.DB, item *Application) error {
 		item.IsABP,
 		item.IsClassC,
 		item.OrganizationID,
+		item.Environment,
+		item.DownlinkAirtimeBudget,
+		item.DownlinkAirtimeBudgetEnforce,
+		item.ProprietaryPayloadPrefix,
+		item.ResidencyRegion,
+		item.MQTTBufferTTL,
 	)
 	if err != nil {
 		switch err := err.(type) {
@@ -108,10 +182,47 @@ func CreateApplication(db *sqlx.DB, item *Application) error {
 	return nil
 }
 
+// GetApplicationForProprietaryPayload returns the Application of which the
+// proprietary payload prefix matches the given MACPayload. In case multiple
+// prefixes match, the application with the longest prefix is returned.
+func GetApplicationForProprietaryPayload(db sqlx.Queryer, macPayload []byte) (Application, error) {
+	var app Application
+	err := sqlx.Get(db, &app, `
+		select *
+		from application
+		where
+			length(proprietary_payload_prefix) > 0
+			and substring($1::bytea from 1 for length(proprietary_payload_prefix)) = proprietary_payload_prefix
+		order by length(proprietary_payload_prefix) desc
+		limit 1`,
+		macPayload,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return app, ErrDoesNotExist
+		}
+		return app, errors.Wrap(err, "select error")
+	}
+	return app, nil
+}
+
 // GetApplication returns the Application for the given id.
-func GetApplication(db *sqlx.DB, id int64) (Application, error) {
+func GetApplication(db sqlx.Queryer, id int64) (Application, error) {
 	var app Application
-	err := db.Get(&app, "select * from application where id = $1", id)
+	err := sqlx.Get(db, &app, "select * from application where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return app, ErrDoesNotExist
+		}
+		return app, errors.Wrap(err, "select error")
+	}
+	return app, nil
+}
+
+// GetApplicationByUUID returns the Application for the given UUID.
+func GetApplicationByUUID(db sqlx.Queryer, u UUID) (Application, error) {
+	var app Application
+	err := sqlx.Get(db, &app, "select * from application where uuid = $1", u)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return app, ErrDoesNotExist
@@ -122,9 +233,17 @@ func GetApplication(db *sqlx.DB, id int64) (Application, error) {
 }
 
 // GetApplicationCount returns the total number of applications.
-func GetApplicationCount(db *sqlx.DB) (int, error) {
+// When an environment is given, the results will be filtered by this
+// environment.
+func GetApplicationCount(db *sqlx.DB, environment string) (int, error) {
 	var count int
-	err := db.Get(&count, "select count(*) from application")
+	err := db.Get(&count, `
+		select count(*)
+		from application
+		where
+			$1 = '' or environment = $1`,
+		environment,
+	)
 	if err != nil {
 		return 0, errors.Wrap(err, "select error")
 	}
@@ -133,9 +252,9 @@ func GetApplicationCount(db *sqlx.DB) (int, error) {
 
 // GetApplicationCountForUser returns the total number of applications
 // available for the given user.
-// When an organizationID is given, the results will be filtered by this
-//
-func GetApplicationCountForUser(db *sqlx.DB, username string, organizationID int64) (int, error) {
+// When an organizationID and / or environment is given, the results will be
+// filtered by these.
+func GetApplicationCountForUser(db *sqlx.DB, username string, organizationID int64, environment string) (int, error) {
 	var count int
 	err := db.Get(&count, `
 		select
@@ -143,7 +262,7 @@ func GetApplicationCountForUser(db *sqlx.DB, username string, organizationID int
 		from application a
 		left join application_user au
 			on a.id = au.application_id
-		left join organization_user ou
+		left join organization_member ou
 			on a.organization_id = ou.organization_id
 		inner join "user" u
 			on au.user_id = u.id or ou.user_id = u.id
@@ -156,7 +275,11 @@ func GetApplicationCountForUser(db *sqlx.DB, username string, organizationID int
 				$2 = 0
 				or a.organization_id = $2
 			)
-	`, username, organizationID)
+			and (
+				$3 = ''
+				or a.environment = $3
+			)
+	`, username, organizationID, environment)
 	if err != nil {
 		return 0, errors.Wrap(err, "select error")
 	}
@@ -164,15 +287,18 @@ func GetApplicationCountForUser(db *sqlx.DB, username string, organizationID int
 }
 
 // GetApplicationCountForOrganizationID returns the total number of
-// applications for the given organization.
-func GetApplicationCountForOrganizationID(db *sqlx.DB, organizationID int64) (int, error) {
+// applications for the given organization, optionally filtered by the
+// given environment.
+func GetApplicationCountForOrganizationID(db *sqlx.DB, organizationID int64, environment string) (int, error) {
 	var count int
 	err := db.Get(&count, `
 		select count(*)
 		from application
 		where
-			organization_id = $1`,
+			organization_id = $1
+			and ($2 = '' or environment = $2)`,
 		organizationID,
+		environment,
 	)
 	if err != nil {
 		return 0, errors.Wrap(err, "select error")
@@ -181,10 +307,21 @@ func GetApplicationCountForOrganizationID(db *sqlx.DB, organizationID int64) (in
 }
 
 // GetApplications returns a slice of applications, sorted by name and
-// respecting the given limit and offset.
-func GetApplications(db *sqlx.DB, limit, offset int) ([]Application, error) {
+// respecting the given limit and offset. When an environment is given, the
+// results will be filtered by this environment.
+func GetApplications(db *sqlx.DB, environment string, limit, offset int) ([]Application, error) {
 	var apps []Application
-	err := db.Select(&apps, "select * from application order by name limit $1 offset $2", limit, offset)
+	err := db.Select(&apps, `
+		select *
+		from application
+		where
+			$1 = '' or environment = $1
+		order by name
+		limit $2 offset $3`,
+		environment,
+		limit,
+		offset,
+	)
 	if err != nil {
 		return nil, errors.Wrap(err, "select error")
 	}
@@ -192,15 +329,16 @@ func GetApplications(db *sqlx.DB, limit, offset int) ([]Application, error) {
 }
 
 // GetApplicationsForUser returns a slice of application of which the given
-// user is a member of.
-func GetApplicationsForUser(db *sqlx.DB, username string, organizationID int64, limit, offset int) ([]Application, error) {
+// user is a member of. When an organizationID and / or environment is given,
+// the results will be filtered by these.
+func GetApplicationsForUser(db *sqlx.DB, username string, organizationID int64, environment string, limit, offset int) ([]Application, error) {
 	var apps []Application
 	err := db.Select(&apps, `
 		select a.*
 		from application a
 		left join application_user au
 			on a.id = au.application_id
-		left join organization_user ou
+		left join organization_member ou
 			on a.organization_id = ou.organization_id
 		inner join "user" u
 			on au.user_id = u.id or ou.user_id = u.id
@@ -213,9 +351,13 @@ func GetApplicationsForUser(db *sqlx.DB, username string, organizationID int64,
 				$2 = 0
 				or a.organization_id = $2
 			)
+			and (
+				$3 = ''
+				or a.environment = $3
+			)
 		order by a.name
-		limit $3 offset $4
-	`, username, organizationID, limit, offset)
+		limit $4 offset $5
+	`, username, organizationID, environment, limit, offset)
 	if err != nil {
 		return nil, errors.Wrap(err, "select error")
 	}
@@ -224,17 +366,19 @@ func GetApplicationsForUser(db *sqlx.DB, username string, organizationID int64,
 }
 
 // GetApplicationsForOrganizationID returns a slice of applications for the given
-// organization.
-func GetApplicationsForOrganizationID(db *sqlx.DB, organizationID int64, limit, offset int) ([]Application, error) {
+// organization, optionally filtered by the given environment.
+func GetApplicationsForOrganizationID(db *sqlx.DB, organizationID int64, environment string, limit, offset int) ([]Application, error) {
 	var apps []Application
 	err := db.Select(&apps, `
 		select *
 		from application
 		where
 			organization_id = $1
+			and ($2 = '' or environment = $2)
 		order by name
-		limit $2 offset $3`,
+		limit $3 offset $4`,
 		organizationID,
+		environment,
 		limit,
 		offset,
 	)
@@ -267,8 +411,16 @@ func UpdateApplication(db *sqlx.DB, item Application) error {
 			installation_margin = $10,
 			is_abp = $11,
 			is_class_c = $12,
-			organization_id = $13
-		where id = $1`,
+			organization_id = $13,
+			environment = $14,
+			downlink_airtime_budget = $15,
+			downlink_airtime_budget_enforce = $16,
+			proprietary_payload_prefix = $17,
+			residency_region = $18,
+			mqtt_buffer_ttl = $20,
+			revision = revision + 1
+		where id = $1
+		and revision = $19`,
 		item.ID,
 		item.Name,
 		item.Description,
@@ -282,6 +434,13 @@ func UpdateApplication(db *sqlx.DB, item Application) error {
 		item.IsABP,
 		item.IsClassC,
 		item.OrganizationID,
+		item.Environment,
+		item.DownlinkAirtimeBudget,
+		item.DownlinkAirtimeBudgetEnforce,
+		item.ProprietaryPayloadPrefix,
+		item.ResidencyRegion,
+		item.Revision,
+		item.MQTTBufferTTL,
 	)
 	if err != nil {
 		switch err := err.(type) {
@@ -301,7 +460,7 @@ func UpdateApplication(db *sqlx.DB, item Application) error {
 		return errors.Wrap(err, "get rows affected error")
 	}
 	if ra == 0 {
-		return ErrDoesNotExist
+		return updateNoRowsError(db, "select count(*) from application where id = $1", item.ID)
 	}
 
 	// update node settings for nodes using the application settings
@@ -316,7 +475,8 @@ func UpdateApplication(db *sqlx.DB, item Application) error {
 			adr_interval = $7,
 			installation_margin = $8,
 			is_abp = $9,
-			is_class_c = $10
+			is_class_c = $10,
+			revision = revision + 1
 		where application_id = $1
 		and use_application_settings = true`,
 		item.ID,
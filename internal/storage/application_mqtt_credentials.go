@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// mqttPasswordSize defines the number of random bytes of a generated
+// MQTT password.
+const mqttPasswordSize = 24
+
+// ApplicationMQTTCredentials contains the MQTT credentials of an
+// application, used by the MQTT broker to authenticate the application
+// and to restrict it to the topics of the application.
+type ApplicationMQTTCredentials struct {
+	ApplicationID int64     `db:"application_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	Username      string    `db:"username"`
+	PasswordHash  string    `db:"password_hash"`
+}
+
+// GenerateApplicationMQTTCredentials generates (or rotates) the MQTT
+// credentials of the given application. It returns the credentials and the
+// generated password, which is only stored as hash.
+func GenerateApplicationMQTTCredentials(db sqlx.Queryer, applicationID int64) (ApplicationMQTTCredentials, string, error) {
+	var creds ApplicationMQTTCredentials
+
+	b := make([]byte, mqttPasswordSize)
+	if _, err := rand.Read(b); err != nil {
+		return creds, "", errors.Wrap(err, "read random bytes error")
+	}
+	password := base64.RawURLEncoding.EncodeToString(b)
+
+	pwHash, err := hash(password, saltSize, HashIterations)
+	if err != nil {
+		return creds, "", err
+	}
+
+	now := time.Now()
+	err = sqlx.Get(db, &creds, `
+		insert into application_mqtt_credentials (
+			application_id,
+			created_at,
+			updated_at,
+			username,
+			password_hash
+		) values ($1, $2, $2, $3, $4)
+		on conflict (application_id) do update
+		set
+			updated_at = excluded.updated_at,
+			password_hash = excluded.password_hash
+		returning *`,
+		applicationID,
+		now,
+		fmt.Sprintf("application-%d", applicationID),
+		pwHash,
+	)
+	if err != nil {
+		return creds, "", handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"application_id": applicationID,
+		"username":       creds.Username,
+	}).Info("application mqtt credentials generated")
+	return creds, password, nil
+}
+
+// GetApplicationMQTTCredentials returns the MQTT credentials of the given
+// application.
+func GetApplicationMQTTCredentials(db sqlx.Queryer, applicationID int64) (ApplicationMQTTCredentials, error) {
+	var creds ApplicationMQTTCredentials
+	err := sqlx.Get(db, &creds, "select * from application_mqtt_credentials where application_id = $1", applicationID)
+	if err != nil {
+		return creds, handlePSQLError(err, "select error")
+	}
+	return creds, nil
+}
+
+// GetApplicationMQTTCredentialsByUsername returns the MQTT credentials
+// matching the given username.
+func GetApplicationMQTTCredentialsByUsername(db sqlx.Queryer, username string) (ApplicationMQTTCredentials, error) {
+	var creds ApplicationMQTTCredentials
+	err := sqlx.Get(db, &creds, "select * from application_mqtt_credentials where username = $1", username)
+	if err != nil {
+		return creds, handlePSQLError(err, "select error")
+	}
+	return creds, nil
+}
+
+// AuthenticateApplicationMQTTCredentials validates the given MQTT username
+// and password and returns the ID of the application on success.
+func AuthenticateApplicationMQTTCredentials(db sqlx.Queryer, username, password string) (int64, error) {
+	creds, err := GetApplicationMQTTCredentialsByUsername(db, username)
+	if err != nil {
+		if err == ErrDoesNotExist {
+			return 0, ErrInvalidUsernameOrPassword
+		}
+		return 0, err
+	}
+
+	if !hashCompare(password, creds.PasswordHash) {
+		return 0, ErrInvalidUsernameOrPassword
+	}
+	return creds.ApplicationID, nil
+}
+
+// DeleteApplicationMQTTCredentials deletes the MQTT credentials of the
+// given application.
+func DeleteApplicationMQTTCredentials(db sqlx.Execer, applicationID int64) error {
+	res, err := db.Exec("delete from application_mqtt_credentials where application_id = $1", applicationID)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("application_id", applicationID).Info("application mqtt credentials deleted")
+	return nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestApplicationMQTTCredentials(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		app := Application{
+			Name:           "test-app",
+			OrganizationID: org.ID,
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("Then getting the mqtt credentials returns ErrDoesNotExist", func() {
+			_, err := GetApplicationMQTTCredentials(db, app.ID)
+			So(err, ShouldEqual, ErrDoesNotExist)
+		})
+
+		Convey("When generating the mqtt credentials", func() {
+			creds, password, err := GenerateApplicationMQTTCredentials(db, app.ID)
+			So(err, ShouldBeNil)
+			So(creds.Username, ShouldEqual, fmt.Sprintf("application-%d", app.ID))
+			So(password, ShouldHaveLength, 32)
+
+			Convey("Then the credentials can be retrieved", func() {
+				c, err := GetApplicationMQTTCredentials(db, app.ID)
+				So(err, ShouldBeNil)
+				So(c.Username, ShouldEqual, creds.Username)
+				So(c.PasswordHash, ShouldEqual, creds.PasswordHash)
+			})
+
+			Convey("Then the credentials authenticate the application", func() {
+				id, err := AuthenticateApplicationMQTTCredentials(db, creds.Username, password)
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, app.ID)
+
+				_, err = AuthenticateApplicationMQTTCredentials(db, creds.Username, "invalid")
+				So(err, ShouldEqual, ErrInvalidUsernameOrPassword)
+
+				_, err = AuthenticateApplicationMQTTCredentials(db, "application-0", password)
+				So(err, ShouldEqual, ErrInvalidUsernameOrPassword)
+			})
+
+			Convey("When rotating the credentials", func() {
+				rotated, newPassword, err := GenerateApplicationMQTTCredentials(db, app.ID)
+				So(err, ShouldBeNil)
+				So(rotated.Username, ShouldEqual, creds.Username)
+				So(rotated.CreatedAt.Equal(creds.CreatedAt), ShouldBeTrue)
+
+				Convey("Then only the new password is valid", func() {
+					_, err := AuthenticateApplicationMQTTCredentials(db, creds.Username, password)
+					So(err, ShouldEqual, ErrInvalidUsernameOrPassword)
+
+					_, err = AuthenticateApplicationMQTTCredentials(db, creds.Username, newPassword)
+					So(err, ShouldBeNil)
+				})
+			})
+
+			Convey("When deleting the credentials", func() {
+				So(DeleteApplicationMQTTCredentials(db, app.ID), ShouldBeNil)
+
+				Convey("Then the credentials have been removed", func() {
+					_, err := GetApplicationMQTTCredentials(db, app.ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+					So(DeleteApplicationMQTTCredentials(db, app.ID), ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+
+		Convey("Then generating credentials for an unknown application returns ErrDoesNotExist", func() {
+			_, _, err := GenerateApplicationMQTTCredentials(db, app.ID+1)
+			So(err, ShouldEqual, ErrDoesNotExist)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// statusPageTokenSize defines the number of random bytes of a generated
+// status page token.
+const statusPageTokenSize = 24
+
+// ApplicationStatusPage contains the public status page settings of an
+// application. The token gives read-only access to the aggregated health
+// data of the application, without authentication.
+type ApplicationStatusPage struct {
+	ApplicationID int64     `db:"application_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	Token         string    `db:"token"`
+}
+
+// EnableApplicationStatusPage enables the public status page of the given
+// application. When the status page is already enabled, the token is
+// rotated so that the previous token stops working.
+func EnableApplicationStatusPage(db sqlx.Queryer, applicationID int64) (ApplicationStatusPage, error) {
+	var sp ApplicationStatusPage
+
+	b := make([]byte, statusPageTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return sp, errors.Wrap(err, "read random bytes error")
+	}
+
+	now := time.Now()
+	err := sqlx.Get(db, &sp, `
+		insert into application_status_page (
+			application_id,
+			created_at,
+			updated_at,
+			token
+		) values ($1, $2, $2, $3)
+		on conflict (application_id) do update
+		set
+			updated_at = excluded.updated_at,
+			token = excluded.token
+		returning *`,
+		applicationID,
+		now,
+		base64.RawURLEncoding.EncodeToString(b),
+	)
+	if err != nil {
+		return sp, handlePSQLError(err, "insert error")
+	}
+
+	log.WithField("application_id", applicationID).Info("application status page enabled")
+	return sp, nil
+}
+
+// GetApplicationStatusPage returns the status page of the given
+// application.
+func GetApplicationStatusPage(db sqlx.Queryer, applicationID int64) (ApplicationStatusPage, error) {
+	var sp ApplicationStatusPage
+	err := sqlx.Get(db, &sp, "select * from application_status_page where application_id = $1", applicationID)
+	if err != nil {
+		return sp, handlePSQLError(err, "select error")
+	}
+	return sp, nil
+}
+
+// GetApplicationStatusPageByToken returns the status page matching the
+// given token.
+func GetApplicationStatusPageByToken(db sqlx.Queryer, token string) (ApplicationStatusPage, error) {
+	var sp ApplicationStatusPage
+	err := sqlx.Get(db, &sp, "select * from application_status_page where token = $1", token)
+	if err != nil {
+		return sp, handlePSQLError(err, "select error")
+	}
+	return sp, nil
+}
+
+// DisableApplicationStatusPage disables the public status page of the
+// given application.
+func DisableApplicationStatusPage(db sqlx.Execer, applicationID int64) error {
+	res, err := db.Exec("delete from application_status_page where application_id = $1", applicationID)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("application_id", applicationID).Info("application status page disabled")
+	return nil
+}
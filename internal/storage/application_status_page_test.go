@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestApplicationStatusPage(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		app := Application{
+			Name:           "test-app",
+			OrganizationID: org.ID,
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("Then getting the status page returns ErrDoesNotExist", func() {
+			_, err := GetApplicationStatusPage(db, app.ID)
+			So(err, ShouldEqual, ErrDoesNotExist)
+		})
+
+		Convey("When enabling the status page", func() {
+			sp, err := EnableApplicationStatusPage(db, app.ID)
+			So(err, ShouldBeNil)
+			So(sp.Token, ShouldHaveLength, 32)
+
+			Convey("Then the status page can be retrieved by application and token", func() {
+				s, err := GetApplicationStatusPage(db, app.ID)
+				So(err, ShouldBeNil)
+				So(s.Token, ShouldEqual, sp.Token)
+
+				s, err = GetApplicationStatusPageByToken(db, sp.Token)
+				So(err, ShouldBeNil)
+				So(s.ApplicationID, ShouldEqual, app.ID)
+			})
+
+			Convey("When enabling the status page again", func() {
+				rotated, err := EnableApplicationStatusPage(db, app.ID)
+				So(err, ShouldBeNil)
+				So(rotated.CreatedAt.Equal(sp.CreatedAt), ShouldBeTrue)
+
+				Convey("Then the token has been rotated", func() {
+					So(rotated.Token, ShouldNotEqual, sp.Token)
+					_, err := GetApplicationStatusPageByToken(db, sp.Token)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
+
+			Convey("When disabling the status page", func() {
+				So(DisableApplicationStatusPage(db, app.ID), ShouldBeNil)
+
+				Convey("Then the status page has been removed", func() {
+					_, err := GetApplicationStatusPage(db, app.ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+					So(DisableApplicationStatusPage(db, app.ID), ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
@@ -34,6 +34,47 @@ func TestApplication(t *testing.T) {
 			})
 		})
 
+		Convey("When creating an application with an invalid environment", func() {
+			app := Application{
+				OrganizationID: org.ID,
+				Name:           "test-application",
+				Environment:    "in production",
+			}
+			err := CreateApplication(db, &app)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(errors.Cause(err), ShouldResemble, ErrApplicationInvalidEnvironment)
+			})
+		})
+
+		Convey("When creating an application with an invalid residency region", func() {
+			app := Application{
+				OrganizationID:  org.ID,
+				Name:            "test-application",
+				ResidencyRegion: "EU West",
+			}
+			err := CreateApplication(db, &app)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(errors.Cause(err), ShouldResemble, ErrApplicationInvalidResidencyRegion)
+			})
+		})
+
+		Convey("When creating an application with an invalid mqtt buffer ttl", func() {
+			app := Application{
+				OrganizationID: org.ID,
+				Name:           "test-application",
+				MQTTBufferTTL:  MaxApplicationMQTTBufferTTL + 1,
+			}
+			err := CreateApplication(db, &app)
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldResemble, ErrApplicationInvalidMQTTBufferTTL)
+			})
+		})
+
 		Convey("When creating an application", func() {
 			app := Application{
 				OrganizationID:     org.ID,
@@ -47,9 +88,47 @@ func TestApplication(t *testing.T) {
 				InstallationMargin: 5,
 				IsABP:              true,
 				IsClassC:           true,
+				Environment:        "production",
+				ResidencyRegion:    "eu",
+				MQTTBufferTTL:      3600,
+
+				DownlinkAirtimeBudget:        1000,
+				DownlinkAirtimeBudgetEnforce: true,
+				ProprietaryPayloadPrefix:     []byte{0xa0, 0x01},
 			}
 			So(CreateApplication(db, &app), ShouldBeNil)
 
+			Convey("Then it can be get by its uuid", func() {
+				So(app.UUID, ShouldNotEqual, UUID{})
+				app2, err := GetApplicationByUUID(db, app.UUID)
+				So(err, ShouldBeNil)
+				So(app2.ID, ShouldEqual, app.ID)
+
+				_, err = GetApplicationByUUID(db, UUID{})
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then it can be get by a matching proprietary payload", func() {
+				app2, err := GetApplicationForProprietaryPayload(db, []byte{0xa0, 0x01, 0x02, 0x03})
+				So(err, ShouldBeNil)
+				So(app2.ID, ShouldEqual, app.ID)
+
+				_, err = GetApplicationForProprietaryPayload(db, []byte{0xa0, 0x02, 0x02, 0x03})
+				So(err, ShouldEqual, ErrDoesNotExist)
+
+				_, err = GetApplicationForProprietaryPayload(db, []byte{0xa0})
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then creating an application with the same proprietary payload prefix fails", func() {
+				app2 := Application{
+					OrganizationID:           org.ID,
+					Name:                     "test-application-2",
+					ProprietaryPayloadPrefix: []byte{0xa0, 0x01},
+				}
+				So(CreateApplication(db, &app2), ShouldEqual, ErrAlreadyExists)
+			})
+
 			Convey("It can be get by id", func() {
 				app2, err := GetApplication(db, app.ID)
 				So(err, ShouldBeNil)
@@ -57,26 +136,40 @@ func TestApplication(t *testing.T) {
 			})
 
 			Convey("Then get applications returns a single application", func() {
-				apps, err := GetApplications(db, 10, 0)
+				apps, err := GetApplications(db, "", 10, 0)
 				So(err, ShouldBeNil)
 				So(apps, ShouldHaveLength, 1)
 				So(apps[0], ShouldResemble, app)
 			})
 
+			Convey("Then filtering on the application environment returns the expected result", func() {
+				apps, err := GetApplications(db, "production", 10, 0)
+				So(err, ShouldBeNil)
+				So(apps, ShouldHaveLength, 1)
+
+				apps, err = GetApplicationsForOrganizationID(db, org.ID, "staging", 10, 0)
+				So(err, ShouldBeNil)
+				So(apps, ShouldHaveLength, 0)
+
+				count, err := GetApplicationCount(db, "staging")
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
 			Convey("Then get application count returns 1", func() {
-				count, err := GetApplicationCount(db)
+				count, err := GetApplicationCount(db, "")
 				So(err, ShouldBeNil)
 				So(count, ShouldEqual, 1)
 			})
 
 			Convey("Then the application count for the organization returns 1", func() {
-				count, err := GetApplicationCountForOrganizationID(db, org.ID)
+				count, err := GetApplicationCountForOrganizationID(db, org.ID, "")
 				So(err, ShouldBeNil)
 				So(count, ShouldEqual, 1)
 			})
 
 			Convey("Then listing the applications for the organization returns the expected application", func() {
-				apps, err := GetApplicationsForOrganizationID(db, org.ID, 10, 0)
+				apps, err := GetApplicationsForOrganizationID(db, org.ID, "", 10, 0)
 				So(err, ShouldBeNil)
 				So(apps, ShouldHaveLength, 1)
 				So(apps[0], ShouldResemble, app)
@@ -94,11 +187,11 @@ func TestApplication(t *testing.T) {
 				So(err, ShouldBeNil)
 
 				Convey("Then the application count for the user is 0", func() {
-					count, err := GetApplicationCountForUser(db, user.Username, org.ID)
+					count, err := GetApplicationCountForUser(db, user.Username, org.ID, "")
 					So(err, ShouldBeNil)
 					So(count, ShouldEqual, 0)
 
-					apps, err := GetApplicationsForUser(db, user.Username, org.ID, 10, 0)
+					apps, err := GetApplicationsForUser(db, user.Username, org.ID, "", 10, 0)
 					So(err, ShouldBeNil)
 					So(apps, ShouldHaveLength, 0)
 				})
@@ -108,11 +201,11 @@ func TestApplication(t *testing.T) {
 					So(err, ShouldBeNil)
 
 					Convey("Then the application count for the user is 1", func() {
-						count, err := GetApplicationCountForUser(db, user.Username, org.ID)
+						count, err := GetApplicationCountForUser(db, user.Username, org.ID, "")
 						So(err, ShouldBeNil)
 						So(count, ShouldEqual, 1)
 
-						apps, err := GetApplicationsForUser(db, user.Username, org.ID, 10, 0)
+						apps, err := GetApplicationsForUser(db, user.Username, org.ID, "", 10, 0)
 						So(err, ShouldBeNil)
 						So(apps, ShouldHaveLength, 1)
 					})
@@ -128,11 +221,11 @@ func TestApplication(t *testing.T) {
 						So(count, ShouldEqual, 1)
 
 						Convey("Then the application count for the user is 1", func() {
-							count, err := GetApplicationCountForUser(db, user.Username, org.ID)
+							count, err := GetApplicationCountForUser(db, user.Username, org.ID, "")
 							So(err, ShouldBeNil)
 							So(count, ShouldEqual, 1)
 
-							apps, err := GetApplicationsForUser(db, user.Username, org.ID, 10, 0)
+							apps, err := GetApplicationsForUser(db, user.Username, org.ID, "", 10, 0)
 							So(err, ShouldBeNil)
 							So(apps, ShouldHaveLength, 1)
 						})
@@ -186,15 +279,20 @@ func TestApplication(t *testing.T) {
 				Convey("Then the application has been updated", func() {
 					app2, err := GetApplication(db, app.ID)
 					So(err, ShouldBeNil)
+					app.Revision = 1
 					So(app2, ShouldResemble, app)
 				})
+
+				Convey("Then updating the application with the old revision fails", func() {
+					So(UpdateApplication(db, app), ShouldResemble, ErrRevisionMismatch)
+				})
 			})
 
 			Convey("When deleting the application", func() {
 				So(DeleteApplication(db, app.ID), ShouldBeNil)
 
 				Convey("Then the application count returns 0", func() {
-					count, err := GetApplicationCount(db)
+					count, err := GetApplicationCount(db, "")
 					So(err, ShouldBeNil)
 					So(count, ShouldEqual, 0)
 				})
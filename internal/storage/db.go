@@ -78,3 +78,24 @@ func Transaction(db *sqlx.DB, f func(tx *sqlx.Tx) error) error {
 	}
 	return nil
 }
+
+// Savepoint wraps the given function in a savepoint within the given
+// transaction. In case the given function returns an error, the transaction
+// is rolled back to the savepoint, so that the transaction can be continued.
+func Savepoint(tx *sqlx.Tx, name string, f func() error) error {
+	if _, err := tx.Exec("savepoint " + name); err != nil {
+		return errors.Wrap(err, "create savepoint error")
+	}
+
+	if err := f(); err != nil {
+		if _, rbErr := tx.Exec("rollback to savepoint " + name); rbErr != nil {
+			return errors.Wrap(rbErr, "rollback to savepoint error")
+		}
+		return err
+	}
+
+	if _, err := tx.Exec("release savepoint " + name); err != nil {
+		return errors.Wrap(err, "release savepoint error")
+	}
+	return nil
+}
@@ -158,7 +158,7 @@ func GetDownlinkQueueItems(db *sqlx.DB, devEUI lorawan.EUI64) ([]DownlinkQueueIt
 
 // DeleteDownlinkQueueItemsForDevEUI deletes all queue items for the given
 // DevEUI.
-func DeleteDownlinkQueueItemsForDevEUI(db *sqlx.DB, devEUI lorawan.EUI64) error {
+func DeleteDownlinkQueueItemsForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
 	_, err := db.Exec("delete from downlink_queue where dev_eui = $1", devEUI[:])
 	if err != nil {
 		return errors.Wrap(err, "delete error")
@@ -0,0 +1,476 @@
+package storage
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Downlink template field types.
+const (
+	DownlinkTemplateFieldConst  = "CONST"
+	DownlinkTemplateFieldUint8  = "UINT8"
+	DownlinkTemplateFieldUint16 = "UINT16"
+	DownlinkTemplateFieldUint32 = "UINT32"
+	DownlinkTemplateFieldInt8   = "INT8"
+	DownlinkTemplateFieldInt16  = "INT16"
+	DownlinkTemplateFieldInt32  = "INT32"
+	DownlinkTemplateFieldBool   = "BOOL"
+	DownlinkTemplateFieldBytes  = "BYTES"
+)
+
+// downlinkTemplateIntRanges contains the size (in bytes) and the range of
+// the integer field types.
+var downlinkTemplateIntRanges = map[string]struct {
+	size     int
+	min, max int64
+}{
+	DownlinkTemplateFieldUint8:  {1, 0, math.MaxUint8},
+	DownlinkTemplateFieldUint16: {2, 0, math.MaxUint16},
+	DownlinkTemplateFieldUint32: {4, 0, math.MaxUint32},
+	DownlinkTemplateFieldInt8:   {1, math.MinInt8, math.MaxInt8},
+	DownlinkTemplateFieldInt16:  {2, math.MinInt16, math.MaxInt16},
+	DownlinkTemplateFieldInt32:  {4, math.MinInt32, math.MaxInt32},
+}
+
+// maxDownlinkTemplateSize defines the max. size (in bytes) of an encoded
+// downlink template (the max. FRMPayload size of LoRaWAN).
+const maxDownlinkTemplateSize = 242
+
+var (
+	downlinkTemplateNameRegexp      = regexp.MustCompile(`^[\w-]{1,100}$`)
+	downlinkTemplateFieldNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// DownlinkTemplateField defines a field of the byte layout of a downlink
+// template. CONST fields contain the (hex encoded) Value, the other fields
+// are filled with the parameter of the same name. The integer fields are
+// big-endian encoded, unless LittleEndian is set, and their parameter must
+// be within Min and Max (when both are 0, the range of the type applies).
+// BYTES fields have a fixed Length, their parameter is hex encoded.
+type DownlinkTemplateField struct {
+	Name         string `json:"name,omitempty"`
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Min          int64  `json:"min,omitempty"`
+	Max          int64  `json:"max,omitempty"`
+	LittleEndian bool   `json:"littleEndian,omitempty"`
+	Length       int    `json:"length,omitempty"`
+	Value        string `json:"value,omitempty"`
+}
+
+// hasParameter returns true when the field is filled with a parameter.
+func (f DownlinkTemplateField) hasParameter() bool {
+	return f.Type != DownlinkTemplateFieldConst
+}
+
+// size returns the size (in bytes) of the encoded field.
+func (f DownlinkTemplateField) size() int {
+	switch f.Type {
+	case DownlinkTemplateFieldConst:
+		return len(f.Value) / 2
+	case DownlinkTemplateFieldBool:
+		return 1
+	case DownlinkTemplateFieldBytes:
+		return f.Length
+	default:
+		return downlinkTemplateIntRanges[f.Type].size
+	}
+}
+
+// intRange returns the allowed range of an integer field.
+func (f DownlinkTemplateField) intRange() (int64, int64) {
+	if f.Min == 0 && f.Max == 0 {
+		r := downlinkTemplateIntRanges[f.Type]
+		return r.min, r.max
+	}
+	return f.Min, f.Max
+}
+
+// Validate validates the field definition.
+func (f DownlinkTemplateField) Validate() error {
+	if f.hasParameter() && !downlinkTemplateFieldNameRegexp.MatchString(f.Name) {
+		return ErrDownlinkTemplateInvalidFieldName
+	}
+
+	switch f.Type {
+	case DownlinkTemplateFieldConst:
+		b, err := hex.DecodeString(f.Value)
+		if err != nil || len(b) == 0 {
+			return ErrDownlinkTemplateInvalidConst
+		}
+	case DownlinkTemplateFieldBool:
+	case DownlinkTemplateFieldBytes:
+		if f.Length < 1 || f.Length > maxDownlinkTemplateSize {
+			return ErrDownlinkTemplateInvalidLength
+		}
+	default:
+		r, ok := downlinkTemplateIntRanges[f.Type]
+		if !ok {
+			return ErrDownlinkTemplateInvalidFieldType
+		}
+		min, max := f.intRange()
+		if min > max || min < r.min || max > r.max {
+			return ErrDownlinkTemplateInvalidRange
+		}
+	}
+
+	return nil
+}
+
+// DownlinkTemplateFields contains the fields of a downlink template, in the
+// order of the byte layout.
+type DownlinkTemplateFields []DownlinkTemplateField
+
+// Scan implements the sql.Scanner interface.
+func (f *DownlinkTemplateFields) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("src must be of type []byte, got: %T", src)
+	}
+	if err := json.Unmarshal(b, f); err != nil {
+		return errors.Wrap(err, "unmarshal downlink template fields error")
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (f DownlinkTemplateFields) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f)
+}
+
+// DownlinkTemplateParameterError is returned by DownlinkTemplate.Encode for
+// a missing, unknown or invalid parameter.
+type DownlinkTemplateParameterError struct {
+	Parameter string
+	Reason    string
+}
+
+func (e DownlinkTemplateParameterError) Error() string {
+	return fmt.Sprintf("parameter %s: %s", e.Parameter, e.Reason)
+}
+
+// DownlinkTemplate defines a reusable downlink payload of an application.
+// The payload is described by the byte layout of the fields, which are
+// filled with the (validated) parameters given when invoking the template
+// (see Encode).
+type DownlinkTemplate struct {
+	ID            int64                  `db:"id"`
+	CreatedAt     time.Time              `db:"created_at"`
+	UpdatedAt     time.Time              `db:"updated_at"`
+	ApplicationID int64                  `db:"application_id"`
+	Name          string                 `db:"name"`
+	Description   string                 `db:"description"`
+	FPort         int                    `db:"f_port"`
+	Confirmed     bool                   `db:"confirmed"`
+	Fields        DownlinkTemplateFields `db:"fields"`
+}
+
+// Validate validates the data of the DownlinkTemplate.
+func (t DownlinkTemplate) Validate() error {
+	if !downlinkTemplateNameRegexp.MatchString(t.Name) {
+		return ErrDownlinkTemplateInvalidName
+	}
+	if t.FPort < 1 || t.FPort > 223 {
+		return ErrDownlinkTemplateInvalidFPort
+	}
+	if len(t.Fields) == 0 {
+		return ErrDownlinkTemplateNoFields
+	}
+
+	names := make(map[string]bool)
+	var size int
+	for _, f := range t.Fields {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+		if f.hasParameter() {
+			if names[f.Name] {
+				return ErrDownlinkTemplateInvalidFieldName
+			}
+			names[f.Name] = true
+		}
+		size += f.size()
+	}
+	if size > maxDownlinkTemplateSize {
+		return ErrDownlinkTemplateTooLarge
+	}
+
+	return nil
+}
+
+// Encode returns the payload for the given parameters (as decoded from JSON
+// using json.Decoder.UseNumber). All parameters are validated before
+// encoding, a DownlinkTemplateParameterError is returned for the first
+// missing, unknown or invalid parameter.
+func (t DownlinkTemplate) Encode(params map[string]interface{}) ([]byte, error) {
+	for name := range params {
+		var found bool
+		for _, f := range t.Fields {
+			if f.hasParameter() && f.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, DownlinkTemplateParameterError{Parameter: name, Reason: "unknown parameter"}
+		}
+	}
+
+	var b []byte
+	for _, f := range t.Fields {
+		if !f.hasParameter() {
+			v, err := hex.DecodeString(f.Value)
+			if err != nil {
+				return nil, errors.Wrap(err, "decode const value error")
+			}
+			b = append(b, v...)
+			continue
+		}
+
+		v, ok := params[f.Name]
+		if !ok {
+			return nil, DownlinkTemplateParameterError{Parameter: f.Name, Reason: "missing parameter"}
+		}
+
+		fb, err := f.encode(v)
+		if err != nil {
+			return nil, DownlinkTemplateParameterError{Parameter: f.Name, Reason: err.Error()}
+		}
+		b = append(b, fb...)
+	}
+
+	return b, nil
+}
+
+// encode validates and encodes the given parameter value.
+func (f DownlinkTemplateField) encode(v interface{}) ([]byte, error) {
+	switch f.Type {
+	case DownlinkTemplateFieldBool:
+		bv, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("must be a boolean")
+		}
+		if bv {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case DownlinkTemplateFieldBytes:
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("must be a hex encoded string")
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, errors.New("must be a hex encoded string")
+		}
+		if len(b) != f.Length {
+			return nil, fmt.Errorf("must be exactly %d bytes", f.Length)
+		}
+		return b, nil
+	default:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, errors.New("must be a number")
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		min, max := f.intRange()
+		if i < min || i > max {
+			return nil, fmt.Errorf("must be between %d and %d", min, max)
+		}
+
+		var order binary.ByteOrder = binary.BigEndian
+		if f.LittleEndian {
+			order = binary.LittleEndian
+		}
+		b := make([]byte, 4)
+		switch f.size() {
+		case 1:
+			b[0] = uint8(i)
+		case 2:
+			order.PutUint16(b, uint16(i))
+		case 4:
+			order.PutUint32(b, uint32(i))
+		}
+		return b[:f.size()], nil
+	}
+}
+
+// CreateDownlinkTemplate creates the given DownlinkTemplate.
+func CreateDownlinkTemplate(db sqlx.Queryer, t *DownlinkTemplate) error {
+	if err := t.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	err := sqlx.Get(db, &t.ID, `
+		insert into downlink_template (
+			created_at,
+			updated_at,
+			application_id,
+			name,
+			description,
+			f_port,
+			confirmed,
+			fields
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+		returning id`,
+		now,
+		now,
+		t.ApplicationID,
+		t.Name,
+		t.Description,
+		t.FPort,
+		t.Confirmed,
+		t.Fields,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	log.WithFields(log.Fields{
+		"id":             t.ID,
+		"application_id": t.ApplicationID,
+		"name":           t.Name,
+	}).Info("downlink template created")
+	return nil
+}
+
+// GetDownlinkTemplate returns the DownlinkTemplate for the given id.
+func GetDownlinkTemplate(db sqlx.Queryer, id int64) (DownlinkTemplate, error) {
+	var t DownlinkTemplate
+	err := sqlx.Get(db, &t, "select * from downlink_template where id = $1", id)
+	if err != nil {
+		return t, handlePSQLError(err, "select error")
+	}
+	return t, nil
+}
+
+// GetDownlinkTemplateByName returns the DownlinkTemplate of the given
+// application with the given name.
+func GetDownlinkTemplateByName(db sqlx.Queryer, applicationID int64, name string) (DownlinkTemplate, error) {
+	var t DownlinkTemplate
+	err := sqlx.Get(db, &t, `
+		select *
+		from downlink_template
+		where application_id = $1 and name = $2`,
+		applicationID,
+		name,
+	)
+	if err != nil {
+		return t, handlePSQLError(err, "select error")
+	}
+	return t, nil
+}
+
+// GetDownlinkTemplateCount returns the total number of downlink templates
+// of the given application.
+func GetDownlinkTemplateCount(db sqlx.Queryer, applicationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select count(*)
+		from downlink_template
+		where application_id = $1`,
+		applicationID,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// GetDownlinkTemplates returns the downlink templates of the given
+// application, sorted by name.
+func GetDownlinkTemplates(db sqlx.Queryer, applicationID int64, limit, offset int) ([]DownlinkTemplate, error) {
+	var templates []DownlinkTemplate
+	err := sqlx.Select(db, &templates, `
+		select *
+		from downlink_template
+		where application_id = $1
+		order by name
+		limit $2 offset $3`,
+		applicationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return templates, nil
+}
+
+// UpdateDownlinkTemplate updates the given DownlinkTemplate.
+func UpdateDownlinkTemplate(db sqlx.Execer, t *DownlinkTemplate) error {
+	if err := t.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	res, err := db.Exec(`
+		update downlink_template
+		set
+			updated_at = $2,
+			name = $3,
+			description = $4,
+			f_port = $5,
+			confirmed = $6,
+			fields = $7
+		where id = $1`,
+		t.ID,
+		now,
+		t.Name,
+		t.Description,
+		t.FPort,
+		t.Confirmed,
+		t.Fields,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	t.UpdatedAt = now
+	log.WithField("id", t.ID).Info("downlink template updated")
+	return nil
+}
+
+// DeleteDownlinkTemplate deletes the DownlinkTemplate matching the given id.
+func DeleteDownlinkTemplate(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from downlink_template where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("downlink template deleted")
+	return nil
+}
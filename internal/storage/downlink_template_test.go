@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestDownlinkTemplateValidate(t *testing.T) {
+	Convey("Given a set of downlink templates", t, func() {
+		valid := DownlinkTemplate{
+			Name:  "set-interval",
+			FPort: 10,
+			Fields: DownlinkTemplateFields{
+				{Type: DownlinkTemplateFieldConst, Value: "01"},
+				{Name: "interval", Type: DownlinkTemplateFieldUint16, Min: 60, Max: 3600},
+			},
+		}
+
+		tests := []struct {
+			Name     string
+			Modify   func(t *DownlinkTemplate)
+			Expected error
+		}{
+			{"valid template", func(t *DownlinkTemplate) {}, nil},
+			{"invalid name", func(t *DownlinkTemplate) { t.Name = "set interval" }, ErrDownlinkTemplateInvalidName},
+			{"invalid fPort", func(t *DownlinkTemplate) { t.FPort = 224 }, ErrDownlinkTemplateInvalidFPort},
+			{"no fields", func(t *DownlinkTemplate) { t.Fields = nil }, ErrDownlinkTemplateNoFields},
+			{"invalid field name", func(t *DownlinkTemplate) { t.Fields[1].Name = "1interval" }, ErrDownlinkTemplateInvalidFieldName},
+			{"duplicate field name", func(t *DownlinkTemplate) {
+				t.Fields = append(t.Fields, DownlinkTemplateField{Name: "interval", Type: DownlinkTemplateFieldBool})
+			}, ErrDownlinkTemplateInvalidFieldName},
+			{"invalid field type", func(t *DownlinkTemplate) { t.Fields[1].Type = "FLOAT" }, ErrDownlinkTemplateInvalidFieldType},
+			{"range exceeding the type", func(t *DownlinkTemplate) { t.Fields[1].Max = 70000 }, ErrDownlinkTemplateInvalidRange},
+			{"min above max", func(t *DownlinkTemplate) { t.Fields[1].Min = 4000 }, ErrDownlinkTemplateInvalidRange},
+			{"invalid const", func(t *DownlinkTemplate) { t.Fields[0].Value = "0g" }, ErrDownlinkTemplateInvalidConst},
+			{"bytes without length", func(t *DownlinkTemplate) {
+				t.Fields = append(t.Fields, DownlinkTemplateField{Name: "key", Type: DownlinkTemplateFieldBytes})
+			}, ErrDownlinkTemplateInvalidLength},
+			{"too large", func(t *DownlinkTemplate) {
+				t.Fields = append(t.Fields, DownlinkTemplateField{Name: "key", Type: DownlinkTemplateFieldBytes, Length: 240})
+			}, ErrDownlinkTemplateTooLarge},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				dt := valid
+				dt.Fields = append(DownlinkTemplateFields{}, valid.Fields...)
+				test.Modify(&dt)
+				So(dt.Validate(), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestDownlinkTemplateEncode(t *testing.T) {
+	Convey("Given a downlink template", t, func() {
+		dt := DownlinkTemplate{
+			Name:  "configure",
+			FPort: 10,
+			Fields: DownlinkTemplateFields{
+				{Type: DownlinkTemplateFieldConst, Value: "01"},
+				{Name: "interval", Type: DownlinkTemplateFieldUint16, Min: 60, Max: 3600},
+				{Name: "offset", Type: DownlinkTemplateFieldInt16, LittleEndian: true},
+				{Name: "enabled", Type: DownlinkTemplateFieldBool},
+				{Name: "key", Type: DownlinkTemplateFieldBytes, Length: 2},
+			},
+		}
+		So(dt.Validate(), ShouldBeNil)
+
+		tests := []struct {
+			Name          string
+			Parameters    string
+			Expected      []byte
+			ExpectedError error
+		}{
+			{
+				"valid parameters",
+				`{"interval": 300, "offset": -2, "enabled": true, "key": "abcd"}`,
+				[]byte{0x01, 0x01, 0x2c, 0xfe, 0xff, 0x01, 0xab, 0xcd},
+				nil,
+			},
+			{
+				"missing parameter",
+				`{"interval": 300, "offset": -2, "enabled": true}`,
+				nil,
+				DownlinkTemplateParameterError{Parameter: "key", Reason: "missing parameter"},
+			},
+			{
+				"unknown parameter",
+				`{"interval": 300, "offset": -2, "enabled": true, "key": "abcd", "mode": 1}`,
+				nil,
+				DownlinkTemplateParameterError{Parameter: "mode", Reason: "unknown parameter"},
+			},
+			{
+				"out of range",
+				`{"interval": 30, "offset": -2, "enabled": true, "key": "abcd"}`,
+				nil,
+				DownlinkTemplateParameterError{Parameter: "interval", Reason: "must be between 60 and 3600"},
+			},
+			{
+				"not an integer",
+				`{"interval": 300.5, "offset": -2, "enabled": true, "key": "abcd"}`,
+				nil,
+				DownlinkTemplateParameterError{Parameter: "interval", Reason: "must be an integer"},
+			},
+			{
+				"invalid type",
+				`{"interval": 300, "offset": -2, "enabled": 1, "key": "abcd"}`,
+				nil,
+				DownlinkTemplateParameterError{Parameter: "enabled", Reason: "must be a boolean"},
+			},
+			{
+				"invalid length",
+				`{"interval": 300, "offset": -2, "enabled": true, "key": "ab"}`,
+				nil,
+				DownlinkTemplateParameterError{Parameter: "key", Reason: "must be exactly 2 bytes"},
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				params := make(map[string]interface{})
+				dec := json.NewDecoder(strings.NewReader(test.Parameters))
+				dec.UseNumber()
+				So(dec.Decode(&params), ShouldBeNil)
+
+				b, err := dt.Encode(params)
+				So(err, ShouldResemble, test.ExpectedError)
+				So(b, ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
+
+func TestDownlinkTemplate(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization and application", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+		app := Application{
+			OrganizationID: org.ID,
+			Name:           "test",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("When creating an invalid downlink template", func() {
+			err := CreateDownlinkTemplate(db, &DownlinkTemplate{
+				ApplicationID: app.ID,
+				Name:          "set-interval",
+				FPort:         10,
+			})
+
+			Convey("Then a validation error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrDownlinkTemplateNoFields)
+			})
+		})
+
+		Convey("When creating a downlink template", func() {
+			dt := DownlinkTemplate{
+				ApplicationID: app.ID,
+				Name:          "set-interval",
+				Description:   "Sets the uplink interval",
+				FPort:         10,
+				Confirmed:     true,
+				Fields: DownlinkTemplateFields{
+					{Type: DownlinkTemplateFieldConst, Value: "01"},
+					{Name: "interval", Type: DownlinkTemplateFieldUint16, Min: 60, Max: 3600},
+				},
+			}
+			So(CreateDownlinkTemplate(db, &dt), ShouldBeNil)
+			dt.CreatedAt = dt.CreatedAt.UTC().Truncate(time.Millisecond)
+			dt.UpdatedAt = dt.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+			Convey("Then it can be retrieved by id and by name", func() {
+				dt2, err := GetDownlinkTemplate(db, dt.ID)
+				So(err, ShouldBeNil)
+				dt2.CreatedAt = dt2.CreatedAt.UTC().Truncate(time.Millisecond)
+				dt2.UpdatedAt = dt2.UpdatedAt.UTC().Truncate(time.Millisecond)
+				So(dt2, ShouldResemble, dt)
+
+				dt2, err = GetDownlinkTemplateByName(db, app.ID, "set-interval")
+				So(err, ShouldBeNil)
+				So(dt2.ID, ShouldEqual, dt.ID)
+			})
+
+			Convey("Then it is returned by the application template list", func() {
+				count, err := GetDownlinkTemplateCount(db, app.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				templates, err := GetDownlinkTemplates(db, app.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(templates, ShouldHaveLength, 1)
+				So(templates[0].ID, ShouldEqual, dt.ID)
+			})
+
+			Convey("Then a template with the same name can not be created", func() {
+				dt2 := dt
+				So(CreateDownlinkTemplate(db, &dt2), ShouldNotBeNil)
+			})
+
+			Convey("When updating the downlink template", func() {
+				dt.FPort = 20
+				dt.Fields[1].Max = 7200
+				So(UpdateDownlinkTemplate(db, &dt), ShouldBeNil)
+
+				Convey("Then it has been updated", func() {
+					dt2, err := GetDownlinkTemplate(db, dt.ID)
+					So(err, ShouldBeNil)
+					So(dt2.FPort, ShouldEqual, 20)
+					So(dt2.Fields, ShouldResemble, dt.Fields)
+				})
+			})
+
+			Convey("When deleting the downlink template", func() {
+				So(DeleteDownlinkTemplate(db, dt.ID), ShouldBeNil)
+
+				Convey("Then it has been deleted", func() {
+					_, err := GetDownlinkTemplate(db, dt.ID)
+					So(errors.Cause(err), ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
@@ -3,23 +3,55 @@ package storage
 import (
 	"database/sql"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
 // errors
 var (
-	ErrAlreadyExists             = errors.New("object already exists")
-	ErrDoesNotExist              = errors.New("object does not exist")
-	ErrApplicationInvalidName    = errors.New("invalid application name")
-	ErrNodeInvalidName           = errors.New("invalid node name")
-	ErrNodeMaxRXDelay            = errors.New("max value of RXDelay is 15")
-	ErrCFListTooManyChannels     = errors.New("too many channels in channel-list")
-	ErrUserInvalidUsername       = errors.New("username name may only be composed of upper and lower case characters and digits")
-	ErrUserPasswordLength        = errors.New("passwords must be at least 6 characters long")
-	ErrInvalidUsernameOrPassword = errors.New("invalid username or password")
-	ErrOrganizationInvalidName   = errors.New("invalid organization name")
-	ErrGatewayInvalidName        = errors.New("invalid gateway name")
+	ErrAlreadyExists                     = errors.New("object already exists")
+	ErrDoesNotExist                      = errors.New("object does not exist")
+	ErrRevisionMismatch                  = errors.New("object has been modified (revision mismatch)")
+	ErrApplicationInvalidName            = errors.New("invalid application name")
+	ErrApplicationInvalidEnvironment     = errors.New("invalid application environment")
+	ErrApplicationInvalidResidencyRegion = errors.New("invalid application residency region")
+	ErrApplicationInvalidMQTTBufferTTL   = errors.New("application mqtt buffer ttl must be between 0 and 604800 seconds")
+	ErrNodeInvalidName                   = errors.New("invalid node name")
+	ErrNodeMaxRXDelay                    = errors.New("max value of RXDelay is 15")
+	ErrNodeInvalidTag                    = errors.New("invalid node tag")
+	ErrNodeInvalidVariableName           = errors.New("invalid node variable name")
+	ErrNodeTagsRequired                  = errors.New("at least one tag is required")
+	ErrNodeRetired                       = errors.New("node is retired")
+	ErrNodeInvalidAlias                  = errors.New("node alias may only be composed of lower case characters, digits, -, _ and . (max 100 characters)")
+	ErrNodeFilterInvalidNotSeenHours     = errors.New("not seen hours must not be negative")
+	ErrNodeFilterInvalidName             = errors.New("invalid node filter name")
+	ErrCFListTooManyChannels             = errors.New("too many channels in channel-list")
+	ErrUserInvalidUsername               = errors.New("username name may only be composed of upper and lower case characters and digits")
+	ErrUserPasswordLength                = errors.New("passwords must be at least 6 characters long")
+	ErrInvalidUsernameOrPassword         = errors.New("invalid username or password")
+	ErrOrganizationInvalidName           = errors.New("invalid organization name")
+	ErrGatewayInvalidName                = errors.New("invalid gateway name")
+	ErrGatewayFilterInvalidMode          = errors.New("gateway filter mode must be ALLOW or DENY")
+	ErrDigestInvalidFrequency            = errors.New("digest frequency must be DAILY or WEEKLY")
+	ErrDigestInvalidWebhookURL           = errors.New("digest webhook url must be a http(s) url")
+	ErrChaosInvalidFailureRate           = errors.New("chaos failure rate must be between 0 and 100")
+	ErrChaosInvalidLatency               = errors.New("chaos latency must be between 0 and 1 minute")
+	ErrChaosInvalidUntil                 = errors.New("chaos end must be in the future and within 24 hours")
+	ErrIntegrationFilterInvalidEventType = errors.New("filter event type must be up, join, ack, error, security, proprietary or custom")
+	ErrIntegrationFilterInvalidFPort     = errors.New("filter fPort must be between 1 and 255")
+	ErrElevationJustificationRequired    = errors.New("elevation justification is required")
+	ErrElevationInvalidExpiresAt         = errors.New("elevation expiry must be in the future and within 24 hours")
+	ErrElevationInactive                 = errors.New("elevation has expired or has been revoked")
+	ErrDownlinkTemplateInvalidName       = errors.New("downlink template name may only be composed of letters, digits, - and _ (max 100 characters)")
+	ErrDownlinkTemplateInvalidFPort      = errors.New("downlink template fPort must be between 1 and 223")
+	ErrDownlinkTemplateNoFields          = errors.New("downlink template must have at least one field")
+	ErrDownlinkTemplateInvalidFieldName  = errors.New("downlink template field names must be unique and may only be composed of letters, digits and _")
+	ErrDownlinkTemplateInvalidFieldType  = errors.New("downlink template field type must be CONST, UINT8, UINT16, UINT32, INT8, INT16, INT32, BOOL or BYTES")
+	ErrDownlinkTemplateInvalidRange      = errors.New("downlink template field min and max must be within the range of the field type")
+	ErrDownlinkTemplateInvalidLength     = errors.New("downlink template BYTES field length must be between 1 and 242")
+	ErrDownlinkTemplateInvalidConst      = errors.New("downlink template CONST field value must be a non-empty hex string")
+	ErrDownlinkTemplateTooLarge          = errors.New("downlink template payload must not exceed 242 bytes")
 )
 
 func handlePSQLError(err error, description string) error {
@@ -39,3 +71,17 @@ func handlePSQLError(err error, description string) error {
 
 	return errors.Wrap(err, description)
 }
+
+// updateNoRowsError returns the error for an update affecting no rows.
+// When the given (count) query returns a count > 0, the object exists but
+// has been modified in the meantime and ErrRevisionMismatch is returned.
+func updateNoRowsError(db sqlx.Queryer, query string, args ...interface{}) error {
+	var count int
+	if err := sqlx.Get(db, &count, query, args...); err != nil {
+		return errors.Wrap(err, "select error")
+	}
+	if count > 0 {
+		return ErrRevisionMismatch
+	}
+	return ErrDoesNotExist
+}
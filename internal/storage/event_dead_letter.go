@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// EventDeadLetter represents an event which could not be delivered to the
+// handlers within the max. number of attempts. Dead-lettered events are
+// kept until they are retried (moved back to the event outbox) or purged.
+// Target and Delivered are retained when retrying the event, so that it is
+// only delivered to the targets which failed (see EventOutboxItem).
+type EventDeadLetter struct {
+	ID             int64           `db:"id"`
+	OrganizationID int64           `db:"organization_id"`
+	CreatedAt      time.Time       `db:"created_at"`
+	FailedAt       time.Time       `db:"failed_at"`
+	Type           string          `db:"type"`
+	Payload        json.RawMessage `db:"payload"`
+	Attempts       int             `db:"attempts"`
+	Error          string          `db:"error"`
+	Target         string          `db:"target"`
+	Delivered      pq.StringArray  `db:"delivered"`
+}
+
+// CreateEventDeadLetter creates the given dead-lettered event.
+func CreateEventDeadLetter(db sqlx.Queryer, dl *EventDeadLetter) error {
+	if dl.FailedAt.IsZero() {
+		dl.FailedAt = time.Now()
+	}
+
+	err := sqlx.Get(db, &dl.ID, `
+		insert into event_dead_letter (
+			organization_id,
+			created_at,
+			failed_at,
+			type,
+			payload,
+			attempts,
+			error,
+			target,
+			delivered
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9) returning id`,
+		dl.OrganizationID,
+		dl.CreatedAt,
+		dl.FailedAt,
+		dl.Type,
+		dl.Payload,
+		dl.Attempts,
+		dl.Error,
+		dl.Target,
+		deliveredTargets(dl.Delivered),
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":              dl.ID,
+		"organization_id": dl.OrganizationID,
+		"type":            dl.Type,
+		"target":          dl.Target,
+	}).Info("event dead letter created")
+	return nil
+}
+
+// DeadLetterEventOutboxItem moves the given event outbox item to the
+// dead-letter store, given the error of the last delivery attempt.
+func DeadLetterEventOutboxItem(db sqlx.Ext, item EventOutboxItem, deliveryErr string) error {
+	dl := EventDeadLetter{
+		OrganizationID: item.OrganizationID,
+		CreatedAt:      item.CreatedAt,
+		Type:           item.Type,
+		Payload:        item.Payload,
+		Attempts:       item.Attempts,
+		Error:          deliveryErr,
+		Target:         item.Target,
+		Delivered:      item.Delivered,
+	}
+	if err := CreateEventDeadLetter(db, &dl); err != nil {
+		return errors.Wrap(err, "create event dead letter error")
+	}
+
+	if err := DeleteEventOutboxItem(db, item.ID); err != nil {
+		return errors.Wrap(err, "delete event outbox item error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":              dl.ID,
+		"outbox_id":       item.ID,
+		"organization_id": dl.OrganizationID,
+		"type":            dl.Type,
+	}).Info("event outbox item dead-lettered")
+	return nil
+}
+
+// RetryEventDeadLetter moves the given dead-lettered event back to the
+// event outbox, so that it is delivered again (starting with a fresh
+// number of attempts).
+func RetryEventDeadLetter(db sqlx.Ext, id int64) error {
+	dl, err := GetEventDeadLetter(db, id)
+	if err != nil {
+		return errors.Wrap(err, "get event dead letter error")
+	}
+
+	item := EventOutboxItem{
+		OrganizationID: dl.OrganizationID,
+		Type:           dl.Type,
+		Payload:        dl.Payload,
+		Target:         dl.Target,
+		Delivered:      dl.Delivered,
+	}
+	if err := CreateEventOutboxItem(db, &item); err != nil {
+		return errors.Wrap(err, "create event outbox item error")
+	}
+
+	if err := DeleteEventDeadLetter(db, id); err != nil {
+		return errors.Wrap(err, "delete event dead letter error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":        id,
+		"outbox_id": item.ID,
+	}).Info("event dead letter retried")
+	return nil
+}
+
+// GetEventDeadLetter returns the dead-lettered event for the given id.
+func GetEventDeadLetter(db sqlx.Queryer, id int64) (EventDeadLetter, error) {
+	var dl EventDeadLetter
+	err := sqlx.Get(db, &dl, "select * from event_dead_letter where id = $1", id)
+	if err != nil {
+		return dl, handlePSQLError(err, "select error")
+	}
+	return dl, nil
+}
+
+// GetEventDeadLetterCount returns the number of dead-lettered events of
+// the given organization.
+func GetEventDeadLetterCount(db sqlx.Queryer, organizationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select count(*)
+		from event_dead_letter
+		where organization_id = $1`,
+		organizationID,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// GetEventDeadLetters returns the dead-lettered events of the given
+// organization, most recently failed first.
+func GetEventDeadLetters(db sqlx.Queryer, organizationID int64, limit, offset int) ([]EventDeadLetter, error) {
+	var dls []EventDeadLetter
+	err := sqlx.Select(db, &dls, `
+		select *
+		from event_dead_letter
+		where organization_id = $1
+		order by failed_at desc, id desc
+		limit $2 offset $3`,
+		organizationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return dls, nil
+}
+
+// DeleteEventDeadLetter deletes the dead-lettered event matching the given
+// id.
+func DeleteEventDeadLetter(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from event_dead_letter where id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	return nil
+}
+
+// DeleteEventDeadLettersForOrganization deletes (purges) all dead-lettered
+// events of the given organization and returns the number of deleted
+// events.
+func DeleteEventDeadLettersForOrganization(db sqlx.Execer, organizationID int64) (int, error) {
+	res, err := db.Exec("delete from event_dead_letter where organization_id = $1", organizationID)
+	if err != nil {
+		return 0, handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "get rows affected error")
+	}
+
+	log.WithFields(log.Fields{
+		"organization_id": organizationID,
+		"count":           ra,
+	}).Info("event dead letters purged")
+	return int(ra), nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestEventDeadLetter(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		Convey("When dead-lettering an outbox item", func() {
+			item := EventOutboxItem{
+				OrganizationID: org.ID,
+				Type:           "data_up",
+				Payload:        []byte(`{"fCnt":10}`),
+				Attempts:       10,
+				Delivered:      []string{"default", "eventlog"},
+			}
+			So(CreateEventOutboxItem(db, &item), ShouldBeNil)
+			So(DeadLetterEventOutboxItem(db, item, "boom"), ShouldBeNil)
+
+			Convey("Then the outbox is empty", func() {
+				count, err := GetEventOutboxCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("Then the dead letter can be listed", func() {
+				count, err := GetEventDeadLetterCount(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				items, err := GetEventDeadLetters(db, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+				So(items[0].Type, ShouldEqual, "data_up")
+				So(items[0].Attempts, ShouldEqual, 10)
+				So(items[0].Error, ShouldEqual, "boom")
+				So(items[0].Delivered, ShouldResemble, pq.StringArray{"default", "eventlog"})
+
+				Convey("When retrying the dead letter", func() {
+					So(RetryEventDeadLetter(db, items[0].ID), ShouldBeNil)
+
+					Convey("Then it is moved back to the outbox", func() {
+						count, err := GetEventOutboxCount(db)
+						So(err, ShouldBeNil)
+						So(count, ShouldEqual, 1)
+
+						_, err = GetEventDeadLetter(db, items[0].ID)
+						So(err, ShouldEqual, ErrDoesNotExist)
+					})
+
+					Convey("Then the delivered targets are retained", func() {
+						items, err := ClaimDueEventOutboxItems(db, org.ID, 10, time.Now().Add(time.Minute))
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 1)
+						So(items[0].Delivered, ShouldResemble, pq.StringArray{"default", "eventlog"})
+
+						Convey("Then the claimed item is not claimed again", func() {
+							items, err := ClaimDueEventOutboxItems(db, org.ID, 10, time.Now().Add(time.Minute))
+							So(err, ShouldBeNil)
+							So(items, ShouldHaveLength, 0)
+						})
+					})
+				})
+			})
+
+			Convey("When dead-lettering the item for a single target", func() {
+				dl := EventDeadLetter{
+					OrganizationID: org.ID,
+					Type:           "data_up",
+					Payload:        []byte(`{"fCnt":10}`),
+					Attempts:       1,
+					Error:          "circuit breaker open",
+					Target:         "integration:a",
+				}
+				So(CreateEventDeadLetter(db, &dl), ShouldBeNil)
+
+				Convey("Then retrying it only targets this target", func() {
+					So(RetryEventDeadLetter(db, dl.ID), ShouldBeNil)
+
+					items, err := ClaimDueEventOutboxItems(db, org.ID, 10, time.Now().Add(time.Minute))
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 1)
+					So(items[0].Target, ShouldEqual, "integration:a")
+				})
+			})
+
+			Convey("When purging the dead letters of the organization", func() {
+				count, err := DeleteEventDeadLettersForOrganization(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				Convey("Then no dead letters are left", func() {
+					count, err := GetEventDeadLetterCount(db, org.ID)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+			})
+		})
+	})
+}
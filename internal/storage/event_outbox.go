@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// EventOutboxItem represents an event waiting to be delivered to the
+// handlers. The OrganizationID is used to partition the delivery per
+// organization (0 when the organization is unknown). Delivered contains
+// the delivery targets (e.g. integrations) which already received the
+// event, these are skipped when retrying the event. When Target is set,
+// the event is only delivered to this target.
+type EventOutboxItem struct {
+	ID             int64           `db:"id"`
+	OrganizationID int64           `db:"organization_id"`
+	CreatedAt      time.Time       `db:"created_at"`
+	Type           string          `db:"type"`
+	Payload        json.RawMessage `db:"payload"`
+	Attempts       int             `db:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at"`
+	Target         string          `db:"target"`
+	Delivered      pq.StringArray  `db:"delivered"`
+}
+
+// CreateEventOutboxItem creates the given event outbox item.
+func CreateEventOutboxItem(db sqlx.Queryer, item *EventOutboxItem) error {
+	now := time.Now()
+	if item.NextAttemptAt.IsZero() {
+		item.NextAttemptAt = now
+	}
+
+	err := sqlx.Get(db, &item.ID, `
+		insert into event_outbox (
+			organization_id,
+			created_at,
+			type,
+			payload,
+			attempts,
+			next_attempt_at,
+			target,
+			delivered
+		) values ($1, $2, $3, $4, $5, $6, $7, $8) returning id`,
+		item.OrganizationID,
+		now,
+		item.Type,
+		item.Payload,
+		item.Attempts,
+		item.NextAttemptAt,
+		item.Target,
+		deliveredTargets(item.Delivered),
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+	item.CreatedAt = now
+
+	log.WithFields(log.Fields{
+		"id":              item.ID,
+		"organization_id": item.OrganizationID,
+		"type":            item.Type,
+	}).Debug("event outbox item created")
+	return nil
+}
+
+// ClaimDueEventOutboxItems claims the event outbox items of the given
+// organization which are due for delivery, by setting their next attempt
+// to claimUntil. The items are claimed in a single statement, so that no
+// rows are locked during the delivery. Items not completed before
+// claimUntil (e.g. after a crash) are delivered again.
+func ClaimDueEventOutboxItems(db sqlx.Queryer, organizationID int64, limit int, claimUntil time.Time) ([]EventOutboxItem, error) {
+	var items []EventOutboxItem
+	err := sqlx.Select(db, &items, `
+		update event_outbox
+		set
+			next_attempt_at = $4
+		where id in (
+			select id
+			from event_outbox
+			where
+				organization_id = $1
+				and next_attempt_at <= $2
+			order by id
+			limit $3
+			for update skip locked
+		)
+		returning *`,
+		organizationID,
+		time.Now(),
+		limit,
+		claimUntil,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "update error")
+	}
+
+	// the order of the returned rows is undefined
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	return items, nil
+}
+
+// GetDueEventOutboxOrganizationIDs returns the IDs of the organizations
+// having event outbox items which are due for delivery.
+func GetDueEventOutboxOrganizationIDs(db sqlx.Queryer) ([]int64, error) {
+	var ids []int64
+	err := sqlx.Select(db, &ids, `
+		select distinct organization_id
+		from event_outbox
+		where next_attempt_at <= $1
+		order by organization_id`,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return ids, nil
+}
+
+// GetEventOutboxCount returns the number of items in the event outbox.
+func GetEventOutboxCount(db sqlx.Queryer) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, "select count(*) from event_outbox")
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// UpdateEventOutboxItem updates the given event outbox item.
+func UpdateEventOutboxItem(db sqlx.Execer, item EventOutboxItem) error {
+	res, err := db.Exec(`
+		update event_outbox
+		set
+			attempts = $2,
+			next_attempt_at = $3,
+			delivered = $4
+		where id = $1`,
+		item.ID,
+		item.Attempts,
+		item.NextAttemptAt,
+		deliveredTargets(item.Delivered),
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	return nil
+}
+
+// DeleteEventOutboxItem deletes the event outbox item matching the given id.
+func DeleteEventOutboxItem(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from event_outbox where id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	return nil
+}
+
+// deliveredTargets returns the given delivered targets, as an empty array
+// when nil (the column is not nullable).
+func deliveredTargets(targets pq.StringArray) pq.StringArray {
+	if targets == nil {
+		return pq.StringArray{}
+	}
+	return targets
+}
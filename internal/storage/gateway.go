@@ -305,7 +305,7 @@ func GetGatewayCountForUser(db *sqlx.DB, username string) (int, error) {
 		from gateway g
 		inner join organization o
 			on o.id = g.organization_id
-		inner join organization_user ou
+		inner join organization_member ou
 			on ou.organization_id = o.id
 		inner join "user" u
 			on u.id = ou.user_id
@@ -328,7 +328,7 @@ func GetGatewaysForUser(db *sqlx.DB, username string, limit, offset int) ([]Gate
 		from gateway g
 		inner join organization o
 			on o.id = g.organization_id
-		inner join organization_user ou
+		inner join organization_member ou
 			on ou.organization_id = o.id
 		inner join "user" u
 			on u.id = ou.user_id
@@ -457,3 +457,53 @@ func GetLastGatewayPingAndRX(db sqlx.Queryer, mac lorawan.EUI64) (GatewayPing, [
 
 	return ping, rx, nil
 }
+
+// GatewayCoverageRX represents a gateway ping received by one of the
+// gateways, used for the coverage map.
+type GatewayCoverageRX struct {
+	GatewayMAC lorawan.EUI64 `db:"gateway_mac"`
+	RSSI       int           `db:"rssi"`
+	LoRaSNR    float64       `db:"lora_snr"`
+	Location   GPSPoint      `db:"location"`
+}
+
+// GetGatewayCoverageRX returns the gateway pings sent by the gateways of
+// the given organization since the given time, which were received at a
+// location within the given bounding box. The GatewayMAC is the MAC of the
+// gateway sending the ping. When mac is not nil, only the pings sent by
+// this gateway are returned.
+func GetGatewayCoverageRX(db sqlx.Queryer, organizationID int64, mac *lorawan.EUI64, since time.Time, min, max GPSPoint) ([]GatewayCoverageRX, error) {
+	var macB []byte
+	if mac != nil {
+		macB = mac[:]
+	}
+
+	var rx []GatewayCoverageRX
+	err := sqlx.Select(db, &rx, `
+		select
+			gp.gateway_mac,
+			rx.rssi,
+			rx.lora_snr,
+			rx.location
+		from gateway_ping_rx rx
+		inner join gateway_ping gp
+			on gp.id = rx.ping_id
+		inner join gateway g
+			on g.mac = gp.gateway_mac
+		where
+			g.organization_id = $1
+			and ($2::bytea is null or gp.gateway_mac = $2)
+			and rx.created_at >= $3
+			and rx.location <@ box($4::point, $5::point)`,
+		organizationID,
+		macB,
+		since,
+		min,
+		max,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return rx, nil
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Gateway filter modes.
+const (
+	GatewayFilterAllow = "ALLOW"
+	GatewayFilterDeny  = "DENY"
+)
+
+// GatewayFilter restricts the gateways from which the uplinks of the nodes
+// of an application are accepted. In ALLOW mode only the listed gateways
+// are accepted, in DENY mode all gateways except the listed gateways are
+// accepted.
+type GatewayFilter struct {
+	ApplicationID int64
+	UpdatedAt     time.Time
+	Mode          string
+	GatewayMACs   []lorawan.EUI64
+}
+
+// Validate validates the GatewayFilter data.
+func (f GatewayFilter) Validate() error {
+	if f.Mode != GatewayFilterAllow && f.Mode != GatewayFilterDeny {
+		return ErrGatewayFilterInvalidMode
+	}
+	return nil
+}
+
+// Allows returns true when the gateway with the given MAC is accepted by
+// the filter.
+func (f GatewayFilter) Allows(mac lorawan.EUI64) bool {
+	var listed bool
+	for _, m := range f.GatewayMACs {
+		if m == mac {
+			listed = true
+			break
+		}
+	}
+
+	if f.Mode == GatewayFilterDeny {
+		return !listed
+	}
+	return listed
+}
+
+// GetGatewayFilter returns the GatewayFilter for the given application id.
+func GetGatewayFilter(db sqlx.Queryer, applicationID int64) (GatewayFilter, error) {
+	f := GatewayFilter{
+		ApplicationID: applicationID,
+	}
+	var macs pq.ByteaArray
+
+	err := db.QueryRowx(`
+		select updated_at, mode, gateway_macs
+		from application_gateway_filter
+		where application_id = $1`,
+		applicationID,
+	).Scan(&f.UpdatedAt, &f.Mode, &macs)
+	if err != nil {
+		return f, handlePSQLError(err, "select error")
+	}
+
+	for _, b := range macs {
+		var mac lorawan.EUI64
+		copy(mac[:], b)
+		f.GatewayMACs = append(f.GatewayMACs, mac)
+	}
+
+	return f, nil
+}
+
+// UpdateGatewayFilter creates or updates the given GatewayFilter.
+func UpdateGatewayFilter(db sqlx.Execer, f *GatewayFilter) error {
+	if err := f.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	macs := make(pq.ByteaArray, 0, len(f.GatewayMACs))
+	for i := range f.GatewayMACs {
+		macs = append(macs, f.GatewayMACs[i][:])
+	}
+
+	now := time.Now()
+	_, err := db.Exec(`
+		insert into application_gateway_filter (
+			application_id,
+			updated_at,
+			mode,
+			gateway_macs
+		) values ($1, $2, $3, $4)
+		on conflict (application_id) do update
+		set
+			updated_at = excluded.updated_at,
+			mode = excluded.mode,
+			gateway_macs = excluded.gateway_macs`,
+		f.ApplicationID,
+		now,
+		f.Mode,
+		macs,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	f.UpdatedAt = now
+	log.WithFields(log.Fields{
+		"application_id": f.ApplicationID,
+		"mode":           f.Mode,
+	}).Info("gateway filter updated")
+	return nil
+}
+
+// DeleteGatewayFilter deletes the GatewayFilter for the given application
+// id.
+func DeleteGatewayFilter(db sqlx.Execer, applicationID int64) error {
+	res, err := db.Exec("delete from application_gateway_filter where application_id = $1", applicationID)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("application_id", applicationID).Info("gateway filter deleted")
+	return nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGatewayFilterAllows(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		listed := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		other := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		tests := []struct {
+			Name     string
+			Mode     string
+			MAC      lorawan.EUI64
+			Expected bool
+		}{
+			{"allow mode, listed gateway", GatewayFilterAllow, listed, true},
+			{"allow mode, other gateway", GatewayFilterAllow, other, false},
+			{"deny mode, listed gateway", GatewayFilterDeny, listed, false},
+			{"deny mode, other gateway", GatewayFilterDeny, other, true},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				f := GatewayFilter{
+					Mode:        test.Mode,
+					GatewayMACs: []lorawan.EUI64{listed},
+				}
+				So(f.Allows(test.MAC), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestGatewayFilter(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		app := Application{
+			Name:           "test-app",
+			OrganizationID: org.ID,
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("Then getting the gateway filter returns ErrDoesNotExist", func() {
+			_, err := GetGatewayFilter(db, app.ID)
+			So(err, ShouldEqual, ErrDoesNotExist)
+		})
+
+		Convey("Then creating a gateway filter with an invalid mode returns an error", func() {
+			err := UpdateGatewayFilter(db, &GatewayFilter{ApplicationID: app.ID, Mode: "FOO"})
+			So(errors.Cause(err), ShouldEqual, ErrGatewayFilterInvalidMode)
+		})
+
+		Convey("When creating a gateway filter", func() {
+			f := GatewayFilter{
+				ApplicationID: app.ID,
+				Mode:          GatewayFilterAllow,
+				GatewayMACs: []lorawan.EUI64{
+					{1, 1, 1, 1, 1, 1, 1, 1},
+					{2, 2, 2, 2, 2, 2, 2, 2},
+				},
+			}
+			So(UpdateGatewayFilter(db, &f), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				f2, err := GetGatewayFilter(db, app.ID)
+				So(err, ShouldBeNil)
+				So(f2.Mode, ShouldEqual, f.Mode)
+				So(f2.GatewayMACs, ShouldResemble, f.GatewayMACs)
+			})
+
+			Convey("Then it can be updated", func() {
+				f.Mode = GatewayFilterDeny
+				f.GatewayMACs = []lorawan.EUI64{{3, 3, 3, 3, 3, 3, 3, 3}}
+				So(UpdateGatewayFilter(db, &f), ShouldBeNil)
+
+				f2, err := GetGatewayFilter(db, app.ID)
+				So(err, ShouldBeNil)
+				So(f2.Mode, ShouldEqual, GatewayFilterDeny)
+				So(f2.GatewayMACs, ShouldResemble, f.GatewayMACs)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteGatewayFilter(db, app.ID), ShouldBeNil)
+				_, err := GetGatewayFilter(db, app.ID)
+				So(err, ShouldEqual, ErrDoesNotExist)
+				So(DeleteGatewayFilter(db, app.ID), ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
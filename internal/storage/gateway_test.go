@@ -202,6 +202,29 @@ func TestGateway(t *testing.T) {
 							So(gwPingRX2[0].ID, ShouldEqual, rx[0].ID)
 						})
 					})
+
+					Convey("Then the ping rx is returned as coverage rx within the bounding box", func() {
+						rx, err := GetGatewayCoverageRX(db, org.ID, nil, now.Add(-time.Minute), GPSPoint{Latitude: 1, Longitude: 1}, GPSPoint{Latitude: 2, Longitude: 2})
+						So(err, ShouldBeNil)
+						So(rx, ShouldResemble, []GatewayCoverageRX{
+							{
+								GatewayMAC: gw.MAC,
+								RSSI:       -10,
+								LoRaSNR:    5.5,
+								Location:   gwPingRX.Location,
+							},
+						})
+
+						rx, err = GetGatewayCoverageRX(db, org.ID, &gw.MAC, now.Add(-time.Minute), GPSPoint{Latitude: 1, Longitude: 1}, GPSPoint{Latitude: 2, Longitude: 2})
+						So(err, ShouldBeNil)
+						So(rx, ShouldHaveLength, 1)
+					})
+
+					Convey("Then no coverage rx is returned outside the bounding box", func() {
+						rx, err := GetGatewayCoverageRX(db, org.ID, nil, now.Add(-time.Minute), GPSPoint{Latitude: 2, Longitude: 2}, GPSPoint{Latitude: 3, Longitude: 3})
+						So(err, ShouldBeNil)
+						So(rx, ShouldHaveLength, 0)
+					})
 				})
 			})
 		})
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Limits of the integration failure simulation.
+const (
+	MaxChaosLatency  = time.Minute
+	MaxChaosDuration = 24 * time.Hour
+)
+
+// IntegrationChaos defines the failure simulation of an integration, used
+// for testing the retry and backfill strategies of the receiving end. While
+// active, each delivery to the integration is delayed with the configured
+// latency and fails with the configured probability.
+type IntegrationChaos struct {
+	// FailureRate defines the percentage (0 - 100) of deliveries that fail.
+	FailureRate int
+
+	// Latency defines the delay added to each delivery.
+	Latency time.Duration
+
+	// Until defines the end of the failure simulation. It is required,
+	// so that a forgotten simulation does not break the integration.
+	Until *time.Time
+}
+
+// Enabled returns true when a failure or latency is configured.
+func (c IntegrationChaos) Enabled() bool {
+	return c.FailureRate > 0 || c.Latency > 0
+}
+
+// Active returns true when the failure simulation is active at the given
+// time.
+func (c IntegrationChaos) Active(t time.Time) bool {
+	return c.Enabled() && c.Until != nil && t.Before(*c.Until)
+}
+
+// Fail returns true when the delivery must fail (based on the failure rate).
+func (c IntegrationChaos) Fail() bool {
+	return rand.Intn(100) < c.FailureRate
+}
+
+// Validate validates the IntegrationChaos data against the given time.
+func (c IntegrationChaos) Validate(t time.Time) error {
+	if c.FailureRate < 0 || c.FailureRate > 100 {
+		return ErrChaosInvalidFailureRate
+	}
+	if c.Latency < 0 || c.Latency > MaxChaosLatency {
+		return ErrChaosInvalidLatency
+	}
+	if c.Enabled() && (c.Until == nil || !c.Until.After(t) || c.Until.After(t.Add(MaxChaosDuration))) {
+		return ErrChaosInvalidUntil
+	}
+	return nil
+}
+
+// SetIntegrationChaos sets the failure simulation of the given integration.
+// When no failure or latency is configured, the failure simulation is
+// disabled.
+func SetIntegrationChaos(db sqlx.Execer, id int64, c IntegrationChaos) error {
+	return setIntegrationChaos(db, "integration", id, c)
+}
+
+// SetNodeIntegrationChaos sets the failure simulation of the given
+// device-level integration (see SetIntegrationChaos).
+func SetNodeIntegrationChaos(db sqlx.Execer, id int64, c IntegrationChaos) error {
+	return setIntegrationChaos(db, "node_integration", id, c)
+}
+
+func setIntegrationChaos(db sqlx.Execer, table string, id int64, c IntegrationChaos) error {
+	if err := c.Validate(time.Now()); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+	if !c.Enabled() {
+		c = IntegrationChaos{}
+	}
+
+	res, err := db.Exec(`
+		update `+table+`
+		set
+			chaos_failure_rate = $2,
+			chaos_latency = $3,
+			chaos_until = $4
+		where id = $1`,
+		id,
+		c.FailureRate,
+		int(c.Latency/time.Millisecond),
+		c.Until,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"table":        table,
+		"id":           id,
+		"failure_rate": c.FailureRate,
+		"latency":      c.Latency,
+		"until":        c.Until,
+	}).Warning("integration failure simulation updated")
+	return nil
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIntegrationChaos(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		now := time.Now()
+		future := now.Add(time.Hour)
+		past := now.Add(-time.Hour)
+		tooFar := now.Add(MaxChaosDuration + time.Hour)
+
+		tests := []struct {
+			Name          string
+			Chaos         IntegrationChaos
+			ExpectedError error
+			Active        bool
+		}{
+			{"disabled", IntegrationChaos{}, nil, false},
+			{"failures with end in future", IntegrationChaos{FailureRate: 50, Until: &future}, nil, true},
+			{"latency with end in future", IntegrationChaos{Latency: time.Second, Until: &future}, nil, true},
+			{"failures without end", IntegrationChaos{FailureRate: 50}, ErrChaosInvalidUntil, false},
+			{"failures with end in past", IntegrationChaos{FailureRate: 50, Until: &past}, ErrChaosInvalidUntil, false},
+			{"failures with end too far ahead", IntegrationChaos{FailureRate: 50, Until: &tooFar}, ErrChaosInvalidUntil, true},
+			{"invalid failure rate", IntegrationChaos{FailureRate: 101, Until: &future}, ErrChaosInvalidFailureRate, true},
+			{"invalid latency", IntegrationChaos{Latency: 2 * time.Minute, Until: &future}, ErrChaosInvalidLatency, true},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Chaos.Validate(now), ShouldEqual, test.ExpectedError)
+				So(test.Chaos.Active(now), ShouldEqual, test.Active)
+			})
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Integration event types (see IntegrationFilter).
+const (
+	IntegrationEventUp          = "up"
+	IntegrationEventJoin        = "join"
+	IntegrationEventACK         = "ack"
+	IntegrationEventError       = "error"
+	IntegrationEventSecurity    = "security"
+	IntegrationEventProprietary = "proprietary"
+	IntegrationEventCustom      = "custom"
+)
+
+var integrationEventTypes = map[string]bool{
+	IntegrationEventUp:          true,
+	IntegrationEventJoin:        true,
+	IntegrationEventACK:         true,
+	IntegrationEventError:       true,
+	IntegrationEventSecurity:    true,
+	IntegrationEventProprietary: true,
+	IntegrationEventCustom:      true,
+}
+
+// IntegrationFilter defines which events are forwarded to an integration.
+// An empty list does not restrict the events.
+type IntegrationFilter struct {
+	// EventTypes defines the event types which are forwarded.
+	EventTypes []string
+
+	// FPorts defines the fPorts of the uplinks which are forwarded. It does
+	// not apply to the other event types.
+	FPorts []int
+
+	// Tags defines the tags which the node of the event must all have.
+	// Events not related to a node are not forwarded when set.
+	Tags []string
+}
+
+// Enabled returns true when the filter restricts the forwarded events.
+func (f IntegrationFilter) Enabled() bool {
+	return len(f.EventTypes) > 0 || len(f.FPorts) > 0 || len(f.Tags) > 0
+}
+
+// Match returns true when an event of the given type, fPort (nil when not
+// an uplink) and node tags (nil when not related to a node) passes the
+// filter.
+func (f IntegrationFilter) Match(eventType string, fPort *int, tags []string) bool {
+	if len(f.EventTypes) > 0 && !containsString(f.EventTypes, eventType) {
+		return false
+	}
+
+	if len(f.FPorts) > 0 && fPort != nil {
+		var found bool
+		for _, p := range f.FPorts {
+			if p == *fPort {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Tags) > 0 {
+		if tags == nil {
+			return false
+		}
+		for _, tag := range f.Tags {
+			if !containsString(tags, tag) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Validate validates the IntegrationFilter data.
+func (f IntegrationFilter) Validate() error {
+	for _, t := range f.EventTypes {
+		if !integrationEventTypes[t] {
+			return ErrIntegrationFilterInvalidEventType
+		}
+	}
+	for _, p := range f.FPorts {
+		if p < 1 || p > 255 {
+			return ErrIntegrationFilterInvalidFPort
+		}
+	}
+	return validateNodeTags(f.Tags)
+}
+
+// SetIntegrationFilter sets the event filter of the given integration. An
+// empty filter forwards all events.
+func SetIntegrationFilter(db sqlx.Execer, id int64, f IntegrationFilter) error {
+	return setIntegrationFilter(db, "integration", id, f)
+}
+
+// SetNodeIntegrationFilter sets the event filter of the given device-level
+// integration (see SetIntegrationFilter).
+func SetNodeIntegrationFilter(db sqlx.Execer, id int64, f IntegrationFilter) error {
+	return setIntegrationFilter(db, "node_integration", id, f)
+}
+
+func setIntegrationFilter(db sqlx.Execer, table string, id int64, f IntegrationFilter) error {
+	if err := f.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	var eventTypes, tags pq.StringArray
+	var fPorts pq.Int64Array
+	if len(f.EventTypes) > 0 {
+		eventTypes = pq.StringArray(f.EventTypes)
+	}
+	for _, p := range f.FPorts {
+		fPorts = append(fPorts, int64(p))
+	}
+	if len(f.Tags) > 0 {
+		tags = pq.StringArray(f.Tags)
+	}
+
+	res, err := db.Exec(`
+		update `+table+`
+		set
+			filter_event_types = $2,
+			filter_f_ports = $3,
+			filter_tags = $4
+		where id = $1`,
+		id,
+		eventTypes,
+		fPorts,
+		tags,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"table":       table,
+		"id":          id,
+		"event_types": f.EventTypes,
+		"f_ports":     f.FPorts,
+		"tags":        f.Tags,
+	}).Info("integration filter updated")
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIntegrationFilter(t *testing.T) {
+	Convey("Given a set of validation tests", t, func() {
+		tests := []struct {
+			Name          string
+			Filter        IntegrationFilter
+			ExpectedError error
+		}{
+			{"empty filter", IntegrationFilter{}, nil},
+			{"valid filter", IntegrationFilter{EventTypes: []string{"up", "error"}, FPorts: []int{1, 255}, Tags: []string{"building-a"}}, nil},
+			{"invalid event type", IntegrationFilter{EventTypes: []string{"downlink"}}, ErrIntegrationFilterInvalidEventType},
+			{"invalid fPort", IntegrationFilter{FPorts: []int{0}}, ErrIntegrationFilterInvalidFPort},
+			{"invalid tag", IntegrationFilter{Tags: []string{"building a"}}, ErrNodeInvalidTag},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Filter.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+
+	Convey("Given a set of match tests", t, func() {
+		fPort10 := 10
+		fPort20 := 20
+
+		tests := []struct {
+			Name      string
+			Filter    IntegrationFilter
+			EventType string
+			FPort     *int
+			Tags      []string
+			Match     bool
+		}{
+			{"empty filter", IntegrationFilter{}, IntegrationEventJoin, nil, nil, true},
+			{"matching event type", IntegrationFilter{EventTypes: []string{"up"}}, IntegrationEventUp, &fPort10, nil, true},
+			{"other event type", IntegrationFilter{EventTypes: []string{"up"}}, IntegrationEventJoin, nil, nil, false},
+			{"matching fPort", IntegrationFilter{FPorts: []int{10}}, IntegrationEventUp, &fPort10, nil, true},
+			{"other fPort", IntegrationFilter{FPorts: []int{10}}, IntegrationEventUp, &fPort20, nil, false},
+			{"fPort filter on other event type", IntegrationFilter{FPorts: []int{10}}, IntegrationEventError, nil, nil, true},
+			{"node has all tags", IntegrationFilter{Tags: []string{"a", "b"}}, IntegrationEventUp, &fPort10, []string{"b", "a", "c"}, true},
+			{"node misses a tag", IntegrationFilter{Tags: []string{"a", "b"}}, IntegrationEventUp, &fPort10, []string{"a"}, false},
+			{"tag filter on event without node", IntegrationFilter{Tags: []string{"a"}}, IntegrationEventProprietary, nil, nil, false},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Filter.Match(test.EventType, test.FPort, test.Tags), ShouldEqual, test.Match)
+			})
+		}
+	})
+}
@@ -14,24 +14,70 @@ import (
 // Integration represents an integration.
 type Integration struct {
 	ID            int64           `db:"id"`
+	UUID          UUID            `db:"uuid"`
 	CreatedAt     time.Time       `db:"created_at"`
 	UpdatedAt     time.Time       `db:"updated_at"`
 	ApplicationID int64           `db:"application_id"`
 	Kind          string          `db:"kind"`
 	Settings      json.RawMessage `db:"settings"`
+	Revision      int64           `db:"revision"`
+
+	IntegrationOptions
+}
+
+// IntegrationOptions contains the failure simulation and the event filter
+// of an application or device-level integration.
+type IntegrationOptions struct {
+	// Failure simulation (see IntegrationChaos).
+	ChaosFailureRate int        `db:"chaos_failure_rate"`
+	ChaosLatency     int        `db:"chaos_latency"`
+	ChaosUntil       *time.Time `db:"chaos_until"`
+
+	// Event filter (see IntegrationFilter).
+	FilterEventTypes pq.StringArray `db:"filter_event_types"`
+	FilterFPorts     pq.Int64Array  `db:"filter_f_ports"`
+	FilterTags       pq.StringArray `db:"filter_tags"`
+}
+
+// Chaos returns the failure simulation of the integration.
+func (o IntegrationOptions) Chaos() IntegrationChaos {
+	return IntegrationChaos{
+		FailureRate: o.ChaosFailureRate,
+		Latency:     time.Duration(o.ChaosLatency) * time.Millisecond,
+		Until:       o.ChaosUntil,
+	}
+}
+
+// Filter returns the event filter of the integration.
+func (o IntegrationOptions) Filter() IntegrationFilter {
+	f := IntegrationFilter{
+		EventTypes: []string(o.FilterEventTypes),
+		Tags:       []string(o.FilterTags),
+	}
+	for _, fPort := range o.FilterFPorts {
+		f.FPorts = append(f.FPorts, int(fPort))
+	}
+	return f
 }
 
 // CreateIntegration creates the given Integration.
 func CreateIntegration(db *sqlx.DB, i *Integration) error {
+	var err error
+	if i.UUID, err = NewUUID(); err != nil {
+		return err
+	}
+
 	now := time.Now()
-	err := db.Get(&i.ID, `
+	err = db.Get(&i.ID, `
 		insert into integration (
+			uuid,
 			created_at,
 			updated_at,
 			application_id,
 			kind,
 			settings
-		) values ($1, $2, $3, $4, $5) returning id`,
+		) values ($1, $2, $3, $4, $5, $6) returning id`,
+		i.UUID,
 		now,
 		now,
 		i.ApplicationID,
@@ -106,6 +152,23 @@ func GetIntegrationsForApplicationID(db *sqlx.DB, applicationID int64) ([]Integr
 	return is, nil
 }
 
+// GetIntegrationsForKind returns the integrations of the given kind, of all
+// applications.
+func GetIntegrationsForKind(db sqlx.Queryer, kind string) ([]Integration, error) {
+	var is []Integration
+	err := sqlx.Select(db, &is, `
+		select *
+		from integration
+		where kind = $1
+		order by id`,
+		kind,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return is, nil
+}
+
 // UpdateIntegration updates the given Integration.
 func UpdateIntegration(db *sqlx.DB, i *Integration) error {
 	now := time.Now()
@@ -115,14 +178,17 @@ func UpdateIntegration(db *sqlx.DB, i *Integration) error {
 			updated_at = $2,
 			application_id = $3,
 			kind = $4,
-			settings = $5
+			settings = $5,
+			revision = revision + 1
 		where
-			id = $1`,
+			id = $1
+			and revision = $6`,
 		i.ID,
 		now,
 		i.ApplicationID,
 		i.Kind,
 		i.Settings,
+		i.Revision,
 	)
 
 	if err != nil {
@@ -144,10 +210,11 @@ func UpdateIntegration(db *sqlx.DB, i *Integration) error {
 		return errors.Wrap(err, "get rows affected error")
 	}
 	if ra == 0 {
-		return ErrDoesNotExist
+		return updateNoRowsError(db, "select count(*) from integration where id = $1", i.ID)
 	}
 
 	i.UpdatedAt = now
+	i.Revision++
 	log.WithFields(log.Fields{
 		"id":             i.ID,
 		"kind":           i.Kind,
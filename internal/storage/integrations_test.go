@@ -55,6 +55,8 @@ func TestIntegration(t *testing.T) {
 				var s testIntegrationSettings
 				So(json.Unmarshal(i.Settings, &s), ShouldBeNil)
 				So(s, ShouldResemble, settings)
+				So(i.Revision, ShouldEqual, 1)
+				So(intgr.Revision, ShouldEqual, 1)
 
 				i.CreatedAt = i.CreatedAt.UTC().Truncate(time.Millisecond)
 				i.UpdatedAt = i.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -72,6 +74,8 @@ func TestIntegration(t *testing.T) {
 				var s testIntegrationSettings
 				So(json.Unmarshal(i.Settings, &s), ShouldBeNil)
 				So(s, ShouldResemble, settings)
+				So(i.Revision, ShouldEqual, 1)
+				So(intgr.Revision, ShouldEqual, 1)
 
 				i.CreatedAt = i.CreatedAt.UTC().Truncate(time.Millisecond)
 				i.UpdatedAt = i.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -102,6 +106,8 @@ func TestIntegration(t *testing.T) {
 				var s testIntegrationSettings
 				So(json.Unmarshal(i.Settings, &s), ShouldBeNil)
 				So(s, ShouldResemble, settings)
+				So(i.Revision, ShouldEqual, 1)
+				So(intgr.Revision, ShouldEqual, 1)
 			})
 
 			Convey("Then it can be deleted", func() {
@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/brocaar/lorawan"
+)
+
+// LinkQuality contains the aggregated link-quality metrics of a node
+// within a period (bucket).
+type LinkQuality struct {
+	DevEUI          lorawan.EUI64 `db:"dev_eui"`
+	Bucket          time.Time     `db:"bucket"`
+	Uplinks         int           `db:"uplinks"`
+	Missed          int           `db:"missed"`
+	Retransmissions int           `db:"retransmissions"`
+	SNRMarginSum    float64       `db:"snr_margin_sum"`
+}
+
+// NodeLinkQuality contains the link-quality metrics of a node, aggregated
+// over multiple buckets.
+type NodeLinkQuality struct {
+	LinkQuality
+	Name string `db:"name"`
+}
+
+// ApplicationUplinkBucket contains the number of uplinks and the number of
+// nodes from which uplinks were received within a link-quality bucket.
+type ApplicationUplinkBucket struct {
+	Bucket  time.Time `db:"bucket"`
+	Uplinks int       `db:"uplinks"`
+	Nodes   int       `db:"nodes"`
+}
+
+// AddLinkQuality adds the metrics of the given LinkQuality to the matching
+// bucket of the node (the bucket is created when it does not yet exist).
+func AddLinkQuality(db sqlx.Execer, lq LinkQuality) error {
+	_, err := db.Exec(`
+		insert into node_link_quality (
+			dev_eui,
+			bucket,
+			uplinks,
+			missed,
+			retransmissions,
+			snr_margin_sum
+		) values ($1, $2, $3, $4, $5, $6)
+		on conflict (dev_eui, bucket) do update
+		set
+			uplinks = node_link_quality.uplinks + excluded.uplinks,
+			missed = node_link_quality.missed + excluded.missed,
+			retransmissions = node_link_quality.retransmissions + excluded.retransmissions,
+			snr_margin_sum = node_link_quality.snr_margin_sum + excluded.snr_margin_sum`,
+		lq.DevEUI[:],
+		lq.Bucket,
+		lq.Uplinks,
+		lq.Missed,
+		lq.Retransmissions,
+		lq.SNRMarginSum,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+	return nil
+}
+
+// CorrectLinkQualityMissed corrects the missed uplinks of the given node
+// for an uplink which arrived out of order. As the missed uplinks are
+// accounted on the next received uplink, the missed uplinks of the first
+// bucket since the given bucket having missed uplinks are decremented.
+func CorrectLinkQualityMissed(db sqlx.Execer, devEUI lorawan.EUI64, since time.Time) error {
+	_, err := db.Exec(`
+		update node_link_quality
+		set
+			missed = missed - 1
+		where
+			dev_eui = $1
+			and bucket = (
+				select min(bucket)
+				from node_link_quality
+				where
+					dev_eui = $1
+					and bucket >= $2
+					and missed > 0
+			)`,
+		devEUI[:],
+		since,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	return nil
+}
+
+// GetLinkQualityForDevEUI returns the link-quality buckets of the given
+// node since the given time, ordered by bucket.
+func GetLinkQualityForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64, since time.Time) ([]LinkQuality, error) {
+	var lqs []LinkQuality
+	err := sqlx.Select(db, &lqs, `
+		select *
+		from node_link_quality
+		where
+			dev_eui = $1
+			and bucket >= $2
+		order by bucket`,
+		devEUI[:],
+		since,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return lqs, nil
+}
+
+// GetLinkQualityForApplicationID returns the link-quality since the given
+// time, aggregated per node, for all nodes of the given application having
+// received uplinks within this period.
+func GetLinkQualityForApplicationID(db sqlx.Queryer, applicationID int64, since time.Time) ([]NodeLinkQuality, error) {
+	var lqs []NodeLinkQuality
+	err := sqlx.Select(db, &lqs, `
+		select
+			n.dev_eui,
+			n.name,
+			min(lq.bucket) as bucket,
+			sum(lq.uplinks) as uplinks,
+			sum(lq.missed) as missed,
+			sum(lq.retransmissions) as retransmissions,
+			sum(lq.snr_margin_sum) as snr_margin_sum
+		from node_link_quality lq
+		inner join node n
+			on n.dev_eui = lq.dev_eui
+		where
+			n.application_id = $1
+			and lq.bucket >= $2
+		group by n.dev_eui, n.name
+		order by n.name`,
+		applicationID,
+		since,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return lqs, nil
+}
+
+// GetLinkQualityForApplicationNodes returns the link-quality since the
+// given time, aggregated per node, for all (enabled) nodes of the given
+// application, including the nodes without uplinks within this period.
+func GetLinkQualityForApplicationNodes(db sqlx.Queryer, applicationID int64, since time.Time) ([]NodeLinkQuality, error) {
+	var lqs []NodeLinkQuality
+	err := sqlx.Select(db, &lqs, `
+		select
+			n.dev_eui,
+			n.name,
+			coalesce(min(lq.bucket), $2) as bucket,
+			coalesce(sum(lq.uplinks), 0) as uplinks,
+			coalesce(sum(lq.missed), 0) as missed,
+			coalesce(sum(lq.retransmissions), 0) as retransmissions,
+			coalesce(sum(lq.snr_margin_sum), 0) as snr_margin_sum
+		from node n
+		left join node_link_quality lq
+			on lq.dev_eui = n.dev_eui
+			and lq.bucket >= $2
+		where
+			n.application_id = $1
+			and not n.disabled
+		group by n.dev_eui, n.name
+		order by n.name`,
+		applicationID,
+		since,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return lqs, nil
+}
+
+// GetLinkQualityHistoryForApplicationID returns the link-quality buckets
+// within the given period of all nodes of the given application, ordered
+// by node name and bucket.
+func GetLinkQualityHistoryForApplicationID(db sqlx.Queryer, applicationID int64, from, to time.Time) ([]NodeLinkQuality, error) {
+	var lqs []NodeLinkQuality
+	err := sqlx.Select(db, &lqs, `
+		select
+			lq.*,
+			n.name
+		from node_link_quality lq
+		inner join node n
+			on n.dev_eui = lq.dev_eui
+		where
+			n.application_id = $1
+			and lq.bucket >= $2
+			and lq.bucket <= $3
+		order by n.name, lq.bucket`,
+		applicationID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return lqs, nil
+}
+
+// GetApplicationUplinkBuckets returns per link-quality bucket since the
+// given time, the number of uplinks and the number of (enabled) nodes of
+// the given application from which uplinks were received, ordered by
+// bucket.
+func GetApplicationUplinkBuckets(db sqlx.Queryer, applicationID int64, since time.Time) ([]ApplicationUplinkBucket, error) {
+	var buckets []ApplicationUplinkBucket
+	err := sqlx.Select(db, &buckets, `
+		select
+			lq.bucket,
+			sum(lq.uplinks) as uplinks,
+			count(distinct lq.dev_eui) as nodes
+		from node_link_quality lq
+		inner join node n
+			on n.dev_eui = lq.dev_eui
+		where
+			n.application_id = $1
+			and not n.disabled
+			and lq.bucket >= $2
+			and lq.uplinks > 0
+		group by lq.bucket
+		order by lq.bucket`,
+		applicationID,
+		since,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return buckets, nil
+}
+
+// DeleteLinkQualityBefore deletes the link-quality buckets before the given
+// time. It returns the number of deleted buckets.
+func DeleteLinkQualityBefore(db sqlx.Execer, before time.Time) (int64, error) {
+	res, err := db.Exec("delete from node_link_quality where bucket < $1", before)
+	if err != nil {
+		return 0, handlePSQLError(err, "delete error")
+	}
+	return res.RowsAffected()
+}
+
+// DeleteLinkQualityForDevEUI deletes all the link-quality buckets of the
+// given node.
+func DeleteLinkQualityForDevEUI(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	_, err := db.Exec("delete from node_link_quality where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Maintenance defines the maintenance mode of an application or node.
+// During maintenance, the error notifications (alarms) are suppressed,
+// but the data keeps flowing (marked as maintenance).
+type Maintenance struct {
+	Enabled bool
+
+	// Until defines the end of the maintenance (optional). After this
+	// time, the maintenance mode is no longer active.
+	Until *time.Time
+}
+
+// Active returns true when the maintenance mode is active at the given
+// time.
+func (m Maintenance) Active(t time.Time) bool {
+	return m.Enabled && (m.Until == nil || t.Before(*m.Until))
+}
+
+// InMaintenance returns true when the given node or its application is in
+// maintenance mode at the given time.
+func InMaintenance(app Application, node Node, t time.Time) bool {
+	return Maintenance{Enabled: app.MaintenanceMode, Until: app.MaintenanceUntil}.Active(t) ||
+		Maintenance{Enabled: node.MaintenanceMode, Until: node.MaintenanceUntil}.Active(t)
+}
+
+// SetApplicationMaintenance sets the maintenance mode of the given
+// application.
+func SetApplicationMaintenance(db sqlx.Execer, id int64, m Maintenance) error {
+	res, err := db.Exec(`
+		update application
+		set
+			maintenance_mode = $2,
+			maintenance_until = $3
+		where id = $1`,
+		id,
+		m.Enabled,
+		m.Until,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":      id,
+		"enabled": m.Enabled,
+	}).Info("application maintenance mode updated")
+	return nil
+}
+
+// SetNodeMaintenance sets the maintenance mode of the given node.
+func SetNodeMaintenance(db sqlx.Execer, devEUI lorawan.EUI64, m Maintenance) error {
+	res, err := db.Exec(`
+		update node
+		set
+			maintenance_mode = $2,
+			maintenance_until = $3
+		where dev_eui = $1`,
+		devEUI[:],
+		m.Enabled,
+		m.Until,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": devEUI,
+		"enabled": m.Enabled,
+	}).Info("node maintenance mode updated")
+	return nil
+}
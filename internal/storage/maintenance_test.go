@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestMaintenanceActive(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		now := time.Now()
+		future := now.Add(time.Hour)
+		past := now.Add(-time.Hour)
+
+		tests := []struct {
+			Name        string
+			Maintenance Maintenance
+			Expected    bool
+		}{
+			{"disabled", Maintenance{}, false},
+			{"disabled with end in future", Maintenance{Until: &future}, false},
+			{"enabled without end", Maintenance{Enabled: true}, true},
+			{"enabled with end in future", Maintenance{Enabled: true, Until: &future}, true},
+			{"enabled with end in past", Maintenance{Enabled: true, Until: &past}, false},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Maintenance.Active(now), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestMaintenance(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application and node", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		app := Application{
+			Name:           "test-app",
+			OrganizationID: org.ID,
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		node := Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(CreateNode(db, node), ShouldBeNil)
+
+		Convey("Then the node is not in maintenance", func() {
+			node, err := GetNode(db, node.DevEUI)
+			So(err, ShouldBeNil)
+			So(InMaintenance(app, node, time.Now()), ShouldBeFalse)
+		})
+
+		Convey("When enabling the maintenance mode of the node until a given time", func() {
+			until := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+			So(SetNodeMaintenance(db, node.DevEUI, Maintenance{Enabled: true, Until: &until}), ShouldBeNil)
+
+			Convey("Then the node is in maintenance until the given time", func() {
+				node, err := GetNode(db, node.DevEUI)
+				So(err, ShouldBeNil)
+				So(node.MaintenanceMode, ShouldBeTrue)
+				So(node.MaintenanceUntil.Equal(until), ShouldBeTrue)
+				So(InMaintenance(app, node, time.Now()), ShouldBeTrue)
+				So(InMaintenance(app, node, until.Add(time.Second)), ShouldBeFalse)
+			})
+		})
+
+		Convey("When enabling the maintenance mode of the application", func() {
+			So(SetApplicationMaintenance(db, app.ID, Maintenance{Enabled: true}), ShouldBeNil)
+
+			Convey("Then the node is in maintenance", func() {
+				app, err := GetApplication(db, app.ID)
+				So(err, ShouldBeNil)
+				node, err := GetNode(db, node.DevEUI)
+				So(err, ShouldBeNil)
+				So(InMaintenance(app, node, time.Now()), ShouldBeTrue)
+			})
+		})
+
+		Convey("When setting the maintenance mode of an unknown node", func() {
+			err := SetNodeMaintenance(db, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, Maintenance{Enabled: true})
+
+			Convey("Then ErrDoesNotExist is returned", func() {
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
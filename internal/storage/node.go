@@ -3,8 +3,11 @@ package storage
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/jmoiron/sqlx"
@@ -15,6 +18,8 @@ import (
 )
 
 var nodeNameRegexp = regexp.MustCompile(`^[\w-]+$`)
+var nodeTagRegexp = regexp.MustCompile(`^[\w-]+$`)
+var nodeVariableNameRegexp = regexp.MustCompile(`^[\w-]+$`)
 
 // DevNonceList represents a list of dev nonces
 type DevNonceList [][2]byte
@@ -48,6 +53,41 @@ func (l DevNonceList) Value() (driver.Value, error) {
 	return b, nil
 }
 
+// NodeVariables contains the user-defined variables of a node (e.g. the
+// credentials used by an integration), indexed by name.
+type NodeVariables map[string]string
+
+// Scan implements the sql.Scanner interface. A node without variables
+// results in a nil map.
+func (v *NodeVariables) Scan(src interface{}) error {
+	*v = nil
+	if src == nil {
+		return nil
+	}
+
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("src must be of type []byte, got: %T", src)
+	}
+
+	var vars NodeVariables
+	if err := json.Unmarshal(b, &vars); err != nil {
+		return errors.Wrap(err, "unmarshal node variables error")
+	}
+	if len(vars) > 0 {
+		*v = vars
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (v NodeVariables) Value() (driver.Value, error) {
+	if v == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(v)
+}
+
 // RXWindow defines the RX window option.
 type RXWindow int8
 
@@ -88,6 +128,8 @@ type Node struct {
 	AppSKey                lorawan.AES128Key `db:"app_s_key"`
 	UsedDevNonces          DevNonceList      `db:"used_dev_nonces"`
 	RelaxFCnt              bool              `db:"relax_fcnt"`
+	Tags                   pq.StringArray    `db:"tags"`
+	Variables              NodeVariables     `db:"variables"`
 
 	RXWindow    RXWindow `db:"rx_window"`
 	RXDelay     uint8    `db:"rx_delay"`
@@ -96,6 +138,21 @@ type Node struct {
 
 	ADRInterval        uint32  `db:"adr_interval"`
 	InstallationMargin float64 `db:"installation_margin"`
+
+	// MaintenanceMode and MaintenanceUntil define the maintenance mode of
+	// the node (see SetNodeMaintenance).
+	MaintenanceMode  bool       `db:"maintenance_mode"`
+	MaintenanceUntil *time.Time `db:"maintenance_until"`
+
+	// Disabled nodes can't join and their uplinks are ignored (see
+	// SetNodeDisabled).
+	Disabled bool `db:"disabled"`
+
+	// RetiredAt is set when the node has been decommissioned (see
+	// RetireNode). Retired nodes are disabled.
+	RetiredAt *time.Time `db:"retired_at"`
+
+	Revision int64 `db:"revision"`
 }
 
 // Validate validates the data of the Node.
@@ -106,6 +163,14 @@ func (n Node) Validate() error {
 	if n.RXDelay > 15 {
 		return ErrNodeMaxRXDelay
 	}
+	if err := validateNodeTags(n.Tags); err != nil {
+		return err
+	}
+	for name := range n.Variables {
+		if !nodeVariableNameRegexp.MatchString(name) {
+			return ErrNodeInvalidVariableName
+		}
+	}
 
 	return nil
 }
@@ -123,7 +188,7 @@ func (n *Node) ValidateDevNonce(nonce [2]byte) bool {
 	return true
 }
 
-func updateNodeSettingsFromApplication(db *sqlx.DB, n *Node) error {
+func updateNodeSettingsFromApplication(db sqlx.Queryer, n *Node) error {
 	app, err := GetApplication(db, n.ApplicationID)
 	if err != nil {
 		return fmt.Errorf("get application error: %s", err)
@@ -143,7 +208,7 @@ func updateNodeSettingsFromApplication(db *sqlx.DB, n *Node) error {
 }
 
 // CreateNode creates the given Node.
-func CreateNode(db *sqlx.DB, n Node) error {
+func CreateNode(db sqlx.Ext, n Node) error {
 	if err := n.Validate(); err != nil {
 		return errors.Wrap(err, "validate error")
 	}
@@ -174,9 +239,11 @@ func CreateNode(db *sqlx.DB, n Node) error {
 			installation_margin,
 			is_abp,
 			is_class_c,
-			use_application_settings
+			use_application_settings,
+			tags,
+			variables
 		)
-		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`,
 		n.ApplicationID,
 		n.Name,
 		n.Description,
@@ -196,6 +263,8 @@ func CreateNode(db *sqlx.DB, n Node) error {
 		n.IsABP,
 		n.IsClassC,
 		n.UseApplicationSettings,
+		nodeTags(n.Tags),
+		n.Variables,
 	)
 	if err != nil {
 		switch err := err.(type) {
@@ -218,7 +287,7 @@ func CreateNode(db *sqlx.DB, n Node) error {
 
 // UpdateNode updates the given Node.
 // TODO: change node into pointer
-func UpdateNode(db *sqlx.DB, n Node) error {
+func UpdateNode(db sqlx.Ext, n Node) error {
 	if err := n.Validate(); err != nil {
 		return errors.Wrap(err, "validate error")
 	}
@@ -249,8 +318,12 @@ func UpdateNode(db *sqlx.DB, n Node) error {
 			installation_margin = $17,
 			is_abp = $18,
 			is_class_c = $19,
-			use_application_settings = $20
-		where dev_eui = $1`,
+			use_application_settings = $20,
+			tags = $22,
+			variables = $23,
+			revision = revision + 1
+		where dev_eui = $1
+		and revision = $21`,
 		n.DevEUI[:],
 		n.ApplicationID,
 		n.Name,
@@ -271,6 +344,9 @@ func UpdateNode(db *sqlx.DB, n Node) error {
 		n.IsABP,
 		n.IsClassC,
 		n.UseApplicationSettings,
+		n.Revision,
+		nodeTags(n.Tags),
+		n.Variables,
 	)
 	if err != nil {
 		switch err := err.(type) {
@@ -292,7 +368,7 @@ func UpdateNode(db *sqlx.DB, n Node) error {
 		return errors.Wrap(err, "get rows affected error")
 	}
 	if ra == 0 {
-		return ErrDoesNotExist
+		return updateNoRowsError(db, "select count(*) from node where dev_eui = $1", n.DevEUI[:])
 	}
 	log.WithField("dev_eui", n.DevEUI).Info("node updated")
 	return nil
@@ -317,10 +393,66 @@ func DeleteNode(db *sqlx.DB, devEUI lorawan.EUI64) error {
 	return nil
 }
 
+// SetNodeDisabled disables or (re-)enables the given node.
+func SetNodeDisabled(db sqlx.Execer, devEUI lorawan.EUI64, disabled bool) error {
+	res, err := db.Exec(`
+		update node
+		set
+			disabled = $2,
+			revision = revision + 1
+		where dev_eui = $1`,
+		devEUI[:],
+		disabled,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	log.WithFields(log.Fields{
+		"dev_eui":  devEUI,
+		"disabled": disabled,
+	}).Info("node disabled flag updated")
+	return nil
+}
+
+// RetireNode marks the given node as retired and disables it. It returns
+// ErrNodeRetired when the node was already retired.
+func RetireNode(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	res, err := db.Exec(`
+		update node
+		set
+			disabled = true,
+			retired_at = now(),
+			revision = revision + 1
+		where
+			dev_eui = $1
+			and retired_at is null`,
+		devEUI[:],
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrNodeRetired
+	}
+	log.WithField("dev_eui", devEUI).Info("node retired")
+	return nil
+}
+
 // GetNode returns the Node for the given DevEUI.
-func GetNode(db *sqlx.DB, devEUI lorawan.EUI64) (Node, error) {
+func GetNode(db sqlx.Queryer, devEUI lorawan.EUI64) (Node, error) {
 	var node Node
-	err := db.Get(&node, "select * from node where dev_eui = $1", devEUI[:])
+	err := sqlx.Get(db, &node, "select * from node where dev_eui = $1", devEUI[:])
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return node, ErrDoesNotExist
@@ -351,6 +483,40 @@ func GetNodesForApplicationID(db *sqlx.DB, applicationID int64, limit, offset in
 	return nodes, nil
 }
 
+// StreamNodesForApplicationID calls fn for each node of the given
+// application id, sorted by name. The rows are read from the database as
+// they are consumed, so the complete result-set is never held in memory.
+// Iteration stops at the first error returned by fn.
+func StreamNodesForApplicationID(db *sqlx.DB, applicationID int64, fn func(Node) error) error {
+	rows, err := db.Queryx(`
+		select *
+		from node
+		where
+			application_id = $1
+		order by name`,
+		applicationID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "select error")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var node Node
+		if err := rows.StructScan(&node); err != nil {
+			return errors.Wrap(err, "scan error")
+		}
+		if err := fn(node); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "iterate rows error")
+	}
+	return nil
+}
+
 // GetNodesCountForApplicationID returns the total number of nodes for the
 // given applicaiton id.
 func GetNodesCountForApplicationID(db *sqlx.DB, applicationID int64) (int, error) {
@@ -366,3 +532,205 @@ func GetNodesCountForApplicationID(db *sqlx.DB, applicationID int64) (int, error
 	}
 	return count, nil
 }
+
+// NodeFilter defines a filter on the nodes of an application.
+type NodeFilter struct {
+	ApplicationID int64
+
+	// Tags which the nodes must all have (optional).
+	Tags []string
+
+	// NamePattern which the node name must match (optional). The pattern
+	// may contain the wildcards * (any sequence of characters) and ? (any
+	// single character).
+	NamePattern string
+
+	// NotSeenHours filters on the nodes from which no uplink has been
+	// received within the given number of hours (optional). As this is
+	// based on the hourly link-quality buckets, it has a granularity of
+	// one hour.
+	NotSeenHours int
+}
+
+// Validate validates the NodeFilter.
+func (f NodeFilter) Validate() error {
+	if f.NotSeenHours < 0 {
+		return ErrNodeFilterInvalidNotSeenHours
+	}
+	return validateNodeTags(f.Tags)
+}
+
+// where returns the SQL where condition of the filter and its arguments.
+func (f NodeFilter) where() (string, []interface{}) {
+	where := `
+			application_id = $1
+			and tags @> $2
+			and name like $3 escape '\'`
+	args := []interface{}{
+		f.ApplicationID,
+		nodeTags(f.Tags),
+		nodeNameLikePattern(f.NamePattern),
+	}
+
+	if f.NotSeenHours > 0 {
+		args = append(args, f.NotSeenHours)
+		where += fmt.Sprintf(`
+			and not exists (
+				select 1
+				from node_link_quality lq
+				where
+					lq.dev_eui = node.dev_eui
+					and lq.bucket > now() - ($%d + 1) * interval '1 hour'
+			)`, len(args))
+	}
+
+	return where, args
+}
+
+// GetNodesForFilter returns a slice of nodes matching the given filter,
+// sorted by name.
+func GetNodesForFilter(db sqlx.Queryer, filter NodeFilter, limit, offset int) ([]Node, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validate error")
+	}
+
+	where, args := filter.where()
+	args = append(args, limit, offset)
+
+	var nodes []Node
+	err := sqlx.Select(db, &nodes, fmt.Sprintf(`
+		select *
+		from node
+		where`+where+`
+		order by name
+		limit $%d offset $%d`, len(args)-1, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return nodes, nil
+}
+
+// GetNodesCountForFilter returns the total number of nodes matching the
+// given filter.
+func GetNodesCountForFilter(db sqlx.Queryer, filter NodeFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, errors.Wrap(err, "validate error")
+	}
+
+	where, args := filter.where()
+
+	var count int
+	err := sqlx.Get(db, &count, `
+		select count(*)
+		from node
+		where`+where,
+		args...,
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// AddNodeTags adds the given tags to the nodes matching the given filter
+// and returns the DevEUIs of the changed nodes. Nodes already having all
+// the given tags are not changed. When dryRun is set, the DevEUIs of the
+// nodes that would be changed are returned without making any changes.
+func AddNodeTags(db sqlx.Queryer, filter NodeFilter, tags []string, dryRun bool) ([]lorawan.EUI64, error) {
+	return updateNodeTags(db, filter, tags, dryRun,
+		"array(select distinct t from unnest(tags || $tags::text[]) t order by t)",
+		"not tags @> $tags",
+	)
+}
+
+// RemoveNodeTags removes the given tags from the nodes matching the given
+// filter and returns the DevEUIs of the changed nodes. Nodes having none
+// of the given tags are not changed. When dryRun is set, the DevEUIs of the
+// nodes that would be changed are returned without making any changes.
+func RemoveNodeTags(db sqlx.Queryer, filter NodeFilter, tags []string, dryRun bool) ([]lorawan.EUI64, error) {
+	return updateNodeTags(db, filter, tags, dryRun,
+		"array(select t from unnest(tags) t where t <> all($tags::text[]))",
+		"tags && $tags",
+	)
+}
+
+// updateNodeTags sets the tags of the nodes matching the given filter and
+// the changed condition to the given set expression. In both expressions
+// $tags refers to the given tags.
+func updateNodeTags(db sqlx.Queryer, filter NodeFilter, tags []string, dryRun bool, set, changed string) ([]lorawan.EUI64, error) {
+	if len(tags) == 0 {
+		return nil, ErrNodeTagsRequired
+	}
+	if err := validateNodeTags(tags); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	where, args := filter.where()
+	args = append(args, pq.StringArray(tags))
+	where += `
+			and ` + changed
+	where = strings.Replace(where, "$tags", fmt.Sprintf("$%d", len(args)), -1)
+	set = strings.Replace(set, "$tags", fmt.Sprintf("$%d", len(args)), -1)
+
+	query := `
+		update node set
+			tags = ` + set + `,
+			revision = revision + 1
+		where` + where + `
+		returning dev_eui`
+	if dryRun {
+		query = `
+		select dev_eui
+		from node
+		where` + where + `
+		order by name`
+	}
+
+	devEUIs := []lorawan.EUI64{}
+	if err := sqlx.Select(db, &devEUIs, query, args...); err != nil {
+		return nil, handlePSQLError(err, "update node tags error")
+	}
+
+	if !dryRun {
+		log.WithFields(log.Fields{
+			"application_id": filter.ApplicationID,
+			"count":          len(devEUIs),
+		}).Info("node tags updated")
+	}
+
+	return devEUIs, nil
+}
+
+// validateNodeTags validates the given tags.
+func validateNodeTags(tags []string) error {
+	for _, tag := range tags {
+		if !nodeTagRegexp.MatchString(tag) {
+			return ErrNodeInvalidTag
+		}
+	}
+	return nil
+}
+
+// nodeTags returns the given tags, or an empty slice when nil as the tags
+// column does not allow null values.
+func nodeTags(tags []string) pq.StringArray {
+	if tags == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(tags)
+}
+
+// nodeNameLikePattern converts the given name pattern (using the * and ?
+// wildcards) into a SQL like pattern. An empty pattern matches all names.
+func nodeNameLikePattern(pattern string) string {
+	if pattern == "" {
+		return "%"
+	}
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "*", "%", "?", "_")
+	return r.Replace(pattern)
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"regexp"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// aliases must be usable in URL paths
+var nodeAliasRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-_.]{0,98}[a-z0-9])?$`)
+
+// NodeLookup contains the location of a node within the organizations and
+// applications.
+type NodeLookup struct {
+	DevEUI           lorawan.EUI64 `db:"dev_eui"`
+	Name             string        `db:"name"`
+	Alias            *string       `db:"alias"`
+	ApplicationID    int64         `db:"application_id"`
+	ApplicationName  string        `db:"application_name"`
+	OrganizationID   int64         `db:"organization_id"`
+	OrganizationName string        `db:"organization_name"`
+}
+
+// SetNodeAlias sets the alias of the given node. The alias must be unique
+// within the organization of the node. An empty alias removes the alias.
+func SetNodeAlias(db sqlx.Execer, devEUI lorawan.EUI64, alias string) error {
+	if alias == "" {
+		_, err := db.Exec("delete from node_alias where dev_eui = $1", devEUI[:])
+		if err != nil {
+			return handlePSQLError(err, "delete error")
+		}
+		log.WithField("dev_eui", devEUI).Info("node alias removed")
+		return nil
+	}
+
+	if !nodeAliasRegexp.MatchString(alias) {
+		return ErrNodeInvalidAlias
+	}
+
+	res, err := db.Exec(`
+		insert into node_alias (
+			dev_eui,
+			organization_id,
+			alias
+		)
+		select n.dev_eui, a.organization_id, $2
+		from node n
+		inner join application a
+			on a.id = n.application_id
+		where
+			n.dev_eui = $1
+		on conflict (dev_eui) do update
+		set
+			organization_id = excluded.organization_id,
+			alias = excluded.alias`,
+		devEUI[:],
+		alias,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": devEUI,
+		"alias":   alias,
+	}).Info("node alias set")
+	return nil
+}
+
+// GetNodeAlias returns the alias of the given node (empty when not set).
+func GetNodeAlias(db sqlx.Queryer, devEUI lorawan.EUI64) (string, error) {
+	var aliases []string
+	err := sqlx.Select(db, &aliases, "select alias from node_alias where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return "", handlePSQLError(err, "select error")
+	}
+	if len(aliases) == 0 {
+		return "", nil
+	}
+	return aliases[0], nil
+}
+
+// GetNodeByAlias returns the Node of the given organization matching the
+// given alias.
+func GetNodeByAlias(db sqlx.Queryer, organizationID int64, alias string) (Node, error) {
+	var node Node
+	err := sqlx.Get(db, &node, `
+		select n.*
+		from node n
+		inner join node_alias na
+			on na.dev_eui = n.dev_eui
+		inner join application a
+			on a.id = n.application_id
+		where
+			na.organization_id = $1
+			and na.alias = $2
+			and a.organization_id = $1`,
+		organizationID,
+		alias,
+	)
+	if err != nil {
+		return node, handlePSQLError(err, "select error")
+	}
+	return node, nil
+}
+
+// LookupNode returns the organization and application of the given node.
+func LookupNode(db sqlx.Queryer, devEUI lorawan.EUI64) (NodeLookup, error) {
+	var l NodeLookup
+	err := sqlx.Get(db, &l, `
+		select
+			n.dev_eui,
+			n.name,
+			na.alias,
+			a.id as application_id,
+			a.name as application_name,
+			o.id as organization_id,
+			o.name as organization_name
+		from node n
+		inner join application a
+			on a.id = n.application_id
+		inner join organization o
+			on o.id = a.organization_id
+		left join node_alias na
+			on na.dev_eui = n.dev_eui
+			and na.organization_id = a.organization_id
+		where
+			n.dev_eui = $1`,
+		devEUI[:],
+	)
+	if err != nil {
+		return l, handlePSQLError(err, "select error")
+	}
+	return l, nil
+}
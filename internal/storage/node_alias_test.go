@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestNodeAlias(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with two organizations with each a node", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		var nodes []Node
+		for i, name := range []string{"test-org-1", "test-org-2"} {
+			org := Organization{
+				Name: name,
+			}
+			So(CreateOrganization(db, &org), ShouldBeNil)
+
+			app := Application{
+				OrganizationID: org.ID,
+				Name:           "test-app",
+			}
+			So(CreateApplication(db, &app), ShouldBeNil)
+
+			node := Node{
+				ApplicationID: app.ID,
+				Name:          "test-node",
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, byte(i)},
+			}
+			So(CreateNode(db, node), ShouldBeNil)
+			nodes = append(nodes, node)
+		}
+
+		Convey("Then setting an invalid alias returns an error", func() {
+			So(SetNodeAlias(db, nodes[0].DevEUI, "Meter 1"), ShouldEqual, ErrNodeInvalidAlias)
+		})
+
+		Convey("Then setting an alias for an unknown node returns an error", func() {
+			So(SetNodeAlias(db, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, "meter-1"), ShouldEqual, ErrDoesNotExist)
+		})
+
+		Convey("When setting the same alias for the nodes of both organizations", func() {
+			So(SetNodeAlias(db, nodes[0].DevEUI, "meter-1"), ShouldBeNil)
+			So(SetNodeAlias(db, nodes[1].DevEUI, "meter-1"), ShouldBeNil)
+
+			Convey("Then the alias can be retrieved", func() {
+				alias, err := GetNodeAlias(db, nodes[0].DevEUI)
+				So(err, ShouldBeNil)
+				So(alias, ShouldEqual, "meter-1")
+			})
+
+			Convey("Then the node is returned by the alias within its organization", func() {
+				for _, n := range nodes {
+					lookup, err := LookupNode(db, n.DevEUI)
+					So(err, ShouldBeNil)
+
+					node, err := GetNodeByAlias(db, lookup.OrganizationID, "meter-1")
+					So(err, ShouldBeNil)
+					So(node.DevEUI, ShouldEqual, n.DevEUI)
+				}
+			})
+
+			Convey("Then the lookup contains the alias, application and organization", func() {
+				lookup, err := LookupNode(db, nodes[0].DevEUI)
+				So(err, ShouldBeNil)
+				So(*lookup.Alias, ShouldEqual, "meter-1")
+				So(lookup.Name, ShouldEqual, "test-node")
+				So(lookup.ApplicationName, ShouldEqual, "test-app")
+				So(lookup.OrganizationName, ShouldEqual, "test-org-1")
+			})
+
+			Convey("Then the alias can not be used twice within the same organization", func() {
+				app, err := GetApplication(db, nodes[0].ApplicationID)
+				So(err, ShouldBeNil)
+				node := Node{
+					ApplicationID: app.ID,
+					Name:          "test-node-2",
+					DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 9},
+				}
+				So(CreateNode(db, node), ShouldBeNil)
+				So(SetNodeAlias(db, node.DevEUI, "meter-1"), ShouldEqual, ErrAlreadyExists)
+			})
+
+			Convey("Then the alias can be removed", func() {
+				So(SetNodeAlias(db, nodes[0].DevEUI, ""), ShouldBeNil)
+				alias, err := GetNodeAlias(db, nodes[0].DevEUI)
+				So(err, ShouldBeNil)
+				So(alias, ShouldEqual, "")
+
+				lookup, err := LookupNode(db, nodes[0].DevEUI)
+				So(err, ShouldBeNil)
+				_, err = GetNodeByAlias(db, lookup.OrganizationID, "meter-1")
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// NodeIntegration represents a device-level integration. It is used in
+// addition to the integrations of the application the node belongs to.
+type NodeIntegration struct {
+	ID        int64           `db:"id"`
+	UUID      UUID            `db:"uuid"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+	DevEUI    lorawan.EUI64   `db:"dev_eui"`
+	Kind      string          `db:"kind"`
+	Settings  json.RawMessage `db:"settings"`
+	Revision  int64           `db:"revision"`
+
+	IntegrationOptions
+}
+
+// CreateNodeIntegration creates the given NodeIntegration.
+func CreateNodeIntegration(db sqlx.Queryer, i *NodeIntegration) error {
+	var err error
+	if i.UUID, err = NewUUID(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = sqlx.Get(db, &i.ID, `
+		insert into node_integration (
+			uuid,
+			created_at,
+			updated_at,
+			dev_eui,
+			kind,
+			settings
+		) values ($1, $2, $3, $4, $5, $6) returning id`,
+		i.UUID,
+		now,
+		now,
+		i.DevEUI[:],
+		i.Kind,
+		i.Settings,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	i.CreatedAt = now
+	i.UpdatedAt = now
+	log.WithFields(log.Fields{
+		"id":      i.ID,
+		"kind":    i.Kind,
+		"dev_eui": i.DevEUI,
+	}).Info("node integration created")
+	return nil
+}
+
+// GetNodeIntegration returns the NodeIntegration for the given DevEUI and
+// kind.
+func GetNodeIntegration(db sqlx.Queryer, devEUI lorawan.EUI64, kind string) (NodeIntegration, error) {
+	var i NodeIntegration
+	err := sqlx.Get(db, &i, "select * from node_integration where dev_eui = $1 and kind = $2", devEUI[:], kind)
+	if err != nil {
+		return i, handlePSQLError(err, "select error")
+	}
+	return i, nil
+}
+
+// GetNodeIntegrationsForDevEUI returns the integrations for the given DevEUI.
+func GetNodeIntegrationsForDevEUI(db sqlx.Queryer, devEUI lorawan.EUI64) ([]NodeIntegration, error) {
+	var is []NodeIntegration
+	err := sqlx.Select(db, &is, `
+		select *
+		from node_integration
+		where dev_eui = $1
+		order by kind`,
+		devEUI[:],
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return is, nil
+}
+
+// UpdateNodeIntegration updates the given NodeIntegration.
+func UpdateNodeIntegration(db sqlx.Ext, i *NodeIntegration) error {
+	now := time.Now()
+	res, err := db.Exec(`
+		update node_integration
+		set
+			updated_at = $2,
+			settings = $3,
+			revision = revision + 1
+		where
+			id = $1
+			and revision = $4`,
+		i.ID,
+		now,
+		i.Settings,
+		i.Revision,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return updateNoRowsError(db, "select count(*) from node_integration where id = $1", i.ID)
+	}
+
+	i.UpdatedAt = now
+	i.Revision++
+	log.WithFields(log.Fields{
+		"id":      i.ID,
+		"kind":    i.Kind,
+		"dev_eui": i.DevEUI,
+	}).Info("node integration updated")
+	return nil
+}
+
+// DeleteNodeIntegration deletes the integration matching the given id.
+func DeleteNodeIntegration(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from node_integration where id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("node integration deleted")
+	return nil
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestNodeIntegration(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a node", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		app := Application{
+			OrganizationID: org.ID,
+			Name:           "test-app",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		node := Node{
+			ApplicationID: app.ID,
+			Name:          "test-node",
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		So(CreateNode(db, node), ShouldBeNil)
+
+		Convey("Then creating an integration for an unknown node returns an error", func() {
+			intgr := NodeIntegration{
+				DevEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				Kind:     "HTTP",
+				Settings: json.RawMessage(`{}`),
+			}
+			So(CreateNodeIntegration(db, &intgr), ShouldEqual, ErrDoesNotExist)
+		})
+
+		Convey("When creating an integration", func() {
+			settings := testIntegrationSettings{
+				URL: "http://foo.bar/",
+				Key: 12345,
+			}
+			intgr := NodeIntegration{
+				DevEUI: node.DevEUI,
+				Kind:   "HTTP",
+			}
+			intgr.Settings, err = json.Marshal(settings)
+			So(err, ShouldBeNil)
+			So(CreateNodeIntegration(db, &intgr), ShouldBeNil)
+
+			Convey("Then it can be retrieved by DevEUI and kind", func() {
+				i, err := GetNodeIntegration(db, node.DevEUI, "HTTP")
+				So(err, ShouldBeNil)
+				So(i.ID, ShouldEqual, intgr.ID)
+				So(i.UUID, ShouldEqual, intgr.UUID)
+				So(i.DevEUI, ShouldEqual, node.DevEUI)
+
+				var s testIntegrationSettings
+				So(json.Unmarshal(i.Settings, &s), ShouldBeNil)
+				So(s, ShouldResemble, settings)
+			})
+
+			Convey("Then it is returned by the integrations of the node", func() {
+				ints, err := GetNodeIntegrationsForDevEUI(db, node.DevEUI)
+				So(err, ShouldBeNil)
+				So(ints, ShouldHaveLength, 1)
+				So(ints[0].ID, ShouldEqual, intgr.ID)
+			})
+
+			Convey("Then a second integration of the same kind can not be created", func() {
+				i := NodeIntegration{
+					DevEUI:   node.DevEUI,
+					Kind:     "HTTP",
+					Settings: json.RawMessage(`{}`),
+				}
+				So(CreateNodeIntegration(db, &i), ShouldEqual, ErrAlreadyExists)
+			})
+
+			Convey("Then it can be updated", func() {
+				settings.URL = "http://foo.bar/updated"
+				intgr.Settings, err = json.Marshal(settings)
+				So(err, ShouldBeNil)
+				So(UpdateNodeIntegration(db, &intgr), ShouldBeNil)
+				So(intgr.Revision, ShouldEqual, 1)
+
+				i, err := GetNodeIntegration(db, node.DevEUI, "HTTP")
+				So(err, ShouldBeNil)
+				So(i.Revision, ShouldEqual, 1)
+
+				var s testIntegrationSettings
+				So(json.Unmarshal(i.Settings, &s), ShouldBeNil)
+				So(s, ShouldResemble, settings)
+
+				Convey("Then updating with an outdated revision returns an error", func() {
+					i.Revision = 0
+					So(UpdateNodeIntegration(db, &i), ShouldEqual, ErrRevisionMismatch)
+				})
+			})
+
+			Convey("Then the failure simulation can be set", func() {
+				until := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+				So(SetNodeIntegrationChaos(db, intgr.ID, IntegrationChaos{
+					FailureRate: 50,
+					Latency:     time.Second,
+					Until:       &until,
+				}), ShouldBeNil)
+
+				i, err := GetNodeIntegration(db, node.DevEUI, "HTTP")
+				So(err, ShouldBeNil)
+				c := i.Chaos()
+				So(c.FailureRate, ShouldEqual, 50)
+				So(c.Latency, ShouldEqual, time.Second)
+				So(c.Until.Equal(until), ShouldBeTrue)
+			})
+
+			Convey("Then the event filter can be set", func() {
+				f := IntegrationFilter{
+					EventTypes: []string{IntegrationEventUp},
+					FPorts:     []int{10},
+					Tags:       []string{"building-a"},
+				}
+				So(SetNodeIntegrationFilter(db, intgr.ID, f), ShouldBeNil)
+
+				i, err := GetNodeIntegration(db, node.DevEUI, "HTTP")
+				So(err, ShouldBeNil)
+				So(i.Filter(), ShouldResemble, f)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteNodeIntegration(db, intgr.ID), ShouldBeNil)
+				_, err := GetNodeIntegration(db, node.DevEUI, "HTTP")
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+
+			Convey("Then it is deleted together with the node", func() {
+				So(DeleteNode(db, node.DevEUI), ShouldBeNil)
+				ints, err := GetNodeIntegrationsForDevEUI(db, node.DevEUI)
+				So(err, ShouldBeNil)
+				So(ints, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
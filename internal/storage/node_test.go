@@ -3,10 +3,12 @@ package storage
 import (
 	"testing"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
 )
 
 func TestValidateDevNonce(t *testing.T) {
@@ -118,6 +120,8 @@ func TestNodeMethods(t *testing.T) {
 				AppKey:        [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
 				IsABP:         true,
 				IsClassC:      true,
+				Tags:          pq.StringArray{"outdoor"},
+				Variables:     NodeVariables{"ThingsBoardAccessToken": "secret-token"},
 
 				RXDelay:            2,
 				RX1DROffset:        3,
@@ -158,8 +162,29 @@ func TestNodeMethods(t *testing.T) {
 					node2, err := GetNode(db, node.DevEUI)
 					So(err, ShouldBeNil)
 					node2.UsedDevNonces = nil
+					node.Revision = 1
 					So(node2, ShouldResemble, node)
 				})
+
+				Convey("Then updating the node with the old revision fails", func() {
+					So(UpdateNode(db, node), ShouldResemble, ErrRevisionMismatch)
+				})
+			})
+
+			Convey("When retiring the node", func() {
+				So(RetireNode(db, node.DevEUI), ShouldBeNil)
+
+				Convey("Then the node is retired and disabled", func() {
+					node2, err := GetNode(db, node.DevEUI)
+					So(err, ShouldBeNil)
+					So(node2.RetiredAt, ShouldNotBeNil)
+					So(node2.Disabled, ShouldBeTrue)
+					So(node2.Revision, ShouldEqual, 1)
+				})
+
+				Convey("Then retiring the node again returns ErrNodeRetired", func() {
+					So(RetireNode(db, node.DevEUI), ShouldEqual, ErrNodeRetired)
+				})
 			})
 
 			Convey("When deleting the node", func() {
@@ -186,6 +211,8 @@ func TestNodeMethods(t *testing.T) {
 						Description: "test node description",
 						DevEUI:      [8]byte{8, 7, 6, 5, 4, 3, 2, 1},
 						AppKey:      [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+						Tags:        pq.StringArray{"outdoor"},
+						Revision:    1,
 					})
 				})
 
@@ -204,6 +231,8 @@ func TestNodeMethods(t *testing.T) {
 							DevEUI:      [8]byte{8, 7, 6, 5, 4, 3, 2, 1},
 							AppKey:      [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
 							IsClassC:    true,
+							Tags:        pq.StringArray{"outdoor"},
+							Revision:    2,
 						})
 					})
 				})
@@ -211,3 +240,120 @@ func TestNodeMethods(t *testing.T) {
 		})
 	})
 }
+
+func TestNodeNameLikePattern(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Pattern  string
+			Expected string
+		}{
+			{"", "%"},
+			{"sensor-*", "sensor-%"},
+			{"sensor-?", "sensor-_"},
+			{"floor_1*", `floor\_1%`},
+			{"100%", `100\%`},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Pattern, func() {
+				So(nodeNameLikePattern(test.Pattern), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestNodeTags(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an application and three nodes", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+		app := Application{
+			OrganizationID: org.ID,
+			Name:           "test",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		nodes := []Node{
+			{ApplicationID: app.ID, Name: "sensor-1", DevEUI: lorawan.EUI64{1}, Tags: pq.StringArray{"outdoor"}},
+			{ApplicationID: app.ID, Name: "sensor-2", DevEUI: lorawan.EUI64{2}},
+			{ApplicationID: app.ID, Name: "gateway-sensor", DevEUI: lorawan.EUI64{3}, Tags: pq.StringArray{"outdoor", "floor-1"}},
+		}
+		for _, n := range nodes {
+			So(CreateNode(db, n), ShouldBeNil)
+		}
+
+		Convey("When creating a node with an invalid variable name", func() {
+			err := CreateNode(db, Node{ApplicationID: app.ID, Name: "sensor-4", DevEUI: lorawan.EUI64{4}, Variables: NodeVariables{"access token": "secret"}})
+
+			Convey("Then a validation error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrNodeInvalidVariableName)
+			})
+		})
+
+		Convey("When creating a node with an invalid tag", func() {
+			err := CreateNode(db, Node{ApplicationID: app.ID, Name: "sensor-4", DevEUI: lorawan.EUI64{4}, Tags: pq.StringArray{"floor 1"}})
+
+			Convey("Then a validation error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrNodeInvalidTag)
+			})
+		})
+
+		Convey("When adding tags without tags", func() {
+			_, err := AddNodeTags(db, NodeFilter{ApplicationID: app.ID}, nil, false)
+
+			Convey("Then ErrNodeTagsRequired is returned", func() {
+				So(err, ShouldEqual, ErrNodeTagsRequired)
+			})
+		})
+
+		Convey("When adding a tag to the nodes matching sensor-* in dry-run mode", func() {
+			devEUIs, err := AddNodeTags(db, NodeFilter{ApplicationID: app.ID, NamePattern: "sensor-*"}, []string{"floor-1"}, true)
+			So(err, ShouldBeNil)
+
+			Convey("Then the matching nodes are returned", func() {
+				So(devEUIs, ShouldResemble, []lorawan.EUI64{{1}, {2}})
+			})
+
+			Convey("Then the nodes have not been updated", func() {
+				n, err := GetNode(db, lorawan.EUI64{1})
+				So(err, ShouldBeNil)
+				So(n.Tags, ShouldResemble, pq.StringArray{"outdoor"})
+				So(n.Revision, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When adding a tag to the nodes having the outdoor tag", func() {
+			devEUIs, err := AddNodeTags(db, NodeFilter{ApplicationID: app.ID, Tags: []string{"outdoor"}}, []string{"floor-1"}, false)
+			So(err, ShouldBeNil)
+
+			Convey("Then only the nodes not having the tag yet have been updated", func() {
+				So(devEUIs, ShouldResemble, []lorawan.EUI64{{1}})
+
+				n, err := GetNode(db, lorawan.EUI64{1})
+				So(err, ShouldBeNil)
+				So(n.Tags, ShouldResemble, pq.StringArray{"floor-1", "outdoor"})
+				So(n.Revision, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When removing a tag from all nodes", func() {
+			devEUIs, err := RemoveNodeTags(db, NodeFilter{ApplicationID: app.ID}, []string{"outdoor"}, false)
+			So(err, ShouldBeNil)
+
+			Convey("Then the tag has been removed from the nodes having it", func() {
+				So(devEUIs, ShouldHaveLength, 2)
+
+				n, err := GetNode(db, lorawan.EUI64{3})
+				So(err, ShouldBeNil)
+				So(n.Tags, ShouldResemble, pq.StringArray{"floor-1"})
+			})
+		})
+	})
+}
@@ -16,6 +16,7 @@ var organizationNameRegexp = regexp.MustCompile(`^[\w-]+$`)
 // Organization represents an organization.
 type Organization struct {
 	ID              int64     `db:"id"`
+	UUID            UUID      `db:"uuid"`
 	CreatedAt       time.Time `db:"created_at"`
 	UpdatedAt       time.Time `db:"updated_at"`
 	Name            string    `db:"name"`
@@ -46,16 +47,23 @@ func CreateOrganization(db *sqlx.DB, org *Organization) error {
 		return errors.Wrap(err, "validate error")
 	}
 
+	var err error
+	if org.UUID, err = NewUUID(); err != nil {
+		return err
+	}
+
 	now := time.Now()
 
-	err := db.Get(&org.ID, `
+	err = db.Get(&org.ID, `
 		insert into organization (
+			uuid,
 			created_at,
 			updated_at,
 			name,
 			display_name,
 			can_have_gateways
-		) values ($1, $2, $3, $4, $5) returning id`,
+		) values ($1, $2, $3, $4, $5, $6) returning id`,
+		org.UUID,
 		now,
 		now,
 		org.Name,
@@ -97,6 +105,30 @@ func GetOrganization(db *sqlx.DB, id int64) (Organization, error) {
 	return org, nil
 }
 
+// GetOrganizationByUUID returns the Organization for the given UUID.
+func GetOrganizationByUUID(db *sqlx.DB, u UUID) (Organization, error) {
+	var org Organization
+	err := db.Get(&org, "select * from organization where uuid = $1", u)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return org, ErrDoesNotExist
+		}
+		return org, errors.Wrap(err, "select error")
+	}
+	return org, nil
+}
+
+// GetOrganizationsByDisplayName returns the organizations matching the
+// given display name.
+func GetOrganizationsByDisplayName(db *sqlx.DB, displayName string) ([]Organization, error) {
+	var orgs []Organization
+	err := db.Select(&orgs, "select * from organization where display_name = $1 order by id", displayName)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return orgs, nil
+}
+
 // GetOrganizationCount returns the total number of organizations.
 func GetOrganizationCount(db *sqlx.DB, search string) (int, error) {
 	var count int
@@ -136,7 +168,7 @@ func GetOrganizationCountForUser(db *sqlx.DB, username string, search string) (i
 		from organization o
 		inner join "user" u
 			on u.username = $1
-		left join organization_user ou
+		left join organization_member ou
 			on o.id = ou.organization_id and u.id = ou.user_id
 		left join application a
 			on o.id = a.organization_id
@@ -197,7 +229,7 @@ func GetOrganizationsForUser(db *sqlx.DB, username string, limit, offset int, se
 		from organization o
 		inner join "user" u
 			on u.username = $1
-		left join organization_user ou
+		left join organization_member ou
 			on o.id = ou.organization_id and u.id = ou.user_id
 		left join application a
 			on o.id = a.organization_id
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Digest frequencies.
+const (
+	DigestDaily  = "DAILY"
+	DigestWeekly = "WEEKLY"
+)
+
+// OrganizationDigest defines the periodic digest report of an organization,
+// which is posted to the configured webhook.
+type OrganizationDigest struct {
+	OrganizationID int64      `db:"organization_id"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+	Frequency      string     `db:"frequency"`
+	WebhookURL     string     `db:"webhook_url"`
+	Template       string     `db:"template"`
+	LastSentAt     *time.Time `db:"last_sent_at"`
+}
+
+// Validate validates the OrganizationDigest data.
+func (d OrganizationDigest) Validate() error {
+	if d.Frequency != DigestDaily && d.Frequency != DigestWeekly {
+		return ErrDigestInvalidFrequency
+	}
+	u, err := url.Parse(d.WebhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrDigestInvalidWebhookURL
+	}
+	return nil
+}
+
+// Period returns the period covered by the digest.
+func (d OrganizationDigest) Period() time.Duration {
+	if d.Frequency == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// DigestNodeStats contains the statistics of a node used by the digest
+// report.
+type DigestNodeStats struct {
+	ApplicationID   int64         `db:"application_id"`
+	ApplicationName string        `db:"application_name"`
+	DevEUI          lorawan.EUI64 `db:"dev_eui"`
+	Name            string        `db:"name"`
+	LastSeen        *time.Time    `db:"last_seen"`
+	Uplinks         int           `db:"uplinks"`
+	Missed          int           `db:"missed"`
+}
+
+// UpdateOrganizationDigest creates or updates the given OrganizationDigest.
+func UpdateOrganizationDigest(db sqlx.Execer, d *OrganizationDigest) error {
+	if err := d.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	_, err := db.Exec(`
+		insert into organization_digest (
+			organization_id,
+			created_at,
+			updated_at,
+			frequency,
+			webhook_url,
+			template
+		) values ($1, $2, $2, $3, $4, $5)
+		on conflict (organization_id) do update
+		set
+			updated_at = excluded.updated_at,
+			frequency = excluded.frequency,
+			webhook_url = excluded.webhook_url,
+			template = excluded.template`,
+		d.OrganizationID,
+		now,
+		d.Frequency,
+		d.WebhookURL,
+		d.Template,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	d.UpdatedAt = now
+	log.WithFields(log.Fields{
+		"organization_id": d.OrganizationID,
+		"frequency":       d.Frequency,
+	}).Info("organization digest updated")
+	return nil
+}
+
+// GetOrganizationDigest returns the OrganizationDigest for the given
+// organization id.
+func GetOrganizationDigest(db sqlx.Queryer, organizationID int64) (OrganizationDigest, error) {
+	var d OrganizationDigest
+	err := sqlx.Get(db, &d, "select * from organization_digest where organization_id = $1", organizationID)
+	if err != nil {
+		return d, handlePSQLError(err, "select error")
+	}
+	return d, nil
+}
+
+// DeleteOrganizationDigest deletes the OrganizationDigest for the given
+// organization id.
+func DeleteOrganizationDigest(db sqlx.Execer, organizationID int64) error {
+	res, err := db.Exec("delete from organization_digest where organization_id = $1", organizationID)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("organization_id", organizationID).Info("organization digest deleted")
+	return nil
+}
+
+// GetDueOrganizationDigests returns the organization digests which have
+// not been sent within their period before the given time.
+func GetDueOrganizationDigests(db sqlx.Queryer, now time.Time) ([]OrganizationDigest, error) {
+	var digests []OrganizationDigest
+	err := sqlx.Select(db, &digests, `
+		select *
+		from organization_digest
+		where
+			last_sent_at is null
+			or last_sent_at <= $1 - (case frequency when $2 then interval '7 days' else interval '1 day' end)
+		order by organization_id`,
+		now,
+		DigestWeekly,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return digests, nil
+}
+
+// SetOrganizationDigestSent sets the time at which the digest of the given
+// organization was last sent.
+func SetOrganizationDigestSent(db sqlx.Execer, organizationID int64, t time.Time) error {
+	_, err := db.Exec(`
+		update organization_digest
+		set
+			last_sent_at = $2
+		where organization_id = $1`,
+		organizationID,
+		t,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	return nil
+}
+
+// GetDigestNodeStats returns for each node of the given organization the
+// time at which it was last seen and its number of received and missed
+// uplinks since the given time, sorted by application and node name.
+func GetDigestNodeStats(db sqlx.Queryer, organizationID int64, since time.Time) ([]DigestNodeStats, error) {
+	var stats []DigestNodeStats
+	err := sqlx.Select(db, &stats, `
+		select
+			a.id as application_id,
+			a.name as application_name,
+			n.dev_eui,
+			n.name,
+			max(lq.bucket) as last_seen,
+			coalesce(sum(lq.uplinks) filter (where lq.bucket >= $2), 0) as uplinks,
+			coalesce(sum(lq.missed) filter (where lq.bucket >= $2), 0) as missed
+		from node n
+		inner join application a
+			on a.id = n.application_id
+		left join node_link_quality lq
+			on lq.dev_eui = n.dev_eui
+		where
+			a.organization_id = $1
+		group by a.id, a.name, n.dev_eui, n.name
+		order by a.name, n.name`,
+		organizationID,
+		since,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return stats, nil
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestOrganizationDigestValidate(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Digest        OrganizationDigest
+			ExpectedError error
+		}{
+			{"valid daily digest", OrganizationDigest{Frequency: DigestDaily, WebhookURL: "https://example.com/digest"}, nil},
+			{"valid weekly digest", OrganizationDigest{Frequency: DigestWeekly, WebhookURL: "http://example.com"}, nil},
+			{"invalid frequency", OrganizationDigest{Frequency: "HOURLY", WebhookURL: "https://example.com"}, ErrDigestInvalidFrequency},
+			{"missing webhook url", OrganizationDigest{Frequency: DigestDaily}, ErrDigestInvalidWebhookURL},
+			{"invalid webhook url scheme", OrganizationDigest{Frequency: DigestDaily, WebhookURL: "ftp://example.com"}, ErrDigestInvalidWebhookURL},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(test.Digest.Validate(), ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestOrganizationDigest(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		Convey("When creating a daily digest", func() {
+			d := OrganizationDigest{
+				OrganizationID: org.ID,
+				Frequency:      DigestDaily,
+				WebhookURL:     "https://example.com/digest",
+				Template:       "{{ .Nodes }}",
+			}
+			So(UpdateOrganizationDigest(db, &d), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				d2, err := GetOrganizationDigest(db, org.ID)
+				So(err, ShouldBeNil)
+				So(d2.Frequency, ShouldEqual, DigestDaily)
+				So(d2.WebhookURL, ShouldEqual, "https://example.com/digest")
+				So(d2.Template, ShouldEqual, "{{ .Nodes }}")
+				So(d2.LastSentAt, ShouldBeNil)
+			})
+
+			Convey("Then it is due", func() {
+				digests, err := GetDueOrganizationDigests(db, time.Now())
+				So(err, ShouldBeNil)
+				So(digests, ShouldHaveLength, 1)
+			})
+
+			Convey("When the digest has been sent", func() {
+				now := time.Now()
+				So(SetOrganizationDigestSent(db, org.ID, now), ShouldBeNil)
+
+				Convey("Then it is only due after a day", func() {
+					digests, err := GetDueOrganizationDigests(db, now.Add(time.Hour))
+					So(err, ShouldBeNil)
+					So(digests, ShouldHaveLength, 0)
+
+					digests, err = GetDueOrganizationDigests(db, now.Add(24*time.Hour))
+					So(err, ShouldBeNil)
+					So(digests, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When deleting the digest", func() {
+				So(DeleteOrganizationDigest(db, org.ID), ShouldBeNil)
+
+				Convey("Then it has been deleted", func() {
+					_, err := GetOrganizationDigest(db, org.ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+
+		Convey("Given an application with a node having link-quality metrics", func() {
+			app := Application{
+				OrganizationID: org.ID,
+				Name:           "test-app",
+			}
+			So(CreateApplication(db, &app), ShouldBeNil)
+			node := Node{
+				ApplicationID: app.ID,
+				Name:          "test-node",
+				DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			}
+			So(CreateNode(db, node), ShouldBeNil)
+
+			bucket := time.Now().Truncate(time.Hour)
+			for _, b := range []time.Time{bucket.Add(-48 * time.Hour), bucket} {
+				So(AddLinkQuality(db, LinkQuality{
+					DevEUI:  node.DevEUI,
+					Bucket:  b,
+					Uplinks: 10,
+					Missed:  1,
+				}), ShouldBeNil)
+			}
+
+			Convey("Then the node stats since yesterday only count the last bucket", func() {
+				stats, err := GetDigestNodeStats(db, org.ID, time.Now().Add(-24*time.Hour))
+				So(err, ShouldBeNil)
+				So(stats, ShouldHaveLength, 1)
+				So(stats[0].ApplicationName, ShouldEqual, "test-app")
+				So(stats[0].DevEUI, ShouldEqual, node.DevEUI)
+				So(stats[0].LastSeen.Equal(bucket), ShouldBeTrue)
+				So(stats[0].Uplinks, ShouldEqual, 10)
+				So(stats[0].Missed, ShouldEqual, 1)
+			})
+		})
+	})
+}
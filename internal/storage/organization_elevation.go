@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxElevationDuration defines the max. duration of an organization
+// elevation.
+const MaxElevationDuration = 24 * time.Hour
+
+// OrganizationElevation defines a time-boxed elevation of a user to
+// organization admin (e.g. to give a support engineer access to an
+// organization during an incident). While active (not expired or revoked),
+// the user has the same rights as an organization admin. The elevations
+// are never deleted (unless the organization or user is deleted), so that
+// they form the audit log of the granted access.
+type OrganizationElevation struct {
+	ID             int64      `db:"id"`
+	CreatedAt      time.Time  `db:"created_at"`
+	OrganizationID int64      `db:"organization_id"`
+	UserID         int64      `db:"user_id"`
+	Username       string     `db:"username"`
+	GrantedBy      string     `db:"granted_by"`
+	Justification  string     `db:"justification"`
+	ExpiresAt      time.Time  `db:"expires_at"`
+	RevokedAt      *time.Time `db:"revoked_at"`
+	RevokedBy      *string    `db:"revoked_by"`
+}
+
+// Validate validates the OrganizationElevation data.
+func (e OrganizationElevation) Validate() error {
+	if strings.TrimSpace(e.Justification) == "" {
+		return ErrElevationJustificationRequired
+	}
+	if !e.ExpiresAt.After(e.CreatedAt) || e.ExpiresAt.After(e.CreatedAt.Add(MaxElevationDuration)) {
+		return ErrElevationInvalidExpiresAt
+	}
+	return nil
+}
+
+// Active returns true when the elevation is active at the given time.
+func (e OrganizationElevation) Active(t time.Time) bool {
+	return e.RevokedAt == nil && t.Before(e.ExpiresAt)
+}
+
+// CreateOrganizationElevation creates the given OrganizationElevation.
+// When CreatedAt is not set, it is set to the current time.
+func CreateOrganizationElevation(db sqlx.Queryer, e *OrganizationElevation) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	if err := e.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	err := sqlx.Get(db, &e.ID, `
+		insert into organization_elevation (
+			created_at,
+			organization_id,
+			user_id,
+			granted_by,
+			justification,
+			expires_at
+		) values ($1, $2, $3, $4, $5, $6)
+		returning id`,
+		e.CreatedAt,
+		e.OrganizationID,
+		e.UserID,
+		e.GrantedBy,
+		e.Justification,
+		e.ExpiresAt,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":              e.ID,
+		"organization_id": e.OrganizationID,
+		"user_id":         e.UserID,
+		"granted_by":      e.GrantedBy,
+		"justification":   e.Justification,
+		"expires_at":      e.ExpiresAt,
+	}).Info("organization elevation granted")
+	return nil
+}
+
+// GetOrganizationElevation returns the OrganizationElevation for the given
+// id.
+func GetOrganizationElevation(db sqlx.Queryer, id int64) (OrganizationElevation, error) {
+	var e OrganizationElevation
+	err := sqlx.Get(db, &e, `
+		select e.*, u.username
+		from organization_elevation e
+		inner join "user" u
+			on u.id = e.user_id
+		where e.id = $1`,
+		id,
+	)
+	if err != nil {
+		return e, handlePSQLError(err, "select error")
+	}
+	return e, nil
+}
+
+// GetOrganizationElevationCount returns the total number of elevations
+// (including the expired and revoked ones) of the given organization.
+func GetOrganizationElevationCount(db sqlx.Queryer, organizationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select count(*)
+		from organization_elevation
+		where organization_id = $1`,
+		organizationID,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// GetOrganizationElevations returns the elevations (including the expired
+// and revoked ones) of the given organization, most recent first.
+func GetOrganizationElevations(db sqlx.Queryer, organizationID int64, limit, offset int) ([]OrganizationElevation, error) {
+	var elevations []OrganizationElevation
+	err := sqlx.Select(db, &elevations, `
+		select e.*, u.username
+		from organization_elevation e
+		inner join "user" u
+			on u.id = e.user_id
+		where e.organization_id = $1
+		order by e.created_at desc, e.id desc
+		limit $2 offset $3`,
+		organizationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return elevations, nil
+}
+
+// RevokeOrganizationElevation revokes the given (active) elevation. It
+// returns ErrElevationInactive when the elevation has already expired or
+// has been revoked.
+func RevokeOrganizationElevation(db sqlx.Execer, id int64, revokedBy string) error {
+	res, err := db.Exec(`
+		update organization_elevation
+		set
+			revoked_at = now(),
+			revoked_by = $2
+		where
+			id = $1
+			and revoked_at is null
+			and expires_at > now()`,
+		id,
+		revokedBy,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrElevationInactive
+	}
+
+	log.WithFields(log.Fields{
+		"id":         id,
+		"revoked_by": revokedBy,
+	}).Info("organization elevation revoked")
+	return nil
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+)
+
+func TestOrganizationElevation(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization and user", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+
+		user := User{
+			Username: "support",
+			IsActive: true,
+		}
+		userID, err := CreateUser(db, &user, "password123")
+		So(err, ShouldBeNil)
+
+		now := time.Now()
+
+		Convey("When creating an elevation without justification", func() {
+			err := CreateOrganizationElevation(db, &OrganizationElevation{
+				CreatedAt:      now,
+				OrganizationID: org.ID,
+				UserID:         userID,
+				GrantedBy:      "admin",
+				Justification:  " ",
+				ExpiresAt:      now.Add(time.Hour),
+			})
+
+			Convey("Then a validation error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrElevationJustificationRequired)
+			})
+		})
+
+		Convey("When creating an elevation exceeding the max. duration", func() {
+			err := CreateOrganizationElevation(db, &OrganizationElevation{
+				CreatedAt:      now,
+				OrganizationID: org.ID,
+				UserID:         userID,
+				GrantedBy:      "admin",
+				Justification:  "INC-123",
+				ExpiresAt:      now.Add(MaxElevationDuration + time.Hour),
+			})
+
+			Convey("Then a validation error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrElevationInvalidExpiresAt)
+			})
+		})
+
+		Convey("When creating an elevation", func() {
+			e := OrganizationElevation{
+				CreatedAt:      now,
+				OrganizationID: org.ID,
+				UserID:         userID,
+				GrantedBy:      "admin",
+				Justification:  "INC-123",
+				ExpiresAt:      now.Add(time.Hour),
+			}
+			So(CreateOrganizationElevation(db, &e), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				e2, err := GetOrganizationElevation(db, e.ID)
+				So(err, ShouldBeNil)
+				So(e2.Username, ShouldEqual, "support")
+				So(e2.Justification, ShouldEqual, "INC-123")
+				So(e2.ExpiresAt.Equal(e.ExpiresAt.Truncate(time.Microsecond)), ShouldBeTrue)
+				So(e2.Active(time.Now()), ShouldBeTrue)
+			})
+
+			Convey("Then the user is an admin member of the organization", func() {
+				var isAdmin bool
+				So(db.Get(&isAdmin, "select is_admin from organization_member where organization_id = $1 and user_id = $2", org.ID, userID), ShouldBeNil)
+				So(isAdmin, ShouldBeTrue)
+			})
+
+			Convey("Then the elevations of the organization can be listed", func() {
+				count, err := GetOrganizationElevationCount(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				elevations, err := GetOrganizationElevations(db, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(elevations, ShouldHaveLength, 1)
+				So(elevations[0].ID, ShouldEqual, e.ID)
+			})
+
+			Convey("When revoking the elevation", func() {
+				So(RevokeOrganizationElevation(db, e.ID, "admin"), ShouldBeNil)
+
+				Convey("Then it is no longer active", func() {
+					e2, err := GetOrganizationElevation(db, e.ID)
+					So(err, ShouldBeNil)
+					So(e2.RevokedAt, ShouldNotBeNil)
+					So(*e2.RevokedBy, ShouldEqual, "admin")
+					So(e2.Active(time.Now()), ShouldBeFalse)
+				})
+
+				Convey("Then the user is no longer a member of the organization", func() {
+					var count int
+					So(db.Get(&count, "select count(*) from organization_member where organization_id = $1 and user_id = $2", org.ID, userID), ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+
+				Convey("Then it can not be revoked twice", func() {
+					So(RevokeOrganizationElevation(db, e.ID, "admin"), ShouldEqual, ErrElevationInactive)
+				})
+			})
+		})
+	})
+}
@@ -47,6 +47,23 @@ func TestOrganization(t *testing.T) {
 				So(o, ShouldResemble, org)
 			})
 
+			Convey("Then it can be retrieved by its display name", func() {
+				orgs, err := GetOrganizationsByDisplayName(db, org.DisplayName)
+				So(err, ShouldBeNil)
+				So(orgs, ShouldHaveLength, 1)
+				So(orgs[0].ID, ShouldEqual, org.ID)
+			})
+
+			Convey("Then it can be retrieved by its uuid", func() {
+				So(org.UUID, ShouldNotEqual, UUID{})
+				o, err := GetOrganizationByUUID(db, org.UUID)
+				So(err, ShouldBeNil)
+				So(o.ID, ShouldEqual, org.ID)
+
+				_, err = GetOrganizationByUUID(db, UUID{})
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+
 			Convey("When updating the organization", func() {
 				org.Name = "test-organization-updated"
 				org.DisplayName = "test organization updated"
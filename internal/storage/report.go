@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/brocaar/lorawan"
+)
+
+// NodeReportStats contains the inventory and link-quality statistics of a
+// node, used for generating the application reports.
+type NodeReportStats struct {
+	DevEUI          lorawan.EUI64  `db:"dev_eui"`
+	Name            string         `db:"name"`
+	Description     string         `db:"description"`
+	IsABP           bool           `db:"is_abp"`
+	IsClassC        bool           `db:"is_class_c"`
+	Tags            pq.StringArray `db:"tags"`
+	Disabled        bool           `db:"disabled"`
+	LastSeen        *time.Time     `db:"last_seen"`
+	ActiveHours     int            `db:"active_hours"`
+	Uplinks         int            `db:"uplinks"`
+	Missed          int            `db:"missed"`
+	Retransmissions int            `db:"retransmissions"`
+}
+
+// GetNodeReportStatsForApplicationID returns the report statistics of all
+// nodes of the given application, ordered by name. The last-seen timestamp
+// is the last (hourly) link-quality bucket before the end of the period,
+// the other link-quality statistics only cover the buckets within the given
+// period.
+func GetNodeReportStatsForApplicationID(db sqlx.Queryer, applicationID int64, from, to time.Time) ([]NodeReportStats, error) {
+	var stats []NodeReportStats
+	err := sqlx.Select(db, &stats, `
+		select
+			n.dev_eui,
+			n.name,
+			n.description,
+			n.is_abp,
+			n.is_class_c,
+			n.tags,
+			n.disabled,
+			max(lq.bucket) as last_seen,
+			count(lq.bucket) filter (where lq.bucket >= $2 and lq.uplinks > 0) as active_hours,
+			coalesce(sum(lq.uplinks) filter (where lq.bucket >= $2), 0) as uplinks,
+			coalesce(sum(lq.missed) filter (where lq.bucket >= $2), 0) as missed,
+			coalesce(sum(lq.retransmissions) filter (where lq.bucket >= $2), 0) as retransmissions
+		from node n
+		left join node_link_quality lq
+			on lq.dev_eui = n.dev_eui
+			and lq.bucket < $3
+		where
+			n.application_id = $1
+		group by n.dev_eui
+		order by n.name`,
+		applicationID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return stats, nil
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var savedNodeFilterNameRegexp = regexp.MustCompile(`^[\w-]+$`)
+
+// SavedNodeFilter defines a named node filter, shared within an
+// organization. It can be referenced by the node listings and the bulk
+// node operations.
+type SavedNodeFilter struct {
+	ID             int64          `db:"id"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+	OrganizationID int64          `db:"organization_id"`
+	Name           string         `db:"name"`
+	Tags           pq.StringArray `db:"tags"`
+	NamePattern    string         `db:"name_pattern"`
+	NotSeenHours   int            `db:"not_seen_hours"`
+}
+
+// Validate validates the data of the SavedNodeFilter.
+func (f SavedNodeFilter) Validate() error {
+	if !savedNodeFilterNameRegexp.MatchString(f.Name) {
+		return ErrNodeFilterInvalidName
+	}
+	return f.NodeFilter(0).Validate()
+}
+
+// NodeFilter returns the filter for the nodes of the given application.
+func (f SavedNodeFilter) NodeFilter(applicationID int64) NodeFilter {
+	return NodeFilter{
+		ApplicationID: applicationID,
+		Tags:          f.Tags,
+		NamePattern:   f.NamePattern,
+		NotSeenHours:  f.NotSeenHours,
+	}
+}
+
+// CreateSavedNodeFilter creates the given SavedNodeFilter.
+func CreateSavedNodeFilter(db sqlx.Queryer, f *SavedNodeFilter) error {
+	if err := f.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	err := sqlx.Get(db, &f.ID, `
+		insert into saved_node_filter (
+			created_at,
+			updated_at,
+			organization_id,
+			name,
+			tags,
+			name_pattern,
+			not_seen_hours
+		) values ($1, $2, $3, $4, $5, $6, $7)
+		returning id`,
+		now,
+		now,
+		f.OrganizationID,
+		f.Name,
+		nodeTags(f.Tags),
+		f.NamePattern,
+		f.NotSeenHours,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	f.CreatedAt = now
+	f.UpdatedAt = now
+	log.WithFields(log.Fields{
+		"id":              f.ID,
+		"organization_id": f.OrganizationID,
+		"name":            f.Name,
+	}).Info("saved node filter created")
+	return nil
+}
+
+// GetSavedNodeFilter returns the SavedNodeFilter for the given id.
+func GetSavedNodeFilter(db sqlx.Queryer, id int64) (SavedNodeFilter, error) {
+	var f SavedNodeFilter
+	err := sqlx.Get(db, &f, "select * from saved_node_filter where id = $1", id)
+	if err != nil {
+		return f, handlePSQLError(err, "select error")
+	}
+	return f, nil
+}
+
+// GetSavedNodeFilterCount returns the total number of saved node filters
+// of the given organization.
+func GetSavedNodeFilterCount(db sqlx.Queryer, organizationID int64) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select count(*)
+		from saved_node_filter
+		where organization_id = $1`,
+		organizationID,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+	return count, nil
+}
+
+// GetSavedNodeFilters returns the saved node filters of the given
+// organization, sorted by name.
+func GetSavedNodeFilters(db sqlx.Queryer, organizationID int64, limit, offset int) ([]SavedNodeFilter, error) {
+	var filters []SavedNodeFilter
+	err := sqlx.Select(db, &filters, `
+		select *
+		from saved_node_filter
+		where organization_id = $1
+		order by name
+		limit $2 offset $3`,
+		organizationID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	return filters, nil
+}
+
+// UpdateSavedNodeFilter updates the given SavedNodeFilter.
+func UpdateSavedNodeFilter(db sqlx.Execer, f *SavedNodeFilter) error {
+	if err := f.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	res, err := db.Exec(`
+		update saved_node_filter
+		set
+			updated_at = $2,
+			name = $3,
+			tags = $4,
+			name_pattern = $5,
+			not_seen_hours = $6
+		where id = $1`,
+		f.ID,
+		now,
+		f.Name,
+		nodeTags(f.Tags),
+		f.NamePattern,
+		f.NotSeenHours,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	f.UpdatedAt = now
+	log.WithField("id", f.ID).Info("saved node filter updated")
+	return nil
+}
+
+// DeleteSavedNodeFilter deletes the SavedNodeFilter matching the given id.
+func DeleteSavedNodeFilter(db sqlx.Execer, id int64) error {
+	res, err := db.Exec("delete from saved_node_filter where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("saved node filter deleted")
+	return nil
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lora-app-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestSavedNodeFilter(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with an organization and application", t, func() {
+		db, err := OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		org := Organization{
+			Name: "test-org",
+		}
+		So(CreateOrganization(db, &org), ShouldBeNil)
+		app := Application{
+			OrganizationID: org.ID,
+			Name:           "test",
+		}
+		So(CreateApplication(db, &app), ShouldBeNil)
+
+		Convey("When creating a saved node filter with an invalid name", func() {
+			err := CreateSavedNodeFilter(db, &SavedNodeFilter{
+				OrganizationID: org.ID,
+				Name:           "offline sensors",
+			})
+
+			Convey("Then a validation error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrNodeFilterInvalidName)
+			})
+		})
+
+		Convey("When creating a saved node filter", func() {
+			f := SavedNodeFilter{
+				OrganizationID: org.ID,
+				Name:           "offline-sensors",
+				Tags:           pq.StringArray{"outdoor"},
+				NamePattern:    "sensor-*",
+				NotSeenHours:   24,
+			}
+			So(CreateSavedNodeFilter(db, &f), ShouldBeNil)
+			f.CreatedAt = f.CreatedAt.UTC().Truncate(time.Millisecond)
+			f.UpdatedAt = f.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+			Convey("Then it can be retrieved", func() {
+				f2, err := GetSavedNodeFilter(db, f.ID)
+				So(err, ShouldBeNil)
+				f2.CreatedAt = f2.CreatedAt.UTC().Truncate(time.Millisecond)
+				f2.UpdatedAt = f2.UpdatedAt.UTC().Truncate(time.Millisecond)
+				So(f2, ShouldResemble, f)
+			})
+
+			Convey("Then it is returned by the organization filter list", func() {
+				count, err := GetSavedNodeFilterCount(db, org.ID)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				filters, err := GetSavedNodeFilters(db, org.ID, 10, 0)
+				So(err, ShouldBeNil)
+				So(filters, ShouldHaveLength, 1)
+				So(filters[0].ID, ShouldEqual, f.ID)
+			})
+
+			Convey("Given a node with a recent uplink and a node without uplinks", func() {
+				nodes := []Node{
+					{ApplicationID: app.ID, Name: "sensor-1", DevEUI: lorawan.EUI64{1}, Tags: pq.StringArray{"outdoor"}},
+					{ApplicationID: app.ID, Name: "sensor-2", DevEUI: lorawan.EUI64{2}, Tags: pq.StringArray{"outdoor"}},
+				}
+				for _, n := range nodes {
+					So(CreateNode(db, n), ShouldBeNil)
+				}
+				So(AddLinkQuality(db, LinkQuality{
+					DevEUI:  lorawan.EUI64{1},
+					Bucket:  time.Now().Truncate(time.Hour),
+					Uplinks: 1,
+				}), ShouldBeNil)
+
+				Convey("Then only the node without uplinks matches the filter", func() {
+					filter := f.NodeFilter(app.ID)
+
+					count, err := GetNodesCountForFilter(db, filter)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 1)
+
+					nodes, err := GetNodesForFilter(db, filter, 10, 0)
+					So(err, ShouldBeNil)
+					So(nodes, ShouldHaveLength, 1)
+					So(nodes[0].DevEUI, ShouldEqual, lorawan.EUI64{2})
+				})
+			})
+
+			Convey("When updating the filter", func() {
+				f.NotSeenHours = 0
+				f.Tags = nil
+				So(UpdateSavedNodeFilter(db, &f), ShouldBeNil)
+
+				Convey("Then the filter has been updated", func() {
+					f2, err := GetSavedNodeFilter(db, f.ID)
+					So(err, ShouldBeNil)
+					So(f2.NotSeenHours, ShouldEqual, 0)
+					So(f2.Tags, ShouldResemble, pq.StringArray{})
+				})
+			})
+
+			Convey("When deleting the filter", func() {
+				So(DeleteSavedNodeFilter(db, f.ID), ShouldBeNil)
+
+				Convey("Then it has been deleted", func() {
+					_, err := GetSavedNodeFilter(db, f.ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,114 @@
+// Package secretmigrate encrypts the credentials of the integrations which
+// were stored in plaintext, before these were stored encrypted (see the
+// secret package). As the encryption key is not available to the SQL
+// migrations, this is done on startup after the database migrations.
+package secretmigrate
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lora-app-server/internal/common"
+	"github.com/brocaar/lora-app-server/internal/handler"
+	"github.com/brocaar/lora-app-server/internal/secret"
+	"github.com/brocaar/lora-app-server/internal/storage"
+)
+
+// secretFields contains per integration kind the (JSON) fields of the
+// settings which are stored encrypted.
+var secretFields = []struct {
+	kind   string
+	fields []string
+}{
+	{handler.AMQPHandlerKind, []string{"url"}},
+	{handler.PostgreSQLHandlerKind, []string{"dsn"}},
+	{handler.AWSSNSHandlerKind, []string{"secretAccessKey"}},
+	{handler.AzureHandlerKind, []string{"connectionString"}},
+	{handler.GCPPubSubHandlerKind, []string{"credentialsFile"}},
+}
+
+// EncryptIntegrationSecrets encrypts the plaintext credentials of the
+// integrations. When no encryption key is configured, these are left as-is
+// (the handlers accept plaintext credentials) and a warning is logged.
+func EncryptIntegrationSecrets() error {
+	var count int
+
+	for _, sf := range secretFields {
+		integrations, err := storage.GetIntegrationsForKind(common.DB, sf.kind)
+		if err != nil {
+			return errors.Wrap(err, "get integrations error")
+		}
+
+		for _, intg := range integrations {
+			settings, changed, err := encryptSettings(intg.Settings, sf.fields)
+			if err == secret.ErrNoKey {
+				log.Warning("secretmigrate: integrations with plaintext credentials found, configure the integration-secret-key to encrypt these")
+				return nil
+			}
+			if err != nil {
+				return errors.Wrapf(err, "encrypt integration %d settings error", intg.ID)
+			}
+			if !changed {
+				continue
+			}
+
+			intg.Settings = settings
+			if err := storage.UpdateIntegration(common.DB, &intg); err != nil {
+				// the integration has been updated in the meantime (e.g. by
+				// an other instance), which also encrypts the credentials
+				if errors.Cause(err) == storage.ErrRevisionMismatch {
+					continue
+				}
+				return errors.Wrapf(err, "update integration %d error", intg.ID)
+			}
+			count++
+		}
+	}
+
+	if count != 0 {
+		log.WithField("count", count).Info("secretmigrate: integration credentials encrypted")
+	}
+
+	return nil
+}
+
+// encryptSettings encrypts the given fields of the (JSON encoded) settings
+// which are not yet encrypted. It returns false when none of the fields
+// needed to be encrypted.
+func encryptSettings(settings []byte, fields []string) ([]byte, bool, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(settings, &m); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal settings error")
+	}
+
+	var changed bool
+	for _, field := range fields {
+		var s string
+		if err := json.Unmarshal(m[field], &s); err != nil || s == "" || secret.Encrypted(s) {
+			continue
+		}
+
+		ciphertext, err := secret.Encrypt(s)
+		if err != nil {
+			return nil, false, err
+		}
+		b, err := json.Marshal(ciphertext)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "marshal ciphertext error")
+		}
+		m[field] = b
+		changed = true
+	}
+
+	if !changed {
+		return settings, false, nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "marshal settings error")
+	}
+	return b, true, nil
+}
@@ -445,7 +445,8 @@ func UpdatePassword(db *sqlx.DB, id int64, newpassword string) error {
 }
 
 // GetProfile returns the user profile (user, applications and organizations
-// to which the user is linked).
+// to which the user is linked). The organizations include the organizations
+// to which the user has an active elevation (as admin).
 func GetProfile(db *sqlx.DB, id int64) (UserProfile, error) {
 	var prof UserProfile
 
@@ -493,7 +494,23 @@ func GetProfile(db *sqlx.DB, id int64) (UserProfile, error) {
 			organization o
 		where
 			ou.user_id = $1
-			and ou.organization_id = o.id`,
+			and ou.organization_id = o.id
+		union all
+		select
+			e.organization_id as organization_id,
+			o.name as organization_name,
+			true as is_admin,
+			e.created_at as created_at,
+			e.created_at as updated_at
+		from
+			organization_elevation e,
+			organization o
+		where
+			e.user_id = $1
+			and e.organization_id = o.id
+			and e.revoked_at is null
+			and e.expires_at > now()
+		order by is_admin desc`,
 		id,
 	)
 	if err != nil {
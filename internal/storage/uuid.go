@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// UUID represents an (RFC 4122) UUID, used as external identifier of the
+// applications, organizations and integrations so that these can be
+// referenced without exposing the serial IDs.
+type UUID [16]byte
+
+// NewUUID returns a new random (version 4) UUID.
+func NewUUID() (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		return u, errors.Wrap(err, "read random bytes error")
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant RFC 4122
+	return u, nil
+}
+
+// ParseUUID parses the given UUID string (in its canonical
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form).
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// String implements fmt.Stringer.
+func (u UUID) String() string {
+	b, _ := u.MarshalText()
+	return string(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UUID) MarshalText() ([]byte, error) {
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return b, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(text []byte) error {
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return fmt.Errorf("invalid uuid: %s", text)
+	}
+
+	var b []byte
+	b = append(b, text[0:8]...)
+	b = append(b, text[9:13]...)
+	b = append(b, text[14:18]...)
+	b = append(b, text[19:23]...)
+	b = append(b, text[24:]...)
+	if _, err := hex.Decode(u[:], b); err != nil {
+		return fmt.Errorf("invalid uuid: %s", text)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (u *UUID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return u.UnmarshalText(v)
+	case string:
+		return u.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("unexpected type: %T", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUUID(t *testing.T) {
+	Convey("Given a new UUID", t, func() {
+		u, err := NewUUID()
+		So(err, ShouldBeNil)
+
+		Convey("Then it is a version 4 UUID", func() {
+			So(u[6]>>4, ShouldEqual, 4)
+			So(u[8]>>6, ShouldEqual, 2)
+		})
+
+		Convey("Then it can be parsed from its string representation", func() {
+			u2, err := ParseUUID(u.String())
+			So(err, ShouldBeNil)
+			So(u2, ShouldEqual, u)
+		})
+	})
+
+	Convey("Given a set of UUID strings", t, func() {
+		tests := []struct {
+			Text     string
+			Expected UUID
+			Valid    bool
+		}{
+			{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, true},
+			{"6BA7B810-9DAD-11D1-80B4-00C04FD430C8", UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, true},
+			{"6ba7b8109dad11d180b400c04fd430c8", UUID{}, false},
+			{"6ba7b810-9dad-11d1-80b4-00c04fd430cx", UUID{}, false},
+			{"6ba7b810-9dad-11d1-80b4", UUID{}, false},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Text, func() {
+				u, err := ParseUUID(test.Text)
+				if !test.Valid {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(u, ShouldEqual, test.Expected)
+				So(u.String(), ShouldEqual, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+			})
+		}
+	})
+}
@@ -0,0 +1,241 @@
+// Package syslog implements a RFC5424 syslog writer, supporting UDP, TCP
+// and TLS transports.
+package syslog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Network types.
+const (
+	UDP = "udp"
+	TCP = "tcp"
+	TLS = "tls"
+)
+
+const (
+	nilValue     = "-"
+	writeTimeout = 10 * time.Second
+)
+
+// Facility defines the syslog facility.
+type Facility int
+
+// Facilities.
+const (
+	Kern     Facility = 0
+	User     Facility = 1
+	Daemon   Facility = 3
+	Auth     Facility = 4
+	Security Facility = 13
+	Local0   Facility = 16
+)
+
+// Severity defines the syslog severity.
+type Severity int
+
+// Severities.
+const (
+	Emergency Severity = iota
+	Alert
+	Critical
+	Error
+	Warning
+	Notice
+	Informational
+	Debug
+)
+
+// SDElement defines a structured data element.
+type SDElement struct {
+	ID     string
+	Params []SDParam
+}
+
+// SDParam defines a structured data parameter.
+type SDParam struct {
+	Name  string
+	Value string
+}
+
+// Message defines a syslog message.
+type Message struct {
+	Facility       Facility
+	Severity       Severity
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData []SDElement
+	Message        string
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, formatting
+// the message according to RFC5424.
+func (m Message) MarshalText() ([]byte, error) {
+	var b bytes.Buffer
+
+	ts := nilValue
+	if !m.Timestamp.IsZero() {
+		ts = m.Timestamp.UTC().Format("2006-01-02T15:04:05.000000Z")
+	}
+
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s ",
+		int(m.Facility)*8+int(m.Severity),
+		ts,
+		headerField(m.Hostname, 255),
+		headerField(m.AppName, 48),
+		headerField(m.ProcID, 128),
+		headerField(m.MsgID, 32),
+	)
+
+	if len(m.StructuredData) == 0 {
+		b.WriteString(nilValue)
+	}
+	for _, e := range m.StructuredData {
+		b.WriteString("[")
+		b.WriteString(sdName(e.ID))
+		for _, p := range e.Params {
+			fmt.Fprintf(&b, ` %s="%s"`, sdName(p.Name), sdParamValueReplacer.Replace(p.Value))
+		}
+		b.WriteString("]")
+	}
+
+	if m.Message != "" {
+		b.WriteString(" ")
+		b.WriteString(m.Message)
+	}
+
+	return b.Bytes(), nil
+}
+
+// Writer implements a syslog writer. In case of a write error, the
+// connection is re-established on the next write.
+type Writer struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewWriter creates a new Writer for the given network (udp, tcp or tls)
+// and address (host:port). The tlsConfig is only used for the tls network.
+func NewWriter(network, address string, tlsConfig *tls.Config) (*Writer, error) {
+	switch network {
+	case UDP, TCP, TLS:
+	default:
+		return nil, fmt.Errorf("invalid syslog network: %s", network)
+	}
+
+	return &Writer{
+		network:   network,
+		address:   address,
+		tlsConfig: tlsConfig,
+	}, nil
+}
+
+// Write writes the given message. When the message hostname is not set,
+// the hostname of the machine is used. For TCP and TLS, the messages are
+// framed using octet counting (RFC6587).
+func (w *Writer) Write(m Message) error {
+	if m.Hostname == "" {
+		m.Hostname, _ = os.Hostname()
+	}
+
+	b, err := m.MarshalText()
+	if err != nil {
+		return errors.Wrap(err, "marshal message error")
+	}
+	if w.network != UDP {
+		b = append([]byte(fmt.Sprintf("%d ", len(b))), b...)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		if err := w.connect(); err != nil {
+			return err
+		}
+	}
+
+	w.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := w.conn.Write(b); err != nil {
+		w.conn.Close()
+		w.conn = nil
+		return errors.Wrap(err, "write error")
+	}
+
+	return nil
+}
+
+// Close closes the underlying connection.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+func (w *Writer) connect() error {
+	var err error
+	if w.network == TLS {
+		w.conn, err = tls.DialWithDialer(&net.Dialer{Timeout: writeTimeout}, "tcp", w.address, w.tlsConfig)
+	} else {
+		w.conn, err = net.DialTimeout(w.network, w.address, writeTimeout)
+	}
+	if err != nil {
+		w.conn = nil
+		return errors.Wrap(err, "dial error")
+	}
+	return nil
+}
+
+var sdParamValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// headerField returns the given value as header field, containing only
+// printable US-ASCII characters (without spaces).
+func headerField(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if s == "" {
+		return nilValue
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
+
+// sdName returns the given value as SD-NAME, removing the characters that
+// are not allowed.
+func sdName(s string) string {
+	return headerField(strings.Map(func(r rune) rune {
+		switch r {
+		case '=', ' ', ']', '"':
+			return -1
+		}
+		return r
+	}, s), 32)
+}
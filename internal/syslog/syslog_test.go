@@ -0,0 +1,115 @@
+package syslog
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMessage(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		ts := time.Date(2017, 10, 11, 22, 14, 15, 3000, time.UTC)
+
+		tests := []struct {
+			Name     string
+			Message  Message
+			Expected string
+		}{
+			{
+				Name: "message without structured data",
+				Message: Message{
+					Facility:  Security,
+					Severity:  Warning,
+					Timestamp: ts,
+					Hostname:  "host.example.com",
+					AppName:   "lora-app-server",
+					MsgID:     "MIC_FAILURE",
+					Message:   "invalid MIC",
+				},
+				Expected: "<108>1 2017-10-11T22:14:15.000003Z host.example.com lora-app-server - MIC_FAILURE - invalid MIC",
+			},
+			{
+				Name: "message with structured data to be escaped",
+				Message: Message{
+					Facility: Local0,
+					Severity: Informational,
+					AppName:  "lora app server",
+					StructuredData: []SDElement{
+						{ID: "event@32473", Params: []SDParam{
+							{Name: "devEUI", Value: "0102030405060708"},
+							{Name: "data", Value: `a"b\c]`},
+						}},
+					},
+				},
+				Expected: `<134>1 - - loraappserver - - [event@32473 devEUI="0102030405060708" data="a\"b\\c\]"]`,
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				b, err := test.Message.MarshalText()
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestWriter(t *testing.T) {
+	Convey("Given a UDP listener and a UDP writer", t, func() {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer conn.Close()
+
+		w, err := NewWriter(UDP, conn.LocalAddr().String(), nil)
+		So(err, ShouldBeNil)
+		defer w.Close()
+
+		Convey("When writing a message", func() {
+			So(w.Write(Message{Hostname: "test", Message: "hello"}), ShouldBeNil)
+
+			Convey("Then the message is received as a single datagram", func() {
+				b := make([]byte, 1024)
+				n, _, err := conn.ReadFrom(b)
+				So(err, ShouldBeNil)
+				So(string(b[:n]), ShouldEqual, "<0>1 - test - - - - hello")
+			})
+		})
+	})
+
+	Convey("Given a TCP listener and a TCP writer", t, func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer ln.Close()
+
+		w, err := NewWriter(TCP, ln.Addr().String(), nil)
+		So(err, ShouldBeNil)
+		defer w.Close()
+
+		Convey("When writing a message", func() {
+			So(w.Write(Message{Hostname: "test", Message: "hello"}), ShouldBeNil)
+
+			Convey("Then the message is received with octet-counting framing", func() {
+				conn, err := ln.Accept()
+				So(err, ShouldBeNil)
+				defer conn.Close()
+
+				b := make([]byte, 28)
+				_, err = io.ReadFull(conn, b)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "25 <0>1 - test - - - - hello")
+			})
+		})
+	})
+
+	Convey("Given an invalid network", t, func() {
+		_, err := NewWriter("foo", "127.0.0.1:514", nil)
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -4,20 +4,28 @@ import "github.com/brocaar/lora-app-server/internal/handler"
 
 // TestHandler implements a Handler for testing.
 type TestHandler struct {
-	SendDataUpChan            chan handler.DataUpPayload
-	SendJoinNotificationChan  chan handler.JoinNotification
-	SendACKNotificationChan   chan handler.ACKNotification
-	SendErrorNotificationChan chan handler.ErrorNotification
-	DataDownPayloadChan       chan handler.DataDownPayload
+	SendDataUpChan               chan handler.DataUpPayload
+	SendJoinNotificationChan     chan handler.JoinNotification
+	SendACKNotificationChan      chan handler.ACKNotification
+	SendErrorNotificationChan    chan handler.ErrorNotification
+	SendSecurityNotificationChan chan handler.SecurityNotification
+	SendProprietaryUpChan        chan handler.ProprietaryUpPayload
+	SendGatewayNotificationChan  chan handler.GatewayNotification
+	SendCustomEventChan          chan handler.CustomEvent
+	DataDownPayloadChan          chan handler.DataDownPayload
 }
 
 func NewTestHandler() *TestHandler {
 	return &TestHandler{
-		SendDataUpChan:            make(chan handler.DataUpPayload, 100),
-		SendJoinNotificationChan:  make(chan handler.JoinNotification, 100),
-		SendACKNotificationChan:   make(chan handler.ACKNotification, 100),
-		SendErrorNotificationChan: make(chan handler.ErrorNotification, 100),
-		DataDownPayloadChan:       make(chan handler.DataDownPayload, 100),
+		SendDataUpChan:               make(chan handler.DataUpPayload, 100),
+		SendJoinNotificationChan:     make(chan handler.JoinNotification, 100),
+		SendACKNotificationChan:      make(chan handler.ACKNotification, 100),
+		SendErrorNotificationChan:    make(chan handler.ErrorNotification, 100),
+		SendSecurityNotificationChan: make(chan handler.SecurityNotification, 100),
+		SendProprietaryUpChan:        make(chan handler.ProprietaryUpPayload, 100),
+		SendGatewayNotificationChan:  make(chan handler.GatewayNotification, 100),
+		SendCustomEventChan:          make(chan handler.CustomEvent, 100),
+		DataDownPayloadChan:          make(chan handler.DataDownPayload, 100),
 	}
 }
 
@@ -45,6 +53,26 @@ func (t *TestHandler) SendErrorNotification(payload handler.ErrorNotification) e
 	return nil
 }
 
+func (t *TestHandler) SendSecurityNotification(payload handler.SecurityNotification) error {
+	t.SendSecurityNotificationChan <- payload
+	return nil
+}
+
+func (t *TestHandler) SendProprietaryUp(payload handler.ProprietaryUpPayload) error {
+	t.SendProprietaryUpChan <- payload
+	return nil
+}
+
+func (t *TestHandler) SendGatewayNotification(payload handler.GatewayNotification) error {
+	t.SendGatewayNotificationChan <- payload
+	return nil
+}
+
+func (t *TestHandler) SendCustomEvent(payload handler.CustomEvent) error {
+	t.SendCustomEventChan <- payload
+	return nil
+}
+
 func (t *TestHandler) DataDownChan() chan handler.DataDownPayload {
 	return t.DataDownPayloadChan
 }
@@ -0,0 +1,222 @@
+// Package webhooksign implements the asymmetric signing of the webhook
+// payloads. Each payload is signed using a JSON Web Signature (JWS) with
+// detached payload (RFC7515, appendix F) and the public keys are published
+// as JSON Web Key Set (JWKS), so that receivers can verify the authenticity
+// of the payloads without a shared secret.
+//
+// Multiple keys can be configured for key rotation: the first key is used
+// for signing, all keys are published in the key set.
+package webhooksign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sync"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+// Header defines the HTTP header containing the signature.
+const Header = "X-LoRa-Signature"
+
+// Key contains a signing key.
+type Key struct {
+	ID        string
+	Algorithm string
+
+	method     jwt.SigningMethod
+	privateKey interface{}
+	jwk        JWK
+}
+
+// JWK contains the public part of a key as JSON Web Key (RFC7517).
+type JWK struct {
+	KTY string `json:"kty"`
+	KID string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC keys
+	CRV string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS contains a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	mu   sync.RWMutex
+	keys []Key
+)
+
+// LoadKeys loads the PEM encoded private keys from the given files and
+// sets them as signing keys. The first key is used for signing.
+func LoadKeys(files []string) error {
+	var ks []Key
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return errors.Wrap(err, "read key file error")
+		}
+		k, err := ParseKey(b)
+		if err != nil {
+			return errors.Wrapf(err, "parse key %s error", f)
+		}
+		ks = append(ks, k)
+	}
+	SetKeys(ks)
+	return nil
+}
+
+// SetKeys sets the signing keys. The first key is used for signing. When
+// no keys are set, the payloads are not signed.
+func SetKeys(ks []Key) {
+	mu.Lock()
+	defer mu.Unlock()
+	keys = ks
+}
+
+// ParseKey parses the given PEM encoded RSA (RS256) or P-256 EC (ES256)
+// private key.
+func ParseKey(b []byte) (Key, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return Key{}, errors.New("no pem data found")
+	}
+
+	var privateKey interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("unsupported pem type: %s", block.Type)
+	}
+	if err != nil {
+		return Key{}, errors.Wrap(err, "parse private key error")
+	}
+
+	var k Key
+	switch pk := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if pk.N.BitLen() < 2048 {
+			return Key{}, errors.New("rsa key must be at least 2048 bits")
+		}
+		k = Key{
+			Algorithm: "RS256",
+			method:    jwt.SigningMethodRS256,
+			jwk: JWK{
+				KTY: "RSA",
+				N:   encodeSegment(pk.N.Bytes()),
+				E:   encodeSegment(big.NewInt(int64(pk.E)).Bytes()),
+			},
+		}
+	case *ecdsa.PrivateKey:
+		if pk.Curve != elliptic.P256() {
+			return Key{}, errors.New("only P-256 ec keys are supported")
+		}
+		k = Key{
+			Algorithm: "ES256",
+			method:    jwt.SigningMethodES256,
+			jwk: JWK{
+				KTY: "EC",
+				CRV: "P-256",
+				X:   encodeSegment(padBytes(pk.X.Bytes(), 32)),
+				Y:   encodeSegment(padBytes(pk.Y.Bytes(), 32)),
+			},
+		}
+	default:
+		return Key{}, fmt.Errorf("unsupported key type: %T", privateKey)
+	}
+
+	k.privateKey = privateKey
+	k.ID = thumbprint(k.jwk)
+	k.jwk.KID = k.ID
+	k.jwk.Use = "sig"
+	k.jwk.Alg = k.Algorithm
+	return k, nil
+}
+
+// Sign returns the JWS with detached payload (header..signature) of the
+// given payload, signed with the first key. It returns an empty string when
+// no keys are configured.
+func Sign(payload []byte) (string, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if len(keys) == 0 {
+		return "", nil
+	}
+	k := keys[0]
+
+	header, err := json.Marshal(map[string]string{
+		"alg": k.Algorithm,
+		"kid": k.ID,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal header error")
+	}
+
+	h := encodeSegment(header)
+	sig, err := k.method.Sign(h+"."+encodeSegment(payload), k.privateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "sign error")
+	}
+	return h + ".." + sig, nil
+}
+
+// GetJWKS returns the public keys as JSON Web Key Set.
+func GetJWKS() JWKS {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	out := JWKS{Keys: []JWK{}}
+	for _, k := range keys {
+		out.Keys = append(out.Keys, k.jwk)
+	}
+	return out
+}
+
+// thumbprint returns the JWK thumbprint (RFC7638) of the given key.
+func thumbprint(jwk JWK) string {
+	var s string
+	if jwk.KTY == "RSA" {
+		s = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	} else {
+		s = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, jwk.CRV, jwk.X, jwk.Y)
+	}
+	sum := sha256.Sum256([]byte(s))
+	return encodeSegment(sum[:])
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
@@ -0,0 +1,125 @@
+package webhooksign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestThumbprint(t *testing.T) {
+	Convey("Given the RSA key of the RFC7638 example", t, func() {
+		jwk := JWK{
+			KTY: "RSA",
+			N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+			E:   "AQAB",
+		}
+
+		Convey("Then the thumbprint matches the RFC7638 example", func() {
+			So(thumbprint(jwk), ShouldEqual, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs")
+		})
+	})
+}
+
+func TestSign(t *testing.T) {
+	Convey("Given a PEM encoded RSA and EC key", t, func() {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		rsaPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		So(err, ShouldBeNil)
+		ecDER, err := x509.MarshalECPrivateKey(ecKey)
+		So(err, ShouldBeNil)
+		ecPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})
+
+		Convey("When no keys are set", func() {
+			SetKeys(nil)
+
+			Convey("Then the payload is not signed", func() {
+				sig, err := Sign([]byte("{}"))
+				So(err, ShouldBeNil)
+				So(sig, ShouldEqual, "")
+				So(GetJWKS().Keys, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When setting the RSA and EC key", func() {
+			k1, err := ParseKey(rsaPEM)
+			So(err, ShouldBeNil)
+			So(k1.Algorithm, ShouldEqual, "RS256")
+			k2, err := ParseKey(ecPEM)
+			So(err, ShouldBeNil)
+			So(k2.Algorithm, ShouldEqual, "ES256")
+			SetKeys([]Key{k1, k2})
+			defer SetKeys(nil)
+
+			Convey("Then both keys are published", func() {
+				jwks := GetJWKS()
+				So(jwks.Keys, ShouldHaveLength, 2)
+				So(jwks.Keys[0].KID, ShouldEqual, k1.ID)
+				So(jwks.Keys[0].KTY, ShouldEqual, "RSA")
+				So(jwks.Keys[1].KID, ShouldEqual, k2.ID)
+				So(jwks.Keys[1].CRV, ShouldEqual, "P-256")
+
+				n, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0].N)
+				So(err, ShouldBeNil)
+				So(new(big.Int).SetBytes(n).Cmp(rsaKey.N), ShouldEqual, 0)
+			})
+
+			Convey("Then the payload is signed with the first key", func() {
+				payload := []byte(`{"applicationID":"1"}`)
+				sig, err := Sign(payload)
+				So(err, ShouldBeNil)
+
+				parts := strings.Split(sig, ".")
+				So(parts, ShouldHaveLength, 3)
+				So(parts[1], ShouldEqual, "")
+
+				header, err := base64.RawURLEncoding.DecodeString(parts[0])
+				So(err, ShouldBeNil)
+				So(string(header), ShouldEqual, `{"alg":"RS256","kid":"`+k1.ID+`"}`)
+
+				signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+				So(jwt.SigningMethodRS256.Verify(signingInput, parts[2], &rsaKey.PublicKey), ShouldBeNil)
+				So(jwt.SigningMethodRS256.Verify(signingInput+"x", parts[2], &rsaKey.PublicKey), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When setting the EC key", func() {
+			k, err := ParseKey(ecPEM)
+			So(err, ShouldBeNil)
+			SetKeys([]Key{k})
+			defer SetKeys(nil)
+
+			Convey("Then the payload is signed using ES256", func() {
+				payload := []byte(`{}`)
+				sig, err := Sign(payload)
+				So(err, ShouldBeNil)
+
+				parts := strings.Split(sig, ".")
+				signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+				So(jwt.SigningMethodES256.Verify(signingInput, parts[2], &ecKey.PublicKey), ShouldBeNil)
+			})
+		})
+
+		Convey("When parsing a too small RSA key", func() {
+			smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+			So(err, ShouldBeNil)
+			_, err = ParseKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(smallKey)}))
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,19 @@
+-- +migrate Up
+alter table node
+	add column revision bigint not null default 0;
+
+alter table application
+	add column revision bigint not null default 0;
+
+alter table integration
+	add column revision bigint not null default 0;
+
+-- +migrate Down
+alter table integration
+	drop column revision;
+
+alter table application
+	drop column revision;
+
+alter table node
+	drop column revision;
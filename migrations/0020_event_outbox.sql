@@ -0,0 +1,15 @@
+-- +migrate Up
+create table event_outbox (
+	id bigserial primary key,
+	created_at timestamp with time zone not null,
+	type varchar(20) not null,
+	payload jsonb not null,
+	attempts integer not null default 0,
+	next_attempt_at timestamp with time zone not null
+);
+
+create index idx_event_outbox_next_attempt_at on event_outbox(next_attempt_at);
+
+-- +migrate Down
+drop index idx_event_outbox_next_attempt_at;
+drop table event_outbox;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table event_outbox
+	add column organization_id bigint not null default 0;
+
+create index idx_event_outbox_organization_id_next_attempt_at on event_outbox(organization_id, next_attempt_at);
+
+-- +migrate Down
+drop index idx_event_outbox_organization_id_next_attempt_at;
+
+alter table event_outbox
+	drop column organization_id;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table application
+	add column environment varchar(20) not null default '';
+
+create index idx_application_environment on application(environment);
+
+-- +migrate Down
+drop index idx_application_environment;
+
+alter table application
+	drop column environment;
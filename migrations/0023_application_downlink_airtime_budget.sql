@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table application
+	add column downlink_airtime_budget integer not null default 0,
+	add column downlink_airtime_budget_enforce boolean not null default false;
+
+-- +migrate Down
+alter table application
+	drop column downlink_airtime_budget_enforce,
+	drop column downlink_airtime_budget;
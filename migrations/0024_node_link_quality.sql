@@ -0,0 +1,17 @@
+-- +migrate Up
+create table node_link_quality (
+	dev_eui bytea not null references node on delete cascade,
+	bucket timestamp with time zone not null,
+	uplinks integer not null default 0,
+	missed integer not null default 0,
+	retransmissions integer not null default 0,
+	snr_margin_sum double precision not null default 0,
+
+	primary key (dev_eui, bucket)
+);
+
+create index idx_node_link_quality_bucket on node_link_quality(bucket);
+
+-- +migrate Down
+drop index idx_node_link_quality_bucket;
+drop table node_link_quality;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table application
+	add column proprietary_payload_prefix bytea;
+
+create unique index idx_application_proprietary_payload_prefix on application(proprietary_payload_prefix) where length(proprietary_payload_prefix) > 0;
+
+-- +migrate Down
+drop index idx_application_proprietary_payload_prefix;
+
+alter table application
+	drop column proprietary_payload_prefix;
@@ -0,0 +1,10 @@
+-- +migrate Up
+create table application_gateway_filter (
+	application_id bigint primary key references application on delete cascade,
+	updated_at timestamp with time zone not null,
+	mode varchar(5) not null,
+	gateway_macs bytea[] not null default '{}'
+);
+
+-- +migrate Down
+drop table application_gateway_filter;
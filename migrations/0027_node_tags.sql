@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table node
+	add column tags text[] not null default '{}';
+
+create index idx_node_tags on node using gin (tags);
+
+-- +migrate Down
+drop index idx_node_tags;
+
+alter table node
+	drop column tags;
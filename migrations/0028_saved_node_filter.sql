@@ -0,0 +1,19 @@
+-- +migrate Up
+create table saved_node_filter (
+	id bigserial primary key,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	organization_id bigint not null references organization on delete cascade,
+	name varchar(100) not null,
+	tags text[] not null default '{}',
+	name_pattern varchar(100) not null default '',
+	not_seen_hours integer not null default 0,
+
+	unique (organization_id, name)
+);
+
+create index idx_saved_node_filter_organization_id on saved_node_filter(organization_id);
+
+-- +migrate Down
+drop index idx_saved_node_filter_organization_id;
+drop table saved_node_filter;
@@ -0,0 +1,17 @@
+-- +migrate Up
+alter table application
+	add column maintenance_mode boolean not null default false,
+	add column maintenance_until timestamp with time zone;
+
+alter table node
+	add column maintenance_mode boolean not null default false,
+	add column maintenance_until timestamp with time zone;
+
+-- +migrate Down
+alter table node
+	drop column maintenance_until,
+	drop column maintenance_mode;
+
+alter table application
+	drop column maintenance_until,
+	drop column maintenance_mode;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table node
+	add column disabled boolean not null default false;
+
+-- +migrate Down
+alter table node
+	drop column disabled;
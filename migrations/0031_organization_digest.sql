@@ -0,0 +1,13 @@
+-- +migrate Up
+create table organization_digest (
+	organization_id bigint primary key references organization on delete cascade,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	frequency varchar(10) not null,
+	webhook_url text not null,
+	template text not null default '',
+	last_sent_at timestamp with time zone
+);
+
+-- +migrate Down
+drop table organization_digest;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table integration
+	add column chaos_failure_rate integer not null default 0,
+	add column chaos_latency integer not null default 0,
+	add column chaos_until timestamp with time zone;
+
+-- +migrate Down
+alter table integration
+	drop column chaos_until,
+	drop column chaos_latency,
+	drop column chaos_failure_rate;
@@ -0,0 +1,13 @@
+-- +migrate Up
+create table node_alias (
+	dev_eui bytea primary key references node on delete cascade,
+	organization_id bigint not null references organization on delete cascade,
+	alias varchar(100) not null
+);
+
+create unique index idx_node_alias_organization_id_alias on node_alias(organization_id, alias);
+
+-- +migrate Down
+drop index idx_node_alias_organization_id_alias;
+
+drop table node_alias;
@@ -0,0 +1,15 @@
+-- +migrate Up
+create table application_mqtt_credentials (
+	application_id bigint primary key references application on delete cascade,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	username varchar(100) not null,
+	password_hash varchar(200) not null
+);
+
+create unique index idx_application_mqtt_credentials_username on application_mqtt_credentials(username);
+
+-- +migrate Down
+drop index idx_application_mqtt_credentials_username;
+
+drop table application_mqtt_credentials;
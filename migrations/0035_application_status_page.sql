@@ -0,0 +1,14 @@
+-- +migrate Up
+create table application_status_page (
+	application_id bigint primary key references application on delete cascade,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	token varchar(100) not null
+);
+
+create unique index idx_application_status_page_token on application_status_page(token);
+
+-- +migrate Down
+drop index idx_application_status_page_token;
+
+drop table application_status_page;
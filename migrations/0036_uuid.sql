@@ -0,0 +1,46 @@
+-- +migrate Up
+alter table organization
+	add column uuid uuid;
+
+alter table application
+	add column uuid uuid;
+
+alter table integration
+	add column uuid uuid;
+
+-- generate random (version 4) uuids for the existing records
+update organization
+set uuid = overlay(overlay(md5(random()::text || clock_timestamp()::text || id::text) placing '4' from 13) placing '8' from 17)::uuid;
+
+update application
+set uuid = overlay(overlay(md5(random()::text || clock_timestamp()::text || id::text) placing '4' from 13) placing '8' from 17)::uuid;
+
+update integration
+set uuid = overlay(overlay(md5(random()::text || clock_timestamp()::text || id::text) placing '4' from 13) placing '8' from 17)::uuid;
+
+alter table organization
+	alter column uuid set not null;
+
+alter table application
+	alter column uuid set not null;
+
+alter table integration
+	alter column uuid set not null;
+
+create unique index idx_organization_uuid on organization(uuid);
+create unique index idx_application_uuid on application(uuid);
+create unique index idx_integration_uuid on integration(uuid);
+
+-- +migrate Down
+drop index idx_integration_uuid;
+drop index idx_application_uuid;
+drop index idx_organization_uuid;
+
+alter table integration
+	drop column uuid;
+
+alter table application
+	drop column uuid;
+
+alter table organization
+	drop column uuid;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table node
+	add column variables jsonb not null default '{}';
+
+-- +migrate Down
+alter table node
+	drop column variables;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table node
+	add column retired_at timestamp with time zone;
+
+-- +migrate Down
+alter table node
+	drop column retired_at;
@@ -0,0 +1,42 @@
+-- +migrate Up
+create table organization_elevation (
+	id bigserial primary key,
+	created_at timestamp with time zone not null,
+	organization_id bigint not null references organization on delete cascade,
+	user_id bigint not null references "user" on delete cascade,
+	granted_by varchar(100) not null,
+	justification text not null,
+	expires_at timestamp with time zone not null,
+	revoked_at timestamp with time zone,
+	revoked_by varchar(100)
+);
+
+create index idx_organization_elevation_organization_id on organization_elevation(organization_id);
+create index idx_organization_elevation_user_id on organization_elevation(user_id);
+
+-- organization_member contains the organization users, including the users
+-- with an active (not expired or revoked) elevation as organization admin.
+create view organization_member as
+	select
+		user_id,
+		organization_id,
+		bool_or(is_admin) as is_admin
+	from (
+		select user_id, organization_id, is_admin
+		from organization_user
+		union all
+		select user_id, organization_id, true
+		from organization_elevation
+		where
+			revoked_at is null
+			and expires_at > now()
+	) m
+	group by user_id, organization_id;
+
+-- +migrate Down
+drop view organization_member;
+
+drop index idx_organization_elevation_user_id;
+drop index idx_organization_elevation_organization_id;
+
+drop table organization_elevation;
@@ -0,0 +1,17 @@
+-- +migrate Up
+create table event_dead_letter (
+	id bigserial primary key,
+	organization_id bigint not null default 0,
+	created_at timestamp with time zone not null,
+	failed_at timestamp with time zone not null,
+	type varchar(20) not null,
+	payload jsonb not null,
+	attempts integer not null,
+	error text not null
+);
+
+create index idx_event_dead_letter_organization_id on event_dead_letter(organization_id);
+
+-- +migrate Down
+drop index idx_event_dead_letter_organization_id;
+drop table event_dead_letter;
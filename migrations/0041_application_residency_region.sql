@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table application
+	add column residency_region varchar(32) not null default '';
+
+-- +migrate Down
+alter table application
+	drop column residency_region;
@@ -0,0 +1,18 @@
+-- +migrate Up
+create table node_integration (
+	id bigserial primary key,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	dev_eui bytea not null references node on delete cascade,
+	kind character varying (20) not null,
+	settings jsonb,
+	revision bigint not null default 0,
+
+	constraint node_integration_kind_dev_eui unique (kind, dev_eui)
+);
+
+create index idx_node_integration_dev_eui on node_integration(dev_eui);
+
+-- +migrate Down
+drop index idx_node_integration_dev_eui;
+drop table node_integration;
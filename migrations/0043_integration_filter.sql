@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table integration
+	add column filter_event_types text[],
+	add column filter_f_ports integer[],
+	add column filter_tags text[];
+
+-- +migrate Down
+alter table integration
+	drop column filter_tags,
+	drop column filter_f_ports,
+	drop column filter_event_types;
@@ -0,0 +1,20 @@
+-- +migrate Up
+create table downlink_template (
+	id bigserial primary key,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	application_id bigint not null references application on delete cascade,
+	name character varying (100) not null,
+	description text not null default '',
+	f_port smallint not null,
+	confirmed boolean not null default false,
+	fields jsonb not null,
+
+	constraint downlink_template_application_id_name unique (application_id, name)
+);
+
+create index idx_downlink_template_application_id on downlink_template(application_id);
+
+-- +migrate Down
+drop index idx_downlink_template_application_id;
+drop table downlink_template;
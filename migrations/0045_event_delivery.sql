@@ -0,0 +1,17 @@
+-- +migrate Up
+alter table event_outbox
+	add column target varchar(100) not null default '',
+	add column delivered text[] not null default '{}';
+
+alter table event_dead_letter
+	add column target varchar(100) not null default '',
+	add column delivered text[] not null default '{}';
+
+-- +migrate Down
+alter table event_dead_letter
+	drop column delivered,
+	drop column target;
+
+alter table event_outbox
+	drop column delivered,
+	drop column target;
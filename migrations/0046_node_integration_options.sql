@@ -0,0 +1,30 @@
+-- +migrate Up
+alter table node_integration
+	add column uuid uuid,
+	add column chaos_failure_rate integer not null default 0,
+	add column chaos_latency integer not null default 0,
+	add column chaos_until timestamp with time zone,
+	add column filter_event_types text[],
+	add column filter_f_ports integer[],
+	add column filter_tags text[];
+
+-- generate random (version 4) uuids for the existing records
+update node_integration
+set uuid = overlay(overlay(md5(random()::text || clock_timestamp()::text || id::text) placing '4' from 13) placing '8' from 17)::uuid;
+
+alter table node_integration
+	alter column uuid set not null;
+
+create unique index idx_node_integration_uuid on node_integration(uuid);
+
+-- +migrate Down
+drop index idx_node_integration_uuid;
+
+alter table node_integration
+	drop column filter_tags,
+	drop column filter_f_ports,
+	drop column filter_event_types,
+	drop column chaos_until,
+	drop column chaos_latency,
+	drop column chaos_failure_rate,
+	drop column uuid;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table application
+	add column mqtt_buffer_ttl integer not null default 0;
+
+-- +migrate Down
+alter table application
+	drop column mqtt_buffer_ttl;
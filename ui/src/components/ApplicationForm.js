@@ -149,6 +149,32 @@ class ApplicationForm extends Component {
               <label className="control-label" htmlFor="name">Application description</label>
               <input className="form-control" id="description" type="text" placeholder="a short description of your application" required value={this.state.application.description || ''} onChange={this.onChange.bind(this, 'description')} />
             </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="environment">Environment</label>
+              <select className="form-control" id="environment" value={this.state.application.environment || ''} onChange={this.onChange.bind(this, 'environment')}>
+                <option value="">none</option>
+                <option value="development">development</option>
+                <option value="staging">staging</option>
+                <option value="production">production</option>
+              </select>
+              <p className="help-block">
+                The environment is included in all events sent by this application.
+              </p>
+            </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="residencyRegion">Residency region</label>
+              <input className="form-control" id="residencyRegion" type="text" placeholder="e.g. eu" pattern="[a-z0-9\-]{0,32}" value={this.state.application.residencyRegion || ''} onChange={this.onChange.bind(this, 'residencyRegion')} />
+              <p className="help-block">
+                When set, the event history of this application is stored in the database of this region. The region must be configured on the server.
+              </p>
+            </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="mqttBufferTTL">MQTT buffer TTL (seconds)</label>
+              <input className="form-control" id="mqttBufferTTL" type="number" min="0" max="604800" placeholder="server default" value={this.state.application.mqttBufferTTL || ''} onChange={this.onChange.bind(this, 'mqttBufferTTL')} />
+              <p className="help-block">
+                Time the events which could not be published to the MQTT broker are buffered, when buffering is enabled on the server.
+              </p>
+            </div>
             <div className={"form-group " + (this.state.isGlobalAdmin && this.props.update ? '' : 'hidden')}>
               <label className="control-label" htmlFor="organization">Organization</label>
               <Select.Async
@@ -247,6 +273,31 @@ class ApplicationForm extends Component {
                 5dB is the default recommended value.
               </p>
             </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="downlinkAirtimeBudget">Downlink airtime budget (ms per hour)</label>
+              <input className="form-control" id="downlinkAirtimeBudget" type="number" min="0" value={this.state.application.downlinkAirtimeBudget || 0} onChange={this.onChange.bind(this, 'downlinkAirtimeBudget')} />
+              <p className="help-block">
+                The downlink airtime budget per node per hour (0 = no budget).
+                The airtime of each downlink payload is estimated based on the data-rate of the last uplink of the node.
+              </p>
+            </div>
+            <div className="form-group">
+              <label className="control-label">Enforce downlink airtime budget</label>
+              <div className="checkbox">
+                <label>
+                  <input type="checkbox" name="downlinkAirtimeBudgetEnforce" id="downlinkAirtimeBudgetEnforce" checked={!!this.state.application.downlinkAirtimeBudgetEnforce} onChange={this.onChange.bind(this, 'downlinkAirtimeBudgetEnforce')} /> Enforce budget
+                </label>
+              </div>
+              <p className="help-block">When checked, downlink payloads exceeding the budget are rejected. Otherwise a warning is logged.</p>
+            </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="proprietaryPayloadPrefix">Proprietary payload prefix</label>
+              <input className="form-control" id="proprietaryPayloadPrefix" type="text" placeholder="e.g. a001" pattern="([0-9a-fA-F]{2}){0,16}" value={this.state.application.proprietaryPayloadPrefix || ''} onChange={this.onChange.bind(this, 'proprietaryPayloadPrefix')} />
+              <p className="help-block">
+                Proprietary (FType 7) uplink frames of which the MAC payload starts with this (hex encoded) prefix are sent to the integrations of this application.
+                Leave empty to disable.
+              </p>
+            </div>
           </div>
           <hr />
           <div className="btn-toolbar pull-right">
@@ -0,0 +1,75 @@
+import React, { Component } from 'react';
+
+class GatewayFilterForm extends Component {
+  static contextTypes = {
+    router: React.PropTypes.object.isRequired
+  };
+
+  constructor() {
+    super();
+
+    this.state = {
+      filter: {},
+    };
+
+    this.handleSubmit = this.handleSubmit.bind(this);
+    this.onGatewayMACsChange = this.onGatewayMACsChange.bind(this);
+  }
+
+  componentWillReceiveProps(nextProps) {
+    this.setState({
+      filter: nextProps.filter,
+    });
+  }
+
+  onChange(field, e) {
+    let filter = this.state.filter;
+    filter[field] = e.target.value;
+    this.setState({
+      filter: filter,
+    });
+  }
+
+  onGatewayMACsChange(e) {
+    let filter = this.state.filter;
+    filter.gatewayMACs = e.target.value.split("\n").map((s) => s.trim());
+    this.setState({
+      filter: filter,
+    });
+  }
+
+  handleSubmit(e) {
+    e.preventDefault();
+    let filter = this.state.filter;
+    filter.gatewayMACs = (filter.gatewayMACs || []).filter((s) => s !== "");
+    this.props.onSubmit(filter);
+  }
+
+  render() {
+    return(
+      <form onSubmit={this.handleSubmit}>
+        <div className="form-group">
+          <label className="control-label" htmlFor="mode">Mode</label>
+          <select className="form-control" id="mode" value={this.state.filter.mode || 'ALLOW'} onChange={this.onChange.bind(this, 'mode')}>
+            <option value="ALLOW">allow only the listed gateways</option>
+            <option value="DENY">deny the listed gateways</option>
+          </select>
+        </div>
+        <div className="form-group">
+          <label className="control-label" htmlFor="gatewayMACs">Gateway MACs</label>
+          <textarea className="form-control" id="gatewayMACs" rows="8" placeholder={"0102030405060708\n0807060504030201"} value={(this.state.filter.gatewayMACs || []).join("\n")} onChange={this.onGatewayMACsChange} />
+          <p className="help-block">
+            One gateway MAC per line. Uplinks which are only received by gateways not allowed by this filter are dropped and reported as security notification.
+          </p>
+        </div>
+        <hr />
+        <div className="btn-toolbar pull-right">
+          <a className="btn btn-default" onClick={this.context.router.goBack}>Go back</a>
+          <button type="submit" className="btn btn-primary">Submit</button>
+        </div>
+      </form>
+    );
+  }
+}
+
+export default GatewayFilterForm;
@@ -18,6 +18,8 @@ class NodeForm extends Component {
     this.handleSubmit = this.handleSubmit.bind(this);
     this.changeTab = this.changeTab.bind(this);
     this.updateNodeSettings = this.updateNodeSettings.bind(this);
+    this.onTagsChange = this.onTagsChange.bind(this);
+    this.onVariablesChange = this.onVariablesChange.bind(this);
   }
 
   componentWillReceiveProps(nextProps) {
@@ -28,6 +30,7 @@ class NodeForm extends Component {
       application: nextProps.application,
       devEUIDisabled: typeof nextProps.node.devEUI !== "undefined",
       disabled: nextProps.disabled,
+      variables: (node.variables || []).map((v) => v.name + "=" + v.value).join("\n"),
     });
 
   }
@@ -39,6 +42,14 @@ class NodeForm extends Component {
     if(node.isABP) {
       node.appKey = "00000000000000000000000000000000";
     }
+    node.tags = (node.tags || []).filter((t) => t !== "");
+    node.variables = (this.state.variables || "").split("\n").filter((line) => line.trim() !== "").map((line) => {
+      const i = line.indexOf("=");
+      if (i === -1) {
+        return {name: line.trim(), value: ""};
+      }
+      return {name: line.slice(0, i).trim(), value: line.slice(i + 1).trim()};
+    });
     this.props.onSubmit(node);
   }
 
@@ -77,6 +88,16 @@ class NodeForm extends Component {
     this.setState({node: node});
   };
 
+  onTagsChange(e) {
+    let node = this.state.node;
+    node.tags = e.target.value.split(",").map((s) => s.trim());
+    this.setState({node: node});
+  }
+
+  onVariablesChange(e) {
+    this.setState({variables: e.target.value});
+  }
+
   render() {
     return (
       <div>
@@ -99,6 +120,20 @@ class NodeForm extends Component {
               <label className="control-label" htmlFor="name">Node description</label>
               <input className="form-control" id="description" type="text" placeholder="a short description of your node" required value={this.state.node.description || ''} onChange={this.onChange.bind(this, 'description')} />
             </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="tags">Tags</label>
+              <input className="form-control" id="tags" type="text" placeholder="e.g. outdoor, floor-1" value={(this.state.node.tags || []).join(', ')} onChange={this.onTagsChange} />
+              <p className="help-block">
+                Comma separated list of tags. Tags may only contain words, numbers and dashes.
+              </p>
+            </div>
+            <div className="form-group">
+              <label className="control-label" htmlFor="variables">Variables</label>
+              <textarea className="form-control" id="variables" rows="3" placeholder="e.g. ThingsBoardAccessToken=..." value={this.state.variables || ''} onChange={this.onVariablesChange} />
+              <p className="help-block">
+                One name=value pair per line, e.g. the access token used by the ThingsBoard integration. Names may only contain words, numbers and dashes.
+              </p>
+            </div>
             <div className="form-group">
               <label className="control-label" htmlFor="devEUI">Device EUI</label>
               <input className="form-control" id="devEUI" type="text" placeholder="0000000000000000" pattern="[A-Fa-f0-9]{16}" required disabled={this.state.devEUIDisabled} value={this.state.node.devEUI || ''} onChange={this.onChange.bind(this, 'devEUI')} /> 
@@ -18,6 +18,9 @@ import UpdateApplicationUser from "./views/applications/UpdateApplicationUser";
 import ApplicationIntegrations from "./views/applications/ApplicationIntegrations";
 import CreateApplicationIntegration from "./views/applications/CreateApplicationIntegration";
 import UpdateApplicationIntegration from "./views/applications/UpdateApplicationIntegration";
+import ApplicationGatewayFilter from "./views/applications/ApplicationGatewayFilter";
+import ApplicationMQTTCredentials from "./views/applications/ApplicationMQTTCredentials";
+import ApplicationStatusPage from "./views/applications/ApplicationStatusPage";
 
 // nodes
 import NodeLayout from './views/nodes/NodeLayout';
@@ -57,6 +60,8 @@ import OrganizationRedirect from './views/organizations/OrganizationRedirect';
 import OrganizationUsers from './views/organizations/OrganizationUsers';
 import CreateOrganizationUser from './views/organizations/CreateOrganizationUser';
 import UpdateOrganizationUser from './views/organizations/UpdateOrganizationUser';
+import OrganizationElevations from './views/organizations/OrganizationElevations';
+import CreateOrganizationElevation from './views/organizations/CreateOrganizationElevation';
 
 // fix leaflet image source
 import Leaflet from 'leaflet';
@@ -92,6 +97,8 @@ ReactDOM.render(
         <Route path="users" component={OrganizationUsers}></Route>
         <Route path="users/create" component={CreateOrganizationUser}></Route>
         <Route path="users/:userID/edit" component={UpdateOrganizationUser}></Route>
+        <Route path="elevations" component={OrganizationElevations}></Route>
+        <Route path="elevations/create" component={CreateOrganizationElevation}></Route>
       </Route>
 
       <Route path="organizations/:organizationID/gateways/:mac" component={GatewayLayout}>
@@ -110,7 +117,10 @@ ReactDOM.render(
         <Route path="nodes/create" component={CreateNode}></Route>
         <Route path="integrations" component={ApplicationIntegrations}></Route>
         <Route path="integrations/create" component={CreateApplicationIntegration}></Route>
-        <Route path="integrations/http" component={UpdateApplicationIntegration}></Route>
+        <Route path="integrations/:kind" component={UpdateApplicationIntegration}></Route>
+        <Route path="gateway-filter" component={ApplicationGatewayFilter}></Route>
+        <Route path="mqtt-credentials" component={ApplicationMQTTCredentials}></Route>
+        <Route path="status-page" component={ApplicationStatusPage}></Route>
       </Route>
 
       <Route path="organizations/:organizationID/applications/:applicationID/nodes/:devEUI" component={NodeLayout}>
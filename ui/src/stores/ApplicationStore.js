@@ -5,6 +5,13 @@ import { checkStatus, errorHandler } from "./helpers";
 
 
 class ApplicationStore extends EventEmitter {
+  constructor() {
+    super();
+    // ETags (revisions) of the last retrieved applications and
+    // integrations, needed for updates
+    this.etags = {};
+  }
+
   getAll(pageSize, offset, callbackFunc) {
     fetch("/api/applications?limit="+pageSize+"&offset="+offset, {headers: sessionStore.getHeader()})
       .then(checkStatus)
@@ -36,7 +43,10 @@ class ApplicationStore extends EventEmitter {
   getApplication(applicationID, callbackFunc) {
     fetch("/api/applications/"+applicationID, {headers: sessionStore.getHeader()})
       .then(checkStatus)
-      .then((response) => response.json())
+      .then((response) => {
+        this.etags["application/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
       .then((responseData) => {
         callbackFunc(responseData);
       })
@@ -54,7 +64,7 @@ class ApplicationStore extends EventEmitter {
   }
 
   updateApplication(applicationID, application, callbackFunc) {
-    fetch("/api/applications/"+applicationID, {method: "PUT", body: JSON.stringify(application), headers: sessionStore.getHeader()})
+    fetch("/api/applications/"+applicationID, {method: "PUT", body: JSON.stringify(application), headers: Object.assign({"If-Match": this.etags["application/"+applicationID]}, sessionStore.getHeader())})
       .then(checkStatus)
       .then((response) => response.json())
       .then((responseData) => {
@@ -142,7 +152,10 @@ class ApplicationStore extends EventEmitter {
   getHTTPIntegration(applicationID, callbackFunc) {
     fetch("/api/applications/"+applicationID+"/integrations/http", {headers: sessionStore.getHeader()})
       .then(checkStatus)
-      .then((response) => response.json())
+      .then((response) => {
+        this.etags["integration/http/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
       .then((responseData) => {
         callbackFunc(responseData);
       })
@@ -150,7 +163,7 @@ class ApplicationStore extends EventEmitter {
   }
 
   updateHTTPIntegration(applicationID, integration, callbackFunc) {
-    fetch("/api/applications/"+applicationID+"/integrations/http", {method: "PUT", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+    fetch("/api/applications/"+applicationID+"/integrations/http", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/http/"+applicationID]}, sessionStore.getHeader())})
       .then(checkStatus)
       .then((response) => response.json())
       .then((responseData) => {
@@ -169,6 +182,498 @@ class ApplicationStore extends EventEmitter {
       .catch(errorHandler);
   }
 
+  createSyslogIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/syslog", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getSyslogIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/syslog", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/syslog/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateSyslogIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/syslog", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/syslog/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteSyslogIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/syslog", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createAMQPIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/amqp", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getAMQPIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/amqp", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/amqp/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateAMQPIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/amqp", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/amqp/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteAMQPIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/amqp", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createPostgreSQLIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/postgresql", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getPostgreSQLIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/postgresql", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/postgresql/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updatePostgreSQLIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/postgresql", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/postgresql/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deletePostgreSQLIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/postgresql", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createAWSSNSIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/aws-sns", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getAWSSNSIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/aws-sns", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/aws-sns/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateAWSSNSIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/aws-sns", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/aws-sns/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteAWSSNSIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/aws-sns", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createAzureIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/azure", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getAzureIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/azure", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/azure/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateAzureIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/azure", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/azure/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteAzureIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/azure", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createGCPPubSubIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/gcp-pub-sub", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getGCPPubSubIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/gcp-pub-sub", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/gcp-pub-sub/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateGCPPubSubIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/gcp-pub-sub", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/gcp-pub-sub/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteGCPPubSubIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/gcp-pub-sub", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createThingsBoardIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/thingsboard", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getThingsBoardIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/thingsboard", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/thingsboard/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateThingsBoardIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/thingsboard", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/thingsboard/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteThingsBoardIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/thingsboard", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createMyDevicesIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/mydevices", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getMyDevicesIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/mydevices", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/mydevices/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateMyDevicesIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/mydevices", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/mydevices/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteMyDevicesIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/mydevices", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  createElasticsearchIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/elasticsearch", {method: "POST", body: JSON.stringify(integration), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getElasticsearchIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/elasticsearch", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => {
+        this.etags["integration/elasticsearch/"+applicationID] = response.headers.get("ETag");
+        return response.json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateElasticsearchIntegration(applicationID, integration, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/elasticsearch", {method: "PUT", body: JSON.stringify(integration), headers: Object.assign({"If-Match": this.etags["integration/elasticsearch/"+applicationID]}, sessionStore.getHeader())})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteElasticsearchIntegration(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/elasticsearch", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getGatewayFilter(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/gateway-filter", {headers: sessionStore.getHeader()})
+      .then((response) => {
+        // no gateway filter has been configured
+        if (response.status === 404) {
+          return {};
+        }
+        return checkStatus(response).json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  updateGatewayFilter(applicationID, filter, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/gateway-filter", {method: "PUT", body: JSON.stringify(filter), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteGatewayFilter(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/gateway-filter", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getMQTTCredentials(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/mqtt-credentials", {headers: sessionStore.getHeader()})
+      .then((response) => {
+        // no mqtt credentials have been generated
+        if (response.status === 404) {
+          return {};
+        }
+        return checkStatus(response).json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  generateMQTTCredentials(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/mqtt-credentials", {method: "POST", body: JSON.stringify({}), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  deleteMQTTCredentials(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/mqtt-credentials", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  getStatusPage(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/status-page", {headers: sessionStore.getHeader()})
+      .then((response) => {
+        // the status page has not been enabled
+        if (response.status === 404) {
+          return {};
+        }
+        return checkStatus(response).json();
+      })
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  enableStatusPage(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/status-page", {method: "POST", body: JSON.stringify({}), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  disableStatusPage(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/status-page", {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
   listIntegrations(applicationID, callbackFunc) {
     fetch("/api/applications/"+applicationID+"/integrations", {headers: sessionStore.getHeader()}) 
       .then(checkStatus)
@@ -178,6 +683,16 @@ class ApplicationStore extends EventEmitter {
       })
       .catch(errorHandler);
   }
+
+  listIntegrationHealth(applicationID, callbackFunc) {
+    fetch("/api/applications/"+applicationID+"/integrations/health", {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
 }
 
 const applicationStore = new ApplicationStore();
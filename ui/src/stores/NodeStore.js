@@ -12,6 +12,12 @@ var checkGetActivationStatus = (response) => {
 };
 
 class NodeStore extends EventEmitter {
+  constructor() {
+    super();
+    // ETag (revision) of the last retrieved node, needed for updates
+    this.etags = {};
+  }
+
   getAll(applicationID, pageSize, offset, callbackFunc) {
     fetch("/api/applications/"+applicationID+"/nodes?limit="+pageSize+"&offset="+offset, {headers: sessionStore.getHeader()})
       .then(checkStatus)
@@ -29,7 +35,10 @@ class NodeStore extends EventEmitter {
   getNode(applicationID, name, callbackFunc) {
     fetch("/api/nodes/"+name, {headers: sessionStore.getHeader()})
       .then(checkStatus)
-      .then((response) => response.json())
+      .then((response) => {
+        this.etags[name] = response.headers.get("ETag");
+        return response.json();
+      })
       .then((responseData) => {
         callbackFunc(responseData);
       })
@@ -47,7 +56,7 @@ class NodeStore extends EventEmitter {
   }
 
   updateNode(applicationID, devEUI, node, callbackFunc) {
-    fetch("/api/nodes/"+devEUI, {method: "PUT", body: JSON.stringify(node), headers: sessionStore.getHeader()})
+    fetch("/api/nodes/"+devEUI, {method: "PUT", body: JSON.stringify(node), headers: Object.assign({"If-Match": this.etags[devEUI]}, sessionStore.getHeader())})
       .then(checkStatus)
       .then((response) => response.json())
       .then((responseData) => {
@@ -66,6 +75,16 @@ class NodeStore extends EventEmitter {
       .catch(errorHandler);
   }
 
+  decommissionNode(applicationID, devEUI, deleteHistory, callbackFunc) {
+    fetch("/api/nodes/"+devEUI+"/decommission", {method: "POST", body: JSON.stringify({devEUI: devEUI, deleteHistory: deleteHistory}), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
   activateNode(applicationID, devEUI, activation, callbackFunc) {
     fetch("/api/nodes/"+devEUI+"/activation", {method: "POST", body: JSON.stringify(activation), headers: sessionStore.getHeader()})
       .then(checkStatus)
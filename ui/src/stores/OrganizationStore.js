@@ -113,6 +113,40 @@ class OrganizationStore extends EventEmitter {
       })
       .catch(errorHandler);
   }
+
+  getElevations(organizationID, pageSize, offset, callbackFunc) {
+    fetch("/api/organizations/"+organizationID+"/elevations?limit="+pageSize+"&offset="+offset, {headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        if(typeof(responseData.result) === "undefined") {
+          callbackFunc(0, []);
+        } else {
+          callbackFunc(responseData.totalCount, responseData.result);
+        }
+      })
+      .catch(errorHandler);
+  }
+
+  createElevation(organizationID, elevation, callbackFunc) {
+    fetch("/api/organizations/"+organizationID+"/elevations", {method: "POST", body: JSON.stringify(elevation), headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
+
+  revokeElevation(organizationID, id, callbackFunc) {
+    fetch("/api/organizations/"+organizationID+"/elevations/"+id, {method: "DELETE", headers: sessionStore.getHeader()})
+      .then(checkStatus)
+      .then((response) => response.json())
+      .then((responseData) => {
+        callbackFunc(responseData);
+      })
+      .catch(errorHandler);
+  }
 }
 
 const organizationStore = new OrganizationStore();
@@ -0,0 +1,63 @@
+import React, { Component } from 'react';
+import { Link } from 'react-router';
+
+import ApplicationStore from "../../stores/ApplicationStore";
+import GatewayFilterForm from "../../components/GatewayFilterForm";
+
+
+class ApplicationGatewayFilter extends Component {
+  static contextTypes = {
+    router: React.PropTypes.object.isRequired
+  };
+
+  constructor() {
+    super();
+
+    this.state = {
+      filter: {},
+    };
+
+    this.onSubmit = this.onSubmit.bind(this);
+    this.onDelete = this.onDelete.bind(this);
+  }
+
+  componentDidMount() {
+    ApplicationStore.getGatewayFilter(this.props.params.applicationID, (filter) => {
+      this.setState({
+        filter: filter,
+      });
+    });
+  }
+
+  onSubmit(filter) {
+    ApplicationStore.updateGatewayFilter(this.props.params.applicationID, filter, (responseData) => {
+      this.context.router.push('/organizations/'+this.props.params.organizationID+'/applications/'+this.props.params.applicationID);
+    });
+  }
+
+  onDelete() {
+    if (confirm("Are you sure you want to remove the gateway filter?")) {
+      ApplicationStore.deleteGatewayFilter(this.props.params.applicationID, (responseData) => {
+        this.context.router.push('/organizations/'+this.props.params.organizationID+'/applications/'+this.props.params.applicationID);
+      });
+    }
+  }
+
+  render() {
+    return(
+      <div className="panel panel-default">
+        <div className="panel-heading clearfix">
+          <h3 className="panel-title panel-title-buttons pull-left">Gateway filter</h3>
+          <div className={"btn-group pull-right " + (typeof(this.state.filter.id) === "undefined" ? "hidden" : "")}>
+            <Link><button type="button" className="btn btn-danger btn-sm" onClick={this.onDelete}>Remove filter</button></Link>
+          </div>
+        </div>
+        <div className="panel-body">
+          <GatewayFilterForm filter={this.state.filter} onSubmit={this.onSubmit} />
+        </div>
+      </div>
+    );
+  }
+}
+
+export default ApplicationGatewayFilter;
@@ -1,5 +1,6 @@
 import React, { Component } from 'react';
 import { Link } from 'react-router';
+import moment from "moment";
 
 import ApplicationStore from "../../stores/ApplicationStore";
 
@@ -8,14 +9,65 @@ const integrationMap = {
     name: 'HTTP integration',
     endpoint: 'http',
   },
+  SYSLOG: {
+    name: 'Syslog integration',
+    endpoint: 'syslog',
+  },
+  AMQP: {
+    name: 'AMQP / RabbitMQ integration',
+    endpoint: 'amqp',
+  },
+  POSTGRESQL: {
+    name: 'PostgreSQL integration',
+    endpoint: 'postgresql',
+  },
+  AWS_SNS: {
+    name: 'AWS SNS integration',
+    endpoint: 'aws-sns',
+  },
+  AZURE: {
+    name: 'Azure integration',
+    endpoint: 'azure',
+  },
+  GCP_PUB_SUB: {
+    name: 'GCP Pub/Sub integration',
+    endpoint: 'gcp-pub-sub',
+  },
+  THINGSBOARD: {
+    name: 'ThingsBoard integration',
+    endpoint: 'thingsboard',
+  },
+  MY_DEVICES: {
+    name: 'myDevices integration',
+    endpoint: 'mydevices',
+  },
+  ELASTICSEARCH: {
+    name: 'Elasticsearch integration',
+    endpoint: 'elasticsearch',
+  },
 };
 
 
 class IntegrationRow extends Component {
   render() {
+    let status = null;
+    let lastDelivery = null;
+    if (this.props.health !== undefined) {
+      if (this.props.health.healthy) {
+        status = <span className="label label-success">OK</span>;
+      } else {
+        status = <span className="label label-danger" title={this.props.health.lastError}>failing ({this.props.health.consecutiveFailures})</span>;
+      }
+      if (this.props.health.lastDeliveryAt !== "") {
+        lastDelivery = moment(this.props.health.lastDeliveryAt).fromNow();
+      }
+    }
+
     return(
       <tr>
-        <td><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/integrations/http`}>{integrationMap[this.props.kind].name}</Link></td>
+        <td><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/integrations/${integrationMap[this.props.kind].endpoint}`}>{integrationMap[this.props.kind].name}</Link></td>
+        <td>{status}</td>
+        <td>{lastDelivery}</td>
       </tr>
     );
   }
@@ -27,6 +79,7 @@ class ApplicationIntegrations extends Component {
 
     this.state = {
       integrations: [],
+      health: {},
     };
   }
 
@@ -36,10 +89,20 @@ class ApplicationIntegrations extends Component {
         integrations: integrations.kinds,
       });
     });    
+
+    ApplicationStore.listIntegrationHealth(this.props.params.applicationID, (health) => {
+      let byKind = {};
+      for (const h of health.result) {
+        byKind[h.kind] = h;
+      }
+      this.setState({
+        health: byKind,
+      });
+    });
   }
 
   render() {
-    const IntegrationRows = this.state.integrations.map((integration, i) => <IntegrationRow key={integration} kind={integration} params={this.props.params} />);
+    const IntegrationRows = this.state.integrations.map((integration, i) => <IntegrationRow key={integration} kind={integration} health={this.state.health[integration]} params={this.props.params} />);
 
     return(
       <div className="panel panel-default">
@@ -53,6 +116,8 @@ class ApplicationIntegrations extends Component {
             <thead>
               <tr>
                 <th>Kind</th>
+                <th>Status</th>
+                <th>Last delivery</th>
               </tr>
             </thead>
             <tbody>
@@ -72,7 +72,10 @@ class ApplicationLayout extends Component {
           <li role="presentation" className={(activeTab === "" || activeTab === "nodes/create") ? 'active' : ''}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}`}>Nodes</Link></li>
           <li role="presentation" className={(activeTab === "edit" ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/edit`}>Application configuration</Link></li>
           <li role="presentation" className={((activeTab === "users" || activeTab === "users/:userID/edit" || activeTab === "users/create") ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/users`}>Application users</Link></li>
-          <li role="presentation" className={((activeTab === "integrations" || activeTab === "integrations/create" || activeTab === "integrations/http") ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/integrations`}>Integrations</Link></li>
+          <li role="presentation" className={((activeTab === "integrations" || activeTab === "integrations/create" || activeTab === "integrations/:kind") ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/integrations`}>Integrations</Link></li>
+          <li role="presentation" className={(activeTab === "gateway-filter" ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/gateway-filter`}>Gateway filter</Link></li>
+          <li role="presentation" className={(activeTab === "mqtt-credentials" ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/mqtt-credentials`}>MQTT credentials</Link></li>
+          <li role="presentation" className={(activeTab === "status-page" ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}/status-page`}>Status page</Link></li>
         </ul>
         <hr />
         {this.props.children}
@@ -0,0 +1,99 @@
+import React, { Component } from 'react';
+import { Link } from 'react-router';
+
+import ApplicationStore from "../../stores/ApplicationStore";
+
+
+class ApplicationMQTTCredentials extends Component {
+  constructor() {
+    super();
+
+    this.state = {
+      credentials: {},
+      password: "",
+    };
+
+    this.onGenerate = this.onGenerate.bind(this);
+    this.onDelete = this.onDelete.bind(this);
+  }
+
+  componentDidMount() {
+    this.loadCredentials();
+  }
+
+  loadCredentials() {
+    ApplicationStore.getMQTTCredentials(this.props.params.applicationID, (credentials) => {
+      this.setState({
+        credentials: credentials,
+      });
+    });
+  }
+
+  onGenerate() {
+    if (typeof(this.state.credentials.id) === "undefined" || confirm("Are you sure you want to rotate the password? The current password will stop working.")) {
+      ApplicationStore.generateMQTTCredentials(this.props.params.applicationID, (responseData) => {
+        this.setState({
+          password: responseData.password,
+        });
+        this.loadCredentials();
+      });
+    }
+  }
+
+  onDelete() {
+    if (confirm("Are you sure you want to remove the MQTT credentials?")) {
+      ApplicationStore.deleteMQTTCredentials(this.props.params.applicationID, (responseData) => {
+        this.setState({
+          password: "",
+        });
+        this.loadCredentials();
+      });
+    }
+  }
+
+  render() {
+    const exists = typeof(this.state.credentials.id) !== "undefined";
+
+    return(
+      <div className="panel panel-default">
+        <div className="panel-heading clearfix">
+          <h3 className="panel-title panel-title-buttons pull-left">MQTT credentials</h3>
+          <div className="btn-group pull-right">
+            <Link><button type="button" className="btn btn-default btn-sm" onClick={this.onGenerate}>{exists ? "Rotate password" : "Generate credentials"}</button></Link>
+            <Link className={exists ? "" : "hidden"}><button type="button" className="btn btn-danger btn-sm" onClick={this.onDelete}>Remove credentials</button></Link>
+          </div>
+        </div>
+        <div className="panel-body">
+          <p className={exists ? "hidden" : ""}>
+            No MQTT credentials have been generated for this application.
+          </p>
+          <table className={"table " + (exists ? "" : "hidden")}>
+            <tbody>
+              <tr>
+                <th>Username</th>
+                <td>{this.state.credentials.username}</td>
+              </tr>
+              <tr className={this.state.password === "" ? "hidden" : ""}>
+                <th>Password</th>
+                <td><code>{this.state.password}</code></td>
+              </tr>
+              <tr>
+                <th>Topic prefix</th>
+                <td>{this.state.credentials.topicPrefix}</td>
+              </tr>
+              <tr>
+                <th>Last rotated</th>
+                <td>{this.state.credentials.updatedAt}</td>
+              </tr>
+            </tbody>
+          </table>
+          <p className={"help-block " + (this.state.password === "" ? "hidden" : "")}>
+            Store the password in a safe place, it can not be retrieved afterwards.
+          </p>
+        </div>
+      </div>
+    );
+  }
+}
+
+export default ApplicationMQTTCredentials;
@@ -0,0 +1,85 @@
+import React, { Component } from 'react';
+import { Link } from 'react-router';
+
+import ApplicationStore from "../../stores/ApplicationStore";
+
+
+class ApplicationStatusPage extends Component {
+  constructor() {
+    super();
+
+    this.state = {
+      statusPage: {},
+    };
+
+    this.onEnable = this.onEnable.bind(this);
+    this.onDisable = this.onDisable.bind(this);
+  }
+
+  componentDidMount() {
+    this.loadStatusPage();
+  }
+
+  loadStatusPage() {
+    ApplicationStore.getStatusPage(this.props.params.applicationID, (statusPage) => {
+      this.setState({
+        statusPage: statusPage,
+      });
+    });
+  }
+
+  onEnable() {
+    if (typeof(this.state.statusPage.id) === "undefined" || confirm("Are you sure you want to rotate the token? The current URL will stop working.")) {
+      ApplicationStore.enableStatusPage(this.props.params.applicationID, (responseData) => {
+        this.loadStatusPage();
+      });
+    }
+  }
+
+  onDisable() {
+    if (confirm("Are you sure you want to disable the status page?")) {
+      ApplicationStore.disableStatusPage(this.props.params.applicationID, (responseData) => {
+        this.loadStatusPage();
+      });
+    }
+  }
+
+  render() {
+    const enabled = typeof(this.state.statusPage.id) !== "undefined";
+    const url = enabled ? window.location.origin + this.state.statusPage.path : "";
+
+    return(
+      <div className="panel panel-default">
+        <div className="panel-heading clearfix">
+          <h3 className="panel-title panel-title-buttons pull-left">Status page</h3>
+          <div className="btn-group pull-right">
+            <Link><button type="button" className="btn btn-default btn-sm" onClick={this.onEnable}>{enabled ? "Rotate token" : "Enable status page"}</button></Link>
+            <Link className={enabled ? "" : "hidden"}><button type="button" className="btn btn-danger btn-sm" onClick={this.onDisable}>Disable status page</button></Link>
+          </div>
+        </div>
+        <div className="panel-body">
+          <p className={enabled ? "hidden" : ""}>
+            The status page of this application has not been enabled.
+          </p>
+          <table className={"table " + (enabled ? "" : "hidden")}>
+            <tbody>
+              <tr>
+                <th>URL</th>
+                <td><a href={url} target="_blank">{url}</a></td>
+              </tr>
+              <tr>
+                <th>Last rotated</th>
+                <td>{this.state.statusPage.updatedAt}</td>
+              </tr>
+            </tbody>
+          </table>
+          <p className="help-block">
+            The status page returns the aggregated health data of the application (devices online, message rates) without authentication. It does not contain any device identities.
+          </p>
+        </div>
+      </div>
+    );
+  }
+}
+
+export default ApplicationStatusPage;
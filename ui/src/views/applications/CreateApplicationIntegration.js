@@ -20,9 +20,44 @@ class CreateApplicationIntegration extends Component {
   }
 
   onSubmit(integration) {
-    ApplicationStore.createHTTPIntegration(this.props.params.applicationID, integration, (responseData) => {
+    const callbackFunc = (responseData) => {
       this.context.router.push('/organizations/'+this.props.params.organizationID+'/applications/'+this.props.params.applicationID+'/integrations');
-    });
+    };
+
+    switch (integration.kind) {
+      case "http":
+        ApplicationStore.createHTTPIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "syslog":
+        ApplicationStore.createSyslogIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "amqp":
+        ApplicationStore.createAMQPIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "postgresql":
+        ApplicationStore.createPostgreSQLIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "aws-sns":
+        ApplicationStore.createAWSSNSIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "azure":
+        ApplicationStore.createAzureIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "gcp-pub-sub":
+        ApplicationStore.createGCPPubSubIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "thingsboard":
+        ApplicationStore.createThingsBoardIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "mydevices":
+        ApplicationStore.createMyDevicesIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "elasticsearch":
+        ApplicationStore.createElasticsearchIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      default:
+        break;
+    }
   }
 
   render() {
@@ -22,25 +22,130 @@ class UpdateApplicationIntegration extends Component {
   }
 
   componentDidMount() {
-    ApplicationStore.getHTTPIntegration(this.props.params.applicationID, (integration) => {
-      integration.kind = "http";
+    const callbackFunc = (integration) => {
+      integration.kind = this.props.params.kind;
       this.setState({
         integration: integration,
-      }); 
-    });
+      });
+    };
+
+    switch (this.props.params.kind) {
+      case "http":
+        ApplicationStore.getHTTPIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "syslog":
+        ApplicationStore.getSyslogIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "amqp":
+        ApplicationStore.getAMQPIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "postgresql":
+        ApplicationStore.getPostgreSQLIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "aws-sns":
+        ApplicationStore.getAWSSNSIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "azure":
+        ApplicationStore.getAzureIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "gcp-pub-sub":
+        ApplicationStore.getGCPPubSubIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "thingsboard":
+        ApplicationStore.getThingsBoardIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "mydevices":
+        ApplicationStore.getMyDevicesIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      case "elasticsearch":
+        ApplicationStore.getElasticsearchIntegration(this.props.params.applicationID, callbackFunc);
+        break;
+      default:
+        break;
+    }
   }
 
   onSubmit(integration) {
-    ApplicationStore.updateHTTPIntegration(this.props.params.applicationID, integration, (responseData) => {
+    const callbackFunc = (responseData) => {
       this.context.router.push('/organizations/'+this.props.params.organizationID+'/applications/'+this.props.params.applicationID+'/integrations');
-    });
+    };
+
+    switch (this.props.params.kind) {
+      case "http":
+        ApplicationStore.updateHTTPIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "syslog":
+        ApplicationStore.updateSyslogIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "amqp":
+        ApplicationStore.updateAMQPIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "postgresql":
+        ApplicationStore.updatePostgreSQLIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "aws-sns":
+        ApplicationStore.updateAWSSNSIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "azure":
+        ApplicationStore.updateAzureIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "gcp-pub-sub":
+        ApplicationStore.updateGCPPubSubIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "thingsboard":
+        ApplicationStore.updateThingsBoardIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "mydevices":
+        ApplicationStore.updateMyDevicesIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      case "elasticsearch":
+        ApplicationStore.updateElasticsearchIntegration(this.props.params.applicationID, integration, callbackFunc);
+        break;
+      default:
+        break;
+    }
   }
 
   onDelete() {
     if (confirm("Are you sure you want to delete this integration?")) {
-      ApplicationStore.deleteHTTPIntegration(this.props.params.applicationID, (responseData) => {
+      const callbackFunc = (responseData) => {
         this.context.router.push("/organizations/"+this.props.params.organizationID+"/applications/"+this.props.params.applicationID+"/integrations");
-      });
+      };
+
+      switch (this.props.params.kind) {
+        case "http":
+          ApplicationStore.deleteHTTPIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "syslog":
+          ApplicationStore.deleteSyslogIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "amqp":
+          ApplicationStore.deleteAMQPIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "postgresql":
+          ApplicationStore.deletePostgreSQLIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "aws-sns":
+          ApplicationStore.deleteAWSSNSIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "azure":
+          ApplicationStore.deleteAzureIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "gcp-pub-sub":
+          ApplicationStore.deleteGCPPubSubIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "thingsboard":
+          ApplicationStore.deleteThingsBoardIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "mydevices":
+          ApplicationStore.deleteMyDevicesIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        case "elasticsearch":
+          ApplicationStore.deleteElasticsearchIntegration(this.props.params.applicationID, callbackFunc);
+          break;
+        default:
+          break;
+      }
     }
   }
 
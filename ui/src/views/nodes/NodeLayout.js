@@ -21,6 +21,7 @@ class NodeLayout extends Component {
     };
 
     this.onDelete = this.onDelete.bind(this);
+    this.onDecommission = this.onDecommission.bind(this);
   }
 
   componentDidMount() {
@@ -50,6 +51,17 @@ class NodeLayout extends Component {
     }
   }
 
+  onDecommission() {
+    if (confirm("Are you sure you want to decommission this node? The node will be disabled, its downlink queue flushed and its node-session deleted. This can not be undone.")) {
+      const deleteHistory = confirm("Do you want to delete the history of this node right away? Otherwise the history is kept until it expires.");
+      NodeStore.decommissionNode(this.props.params.applicationID, this.props.params.devEUI, deleteHistory, (responseData) => {
+        NodeStore.getNode(this.props.params.applicationID, this.props.params.devEUI, (node) => {
+          this.setState({node: node});
+        });
+      });
+    }
+  }
+
   render() {
     let activeTab = "";
 
@@ -66,8 +78,12 @@ class NodeLayout extends Component {
           <li><Link to={`/organizations/${this.props.params.organizationID}/applications/${this.props.params.applicationID}`}>{this.state.application.name}</Link></li>
           <li className="active">{this.state.node.name}</li>
         </ol>
+        <div className={"alert alert-warning " + (this.state.node.retiredAt ? '' : 'hidden')}>
+          This node has been decommissioned at {this.state.node.retiredAt}.
+        </div>
         <div className="clearfix">
           <div className="btn-group pull-right" role="group" aria-label="...">
+            <Link><button type="button" className={"btn btn-default " + (this.state.isAdmin && !this.state.node.retiredAt ? '' : 'hidden')} onClick={this.onDecommission}>Decommission node</button></Link>
             <Link><button type="button" className={"btn btn-danger " + (this.state.isAdmin ? '' : 'hidden')} onClick={this.onDelete}>Delete node</button></Link>
           </div>
         </div>
@@ -0,0 +1,145 @@
+import React, { Component } from 'react';
+
+import Select from "react-select";
+
+import OrganizationStore from "../../stores/OrganizationStore";
+import UserStore from "../../stores/UserStore";
+
+
+class OrganizationElevationForm extends Component {
+  static contextTypes = {
+    router: React.PropTypes.object.isRequired
+  };
+
+  constructor() {
+    super();
+
+    this.state = {
+      elevation: {
+        hours: 4,
+      },
+      initialOptions: [],
+    };
+
+    this.handleSubmit = this.handleSubmit.bind(this);
+    this.onAutocompleteSelect = this.onAutocompleteSelect.bind(this);
+    this.onAutocomplete = this.onAutocomplete.bind(this);
+    this.setInitialOptions = this.setInitialOptions.bind(this);
+  }
+
+  setInitialOptions() {
+    if (this.state.initialOptions.length === 0) {
+      UserStore.getAll("", 10, 0, (totalCount, users) => {
+        const options = users.map((user, i) => {
+          return {
+            value: user.id,
+            label: user.username,
+          };
+        });
+
+        this.setState({
+          initialOptions: options,
+        });
+      });
+    }
+  }
+
+  handleSubmit(e) {
+    e.preventDefault();
+    this.props.onSubmit(this.state.elevation);
+  }
+
+  onChange(field, e) {
+    let elevation = this.state.elevation;
+    if (e.target.type === "number") {
+      elevation[field] = parseInt(e.target.value, 10);
+    } else {
+      elevation[field] = e.target.value;
+    }
+    this.setState({elevation: elevation});
+  }
+
+  onAutocompleteSelect(val) {
+    let elevation = this.state.elevation;
+    elevation.userID = val.value;
+    this.setState({elevation: elevation});
+  }
+
+  onAutocomplete(input, callbackFunc) {
+    UserStore.getAll(input, 10, 0, (totalCount, users) => {
+      const options = users.map((user, i) => {
+        return {
+          value: user.id,
+          label: user.username,
+      }});
+
+      callbackFunc(null, {
+        options: options,
+        complete: true,
+      });
+    });
+  }
+
+  render() {
+    return(
+      <form onSubmit={this.handleSubmit}>
+        <div className="form-group">
+          <label className="control-label" htmlFor="name">Username</label>
+          <Select.Async name="username" required onOpen={this.setInitialOptions} options={this.state.initialOptions} loadOptions={this.onAutocomplete} value={this.state.elevation.userID} onChange={this.onAutocompleteSelect} clearable={false} autoload={false} />
+          <p className="help-block">
+            The user will have organization admin permissions until the elevated access expires or is revoked.
+          </p>
+        </div>
+        <div className="form-group">
+          <label className="control-label" htmlFor="hours">Duration (hours)</label>
+          <input className="form-control" id="hours" type="number" min="1" max="24" required value={this.state.elevation.hours || ''} onChange={this.onChange.bind(this, 'hours')} />
+          <p className="help-block">
+            The elevated access is automatically revoked after the given number of hours (max. 24).
+          </p>
+        </div>
+        <div className="form-group">
+          <label className="control-label" htmlFor="justification">Justification</label>
+          <textarea className="form-control" id="justification" rows="3" required value={this.state.elevation.justification || ''} onChange={this.onChange.bind(this, 'justification')} />
+          <p className="help-block">
+            The reason for the elevated access (e.g. the ticket reference). It is recorded together with the elevation.
+          </p>
+        </div>
+        <hr />
+        <div className="btn-toolbar pull-right">
+          <a className="btn btn-default" onClick={this.context.router.goBack}>Go back</a>
+          <button type="submit" className="btn btn-primary">Submit</button>
+        </div>
+      </form>
+    );
+  }
+}
+
+
+class CreateOrganizationElevation extends Component {
+  static contextTypes = {
+    router: React.PropTypes.object.isRequired
+  };
+
+  constructor() {
+    super();
+    this.onSubmit = this.onSubmit.bind(this);
+  }
+
+  onSubmit(elevation) {
+    OrganizationStore.createElevation(this.props.params.organizationID, elevation, (responseData) => {
+      this.context.router.push("/organizations/"+this.props.params.organizationID+"/elevations");
+    });
+  }
+
+  render() {
+    return(
+      <div className="panel panel-default">
+        <div className="panel-body">
+          <OrganizationElevationForm onSubmit={this.onSubmit} />
+        </div>
+      </div>
+    );
+  }
+}
+
+export default CreateOrganizationElevation;
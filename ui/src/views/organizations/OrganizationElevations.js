@@ -0,0 +1,133 @@
+import React, { Component } from 'react';
+import { Link } from 'react-router';
+
+import moment from "moment";
+
+import OrganizationStore from "../../stores/OrganizationStore";
+import SessionStore from "../../stores/SessionStore";
+import Pagination from "../../components/Pagination";
+
+
+class OrganizationElevationRow extends Component {
+  constructor() {
+    super();
+    this.onRevoke = this.onRevoke.bind(this);
+  }
+
+  onRevoke() {
+    if (confirm("Are you sure you want to revoke the elevated access of " + this.props.elevation.username + "?")) {
+      this.props.onRevoke(this.props.elevation);
+    }
+  }
+
+  render() {
+    let status = "expired";
+    if (this.props.elevation.active) {
+      status = "active";
+    } else if (this.props.elevation.revokedAt !== "") {
+      status = "revoked by " + this.props.elevation.revokedBy + " at " + moment(this.props.elevation.revokedAt).format("YYYY-MM-DD HH:mm");
+    }
+
+    return(
+      <tr>
+        <td>{moment(this.props.elevation.createdAt).format("YYYY-MM-DD HH:mm")}</td>
+        <td>{this.props.elevation.username}</td>
+        <td>{this.props.elevation.grantedBy}</td>
+        <td>{this.props.elevation.justification}</td>
+        <td>{moment(this.props.elevation.expiresAt).format("YYYY-MM-DD HH:mm")}</td>
+        <td>{status}</td>
+        <td>
+          <button type="button" className={"btn btn-danger btn-xs " + (this.props.elevation.active ? '' : 'hidden')} onClick={this.onRevoke}>Revoke</button>
+        </td>
+      </tr>
+    );
+  }
+}
+
+
+class OrganizationElevations extends Component {
+  constructor() {
+    super();
+
+    this.state = {
+      elevations: [],
+      isGlobalAdmin: false,
+      pageSize: 20,
+      pageNumber: 1,
+      pages: 1,
+    };
+
+    this.updatePage = this.updatePage.bind(this);
+    this.onRevoke = this.onRevoke.bind(this);
+  }
+
+  componentDidMount() {
+    this.setState({
+      isGlobalAdmin: SessionStore.isAdmin(),
+    });
+    this.updatePage(this.props);
+
+    SessionStore.on("change", () => {
+      this.setState({
+        isGlobalAdmin: SessionStore.isAdmin(),
+      });
+    });
+  }
+
+  componentWillReceiveProps(nextProps) {
+    this.updatePage(nextProps);
+  }
+
+  updatePage(props) {
+    const page = (props.location.query.page === undefined) ? 1 : props.location.query.page;
+
+    OrganizationStore.getElevations(this.props.params.organizationID, this.state.pageSize, (page-1) * this.state.pageSize, (totalCount, elevations) => {
+      this.setState({
+        elevations: elevations,
+        pages: Math.ceil(totalCount / this.state.pageSize),
+        pageNumber: page,
+      });
+    });
+  }
+
+  onRevoke(elevation) {
+    OrganizationStore.revokeElevation(this.props.params.organizationID, elevation.id, (responseData) => {
+      this.updatePage(this.props);
+    });
+  }
+
+  render() {
+    const ElevationRows = this.state.elevations.map((elevation, i) => <OrganizationElevationRow key={elevation.id} elevation={elevation} onRevoke={this.onRevoke} />);
+
+    return(
+      <div className="panel panel-default">
+        <div className="panel-heading clearfix">
+          <div className={"btn-group pull-right " + (this.state.isGlobalAdmin ? '' : 'hidden')}>
+           <Link to={`/organizations/${this.props.params.organizationID}/elevations/create`}><button type="button" className="btn btn-default btn-sm">Grant elevated access</button></Link>
+          </div>
+        </div>
+        <div className="panel-body">
+          <table className="table table-hover">
+            <thead>
+              <tr>
+                <th className="col-md-2">Granted at</th>
+                <th>Username</th>
+                <th>Granted by</th>
+                <th>Justification</th>
+                <th className="col-md-2">Expires at</th>
+                <th>Status</th>
+                <th className="col-md-1"></th>
+              </tr>
+            </thead>
+            <tbody>
+              {ElevationRows}
+            </tbody>
+          </table>
+        </div>
+        <Pagination pages={this.state.pages} currentPage={this.state.pageNumber} pathname={`/organizations/${this.props.params.organizationID}/elevations`} />
+      </div>
+    );
+  }
+}
+
+export default OrganizationElevations;
@@ -84,6 +84,7 @@ class OrganizationLayout extends Component {
           <li role="presentation" className={(activeTab.startsWith("gateways") ? 'active' : '') + (this.state.organization.canHaveGateways ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/gateways`}>Gateways</Link></li>
           <li role="presentation" className={(activeTab === "edit" ? 'active': '') + (this.state.isGlobalAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/edit`}>Organization configuration</Link></li>
           <li role="presentation" className={(activeTab.startsWith("users") ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/users`}>Organization users</Link></li>
+          <li role="presentation" className={(activeTab.startsWith("elevations") ? 'active' : '') + (this.state.isAdmin ? '' : 'hidden')}><Link to={`/organizations/${this.props.params.organizationID}/elevations`}>Elevated access</Link></li>
         </ul>
         <hr />
         {this.props.children} 